@@ -3,11 +3,21 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"flag"
 	"log"
 	"os"
+	"strings"
+	"time"
 
+	"shopapi/internal/auth"
+	"shopapi/internal/clients/blob"
 	"shopapi/internal/clients/postgres"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/logger"
+	"shopapi/internal/sweeper"
 
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"github.com/pressly/goose/v3"
 )
@@ -17,6 +27,13 @@ const (
 	cmdUp                 = "up"
 	cmdDown               = "down"
 	cmdStatus             = "status"
+	cmdImages             = "images"
+	cmdOrphans            = "orphans"
+	cmdReferences         = "references"
+	cmdPurge              = "purge"
+	cmdApiKey             = "api-key"
+
+	moveImagesBatch = 100
 )
 
 var executors = map[string]func(db *sql.DB, dir string, opts ...goose.OptionsFunc) error{
@@ -33,10 +50,35 @@ func main() {
 	}()
 
 	if len(os.Args) < 2 {
-		log.Fatalf("migration action is required: %s/%s/%s", cmdUp, cmdDown, cmdStatus)
+		log.Fatalf("migration action is required: %s/%s/%s/%s/%s/%s/%s/%s", cmdUp, cmdDown, cmdStatus, cmdImages, cmdOrphans, cmdReferences, cmdPurge, cmdApiKey)
 	}
 	command := os.Args[1]
 
+	if command == cmdImages {
+		MigrateImages()
+		return
+	}
+
+	if command == cmdOrphans {
+		SweepOrphans(os.Args[2:])
+		return
+	}
+
+	if command == cmdReferences {
+		ReportDanglingReferences()
+		return
+	}
+
+	if command == cmdPurge {
+		PurgeDeleted(os.Args[2:])
+		return
+	}
+
+	if command == cmdApiKey {
+		IssueApiKey(os.Args[2:])
+		return
+	}
+
 	goose.SetBaseFS(nil)
 
 	MigratePostgres(command)
@@ -61,7 +103,7 @@ func MigratePostgres(command string) {
 func ExecMigration(db *sql.DB, command, migrationsDir string) {
 	executor, exists := executors[command]
 	if !exists {
-		log.Fatalf("Wrong comand send: %s. Required: %s/%s/%s", command, cmdUp, cmdDown, cmdStatus)
+		log.Fatalf("Wrong comand send: %s. Required: %s/%s/%s/%s/%s/%s/%s/%s", command, cmdUp, cmdDown, cmdStatus, cmdImages, cmdOrphans, cmdReferences, cmdPurge, cmdApiKey)
 	}
 
 	if err := executor(db, migrationsDir); err != nil {
@@ -70,3 +112,182 @@ func ExecMigration(db *sql.DB, command, migrationsDir string) {
 
 	log.Printf("%s successfully migrated\n", migrationsDir)
 }
+
+// MigrateImages moves image content still stored in postgres to the configured blob storage.
+func MigrateImages() {
+	ctx := context.Background()
+	conn, err := postgres.NewSQLConn(ctx)
+	if err != nil {
+		log.Fatalf("failed to open DB: %v", err)
+	}
+
+	blobs, err := blob.NewStorage()
+	if err != nil {
+		log.Fatalf("failed to open blob storage: %v", err)
+	}
+
+	moved, err := postgres.NewClient(ctx, conn, blobs).MoveImagesToBlobStorage(moveImagesBatch)
+	if err != nil {
+		log.Fatalf("failed to move images after %d moved: %v", moved, err)
+	}
+
+	log.Printf("%d images successfully moved to blob storage\n", moved)
+}
+
+// SweepOrphans deletes the images and addresses orphaned longer than the grace period
+// and prints the report, the dry run only reports them.
+func SweepOrphans(args []string) {
+	config, err := sweeper.ReadConfig()
+	if err != nil {
+		log.Fatalf("failed to read orphans sweep config: %v", err)
+	}
+
+	flags := flag.NewFlagSet(cmdOrphans, flag.ExitOnError)
+	flags.BoolVar(&config.DryRun, "dry-run", config.DryRun, "report the orphans without deleting them")
+	flags.DurationVar(&config.GracePeriod, "grace", config.GracePeriod, "time an orphan is kept after it was found")
+	if err = flags.Parse(args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+
+	ctx := context.Background()
+	conn, err := postgres.NewSQLConn(ctx)
+	if err != nil {
+		log.Fatalf("failed to open DB: %v", err)
+	}
+
+	blobs, err := blob.NewStorage()
+	if err != nil {
+		log.Fatalf("failed to open blob storage: %v", err)
+	}
+
+	sweepLog := logger.NewLogger(os.Stderr, "ORPHANS")
+	defer sweepLog.Stop()
+
+	resp, err := sweeper.NewSweeper(postgres.NewClient(ctx, conn, blobs), sweepLog, config).Sweep()
+	if err != nil {
+		log.Fatalf("failed to sweep orphans: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(resp); err != nil {
+		log.Fatalf("failed to print report: %v", err)
+	}
+}
+
+// ReportDanglingReferences prints the products referring to missing suppliers or images,
+// the foreign keys migration fails until they are fixed.
+func ReportDanglingReferences() {
+	ctx := context.Background()
+	conn, err := postgres.NewSQLConn(ctx)
+	if err != nil {
+		log.Fatalf("failed to open DB: %v", err)
+	}
+
+	refs, err := postgres.NewClient(ctx, conn, nil).GetDanglingReferences()
+	if err != nil {
+		log.Fatalf("failed to find dangling references: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(refs); err != nil {
+		log.Fatalf("failed to print report: %v", err)
+	}
+
+	log.Printf("%d dangling references found\n", len(refs))
+}
+
+// PurgeDeleted deletes for good the products, images, suppliers and clients deleted longer
+// than the retention period ago and prints the purged ones.
+func PurgeDeleted(args []string) {
+	config, err := sweeper.ReadConfig()
+	if err != nil {
+		log.Fatalf("failed to read purge config: %v", err)
+	}
+
+	flags := flag.NewFlagSet(cmdPurge, flag.ExitOnError)
+	flags.DurationVar(&config.Retention, "retention", config.Retention, "time a deleted resource is kept in the trash")
+	if err = flags.Parse(args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+
+	ctx := context.Background()
+	conn, err := postgres.NewSQLConn(ctx)
+	if err != nil {
+		log.Fatalf("failed to open DB: %v", err)
+	}
+
+	blobs, err := blob.NewStorage()
+	if err != nil {
+		log.Fatalf("failed to open blob storage: %v", err)
+	}
+
+	purgeLog := logger.NewLogger(os.Stderr, "PURGE")
+	defer purgeLog.Stop()
+
+	resp, err := sweeper.NewSweeper(postgres.NewClient(ctx, conn, blobs), purgeLog, config).Purge()
+	if err != nil {
+		log.Fatalf("failed to purge deleted: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(resp); err != nil {
+		log.Fatalf("failed to print report: %v", err)
+	}
+}
+
+// IssueApiKey issues an API key straight in the database and prints it, so the first key
+// is issued before any caller can authenticate to the API.
+func IssueApiKey(args []string) {
+	flags := flag.NewFlagSet(cmdApiKey, flag.ExitOnError)
+	name := flags.String("name", "", "name of the service the key is issued to")
+	ttl := flags.Duration("ttl", 0, "time the key is valid, 0 keeps it valid until revoked")
+	roles := flags.String("roles", ds.RoleAdmin, "comma separated roles granted to the key")
+	if err := flags.Parse(args); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+	if *name == "" {
+		log.Fatalf("api key name is required: -name")
+	}
+
+	key, err := auth.NewAPIKey()
+	if err != nil {
+		log.Fatalf("failed to generate api key: %v", err)
+	}
+
+	req := &ds.IssueApiKeyRequest{
+		Audit:   ds.Audit{ActorKind: ds.ActorMigrator, Actor: ds.ActorMigrator},
+		Name:    *name,
+		Uid:     uuid.New(),
+		Prefix:  auth.ShownPrefix(key),
+		KeyHash: auth.HashAPIKey(key),
+		Roles:   strings.Split(*roles, ","),
+	}
+	if *ttl > 0 {
+		expiresAt := time.Now().Add(*ttl)
+		req.ExpiresAt = &expiresAt
+	}
+
+	ctx := context.Background()
+	conn, err := postgres.NewSQLConn(ctx)
+	if err != nil {
+		log.Fatalf("failed to open DB: %v", err)
+	}
+
+	resp, err := postgres.NewClient(ctx, conn, nil).AddApiKey(req)
+	if err != nil {
+		log.Fatalf("failed to issue api key: %v", err)
+	}
+	if resp.ApiKey == nil {
+		log.Fatalf("api key is not issued: %s %v", resp.GetStatus(), resp.UnknownRoles)
+	}
+	resp.Key = key
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(resp); err != nil {
+		log.Fatalf("failed to print api key: %v", err)
+	}
+}
@@ -8,12 +8,15 @@ import (
 	"syscall"
 
 	"shopapi/internal/api/v1"
+	"shopapi/internal/clients/blob"
 	"shopapi/internal/clients/postgres"
 	"shopapi/internal/clients/redis"
 	"shopapi/internal/logger"
 	"shopapi/internal/mem_cache"
+	"shopapi/internal/ratelimit"
 	"shopapi/internal/service"
 	"shopapi/internal/supports"
+	"shopapi/internal/sweeper"
 
 	go_redis "github.com/redis/go-redis/v9"
 )
@@ -32,6 +35,16 @@ import (
 
 // @host      localhost:8080
 // @BasePath  /api/v1
+
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 Ключ API для вызовов между сервисами, выпускается через POST /api-key.
+
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 JWT админки, подписанный HS256, в виде "Bearer <token>".
 func main() {
 	ctx, cancelCtx := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancelCtx()
@@ -46,23 +59,51 @@ func main() {
 		log.Fatal(err)
 	}
 
-	db := postgres.NewClient(ctx, conn)
+	blobs, err := blob.NewStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db := postgres.NewClient(ctx, conn, blobs)
 
 	var cacher service.ICache
+	var limiter api.IRateLimiter
 
 	if !supports.IsInContainer() {
 		cacher = mem_cache.NewCache()
+		limiter = ratelimit.NewMemory()
 	} else {
 		var c *go_redis.Client
 		c, err = redis.NewRedisConn(ctx)
 		if err != nil {
 			log.Fatal(err)
 		}
-		cacher = redis.NewClient(c)
+		rc := redis.NewClient(c)
+		cacher = rc
+		limiter = rc
 	}
 
-	s := service.NewService(ctx, serviceLog, cacher, db, db, db, db)
-	api := api.NewAPI(ctx, apiLog, s, s, s, s)
+	s := service.NewService(ctx, serviceLog, cacher, db, db, db, db, db, db, db, db)
+	api := api.NewAPI(ctx, apiLog, s, s, s, s, s, s, s, s, limiter)
+
+	// The upload types are reloaded on SIGHUP, so the whitelist changes without a restart.
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if err := api.LoadUploadTypes(); err != nil {
+				apiLog.ErrorKV("failed reloading upload types", "error", err.Error())
+				continue
+			}
+			apiLog.Infof("upload types reloaded")
+		}
+	}()
+
+	sweepConfig, err := sweeper.ReadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	go sweeper.NewSweeper(db, serviceLog, sweepConfig).Run(ctx)
 
 	err = api.Start()
 	if err != nil {
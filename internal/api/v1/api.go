@@ -4,18 +4,27 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"os"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	ds "shopapi/internal/datastruct"
 	mimeManager "shopapi/internal/mime-manager"
+	"shopapi/internal/ratelimit"
+	"shopapi/internal/scanner"
 	"shopapi/internal/service"
 	"shopapi/internal/supports"
+	"shopapi/internal/thumbnail"
 
 	_ "shopapi/internal/docs"
 
@@ -23,24 +32,40 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
-//go:generate mockgen -source=api.go -destination=api_mock.go -package=api IClientService,IProductService,ISupplierService,IImageService,IWithStatus,IServer,IRouter
+//go:generate mockgen -source=api.go -destination=api_mock.go -package=api IClientService,IProductService,ISupplierService,IImageService,ICategoryService,IAuthService,IAuditService,IPromotionService,IRateLimiter,IWithStatus,IServer,IRouter
 //go:generate mockgen -destination=http_mock.go -package=api net/http ResponseWriter
 
 const (
-	address                    = ":8080"
-	readTimeout                = time.Second * 5
-	writeTimeout               = time.Second * 5
-	maxMultipartFormMemory10MB = 10 << 20
+	address             = ":8080"
+	readTimeout         = time.Second * 5
+	writeTimeout        = time.Second * 5
+	defaultMaxImageSize = 10 << 20
+	maxFormValueSize    = 1 << 10
+
+	max_image_size_secret_path = "./secrets/max_image_size.txt"
+	upload_types_secret_path   = "./secrets/upload_types.txt"
+	jwt_key_secret_path        = "./secrets/jwt_key.txt"
 
 	contentTypeKey        = "Content-Type"
 	contentLenKey         = "Content-Length"
 	contentCachingKey     = "Cache-Control"
 	contentDispositionKey = "Content-Disposition"
+	contentRangeKey       = "Content-Range"
+	acceptRangesKey       = "Accept-Ranges"
+	etagKey               = "ETag"
+	lastModifiedKey       = "Last-Modified"
+	ifNoneMatchKey        = "If-None-Match"
+	ifModifiedSinceKey    = "If-Modified-Since"
+	rangeKey              = "Range"
+	ifRangeKey            = "If-Range"
+	authorizationKey      = "Authorization"
+	wwwAuthenticateKey    = "WWW-Authenticate"
+	apiKeyKey             = "X-API-Key"
+	requestIdKey          = "X-Request-ID"
 
 	appJSONValue         = "application/json"
-	appOctetStream       = "application/octet-stream"
 	appMiltipartFormData = "multipart/form-data"
-	appPublicCacheOneDay = "public, max-age=86400"
+	appPublicRevalidate  = "public, no-cache"
 
 	apiPrefix     = "/api/v1"
 	swaggerPrefix = "/swagger/"
@@ -48,42 +73,127 @@ const (
 
 var schemaDecoder = schema.NewDecoder()
 
+// defaultUploadTypes are the extensions allowed per file type when the upload types aren't configured.
+var defaultUploadTypes = map[string][]string{
+	"image":    {".jpg", ".jpeg", ".png", ".webp", ".gif", ".avif", ".heic", ".heif", ".svg"},
+	"barcode":  {".png", ".svg"},
+	"document": {".pdf"},
+}
+
 type IClientService interface {
 	AddClient(*ds.AddClientRequest) *ds.AddClientResponse
 	DeleteClient(*ds.DeleteClientRequest) *ds.DeleteClientResponse
+	RestoreClient(*ds.RestoreClientRequest) *ds.RestoreClientResponse
 	GetClientsByName(*ds.GetClientsByNameRequest) *ds.GetClientsByNameResponse
 	GetClients(*ds.GetClientsRequest) *ds.GetClientsResponse
 	PatchClientAddress(*ds.PatchClientAddressRequest) *ds.PatchClientAddressResponse
+	GetClientHistory(*ds.GetHistoryRequest) *ds.GetClientHistoryResponse
 }
 
 type IProductService interface {
 	AddProduct(*ds.AddProductRequest) *ds.AddProductResponse
 	DecreaseProducts(*ds.DecreaseProductsRequest) *ds.DecreaseProductsResponse
 	GetProduct(*ds.GetProductRequest) *ds.GetProductResponse
+	GetProductBySku(*ds.GetProductBySkuRequest) *ds.GetProductResponse
+	GetProductByBarcode(*ds.GetProductByBarcodeRequest) *ds.GetProductResponse
 	GetProducts(*ds.GetProductsRequest) *ds.GetProductsResponse
 	DeleteProduct(*ds.DeleteProductRequest) *ds.DeleteProductResponse
+	RestoreProduct(*ds.RestoreProductRequest) *ds.RestoreProductResponse
+	UpdateProductAttributes(*ds.UpdateProductAttributesRequest) *ds.UpdateProductAttributesResponse
+	AddProductVariant(*ds.AddProductVariantRequest) *ds.AddProductVariantResponse
+	DeleteProductVariant(*ds.DeleteProductVariantRequest) *ds.DeleteProductVariantResponse
+	GetBarcode(*ds.GetBarcodeRequest) *ds.GetBarcodeResponse
+	AddProductDocument(*ds.AddProductDocumentRequest) *ds.AddProductDocumentResponse
+	GetProductDocument(*ds.GetProductDocumentRequest) *ds.GetProductDocumentResponse
+	GetProductDocuments(*ds.GetProductDocumentsRequest) *ds.GetProductDocumentsResponse
+	DeleteProductDocument(*ds.DeleteProductDocumentRequest) *ds.DeleteProductDocumentResponse
+	GetProductHistory(*ds.GetHistoryRequest) *ds.GetProductHistoryResponse
+	SetVariantPrice(*ds.SetVariantPriceRequest) *ds.SetVariantPriceResponse
+	GetVariantPrices(*ds.GetVariantPricesRequest) *ds.GetVariantPricesResponse
+	SetExchangeRate(*ds.SetExchangeRateRequest) *ds.SetExchangeRateResponse
+	GetExchangeRates(*ds.GetExchangeRatesRequest) *ds.GetExchangeRatesResponse
 }
 
 type ISupplierService interface {
 	AddSupplier(*ds.AddSupplierRequest) *ds.AddSupplierResponse
 	UpdateSupplierAddress(*ds.UpdateSupplierAddressRequest) *ds.UpdateSupplierAddressResponse
 	DeleteSupplier(*ds.DeleteSupplierRequest) *ds.DeleteSupplierResponse
+	RestoreSupplier(*ds.RestoreSupplierRequest) *ds.RestoreSupplierResponse
 	GetSuppliers(*ds.GetSuppliersRequest) *ds.GetSuppliersResponse
 	GetSupplier(*ds.GetSupplierRequest) *ds.GetSupplierResponse
+	GetSupplierHistory(*ds.GetHistoryRequest) *ds.GetSupplierHistoryResponse
 }
 
 type IImageService interface {
 	AddImage(*ds.AddImageRequest) *ds.AddImageResponse
 	UpdateImage(*ds.UpdateImageRequest) *ds.UpdateImageResponse
 	DeleteImage(*ds.DeleteImageRequest) *ds.DeleteImageResponse
+	RestoreImage(*ds.RestoreImageRequest) *ds.RestoreImageResponse
 	GetProductImage(*ds.GetProductImageRequest) *ds.GetProductImageResponse
 	GetImage(*ds.GetImageRequest) *ds.GetImageResponse
+	GetImageMeta(*ds.GetImageMetaRequest) *ds.GetImageMetaResponse
+	AttachProductImage(*ds.AttachProductImageRequest) *ds.AttachProductImageResponse
+	DetachProductImage(*ds.DetachProductImageRequest) *ds.DetachProductImageResponse
+	ReorderProductImages(*ds.ReorderProductImagesRequest) *ds.ReorderProductImagesResponse
+}
+
+type ICategoryService interface {
+	AddCategory(*ds.AddCategoryRequest) *ds.AddCategoryResponse
+	UpdateCategory(*ds.UpdateCategoryRequest) *ds.UpdateCategoryResponse
+	DeleteCategory(*ds.DeleteCategoryRequest) *ds.DeleteCategoryResponse
+	GetCategory(*ds.GetCategoryRequest) *ds.GetCategoryResponse
+	GetCategoriesTree(*ds.GetCategoriesTreeRequest) *ds.GetCategoriesTreeResponse
+}
+
+type IAuthService interface {
+	IssueApiKey(*ds.IssueApiKeyRequest) *ds.IssueApiKeyResponse
+	GetApiKeys(*ds.GetApiKeysRequest) *ds.GetApiKeysResponse
+	RevokeApiKey(*ds.RevokeApiKeyRequest) *ds.RevokeApiKeyResponse
+	AuthenticateApiKey(*ds.AuthenticateApiKeyRequest) *ds.AuthenticateApiKeyResponse
+	GetRoles(*ds.GetRolesRequest) *ds.GetRolesResponse
+	AddRole(*ds.AddRoleRequest) *ds.AddRoleResponse
+	UpdateRole(*ds.UpdateRoleRequest) *ds.UpdateRoleResponse
+	DeleteRole(*ds.DeleteRoleRequest) *ds.DeleteRoleResponse
+	GetPermissions(*ds.GetPermissionsRequest) *ds.GetPermissionsResponse
+}
+
+type IAuditService interface {
+	GetAudit(*ds.GetAuditRequest) *ds.GetAuditResponse
+}
+
+type IPromotionService interface {
+	AddPromotion(*ds.AddPromotionRequest) *ds.AddPromotionResponse
+	UpdatePromotion(*ds.UpdatePromotionRequest) *ds.UpdatePromotionResponse
+	GetPromotions(*ds.GetPromotionsRequest) *ds.GetPromotionsResponse
+	PriceBasket(*ds.PriceBasketRequest) *ds.PriceBasketResponse
+	RedeemBasket(*ds.RedeemBasketRequest) *ds.PriceBasketResponse
+}
+
+// IRateLimiter takes a request from the token bucket of the key.
+type IRateLimiter interface {
+	Allow(key string, l ratelimit.Limit) (ratelimit.Result, error)
 }
 
 type IWithStatus interface {
 	GetStatus() string
 }
 
+// IStreamed is a response with the file content streamed from storage instead of loaded.
+type IStreamed interface {
+	Stream() (io.ReadCloser, int64)
+}
+
+// IValidated is a file response with the validators of conditional requests.
+// Empty hash means the ETag is computed from the loaded content.
+type IValidated interface {
+	Validators() (hash string, modified time.Time)
+}
+
+// IAudited is a mutating request, the caller making it is recorded in the audit log.
+type IAudited interface {
+	SetAudit(ds.Audit)
+}
+
 type IServer interface {
 	ListenAndServe() error
 	Shutdown(ctx context.Context) error
@@ -94,21 +204,29 @@ type IRouter interface {
 }
 
 type API struct {
-	ctx             context.Context
-	server          IServer
-	router          IRouter
-	logger          service.ILogger
-	clientService   IClientService
-	productService  IProductService
-	supplierService ISupplierService
-	imageService    IImageService
+	ctx              context.Context
+	server           IServer
+	router           IRouter
+	logger           service.ILogger
+	clientService    IClientService
+	productService   IProductService
+	supplierService  ISupplierService
+	imageService     IImageService
+	categoryService  ICategoryService
+	authService      IAuthService
+	auditService     IAuditService
+	promotionService IPromotionService
+	limiter          IRateLimiter
+	maxImageSize     int64
+	uploadGuard      *scanner.Guard
+	jwtKey           []byte
 }
 
 type ExecArgs[ReqT any, RespT any] struct {
 	api              *API
 	serviceFunc      func(*ReqT) *RespT
 	requestExtractor func(r *http.Request, v any) error
-	responseWriter   func(r *http.ResponseWriter, v any) error
+	responseWriter   func(w *http.ResponseWriter, r *http.Request, v any) error
 	httpRequest      *http.Request
 	httpResponse     *http.ResponseWriter
 }
@@ -116,9 +234,21 @@ type ExecArgs[ReqT any, RespT any] struct {
 var mutex sync.Mutex
 
 var statusCodeMap = map[string]int{
-	ds.StatusNotFound:     http.StatusNotFound,
-	ds.StatusServiceError: http.StatusInternalServerError,
-	ds.StatusOK:           http.StatusOK,
+	ds.StatusNotFound:                    http.StatusNotFound,
+	ds.StatusServiceError:                http.StatusInternalServerError,
+	ds.StatusTooLarge:                    http.StatusRequestEntityTooLarge,
+	ds.StatusConflict:                    http.StatusConflict,
+	ds.StatusRestoreWithDeletedReference: http.StatusConflict,
+	ds.StatusUnauthorized:                http.StatusUnauthorized,
+	ds.StatusInvalidCredentials:          http.StatusUnauthorized,
+	ds.StatusForbidden:                   http.StatusForbidden,
+	ds.StatusTooManyRequests:             http.StatusTooManyRequests,
+	ds.StatusRoleInUse:                   http.StatusConflict,
+	ds.StatusRoleProtected:               http.StatusConflict,
+	ds.StatusPromoCodeExhausted:          http.StatusConflict,
+	ds.StatusPromotionExhausted:          http.StatusConflict,
+	ds.StatusOrderOfAnotherClient:        http.StatusConflict,
+	ds.StatusOK:                          http.StatusOK,
 }
 
 func getStatusCode(s string) (int, bool) {
@@ -132,14 +262,18 @@ func NewAPI(ctx context.Context, l service.ILogger,
 	cs IClientService,
 	ps IProductService,
 	ss ISupplierService,
-	is IImageService) *API {
+	is IImageService,
+	cats ICategoryService,
+	as IAuthService,
+	aus IAuditService,
+	prs IPromotionService,
+	rl IRateLimiter) *API {
 
 	router := http.NewServeMux()
 	router.Handle(swaggerPrefix, httpSwagger.WrapHandler)
 
 	server := &http.Server{
 		Addr:         address,
-		Handler:      middlewareHandler(router),
 		ReadTimeout:  readTimeout,
 		WriteTimeout: writeTimeout,
 	}
@@ -152,7 +286,95 @@ func NewAPI(ctx context.Context, l service.ILogger,
 		}
 	}()
 
-	return buildAPI(ctx, l, server, router, cs, ps, ss, is)
+	api := buildAPI(ctx, l, server, router, cs, ps, ss, is, cats, as, aus, prs, rl)
+	server.Handler = requestIdHandler(api.rateLimitHandler(api.middlewareHandler(api.keyRateLimitHandler(router))))
+
+	maxImageSize, err := readMaxImageSize()
+	if err != nil {
+		l.FatalKV("failed reading max image size", "error", err.Error())
+	}
+	api.maxImageSize = maxImageSize
+
+	guard, err := scanner.NewGuardFromSecrets()
+	if err != nil {
+		l.FatalKV("failed building upload scanners", "error", err.Error())
+	}
+	guard.OnReject(func(rec scanner.Record) {
+		l.WarnKV("upload rejected", "file", rec.FileName, "reason", rec.Reason, "quarantine_id", rec.ID.String())
+	})
+	api.uploadGuard = guard
+
+	if err = api.LoadUploadTypes(); err != nil {
+		l.FatalKV("failed loading upload types", "error", err.Error())
+	}
+
+	jwtKey, err := readJWTKey()
+	if err != nil {
+		l.FatalKV("failed reading jwt key", "error", err.Error())
+	}
+	if jwtKey == nil {
+		l.WarnKV("jwt key is not set, bearer tokens are rejected", "path", jwt_key_secret_path)
+	}
+	api.jwtKey = jwtKey
+
+	return api
+}
+
+// LoadUploadTypes replaces the allowed extensions of the file types listed in the upload types secret,
+// the types missing there keep their extensions. It is called again to apply the changed secret at runtime.
+func (a *API) LoadUploadTypes() error {
+	f, err := os.Open(upload_types_secret_path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	uploadTypes, err := mimeManager.ParseAllowedExtensions(f)
+	if err != nil {
+		return err
+	}
+
+	return setUploadTypes(uploadTypes)
+}
+
+// setUploadTypes checks all the extensions before any of them is applied, so a malformed secret changes nothing.
+func setUploadTypes(uploadTypes map[string][]string) error {
+	for fileType, extensions := range uploadTypes {
+		for _, ext := range extensions {
+			if mime.TypeByExtension(strings.ToLower(ext)) == "" {
+				return fmt.Errorf("file type '%s': no mime type associated with %s", fileType, ext)
+			}
+		}
+	}
+
+	for fileType, extensions := range uploadTypes {
+		if err := mimeManager.SetAllowedExtensions(fileType, extensions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readMaxImageSize reads the limit of uploaded image in bytes, the default one is used if it isn't set.
+func readMaxImageSize() (int64, error) {
+	value, err := supports.ReadSecret(max_image_size_secret_path)
+	if errors.Is(err, os.ErrNotExist) {
+		return defaultMaxImageSize, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("max image size has to be a positive number of bytes, got '%s'", value)
+	}
+
+	return size, nil
 }
 
 func buildAPI(ctx context.Context,
@@ -162,29 +384,46 @@ func buildAPI(ctx context.Context,
 	cs IClientService,
 	ps IProductService,
 	ss ISupplierService,
-	is IImageService) *API {
+	is IImageService,
+	cats ICategoryService,
+	as IAuthService,
+	aus IAuditService,
+	prs IPromotionService,
+	rl IRateLimiter) *API {
 	api := &API{
-		ctx:             ctx,
-		server:          s,
-		router:          r,
-		clientService:   cs,
-		productService:  ps,
-		supplierService: ss,
-		imageService:    is,
-		logger:          l,
+		ctx:              ctx,
+		server:           s,
+		router:           r,
+		clientService:    cs,
+		productService:   ps,
+		supplierService:  ss,
+		imageService:     is,
+		categoryService:  cats,
+		authService:      as,
+		auditService:     aus,
+		promotionService: prs,
+		limiter:          rl,
+		logger:           l,
+		maxImageSize:     defaultMaxImageSize,
+		uploadGuard:      scanner.NewGuard(nil, scanner.NewContentScanner(thumbnail.MaxSourcePixels)),
 	}
 
 	api.setupClientsHandlers(api.router)
 	api.setupProductsHandlers(api.router)
 	api.setupSuppliersHandlers(api.router)
 	api.setupImagesHandlers(api.router)
-
-	mimeManager.AddAllowedExtensions("image", []string{
-		".jpg",
-		".jpeg",
-		".png",
-		".webp",
-	})
+	api.setupCategoriesHandlers(api.router)
+	api.setupDocumentsHandlers(api.router)
+	api.setupAuthHandlers(api.router)
+	api.setupRolesHandlers(api.router)
+	api.setupAuditHandlers(api.router)
+	api.setupHistoryHandlers(api.router)
+	api.setupPricesHandlers(api.router)
+	api.setupPromotionsHandlers(api.router)
+
+	if err := setUploadTypes(defaultUploadTypes); err != nil {
+		panic(err)
+	}
 
 	return api
 }
@@ -194,12 +433,6 @@ func (a *API) Start() error {
 	return a.server.ListenAndServe()
 }
 
-func middlewareHandler(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		next.ServeHTTP(w, r)
-	})
-}
-
 func pattern(method, prefixPath string) string {
 	return supports.Concat(method, " ", prefixPath)
 }
@@ -215,7 +448,7 @@ func extractJsonBody(r *http.Request, v any) error {
 	return nil
 }
 
-func writeJsonResponse(w *http.ResponseWriter, resp any) error {
+func writeJsonResponse(w *http.ResponseWriter, _ *http.Request, resp any) error {
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(resp); err != nil {
 		return err
@@ -259,110 +492,90 @@ func extractSchemaQuery(r *http.Request, v any) error {
 	return nil
 }
 
-func extractMultipartWithFile(r *http.Request, v any) error {
-	if err := r.ParseMultipartForm(maxMultipartFormMemory10MB); err != nil {
-		return err
-	}
-
-	if err := schemaDecoder.Decode(v, r.PostForm); err != nil && err != io.EOF {
-		return err
-	}
-
+// extractMultipartWithFile streams the file instead of reading it into memory: the form values have to
+// go before the file, the mime type is checked by the head of the file and its size is limited by maxImageSize.
+// The file is read later by the service, so exceeding the limit is reported by its response. The upload scanners
+// check it on the way as well, a rejected file fails the reading before the storage keeps it.
+func (a *API) extractMultipartWithFile(r *http.Request, v any) error {
 	formName, field, err := supports.GetStructFieldByTagKey(v, "file")
 	if err != nil {
 		return err
 	}
 
-	if !supports.IsFieldByteSlice(field) {
-		return fmt.Errorf("field '%s' has not '[]byte' type", formName)
-	}
-
-	file, _, err := r.FormFile(formName)
-	if err != nil {
-		return err
-	}
-
-	data, err := io.ReadAll(file)
-	if err != nil {
-		return err
+	if !supports.IsFieldReader(field) {
+		return fmt.Errorf("field '%s' has not 'io.Reader' type", formName)
 	}
 
-	if err = file.Close(); err != nil {
-		return err
-	}
-
-	err = mimeManager.IsFileAllowed(data, formName)
+	mr, err := r.MultipartReader()
 	if err != nil {
 		return err
 	}
 
-	field.SetBytes(data)
-
-	return nil
-}
-
-func writeFileResponse(w *http.ResponseWriter, resp any) error {
-	value := reflect.ValueOf(resp)
-
-	if value.Kind() == reflect.Ptr {
-		value = value.Elem()
-	}
-
-	if value.Kind() != reflect.Struct {
-		return fmt.Errorf("expected struct but got '%s'", value.Kind().String())
-	}
+	values := url.Values{}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return fmt.Errorf("file '%s' is missing", formName)
+		}
+		if err != nil {
+			return err
+		}
 
-	formName, field, err := supports.GetStructFieldByTagKey(resp, "file")
+		if part.FormName() != formName {
+			value, err := io.ReadAll(supports.LimitReader(part, maxFormValueSize))
+			if err != nil {
+				return fmt.Errorf("form value '%s': %w", part.FormName(), err)
+			}
+			values.Add(part.FormName(), string(value))
+			continue
+		}
 
-	if err != nil {
-		return err
-	}
+		head := make([]byte, mimeManager.SniffLen)
+		n, err := io.ReadFull(part, head)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		head = head[:n]
 
-	if !supports.IsFieldByteSlice(field) {
-		return fmt.Errorf("field '%s' has not '[]byte' type", formName)
-	}
+		if err = mimeManager.IsFileAllowed(head, formName); err != nil {
+			return err
+		}
 
-	data := field.Bytes()
+		if err = schemaDecoder.Decode(v, values); err != nil && err != io.EOF {
+			return err
+		}
 
-	if len(data) == 0 {
-		return writeJsonResponse(w, resp)
-	}
+		file := io.MultiReader(bytes.NewReader(head), &multipartFile{part: part, reader: mr})
+		guarded, err := a.uploadGuard.Reader(r.Context(), part.FileName(), supports.LimitReader(file, a.maxImageSize))
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(guarded))
 
-	filename, err := getAttachmentFileName(resp, data, formName)
-	if err != nil {
-		return err
+		return nil
 	}
+}
 
-	(*w).Header().Set(contentDispositionKey, filename)
-	(*w).Header().Set(contentLenKey, strconv.Itoa(len(data)))
-	(*w).Header().Set(contentTypeKey, appOctetStream)
-	// (*w).Header().Set(contentCachingKey, appPublicCacheOneDay)
+var errFormValueAfterFile = errors.New("form values have to go before the file")
 
-	(*w).WriteHeader(http.StatusOK)
-	_, err = (*w).Write(data)
-	if err != nil {
-		return err
-	}
-
-	return nil
+// multipartFile reads the file part and makes sure it is the last one,
+// a form value after it can't be decoded anymore and would be silently lost.
+type multipartFile struct {
+	part   *multipart.Part
+	reader *multipart.Reader
 }
 
-func getAttachmentFileName(resp any, outData []byte, expectFileType string) (string, error) {
-	var fieldName string
-	_, field, err := supports.GetStructFieldByTagKey(resp, "asFileName")
-	if err == nil {
-		if v, ok := field.Interface().(fmt.Stringer); ok {
-			fieldName = v.String()
-		}
+func (f *multipartFile) Read(p []byte) (int, error) {
+	n, err := f.part.Read(p)
+	if err != io.EOF {
+		return n, err
 	}
 
-	ext, err := mimeManager.GetFileExtension(outData, expectFileType)
-	if err != nil {
-		return "", err
+	if _, err = f.reader.NextPart(); err != io.EOF {
+		return n, errors.Join(errFormValueAfterFile, err)
 	}
-	return supports.Concat("attachment; filename=\"file_", fieldName, "_", supports.GetDateAsFileName(time.Now()), ext, "\""), nil
-	// return fmt.Sprintf("attachment; filename=\"file_%s_%s%s\"",
-	// 	fieldName, supports.GetDateAsFileName(time.Now()), ext), nil
+
+	return n, io.EOF
 }
 
 func Exec[ReqT any, RespT any](a ExecArgs[ReqT, RespT]) {
@@ -373,7 +586,7 @@ func Exec[ReqT any, RespT any](a ExecArgs[ReqT, RespT]) {
 		a.api.logger.ErrorKV(msg, "error", err.Error())
 
 		resp := ds.Status{Message: supports.Concat(msg, ": ", err.Error())}
-		err = writeJsonResponse(a.httpResponse, resp)
+		err = writeJsonResponse(a.httpResponse, a.httpRequest, resp)
 		if err != nil {
 			a.api.logger.ErrorKV("failed write response",
 				"error", err.Error(), "response", resp)
@@ -387,7 +600,7 @@ func Exec[ReqT any, RespT any](a ExecArgs[ReqT, RespT]) {
 		a.api.logger.ErrorKV(msg, "error", err.Error(), "request", req)
 
 		resp := ds.Status{Message: supports.Concat(msg, ": ", err.Error())}
-		err = writeJsonResponse(a.httpResponse, resp)
+		err = writeJsonResponse(a.httpResponse, a.httpRequest, resp)
 		if err != nil {
 			a.api.logger.ErrorKV("failed write response",
 				"error", err.Error(), "response", resp)
@@ -395,19 +608,23 @@ func Exec[ReqT any, RespT any](a ExecArgs[ReqT, RespT]) {
 		return
 	}
 
+	if v, ok := any(&req).(IAudited); ok {
+		v.SetAudit(auditFromContext(a.httpRequest.Context()))
+	}
+
 	resp := a.serviceFunc(&req)
 	if resp == nil {
 		resp := ds.Status{Message: ds.StatusServiceError}
 		msg := "failed execute request on service"
 		a.api.logger.ErrorKV(msg, "error", "service return no response", "request", req)
-		err := writeJsonResponse(a.httpResponse, resp)
+		err := writeJsonResponse(a.httpResponse, a.httpRequest, resp)
 		if err != nil {
 
 		}
 		return
 	}
 
-	if err := a.responseWriter(a.httpResponse, resp); err != nil {
+	if err := a.responseWriter(a.httpResponse, a.httpRequest, resp); err != nil {
 		msg := "failed writing response"
 		http.Error(*a.httpResponse, msg, http.StatusInternalServerError)
 		a.api.logger.ErrorKV(msg, "error", err.Error(), "request", req)
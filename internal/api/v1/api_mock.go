@@ -6,9 +6,12 @@ package api
 
 import (
 	context "context"
+	io "io"
 	http "net/http"
 	reflect "reflect"
 	datastruct "shopapi/internal/datastruct"
+	ratelimit "shopapi/internal/ratelimit"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -64,6 +67,20 @@ func (mr *MockIClientServiceMockRecorder) DeleteClient(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockIClientService)(nil).DeleteClient), arg0)
 }
 
+// GetClientHistory mocks base method.
+func (m *MockIClientService) GetClientHistory(arg0 *datastruct.GetHistoryRequest) *datastruct.GetClientHistoryResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientHistory", arg0)
+	ret0, _ := ret[0].(*datastruct.GetClientHistoryResponse)
+	return ret0
+}
+
+// GetClientHistory indicates an expected call of GetClientHistory.
+func (mr *MockIClientServiceMockRecorder) GetClientHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientHistory", reflect.TypeOf((*MockIClientService)(nil).GetClientHistory), arg0)
+}
+
 // GetClients mocks base method.
 func (m *MockIClientService) GetClients(arg0 *datastruct.GetClientsRequest) *datastruct.GetClientsResponse {
 	m.ctrl.T.Helper()
@@ -106,6 +123,20 @@ func (mr *MockIClientServiceMockRecorder) PatchClientAddress(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchClientAddress", reflect.TypeOf((*MockIClientService)(nil).PatchClientAddress), arg0)
 }
 
+// RestoreClient mocks base method.
+func (m *MockIClientService) RestoreClient(arg0 *datastruct.RestoreClientRequest) *datastruct.RestoreClientResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreClient", arg0)
+	ret0, _ := ret[0].(*datastruct.RestoreClientResponse)
+	return ret0
+}
+
+// RestoreClient indicates an expected call of RestoreClient.
+func (mr *MockIClientServiceMockRecorder) RestoreClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreClient", reflect.TypeOf((*MockIClientService)(nil).RestoreClient), arg0)
+}
+
 // MockIProductService is a mock of IProductService interface.
 type MockIProductService struct {
 	ctrl     *gomock.Controller
@@ -143,6 +174,34 @@ func (mr *MockIProductServiceMockRecorder) AddProduct(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockIProductService)(nil).AddProduct), arg0)
 }
 
+// AddProductDocument mocks base method.
+func (m *MockIProductService) AddProductDocument(arg0 *datastruct.AddProductDocumentRequest) *datastruct.AddProductDocumentResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProductDocument", arg0)
+	ret0, _ := ret[0].(*datastruct.AddProductDocumentResponse)
+	return ret0
+}
+
+// AddProductDocument indicates an expected call of AddProductDocument.
+func (mr *MockIProductServiceMockRecorder) AddProductDocument(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductDocument", reflect.TypeOf((*MockIProductService)(nil).AddProductDocument), arg0)
+}
+
+// AddProductVariant mocks base method.
+func (m *MockIProductService) AddProductVariant(arg0 *datastruct.AddProductVariantRequest) *datastruct.AddProductVariantResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProductVariant", arg0)
+	ret0, _ := ret[0].(*datastruct.AddProductVariantResponse)
+	return ret0
+}
+
+// AddProductVariant indicates an expected call of AddProductVariant.
+func (mr *MockIProductServiceMockRecorder) AddProductVariant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductVariant", reflect.TypeOf((*MockIProductService)(nil).AddProductVariant), arg0)
+}
+
 // DecreaseProducts mocks base method.
 func (m *MockIProductService) DecreaseProducts(arg0 *datastruct.DecreaseProductsRequest) *datastruct.DecreaseProductsResponse {
 	m.ctrl.T.Helper()
@@ -171,6 +230,62 @@ func (mr *MockIProductServiceMockRecorder) DeleteProduct(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockIProductService)(nil).DeleteProduct), arg0)
 }
 
+// DeleteProductDocument mocks base method.
+func (m *MockIProductService) DeleteProductDocument(arg0 *datastruct.DeleteProductDocumentRequest) *datastruct.DeleteProductDocumentResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductDocument", arg0)
+	ret0, _ := ret[0].(*datastruct.DeleteProductDocumentResponse)
+	return ret0
+}
+
+// DeleteProductDocument indicates an expected call of DeleteProductDocument.
+func (mr *MockIProductServiceMockRecorder) DeleteProductDocument(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductDocument", reflect.TypeOf((*MockIProductService)(nil).DeleteProductDocument), arg0)
+}
+
+// DeleteProductVariant mocks base method.
+func (m *MockIProductService) DeleteProductVariant(arg0 *datastruct.DeleteProductVariantRequest) *datastruct.DeleteProductVariantResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductVariant", arg0)
+	ret0, _ := ret[0].(*datastruct.DeleteProductVariantResponse)
+	return ret0
+}
+
+// DeleteProductVariant indicates an expected call of DeleteProductVariant.
+func (mr *MockIProductServiceMockRecorder) DeleteProductVariant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductVariant", reflect.TypeOf((*MockIProductService)(nil).DeleteProductVariant), arg0)
+}
+
+// GetBarcode mocks base method.
+func (m *MockIProductService) GetBarcode(arg0 *datastruct.GetBarcodeRequest) *datastruct.GetBarcodeResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBarcode", arg0)
+	ret0, _ := ret[0].(*datastruct.GetBarcodeResponse)
+	return ret0
+}
+
+// GetBarcode indicates an expected call of GetBarcode.
+func (mr *MockIProductServiceMockRecorder) GetBarcode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBarcode", reflect.TypeOf((*MockIProductService)(nil).GetBarcode), arg0)
+}
+
+// GetExchangeRates mocks base method.
+func (m *MockIProductService) GetExchangeRates(arg0 *datastruct.GetExchangeRatesRequest) *datastruct.GetExchangeRatesResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates", arg0)
+	ret0, _ := ret[0].(*datastruct.GetExchangeRatesResponse)
+	return ret0
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockIProductServiceMockRecorder) GetExchangeRates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockIProductService)(nil).GetExchangeRates), arg0)
+}
+
 // GetProduct mocks base method.
 func (m *MockIProductService) GetProduct(arg0 *datastruct.GetProductRequest) *datastruct.GetProductResponse {
 	m.ctrl.T.Helper()
@@ -185,6 +300,76 @@ func (mr *MockIProductServiceMockRecorder) GetProduct(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockIProductService)(nil).GetProduct), arg0)
 }
 
+// GetProductByBarcode mocks base method.
+func (m *MockIProductService) GetProductByBarcode(arg0 *datastruct.GetProductByBarcodeRequest) *datastruct.GetProductResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByBarcode", arg0)
+	ret0, _ := ret[0].(*datastruct.GetProductResponse)
+	return ret0
+}
+
+// GetProductByBarcode indicates an expected call of GetProductByBarcode.
+func (mr *MockIProductServiceMockRecorder) GetProductByBarcode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByBarcode", reflect.TypeOf((*MockIProductService)(nil).GetProductByBarcode), arg0)
+}
+
+// GetProductBySku mocks base method.
+func (m *MockIProductService) GetProductBySku(arg0 *datastruct.GetProductBySkuRequest) *datastruct.GetProductResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductBySku", arg0)
+	ret0, _ := ret[0].(*datastruct.GetProductResponse)
+	return ret0
+}
+
+// GetProductBySku indicates an expected call of GetProductBySku.
+func (mr *MockIProductServiceMockRecorder) GetProductBySku(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductBySku", reflect.TypeOf((*MockIProductService)(nil).GetProductBySku), arg0)
+}
+
+// GetProductDocument mocks base method.
+func (m *MockIProductService) GetProductDocument(arg0 *datastruct.GetProductDocumentRequest) *datastruct.GetProductDocumentResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductDocument", arg0)
+	ret0, _ := ret[0].(*datastruct.GetProductDocumentResponse)
+	return ret0
+}
+
+// GetProductDocument indicates an expected call of GetProductDocument.
+func (mr *MockIProductServiceMockRecorder) GetProductDocument(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductDocument", reflect.TypeOf((*MockIProductService)(nil).GetProductDocument), arg0)
+}
+
+// GetProductDocuments mocks base method.
+func (m *MockIProductService) GetProductDocuments(arg0 *datastruct.GetProductDocumentsRequest) *datastruct.GetProductDocumentsResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductDocuments", arg0)
+	ret0, _ := ret[0].(*datastruct.GetProductDocumentsResponse)
+	return ret0
+}
+
+// GetProductDocuments indicates an expected call of GetProductDocuments.
+func (mr *MockIProductServiceMockRecorder) GetProductDocuments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductDocuments", reflect.TypeOf((*MockIProductService)(nil).GetProductDocuments), arg0)
+}
+
+// GetProductHistory mocks base method.
+func (m *MockIProductService) GetProductHistory(arg0 *datastruct.GetHistoryRequest) *datastruct.GetProductHistoryResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductHistory", arg0)
+	ret0, _ := ret[0].(*datastruct.GetProductHistoryResponse)
+	return ret0
+}
+
+// GetProductHistory indicates an expected call of GetProductHistory.
+func (mr *MockIProductServiceMockRecorder) GetProductHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductHistory", reflect.TypeOf((*MockIProductService)(nil).GetProductHistory), arg0)
+}
+
 // GetProducts mocks base method.
 func (m *MockIProductService) GetProducts(arg0 *datastruct.GetProductsRequest) *datastruct.GetProductsResponse {
 	m.ctrl.T.Helper()
@@ -199,6 +384,76 @@ func (mr *MockIProductServiceMockRecorder) GetProducts(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProducts", reflect.TypeOf((*MockIProductService)(nil).GetProducts), arg0)
 }
 
+// GetVariantPrices mocks base method.
+func (m *MockIProductService) GetVariantPrices(arg0 *datastruct.GetVariantPricesRequest) *datastruct.GetVariantPricesResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantPrices", arg0)
+	ret0, _ := ret[0].(*datastruct.GetVariantPricesResponse)
+	return ret0
+}
+
+// GetVariantPrices indicates an expected call of GetVariantPrices.
+func (mr *MockIProductServiceMockRecorder) GetVariantPrices(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantPrices", reflect.TypeOf((*MockIProductService)(nil).GetVariantPrices), arg0)
+}
+
+// RestoreProduct mocks base method.
+func (m *MockIProductService) RestoreProduct(arg0 *datastruct.RestoreProductRequest) *datastruct.RestoreProductResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProduct", arg0)
+	ret0, _ := ret[0].(*datastruct.RestoreProductResponse)
+	return ret0
+}
+
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockIProductServiceMockRecorder) RestoreProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockIProductService)(nil).RestoreProduct), arg0)
+}
+
+// SetExchangeRate mocks base method.
+func (m *MockIProductService) SetExchangeRate(arg0 *datastruct.SetExchangeRateRequest) *datastruct.SetExchangeRateResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExchangeRate", arg0)
+	ret0, _ := ret[0].(*datastruct.SetExchangeRateResponse)
+	return ret0
+}
+
+// SetExchangeRate indicates an expected call of SetExchangeRate.
+func (mr *MockIProductServiceMockRecorder) SetExchangeRate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExchangeRate", reflect.TypeOf((*MockIProductService)(nil).SetExchangeRate), arg0)
+}
+
+// SetVariantPrice mocks base method.
+func (m *MockIProductService) SetVariantPrice(arg0 *datastruct.SetVariantPriceRequest) *datastruct.SetVariantPriceResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVariantPrice", arg0)
+	ret0, _ := ret[0].(*datastruct.SetVariantPriceResponse)
+	return ret0
+}
+
+// SetVariantPrice indicates an expected call of SetVariantPrice.
+func (mr *MockIProductServiceMockRecorder) SetVariantPrice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVariantPrice", reflect.TypeOf((*MockIProductService)(nil).SetVariantPrice), arg0)
+}
+
+// UpdateProductAttributes mocks base method.
+func (m *MockIProductService) UpdateProductAttributes(arg0 *datastruct.UpdateProductAttributesRequest) *datastruct.UpdateProductAttributesResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductAttributes", arg0)
+	ret0, _ := ret[0].(*datastruct.UpdateProductAttributesResponse)
+	return ret0
+}
+
+// UpdateProductAttributes indicates an expected call of UpdateProductAttributes.
+func (mr *MockIProductServiceMockRecorder) UpdateProductAttributes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductAttributes", reflect.TypeOf((*MockIProductService)(nil).UpdateProductAttributes), arg0)
+}
+
 // MockISupplierService is a mock of ISupplierService interface.
 type MockISupplierService struct {
 	ctrl     *gomock.Controller
@@ -264,6 +519,20 @@ func (mr *MockISupplierServiceMockRecorder) GetSupplier(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockISupplierService)(nil).GetSupplier), arg0)
 }
 
+// GetSupplierHistory mocks base method.
+func (m *MockISupplierService) GetSupplierHistory(arg0 *datastruct.GetHistoryRequest) *datastruct.GetSupplierHistoryResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplierHistory", arg0)
+	ret0, _ := ret[0].(*datastruct.GetSupplierHistoryResponse)
+	return ret0
+}
+
+// GetSupplierHistory indicates an expected call of GetSupplierHistory.
+func (mr *MockISupplierServiceMockRecorder) GetSupplierHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplierHistory", reflect.TypeOf((*MockISupplierService)(nil).GetSupplierHistory), arg0)
+}
+
 // GetSuppliers mocks base method.
 func (m *MockISupplierService) GetSuppliers(arg0 *datastruct.GetSuppliersRequest) *datastruct.GetSuppliersResponse {
 	m.ctrl.T.Helper()
@@ -278,6 +547,20 @@ func (mr *MockISupplierServiceMockRecorder) GetSuppliers(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuppliers", reflect.TypeOf((*MockISupplierService)(nil).GetSuppliers), arg0)
 }
 
+// RestoreSupplier mocks base method.
+func (m *MockISupplierService) RestoreSupplier(arg0 *datastruct.RestoreSupplierRequest) *datastruct.RestoreSupplierResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSupplier", arg0)
+	ret0, _ := ret[0].(*datastruct.RestoreSupplierResponse)
+	return ret0
+}
+
+// RestoreSupplier indicates an expected call of RestoreSupplier.
+func (mr *MockISupplierServiceMockRecorder) RestoreSupplier(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSupplier", reflect.TypeOf((*MockISupplierService)(nil).RestoreSupplier), arg0)
+}
+
 // UpdateSupplierAddress mocks base method.
 func (m *MockISupplierService) UpdateSupplierAddress(arg0 *datastruct.UpdateSupplierAddressRequest) *datastruct.UpdateSupplierAddressResponse {
 	m.ctrl.T.Helper()
@@ -329,6 +612,20 @@ func (mr *MockIImageServiceMockRecorder) AddImage(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddImage", reflect.TypeOf((*MockIImageService)(nil).AddImage), arg0)
 }
 
+// AttachProductImage mocks base method.
+func (m *MockIImageService) AttachProductImage(arg0 *datastruct.AttachProductImageRequest) *datastruct.AttachProductImageResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachProductImage", arg0)
+	ret0, _ := ret[0].(*datastruct.AttachProductImageResponse)
+	return ret0
+}
+
+// AttachProductImage indicates an expected call of AttachProductImage.
+func (mr *MockIImageServiceMockRecorder) AttachProductImage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachProductImage", reflect.TypeOf((*MockIImageService)(nil).AttachProductImage), arg0)
+}
+
 // DeleteImage mocks base method.
 func (m *MockIImageService) DeleteImage(arg0 *datastruct.DeleteImageRequest) *datastruct.DeleteImageResponse {
 	m.ctrl.T.Helper()
@@ -343,6 +640,20 @@ func (mr *MockIImageServiceMockRecorder) DeleteImage(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockIImageService)(nil).DeleteImage), arg0)
 }
 
+// DetachProductImage mocks base method.
+func (m *MockIImageService) DetachProductImage(arg0 *datastruct.DetachProductImageRequest) *datastruct.DetachProductImageResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachProductImage", arg0)
+	ret0, _ := ret[0].(*datastruct.DetachProductImageResponse)
+	return ret0
+}
+
+// DetachProductImage indicates an expected call of DetachProductImage.
+func (mr *MockIImageServiceMockRecorder) DetachProductImage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachProductImage", reflect.TypeOf((*MockIImageService)(nil).DetachProductImage), arg0)
+}
+
 // GetImage mocks base method.
 func (m *MockIImageService) GetImage(arg0 *datastruct.GetImageRequest) *datastruct.GetImageResponse {
 	m.ctrl.T.Helper()
@@ -357,6 +668,20 @@ func (mr *MockIImageServiceMockRecorder) GetImage(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImage", reflect.TypeOf((*MockIImageService)(nil).GetImage), arg0)
 }
 
+// GetImageMeta mocks base method.
+func (m *MockIImageService) GetImageMeta(arg0 *datastruct.GetImageMetaRequest) *datastruct.GetImageMetaResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageMeta", arg0)
+	ret0, _ := ret[0].(*datastruct.GetImageMetaResponse)
+	return ret0
+}
+
+// GetImageMeta indicates an expected call of GetImageMeta.
+func (mr *MockIImageServiceMockRecorder) GetImageMeta(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageMeta", reflect.TypeOf((*MockIImageService)(nil).GetImageMeta), arg0)
+}
+
 // GetProductImage mocks base method.
 func (m *MockIImageService) GetProductImage(arg0 *datastruct.GetProductImageRequest) *datastruct.GetProductImageResponse {
 	m.ctrl.T.Helper()
@@ -371,6 +696,34 @@ func (mr *MockIImageServiceMockRecorder) GetProductImage(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImage", reflect.TypeOf((*MockIImageService)(nil).GetProductImage), arg0)
 }
 
+// ReorderProductImages mocks base method.
+func (m *MockIImageService) ReorderProductImages(arg0 *datastruct.ReorderProductImagesRequest) *datastruct.ReorderProductImagesResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderProductImages", arg0)
+	ret0, _ := ret[0].(*datastruct.ReorderProductImagesResponse)
+	return ret0
+}
+
+// ReorderProductImages indicates an expected call of ReorderProductImages.
+func (mr *MockIImageServiceMockRecorder) ReorderProductImages(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderProductImages", reflect.TypeOf((*MockIImageService)(nil).ReorderProductImages), arg0)
+}
+
+// RestoreImage mocks base method.
+func (m *MockIImageService) RestoreImage(arg0 *datastruct.RestoreImageRequest) *datastruct.RestoreImageResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreImage", arg0)
+	ret0, _ := ret[0].(*datastruct.RestoreImageResponse)
+	return ret0
+}
+
+// RestoreImage indicates an expected call of RestoreImage.
+func (mr *MockIImageServiceMockRecorder) RestoreImage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreImage", reflect.TypeOf((*MockIImageService)(nil).RestoreImage), arg0)
+}
+
 // UpdateImage mocks base method.
 func (m *MockIImageService) UpdateImage(arg0 *datastruct.UpdateImageRequest) *datastruct.UpdateImageResponse {
 	m.ctrl.T.Helper()
@@ -385,6 +738,416 @@ func (mr *MockIImageServiceMockRecorder) UpdateImage(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImage", reflect.TypeOf((*MockIImageService)(nil).UpdateImage), arg0)
 }
 
+// MockICategoryService is a mock of ICategoryService interface.
+type MockICategoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockICategoryServiceMockRecorder
+}
+
+// MockICategoryServiceMockRecorder is the mock recorder for MockICategoryService.
+type MockICategoryServiceMockRecorder struct {
+	mock *MockICategoryService
+}
+
+// NewMockICategoryService creates a new mock instance.
+func NewMockICategoryService(ctrl *gomock.Controller) *MockICategoryService {
+	mock := &MockICategoryService{ctrl: ctrl}
+	mock.recorder = &MockICategoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICategoryService) EXPECT() *MockICategoryServiceMockRecorder {
+	return m.recorder
+}
+
+// AddCategory mocks base method.
+func (m *MockICategoryService) AddCategory(arg0 *datastruct.AddCategoryRequest) *datastruct.AddCategoryResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCategory", arg0)
+	ret0, _ := ret[0].(*datastruct.AddCategoryResponse)
+	return ret0
+}
+
+// AddCategory indicates an expected call of AddCategory.
+func (mr *MockICategoryServiceMockRecorder) AddCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCategory", reflect.TypeOf((*MockICategoryService)(nil).AddCategory), arg0)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockICategoryService) DeleteCategory(arg0 *datastruct.DeleteCategoryRequest) *datastruct.DeleteCategoryResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0)
+	ret0, _ := ret[0].(*datastruct.DeleteCategoryResponse)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockICategoryServiceMockRecorder) DeleteCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockICategoryService)(nil).DeleteCategory), arg0)
+}
+
+// GetCategoriesTree mocks base method.
+func (m *MockICategoryService) GetCategoriesTree(arg0 *datastruct.GetCategoriesTreeRequest) *datastruct.GetCategoriesTreeResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesTree", arg0)
+	ret0, _ := ret[0].(*datastruct.GetCategoriesTreeResponse)
+	return ret0
+}
+
+// GetCategoriesTree indicates an expected call of GetCategoriesTree.
+func (mr *MockICategoryServiceMockRecorder) GetCategoriesTree(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesTree", reflect.TypeOf((*MockICategoryService)(nil).GetCategoriesTree), arg0)
+}
+
+// GetCategory mocks base method.
+func (m *MockICategoryService) GetCategory(arg0 *datastruct.GetCategoryRequest) *datastruct.GetCategoryResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0)
+	ret0, _ := ret[0].(*datastruct.GetCategoryResponse)
+	return ret0
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockICategoryServiceMockRecorder) GetCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockICategoryService)(nil).GetCategory), arg0)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockICategoryService) UpdateCategory(arg0 *datastruct.UpdateCategoryRequest) *datastruct.UpdateCategoryResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0)
+	ret0, _ := ret[0].(*datastruct.UpdateCategoryResponse)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockICategoryServiceMockRecorder) UpdateCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockICategoryService)(nil).UpdateCategory), arg0)
+}
+
+// MockIAuthService is a mock of IAuthService interface.
+type MockIAuthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuthServiceMockRecorder
+}
+
+// MockIAuthServiceMockRecorder is the mock recorder for MockIAuthService.
+type MockIAuthServiceMockRecorder struct {
+	mock *MockIAuthService
+}
+
+// NewMockIAuthService creates a new mock instance.
+func NewMockIAuthService(ctrl *gomock.Controller) *MockIAuthService {
+	mock := &MockIAuthService{ctrl: ctrl}
+	mock.recorder = &MockIAuthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuthService) EXPECT() *MockIAuthServiceMockRecorder {
+	return m.recorder
+}
+
+// AddRole mocks base method.
+func (m *MockIAuthService) AddRole(arg0 *datastruct.AddRoleRequest) *datastruct.AddRoleResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRole", arg0)
+	ret0, _ := ret[0].(*datastruct.AddRoleResponse)
+	return ret0
+}
+
+// AddRole indicates an expected call of AddRole.
+func (mr *MockIAuthServiceMockRecorder) AddRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRole", reflect.TypeOf((*MockIAuthService)(nil).AddRole), arg0)
+}
+
+// AuthenticateApiKey mocks base method.
+func (m *MockIAuthService) AuthenticateApiKey(arg0 *datastruct.AuthenticateApiKeyRequest) *datastruct.AuthenticateApiKeyResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateApiKey", arg0)
+	ret0, _ := ret[0].(*datastruct.AuthenticateApiKeyResponse)
+	return ret0
+}
+
+// AuthenticateApiKey indicates an expected call of AuthenticateApiKey.
+func (mr *MockIAuthServiceMockRecorder) AuthenticateApiKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateApiKey", reflect.TypeOf((*MockIAuthService)(nil).AuthenticateApiKey), arg0)
+}
+
+// DeleteRole mocks base method.
+func (m *MockIAuthService) DeleteRole(arg0 *datastruct.DeleteRoleRequest) *datastruct.DeleteRoleResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", arg0)
+	ret0, _ := ret[0].(*datastruct.DeleteRoleResponse)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockIAuthServiceMockRecorder) DeleteRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockIAuthService)(nil).DeleteRole), arg0)
+}
+
+// GetApiKeys mocks base method.
+func (m *MockIAuthService) GetApiKeys(arg0 *datastruct.GetApiKeysRequest) *datastruct.GetApiKeysResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeys", arg0)
+	ret0, _ := ret[0].(*datastruct.GetApiKeysResponse)
+	return ret0
+}
+
+// GetApiKeys indicates an expected call of GetApiKeys.
+func (mr *MockIAuthServiceMockRecorder) GetApiKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeys", reflect.TypeOf((*MockIAuthService)(nil).GetApiKeys), arg0)
+}
+
+// GetPermissions mocks base method.
+func (m *MockIAuthService) GetPermissions(arg0 *datastruct.GetPermissionsRequest) *datastruct.GetPermissionsResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissions", arg0)
+	ret0, _ := ret[0].(*datastruct.GetPermissionsResponse)
+	return ret0
+}
+
+// GetPermissions indicates an expected call of GetPermissions.
+func (mr *MockIAuthServiceMockRecorder) GetPermissions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissions", reflect.TypeOf((*MockIAuthService)(nil).GetPermissions), arg0)
+}
+
+// GetRoles mocks base method.
+func (m *MockIAuthService) GetRoles(arg0 *datastruct.GetRolesRequest) *datastruct.GetRolesResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoles", arg0)
+	ret0, _ := ret[0].(*datastruct.GetRolesResponse)
+	return ret0
+}
+
+// GetRoles indicates an expected call of GetRoles.
+func (mr *MockIAuthServiceMockRecorder) GetRoles(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockIAuthService)(nil).GetRoles), arg0)
+}
+
+// IssueApiKey mocks base method.
+func (m *MockIAuthService) IssueApiKey(arg0 *datastruct.IssueApiKeyRequest) *datastruct.IssueApiKeyResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueApiKey", arg0)
+	ret0, _ := ret[0].(*datastruct.IssueApiKeyResponse)
+	return ret0
+}
+
+// IssueApiKey indicates an expected call of IssueApiKey.
+func (mr *MockIAuthServiceMockRecorder) IssueApiKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueApiKey", reflect.TypeOf((*MockIAuthService)(nil).IssueApiKey), arg0)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockIAuthService) RevokeApiKey(arg0 *datastruct.RevokeApiKeyRequest) *datastruct.RevokeApiKeyResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeApiKey", arg0)
+	ret0, _ := ret[0].(*datastruct.RevokeApiKeyResponse)
+	return ret0
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockIAuthServiceMockRecorder) RevokeApiKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockIAuthService)(nil).RevokeApiKey), arg0)
+}
+
+// UpdateRole mocks base method.
+func (m *MockIAuthService) UpdateRole(arg0 *datastruct.UpdateRoleRequest) *datastruct.UpdateRoleResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", arg0)
+	ret0, _ := ret[0].(*datastruct.UpdateRoleResponse)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockIAuthServiceMockRecorder) UpdateRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockIAuthService)(nil).UpdateRole), arg0)
+}
+
+// MockIAuditService is a mock of IAuditService interface.
+type MockIAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditServiceMockRecorder
+}
+
+// MockIAuditServiceMockRecorder is the mock recorder for MockIAuditService.
+type MockIAuditServiceMockRecorder struct {
+	mock *MockIAuditService
+}
+
+// NewMockIAuditService creates a new mock instance.
+func NewMockIAuditService(ctrl *gomock.Controller) *MockIAuditService {
+	mock := &MockIAuditService{ctrl: ctrl}
+	mock.recorder = &MockIAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditService) EXPECT() *MockIAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAudit mocks base method.
+func (m *MockIAuditService) GetAudit(arg0 *datastruct.GetAuditRequest) *datastruct.GetAuditResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAudit", arg0)
+	ret0, _ := ret[0].(*datastruct.GetAuditResponse)
+	return ret0
+}
+
+// GetAudit indicates an expected call of GetAudit.
+func (mr *MockIAuditServiceMockRecorder) GetAudit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAudit", reflect.TypeOf((*MockIAuditService)(nil).GetAudit), arg0)
+}
+
+// MockIPromotionService is a mock of IPromotionService interface.
+type MockIPromotionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPromotionServiceMockRecorder
+}
+
+// MockIPromotionServiceMockRecorder is the mock recorder for MockIPromotionService.
+type MockIPromotionServiceMockRecorder struct {
+	mock *MockIPromotionService
+}
+
+// NewMockIPromotionService creates a new mock instance.
+func NewMockIPromotionService(ctrl *gomock.Controller) *MockIPromotionService {
+	mock := &MockIPromotionService{ctrl: ctrl}
+	mock.recorder = &MockIPromotionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPromotionService) EXPECT() *MockIPromotionServiceMockRecorder {
+	return m.recorder
+}
+
+// AddPromotion mocks base method.
+func (m *MockIPromotionService) AddPromotion(arg0 *datastruct.AddPromotionRequest) *datastruct.AddPromotionResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPromotion", arg0)
+	ret0, _ := ret[0].(*datastruct.AddPromotionResponse)
+	return ret0
+}
+
+// AddPromotion indicates an expected call of AddPromotion.
+func (mr *MockIPromotionServiceMockRecorder) AddPromotion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPromotion", reflect.TypeOf((*MockIPromotionService)(nil).AddPromotion), arg0)
+}
+
+// GetPromotions mocks base method.
+func (m *MockIPromotionService) GetPromotions(arg0 *datastruct.GetPromotionsRequest) *datastruct.GetPromotionsResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotions", arg0)
+	ret0, _ := ret[0].(*datastruct.GetPromotionsResponse)
+	return ret0
+}
+
+// GetPromotions indicates an expected call of GetPromotions.
+func (mr *MockIPromotionServiceMockRecorder) GetPromotions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotions", reflect.TypeOf((*MockIPromotionService)(nil).GetPromotions), arg0)
+}
+
+// PriceBasket mocks base method.
+func (m *MockIPromotionService) PriceBasket(arg0 *datastruct.PriceBasketRequest) *datastruct.PriceBasketResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PriceBasket", arg0)
+	ret0, _ := ret[0].(*datastruct.PriceBasketResponse)
+	return ret0
+}
+
+// PriceBasket indicates an expected call of PriceBasket.
+func (mr *MockIPromotionServiceMockRecorder) PriceBasket(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PriceBasket", reflect.TypeOf((*MockIPromotionService)(nil).PriceBasket), arg0)
+}
+
+// RedeemBasket mocks base method.
+func (m *MockIPromotionService) RedeemBasket(arg0 *datastruct.RedeemBasketRequest) *datastruct.PriceBasketResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemBasket", arg0)
+	ret0, _ := ret[0].(*datastruct.PriceBasketResponse)
+	return ret0
+}
+
+// RedeemBasket indicates an expected call of RedeemBasket.
+func (mr *MockIPromotionServiceMockRecorder) RedeemBasket(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemBasket", reflect.TypeOf((*MockIPromotionService)(nil).RedeemBasket), arg0)
+}
+
+// UpdatePromotion mocks base method.
+func (m *MockIPromotionService) UpdatePromotion(arg0 *datastruct.UpdatePromotionRequest) *datastruct.UpdatePromotionResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePromotion", arg0)
+	ret0, _ := ret[0].(*datastruct.UpdatePromotionResponse)
+	return ret0
+}
+
+// UpdatePromotion indicates an expected call of UpdatePromotion.
+func (mr *MockIPromotionServiceMockRecorder) UpdatePromotion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePromotion", reflect.TypeOf((*MockIPromotionService)(nil).UpdatePromotion), arg0)
+}
+
+// MockIRateLimiter is a mock of IRateLimiter interface.
+type MockIRateLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRateLimiterMockRecorder
+}
+
+// MockIRateLimiterMockRecorder is the mock recorder for MockIRateLimiter.
+type MockIRateLimiterMockRecorder struct {
+	mock *MockIRateLimiter
+}
+
+// NewMockIRateLimiter creates a new mock instance.
+func NewMockIRateLimiter(ctrl *gomock.Controller) *MockIRateLimiter {
+	mock := &MockIRateLimiter{ctrl: ctrl}
+	mock.recorder = &MockIRateLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRateLimiter) EXPECT() *MockIRateLimiterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockIRateLimiter) Allow(key string, l ratelimit.Limit) (ratelimit.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", key, l)
+	ret0, _ := ret[0].(ratelimit.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockIRateLimiterMockRecorder) Allow(key, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockIRateLimiter)(nil).Allow), key, l)
+}
+
 // MockIWithStatus is a mock of IWithStatus interface.
 type MockIWithStatus struct {
 	ctrl     *gomock.Controller
@@ -422,6 +1185,117 @@ func (mr *MockIWithStatusMockRecorder) GetStatus() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockIWithStatus)(nil).GetStatus))
 }
 
+// MockIStreamed is a mock of IStreamed interface.
+type MockIStreamed struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStreamedMockRecorder
+}
+
+// MockIStreamedMockRecorder is the mock recorder for MockIStreamed.
+type MockIStreamedMockRecorder struct {
+	mock *MockIStreamed
+}
+
+// NewMockIStreamed creates a new mock instance.
+func NewMockIStreamed(ctrl *gomock.Controller) *MockIStreamed {
+	mock := &MockIStreamed{ctrl: ctrl}
+	mock.recorder = &MockIStreamedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStreamed) EXPECT() *MockIStreamedMockRecorder {
+	return m.recorder
+}
+
+// Stream mocks base method.
+func (m *MockIStreamed) Stream() (io.ReadCloser, int64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream")
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(int64)
+	return ret0, ret1
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockIStreamedMockRecorder) Stream() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockIStreamed)(nil).Stream))
+}
+
+// MockIValidated is a mock of IValidated interface.
+type MockIValidated struct {
+	ctrl     *gomock.Controller
+	recorder *MockIValidatedMockRecorder
+}
+
+// MockIValidatedMockRecorder is the mock recorder for MockIValidated.
+type MockIValidatedMockRecorder struct {
+	mock *MockIValidated
+}
+
+// NewMockIValidated creates a new mock instance.
+func NewMockIValidated(ctrl *gomock.Controller) *MockIValidated {
+	mock := &MockIValidated{ctrl: ctrl}
+	mock.recorder = &MockIValidatedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIValidated) EXPECT() *MockIValidatedMockRecorder {
+	return m.recorder
+}
+
+// Validators mocks base method.
+func (m *MockIValidated) Validators() (string, time.Time) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validators")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	return ret0, ret1
+}
+
+// Validators indicates an expected call of Validators.
+func (mr *MockIValidatedMockRecorder) Validators() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validators", reflect.TypeOf((*MockIValidated)(nil).Validators))
+}
+
+// MockIAudited is a mock of IAudited interface.
+type MockIAudited struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditedMockRecorder
+}
+
+// MockIAuditedMockRecorder is the mock recorder for MockIAudited.
+type MockIAuditedMockRecorder struct {
+	mock *MockIAudited
+}
+
+// NewMockIAudited creates a new mock instance.
+func NewMockIAudited(ctrl *gomock.Controller) *MockIAudited {
+	mock := &MockIAudited{ctrl: ctrl}
+	mock.recorder = &MockIAuditedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAudited) EXPECT() *MockIAuditedMockRecorder {
+	return m.recorder
+}
+
+// SetAudit mocks base method.
+func (m *MockIAudited) SetAudit(arg0 datastruct.Audit) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAudit", arg0)
+}
+
+// SetAudit indicates an expected call of SetAudit.
+func (mr *MockIAuditedMockRecorder) SetAudit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAudit", reflect.TypeOf((*MockIAudited)(nil).SetAudit), arg0)
+}
+
 // MockIServer is a mock of IServer interface.
 type MockIServer struct {
 	ctrl     *gomock.Controller
@@ -2,10 +2,12 @@ package api
 
 import (
 	"context"
+	mimeManager "shopapi/internal/mime-manager"
 	"shopapi/internal/service"
 	"testing"
 
 	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
 )
 
 type TestAPI struct {
@@ -13,6 +15,11 @@ type TestAPI struct {
 	imageMock      *MockIImageService
 	productMock    *MockIProductService
 	supplierMock   *MockISupplierService
+	categoryMock   *MockICategoryService
+	authMock       *MockIAuthService
+	auditMock      *MockIAuditService
+	promotionMock  *MockIPromotionService
+	limiterMock    *MockIRateLimiter
 	serverMock     *MockIServer
 	routerMock     *MockIRouter
 	loggerMock     *service.MockILogger
@@ -27,6 +34,11 @@ func NewTestApi(ctx context.Context, t *testing.T) *TestAPI {
 		imageMock:      NewMockIImageService(mc),
 		productMock:    NewMockIProductService(mc),
 		supplierMock:   NewMockISupplierService(mc),
+		categoryMock:   NewMockICategoryService(mc),
+		authMock:       NewMockIAuthService(mc),
+		auditMock:      NewMockIAuditService(mc),
+		promotionMock:  NewMockIPromotionService(mc),
+		limiterMock:    NewMockIRateLimiter(mc),
 		serverMock:     NewMockIServer(mc),
 		routerMock:     NewMockIRouter(mc),
 		loggerMock:     service.NewMockILogger(mc),
@@ -36,11 +48,47 @@ func NewTestApi(ctx context.Context, t *testing.T) *TestAPI {
 	ta.routerMock.EXPECT().HandleFunc(gomock.Any(), gomock.Any()).MinTimes(1)
 
 	ta.api = buildAPI(ctx, ta.loggerMock, ta.serverMock, ta.routerMock,
-		ta.clientMock, ta.productMock, ta.supplierMock, ta.imageMock)
+		ta.clientMock, ta.productMock, ta.supplierMock, ta.imageMock, ta.categoryMock, ta.authMock,
+		ta.auditMock, ta.promotionMock, ta.limiterMock)
 
 	return ta
 }
 
+func TestReadMaxImageSize(t *testing.T) {
+	t.Parallel()
+
+	size, err := readMaxImageSize()
+	require.Nil(t, err)
+	require.Equal(t, int64(defaultMaxImageSize), size)
+}
+
+func TestLoadUploadTypes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("LoadUploadTypes ok", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		// The secret is missing in tests, so the defaults are kept.
+		require.Nil(t, a.api.LoadUploadTypes())
+		require.Equal(t, []string{".pdf"}, mimeManager.AllowedExtensions("document"))
+	})
+
+	t.Run("LoadUploadTypes error on unknown extension", func(t *testing.T) {
+		t.Parallel()
+
+		NewTestApi(context.Background(), t)
+
+		err := setUploadTypes(map[string][]string{
+			"document": {".pdf", ".txt"},
+			"image":    {".png", ".unknown"},
+		})
+		require.ErrorContains(t, err, ".unknown")
+		require.Equal(t, []string{".pdf"}, mimeManager.AllowedExtensions("document"))
+	})
+}
+
 func TestBuildApi(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
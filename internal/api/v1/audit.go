@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	ds "shopapi/internal/datastruct"
+
+	"github.com/google/uuid"
+)
+
+const prefixAudit = apiPrefix + "/audit"
+
+// requestIdPattern keeps the request ID of the caller only if it is safe to log and to store.
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type requestIdKeyType struct{}
+
+func (a *API) setupAuditHandlers(router IRouter) {
+	router.HandleFunc(pattern(http.MethodGet, prefixAudit), a.authorize(ds.PermissionAuditRead, a.GetAudit))
+}
+
+// requestIdHandler takes the request ID from the X-Request-ID header or generates a new one,
+// the ID is returned in the same header and recorded with the changes the request makes.
+func requestIdHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIdKey)
+		if !requestIdPattern.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set(requestIdKey, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIdKeyType{}, id)))
+	})
+}
+
+// auditFromContext returns the caller of the request as the audit log records it.
+func auditFromContext(ctx context.Context) ds.Audit {
+	audit := ds.Audit{}
+	audit.RequestID, _ = ctx.Value(requestIdKeyType{}).(string)
+
+	if p, ok := principalFromContext(ctx); ok {
+		audit.ActorKind = p.Kind
+		audit.Actor = p.Subject
+		audit.ActorKey = p.ApiKeyUid
+		audit.Granted = p.Permissions
+	}
+
+	return audit
+}
+
+// GetAudit Возвращает журнал изменений
+// @Summary      Журнал изменений
+// @Description  Возвращает записи журнала изменений, новые первыми. Запись хранит состояние сущности до и после
+// @Description  изменения, кто и каким запросом его сделал. Без limit возвращается не больше 1000 записей.
+// @Tags         Auth
+// @Produce      json
+// @Param        entity      query     string  false "Тип сущности" Enums(client, supplier, product, product_variant, variant_price, product_images, product_document, image, category, api_key, role, exchange_rate, promotion)
+// @Param        entity_id   query     string  false "Идентификатор сущности, для роли - ее название"
+// @Param        actor       query     string  false "Имя API ключа или субъект токена"
+// @Param        action      query     string  false "Операция, например DeleteSupplier"
+// @Param        request_id  query     string  false "Идентификатор запроса из заголовка X-Request-ID"
+// @Param        since       query     string  false "Начало периода, RFC 3339"
+// @Param        until       query     string  false "Конец периода, RFC 3339"
+// @Param        limit       query     int     false "Количество записей"
+// @Param        offset      query     int     false "Смещение"
+// @Success      200  {object}  ds.GetAuditResponse
+// @Failure      400  {object}  ds.Status
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /audit [get]
+func (a *API) GetAudit(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetAuditRequest, ds.GetAuditResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.auditService.GetAudit,
+	})
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ds "shopapi/internal/datastruct"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAudit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetAudit 200", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixAudit+"?entity=supplier&actor=warehouse&limit=10", nil)
+
+		resp := &ds.GetAuditResponse{Entries: []ds.AuditEntry{{
+			Id:     1,
+			Action: "DeleteSupplier",
+			Entity: ds.AuditSupplier,
+			Actor:  "warehouse",
+			Before: json.RawMessage(`{"name":"Supplier"}`),
+			After:  json.RawMessage(`null`),
+		}}}
+
+		a.auditMock.EXPECT().GetAudit(&ds.GetAuditRequest{
+			Entity: ds.AuditSupplier,
+			Actor:  "warehouse",
+			Limit:  10,
+		}).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.GetAudit(a.responseWriter, apiReq)
+	})
+
+	t.Run("GetAudit unknown entity", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixAudit+"?entity=order", nil)
+
+		w := httptest.NewRecorder()
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+
+		a.api.GetAudit(w, apiReq)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRequestIdHandler(t *testing.T) {
+	t.Parallel()
+
+	serve := func(header string) (string, string) {
+		var seen string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = auditFromContext(r.Context()).RequestID
+		})
+
+		r := httptest.NewRequest(http.MethodGet, prefixRoles, nil)
+		if header != "" {
+			r.Header.Set(requestIdKey, header)
+		}
+		w := httptest.NewRecorder()
+		requestIdHandler(next).ServeHTTP(w, r)
+
+		return seen, w.Header().Get(requestIdKey)
+	}
+
+	t.Run("requestIdHandler keeps the caller's id", func(t *testing.T) {
+		t.Parallel()
+
+		seen, returned := serve("batch-42:7")
+		require.Equal(t, "batch-42:7", seen)
+		require.Equal(t, "batch-42:7", returned)
+	})
+
+	t.Run("requestIdHandler generates missing id", func(t *testing.T) {
+		t.Parallel()
+
+		seen, returned := serve("")
+		require.Equal(t, seen, returned)
+		_, err := uuid.Parse(seen)
+		require.Nil(t, err)
+	})
+
+	t.Run("requestIdHandler replaces unsafe id", func(t *testing.T) {
+		t.Parallel()
+
+		seen, returned := serve("id\"with quotes " + strings.Repeat("x", 200))
+		require.Equal(t, seen, returned)
+		_, err := uuid.Parse(seen)
+		require.Nil(t, err)
+	})
+}
+
+func TestExecSetsAudit(t *testing.T) {
+	t.Parallel()
+
+	a := NewTestApi(context.Background(), t)
+
+	keyUid := uuid.New()
+	principal := &ds.Principal{
+		Kind:        ds.PrincipalApiKey,
+		Subject:     "crm",
+		ApiKeyUid:   &keyUid,
+		Permissions: []string{ds.PermissionRolesManage},
+	}
+
+	apiReq := httptest.NewRequest(http.MethodDelete, prefixRole, strings.NewReader(`{"name":"viewer"}`))
+	apiReq.Header.Set("Content-Type", "application/json")
+	ctx := context.WithValue(apiReq.Context(), principalKey{}, principal)
+	ctx = context.WithValue(ctx, requestIdKeyType{}, "req-1")
+	apiReq = apiReq.WithContext(ctx)
+
+	a.authMock.EXPECT().DeleteRole(&ds.DeleteRoleRequest{
+		Audit: ds.Audit{
+			RequestID: "req-1",
+			ActorKind: ds.PrincipalApiKey,
+			Actor:     "crm",
+			ActorKey:  &keyUid,
+			Granted:   []string{ds.PermissionRolesManage},
+		},
+		Name: "viewer",
+	}).Return(&ds.DeleteRoleResponse{Status: ds.Status{Message: ds.StatusOK}})
+
+	w := httptest.NewRecorder()
+	a.api.DeleteRole(w, apiReq)
+	require.Equal(t, http.StatusOK, w.Code)
+}
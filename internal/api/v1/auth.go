@@ -0,0 +1,275 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"shopapi/internal/auth"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/supports"
+)
+
+const (
+	prefixApiKey  = apiPrefix + "/api-key"
+	prefixApiKeys = apiPrefix + "/api-keys"
+
+	bearerScheme = "Bearer"
+)
+
+var (
+	errNoCredentials    = errors.New("no api key or bearer token")
+	errBearerDisabled   = errors.New("bearer tokens are not accepted, jwt key is not set")
+	errInvalidApiKey    = errors.New("api key is unknown, revoked or expired")
+	errAuthServiceFail  = errors.New("service failed checking api key")
+	errRolesServiceFail = errors.New("service failed resolving role permissions")
+)
+
+type principalKey struct{}
+
+func (a *API) setupAuthHandlers(router IRouter) {
+	router.HandleFunc(pattern(http.MethodPost, prefixApiKey), a.authorize(ds.PermissionApiKeysManage, a.IssueApiKey))
+	router.HandleFunc(pattern(http.MethodGet, prefixApiKeys), a.authorize(ds.PermissionApiKeysManage, a.GetApiKeys))
+	router.HandleFunc(pattern(http.MethodDelete, prefixApiKey), a.authorize(ds.PermissionApiKeysManage, a.RevokeApiKey))
+}
+
+// middlewareHandler lets through only the requests authenticated by an API key in the X-API-Key header
+// or by a JWT in the Authorization header, the swagger UI stays public. The caller is put into the context
+// with the permissions its roles grant, the routes check them in authorize.
+func (a *API) middlewareHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, swaggerPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, err := a.authenticate(r)
+		if err == nil {
+			err = a.resolvePermissions(principal)
+		}
+		if err != nil {
+			a.writeAuthError(w, r, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	})
+}
+
+// principalFromContext returns the caller the request is authenticated as.
+func principalFromContext(ctx context.Context) (*ds.Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*ds.Principal)
+	return p, ok
+}
+
+func (a *API) authenticate(r *http.Request) (*ds.Principal, error) {
+	if key := r.Header.Get(apiKeyKey); key != "" {
+		return a.authenticateApiKey(key)
+	}
+
+	scheme, token, found := strings.Cut(r.Header.Get(authorizationKey), " ")
+	if !found || !strings.EqualFold(scheme, bearerScheme) {
+		return nil, errNoCredentials
+	}
+
+	if a.jwtKey == nil {
+		return nil, errBearerDisabled
+	}
+
+	claims, err := auth.ParseJWT(strings.TrimSpace(token), a.jwtKey, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ds.Principal{
+		Kind:    ds.PrincipalToken,
+		Subject: claims.Subject,
+		Roles:   claims.Roles,
+	}, nil
+}
+
+func (a *API) authenticateApiKey(key string) (*ds.Principal, error) {
+	resp := a.authService.AuthenticateApiKey(&ds.AuthenticateApiKeyRequest{Key: key})
+	if resp == nil {
+		return nil, errAuthServiceFail
+	}
+	if resp.ApiKey == nil {
+		return nil, errInvalidApiKey
+	}
+
+	return &ds.Principal{
+		Kind:      ds.PrincipalApiKey,
+		Subject:   resp.ApiKey.Name,
+		ApiKeyUid: &resp.ApiKey.Uid,
+		Roles:     resp.ApiKey.Roles,
+	}, nil
+}
+
+// resolvePermissions looks the permissions up on every request, so a changed role applies at once.
+func (a *API) resolvePermissions(principal *ds.Principal) error {
+	if len(principal.Roles) == 0 {
+		return nil
+	}
+
+	resp := a.authService.GetPermissions(&ds.GetPermissionsRequest{Roles: principal.Roles})
+	if resp == nil {
+		return errRolesServiceFail
+	}
+
+	principal.Permissions = resp.Permissions
+	return nil
+}
+
+// authorize lets the request through to the handler only if the caller's roles grant the permission,
+// otherwise it answers 403 naming the missing permission.
+func (a *API) authorize(permission string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := principalFromContext(r.Context())
+		if !ok {
+			a.writeAuthError(w, r, errNoCredentials)
+			return
+		}
+
+		if principal.Can(permission) {
+			handler(w, r)
+			return
+		}
+
+		resp := ds.ForbiddenResponse{
+			Status:     ds.Status{Message: ds.StatusForbidden},
+			Permission: permission,
+		}
+
+		a.logger.WarnKV("request not authorized", "path", r.URL.Path, "subject", principal.Subject, "permission", permission)
+		if err := writeJsonResponse(&w, r, resp); err != nil {
+			a.logger.ErrorKV("failed write response", "error", err.Error(), "response", resp)
+		}
+	}
+}
+
+// writeAuthError answers 401 without telling which check failed, the reason is only logged.
+// The service failure is answered 500, so the caller retries instead of dropping its key.
+func (a *API) writeAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	status := ds.Status{Message: ds.StatusInvalidCredentials}
+	switch {
+	case errors.Is(err, errAuthServiceFail), errors.Is(err, errRolesServiceFail):
+		status.Message = ds.StatusServiceError
+	case errors.Is(err, errNoCredentials):
+		status.Message = ds.StatusUnauthorized
+	}
+
+	if status.Message != ds.StatusServiceError {
+		w.Header().Set(wwwAuthenticateKey, bearerScheme)
+	}
+
+	a.logger.WarnKV("request not authenticated", "path", r.URL.Path, "error", err.Error())
+	if err = writeJsonResponse(&w, r, status); err != nil {
+		a.logger.ErrorKV("failed write response", "error", err.Error(), "response", status)
+	}
+}
+
+// readJWTKey reads the key the admin UI tokens are signed with, no key means the tokens are not accepted.
+func readJWTKey() ([]byte, error) {
+	value, err := supports.ReadSecret(jwt_key_secret_path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(value) < auth.MinKeySize {
+		return nil, fmt.Errorf("jwt key has to be at least %d bytes long", auth.MinKeySize)
+	}
+
+	return []byte(value), nil
+}
+
+// IssueApiKey Выпускает ключ API
+// @Summary      Выпуск ключа API
+// @Description  Выпускает ключ API для вызовов между сервисами. Ключ возвращается только в этом ответе, сервис хранит лишь его хеш.
+// @Description  Ключ передается в заголовке X-API-Key. Без expires_at ключ действует до отзыва.
+// @Description  Права ключа задаются ролями, с неизвестными ролями ключ не выпускается и они перечисляются в unknown_roles.
+// @Description  Ключ с правами, которых нет у вызывающего, не выпускается с 403, недостающие права перечисляются в missing_permissions.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.IssueApiKeyRequest  true "Название ключа, роли и срок действия"
+// @Success      200   {object}  ds.IssueApiKeyResponse
+// @Failure      400   {object}  ds.Status
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api-key [post]
+func (a *API) IssueApiKey(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.IssueApiKeyRequest, ds.IssueApiKeyResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.authService.IssueApiKey,
+	})
+}
+
+// GetApiKeys Возвращает ключи API
+// @Summary      Список ключей API
+// @Description  Возвращает выпущенные ключи API без самих ключей: название, начало ключа, сроки и время последнего использования.
+// @Description  Отозванные ключи возвращаются с include_revoked=true.
+// @Tags         Auth
+// @Produce      json
+// @Param        include_revoked  query     bool  false  "Включить отозванные ключи"
+// @Success      200              {object}  ds.GetApiKeysResponse
+// @Failure      400              {object}  ds.Status
+// @Failure      401              {object}  ds.Status
+// @Failure      403              {object}  ds.ForbiddenResponse
+// @Failure      429              {object}  ds.Status
+// @Failure      500              {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api-keys [get]
+func (a *API) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetApiKeysRequest, ds.GetApiKeysResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.authService.GetApiKeys,
+	})
+}
+
+// RevokeApiKey Отзывает ключ API
+// @Summary      Отзыв ключа API
+// @Description  Отзывает ключ API, запросы с ним сразу перестают приниматься. Отозванный ключ остается в списке с revoked_at.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.RevokeApiKeyRequest  true "uid"
+// @Success      200   {object}  ds.RevokeApiKeyResponse
+// @Failure      400   {object}  ds.Status
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      404   {object}  ds.RevokeApiKeyResponse
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /api-key [delete]
+func (a *API) RevokeApiKey(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.RevokeApiKeyRequest, ds.RevokeApiKeyResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.authService.RevokeApiKey,
+	})
+}
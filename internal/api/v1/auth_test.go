@@ -0,0 +1,413 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"shopapi/internal/auth"
+	ds "shopapi/internal/datastruct"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var testJWTKey = []byte("0123456789abcdef0123456789abcdef")
+
+// serveAuthenticated runs the request through the middleware and returns the recorded response
+// with the caller the handler got, nil if the handler wasn't reached.
+func serveAuthenticated(a *TestAPI, r *http.Request) (*httptest.ResponseRecorder, *ds.Principal) {
+	var principal *ds.Principal
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ = principalFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	a.api.middlewareHandler(next).ServeHTTP(rec, r)
+	return rec, principal
+}
+
+func testToken(t *testing.T, claims auth.Claims) string {
+	token, err := auth.SignJWT(claims, testJWTKey)
+	require.Nil(t, err)
+	return token
+}
+
+func TestMiddlewareHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Middleware api key ok", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		key := ds.ApiKey{Uid: uuid.New(), Name: "warehouse", Roles: []string{"warehouse"}}
+		a.authMock.EXPECT().AuthenticateApiKey(&ds.AuthenticateApiKeyRequest{Key: "sk_key"}).
+			Return(&ds.AuthenticateApiKeyResponse{ApiKey: &key})
+		a.authMock.EXPECT().GetPermissions(&ds.GetPermissionsRequest{Roles: key.Roles}).
+			Return(&ds.GetPermissionsResponse{Permissions: []string{ds.PermissionStockWrite}})
+
+		r := httptest.NewRequest(http.MethodDelete, prefixProduct, nil)
+		r.Header.Set(apiKeyKey, "sk_key")
+
+		rec, principal := serveAuthenticated(a, r)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, &ds.Principal{
+			Kind:        ds.PrincipalApiKey,
+			Subject:     "warehouse",
+			ApiKeyUid:   &key.Uid,
+			Roles:       key.Roles,
+			Permissions: []string{ds.PermissionStockWrite},
+		}, principal)
+	})
+
+	t.Run("Middleware permissions service error", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		key := ds.ApiKey{Uid: uuid.New(), Name: "warehouse", Roles: []string{"warehouse"}}
+		a.authMock.EXPECT().AuthenticateApiKey(gomock.Any()).Return(&ds.AuthenticateApiKeyResponse{ApiKey: &key})
+		a.authMock.EXPECT().GetPermissions(gomock.Any()).Return(nil)
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		r := httptest.NewRequest(http.MethodGet, prefixProducts, nil)
+		r.Header.Set(apiKeyKey, "sk_key")
+
+		rec, principal := serveAuthenticated(a, r)
+		require.Nil(t, principal)
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("Middleware api key invalid", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		a.authMock.EXPECT().AuthenticateApiKey(gomock.Any()).Return(&ds.AuthenticateApiKeyResponse{
+			Status: ds.Status{Message: ds.StatusInvalidCredentials},
+		})
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		r := httptest.NewRequest(http.MethodDelete, prefixClient, nil)
+		r.Header.Set(apiKeyKey, "sk_revoked")
+
+		rec, principal := serveAuthenticated(a, r)
+		require.Nil(t, principal)
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.Equal(t, bearerScheme, rec.Header().Get(wwwAuthenticateKey))
+		require.Contains(t, rec.Body.String(), ds.StatusInvalidCredentials)
+	})
+
+	t.Run("Middleware api key service error", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		a.authMock.EXPECT().AuthenticateApiKey(gomock.Any()).Return(nil)
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		r := httptest.NewRequest(http.MethodGet, prefixProducts, nil)
+		r.Header.Set(apiKeyKey, "sk_key")
+
+		rec, principal := serveAuthenticated(a, r)
+		require.Nil(t, principal)
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("Middleware bearer token ok", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+		a.api.jwtKey = testJWTKey
+
+		r := httptest.NewRequest(http.MethodGet, prefixProducts, nil)
+		r.Header.Set(authorizationKey, "Bearer "+testToken(t, auth.Claims{
+			Subject:   "admin@shop",
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			Roles:     []string{ds.RoleAdmin},
+		}))
+
+		a.authMock.EXPECT().GetPermissions(&ds.GetPermissionsRequest{Roles: []string{ds.RoleAdmin}}).
+			Return(&ds.GetPermissionsResponse{Permissions: []string{ds.PermissionAll}})
+
+		rec, principal := serveAuthenticated(a, r)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, &ds.Principal{
+			Kind:        ds.PrincipalToken,
+			Subject:     "admin@shop",
+			Roles:       []string{ds.RoleAdmin},
+			Permissions: []string{ds.PermissionAll},
+		}, principal)
+	})
+
+	t.Run("Middleware bearer token expired", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+		a.api.jwtKey = testJWTKey
+
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		r := httptest.NewRequest(http.MethodGet, prefixProducts, nil)
+		r.Header.Set(authorizationKey, "Bearer "+testToken(t, auth.Claims{
+			Subject:   "admin@shop",
+			ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+		}))
+
+		rec, principal := serveAuthenticated(a, r)
+		require.Nil(t, principal)
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("Middleware bearer token without jwt key", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		r := httptest.NewRequest(http.MethodGet, prefixProducts, nil)
+		r.Header.Set(authorizationKey, "Bearer "+testToken(t, auth.Claims{
+			Subject:   "admin@shop",
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		}))
+
+		rec, principal := serveAuthenticated(a, r)
+		require.Nil(t, principal)
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("Middleware no credentials", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		r := httptest.NewRequest(http.MethodDelete, prefixProduct, nil)
+		r.Header.Set(authorizationKey, "Basic YWRtaW46YWRtaW4=")
+
+		rec, principal := serveAuthenticated(a, r)
+		require.Nil(t, principal)
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.Contains(t, rec.Body.String(), ds.StatusUnauthorized)
+	})
+
+	t.Run("Middleware swagger is public", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		rec, principal := serveAuthenticated(a, httptest.NewRequest(http.MethodGet, swaggerPrefix+"index.html", nil))
+		require.Nil(t, principal)
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+
+	serve := func(a *TestAPI, principal *ds.Principal, permission string) *httptest.ResponseRecorder {
+		handler := a.api.authorize(permission, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		r := httptest.NewRequest(http.MethodPost, prefixProduct, nil)
+		if principal != nil {
+			r = r.WithContext(context.WithValue(r.Context(), principalKey{}, principal))
+		}
+
+		rec := httptest.NewRecorder()
+		handler(rec, r)
+		return rec
+	}
+
+	t.Run("Authorize granted", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		rec := serve(a, &ds.Principal{Permissions: []string{ds.PermissionProductsWrite}}, ds.PermissionProductsWrite)
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("Authorize admin", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		rec := serve(a, &ds.Principal{Permissions: []string{ds.PermissionAll}}, ds.PermissionRolesManage)
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("Authorize forbidden", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		rec := serve(a, &ds.Principal{Subject: "viewer", Permissions: []string{ds.PermissionProductsRead}}, ds.PermissionProductsWrite)
+		require.Equal(t, http.StatusForbidden, rec.Code)
+
+		var resp ds.ForbiddenResponse
+		require.Nil(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.Equal(t, ds.StatusForbidden, resp.GetStatus())
+		require.Equal(t, ds.PermissionProductsWrite, resp.Permission)
+	})
+
+	t.Run("Authorize without roles", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		rec := serve(a, &ds.Principal{Subject: "legacy"}, ds.PermissionProductsRead)
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("Authorize not authenticated", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		rec := serve(a, nil, ds.PermissionProductsRead)
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestReadJWTKey(t *testing.T) {
+	t.Parallel()
+
+	// The secret is missing in tests, so the bearer tokens are off.
+	key, err := readJWTKey()
+	require.Nil(t, err)
+	require.Nil(t, key)
+}
+
+func TestIssueApiKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("IssueApiKey 200", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		req := &ds.IssueApiKeyRequest{Name: "warehouse", Roles: []string{"warehouse"}}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixApiKey, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.IssueApiKeyResponse{
+			ApiKey: &ds.ApiKey{Uid: uuid.New(), Name: "warehouse", Prefix: "sk_abcdef"},
+			Key:    "sk_abcdefghijk",
+		}
+
+		a.authMock.EXPECT().IssueApiKey(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.IssueApiKey(a.responseWriter, apiReq)
+	})
+
+	t.Run("IssueApiKey 400", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixApiKey, strings.NewReader(`{}`))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.IssueApiKey(a.responseWriter, apiReq)
+	})
+}
+
+func TestGetApiKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetApiKeys 200", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixApiKeys+"?include_revoked=true", nil)
+
+		resp := &ds.GetApiKeysResponse{ApiKeys: []ds.ApiKey{{Uid: uuid.New(), Name: "warehouse"}}}
+
+		a.authMock.EXPECT().GetApiKeys(&ds.GetApiKeysRequest{IncludeRevoked: true}).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.GetApiKeys(a.responseWriter, apiReq)
+	})
+}
+
+func TestRevokeApiKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RevokeApiKey 404", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		req := &ds.RevokeApiKeyRequest{Uid: uuid.New()}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodDelete, prefixApiKey, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.RevokeApiKeyResponse{Status: ds.Status{Message: ds.StatusNotFound}}
+
+		a.authMock.EXPECT().RevokeApiKey(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.RevokeApiKey(a.responseWriter, apiReq)
+	})
+}
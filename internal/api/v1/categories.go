@@ -0,0 +1,154 @@
+package api
+
+import (
+	"net/http"
+	ds "shopapi/internal/datastruct"
+)
+
+const (
+	prefixCategory       = apiPrefix + "/category"
+	prefixCategories     = apiPrefix + "/categories"
+	prefixCategoriesTree = prefixCategories + "/tree"
+)
+
+func (a *API) setupCategoriesHandlers(router IRouter) {
+	router.HandleFunc(pattern(http.MethodPost, prefixCategory), a.authorize(ds.PermissionCategoriesWrite, a.PutCategory))
+	router.HandleFunc(pattern(http.MethodPatch, prefixCategory), a.authorize(ds.PermissionCategoriesWrite, a.UpdateCategory))
+	router.HandleFunc(pattern(http.MethodDelete, prefixCategory), a.authorize(ds.PermissionCategoriesWrite, a.DeleteCategory))
+	router.HandleFunc(pattern(http.MethodGet, prefixCategory), a.authorize(ds.PermissionCategoriesRead, a.GetCategory))
+	router.HandleFunc(pattern(http.MethodGet, prefixCategoriesTree), a.authorize(ds.PermissionCategoriesRead, a.GetCategoriesTree))
+}
+
+// PutCategory Добавляет новую категорию
+// @Summary      Добавление категории
+// @Description  Добавление категории. Если slug не указан, он будет сформирован из названия. parent_id задает родительскую категорию. attributes задает схему атрибутов продуктов категории: тип (string, number, integer, boolean), единицу измерения, обязательность и допустимые значения.
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.AddCategoryRequest  true "Информация о категории"
+// @Success      200   {object}  ds.AddCategoryResponse
+// @Failure      400   {object}  ds.AddCategoryResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /category [post]
+func (a *API) PutCategory(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.AddCategoryRequest, ds.AddCategoryResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.categoryService.AddCategory,
+	})
+}
+
+// UpdateCategory Обновляет категорию
+// @Summary      Обновление категории
+// @Description  Обновление названия, slug, порядка сортировки, схемы атрибутов и родителя категории. Категорию нельзя перенести в саму себя или в ее подкатегорию.
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.UpdateCategoryRequest  true "Информация о категории"
+// @Success      200   {object}  ds.UpdateCategoryResponse
+// @Failure      400   {object}  ds.UpdateCategoryResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /category [patch]
+func (a *API) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.UpdateCategoryRequest, ds.UpdateCategoryResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.categoryService.UpdateCategory,
+	})
+}
+
+// DeleteCategory Удаляет категорию
+// @Summary      Удаление категории
+// @Description  Удаление категории. Категорию с подкатегориями или продуктами удалить нельзя.
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.DeleteCategoryRequest  true "uid"
+// @Success      200   {object}  ds.DeleteCategoryResponse
+// @Failure      400   {object}  ds.DeleteCategoryResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /category [delete]
+func (a *API) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.DeleteCategoryRequest, ds.DeleteCategoryResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.categoryService.DeleteCategory,
+	})
+}
+
+// GetCategory возвращает категорию
+// @Summary      Возвращает категорию
+// @Description  Возвращает категорию.
+// @Tags         Category
+// @Produce      json
+// @Param        uid            query  string  true  "uid"         example("0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10")
+// @Param        avoid_cache    query  string  false "avoid_cache" example(true)
+// @Success      200  {object}  ds.GetCategoryResponse
+// @Failure      400  {object}  ds.GetCategoryResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /category [get]
+func (a *API) GetCategory(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetCategoryRequest, ds.GetCategoryResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.categoryService.GetCategory,
+	})
+}
+
+// GetCategoriesTree возвращает дерево категорий
+// @Summary      Возвращает дерево категорий
+// @Description  Возвращает все категории в виде дерева. Категории одного уровня отсортированы по sort_order и названию.
+// @Tags         Category
+// @Produce      json
+// @Param        avoid_cache    query  string  false "avoid_cache" example(true)
+// @Success      200  {object}  ds.GetCategoriesTreeResponse
+// @Failure      400  {object}  ds.Status
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /categories/tree [get]
+func (a *API) GetCategoriesTree(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetCategoriesTreeRequest, ds.GetCategoriesTreeResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.categoryService.GetCategoriesTree,
+	})
+}
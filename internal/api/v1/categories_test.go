@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	ds "shopapi/internal/datastruct"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func TestPutCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PutCategory 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.AddCategoryRequest{
+			Category: ds.Category{Name: "Beams"},
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodPost, prefixCategory, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.categoryMock.EXPECT().AddCategory(reqStruct).Return(&ds.AddCategoryResponse{Status: ds.Status{Message: ds.StatusOK}})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.PutCategory(a.responseWriter, testReq)
+	})
+
+	t.Run("PutCategory 400 on validation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		testReq := httptest.NewRequest(http.MethodPost, prefixCategory, strings.NewReader("{}"))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.PutCategory(a.responseWriter, testReq)
+	})
+}
+
+func TestDeleteCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteCategory 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.DeleteCategoryRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodDelete, prefixCategory, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.categoryMock.EXPECT().DeleteCategory(reqStruct).Return(&ds.DeleteCategoryResponse{Status: ds.Status{Message: ds.StatusOK}})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.DeleteCategory(a.responseWriter, testReq)
+	})
+
+	t.Run("DeleteCategory 400 in use", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.DeleteCategoryRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodDelete, prefixCategory, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.categoryMock.EXPECT().DeleteCategory(reqStruct).Return(&ds.DeleteCategoryResponse{Status: ds.Status{Message: ds.StatusCategoryInUse}})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.DeleteCategory(a.responseWriter, testReq)
+	})
+}
+
+func TestGetCategoriesTree(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetCategoriesTree 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		testReq := httptest.NewRequest(http.MethodGet, prefixCategoriesTree, nil)
+
+		a.categoryMock.EXPECT().GetCategoriesTree(gomock.Any()).Return(&ds.GetCategoriesTreeResponse{})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetCategoriesTree(a.responseWriter, testReq)
+	})
+
+	t.Run("GetCategoriesTree 500", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		testReq := httptest.NewRequest(http.MethodGet, prefixCategoriesTree, nil)
+
+		a.categoryMock.EXPECT().GetCategoriesTree(gomock.Any()).Return(nil)
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusInternalServerError)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetCategoriesTree(a.responseWriter, testReq)
+	})
+}
+
+func TestPutCategoryAttributes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PutCategory 400 on duplicate attributes", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.AddCategoryRequest{
+			Category: ds.Category{
+				Name: "Lamps",
+				Attributes: []ds.AttributeDefinition{
+					{Name: "voltage", Type: ds.AttributeTypeNumber},
+					{Name: "voltage", Type: ds.AttributeTypeInteger},
+				},
+			},
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodPost, prefixCategory, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.PutCategory(a.responseWriter, testReq)
+	})
+
+	t.Run("PutCategory 400 on unknown attribute type", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.AddCategoryRequest{
+			Category: ds.Category{
+				Name:       "Lamps",
+				Attributes: []ds.AttributeDefinition{{Name: "voltage", Type: "float"}},
+			},
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodPost, prefixCategory, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.PutCategory(a.responseWriter, testReq)
+	})
+}
@@ -8,16 +8,18 @@ import (
 const (
 	prefixClient        = apiPrefix + "/client"
 	prefixClientAddress = prefixClient + "/address"
+	prefixClientRestore = prefixClient + "/restore"
 	prefixClients       = apiPrefix + "/clients"
 	prefixClientsByName = prefixClients + "/named"
 )
 
 func (a *API) setupClientsHandlers(router IRouter) {
-	router.HandleFunc(pattern(http.MethodPost, prefixClient), a.PutClient)
-	router.HandleFunc(pattern(http.MethodDelete, prefixClient), a.DeleteClient)
-	router.HandleFunc(pattern(http.MethodGet, prefixClients), a.GetClients)
-	router.HandleFunc(pattern(http.MethodGet, prefixClientsByName), a.GetClientsByName)
-	router.HandleFunc(pattern(http.MethodPatch, prefixClientAddress), a.PatchClientAddress)
+	router.HandleFunc(pattern(http.MethodPost, prefixClient), a.authorize(ds.PermissionClientsWrite, a.PutClient))
+	router.HandleFunc(pattern(http.MethodDelete, prefixClient), a.authorize(ds.PermissionClientsWrite, a.DeleteClient))
+	router.HandleFunc(pattern(http.MethodPost, prefixClientRestore), a.authorize(ds.PermissionClientsWrite, a.RestoreClient))
+	router.HandleFunc(pattern(http.MethodGet, prefixClients), a.authorize(ds.PermissionClientsRead, a.GetClients))
+	router.HandleFunc(pattern(http.MethodGet, prefixClientsByName), a.authorize(ds.PermissionClientsRead, a.GetClientsByName))
+	router.HandleFunc(pattern(http.MethodPatch, prefixClientAddress), a.authorize(ds.PermissionClientsWrite, a.PatchClientAddress))
 }
 
 // PutClient Добавляет нового клиента
@@ -29,7 +31,12 @@ func (a *API) setupClientsHandlers(router IRouter) {
 // @Param        input body      ds.AddClientRequest  true "Информация о клиенте"
 // @Success      200   {object}  ds.AddClientResponse
 // @Failure      400   {object}  ds.Status
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
 // @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /client [post]
 func (a *API) PutClient(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.AddClientRequest, ds.AddClientResponse]{
@@ -44,14 +51,20 @@ func (a *API) PutClient(w http.ResponseWriter, r *http.Request) {
 
 // DeleteClient удаляет клиента
 // @Summary      Удаление клиента
-// @Description  Удаляет клиента по его uid
+// @Description  Удаляет клиента по его uid. Клиент переносится в корзину и удаляется окончательно
+// @Description  по истечении срока хранения, до этого его можно восстановить.
 // @Tags         Client
 // @Accept       json
 // @Produce      json
 // @Param        input body      ds.DeleteClientRequest  true "uid клиента"
 // @Success      200   {object}  ds.DeleteClientResponse
 // @Failure      400   {object}  ds.DeleteClientResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
 // @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /client [delete]
 func (a *API) DeleteClient(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.DeleteClientRequest, ds.DeleteClientResponse]{
@@ -64,18 +77,55 @@ func (a *API) DeleteClient(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RestoreClient восстанавливает клиента
+// @Summary      Восстановление клиента
+// @Description  Восстанавливает удаленного клиента из корзины. Восстановление не удаленного клиента тоже успешно.
+// @Tags         Client
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.RestoreClientRequest  true "uid клиента"
+// @Success      200   {object}  ds.RestoreClientResponse
+// @Failure      400   {object}  ds.RestoreClientResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /client/restore [post]
+func (a *API) RestoreClient(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.RestoreClientRequest, ds.RestoreClientResponse]{
+		httpRequest:      r,
+		httpResponse:     &w,
+		api:              a,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.clientService.RestoreClient,
+	})
+}
+
 // GetClientsByName возвращает клиентов по имени и фамилии
-// @Summary      Возвращает клиентов по имени и фамилии
-// @Description  Возвращает клиентов по имени и фамилии
+// @Summary      Нечеткий поиск клиентов по имени и фамилии
+// @Description  Нечеткий поиск клиентов по имени и фамилии (pg_trgm). Клиенты отсортированы по убыванию score. С transliterate=true ищет также в транслитерации кириллица/латиница. Если offset limit равны 0, вернет всех найденных клиентов
 // @Tags         Client
 // @Accept       json
 // @Produce      json
 // @Param        client_name    query  string false "client_name" example(Vasilisa)
 // @Param        client_surname query  string false "client_surname" example(Kadyk)
+// @Param        transliterate  query  string false "transliterate" example(true)
+// @Param        min_score      query  string false "min_score" example(0.3)
+// @Param        offset         query  string false "offset" example(0)
+// @Param        limit          query  string false "limit" example(10)
+// @Param        include_deleted query string false "include_deleted" example(true)
 // @Param        avoid_cache    query  string false "avoid_cache" example(true)
 // @Success      200  {object}  ds.GetClientsByNameResponse
 // @Failure      400  {object}  ds.Status
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
 // @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /clients/named [get]
 func (a *API) GetClientsByName(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.GetClientsByNameRequest, ds.GetClientsByNameResponse]{
@@ -90,15 +140,24 @@ func (a *API) GetClientsByName(w http.ResponseWriter, r *http.Request) {
 
 // GetClients возвращает список клиентов
 // @Summary      Возвращает список клиентов
-// @Description  Возвращает список клиентов. Если offset limit равны 0, вернет список всех клиентов
+// @Description  Возвращает список клиентов. Если offset limit равны 0, вернет список всех клиентов.
+// @Description  С include_deleted=true вернет также клиентов из корзины.
+// @Description  С as_of вернет клиентов такими, какими они были в этот момент.
 // @Tags         Client
 // @Produce      json
-// @Param        offset       query  string true  "offset"      example(0)
-// @Param        limit        query  string true  "limit"       example(10)
-// @Param        avoid_cache  query  string false "avoid_cache" example(true)
+// @Param        offset          query  string true  "offset"          example(0)
+// @Param        limit           query  string true  "limit"           example(10)
+// @Param        include_deleted query  string false "include_deleted" example(true)
+// @Param        avoid_cache     query  string false "avoid_cache"     example(true)
+// @Param        as_of           query  string  false "Момент времени, RFC 3339" example("2025-01-01T00:00:00Z")
 // @Success      200  {object}  ds.GetClientsResponse
 // @Failure      400  {object}  ds.Status
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
 // @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /clients [get]
 func (a *API) GetClients(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.GetClientsRequest, ds.GetClientsResponse]{
@@ -120,7 +179,12 @@ func (a *API) GetClients(w http.ResponseWriter, r *http.Request) {
 // @Param        input body     ds.PatchClientAddressRequest  true "uid и адрес"
 // @Success      200   {object} ds.PatchClientAddressResponse
 // @Failure      400   {object} ds.PatchClientAddressResponse
+// @Failure      401   {object} ds.Status
+// @Failure      403   {object} ds.ForbiddenResponse
+// @Failure      429   {object} ds.Status
 // @Failure      500   {object} ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /client/address [patch]
 func (a *API) PatchClientAddress(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.PatchClientAddressRequest, ds.PatchClientAddressResponse]{
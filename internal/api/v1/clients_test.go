@@ -228,6 +228,80 @@ func TestDeleteClient(t *testing.T) {
 	})
 }
 
+func TestRestoreClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RestoreClient 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.RestoreClientRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixClientRestore, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.RestoreClientResponse{}
+
+		a.clientMock.EXPECT().RestoreClient(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.RestoreClient(a.responseWriter, apiReq)
+	})
+
+	t.Run("RestoreClient 404", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.RestoreClientRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixClientRestore, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.RestoreClientResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}
+
+		a.clientMock.EXPECT().RestoreClient(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.RestoreClient(a.responseWriter, apiReq)
+	})
+}
+
 func TestGetClientsByName(t *testing.T) {
 	t.Parallel()
 
@@ -261,7 +335,7 @@ func TestGetClientsByName(t *testing.T) {
 		testReq.URL.RawQuery = q.Encode()
 
 		resp := &ds.GetClientsByNameResponse{
-			Clients: []ds.Client{clientStruct},
+			Clients: []ds.ClientMatch{{Client: clientStruct, Score: 1}},
 		}
 
 		a.clientMock.EXPECT().GetClientsByName(&ds.GetClientsByNameRequest{
@@ -298,7 +372,7 @@ func TestGetClientsByName(t *testing.T) {
 		testReq.URL.RawQuery = q.Encode()
 
 		resp := &ds.GetClientsByNameResponse{
-			Clients: []ds.Client{},
+			Clients: []ds.ClientMatch{},
 		}
 
 		var buf bytes.Buffer
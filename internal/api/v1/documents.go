@@ -0,0 +1,141 @@
+package api
+
+import (
+	"net/http"
+	ds "shopapi/internal/datastruct"
+)
+
+const (
+	prefixProductDocument  = prefixProduct + "/document"
+	prefixProductDocuments = prefixProduct + "/documents"
+)
+
+func (a *API) setupDocumentsHandlers(router IRouter) {
+	router.HandleFunc(pattern(http.MethodPost, prefixProductDocument), a.authorize(ds.PermissionProductsWrite, a.PutProductDocument))
+	router.HandleFunc(pattern(http.MethodGet, prefixProductDocument), a.authorize(ds.PermissionProductsRead, a.GetProductDocument))
+	router.HandleFunc(pattern(http.MethodGet, prefixProductDocuments), a.authorize(ds.PermissionProductsRead, a.GetProductDocuments))
+	router.HandleFunc(pattern(http.MethodDelete, prefixProductDocument), a.authorize(ds.PermissionProductsWrite, a.DeleteProductDocument))
+}
+
+// PutProductDocument добавляет документ продукта
+// @Summary      Добавляет документ продукта
+// @Description  Добавляет документ продукта, например спецификацию в pdf. Файл передаётся потоком, поэтому поля формы должны идти перед ним. Размер ограничен настройкой max_image_size.
+// @Description  Допустимые типы файлов задаются настройкой upload_types. Перед сохранением файл проверяется сканерами (сигнатура формата, активное содержимое pdf, антивирус clamd), отклонённый файл помещается в карантин и возвращается 400.
+// @Tags         Product
+// @Accept       mpfd
+// @Produce      json
+// @Param        uid            formData  string  false "uid"              example("8a3c1e4f-2b7d-4c5e-9f1a-6d2e8b0c4a17")
+// @Param        product_id     formData  string  true  "product_id"       example("c85a189d-d173-42e2-8e00-54395234d93d")
+// @Param        title          formData  string  true  "название"         example("Oak beam spec sheet")
+// @Param        document       formData  file    true  "Файл документа"
+// @Param        avoid_cache    query     string  false "avoid_cache"      example(true)
+// @Success      200   {object}  ds.AddProductDocumentResponse
+// @Failure      400   {object}  ds.AddProductDocumentResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      413   {object}  ds.AddProductDocumentResponse
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/document [post]
+func (a *API) PutProductDocument(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.AddProductDocumentRequest, ds.AddProductDocumentResponse]{
+		httpRequest:      r,
+		httpResponse:     &w,
+		api:              a,
+		requestExtractor: a.extractMultipartWithFile,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.AddProductDocument,
+	})
+}
+
+// GetProductDocument возвращает документ продукта
+// @Summary      Возвращает документ продукта
+// @Description  Возвращает файл документа продукта.
+// @Description  Отдаёт ETag и Last-Modified: по If-None-Match или If-Modified-Since отвечает 304, по Range отдаёт один диапазон байт.
+// @Tags         Product
+// @Produce      application/pdf
+// @Param        uid            query     string true  "uid"         example("8a3c1e4f-2b7d-4c5e-9f1a-6d2e8b0c4a17")
+// @Param        inline         query     bool   false "показать в браузере вместо скачивания" example(true)
+// @Param        avoid_cache    query     string false "avoid_cache" example(true)
+// @Param        If-None-Match  header    string false "ETag полученного ранее файла"
+// @Param        Range          header    string false "диапазон байт" example(bytes=0-1023)
+// @Success      200  {file}    binary
+// @Header       200  {string}  ETag           "хеш содержимого"
+// @Header       200  {string}  Last-Modified  "время добавления"
+// @Success      206  {file}    binary
+// @Success      304  "не изменилось"
+// @Failure      400  {object}  ds.GetProductDocumentResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      416  "диапазон вне файла"
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/document [get]
+func (a *API) GetProductDocument(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetProductDocumentRequest, ds.GetProductDocumentResponse]{
+		httpRequest:      r,
+		httpResponse:     &w,
+		api:              a,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeFileResponse,
+		serviceFunc:      a.productService.GetProductDocument,
+	})
+}
+
+// GetProductDocuments возвращает список документов продукта
+// @Summary      Возвращает список документов продукта
+// @Description  Возвращает документы продукта в порядке добавления: название, тип, размер и хеш файла.
+// @Tags         Product
+// @Produce      json
+// @Param        product_id     query     string true  "product_id"  example("c85a189d-d173-42e2-8e00-54395234d93d")
+// @Param        avoid_cache    query     string false "avoid_cache" example(true)
+// @Success      200  {object}  ds.GetProductDocumentsResponse
+// @Failure      400  {object}  ds.GetProductDocumentsResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/documents [get]
+func (a *API) GetProductDocuments(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetProductDocumentsRequest, ds.GetProductDocumentsResponse]{
+		httpRequest:      r,
+		httpResponse:     &w,
+		api:              a,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.GetProductDocuments,
+	})
+}
+
+// DeleteProductDocument удаляет документ продукта
+// @Summary      удаляет документ продукта
+// @Description  удаляет документ продукта
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.DeleteProductDocumentRequest  true "uid"
+// @Success      200   {object}  ds.DeleteProductDocumentResponse
+// @Failure      400   {object}  ds.DeleteProductDocumentResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/document [delete]
+func (a *API) DeleteProductDocument(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.DeleteProductDocumentRequest, ds.DeleteProductDocumentResponse]{
+		httpRequest:      r,
+		httpResponse:     &w,
+		api:              a,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.DeleteProductDocument,
+	})
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/supports"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var testPDF = []byte("%PDF-1.7\n1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n%%EOF\n")
+
+func newDocumentUpload(t *testing.T, productUid uuid.UUID, fileName string, content []byte) *http.Request {
+	buff := &bytes.Buffer{}
+
+	writer := multipart.NewWriter(buff)
+
+	require.Nil(t, writer.WriteField("product_id", productUid.String()))
+	require.Nil(t, writer.WriteField("title", "Spec sheet"))
+
+	part, err := writer.CreateFormFile("document", fileName)
+	require.Nil(t, err)
+
+	_, err = part.Write(content)
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, prefixProductDocument, buff)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req
+}
+
+func TestPutProductDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PutProductDocument 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		productUid := uuid.New()
+		uid := uuid.New()
+		resp := &ds.AddProductDocumentResponse{Uid: &uid}
+
+		var buf bytes.Buffer
+		require.Nil(t, json.NewEncoder(&buf).Encode(resp))
+
+		a.productMock.EXPECT().AddProductDocument(gomock.Any()).DoAndReturn(func(req *ds.AddProductDocumentRequest) *ds.AddProductDocumentResponse {
+			require.Equal(t, productUid, req.ProductUid)
+			require.Equal(t, "Spec sheet", req.Title)
+			require.Equal(t, testPDF, readAll(t, req.Document))
+			return resp
+		})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.PutProductDocument(a.responseWriter, newDocumentUpload(t, productUid, "spec.pdf", testPDF))
+	})
+
+	t.Run("PutProductDocument 400 on image", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.PutProductDocument(a.responseWriter, newDocumentUpload(t, uuid.New(), "spec.pdf", supports.TestImage))
+	})
+
+	t.Run("PutProductDocument 400 on pdf action", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		pdf := append(bytes.Clone(testPDF), "2 0 obj << /S /JavaScript /JS (app.alert(1)) >> endobj\n"...)
+
+		a.productMock.EXPECT().AddProductDocument(gomock.Any()).DoAndReturn(func(req *ds.AddProductDocumentRequest) *ds.AddProductDocumentResponse {
+			_, err := io.ReadAll(req.Document)
+			require.ErrorIs(t, err, supports.ErrRejected)
+			return &ds.AddProductDocumentResponse{Status: ds.Status{Message: ds.StatusRejected}}
+		})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.PutProductDocument(a.responseWriter, newDocumentUpload(t, uuid.New(), "spec.pdf", pdf))
+	})
+}
+
+func TestGetProductDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductDocument 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		uid := uuid.New()
+		testReq := httptest.NewRequest(http.MethodGet, prefixProductDocument+"?uid="+uid.String(), nil)
+
+		resp := &ds.GetProductDocumentResponse{
+			Uid: &uid,
+			DocumentContent: ds.DocumentContent{
+				Content: io.NopCloser(bytes.NewReader(testPDF)),
+				Size:    int64(len(testPDF)),
+				Hash:    "hash",
+			},
+		}
+
+		header := http.Header{}
+		written := &bytes.Buffer{}
+
+		a.productMock.EXPECT().GetProductDocument(gomock.Any()).Return(resp)
+		a.responseWriter.EXPECT().Header().Return(header).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any()).DoAndReturn(written.Write).MinTimes(1)
+
+		a.api.GetProductDocument(a.responseWriter, testReq)
+
+		require.Equal(t, testPDF, written.Bytes())
+		require.Equal(t, strconv.Itoa(len(testPDF)), header.Get(contentLenKey))
+		require.Equal(t, "application/pdf", header.Get(contentTypeKey))
+		require.Equal(t, `"hash"`, header.Get(etagKey))
+		require.Regexp(t, `^attachment; filename=".+\.pdf"$`, header.Get(contentDispositionKey))
+	})
+
+	t.Run("GetProductDocument 404", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		testReq := httptest.NewRequest(http.MethodGet, prefixProductDocument+"?uid="+uuid.NewString(), nil)
+
+		a.productMock.EXPECT().GetProductDocument(gomock.Any()).Return(&ds.GetProductDocumentResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetProductDocument(a.responseWriter, testReq)
+	})
+}
+
+func TestGetProductDocuments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductDocuments 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		productUid := uuid.New()
+		testReq := httptest.NewRequest(http.MethodGet, prefixProductDocuments+"?product_id="+productUid.String(), nil)
+
+		a.productMock.EXPECT().GetProductDocuments(&ds.GetProductDocumentsRequest{ProductUid: productUid}).
+			Return(&ds.GetProductDocumentsResponse{Documents: []ds.ProductDocument{{Uid: uuid.New(), Title: "Spec sheet"}}})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetProductDocuments(a.responseWriter, testReq)
+	})
+
+	t.Run("GetProductDocuments 400", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		testReq := httptest.NewRequest(http.MethodGet, prefixProductDocuments, nil)
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetProductDocuments(a.responseWriter, testReq)
+	})
+}
+
+func TestDeleteProductDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteProductDocument 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.DeleteProductDocumentRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		require.Nil(t, err)
+
+		testReq := httptest.NewRequest(http.MethodDelete, prefixProductDocument, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.productMock.EXPECT().DeleteProductDocument(reqStruct).Return(&ds.DeleteProductDocumentResponse{Status: ds.Status{Message: ds.StatusOK}})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.DeleteProductDocument(a.responseWriter, testReq)
+	})
+}
@@ -0,0 +1,288 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	mimeManager "shopapi/internal/mime-manager"
+	"shopapi/internal/supports"
+)
+
+const (
+	inlineParam       = "inline"
+	dispositionInline = "inline"
+	dispositionFile   = "attachment"
+	bytesUnit         = "bytes"
+
+	contentTypeOptionsKey    = "X-Content-Type-Options"
+	contentSecurityPolicyKey = "Content-Security-Policy"
+	noSniffValue             = "nosniff"
+	// The served files are never run as pages, even an SVG opened by its link.
+	fileSecurityPolicy = "default-src 'none'; img-src data:; style-src 'unsafe-inline'; sandbox"
+)
+
+var errRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// byteRange is an inclusive range of the file bytes.
+type byteRange struct {
+	start int64
+	end   int64
+}
+
+func (r byteRange) length() int64 {
+	return r.end - r.start + 1
+}
+
+// writeFileResponse writes the file of the response, either loaded or streamed, or the response as json if there is no file.
+// The file is sent with validators, so clients revalidate it by a conditional request, and a single byte range is served.
+func writeFileResponse(w *http.ResponseWriter, r *http.Request, resp any) error {
+	value := reflect.ValueOf(resp)
+
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("expected struct but got '%s'", value.Kind().String())
+	}
+
+	formName, field, err := supports.GetStructFieldByTagKey(resp, "file")
+
+	if err != nil {
+		return err
+	}
+
+	if !supports.IsFieldByteSlice(field) {
+		return fmt.Errorf("field '%s' has not '[]byte' type", formName)
+	}
+
+	var hash string
+	var modified time.Time
+	if v, ok := resp.(IValidated); ok {
+		hash, modified = v.Validators()
+	}
+
+	data := field.Bytes()
+	if len(data) != 0 {
+		sum := sha256.Sum256(data)
+		return writeFile(w, r, resp, bytes.NewReader(data), int64(len(data)),
+			hex.EncodeToString(sum[:]), modified, formName)
+	}
+
+	if v, ok := resp.(IStreamed); ok {
+		if content, size := v.Stream(); content != nil {
+			defer content.Close()
+			return writeFile(w, r, resp, content, size, hash, modified, formName)
+		}
+	}
+
+	return writeJsonResponse(w, r, resp)
+}
+
+// writeFile copies the content to the response as it is read, the head is used to get the file type.
+// Zero size means unknown, so no Content-Length is sent and ranges are not served.
+func writeFile(w *http.ResponseWriter, r *http.Request, resp any, content io.Reader, size int64,
+	hash string, modified time.Time, fileType string) error {
+	header := (*w).Header()
+
+	etag := ""
+	if hash != "" {
+		etag = strconv.Quote(hash)
+		header.Set(etagKey, etag)
+	}
+	if !modified.IsZero() {
+		header.Set(lastModifiedKey, modified.UTC().Format(http.TimeFormat))
+	}
+	header.Set(contentCachingKey, appPublicRevalidate)
+
+	if isNotModified(r, etag, modified) {
+		(*w).WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	head, body, err := readHead(content)
+	if err != nil {
+		return err
+	}
+
+	disposition, err := getContentDisposition(r, resp, head, fileType)
+	if err != nil {
+		return err
+	}
+
+	header.Set(contentDispositionKey, disposition)
+	header.Set(contentTypeKey, mimeManager.DetectMimeType(head))
+	header.Set(contentTypeOptionsKey, noSniffValue)
+	header.Set(contentSecurityPolicyKey, fileSecurityPolicy)
+
+	if size <= 0 {
+		(*w).WriteHeader(http.StatusOK)
+		_, err = io.Copy(*w, body)
+		return err
+	}
+
+	header.Set(acceptRangesKey, bytesUnit)
+
+	rng, ok, err := getRange(r, etag, modified, size)
+	if errors.Is(err, errRangeNotSatisfiable) {
+		header.Set(contentRangeKey, supports.Concat(bytesUnit, " */", strconv.FormatInt(size, 10)))
+		(*w).WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return nil
+	}
+
+	if !ok {
+		header.Set(contentLenKey, strconv.FormatInt(size, 10))
+		(*w).WriteHeader(http.StatusOK)
+		_, err = io.Copy(*w, body)
+		return err
+	}
+
+	if _, err = io.CopyN(io.Discard, body, rng.start); err != nil {
+		return err
+	}
+
+	header.Set(contentLenKey, strconv.FormatInt(rng.length(), 10))
+	header.Set(contentRangeKey, fmt.Sprintf("%s %d-%d/%d", bytesUnit, rng.start, rng.end, size))
+	(*w).WriteHeader(http.StatusPartialContent)
+	_, err = io.CopyN(*w, body, rng.length())
+
+	return err
+}
+
+// readHead reads the head of the content to detect its type and returns the body to send from the start.
+func readHead(content io.Reader) ([]byte, io.Reader, error) {
+	head := make([]byte, mimeManager.SniffLen)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, nil, err
+	}
+	head = head[:n]
+
+	if s, ok := content.(io.Seeker); ok {
+		_, err = s.Seek(0, io.SeekStart)
+		return head, content, err
+	}
+
+	return head, io.MultiReader(bytes.NewReader(head), content), nil
+}
+
+// isNotModified checks the validators of a conditional request, If-None-Match takes precedence over If-Modified-Since.
+func isNotModified(r *http.Request, etag string, modified time.Time) bool {
+	if match := r.Header.Get(ifNoneMatchKey); match != "" {
+		return etag != "" && etagMatches(match, etag)
+	}
+
+	since := r.Header.Get(ifModifiedSinceKey)
+	if since == "" || modified.IsZero() {
+		return false
+	}
+
+	t, err := http.ParseTime(since)
+	if err != nil {
+		return false
+	}
+
+	// The header has the second precision only.
+	return !modified.Truncate(time.Second).After(t)
+}
+
+// etagMatches compares the list of If-None-Match tags weakly, as the header requires.
+func etagMatches(match, etag string) bool {
+	for _, tag := range strings.Split(match, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// getRange returns the single byte range requested, false means the whole file is sent.
+// A malformed or multiple range is ignored, as well as a range of the outdated If-Range.
+func getRange(r *http.Request, etag string, modified time.Time, size int64) (byteRange, bool, error) {
+	spec, ok := strings.CutPrefix(r.Header.Get(rangeKey), bytesUnit+"=")
+	if !ok || strings.Contains(spec, ",") {
+		return byteRange{}, false, nil
+	}
+
+	if ifRange := r.Header.Get(ifRangeKey); ifRange != "" {
+		t, err := http.ParseTime(ifRange)
+		switch {
+		case err == nil:
+			if modified.IsZero() || !modified.Truncate(time.Second).Equal(t) {
+				return byteRange{}, false, nil
+			}
+		case etag == "" || ifRange != etag:
+			return byteRange{}, false, nil
+		}
+	}
+
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return byteRange{}, false, nil
+	}
+
+	if first == "" {
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix < 0 {
+			return byteRange{}, false, nil
+		}
+		if suffix == 0 {
+			return byteRange{}, false, errRangeNotSatisfiable
+		}
+		return byteRange{start: max(0, size-suffix), end: size - 1}, true, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return byteRange{}, false, nil
+	}
+
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return byteRange{}, false, nil
+		}
+	}
+
+	if start >= size {
+		return byteRange{}, false, errRangeNotSatisfiable
+	}
+
+	return byteRange{start: start, end: min(end, size-1)}, true, nil
+}
+
+// getContentDisposition shows the file in the browser if the request asks it by the inline parameter,
+// otherwise the file is downloaded as an attachment.
+func getContentDisposition(r *http.Request, resp any, outData []byte, expectFileType string) (string, error) {
+	var fieldName string
+	_, field, err := supports.GetStructFieldByTagKey(resp, "asFileName")
+	if err == nil {
+		if v, ok := field.Interface().(fmt.Stringer); ok {
+			fieldName = v.String()
+		}
+	}
+
+	ext, err := mimeManager.GetFileExtension(outData, expectFileType)
+	if err != nil {
+		return "", err
+	}
+
+	disposition := dispositionFile
+	if inline, err := strconv.ParseBool(r.URL.Query().Get(inlineParam)); err == nil && inline {
+		disposition = dispositionInline
+	}
+
+	return supports.Concat(disposition, "; filename=\"file_", fieldName, "_",
+		supports.GetDateAsFileName(time.Now()), ext, "\""), nil
+}
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/supports"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newFileResponse(modified time.Time) *ds.GetImageResponse {
+	uid := uuid.New()
+	return &ds.GetImageResponse{
+		Uid:          &uid,
+		ImageContent: ds.ImageContent{Image: supports.TestImage, ModifiedAt: modified},
+	}
+}
+
+func testImageETag() string {
+	sum := sha256.Sum256(supports.TestImage)
+	return strconv.Quote(hex.EncodeToString(sum[:]))
+}
+
+func writeTestFile(t *testing.T, req *http.Request, resp any) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	var w http.ResponseWriter = rec
+	require.Nil(t, writeFileResponse(&w, req, resp))
+	return rec
+}
+
+func TestWriteFileResponse(t *testing.T) {
+	t.Parallel()
+
+	// The allowed file types are registered by the API.
+	NewTestApi(context.Background(), t)
+
+	modified := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	size := len(supports.TestImage)
+
+	t.Run("WriteFileResponse 200", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+		rec := writeTestFile(t, req, newFileResponse(modified))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, supports.TestImage, rec.Body.Bytes())
+		require.Equal(t, testImageETag(), rec.Header().Get(etagKey))
+		require.Equal(t, modified.Format(http.TimeFormat), rec.Header().Get(lastModifiedKey))
+		require.Equal(t, appPublicRevalidate, rec.Header().Get(contentCachingKey))
+		require.Equal(t, "image/jpeg", rec.Header().Get(contentTypeKey))
+		require.Equal(t, strconv.Itoa(size), rec.Header().Get(contentLenKey))
+		require.Equal(t, noSniffValue, rec.Header().Get(contentTypeOptionsKey))
+		require.Equal(t, fileSecurityPolicy, rec.Header().Get(contentSecurityPolicyKey))
+		require.Equal(t, bytesUnit, rec.Header().Get(acceptRangesKey))
+		require.Regexp(t, `^attachment; filename=".+\.jpeg"$`, rec.Header().Get(contentDispositionKey))
+	})
+
+	t.Run("WriteFileResponse inline 200", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, prefixImage+"?inline=true", nil)
+		rec := writeTestFile(t, req, newFileResponse(modified))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Regexp(t, `^inline; filename=".+\.jpeg"$`, rec.Header().Get(contentDispositionKey))
+	})
+
+	t.Run("WriteFileResponse streamed 200", func(t *testing.T) {
+		t.Parallel()
+
+		resp := newFileResponse(modified)
+		resp.Image = nil
+		resp.Content = io.NopCloser(&onlyReader{data: supports.TestImage})
+		resp.Size = int64(size)
+		resp.Hash = "stored"
+
+		req := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+		rec := writeTestFile(t, req, resp)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, supports.TestImage, rec.Body.Bytes())
+		require.Equal(t, `"stored"`, rec.Header().Get(etagKey))
+		require.Equal(t, "image/jpeg", rec.Header().Get(contentTypeKey))
+	})
+
+	t.Run("WriteFileResponse 304 on If-None-Match", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tag := range []string{testImageETag(), "W/" + testImageETag(), `"other", ` + testImageETag(), "*"} {
+			req := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+			req.Header.Set(ifNoneMatchKey, tag)
+			rec := writeTestFile(t, req, newFileResponse(modified))
+
+			require.Equal(t, http.StatusNotModified, rec.Code, tag)
+			require.Empty(t, rec.Body.Bytes())
+			require.Equal(t, testImageETag(), rec.Header().Get(etagKey))
+		}
+	})
+
+	t.Run("WriteFileResponse 200 on other If-None-Match", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+		req.Header.Set(ifNoneMatchKey, `"other"`)
+		req.Header.Set(ifModifiedSinceKey, modified.Format(http.TimeFormat))
+		rec := writeTestFile(t, req, newFileResponse(modified))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("WriteFileResponse 304 on If-Modified-Since", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+		req.Header.Set(ifModifiedSinceKey, modified.Format(http.TimeFormat))
+		rec := writeTestFile(t, req, newFileResponse(modified.Add(time.Millisecond)))
+
+		require.Equal(t, http.StatusNotModified, rec.Code)
+
+		req.Header.Set(ifModifiedSinceKey, modified.Add(-time.Second).Format(http.TimeFormat))
+		rec = writeTestFile(t, req, newFileResponse(modified))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("WriteFileResponse 206", func(t *testing.T) {
+		t.Parallel()
+
+		cases := []struct {
+			spec       string
+			start, end int
+		}{
+			{spec: "bytes=0-9", start: 0, end: 9},
+			{spec: "bytes=100-", start: 100, end: size - 1},
+			{spec: "bytes=-10", start: size - 10, end: size - 1},
+			{spec: fmt.Sprintf("bytes=10-%d", size+100), start: 10, end: size - 1},
+		}
+
+		for _, c := range cases {
+			resp := newFileResponse(modified)
+			resp.Image = nil
+			resp.Content = io.NopCloser(&onlyReader{data: supports.TestImage})
+			resp.Size = int64(size)
+
+			req := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+			req.Header.Set(rangeKey, c.spec)
+			rec := writeTestFile(t, req, resp)
+
+			require.Equal(t, http.StatusPartialContent, rec.Code, c.spec)
+			require.Equal(t, supports.TestImage[c.start:c.end+1], rec.Body.Bytes(), c.spec)
+			require.Equal(t, fmt.Sprintf("bytes %d-%d/%d", c.start, c.end, size), rec.Header().Get(contentRangeKey))
+			require.Equal(t, strconv.Itoa(c.end-c.start+1), rec.Header().Get(contentLenKey))
+		}
+	})
+
+	t.Run("WriteFileResponse 200 on ignored range", func(t *testing.T) {
+		t.Parallel()
+
+		cases := []struct {
+			spec    string
+			ifRange string
+		}{
+			{spec: "bytes=0-1,5-6"},
+			{spec: "bytes=5-1"},
+			{spec: "items=0-1"},
+			{spec: "bytes=0-1", ifRange: `"other"`},
+			{spec: "bytes=0-1", ifRange: modified.Add(-time.Hour).Format(http.TimeFormat)},
+		}
+
+		for _, c := range cases {
+			req := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+			req.Header.Set(rangeKey, c.spec)
+			if c.ifRange != "" {
+				req.Header.Set(ifRangeKey, c.ifRange)
+			}
+			rec := writeTestFile(t, req, newFileResponse(modified))
+
+			require.Equal(t, http.StatusOK, rec.Code, c.spec)
+			require.Equal(t, supports.TestImage, rec.Body.Bytes())
+		}
+	})
+
+	t.Run("WriteFileResponse 206 on If-Range", func(t *testing.T) {
+		t.Parallel()
+
+		for _, ifRange := range []string{testImageETag(), modified.Format(http.TimeFormat)} {
+			req := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+			req.Header.Set(rangeKey, "bytes=0-1")
+			req.Header.Set(ifRangeKey, ifRange)
+			rec := writeTestFile(t, req, newFileResponse(modified))
+
+			require.Equal(t, http.StatusPartialContent, rec.Code, ifRange)
+			require.Equal(t, supports.TestImage[:2], rec.Body.Bytes())
+		}
+	})
+
+	t.Run("WriteFileResponse 416", func(t *testing.T) {
+		t.Parallel()
+
+		for _, spec := range []string{fmt.Sprintf("bytes=%d-", size), "bytes=-0"} {
+			req := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+			req.Header.Set(rangeKey, spec)
+			rec := writeTestFile(t, req, newFileResponse(modified))
+
+			require.Equal(t, http.StatusRequestedRangeNotSatisfiable, rec.Code, spec)
+			require.Equal(t, fmt.Sprintf("bytes */%d", size), rec.Header().Get(contentRangeKey))
+			require.Empty(t, rec.Body.Bytes())
+		}
+	})
+
+	t.Run("WriteFileResponse json without file", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+		rec := writeTestFile(t, req, &ds.GetImageResponse{Status: ds.Status{Message: ds.StatusNotFound}})
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.Equal(t, appJSONValue, rec.Header().Get(contentTypeKey))
+		require.Empty(t, rec.Header().Get(etagKey))
+	})
+}
+
+// onlyReader hides the other interfaces of the reader, as the content streamed from storage.
+type onlyReader struct {
+	data []byte
+}
+
+func (r *onlyReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"net/http"
+	ds "shopapi/internal/datastruct"
+)
+
+const (
+	prefixProductHistory  = prefixProduct + "/history"
+	prefixSupplierHistory = prefixSupplier + "/history"
+	prefixClientHistory   = prefixClient + "/history"
+)
+
+func (a *API) setupHistoryHandlers(router IRouter) {
+	router.HandleFunc(pattern(http.MethodGet, prefixProductHistory), a.authorize(ds.PermissionProductsRead, a.GetProductHistory))
+	router.HandleFunc(pattern(http.MethodGet, prefixSupplierHistory), a.authorize(ds.PermissionSuppliersRead, a.GetSupplierHistory))
+	router.HandleFunc(pattern(http.MethodGet, prefixClientHistory), a.authorize(ds.PermissionClientsRead, a.GetClientHistory))
+}
+
+// GetProductHistory возвращает версии продукта
+// @Summary      История продукта
+// @Description  Возвращает версии продукта, новые первыми. Версия - состояние продукта вместе с вариантами и изображениями
+// @Description  с valid_from до valid_to, у текущей версии valid_to нет. Без limit возвращается не больше 1000 версий.
+// @Tags         Product
+// @Produce      json
+// @Param        uid     query  string  true  "uid"    example("c85a189d-d173-42e2-8e00-54395234d93d")
+// @Param        limit   query  int     false "Количество версий"
+// @Param        offset  query  int     false "Смещение"
+// @Success      200  {object}  ds.GetProductHistoryResponse
+// @Failure      400  {object}  ds.GetProductHistoryResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/history [get]
+func (a *API) GetProductHistory(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetHistoryRequest, ds.GetProductHistoryResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.GetProductHistory,
+	})
+}
+
+// GetSupplierHistory возвращает версии поставщика
+// @Summary      История поставщика
+// @Description  Возвращает версии поставщика, новые первыми. Адрес поставщика меняется вместе с его версией.
+// @Description  Без limit возвращается не больше 1000 версий.
+// @Tags         Supplier
+// @Produce      json
+// @Param        uid     query  string  true  "uid"    example("609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4")
+// @Param        limit   query  int     false "Количество версий"
+// @Param        offset  query  int     false "Смещение"
+// @Success      200  {object}  ds.GetSupplierHistoryResponse
+// @Failure      400  {object}  ds.GetSupplierHistoryResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /supplier/history [get]
+func (a *API) GetSupplierHistory(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetHistoryRequest, ds.GetSupplierHistoryResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.supplierService.GetSupplierHistory,
+	})
+}
+
+// GetClientHistory возвращает версии клиента
+// @Summary      История клиента
+// @Description  Возвращает версии клиента, новые первыми. Без limit возвращается не больше 1000 версий.
+// @Tags         Client
+// @Produce      json
+// @Param        uid     query  string  true  "uid"    example("4988150e-1c82-490f-8c07-ee74ace2dd14")
+// @Param        limit   query  int     false "Количество версий"
+// @Param        offset  query  int     false "Смещение"
+// @Success      200  {object}  ds.GetClientHistoryResponse
+// @Failure      400  {object}  ds.GetClientHistoryResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /client/history [get]
+func (a *API) GetClientHistory(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetHistoryRequest, ds.GetClientHistoryResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.clientService.GetClientHistory,
+	})
+}
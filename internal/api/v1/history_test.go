@@ -0,0 +1,124 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ds "shopapi/internal/datastruct"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductHistory 200", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		uid := uuid.New()
+		apiReq := httptest.NewRequest(http.MethodGet, prefixProductHistory+"?uid="+uid.String()+"&limit=2", nil)
+
+		validTo := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		resp := &ds.GetProductHistoryResponse{Versions: []ds.ProductVersion{
+			{
+				Version: ds.Version{Version: 2, ValidFrom: validTo},
+				Product: ds.Product{Uid: uid, Name: "new"},
+			},
+			{
+				Version: ds.Version{Version: 1, ValidFrom: validTo.AddDate(0, -1, 0), ValidTo: &validTo},
+				Product: ds.Product{Uid: uid, Name: "old"},
+			},
+		}}
+
+		a.productMock.EXPECT().GetProductHistory(&ds.GetHistoryRequest{Uid: uid, Limit: 2}).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.GetProductHistory(a.responseWriter, apiReq)
+	})
+
+	t.Run("GetSupplierHistory 404", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		uid := uuid.New()
+		apiReq := httptest.NewRequest(http.MethodGet, prefixSupplierHistory+"?uid="+uid.String(), nil)
+
+		a.supplierMock.EXPECT().GetSupplierHistory(&ds.GetHistoryRequest{Uid: uid}).Return(&ds.GetSupplierHistoryResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		})
+
+		w := httptest.NewRecorder()
+		a.api.GetSupplierHistory(w, apiReq)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("GetClientHistory without uid", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixClientHistory+"?limit=2000", nil)
+
+		w := httptest.NewRecorder()
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+
+		a.api.GetClientHistory(w, apiReq)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetAsOf(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProduct as of", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		uid := uuid.New()
+		apiReq := httptest.NewRequest(http.MethodGet, prefixProduct+"?uid="+uid.String()+"&as_of=2025-01-01T03:00:00%2B03:00", nil)
+
+		asOf := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		a.productMock.EXPECT().GetProduct(gomock.Any()).DoAndReturn(func(req *ds.GetProductRequest) *ds.GetProductResponse {
+			require.NotNil(t, req.AsOf)
+			require.True(t, asOf.Equal(*req.AsOf))
+			return &ds.GetProductResponse{Product: &ds.Product{Uid: uid}}
+		})
+
+		w := httptest.NewRecorder()
+		a.api.GetProduct(w, apiReq)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("GetClients as of malformed", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixClients+"?as_of=yesterday", nil)
+
+		w := httptest.NewRecorder()
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+
+		a.api.GetClients(w, apiReq)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
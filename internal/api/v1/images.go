@@ -6,21 +6,32 @@ import (
 )
 
 const (
-	prefixImage        = apiPrefix + "/image"
-	prefixImageProduct = prefixImage + "/product"
+	prefixImage             = apiPrefix + "/image"
+	prefixImageProduct      = prefixImage + "/product"
+	prefixImageProductOrder = prefixImageProduct + "/order"
+	prefixImageMeta         = prefixImage + "/meta"
+	prefixImageRestore      = prefixImage + "/restore"
 )
 
 func (a *API) setupImagesHandlers(router IRouter) {
-	router.HandleFunc(pattern(http.MethodPost, prefixImage), a.PutImage)
-	router.HandleFunc(pattern(http.MethodPatch, prefixImage), a.UpdateImage)
-	router.HandleFunc(pattern(http.MethodGet, prefixImageProduct), a.GetProductImage)
-	router.HandleFunc(pattern(http.MethodGet, prefixImage), a.GetImage)
-	router.HandleFunc(pattern(http.MethodDelete, prefixImage), a.DeleteImage)
+	router.HandleFunc(pattern(http.MethodPost, prefixImage), a.authorize(ds.PermissionImagesWrite, a.PutImage))
+	router.HandleFunc(pattern(http.MethodPatch, prefixImage), a.authorize(ds.PermissionImagesWrite, a.UpdateImage))
+	router.HandleFunc(pattern(http.MethodGet, prefixImageProduct), a.authorize(ds.PermissionImagesRead, a.GetProductImage))
+	router.HandleFunc(pattern(http.MethodGet, prefixImage), a.authorize(ds.PermissionImagesRead, a.GetImage))
+	router.HandleFunc(pattern(http.MethodGet, prefixImageMeta), a.authorize(ds.PermissionImagesRead, a.GetImageMeta))
+	router.HandleFunc(pattern(http.MethodDelete, prefixImage), a.authorize(ds.PermissionImagesWrite, a.DeleteImage))
+	router.HandleFunc(pattern(http.MethodPost, prefixImageRestore), a.authorize(ds.PermissionImagesWrite, a.RestoreImage))
+	router.HandleFunc(pattern(http.MethodPost, prefixImageProduct), a.authorize(ds.PermissionImagesWrite, a.AttachProductImage))
+	router.HandleFunc(pattern(http.MethodDelete, prefixImageProduct), a.authorize(ds.PermissionImagesWrite, a.DetachProductImage))
+	router.HandleFunc(pattern(http.MethodPut, prefixImageProductOrder), a.authorize(ds.PermissionImagesWrite, a.ReorderProductImages))
 }
 
 // PutImage добавляет новое изображение
 // @Summary      Добавляет новое изображение
-// @Description  добавляет новое изображение
+// @Description  добавляет новое изображение. Файл передаётся потоком, поэтому uid должен идти в форме перед ним. Размер ограничен настройкой max_image_size.
+// @Description  Допустимые форматы задаются настройкой upload_types: по умолчанию jpeg, png, webp, gif, avif, heic и svg.
+// @Description  Метаданные (EXIF, XMP, комментарии) удаляются, кроме ориентации jpeg. Из svg удаляются скрипты, обработчики событий и встроенные документы. Файл, который не декодируется как изображение, отклоняется с 400.
+// @Description  Перед сохранением файл проверяется сканерами (сигнатура формата, число пикселей, вложенная разметка или архив, антивирус clamd), отклонённый файл помещается в карантин и возвращается 400.
 // @Tags         Image
 // @Accept       mpfd
 // @Produce      json
@@ -29,14 +40,20 @@ func (a *API) setupImagesHandlers(router IRouter) {
 // @Param        avoid_cache    query     string  false "avoid_cache"      example(true)
 // @Success      200   {object}  ds.AddImageResponse
 // @Failure      400   {object}  ds.AddImageResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      413   {object}  ds.AddImageResponse
 // @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /image [post]
 func (a *API) PutImage(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.AddImageRequest, ds.AddImageResponse]{
 		httpRequest:      r,
 		httpResponse:     &w,
 		api:              a,
-		requestExtractor: extractMultipartWithFile,
+		requestExtractor: a.extractMultipartWithFile,
 		responseWriter:   writeJsonResponse,
 		serviceFunc:      a.imageService.AddImage,
 	})
@@ -44,7 +61,10 @@ func (a *API) PutImage(w http.ResponseWriter, r *http.Request) {
 
 // UpdateImage обновление изображение
 // @Summary      обновить изображение
-// @Description  обновить существующее изображение
+// @Description  обновить существующее изображение. Файл передаётся потоком, поэтому uid должен идти в форме перед ним. Размер ограничен настройкой max_image_size.
+// @Description  Допустимые форматы задаются настройкой upload_types: по умолчанию jpeg, png, webp, gif, avif, heic и svg.
+// @Description  Метаданные (EXIF, XMP, комментарии) удаляются, кроме ориентации jpeg. Из svg удаляются скрипты, обработчики событий и встроенные документы. Файл, который не декодируется как изображение, отклоняется с 400.
+// @Description  Перед сохранением файл проверяется сканерами (сигнатура формата, число пикселей, вложенная разметка или архив, антивирус clamd), отклонённый файл помещается в карантин и возвращается 400.
 // @Tags         Image
 // @Accept       mpfd
 // @Produce      json
@@ -53,14 +73,20 @@ func (a *API) PutImage(w http.ResponseWriter, r *http.Request) {
 // @Param        avoid_cache    query     string  false "avoid_cache"      example(true)
 // @Success      200   {object}  ds.UpdateImageResponse
 // @Failure      400   {object}  ds.UpdateImageResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      413   {object}  ds.UpdateImageResponse
 // @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /image [patch]
 func (a *API) UpdateImage(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.UpdateImageRequest, ds.UpdateImageResponse]{
 		httpRequest:      r,
 		httpResponse:     &w,
 		api:              a,
-		requestExtractor: extractMultipartWithFile,
+		requestExtractor: a.extractMultipartWithFile,
 		responseWriter:   writeJsonResponse,
 		serviceFunc:      a.imageService.UpdateImage,
 	})
@@ -68,14 +94,32 @@ func (a *API) UpdateImage(w http.ResponseWriter, r *http.Request) {
 
 // GetProductImage возвращает изображение продукта
 // @Summary      Возвращает изображение продукта
-// @Description  Возвращает изображение продукта
+// @Description  Возвращает основное изображение продукта. Если указаны w или h, возвращает уменьшенный вариант.
+// @Description  Отдаёт ETag и Last-Modified: по If-None-Match или If-Modified-Since отвечает 304, по Range отдаёт один диапазон байт.
 // @Tags         Image
-// @Produce      application/octet-stream
-// @Param        product_uid    query  string  true  "product_uid" example("c85a189d-d173-42e2-8e00-54395234d93d")
-// @Param        avoid_cache    query  string  false "avoid_cache" example(true)
+// @Produce      image/jpeg,image/png,image/gif,image/webp,image/avif,image/heic,image/svg+xml
+// @Param        product_uid    query     string true  "product_uid" example("c85a189d-d173-42e2-8e00-54395234d93d")
+// @Param        w              query     int    false "ширина варианта, до 2000"                     example(200)
+// @Param        h              query     int    false "высота варианта, до 2000"                     example(200)
+// @Param        fit            query     string false "вписывание: cover (по умолчанию), contain, fill" example(cover)
+// @Param        format         query     string false "формат варианта: jpeg или png, webp не кодируется" example(jpeg)
+// @Param        inline         query     bool   false "показать в браузере вместо скачивания" example(true)
+// @Param        avoid_cache    query     string false "avoid_cache" example(true)
+// @Param        If-None-Match  header    string false "ETag полученного ранее файла"
+// @Param        Range          header    string false "диапазон байт" example(bytes=0-1023)
 // @Success      200  {file}    binary
+// @Header       200  {string}  ETag           "хеш содержимого"
+// @Header       200  {string}  Last-Modified  "время изменения"
+// @Success      206  {file}    binary
+// @Success      304  "не изменилось"
 // @Failure      400  {object}  ds.GetProductImageResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      416  "диапазон вне файла"
 // @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /image/product [get]
 func (a *API) GetProductImage(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.GetProductImageRequest, ds.GetProductImageResponse]{
@@ -90,14 +134,33 @@ func (a *API) GetProductImage(w http.ResponseWriter, r *http.Request) {
 
 // GetImage возвращает изображение
 // @Summary      Возвращает изображение
-// @Description  Возвращает изображение
+// @Description  Возвращает изображение. Если указаны w или h, возвращает вариант заданного размера.
+// @Description  Отдаёт ETag и Last-Modified: по If-None-Match или If-Modified-Since отвечает 304, по Range отдаёт один диапазон байт.
 // @Tags         Image
-// @Produce      application/octet-stream
+// @Produce      image/jpeg,image/png,image/gif,image/webp,image/avif,image/heic,image/svg+xml
 // @Param        uid            query     string true  "uid"         example("376de312-5bcb-4320-8ba3-bd2050548229")
+// @Param        w              query     int    false "ширина варианта, до 2000"                     example(200)
+// @Param        h              query     int    false "высота варианта, до 2000"                     example(200)
+// @Param        fit            query     string false "вписывание: cover (по умолчанию), contain, fill" example(cover)
+// @Param        format         query     string false "формат варианта: jpeg или png, webp не кодируется" example(jpeg)
+// @Param        inline         query     bool   false "показать в браузере вместо скачивания" example(true)
+// @Param        include_deleted query    bool   false "вернуть и изображение из корзины" example(true)
 // @Param        avoid_cache    query     string false "avoid_cache" example(true)
+// @Param        If-None-Match  header    string false "ETag полученного ранее файла"
+// @Param        Range          header    string false "диапазон байт" example(bytes=0-1023)
 // @Success      200  {file}    binary
+// @Header       200  {string}  ETag           "хеш содержимого"
+// @Header       200  {string}  Last-Modified  "время изменения"
+// @Success      206  {file}    binary
+// @Success      304  "не изменилось"
 // @Failure      400  {object}  ds.GetImageResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      416  "диапазон вне файла"
 // @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /image [get]
 func (a *API) GetImage(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.GetImageRequest, ds.GetImageResponse]{
@@ -110,16 +173,53 @@ func (a *API) GetImage(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetImageMeta возвращает метаданные изображения
+// @Summary      Возвращает метаданные изображения
+// @Description  Возвращает формат, размеры в пикселях, размер файла и хеш изображения. Размеры и формат пусты для изображений, загруженных до их учёта.
+// @Description  С include_deleted=true вернет и метаданные изображения из корзины, с временем удаления deleted_at.
+// @Tags         Image
+// @Produce      json
+// @Param        uid             query     string true  "uid"             example("376de312-5bcb-4320-8ba3-bd2050548229")
+// @Param        include_deleted query     string false "include_deleted" example(true)
+// @Param        avoid_cache     query     string false "avoid_cache"     example(true)
+// @Success      200  {object}  ds.GetImageMetaResponse
+// @Failure      400  {object}  ds.GetImageMetaResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /image/meta [get]
+func (a *API) GetImageMeta(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetImageMetaRequest, ds.GetImageMetaResponse]{
+		httpRequest:      r,
+		httpResponse:     &w,
+		api:              a,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.imageService.GetImageMeta,
+	})
+}
+
 // DeleteImage удаляет изображение
 // @Summary      удаляет изображение
-// @Description  удаляет изображение
+// @Description  удаляет изображение. Если изображение используется товарами, по умолчанию (policy=restrict) вернется 409
+// @Description  со списком этих товаров, с policy=cascade товары удаляются вместе с изображением.
+// @Description  Удаленные изображение и товары переносятся в корзину, содержимое изображения удаляется по истечении срока хранения.
 // @Tags         Image
 // @Accept       json
 // @Produce      json
 // @Param        input body      ds.DeleteImageRequest  true "uid"
 // @Success      200   {object}  ds.DeleteImageResponse
 // @Failure      400   {object}  ds.DeleteImageResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      409   {object}  ds.DeleteImageResponse
 // @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /image [delete]
 func (a *API) DeleteImage(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.DeleteImageRequest, ds.DeleteImageResponse]{
@@ -131,3 +231,111 @@ func (a *API) DeleteImage(w http.ResponseWriter, r *http.Request) {
 		serviceFunc:      a.imageService.DeleteImage,
 	})
 }
+
+// RestoreImage восстанавливает изображение
+// @Summary      восстанавливает изображение
+// @Description  восстанавливает удаленное изображение из корзины. Товары, удаленные вместе с ним, восстанавливаются отдельно.
+// @Tags         Image
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.RestoreImageRequest  true "uid"
+// @Success      200   {object}  ds.RestoreImageResponse
+// @Failure      400   {object}  ds.RestoreImageResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /image/restore [post]
+func (a *API) RestoreImage(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.RestoreImageRequest, ds.RestoreImageResponse]{
+		httpRequest:      r,
+		httpResponse:     &w,
+		api:              a,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.imageService.RestoreImage,
+	})
+}
+
+// AttachProductImage прикрепляет изображение к продукту
+// @Summary      Прикрепляет изображение к продукту
+// @Description  Прикрепляет загруженное изображение к продукту последним в списке. Если primary равен true, изображение становится основным.
+// @Tags         Image
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.AttachProductImageRequest  true "product_id, image_id"
+// @Success      200   {object}  ds.AttachProductImageResponse
+// @Failure      400   {object}  ds.AttachProductImageResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /image/product [post]
+func (a *API) AttachProductImage(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.AttachProductImageRequest, ds.AttachProductImageResponse]{
+		httpRequest:      r,
+		httpResponse:     &w,
+		api:              a,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.imageService.AttachProductImage,
+	})
+}
+
+// DetachProductImage открепляет изображение от продукта
+// @Summary      Открепляет изображение от продукта
+// @Description  Открепляет изображение от продукта. Единственное изображение продукта открепить нельзя. Если открепляется основное изображение, основным становится первое из оставшихся.
+// @Tags         Image
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.DetachProductImageRequest  true "product_id, image_id"
+// @Success      200   {object}  ds.DetachProductImageResponse
+// @Failure      400   {object}  ds.DetachProductImageResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /image/product [delete]
+func (a *API) DetachProductImage(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.DetachProductImageRequest, ds.DetachProductImageResponse]{
+		httpRequest:      r,
+		httpResponse:     &w,
+		api:              a,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.imageService.DetachProductImage,
+	})
+}
+
+// ReorderProductImages меняет порядок изображений продукта
+// @Summary      Меняет порядок изображений продукта
+// @Description  Задает порядок изображений продукта. image_ids должен содержать все прикрепленные изображения продукта. Если указан primary_image_id, это изображение становится основным.
+// @Tags         Image
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.ReorderProductImagesRequest  true "product_id, image_ids"
+// @Success      200   {object}  ds.ReorderProductImagesResponse
+// @Failure      400   {object}  ds.ReorderProductImagesResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /image/product/order [put]
+func (a *API) ReorderProductImages(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.ReorderProductImagesRequest, ds.ReorderProductImagesResponse]{
+		httpRequest:      r,
+		httpResponse:     &w,
+		api:              a,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.imageService.ReorderProductImages,
+	})
+}
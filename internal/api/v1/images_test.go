@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -18,14 +20,21 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func readAll(t *testing.T, r io.Reader) []byte {
+	data, err := io.ReadAll(r)
+	require.Nil(t, err)
+	return data
+}
+
 func TestPutImage(t *testing.T) {
 	t.Parallel()
 
-	t.Run("PutImage 200", func(t *testing.T) {
+	t.Run("PutImage 413", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
 		a := NewTestApi(ctx, t)
+		a.api.maxImageSize = 1024
 
 		buff := &bytes.Buffer{}
 
@@ -36,8 +45,38 @@ func TestPutImage(t *testing.T) {
 		_, err := part.Write(supports.TestImage)
 		require.Nil(t, err)
 
-		uid := uuid.New()
-		err = writer.WriteField("uid", uid.String())
+		err = writer.Close()
+		require.Nil(t, err)
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixImage, buff)
+
+		apiReq.Header.Set("Content-Type", writer.FormDataContentType())
+
+		a.imageMock.EXPECT().AddImage(gomock.Any()).DoAndReturn(func(req *ds.AddImageRequest) *ds.AddImageResponse {
+			_, err := io.ReadAll(req.Image)
+			require.ErrorIs(t, err, supports.ErrTooLarge)
+			return &ds.AddImageResponse{Status: ds.Status{Message: ds.StatusTooLarge}}
+		})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusRequestEntityTooLarge)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.PutImage(a.responseWriter, apiReq)
+	})
+
+	t.Run("PutImage 400 on rejected image", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		buff := &bytes.Buffer{}
+
+		writer := multipart.NewWriter(buff)
+
+		part, _ := writer.CreateFormFile("image", "some.jpeg")
+
+		_, err := part.Write(append(bytes.Clone(supports.TestImage), "<script>alert(1)</script>"...))
 		require.Nil(t, err)
 
 		err = writer.Close()
@@ -47,10 +86,81 @@ func TestPutImage(t *testing.T) {
 
 		apiReq.Header.Set("Content-Type", writer.FormDataContentType())
 
-		req := &ds.AddImageRequest{
-			Uid:   uid,
-			Image: supports.TestImage,
-		}
+		a.imageMock.EXPECT().AddImage(gomock.Any()).DoAndReturn(func(req *ds.AddImageRequest) *ds.AddImageResponse {
+			_, err := io.ReadAll(req.Image)
+			require.ErrorIs(t, err, supports.ErrRejected)
+			return &ds.AddImageResponse{Status: ds.Status{Message: ds.StatusRejected}}
+		})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.PutImage(a.responseWriter, apiReq)
+	})
+
+	t.Run("PutImage 500 on form value after file", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		buff := &bytes.Buffer{}
+
+		writer := multipart.NewWriter(buff)
+
+		part, _ := writer.CreateFormFile("image", "some.png")
+
+		_, err := part.Write(supports.TestImage)
+		require.Nil(t, err)
+
+		err = writer.WriteField("uid", uuid.New().String())
+		require.Nil(t, err)
+
+		err = writer.Close()
+		require.Nil(t, err)
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixImage, buff)
+
+		apiReq.Header.Set("Content-Type", writer.FormDataContentType())
+
+		a.imageMock.EXPECT().AddImage(gomock.Any()).DoAndReturn(func(req *ds.AddImageRequest) *ds.AddImageResponse {
+			_, err := io.ReadAll(req.Image)
+			require.ErrorIs(t, err, errFormValueAfterFile)
+			return nil
+		})
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusInternalServerError)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.PutImage(a.responseWriter, apiReq)
+	})
+
+	t.Run("PutImage 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		buff := &bytes.Buffer{}
+
+		writer := multipart.NewWriter(buff)
+
+		uid := uuid.New()
+		err := writer.WriteField("uid", uid.String())
+		require.Nil(t, err)
+
+		part, _ := writer.CreateFormFile("image", "some.png")
+
+		_, err = part.Write(supports.TestImage)
+		require.Nil(t, err)
+
+		err = writer.Close()
+		require.Nil(t, err)
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixImage, buff)
+
+		apiReq.Header.Set("Content-Type", writer.FormDataContentType())
 
 		resp := &ds.AddImageResponse{
 			Uid: &uid,
@@ -65,7 +175,11 @@ func TestPutImage(t *testing.T) {
 		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
 		a.responseWriter.EXPECT().Write(buf.Bytes())
 
-		a.imageMock.EXPECT().AddImage(req).Return(resp)
+		a.imageMock.EXPECT().AddImage(gomock.Any()).DoAndReturn(func(req *ds.AddImageRequest) *ds.AddImageResponse {
+			require.Equal(t, uid, req.Uid)
+			require.Equal(t, supports.TestImage, readAll(t, req.Image))
+			return resp
+		})
 
 		a.api.PutImage(a.responseWriter, apiReq)
 	})
@@ -80,13 +194,13 @@ func TestPutImage(t *testing.T) {
 
 		writer := multipart.NewWriter(buff)
 
-		part, _ := writer.CreateFormFile("image", "some.png")
-
-		_, err := part.Write([]byte("just s text file instead of image"))
+		uid := uuid.New()
+		err := writer.WriteField("uid", uid.String())
 		require.Nil(t, err)
 
-		uid := uuid.New()
-		err = writer.WriteField("uid", uid.String())
+		part, _ := writer.CreateFormFile("image", "some.png")
+
+		_, err = part.Write([]byte("just s text file instead of image"))
 		require.Nil(t, err)
 
 		err = writer.Close()
@@ -114,13 +228,13 @@ func TestPutImage(t *testing.T) {
 
 		writer := multipart.NewWriter(buff)
 
-		part, _ := writer.CreateFormFile("image", "some.png")
-
-		_, err := part.Write(supports.TestImage)
+		uid := uuid.New()
+		err := writer.WriteField("uid", uid.String())
 		require.Nil(t, err)
 
-		uid := uuid.New()
-		err = writer.WriteField("uid", uid.String())
+		part, _ := writer.CreateFormFile("image", "some.png")
+
+		_, err = part.Write(supports.TestImage)
 		require.Nil(t, err)
 
 		err = writer.Close()
@@ -130,17 +244,12 @@ func TestPutImage(t *testing.T) {
 
 		apiReq.Header.Set("Content-Type", writer.FormDataContentType())
 
-		req := &ds.AddImageRequest{
-			Uid:   uid,
-			Image: supports.TestImage,
-		}
-
 		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
 		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
 		a.responseWriter.EXPECT().WriteHeader(http.StatusInternalServerError)
 		a.responseWriter.EXPECT().Write(gomock.Any())
 
-		a.imageMock.EXPECT().AddImage(req).Return(nil)
+		a.imageMock.EXPECT().AddImage(gomock.Any()).Return(nil)
 
 		a.api.PutImage(a.responseWriter, apiReq)
 	})
@@ -159,13 +268,13 @@ func TestUpdateImage(t *testing.T) {
 
 		writer := multipart.NewWriter(buff)
 
-		part, _ := writer.CreateFormFile("image", "some.png")
-
-		_, err := part.Write(supports.TestImage)
+		uid := uuid.New()
+		err := writer.WriteField("uid", uid.String())
 		require.Nil(t, err)
 
-		uid := uuid.New()
-		err = writer.WriteField("uid", uid.String())
+		part, _ := writer.CreateFormFile("image", "some.png")
+
+		_, err = part.Write(supports.TestImage)
 		require.Nil(t, err)
 
 		err = writer.Close()
@@ -175,11 +284,6 @@ func TestUpdateImage(t *testing.T) {
 
 		apiReq.Header.Set("Content-Type", writer.FormDataContentType())
 
-		req := &ds.UpdateImageRequest{
-			Uid:   uid,
-			Image: supports.TestImage,
-		}
-
 		resp := &ds.UpdateImageResponse{
 			Status: ds.Status{Message: ds.StatusOK},
 		}
@@ -193,7 +297,11 @@ func TestUpdateImage(t *testing.T) {
 		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
 		a.responseWriter.EXPECT().Write(buf.Bytes())
 
-		a.imageMock.EXPECT().UpdateImage(req).Return(resp)
+		a.imageMock.EXPECT().UpdateImage(gomock.Any()).DoAndReturn(func(req *ds.UpdateImageRequest) *ds.UpdateImageResponse {
+			require.Equal(t, uid, req.Uid)
+			require.Equal(t, supports.TestImage, readAll(t, req.Image))
+			return resp
+		})
 
 		a.api.UpdateImage(a.responseWriter, apiReq)
 	})
@@ -238,13 +346,13 @@ func TestUpdateImage(t *testing.T) {
 
 		writer := multipart.NewWriter(buff)
 
-		part, _ := writer.CreateFormFile("image", "some.png")
-
-		_, err := part.Write(supports.TestImage)
+		uid := uuid.New()
+		err := writer.WriteField("uid", uid.String())
 		require.Nil(t, err)
 
-		uid := uuid.New()
-		err = writer.WriteField("uid", uid.String())
+		part, _ := writer.CreateFormFile("image", "some.png")
+
+		_, err = part.Write(supports.TestImage)
 		require.Nil(t, err)
 
 		err = writer.Close()
@@ -254,17 +362,12 @@ func TestUpdateImage(t *testing.T) {
 
 		apiReq.Header.Set("Content-Type", writer.FormDataContentType())
 
-		req := &ds.UpdateImageRequest{
-			Uid:   uid,
-			Image: supports.TestImage,
-		}
-
 		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
 		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
 		a.responseWriter.EXPECT().WriteHeader(http.StatusInternalServerError)
 		a.responseWriter.EXPECT().Write(gomock.Any())
 
-		a.imageMock.EXPECT().UpdateImage(req).Return(nil)
+		a.imageMock.EXPECT().UpdateImage(gomock.Any()).Return(nil)
 
 		a.api.UpdateImage(a.responseWriter, apiReq)
 	})
@@ -293,14 +396,14 @@ func TestGetProductImage(t *testing.T) {
 
 		imageUid := uuid.New()
 		resp := &ds.GetProductImageResponse{
-			Uid:   &imageUid,
-			Image: supports.TestImage,
+			Uid:          &imageUid,
+			ImageContent: ds.ImageContent{Image: supports.TestImage},
 		}
 
 		a.imageMock.EXPECT().GetProductImage(req).Return(resp)
 		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
 		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
-		a.responseWriter.EXPECT().Write(supports.TestImage)
+		a.responseWriter.EXPECT().Write(supports.TestImage).Return(len(supports.TestImage), nil)
 
 		a.api.GetProductImage(a.responseWriter, testReq)
 
@@ -405,17 +508,54 @@ func TestGetImage(t *testing.T) {
 		}
 
 		resp := &ds.GetImageResponse{
-			Uid:   &uid,
-			Image: supports.TestImage,
+			Uid:          &uid,
+			ImageContent: ds.ImageContent{Image: supports.TestImage},
 		}
 
 		a.imageMock.EXPECT().GetImage(req).Return(resp)
 		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
 		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
-		a.responseWriter.EXPECT().Write(supports.TestImage)
+		a.responseWriter.EXPECT().Write(supports.TestImage).Return(len(supports.TestImage), nil)
+
+		a.api.GetImage(a.responseWriter, testReq)
+
+	})
+
+	t.Run("GetImage streamed 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		testReq := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+
+		uid := uuid.New()
+
+		q := testReq.URL.Query()
+		q.Add("uid", uid.String())
+		testReq.URL.RawQuery = q.Encode()
+
+		resp := &ds.GetImageResponse{
+			Uid: &uid,
+			ImageContent: ds.ImageContent{
+				Content: io.NopCloser(bytes.NewReader(supports.TestImage)),
+				Size:    int64(len(supports.TestImage)),
+			},
+		}
+
+		header := http.Header{}
+		written := &bytes.Buffer{}
+
+		a.imageMock.EXPECT().GetImage(gomock.Any()).Return(resp)
+		a.responseWriter.EXPECT().Header().Return(header).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any()).DoAndReturn(written.Write).MinTimes(1)
 
 		a.api.GetImage(a.responseWriter, testReq)
 
+		require.Equal(t, supports.TestImage, written.Bytes())
+		require.Equal(t, strconv.Itoa(len(supports.TestImage)), header.Get(contentLenKey))
+		require.Contains(t, header.Get(contentDispositionKey), ".jpeg")
 	})
 
 	t.Run("GetImage 400", func(t *testing.T) {
@@ -435,6 +575,88 @@ func TestGetImage(t *testing.T) {
 
 	})
 
+	t.Run("GetImage variant 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		testReq := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+
+		uid := uuid.New()
+
+		q := testReq.URL.Query()
+		q.Add("uid", uid.String())
+		q.Add("w", "200")
+		q.Add("h", "100")
+		q.Add("fit", "contain")
+		q.Add("format", "jpeg")
+		testReq.URL.RawQuery = q.Encode()
+
+		req := &ds.GetImageRequest{
+			Uid:          uid,
+			ImageVariant: ds.ImageVariant{Width: 200, Height: 100, Fit: "contain", Format: "jpeg"},
+		}
+
+		resp := &ds.GetImageResponse{
+			Uid:          &uid,
+			ImageContent: ds.ImageContent{Image: supports.TestImage},
+		}
+
+		a.imageMock.EXPECT().GetImage(req).Return(resp)
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(supports.TestImage).Return(len(supports.TestImage), nil)
+
+		a.api.GetImage(a.responseWriter, testReq)
+
+	})
+
+	t.Run("GetImage variant 400 on size", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		testReq := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+
+		q := testReq.URL.Query()
+		q.Add("uid", uuid.New().String())
+		q.Add("w", "5000")
+		testReq.URL.RawQuery = q.Encode()
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetImage(a.responseWriter, testReq)
+
+	})
+
+	t.Run("GetImage variant 400 on webp format", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		testReq := httptest.NewRequest(http.MethodGet, prefixImage, nil)
+
+		q := testReq.URL.Query()
+		q.Add("uid", uuid.New().String())
+		q.Add("w", "200")
+		q.Add("format", "webp")
+		testReq.URL.RawQuery = q.Encode()
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetImage(a.responseWriter, testReq)
+
+	})
+
 	t.Run("GetImage 404", func(t *testing.T) {
 		t.Parallel()
 
@@ -495,6 +717,64 @@ func TestGetImage(t *testing.T) {
 	})
 }
 
+func TestGetImageMeta(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetImageMeta 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		uid := uuid.New()
+		testReq := httptest.NewRequest(http.MethodGet, prefixImageMeta+"?uid="+uid.String(), nil)
+
+		resp := &ds.GetImageMetaResponse{
+			Meta: &ds.ImageMeta{Uid: uid, Format: "jpeg", Width: 1920, Height: 1080},
+		}
+
+		a.imageMock.EXPECT().GetImageMeta(&ds.GetImageMetaRequest{Uid: uid}).Return(resp)
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetImageMeta(a.responseWriter, testReq)
+	})
+
+	t.Run("GetImageMeta 400", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		testReq := httptest.NewRequest(http.MethodGet, prefixImageMeta, nil)
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetImageMeta(a.responseWriter, testReq)
+	})
+
+	t.Run("GetImageMeta 404", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		uid := uuid.New()
+		testReq := httptest.NewRequest(http.MethodGet, prefixImageMeta+"?uid="+uid.String(), nil)
+
+		a.imageMock.EXPECT().GetImageMeta(gomock.Any()).Return(&ds.GetImageMetaResponse{Status: ds.Status{Message: ds.StatusNotFound}})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetImageMeta(a.responseWriter, testReq)
+	})
+}
+
 func TestDeleteImage(t *testing.T) {
 	t.Parallel()
 
@@ -574,6 +854,62 @@ func TestDeleteImage(t *testing.T) {
 		a.api.DeleteImage(a.responseWriter, testReq)
 	})
 
+	t.Run("DeleteImage 400 on reassign", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.DeleteImageRequest{
+			Uid:    uuid.New(),
+			Policy: ds.DeleteReassign,
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodDelete, prefixClient, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.DeleteImage(a.responseWriter, testReq)
+	})
+
+	t.Run("DeleteImage 409", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.DeleteImageRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodDelete, prefixClient, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.imageMock.EXPECT().DeleteImage(reqStruct).Return(&ds.DeleteImageResponse{
+			Status:   ds.Status{Message: ds.StatusConflict},
+			Products: []uuid.UUID{uuid.New()},
+		})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusConflict)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.DeleteImage(a.responseWriter, testReq)
+	})
+
 	t.Run("DeleteImage 500", func(t *testing.T) {
 		t.Parallel()
 
@@ -601,3 +937,278 @@ func TestDeleteImage(t *testing.T) {
 		a.api.DeleteImage(a.responseWriter, testReq)
 	})
 }
+
+func TestRestoreImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RestoreImage 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.RestoreImageRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixImageRestore, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.RestoreImageResponse{}
+
+		a.imageMock.EXPECT().RestoreImage(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.RestoreImage(a.responseWriter, apiReq)
+	})
+
+	t.Run("RestoreImage 404", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.RestoreImageRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixImageRestore, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.RestoreImageResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}
+
+		a.imageMock.EXPECT().RestoreImage(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.RestoreImage(a.responseWriter, apiReq)
+	})
+}
+
+func TestAttachProductImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AttachProductImage 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.AttachProductImageRequest{
+			ProductUid: uuid.New(),
+			ImageUid:   uuid.New(),
+			AltText:    "alt",
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodPost, prefixImageProduct, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.imageMock.EXPECT().AttachProductImage(reqStruct).Return(&ds.AttachProductImageResponse{Status: ds.Status{Message: ds.StatusOK}})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.AttachProductImage(a.responseWriter, testReq)
+	})
+
+	t.Run("AttachProductImage 400", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.AttachProductImageRequest{
+			ProductUid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodPost, prefixImageProduct, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.AttachProductImage(a.responseWriter, testReq)
+	})
+}
+
+func TestDetachProductImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DetachProductImage 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.DetachProductImageRequest{
+			ProductUid: uuid.New(),
+			ImageUid:   uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodDelete, prefixImageProduct, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.imageMock.EXPECT().DetachProductImage(reqStruct).Return(&ds.DetachProductImageResponse{Status: ds.Status{Message: ds.StatusOK}})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.DetachProductImage(a.responseWriter, testReq)
+	})
+
+	t.Run("DetachProductImage 400", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.DetachProductImageRequest{
+			ProductUid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodDelete, prefixImageProduct, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.DetachProductImage(a.responseWriter, testReq)
+	})
+
+	t.Run("DetachProductImage 400 on only image", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.DetachProductImageRequest{
+			ProductUid: uuid.New(),
+			ImageUid:   uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodDelete, prefixImageProduct, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.DetachProductImageResponse{Status: ds.Status{Message: ds.StatusDetachOnlyProductImage}}
+
+		a.imageMock.EXPECT().DetachProductImage(reqStruct).Return(resp)
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.DetachProductImage(a.responseWriter, testReq)
+	})
+}
+
+func TestReorderProductImages(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ReorderProductImages 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.ReorderProductImagesRequest{
+			ProductUid: uuid.New(),
+			ImageUids:  []uuid.UUID{uuid.New(), uuid.New()},
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodPut, prefixImageProductOrder, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.imageMock.EXPECT().ReorderProductImages(reqStruct).Return(&ds.ReorderProductImagesResponse{Status: ds.Status{Message: ds.StatusOK}})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.ReorderProductImages(a.responseWriter, testReq)
+	})
+
+	t.Run("ReorderProductImages 400", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		reqStruct := &ds.ReorderProductImagesRequest{
+			ProductUid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&reqStruct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodPut, prefixImageProductOrder, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.ReorderProductImages(a.responseWriter, testReq)
+	})
+}
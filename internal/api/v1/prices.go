@@ -0,0 +1,129 @@
+package api
+
+import (
+	"net/http"
+	ds "shopapi/internal/datastruct"
+)
+
+const (
+	prefixVariantPrice  = prefixProductVariant + "/price"
+	prefixVariantPrices = prefixProductVariant + "/prices"
+	prefixExchangeRate  = apiPrefix + "/currency/rate"
+	prefixExchangeRates = apiPrefix + "/currency/rates"
+)
+
+func (a *API) setupPricesHandlers(router IRouter) {
+	router.HandleFunc(pattern(http.MethodPost, prefixVariantPrice), a.authorize(ds.PermissionProductsWrite, a.SetVariantPrice))
+	router.HandleFunc(pattern(http.MethodGet, prefixVariantPrices), a.authorize(ds.PermissionProductsRead, a.GetVariantPrices))
+	router.HandleFunc(pattern(http.MethodPost, prefixExchangeRate), a.authorize(ds.PermissionProductsWrite, a.SetExchangeRate))
+	router.HandleFunc(pattern(http.MethodGet, prefixExchangeRates), a.authorize(ds.PermissionProductsRead, a.GetExchangeRates))
+}
+
+// SetVariantPrice Устанавливает цену варианта продукта
+// @Summary      Установка цены варианта
+// @Description  Устанавливает обычную цену варианта с effective_from, без него - сразу. С effective_to цена временная:
+// @Description  пока она действует, продукт возвращается с ней и с обычной ценой в regular_price, после нее снова действует обычная.
+// @Description  Прежние цены остаются в истории цен варианта.
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.SetVariantPriceRequest  true "uid варианта, цена и период ее действия"
+// @Success      200   {object}  ds.SetVariantPriceResponse
+// @Failure      400   {object}  ds.SetVariantPriceResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      404   {object}  ds.SetVariantPriceResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/variant/price [post]
+func (a *API) SetVariantPrice(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.SetVariantPriceRequest, ds.SetVariantPriceResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.SetVariantPrice,
+	})
+}
+
+// GetVariantPrices возвращает историю цен варианта продукта
+// @Summary      История цен варианта
+// @Description  Возвращает прошлые, текущие и запланированные цены варианта, поздние первыми.
+// @Tags         Product
+// @Produce      json
+// @Param        variant_uid  query  string  true  "uid варианта"  example("5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11")
+// @Success      200  {object}  ds.GetVariantPricesResponse
+// @Failure      400  {object}  ds.GetVariantPricesResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      404  {object}  ds.GetVariantPricesResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/variant/prices [get]
+func (a *API) GetVariantPrices(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetVariantPricesRequest, ds.GetVariantPricesResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.GetVariantPrices,
+	})
+}
+
+// SetExchangeRate Устанавливает курс валюты
+// @Summary      Установка курса валюты
+// @Description  Устанавливает, сколько рублей стоит единица валюты. Курс между двумя другими валютами считается через рубль.
+// @Description  Продукты с параметром currency возвращаются с ценами, переведенными по этим курсам.
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.SetExchangeRateRequest  true "Код валюты и курс"
+// @Success      200   {object}  ds.SetExchangeRateResponse
+// @Failure      400   {object}  ds.SetExchangeRateResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /currency/rate [post]
+func (a *API) SetExchangeRate(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.SetExchangeRateRequest, ds.SetExchangeRateResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.SetExchangeRate,
+	})
+}
+
+// GetExchangeRates возвращает курсы валют
+// @Summary      Курсы валют
+// @Description  Возвращает курсы валют к рублю.
+// @Tags         Product
+// @Produce      json
+// @Success      200  {object}  ds.GetExchangeRatesResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /currency/rates [get]
+func (a *API) GetExchangeRates(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetExchangeRatesRequest, ds.GetExchangeRatesResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.GetExchangeRates,
+	})
+}
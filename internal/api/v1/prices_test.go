@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetVariantPrice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SetVariantPrice 200", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		uid := uuid.New()
+		body := `{"variant_uid":"` + uid.String() + `","price":"249.95 USD",` +
+			`"effective_from":"2030-01-01T00:00:00Z","effective_to":"2030-02-01T00:00:00Z"}`
+		apiReq := httptest.NewRequest(http.MethodPost, prefixVariantPrice, strings.NewReader(body))
+
+		from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2030, 2, 1, 0, 0, 0, 0, time.UTC)
+		resp := &ds.SetVariantPriceResponse{Id: 42}
+
+		a.productMock.EXPECT().SetVariantPrice(gomock.Any()).DoAndReturn(func(req *ds.SetVariantPriceRequest) *ds.SetVariantPriceResponse {
+			require.Equal(t, uid, req.VariantUid)
+			require.Equal(t, money.New(24995, "USD"), req.Price)
+			require.True(t, from.Equal(*req.EffectiveFrom))
+			require.True(t, to.Equal(*req.EffectiveTo))
+			return resp
+		})
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.SetVariantPrice(a.responseWriter, apiReq)
+	})
+
+	t.Run("SetVariantPrice ends before it starts", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		body := `{"variant_uid":"` + uuid.NewString() + `","price":249.95,"effective_to":"2020-01-01T00:00:00Z"}`
+		apiReq := httptest.NewRequest(http.MethodPost, prefixVariantPrice, strings.NewReader(body))
+
+		a.productMock.EXPECT().SetVariantPrice(gomock.Any()).Return(&ds.SetVariantPriceResponse{
+			Status: ds.Status{Message: ds.StatusPriceEndsNotInTime},
+		})
+
+		w := httptest.NewRecorder()
+		a.api.SetVariantPrice(w, apiReq)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("SetVariantPrice without price", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		body := `{"variant_uid":"` + uuid.NewString() + `"}`
+		apiReq := httptest.NewRequest(http.MethodPost, prefixVariantPrice, strings.NewReader(body))
+
+		w := httptest.NewRecorder()
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+
+		a.api.SetVariantPrice(w, apiReq)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetVariantPrices(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetVariantPrices 200", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		uid := uuid.New()
+		apiReq := httptest.NewRequest(http.MethodGet, prefixVariantPrices+"?variant_uid="+uid.String(), nil)
+
+		resp := &ds.GetVariantPricesResponse{Prices: []ds.VariantPrice{{Id: 1, Price: money.New(29995, money.RUB)}}}
+		a.productMock.EXPECT().GetVariantPrices(&ds.GetVariantPricesRequest{VariantUid: uid}).Return(resp)
+
+		w := httptest.NewRecorder()
+		a.api.GetVariantPrices(w, apiReq)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.JSONEq(t, `{"prices":[{"id":1,"price":"299.95 RUB","effective_from":"0001-01-01T00:00:00Z","created_at":"0001-01-01T00:00:00Z"}]}`,
+			w.Body.String())
+	})
+
+	t.Run("GetVariantPrices 404", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixVariantPrices+"?variant_uid="+uuid.NewString(), nil)
+
+		a.productMock.EXPECT().GetVariantPrices(gomock.Any()).Return(&ds.GetVariantPricesResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		})
+
+		w := httptest.NewRecorder()
+		a.api.GetVariantPrices(w, apiReq)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -6,28 +6,46 @@ import (
 )
 
 const (
-	prefixProduct  = apiPrefix + "/product"
-	prefixProducts = apiPrefix + "/products"
+	prefixProduct        = apiPrefix + "/product"
+	prefixProducts       = apiPrefix + "/products"
+	prefixProductVariant = prefixProduct + "/variant"
+	prefixProductAttrs   = prefixProduct + "/attributes"
+	prefixProductSku     = prefixProduct + "/sku"
+	prefixProductBarcode = prefixProduct + "/barcode"
+	prefixProductRestore = prefixProduct + "/restore"
+	prefixBarcode        = apiPrefix + "/barcode"
 )
 
 func (a *API) setupProductsHandlers(router IRouter) {
-	router.HandleFunc(pattern(http.MethodPost, prefixProduct), a.PutProduct)
-	router.HandleFunc(pattern(http.MethodPatch, prefixProduct), a.DecreaseProduct)
-	router.HandleFunc(pattern(http.MethodGet, prefixProduct), a.GetProduct)
-	router.HandleFunc(pattern(http.MethodGet, prefixProducts), a.GetProducts)
-	router.HandleFunc(pattern(http.MethodDelete, prefixProduct), a.DeleteProduct)
+	router.HandleFunc(pattern(http.MethodPost, prefixProduct), a.authorize(ds.PermissionProductsWrite, a.PutProduct))
+	router.HandleFunc(pattern(http.MethodPatch, prefixProduct), a.authorize(ds.PermissionStockWrite, a.DecreaseProduct))
+	router.HandleFunc(pattern(http.MethodGet, prefixProduct), a.authorize(ds.PermissionProductsRead, a.GetProduct))
+	router.HandleFunc(pattern(http.MethodGet, prefixProductSku), a.authorize(ds.PermissionProductsRead, a.GetProductBySku))
+	router.HandleFunc(pattern(http.MethodGet, prefixProductBarcode), a.authorize(ds.PermissionProductsRead, a.GetProductByBarcode))
+	router.HandleFunc(pattern(http.MethodGet, prefixProducts), a.authorize(ds.PermissionProductsRead, a.GetProducts))
+	router.HandleFunc(pattern(http.MethodDelete, prefixProduct), a.authorize(ds.PermissionProductsWrite, a.DeleteProduct))
+	router.HandleFunc(pattern(http.MethodPost, prefixProductRestore), a.authorize(ds.PermissionProductsWrite, a.RestoreProduct))
+	router.HandleFunc(pattern(http.MethodPut, prefixProductAttrs), a.authorize(ds.PermissionProductsWrite, a.UpdateProductAttributes))
+	router.HandleFunc(pattern(http.MethodPost, prefixProductVariant), a.authorize(ds.PermissionProductsWrite, a.PutProductVariant))
+	router.HandleFunc(pattern(http.MethodDelete, prefixProductVariant), a.authorize(ds.PermissionProductsWrite, a.DeleteProductVariant))
+	router.HandleFunc(pattern(http.MethodGet, prefixBarcode), a.authorize(ds.PermissionProductsRead, a.GetBarcode))
 }
 
 // PutProduct Добавляет новый продукт
 // @Summary      Добавление продукта
-// @Description  Добавление продукта. Если продукт существует, обновится цена, количество в наличии, дата обновления и вернется uid этого продукта.
+// @Description  Добавление продукта вместе с его вариантами. Опции каждого варианта должны совпадать с осями опций продукта (options). Атрибуты проверяются по схеме атрибутов категории.
 // @Tags         Product
 // @Accept       json
 // @Produce      json
 // @Param        input body      ds.AddProductRequest  true "Информация о продукте"
 // @Success      200   {object}  ds.AddProductResponse
 // @Failure      400   {object}  ds.AddProductResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
 // @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /product [post]
 func (a *API) PutProduct(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.AddProductRequest, ds.AddProductResponse]{
@@ -40,16 +58,21 @@ func (a *API) PutProduct(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// DecreaseProduct Убавляет количество продукта
-// @Summary      Убавление количества продукта
-// @Description  Убавление количества продукта.
+// DecreaseProduct Убавляет количество варианта продукта
+// @Summary      Убавление количества варианта продукта
+// @Description  Убавление количества варианта продукта.
 // @Tags         Product
 // @Accept       json
 // @Produce      json
-// @Param        input body      ds.DecreaseProductsRequest  true "uid и уколичество"
+// @Param        input body      ds.DecreaseProductsRequest  true "uid варианта и количество"
 // @Success      200   {object}  ds.DecreaseProductsResponse
 // @Failure      400   {object}  ds.DecreaseProductsResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
 // @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /product [patch]
 func (a *API) DecreaseProduct(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.DecreaseProductsRequest, ds.DecreaseProductsResponse]{
@@ -64,14 +87,24 @@ func (a *API) DecreaseProduct(w http.ResponseWriter, r *http.Request) {
 
 // GetProduct возвращает продукт
 // @Summary      Возвращает продукт
-// @Description  Возвращает продукт.
+// @Description  Возвращает продукт. С include_deleted=true вернет и продукт из корзины.
+// @Description  С as_of вернет продукт с вариантами и изображениями таким, каким он был в этот момент.
+// @Description  Цены возвращаются строкой с валютой, например "299.95 RUB". С currency вернет их в этой валюте по текущим курсам.
 // @Tags         Product
 // @Produce      json
-// @Param        uid            query  string  true  "uid"         example("c85a189d-d173-42e2-8e00-54395234d93d")
-// @Param        avoid_cache    query  string  false "avoid_cache" example(true)
+// @Param        uid             query  string  true  "uid"             example("c85a189d-d173-42e2-8e00-54395234d93d")
+// @Param        include_deleted query  string  false "include_deleted" example(true)
+// @Param        avoid_cache     query  string  false "avoid_cache"     example(true)
+// @Param        as_of           query  string  false "Момент времени, RFC 3339" example("2025-01-01T00:00:00Z")
+// @Param        currency        query  string  false "Код валюты ISO 4217, цены переводятся в нее по курсам валют" example(USD)
 // @Success      200  {object}  ds.GetProductResponse
 // @Failure      400  {object}  ds.GetProductResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
 // @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /product [get]
 func (a *API) GetProduct(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.GetProductRequest, ds.GetProductResponse]{
@@ -84,17 +117,83 @@ func (a *API) GetProduct(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetProductBySku возвращает продукт по артикулу
+// @Summary      Возвращает продукт по артикулу
+// @Description  Возвращает продукт по артикулу (SKU) продукта или любого из его вариантов.
+// @Tags         Product
+// @Produce      json
+// @Param        sku            query  string  true  "sku"         example("BEAM-OAK-2M")
+// @Param        avoid_cache    query  string  false "avoid_cache" example(true)
+// @Param        currency       query  string  false "Код валюты ISO 4217, цены переводятся в нее по курсам валют" example(USD)
+// @Success      200  {object}  ds.GetProductResponse
+// @Failure      400  {object}  ds.GetProductResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/sku [get]
+func (a *API) GetProductBySku(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetProductBySkuRequest, ds.GetProductResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.GetProductBySku,
+	})
+}
+
+// GetProductByBarcode возвращает продукт по штрихкоду
+// @Summary      Возвращает продукт по штрихкоду
+// @Description  Возвращает продукт по штрихкоду EAN-8, EAN-13 или UPC-A продукта или любого из его вариантов.
+// @Tags         Product
+// @Produce      json
+// @Param        barcode        query  string  true  "barcode"     example("4006381333931")
+// @Param        avoid_cache    query  string  false "avoid_cache" example(true)
+// @Param        currency       query  string  false "Код валюты ISO 4217, цены переводятся в нее по курсам валют" example(USD)
+// @Success      200  {object}  ds.GetProductResponse
+// @Failure      400  {object}  ds.GetProductResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/barcode [get]
+func (a *API) GetProductByBarcode(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetProductByBarcodeRequest, ds.GetProductResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.GetProductByBarcode,
+	})
+}
+
 // GetProducts возвращает список продуктов
 // @Summary      Возвращает список продуктов
-// @Description  Возвращает список продуктов. Если offset limit равны 0, вернет список всех
+// @Description  Возвращает список продуктов. Если offset limit равны 0, вернет список всех. Если указан category_id, вернет продукты этой категории и всех ее подкатегорий. Параметр attr в формате name:value фильтрует по значениям атрибутов, может повторяться.
+// @Description  С include_deleted=true вернет также продукты из корзины.
 // @Tags         Product
 // @Produce      json
 // @Param        offset      query  string true  "offset"      example(0)
 // @Param        limit       query  string true  "limit"       example(10)
+// @Param        category_id query  string false "category_id" example("0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10")
+// @Param        attr        query  []string false "attr"     collectionFormat(multi) example(material:oak)
+// @Param        include_deleted query string false "include_deleted" example(true)
 // @Param        avoid_cache query  string false "avoid_cache" example(true)
+// @Param        currency    query  string false "Код валюты ISO 4217, цены переводятся в нее по курсам валют" example(USD)
 // @Success      200    {object} ds.GetProductsResponse
-// @Failure      400    {object} ds.Status
+// @Failure      400    {object} ds.GetProductsResponse
+// @Failure      401    {object} ds.Status
+// @Failure      403    {object} ds.ForbiddenResponse
+// @Failure      429    {object} ds.Status
 // @Failure      500    {object} ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /products [get]
 func (a *API) GetProducts(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.GetProductsRequest, ds.GetProductsResponse]{
@@ -107,16 +206,49 @@ func (a *API) GetProducts(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// UpdateProductAttributes Обновляет атрибуты продукта
+// @Summary      Обновление атрибутов продукта
+// @Description  Заменяет атрибуты продукта. Значения проверяются по схеме атрибутов категории продукта.
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.UpdateProductAttributesRequest  true "uid и атрибуты"
+// @Success      200   {object}  ds.UpdateProductAttributesResponse
+// @Failure      400   {object}  ds.UpdateProductAttributesResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/attributes [put]
+func (a *API) UpdateProductAttributes(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.UpdateProductAttributesRequest, ds.UpdateProductAttributesResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.UpdateProductAttributes,
+	})
+}
+
 // DeleteProduct Удаляет продукт
 // @Summary      Удаление продукта
-// @Description  Удаление продукта.
+// @Description  Удаление продукта. Продукт переносится в корзину вместе с вариантами, изображениями и документами
+// @Description  и удаляется окончательно по истечении срока хранения.
 // @Tags         Product
 // @Accept       json
 // @Produce      json
 // @Param        input body      ds.DeleteProductRequest  true "uid"
 // @Success      200   {object}  ds.DeleteProductResponse
 // @Failure      400   {object}  ds.DeleteProductResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
 // @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /product [delete]
 func (a *API) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.DeleteProductRequest, ds.DeleteProductResponse]{
@@ -128,3 +260,124 @@ func (a *API) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 		serviceFunc:      a.productService.DeleteProduct,
 	})
 }
+
+// RestoreProduct Восстанавливает продукт
+// @Summary      Восстановление продукта
+// @Description  Восстанавливает удаленный продукт из корзины. Если его поставщик или изображение в корзине, вернется 409,
+// @Description  их нужно восстановить раньше.
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.RestoreProductRequest  true "uid"
+// @Success      200   {object}  ds.RestoreProductResponse
+// @Failure      400   {object}  ds.RestoreProductResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      409   {object}  ds.RestoreProductResponse
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/restore [post]
+func (a *API) RestoreProduct(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.RestoreProductRequest, ds.RestoreProductResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.RestoreProduct,
+	})
+}
+
+// PutProductVariant Добавляет вариант продукта
+// @Summary      Добавление варианта продукта
+// @Description  Добавление варианта к существующему продукту. Опции варианта должны совпадать с осями опций продукта.
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.AddProductVariantRequest  true "Информация о варианте"
+// @Success      200   {object}  ds.AddProductVariantResponse
+// @Failure      400   {object}  ds.AddProductVariantResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/variant [post]
+func (a *API) PutProductVariant(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.AddProductVariantRequest, ds.AddProductVariantResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.AddProductVariant,
+	})
+}
+
+// DeleteProductVariant Удаляет вариант продукта
+// @Summary      Удаление варианта продукта
+// @Description  Удаление варианта продукта.
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.DeleteProductVariantRequest  true "uid"
+// @Success      200   {object}  ds.DeleteProductVariantResponse
+// @Failure      400   {object}  ds.DeleteProductVariantResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /product/variant [delete]
+func (a *API) DeleteProductVariant(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.DeleteProductVariantRequest, ds.DeleteProductVariantResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.productService.DeleteProductVariant,
+	})
+}
+
+// GetBarcode возвращает изображение штрихкода
+// @Summary      Возвращает изображение штрихкода
+// @Description  Возвращает изображение штрихкода EAN-8, EAN-13 или UPC-A для печати ценников. Формат png (по умолчанию) или svg.
+// @Description  Отдаёт ETag: по If-None-Match отвечает 304, по Range отдаёт один диапазон байт.
+// @Tags         Product
+// @Produce      image/png,image/svg+xml
+// @Param        code           query     string true  "code"        example("4006381333931")
+// @Param        format         query     string false "format"      example(svg)
+// @Param        scale          query     string false "scale"       example(2)
+// @Param        height         query     string false "height"      example(80)
+// @Param        inline         query     bool   false "показать в браузере вместо скачивания" example(true)
+// @Param        avoid_cache    query     string false "avoid_cache" example(true)
+// @Param        If-None-Match  header    string false "ETag полученного ранее файла"
+// @Param        Range          header    string false "диапазон байт" example(bytes=0-1023)
+// @Success      200  {file}    binary
+// @Header       200  {string}  ETag           "хеш содержимого"
+// @Success      206  {file}    binary
+// @Success      304  "не изменилось"
+// @Failure      400  {object}  ds.GetBarcodeResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      416  "диапазон вне файла"
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /barcode [get]
+func (a *API) GetBarcode(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetBarcodeRequest, ds.GetBarcodeResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeFileResponse,
+		serviceFunc:      a.productService.GetBarcode,
+	})
+}
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"shopapi/internal/barcode"
 	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
 	"strings"
 	"testing"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPutProduct(t *testing.T) {
@@ -27,14 +30,14 @@ func TestPutProduct(t *testing.T) {
 		uid := uuid.New()
 
 		reqStruct := ds.Product{
-			Uid:             uuid.New(),
-			SupplierUid:     uuid.New(),
-			ImageUid:        uuid.New(),
-			LastUpdateDate:  ds.DateOnlyFromString("01.01.2026"),
-			Name:            "name",
-			Category:        "category",
-			Price:           299.99,
-			AvaliableStocks: 20,
+			Uid:            uuid.New(),
+			SupplierUid:    uuid.New(),
+			ImageUid:       uuid.New(),
+			LastUpdateDate: ds.DateOnlyFromString("01.01.2026"),
+			Name:           "name",
+			Sku:            "sku",
+			CategoryUid:    uuid.New(),
+			Variants:       []ds.ProductVariant{{Sku: "sku", Price: money.New(29999, money.RUB), AvaliableStocks: 20}},
 		}
 
 		jsonBody, err := json.Marshal(&reqStruct)
@@ -76,12 +79,12 @@ func TestPutProduct(t *testing.T) {
 		reqStruct := ds.Product{
 			Uid: uuid.New(),
 			// SupplierUid:     uuid.New(),
-			ImageUid:        uuid.New(),
-			LastUpdateDate:  ds.DateOnlyFromString("01.01.2026"),
-			Name:            "name",
-			Category:        "category",
-			Price:           299.99,
-			AvaliableStocks: 20,
+			ImageUid:       uuid.New(),
+			LastUpdateDate: ds.DateOnlyFromString("01.01.2026"),
+			Name:           "name",
+			Sku:            "sku",
+			CategoryUid:    uuid.New(),
+			Variants:       []ds.ProductVariant{{Sku: "sku", Price: money.New(29999, money.RUB), AvaliableStocks: 20}},
 		}
 
 		jsonBody, err := json.Marshal(&reqStruct)
@@ -107,14 +110,14 @@ func TestPutProduct(t *testing.T) {
 		a := NewTestApi(ctx, t)
 
 		reqStruct := ds.Product{
-			Uid:             uuid.New(),
-			SupplierUid:     uuid.New(),
-			ImageUid:        uuid.New(),
-			LastUpdateDate:  ds.DateOnlyFromString("01.01.2026"),
-			Name:            "name",
-			Category:        "category",
-			Price:           299.99,
-			AvaliableStocks: 20,
+			Uid:            uuid.New(),
+			SupplierUid:    uuid.New(),
+			ImageUid:       uuid.New(),
+			LastUpdateDate: ds.DateOnlyFromString("01.01.2026"),
+			Name:           "name",
+			Sku:            "sku",
+			CategoryUid:    uuid.New(),
+			Variants:       []ds.ProductVariant{{Sku: "sku", Price: money.New(29999, money.RUB), AvaliableStocks: 20}},
 		}
 
 		jsonBody, err := json.Marshal(&reqStruct)
@@ -152,8 +155,8 @@ func TestDecreaseProduct(t *testing.T) {
 		uid := uuid.New()
 
 		req := &ds.DecreaseProductsRequest{
-			Uid:    uid,
-			Amount: int64(12),
+			VariantUid: uid,
+			Amount:     int64(12),
 		}
 
 		jsonBody, err := json.Marshal(req)
@@ -192,7 +195,7 @@ func TestDecreaseProduct(t *testing.T) {
 		uid := uuid.New()
 
 		req := &ds.DecreaseProductsRequest{
-			Uid: uid,
+			VariantUid: uid,
 			// Amount: int64(12),
 		}
 
@@ -221,8 +224,8 @@ func TestDecreaseProduct(t *testing.T) {
 		uid := uuid.New()
 
 		req := &ds.DecreaseProductsRequest{
-			Uid:    uid,
-			Amount: int64(12),
+			VariantUid: uid,
+			Amount:     int64(12),
 		}
 
 		jsonBody, err := json.Marshal(req)
@@ -260,8 +263,8 @@ func TestDecreaseProduct(t *testing.T) {
 		uid := uuid.New()
 
 		req := &ds.DecreaseProductsRequest{
-			Uid:    uid,
-			Amount: int64(12),
+			VariantUid: uid,
+			Amount:     int64(12),
 		}
 
 		jsonBody, err := json.Marshal(req)
@@ -305,14 +308,14 @@ func TestGetProduct(t *testing.T) {
 
 		resp := &ds.GetProductResponse{
 			Product: &ds.Product{
-				Uid:             uuid.New(),
-				SupplierUid:     uuid.New(),
-				ImageUid:        uuid.New(),
-				LastUpdateDate:  ds.DateOnlyFromString("01.01.2026"),
-				Name:            "name",
-				Category:        "category",
-				Price:           299.99,
-				AvaliableStocks: 20,
+				Uid:            uuid.New(),
+				SupplierUid:    uuid.New(),
+				ImageUid:       uuid.New(),
+				LastUpdateDate: ds.DateOnlyFromString("01.01.2026"),
+				Name:           "name",
+				Sku:            "sku",
+				CategoryUid:    uuid.New(),
+				Variants:       []ds.ProductVariant{{Sku: "sku", Price: money.New(29999, money.RUB), AvaliableStocks: 20}},
 			},
 		}
 
@@ -409,6 +412,187 @@ func TestGetProduct(t *testing.T) {
 	})
 }
 
+func TestGetProductBySku(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductBySku 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.GetProductBySkuRequest{
+			Sku: "BEAM-OAK-2M",
+		}
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixProductSku, nil)
+		q := apiReq.URL.Query()
+		q.Add("sku", req.Sku)
+		apiReq.URL.RawQuery = q.Encode()
+
+		resp := &ds.GetProductResponse{
+			Product: &ds.Product{
+				Uid:            uuid.New(),
+				SupplierUid:    uuid.New(),
+				ImageUid:       uuid.New(),
+				LastUpdateDate: ds.DateOnlyFromString("01.01.2026"),
+				Name:           "name",
+				Sku:            "BEAM-OAK",
+				CategoryUid:    uuid.New(),
+				Variants:       []ds.ProductVariant{{Sku: "BEAM-OAK-2M", Price: money.New(29999, money.RUB), AvaliableStocks: 20}},
+			},
+		}
+
+		a.productMock.EXPECT().GetProductBySku(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.GetProductBySku(a.responseWriter, apiReq)
+	})
+
+	t.Run("GetProductBySku 400", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixProductSku, nil)
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetProductBySku(a.responseWriter, apiReq)
+	})
+}
+
+func TestGetProductByBarcode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductByBarcode 404", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.GetProductByBarcodeRequest{
+			Barcode: "4006381333931",
+		}
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixProductBarcode, nil)
+		q := apiReq.URL.Query()
+		q.Add("barcode", req.Barcode)
+		apiReq.URL.RawQuery = q.Encode()
+
+		resp := &ds.GetProductResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}
+
+		a.productMock.EXPECT().GetProductByBarcode(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.GetProductByBarcode(a.responseWriter, apiReq)
+	})
+
+	t.Run("GetProductByBarcode 400 on wrong check digit", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixProductBarcode, nil)
+		q := apiReq.URL.Query()
+		q.Add("barcode", "4006381333932")
+		apiReq.URL.RawQuery = q.Encode()
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetProductByBarcode(a.responseWriter, apiReq)
+	})
+}
+
+func TestGetBarcode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetBarcode 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.GetBarcodeRequest{
+			Code:   "4006381333931",
+			Format: barcode.FormatSVG,
+		}
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixBarcode, nil)
+		q := apiReq.URL.Query()
+		q.Add("code", req.Code)
+		q.Add("format", req.Format)
+		apiReq.URL.RawQuery = q.Encode()
+
+		image, err := barcode.Render(req.Code, req.Format, barcode.DefaultScale, barcode.DefaultHeight)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp := &ds.GetBarcodeResponse{
+			Image: image,
+		}
+
+		header := http.Header{}
+
+		a.productMock.EXPECT().GetBarcode(req).Return(resp)
+		a.responseWriter.EXPECT().Header().Return(header).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(image).Return(len(image), nil)
+
+		a.api.GetBarcode(a.responseWriter, apiReq)
+
+		require.Contains(t, header.Get(contentDispositionKey), ".svg")
+		require.Equal(t, "image/svg+xml", header.Get(contentTypeKey))
+	})
+
+	t.Run("GetBarcode 400", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixBarcode, nil)
+		q := apiReq.URL.Query()
+		q.Add("code", "4006381333931")
+		q.Add("format", "gif")
+		apiReq.URL.RawQuery = q.Encode()
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetBarcode(a.responseWriter, apiReq)
+	})
+}
+
 func TestGetProducts(t *testing.T) {
 	t.Parallel()
 
@@ -432,14 +616,14 @@ func TestGetProducts(t *testing.T) {
 		resp := &ds.GetProductsResponse{
 			Products: []ds.Product{
 				{
-					Uid:             uuid.New(),
-					SupplierUid:     uuid.New(),
-					ImageUid:        uuid.New(),
-					LastUpdateDate:  ds.DateOnlyFromString("01.01.2026"),
-					Name:            "name",
-					Category:        "category",
-					Price:           299.99,
-					AvaliableStocks: 20,
+					Uid:            uuid.New(),
+					SupplierUid:    uuid.New(),
+					ImageUid:       uuid.New(),
+					LastUpdateDate: ds.DateOnlyFromString("01.01.2026"),
+					Name:           "name",
+					Sku:            "sku",
+					CategoryUid:    uuid.New(),
+					Variants:       []ds.ProductVariant{{Sku: "sku", Price: money.New(29999, money.RUB), AvaliableStocks: 20}},
 				},
 			},
 		}
@@ -590,3 +774,265 @@ func TestDeleteProduct(t *testing.T) {
 		a.api.DeleteProduct(a.responseWriter, apiReq)
 	})
 }
+
+func TestRestoreProduct(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RestoreProduct 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.RestoreProductRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixProductRestore, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.RestoreProductResponse{}
+
+		a.productMock.EXPECT().RestoreProduct(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.RestoreProduct(a.responseWriter, apiReq)
+	})
+
+	t.Run("RestoreProduct 409", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.RestoreProductRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixProductRestore, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.RestoreProductResponse{
+			Status: ds.Status{Message: ds.StatusRestoreWithDeletedReference},
+		}
+
+		a.productMock.EXPECT().RestoreProduct(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusConflict)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.RestoreProduct(a.responseWriter, apiReq)
+	})
+}
+
+func TestPutProductVariant(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PutProductVariant 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		uid := uuid.New()
+
+		req := &ds.AddProductVariantRequest{
+			ProductUid: uuid.New(),
+			ProductVariant: ds.ProductVariant{
+				Sku:             "sku",
+				Options:         map[string]string{"size": "L"},
+				Price:           money.New(29999, money.RUB),
+				AvaliableStocks: 20,
+			},
+		}
+
+		jsonBody, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixProductVariant, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.AddProductVariantResponse{
+			Uid: &uid,
+		}
+
+		a.productMock.EXPECT().AddProductVariant(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.PutProductVariant(a.responseWriter, apiReq)
+	})
+
+	t.Run("PutProductVariant 400", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.AddProductVariantRequest{
+			ProductUid: uuid.New(),
+			ProductVariant: ds.ProductVariant{
+				Price:           money.New(29999, money.RUB),
+				AvaliableStocks: 20,
+			},
+		}
+
+		jsonBody, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixProductVariant, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.PutProductVariant(a.responseWriter, apiReq)
+	})
+}
+
+func TestDeleteProductVariant(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteProductVariant 404", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.DeleteProductVariantRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodDelete, prefixProductVariant, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		a.productMock.EXPECT().DeleteProductVariant(req).Return(&ds.DeleteProductVariantResponse{Status: ds.Status{Message: ds.StatusNotFound}})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.DeleteProductVariant(a.responseWriter, apiReq)
+	})
+}
+
+func TestGetProductsByAttributes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProducts by attributes 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.GetProductsRequest{
+			Attributes: []string{"material:oak", "length:2.5"},
+		}
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixProducts, nil)
+		q := apiReq.URL.Query()
+		q.Add("attr", "material:oak")
+		q.Add("attr", "length:2.5")
+		apiReq.URL.RawQuery = q.Encode()
+
+		a.productMock.EXPECT().GetProducts(req).Return(&ds.GetProductsResponse{})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetProducts(a.responseWriter, apiReq)
+	})
+
+	t.Run("GetProducts by attributes 400", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixProducts, nil)
+		q := apiReq.URL.Query()
+		q.Add("attr", "material")
+		apiReq.URL.RawQuery = q.Encode()
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.GetProducts(a.responseWriter, apiReq)
+	})
+}
+
+func TestUpdateProductAttributes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UpdateProductAttributes 400 on invalid attributes", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.UpdateProductAttributesRequest{
+			Uid:        uuid.New(),
+			Attributes: map[string]any{"voltage": "high"},
+		}
+
+		jsonBody, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPut, prefixProductAttrs, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		a.productMock.EXPECT().UpdateProductAttributes(req).Return(&ds.UpdateProductAttributesResponse{
+			Status: ds.Status{Message: ds.StatusInvalidAttributes + ": voltage must be number"},
+		})
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.UpdateProductAttributes(a.responseWriter, apiReq)
+	})
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"net/http"
+	ds "shopapi/internal/datastruct"
+)
+
+const (
+	prefixPromotion    = apiPrefix + "/promotion"
+	prefixPromotions   = apiPrefix + "/promotions"
+	prefixBasket       = apiPrefix + "/basket"
+	prefixBasketPrice  = prefixBasket + "/price"
+	prefixBasketRedeem = prefixBasket + "/redeem"
+)
+
+func (a *API) setupPromotionsHandlers(router IRouter) {
+	router.HandleFunc(pattern(http.MethodPost, prefixPromotion), a.authorize(ds.PermissionPromotionsWrite, a.AddPromotion))
+	router.HandleFunc(pattern(http.MethodPatch, prefixPromotion), a.authorize(ds.PermissionPromotionsWrite, a.UpdatePromotion))
+	router.HandleFunc(pattern(http.MethodGet, prefixPromotions), a.authorize(ds.PermissionPromotionsRead, a.GetPromotions))
+	router.HandleFunc(pattern(http.MethodPost, prefixBasketPrice), a.authorize(ds.PermissionPromotionsRead, a.PriceBasket))
+	router.HandleFunc(pattern(http.MethodPost, prefixBasketRedeem), a.authorize(ds.PermissionPromotionsRedeem, a.RedeemBasket))
+}
+
+// AddPromotion Добавляет акцию
+// @Summary      Добавление акции
+// @Description  Добавляет акцию со скидкой в процентах (kind percent) или фиксированной суммой с каждой единицы (kind fixed).
+// @Description  Акция действует на перечисленные продукты, на продукты категорий с их подкатегориями и поставщиков,
+// @Description  без них - на все продукты. Акция с code применяется только к корзине с этим промокодом.
+// @Description  Суммируемые (stackable) акции применяются вместе, остальные - по одной.
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.AddPromotionRequest  true "Акция"
+// @Success      200   {object}  ds.AddPromotionResponse
+// @Failure      400   {object}  ds.AddPromotionResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /promotion [post]
+func (a *API) AddPromotion(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.AddPromotionRequest, ds.AddPromotionResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.promotionService.AddPromotion,
+	})
+}
+
+// UpdatePromotion Обновляет акцию
+// @Summary      Обновление акции
+// @Description  Заменяет акцию целиком, сделанные использования остаются в ее лимитах. Акция завершается установкой ends_at.
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.UpdatePromotionRequest  true "Акция с uid"
+// @Success      200   {object}  ds.UpdatePromotionResponse
+// @Failure      400   {object}  ds.UpdatePromotionResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      404   {object}  ds.UpdatePromotionResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /promotion [patch]
+func (a *API) UpdatePromotion(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.UpdatePromotionRequest, ds.UpdatePromotionResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.promotionService.UpdatePromotion,
+	})
+}
+
+// GetPromotions возвращает акции
+// @Summary      Список акций
+// @Description  Возвращает действующие и запланированные акции с числом их использований, старшие по priority первыми.
+// @Tags         Promotion
+// @Produce      json
+// @Param        include_expired  query  bool  false  "Вернуть и завершенные акции"
+// @Success      200  {object}  ds.GetPromotionsResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /promotions [get]
+func (a *API) GetPromotions(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetPromotionsRequest, ds.GetPromotionsResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.promotionService.GetPromotions,
+	})
+}
+
+// PriceBasket Рассчитывает корзину
+// @Summary      Расчет корзины
+// @Description  Считает корзину клиента по текущим ценам в валюте currency (по умолчанию RUB) с действующими акциями.
+// @Description  К строке применяется лучшая из акций или все суммируемые вместе, если их скидка больше. Суммируемые
+// @Description  применяются по priority, каждая к остатку. Акции, исчерпавшие лимиты, пропускаются, исчерпанный промокод
+// @Description  отклоняется с 409. Фиксированные скидки пересчитываются в валюту корзины по курсу, акция без курса
+// @Description  пропускается, а промокод без курса отклоняется с 400. Использования не учитываются.
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.PriceBasketRequest  true "Клиент, промокод и позиции корзины"
+// @Success      200   {object}  ds.PriceBasketResponse
+// @Failure      400   {object}  ds.PriceBasketResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      404   {object}  ds.PriceBasketResponse
+// @Failure      409   {object}  ds.PriceBasketResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /basket/price [post]
+func (a *API) PriceBasket(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.PriceBasketRequest, ds.PriceBasketResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.promotionService.PriceBasket,
+	})
+}
+
+// RedeemBasket Применяет акции к заказу
+// @Summary      Оформление корзины
+// @Description  Считает корзину оформленного заказа как /basket/price и учитывает примененные акции в их лимитах.
+// @Description  Если акция исчерпала лимит после расчета, ничего не учитывается и возвращается 409, корзину нужно пересчитать.
+// @Description  Использования учитываются один раз на order_id, повторный запрос того же заказа возвращает 200 и не учитывает их снова.
+// @Description  order_id, уже оформленный другим клиентом, отклоняется с 409.
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.RedeemBasketRequest  true "Заказ, клиент, промокод и позиции корзины"
+// @Success      200   {object}  ds.PriceBasketResponse
+// @Failure      400   {object}  ds.PriceBasketResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      404   {object}  ds.PriceBasketResponse
+// @Failure      409   {object}  ds.PriceBasketResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /basket/redeem [post]
+func (a *API) RedeemBasket(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.RedeemBasketRequest, ds.PriceBasketResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.promotionService.RedeemBasket,
+	})
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPromotion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddPromotion 200", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		body := `{"name":"Ten off","code":"TEN","kind":"fixed","amount":"100 RUB","per_client_limit":1}`
+		apiReq := httptest.NewRequest(http.MethodPost, prefixPromotion, strings.NewReader(body))
+
+		uid := uuid.New()
+		a.promotionMock.EXPECT().AddPromotion(gomock.Any()).DoAndReturn(func(req *ds.AddPromotionRequest) *ds.AddPromotionResponse {
+			require.Equal(t, "TEN", req.Code)
+			require.Equal(t, money.New(10000, money.RUB), *req.Amount)
+			require.Equal(t, int32(1), *req.PerClientLimit)
+			return &ds.AddPromotionResponse{Uid: &uid}
+		})
+
+		w := httptest.NewRecorder()
+		a.api.AddPromotion(w, apiReq)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), uid.String())
+	})
+
+	t.Run("AddPromotion unknown kind", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		body := `{"name":"Gift","kind":"gift"}`
+		apiReq := httptest.NewRequest(http.MethodPost, prefixPromotion, strings.NewReader(body))
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		w := httptest.NewRecorder()
+		a.api.AddPromotion(w, apiReq)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("AddPromotion invalid discount", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		body := `{"name":"Zero","kind":"percent"}`
+		apiReq := httptest.NewRequest(http.MethodPost, prefixPromotion, strings.NewReader(body))
+
+		a.promotionMock.EXPECT().AddPromotion(gomock.Any()).Return(&ds.AddPromotionResponse{
+			Status: ds.Status{Message: ds.StatusInvalidDiscount},
+		})
+
+		w := httptest.NewRecorder()
+		a.api.AddPromotion(w, apiReq)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestUpdatePromotion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UpdatePromotion 404", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		body := `{"uid":"` + uuid.NewString() + `","name":"Spring sale","kind":"percent","percent":25}`
+		apiReq := httptest.NewRequest(http.MethodPatch, prefixPromotion, strings.NewReader(body))
+
+		a.promotionMock.EXPECT().UpdatePromotion(gomock.Any()).Return(&ds.UpdatePromotionResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		})
+
+		w := httptest.NewRecorder()
+		a.api.UpdatePromotion(w, apiReq)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestGetPromotions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetPromotions 200", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixPromotions+"?include_expired=true", nil)
+
+		a.promotionMock.EXPECT().GetPromotions(&ds.GetPromotionsRequest{IncludeExpired: true}).
+			Return(&ds.GetPromotionsResponse{Promotions: []ds.Promotion{{Name: "Spring sale"}}})
+
+		w := httptest.NewRecorder()
+		a.api.GetPromotions(w, apiReq)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"name":"Spring sale"`)
+	})
+}
+
+func TestPriceBasket(t *testing.T) {
+	t.Parallel()
+
+	clientUid := uuid.New()
+	productUid := uuid.New()
+
+	t.Run("PriceBasket 200", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		body := `{"client_id":"` + clientUid.String() + `","code":"spring25",` +
+			`"items":[{"product_id":"` + productUid.String() + `","quantity":2}]}`
+		apiReq := httptest.NewRequest(http.MethodPost, prefixBasketPrice, strings.NewReader(body))
+
+		a.promotionMock.EXPECT().PriceBasket(gomock.Any()).DoAndReturn(func(req *ds.PriceBasketRequest) *ds.PriceBasketResponse {
+			require.Equal(t, clientUid, req.ClientUid)
+			require.Equal(t, "spring25", req.Code)
+			require.Equal(t, []ds.BasketItem{{ProductUid: productUid, Quantity: 2}}, req.Items)
+			return &ds.PriceBasketResponse{
+				Subtotal: money.New(59990, money.RUB),
+				Discount: money.New(14998, money.RUB),
+				Total:    money.New(44992, money.RUB),
+			}
+		})
+
+		w := httptest.NewRecorder()
+		a.api.PriceBasket(w, apiReq)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"total":"449.92 RUB"`)
+	})
+
+	t.Run("PriceBasket without items", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		body := `{"client_id":"` + clientUid.String() + `","items":[]}`
+		apiReq := httptest.NewRequest(http.MethodPost, prefixBasketPrice, strings.NewReader(body))
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		w := httptest.NewRecorder()
+		a.api.PriceBasket(w, apiReq)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("PriceBasket zero quantity", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		body := `{"client_id":"` + clientUid.String() + `","items":[{"product_id":"` + productUid.String() + `","quantity":0}]}`
+		apiReq := httptest.NewRequest(http.MethodPost, prefixBasketPrice, strings.NewReader(body))
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		w := httptest.NewRecorder()
+		a.api.PriceBasket(w, apiReq)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("PriceBasket code exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		body := `{"client_id":"` + clientUid.String() + `","code":"SPRING25",` +
+			`"items":[{"product_id":"` + productUid.String() + `","quantity":1}]}`
+		apiReq := httptest.NewRequest(http.MethodPost, prefixBasketPrice, strings.NewReader(body))
+
+		a.promotionMock.EXPECT().PriceBasket(gomock.Any()).Return(&ds.PriceBasketResponse{
+			Status: ds.Status{Message: ds.StatusPromoCodeExhausted},
+		})
+
+		w := httptest.NewRecorder()
+		a.api.PriceBasket(w, apiReq)
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestRedeemBasket(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RedeemBasket exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		clientUid := uuid.New()
+		body := `{"client_id":"` + clientUid.String() + `","order_id":"ORD-1","items":[{"product_id":"` + uuid.NewString() + `","quantity":1}]}`
+		apiReq := httptest.NewRequest(http.MethodPost, prefixBasketRedeem, strings.NewReader(body))
+
+		a.promotionMock.EXPECT().RedeemBasket(gomock.Any()).DoAndReturn(func(req *ds.RedeemBasketRequest) *ds.PriceBasketResponse {
+			require.Equal(t, clientUid, req.ClientUid)
+			require.Equal(t, "ORD-1", req.OrderID)
+			return &ds.PriceBasketResponse{Status: ds.Status{Message: ds.StatusPromotionExhausted}}
+		})
+
+		w := httptest.NewRecorder()
+		a.api.RedeemBasket(w, apiReq)
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/ratelimit"
+	"shopapi/internal/supports"
+)
+
+const (
+	retryAfterKey         = "Retry-After"
+	rateLimitLimitKey     = "RateLimit-Limit"
+	rateLimitRemainingKey = "RateLimit-Remaining"
+	rateLimitResetKey     = "RateLimit-Reset"
+)
+
+// routeLimit is the bucket the route takes its requests from, the routes of the same name share it.
+type routeLimit struct {
+	name  string
+	limit ratelimit.Limit
+}
+
+var (
+	defaultRouteLimit = routeLimit{name: "default", limit: ratelimit.PerMinute(600, 100)}
+	uploadRouteLimit  = routeLimit{name: "upload", limit: ratelimit.PerMinute(30, 10)}
+	stockRouteLimit   = routeLimit{name: "stock", limit: ratelimit.PerMinute(120, 20)}
+)
+
+// routeLimits are the routes limited stricter than the default: the uploads are scanned and thumbnailed,
+// and the stock decrease locks the product rows.
+var routeLimits = map[string]routeLimit{
+	pattern(http.MethodPost, prefixImage):           uploadRouteLimit,
+	pattern(http.MethodPost, prefixProductDocument): uploadRouteLimit,
+	pattern(http.MethodPatch, prefixProduct):        stockRouteLimit,
+}
+
+// rateLimitHandler takes every request from the bucket of the client IP and the route before it is
+// authenticated, so the requests with made up keys are limited as well. The limiter failure lets the request through.
+func (a *API) rateLimitHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, swaggerPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if a.takeRequest(w, r, supports.Concat("ip:", clientIP(r))) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// keyRateLimitHandler takes the request authenticated by an API key from the bucket of the key too,
+// so a key used from several addresses still gets a single rate.
+func (a *API) keyRateLimitHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := principalFromContext(r.Context())
+		if !ok || principal.ApiKeyUid == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if a.takeRequest(w, r, supports.Concat("key:", principal.ApiKeyUid.String())) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// takeRequest takes the request from the caller's bucket of the route and answers 429 when it's empty.
+// It reports whether the request is to be served.
+func (a *API) takeRequest(w http.ResponseWriter, r *http.Request, caller string) bool {
+	route, ok := routeLimits[pattern(r.Method, r.URL.Path)]
+	if !ok {
+		route = defaultRouteLimit
+	}
+
+	res, err := a.limiter.Allow(supports.Concat(route.name, ":", caller), route.limit)
+	if err != nil {
+		a.logger.WarnKV("rate limiter failed", "path", r.URL.Path, "error", err.Error())
+		return true
+	}
+
+	setRateLimitHeaders(w, res)
+	if res.Allowed {
+		return true
+	}
+
+	w.Header().Set(retryAfterKey, strconv.Itoa(ceilSeconds(res.RetryAfter)))
+
+	status := ds.Status{Message: ds.StatusTooManyRequests}
+	a.logger.WarnKV("request rate limited", "path", r.URL.Path, "limit", route.name)
+	if err = writeJsonResponse(&w, r, status); err != nil {
+		a.logger.ErrorKV("failed write response", "error", err.Error(), "response", status)
+	}
+	return false
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// setRateLimitHeaders reports the bucket closer to running out of the IP and the key ones.
+func setRateLimitHeaders(w http.ResponseWriter, res ratelimit.Result) {
+	if remaining, err := strconv.Atoi(w.Header().Get(rateLimitRemainingKey)); err == nil && remaining <= res.Remaining {
+		return
+	}
+
+	w.Header().Set(rateLimitLimitKey, strconv.Itoa(res.Limit))
+	w.Header().Set(rateLimitRemainingKey, strconv.Itoa(res.Remaining))
+	w.Header().Set(rateLimitResetKey, strconv.Itoa(ceilSeconds(res.Reset)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
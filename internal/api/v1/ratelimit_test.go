@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/ratelimit"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// serveRateLimited runs the request through the rate limiter and reports whether the next handler was reached.
+func serveRateLimited(a *TestAPI, r *http.Request) (*httptest.ResponseRecorder, bool) {
+	reached := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	a.api.rateLimitHandler(next).ServeHTTP(rec, r)
+	return rec, reached
+}
+
+// serveKeyRateLimited runs the request of the principal through the key rate limiter and reports whether the next handler was reached.
+func serveKeyRateLimited(a *TestAPI, r *http.Request, principal *ds.Principal) (*httptest.ResponseRecorder, bool) {
+	reached := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if principal != nil {
+		r = r.WithContext(context.WithValue(r.Context(), principalKey{}, principal))
+	}
+
+	rec := httptest.NewRecorder()
+	a.api.keyRateLimitHandler(next).ServeHTTP(rec, r)
+	return rec, reached
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RateLimit allowed", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		r := httptest.NewRequest(http.MethodGet, prefixProducts, nil)
+		r.RemoteAddr = "10.0.0.1:54321"
+
+		a.limiterMock.EXPECT().Allow("default:ip:10.0.0.1", defaultRouteLimit.limit).
+			Return(ratelimit.Result{Allowed: true, Limit: 100, Remaining: 99, Reset: 100 * time.Millisecond}, nil)
+
+		rec, reached := serveRateLimited(a, r)
+		require.True(t, reached)
+		require.Equal(t, "100", rec.Header().Get(rateLimitLimitKey))
+		require.Equal(t, "99", rec.Header().Get(rateLimitRemainingKey))
+		require.Equal(t, "1", rec.Header().Get(rateLimitResetKey))
+		require.Empty(t, rec.Header().Get(retryAfterKey))
+	})
+
+	t.Run("RateLimit refused", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		r := httptest.NewRequest(http.MethodPost, prefixImage, nil)
+		r.RemoteAddr = "10.0.0.3:1000"
+		r.Header.Set(apiKeyKey, "sk_guessed")
+
+		a.limiterMock.EXPECT().Allow("upload:ip:10.0.0.3", uploadRouteLimit.limit).
+			Return(ratelimit.Result{Limit: 10, RetryAfter: 1500 * time.Millisecond, Reset: 20 * time.Second}, nil)
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		rec, reached := serveRateLimited(a, r)
+		require.False(t, reached)
+		require.Equal(t, http.StatusTooManyRequests, rec.Code)
+		require.Equal(t, "2", rec.Header().Get(retryAfterKey))
+		require.Equal(t, "0", rec.Header().Get(rateLimitRemainingKey))
+		require.Equal(t, "20", rec.Header().Get(rateLimitResetKey))
+		require.Contains(t, rec.Body.String(), ds.StatusTooManyRequests)
+	})
+
+	t.Run("RateLimit stock route", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		r := httptest.NewRequest(http.MethodPatch, prefixProduct, nil)
+		r.RemoteAddr = "10.0.0.2:1000"
+
+		a.limiterMock.EXPECT().Allow("stock:ip:10.0.0.2", stockRouteLimit.limit).
+			Return(ratelimit.Result{Allowed: true}, nil)
+
+		_, reached := serveRateLimited(a, r)
+		require.True(t, reached)
+	})
+
+	t.Run("RateLimit limiter error", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		a.limiterMock.EXPECT().Allow(gomock.Any(), gomock.Any()).Return(ratelimit.Result{}, errors.New("error"))
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		rec, reached := serveRateLimited(a, httptest.NewRequest(http.MethodGet, prefixProducts, nil))
+		require.True(t, reached)
+		require.Empty(t, rec.Header().Get(rateLimitLimitKey))
+	})
+
+	t.Run("RateLimit swagger is not limited", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		_, reached := serveRateLimited(a, httptest.NewRequest(http.MethodGet, swaggerPrefix+"index.html", nil))
+		require.True(t, reached)
+	})
+}
+
+func TestKeyRateLimitHandler(t *testing.T) {
+	t.Parallel()
+
+	keyUid := uuid.MustParse("9a0c6a52-3c57-4c61-9c1e-7b3c1c8a5b21")
+
+	t.Run("KeyRateLimit allowed", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		r := httptest.NewRequest(http.MethodGet, prefixProducts, nil)
+		rec := httptest.NewRecorder()
+		rec.Header().Set(rateLimitRemainingKey, "5")
+
+		a.limiterMock.EXPECT().Allow("default:key:"+keyUid.String(), defaultRouteLimit.limit).
+			Return(ratelimit.Result{Allowed: true, Limit: 100, Remaining: 50, Reset: time.Second}, nil)
+
+		reached := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { reached = true })
+		r = r.WithContext(context.WithValue(r.Context(), principalKey{}, &ds.Principal{ApiKeyUid: &keyUid}))
+		a.api.keyRateLimitHandler(next).ServeHTTP(rec, r)
+
+		require.True(t, reached)
+		require.Equal(t, "5", rec.Header().Get(rateLimitRemainingKey))
+	})
+
+	t.Run("KeyRateLimit refused", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		a.limiterMock.EXPECT().Allow("upload:key:"+keyUid.String(), uploadRouteLimit.limit).
+			Return(ratelimit.Result{Limit: 10, RetryAfter: time.Second, Reset: 20 * time.Second}, nil)
+		a.loggerMock.EXPECT().WarnKV(gomock.Any(), gomock.Any())
+
+		rec, reached := serveKeyRateLimited(a, httptest.NewRequest(http.MethodPost, prefixImage, nil), &ds.Principal{ApiKeyUid: &keyUid})
+		require.False(t, reached)
+		require.Equal(t, http.StatusTooManyRequests, rec.Code)
+		require.Equal(t, "1", rec.Header().Get(retryAfterKey))
+	})
+
+	t.Run("KeyRateLimit without key", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		_, reached := serveKeyRateLimited(a, httptest.NewRequest(http.MethodGet, prefixProducts, nil), &ds.Principal{})
+		require.True(t, reached)
+
+		_, reached = serveKeyRateLimited(a, httptest.NewRequest(http.MethodGet, prefixProducts, nil), nil)
+		require.True(t, reached)
+	})
+}
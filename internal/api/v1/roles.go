@@ -0,0 +1,129 @@
+package api
+
+import (
+	"net/http"
+	ds "shopapi/internal/datastruct"
+)
+
+const (
+	prefixRole  = apiPrefix + "/role"
+	prefixRoles = apiPrefix + "/roles"
+)
+
+func (a *API) setupRolesHandlers(router IRouter) {
+	router.HandleFunc(pattern(http.MethodGet, prefixRoles), a.authorize(ds.PermissionRolesManage, a.GetRoles))
+	router.HandleFunc(pattern(http.MethodPost, prefixRole), a.authorize(ds.PermissionRolesManage, a.PutRole))
+	router.HandleFunc(pattern(http.MethodPatch, prefixRole), a.authorize(ds.PermissionRolesManage, a.UpdateRole))
+	router.HandleFunc(pattern(http.MethodDelete, prefixRole), a.authorize(ds.PermissionRolesManage, a.DeleteRole))
+}
+
+// GetRoles Возвращает роли
+// @Summary      Список ролей
+// @Description  Возвращает роли с их правами. Право "*" дает доступ ко всем маршрутам.
+// @Tags         Auth
+// @Produce      json
+// @Success      200  {object}  ds.GetRolesResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
+// @Failure      500  {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /roles [get]
+func (a *API) GetRoles(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.GetRolesRequest, ds.GetRolesResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractSchemaQuery,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.authService.GetRoles,
+	})
+}
+
+// PutRole Добавляет роль
+// @Summary      Добавление роли
+// @Description  Добавляет роль с набором прав. Права на запись не включают права на чтение.
+// @Description  Роль может дать только права, которые есть у вызывающего, иначе возвращается 403 с missing_permissions.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.AddRoleRequest  true "Название, описание и права роли"
+// @Success      200   {object}  ds.AddRoleResponse
+// @Failure      400   {object}  ds.AddRoleResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /role [post]
+func (a *API) PutRole(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.AddRoleRequest, ds.AddRoleResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.authService.AddRole,
+	})
+}
+
+// UpdateRole Обновляет роль
+// @Summary      Обновление роли
+// @Description  Заменяет описание и права роли, новые права действуют со следующего запроса. Роль admin изменить нельзя.
+// @Description  Роль может дать только права, которые есть у вызывающего, иначе возвращается 403 с missing_permissions.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.UpdateRoleRequest  true "Название, описание и права роли"
+// @Success      200   {object}  ds.UpdateRoleResponse
+// @Failure      400   {object}  ds.Status
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      404   {object}  ds.UpdateRoleResponse
+// @Failure      409   {object}  ds.UpdateRoleResponse
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /role [patch]
+func (a *API) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.UpdateRoleRequest, ds.UpdateRoleResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.authService.UpdateRole,
+	})
+}
+
+// DeleteRole Удаляет роль
+// @Summary      Удаление роли
+// @Description  Удаляет роль. Роль, назначенную действующим ключам API, удалить нельзя, такие ключи перечисляются в api_keys. Роль admin удалить нельзя.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.DeleteRoleRequest  true "Название роли"
+// @Success      200   {object}  ds.DeleteRoleResponse
+// @Failure      400   {object}  ds.Status
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      404   {object}  ds.DeleteRoleResponse
+// @Failure      409   {object}  ds.DeleteRoleResponse
+// @Failure      500   {object}  ds.Status
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /role [delete]
+func (a *API) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.DeleteRoleRequest, ds.DeleteRoleResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.authService.DeleteRole,
+	})
+}
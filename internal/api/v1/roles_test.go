@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ds "shopapi/internal/datastruct"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func TestGetRoles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetRoles 200", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		apiReq := httptest.NewRequest(http.MethodGet, prefixRoles, nil)
+
+		resp := &ds.GetRolesResponse{Roles: []ds.Role{{Name: ds.RoleAdmin, Permissions: []string{ds.PermissionAll}}}}
+
+		a.authMock.EXPECT().GetRoles(&ds.GetRolesRequest{}).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.GetRoles(a.responseWriter, apiReq)
+	})
+}
+
+func TestPutRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PutRole 200", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		req := &ds.AddRoleRequest{Role: ds.Role{
+			Name:        "auditor",
+			Permissions: []string{ds.PermissionClientsRead, ds.PermissionProductsRead},
+		}}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixRole, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.AddRoleResponse{Status: ds.Status{Message: ds.StatusOK}}
+
+		a.authMock.EXPECT().AddRole(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.PutRole(a.responseWriter, apiReq)
+	})
+
+	t.Run("PutRole unknown permission", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixRole,
+			strings.NewReader(`{"name":"auditor","permissions":["clients:delete"]}`))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.PutRole(a.responseWriter, apiReq)
+	})
+}
+
+func TestUpdateRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UpdateRole 409", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		req := &ds.UpdateRoleRequest{Role: ds.Role{Name: ds.RoleAdmin, Permissions: []string{ds.PermissionProductsRead}}}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPatch, prefixRole, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.UpdateRoleResponse{Status: ds.Status{Message: ds.StatusRoleProtected}}
+
+		a.authMock.EXPECT().UpdateRole(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusConflict)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.UpdateRole(a.responseWriter, apiReq)
+	})
+}
+
+func TestDeleteRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteRole 409", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewTestApi(context.Background(), t)
+
+		req := &ds.DeleteRoleRequest{Name: "crm"}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodDelete, prefixRole, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.DeleteRoleResponse{
+			Status:  ds.Status{Message: ds.StatusRoleInUse},
+			ApiKeys: []uuid.UUID{uuid.New()},
+		}
+
+		a.authMock.EXPECT().DeleteRole(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusConflict)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.DeleteRole(a.responseWriter, apiReq)
+	})
+}
@@ -8,15 +8,17 @@ import (
 const (
 	prefixSupplier        = apiPrefix + "/supplier"
 	prefixSupplierAddress = prefixSupplier + "/address"
+	prefixSupplierRestore = prefixSupplier + "/restore"
 	prefixSuppliers       = apiPrefix + "/suppliers"
 )
 
 func (a *API) setupSuppliersHandlers(router IRouter) {
-	router.HandleFunc(pattern(http.MethodPost, prefixSupplier), a.PutSupplier)
-	router.HandleFunc(pattern(http.MethodPatch, prefixSupplierAddress), a.UpdateSupplierAddress)
-	router.HandleFunc(pattern(http.MethodDelete, prefixSupplier), a.DeleteSupplier)
-	router.HandleFunc(pattern(http.MethodGet, prefixSuppliers), a.GetSuppliers)
-	router.HandleFunc(pattern(http.MethodGet, prefixSupplier), a.GetSupplier)
+	router.HandleFunc(pattern(http.MethodPost, prefixSupplier), a.authorize(ds.PermissionSuppliersWrite, a.PutSupplier))
+	router.HandleFunc(pattern(http.MethodPatch, prefixSupplierAddress), a.authorize(ds.PermissionSuppliersWrite, a.UpdateSupplierAddress))
+	router.HandleFunc(pattern(http.MethodDelete, prefixSupplier), a.authorize(ds.PermissionSuppliersWrite, a.DeleteSupplier))
+	router.HandleFunc(pattern(http.MethodPost, prefixSupplierRestore), a.authorize(ds.PermissionSuppliersWrite, a.RestoreSupplier))
+	router.HandleFunc(pattern(http.MethodGet, prefixSuppliers), a.authorize(ds.PermissionSuppliersRead, a.GetSuppliers))
+	router.HandleFunc(pattern(http.MethodGet, prefixSupplier), a.authorize(ds.PermissionSuppliersRead, a.GetSupplier))
 }
 
 // PutSupplier Добавляет нового поставщика
@@ -28,7 +30,12 @@ func (a *API) setupSuppliersHandlers(router IRouter) {
 // @Param        input body      ds.AddSupplierRequest  true "Информация о поставщике"
 // @Success      200   {object}  ds.AddSupplierResponse
 // @Failure      400   {object}  ds.AddSupplierResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
 // @Failure      500   {object}  ds.AddSupplierResponse
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /supplier [post]
 func (a *API) PutSupplier(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.AddSupplierRequest, ds.AddSupplierResponse]{
@@ -50,7 +57,12 @@ func (a *API) PutSupplier(w http.ResponseWriter, r *http.Request) {
 // @Param        input body      ds.UpdateSupplierAddressRequest  true "uid и адрес"
 // @Success      200   {object}  ds.UpdateSupplierAddressResponse
 // @Failure      400   {object}  ds.UpdateSupplierAddressResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
 // @Failure      500   {object}  ds.UpdateSupplierAddressResponse
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /supplier/address [patch]
 func (a *API) UpdateSupplierAddress(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.UpdateSupplierAddressRequest, ds.UpdateSupplierAddressResponse]{
@@ -65,14 +77,23 @@ func (a *API) UpdateSupplierAddress(w http.ResponseWriter, r *http.Request) {
 
 // DeleteSupplier Удаляет поставщика
 // @Summary      Удаление поставщика
-// @Description  Удаление поставщика
+// @Description  Удаление поставщика. Если у поставщика есть товары, по умолчанию (policy=restrict) вернется 409
+// @Description  со списком этих товаров, с policy=cascade товары удаляются вместе с поставщиком,
+// @Description  с policy=reassign они передаются поставщику reassign_to.
+// @Description  Удаленные поставщик и товары переносятся в корзину и удаляются окончательно по истечении срока хранения.
 // @Tags         Supplier
 // @Accept       json
 // @Produce      json
 // @Param        input body      ds.DeleteSupplierRequest  true "uid"
 // @Success      200   {object}  ds.DeleteSupplierResponse
 // @Failure      400   {object}  ds.DeleteSupplierResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      409   {object}  ds.DeleteSupplierResponse
 // @Failure      500   {object}  ds.DeleteSupplierResponse
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /supplier [delete]
 func (a *API) DeleteSupplier(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.DeleteSupplierRequest, ds.DeleteSupplierResponse]{
@@ -85,16 +106,51 @@ func (a *API) DeleteSupplier(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RestoreSupplier Восстанавливает поставщика
+// @Summary      Восстановление поставщика
+// @Description  Восстанавливает удаленного поставщика из корзины. Товары, удаленные вместе с ним, восстанавливаются отдельно.
+// @Tags         Supplier
+// @Accept       json
+// @Produce      json
+// @Param        input body      ds.RestoreSupplierRequest  true "uid"
+// @Success      200   {object}  ds.RestoreSupplierResponse
+// @Failure      400   {object}  ds.RestoreSupplierResponse
+// @Failure      401   {object}  ds.Status
+// @Failure      403   {object}  ds.ForbiddenResponse
+// @Failure      429   {object}  ds.Status
+// @Failure      500   {object}  ds.RestoreSupplierResponse
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Router       /supplier/restore [post]
+func (a *API) RestoreSupplier(w http.ResponseWriter, r *http.Request) {
+	Exec(ExecArgs[ds.RestoreSupplierRequest, ds.RestoreSupplierResponse]{
+		api:              a,
+		httpRequest:      r,
+		httpResponse:     &w,
+		requestExtractor: extractJsonBody,
+		responseWriter:   writeJsonResponse,
+		serviceFunc:      a.supplierService.RestoreSupplier,
+	})
+}
+
 // GetSupplier возвращает поставщика
 // @Summary      Возвращает поставщика
-// @Description  Возвращает поставщика
+// @Description  Возвращает поставщика. С include_deleted=true вернет и поставщика из корзины.
+// @Description  С as_of вернет поставщика таким, каким он был в этот момент.
 // @Tags         Supplier
 // @Produce      json
-// @Param        uid            query  string  true  "uid"         example("609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4")
-// @Param        avoid_cache    query  string  false "avoid_cache" example(true)
+// @Param        uid             query  string  true  "uid"             example("609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4")
+// @Param        include_deleted query  string  false "include_deleted" example(true)
+// @Param        avoid_cache     query  string  false "avoid_cache"     example(true)
+// @Param        as_of           query  string  false "Момент времени, RFC 3339" example("2025-01-01T00:00:00Z")
 // @Success      200  {object}  ds.GetSupplierResponse
 // @Failure      400  {object}  ds.GetSupplierResponse
+// @Failure      401  {object}  ds.Status
+// @Failure      403  {object}  ds.ForbiddenResponse
+// @Failure      429  {object}  ds.Status
 // @Failure      500  {object}  ds.GetSupplierResponse
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /supplier [get]
 func (a *API) GetSupplier(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.GetSupplierRequest, ds.GetSupplierResponse]{
@@ -109,15 +165,21 @@ func (a *API) GetSupplier(w http.ResponseWriter, r *http.Request) {
 
 // GetSuppliers возвращает поставщиков
 // @Summary      Возвращает поставщиков
-// @Description  Возвращает поставщиков
+// @Description  Возвращает поставщиков. С include_deleted=true вернет также поставщиков из корзины.
 // @Tags         Supplier
 // @Produce      json
-// @Param        offset      query  string true  "offset"      example(0)
-// @Param        limit       query  string true  "limit"       example(10)
-// @Param        avoid_cache query  string false "avoid_cache" example(true)
+// @Param        offset          query  string true  "offset"          example(0)
+// @Param        limit           query  string true  "limit"           example(10)
+// @Param        include_deleted query  string false "include_deleted" example(true)
+// @Param        avoid_cache     query  string false "avoid_cache"     example(true)
 // @Success      200    {object}  ds.GetSuppliersResponse
 // @Failure      400    {object}  ds.GetSuppliersResponse
+// @Failure      401    {object}  ds.Status
+// @Failure      403    {object}  ds.ForbiddenResponse
+// @Failure      429    {object}  ds.Status
 // @Failure      500    {object}  ds.GetSuppliersResponse
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Router       /suppliers [get]
 func (a *API) GetSuppliers(w http.ResponseWriter, r *http.Request) {
 	Exec(ExecArgs[ds.GetSuppliersRequest, ds.GetSuppliersResponse]{
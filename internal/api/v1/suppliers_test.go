@@ -346,6 +346,70 @@ func TestDeleteSupplier(t *testing.T) {
 		a.api.DeleteSupplier(a.responseWriter, testReq)
 	})
 
+	t.Run("DeleteSupplier 400 on reassign without supplier", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.DeleteSupplierRequest{
+			Uid:    uuid.New(),
+			Policy: ds.DeleteReassign,
+		}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodDelete, prefixSupplier, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		a.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any())
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusBadRequest)
+		a.responseWriter.EXPECT().Write(gomock.Any())
+
+		a.api.DeleteSupplier(a.responseWriter, testReq)
+	})
+
+	t.Run("DeleteSupplier 409", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.DeleteSupplierRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testReq := httptest.NewRequest(http.MethodDelete, prefixSupplier, strings.NewReader(string(jsonBody)))
+		testReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.DeleteSupplierResponse{
+			Status:   ds.Status{Message: ds.StatusConflict},
+			Products: []uuid.UUID{uuid.New()},
+		}
+
+		a.supplierMock.EXPECT().DeleteSupplier(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusConflict)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.DeleteSupplier(a.responseWriter, testReq)
+	})
+
 	t.Run("DeleteSupplier 404", func(t *testing.T) {
 		t.Parallel()
 
@@ -411,6 +475,80 @@ func TestDeleteSupplier(t *testing.T) {
 	})
 }
 
+func TestRestoreSupplier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RestoreSupplier 200", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.RestoreSupplierRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixSupplierRestore, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.RestoreSupplierResponse{}
+
+		a.supplierMock.EXPECT().RestoreSupplier(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusOK)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.RestoreSupplier(a.responseWriter, apiReq)
+	})
+
+	t.Run("RestoreSupplier 404", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		a := NewTestApi(ctx, t)
+
+		req := &ds.RestoreSupplierRequest{
+			Uid: uuid.New(),
+		}
+
+		jsonBody, err := json.Marshal(&req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiReq := httptest.NewRequest(http.MethodPost, prefixSupplierRestore, strings.NewReader(string(jsonBody)))
+		apiReq.Header.Set("Content-Type", "application/json")
+
+		resp := &ds.RestoreSupplierResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}
+
+		a.supplierMock.EXPECT().RestoreSupplier(req).Return(resp)
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+
+		a.responseWriter.EXPECT().Header().Return(http.Header{}).MinTimes(1)
+		a.responseWriter.EXPECT().WriteHeader(http.StatusNotFound)
+		a.responseWriter.EXPECT().Write(buf.Bytes())
+
+		a.api.RestoreSupplier(a.responseWriter, apiReq)
+	})
+}
+
 func TestGetSupplier(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const (
+	// KeyPrefix marks the API keys, so a leaked one is easy to find in logs and repositories.
+	KeyPrefix = "sk_"
+
+	keyBytes        = 32
+	shownPrefixSize = len(KeyPrefix) + 6
+)
+
+// NewAPIKey returns a random API key. Only its hash is stored, the key itself is shown once on issue.
+func NewAPIKey() (string, error) {
+	b := make([]byte, keyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return KeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashAPIKey returns the hash the key is stored and looked up by. The keys are random enough
+// for a plain SHA-256, a slow password hash would only slow down every request.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ShownPrefix returns the head of the key listed along with its name to tell the keys apart.
+func ShownPrefix(key string) string {
+	if len(key) <= shownPrefixSize {
+		return key
+	}
+	return key[:shownPrefixSize]
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NewAPIKey unique", func(t *testing.T) {
+		t.Parallel()
+
+		a, err := NewAPIKey()
+		require.Nil(t, err)
+		b, err := NewAPIKey()
+		require.Nil(t, err)
+
+		require.True(t, strings.HasPrefix(a, KeyPrefix))
+		require.NotEqual(t, a, b)
+		require.NotEqual(t, HashAPIKey(a), HashAPIKey(b))
+	})
+
+	t.Run("HashAPIKey stable", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, HashAPIKey("sk_key"), HashAPIKey("sk_key"))
+		require.Len(t, HashAPIKey("sk_key"), 64)
+	})
+
+	t.Run("ShownPrefix", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "sk_abcdef", ShownPrefix("sk_abcdefghijk"))
+		require.Equal(t, "sk_ab", ShownPrefix("sk_ab"))
+	})
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestParseJWT(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+
+	t.Run("ParseJWT ok", func(t *testing.T) {
+		t.Parallel()
+
+		claims := Claims{Subject: "admin", IssuedAt: now.Unix(), ExpiresAt: now.Add(time.Hour).Unix()}
+		token, err := SignJWT(claims, testKey)
+		require.Nil(t, err)
+
+		res, err := ParseJWT(token, testKey, now)
+		require.Nil(t, err)
+		require.Equal(t, &claims, res)
+	})
+
+	t.Run("ParseJWT known token", func(t *testing.T) {
+		t.Parallel()
+
+		// Signed by another implementation with the test key.
+		token := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+			"eyJzdWIiOiJhZG1pbiIsImV4cCI6MTcwMDAwMzYwMH0." +
+			"r69KHcPsyeabrUjtZjX2Oh9yb6jCf-WUfTRSUe3VHrE"
+
+		res, err := ParseJWT(token, testKey, now)
+		require.Nil(t, err)
+		require.Equal(t, "admin", res.Subject)
+		require.Equal(t, int64(1700003600), res.ExpiresAt)
+	})
+
+	t.Run("ParseJWT wrong key", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := SignJWT(Claims{Subject: "admin", ExpiresAt: now.Add(time.Hour).Unix()}, testKey)
+		require.Nil(t, err)
+
+		_, err = ParseJWT(token, []byte("another key another key another k"), now)
+		require.ErrorIs(t, err, ErrBadSignature)
+	})
+
+	t.Run("ParseJWT tampered claims", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := SignJWT(Claims{Subject: "user", ExpiresAt: now.Add(time.Hour).Unix()}, testKey)
+		require.Nil(t, err)
+
+		parts := strings.Split(token, ".")
+		parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin","exp":1700003600}`))
+
+		_, err = ParseJWT(strings.Join(parts, "."), testKey, now)
+		require.ErrorIs(t, err, ErrBadSignature)
+	})
+
+	t.Run("ParseJWT alg none", func(t *testing.T) {
+		t.Parallel()
+
+		h := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+		c := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin","exp":1700003600}`))
+
+		_, err := ParseJWT(h+"."+c+".", testKey, now)
+		require.ErrorIs(t, err, ErrUnsupportedAlg)
+	})
+
+	t.Run("ParseJWT expired", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := SignJWT(Claims{Subject: "admin", ExpiresAt: now.Unix()}, testKey)
+		require.Nil(t, err)
+
+		_, err = ParseJWT(token, testKey, now)
+		require.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("ParseJWT not active yet", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := SignJWT(Claims{
+			Subject:   "admin",
+			NotBefore: now.Add(time.Minute).Unix(),
+			ExpiresAt: now.Add(time.Hour).Unix(),
+		}, testKey)
+		require.Nil(t, err)
+
+		_, err = ParseJWT(token, testKey, now)
+		require.ErrorIs(t, err, ErrTokenNotActive)
+	})
+
+	t.Run("ParseJWT no expiration", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := SignJWT(Claims{Subject: "admin"}, testKey)
+		require.Nil(t, err)
+
+		_, err = ParseJWT(token, testKey, now)
+		require.ErrorIs(t, err, ErrNoExpiration)
+	})
+
+	t.Run("ParseJWT malformed", func(t *testing.T) {
+		t.Parallel()
+
+		for _, token := range []string{"", "a.b", "a.b.c", "!!.e30.sig"} {
+			_, err := ParseJWT(token, testKey, now)
+			require.ErrorIs(t, err, ErrMalformedToken, token)
+		}
+	})
+}
+
+func TestSignJWT(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SignJWT short key", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := SignJWT(Claims{Subject: "admin", ExpiresAt: 1}, []byte("short"))
+		require.ErrorIs(t, err, ErrShortKey)
+	})
+}
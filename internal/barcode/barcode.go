@@ -0,0 +1,209 @@
+package barcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+
+	DefaultScale  = 2
+	DefaultHeight = 80
+
+	quietZone = 10
+)
+
+var ErrInvalidBarcode = errors.New("invalid EAN-8, EAN-13 or UPC-A barcode")
+
+var (
+	startGuard  = "101"
+	middleGuard = "01010"
+	endGuard    = "101"
+
+	lCodes = [10]string{"0001101", "0011001", "0010011", "0111101", "0100011",
+		"0110001", "0101111", "0111011", "0110111", "0001011"}
+	gCodes = [10]string{"0100111", "0110011", "0011011", "0100001", "0011101",
+		"0111001", "0000101", "0010001", "0001001", "0010111"}
+	rCodes = [10]string{"1110010", "1100110", "1101100", "1000010", "1011100",
+		"1001110", "1010000", "1000100", "1001000", "1110100"}
+
+	// Parity of the left half digits of EAN-13 encodes the first digit.
+	ean13Parity = [10]string{"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG",
+		"LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL"}
+)
+
+// IsValid reports whether code is an EAN-8, UPC-A or EAN-13 barcode with a correct check digit.
+func IsValid(code string) bool {
+	switch len(code) {
+	case 8, 12, 13:
+	default:
+		return false
+	}
+
+	digits, ok := toDigits(code)
+	if !ok {
+		return false
+	}
+
+	last := len(digits) - 1
+	return checkDigit(digits[:last]) == digits[last]
+}
+
+// Modules encodes code into bars, true means a dark module. Quiet zones are not included.
+func Modules(code string) ([]bool, error) {
+	if !IsValid(code) {
+		return nil, ErrInvalidBarcode
+	}
+
+	digits, _ := toDigits(code)
+
+	var b strings.Builder
+	b.WriteString(startGuard)
+
+	switch len(digits) {
+	case 8:
+		for _, d := range digits[:4] {
+			b.WriteString(lCodes[d])
+		}
+		b.WriteString(middleGuard)
+		for _, d := range digits[4:] {
+			b.WriteString(rCodes[d])
+		}
+	default:
+		// UPC-A is EAN-13 with the leading zero.
+		if len(digits) == 12 {
+			digits = append([]int{0}, digits...)
+		}
+
+		parity := ean13Parity[digits[0]]
+		for i, d := range digits[1:7] {
+			if parity[i] == 'L' {
+				b.WriteString(lCodes[d])
+			} else {
+				b.WriteString(gCodes[d])
+			}
+		}
+		b.WriteString(middleGuard)
+		for _, d := range digits[7:] {
+			b.WriteString(rCodes[d])
+		}
+	}
+
+	b.WriteString(endGuard)
+
+	pattern := b.String()
+	modules := make([]bool, len(pattern))
+	for i := range pattern {
+		modules[i] = pattern[i] == '1'
+	}
+
+	return modules, nil
+}
+
+// Render draws code in the given format. Scale is the width of one module in pixels.
+func Render(code, format string, scale, height int) ([]byte, error) {
+	if scale <= 0 {
+		scale = DefaultScale
+	}
+	if height <= 0 {
+		height = DefaultHeight
+	}
+
+	modules, err := Modules(code)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatPNG, "":
+		return renderPNG(modules, scale, height)
+	case FormatSVG:
+		return renderSVG(modules, scale, height), nil
+	}
+
+	return nil, fmt.Errorf("unknown barcode format '%s'", format)
+}
+
+func renderPNG(modules []bool, scale, height int) ([]byte, error) {
+	width := (len(modules) + 2*quietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, width, height), color.Palette{color.White, color.Black})
+
+	for i, dark := range modules {
+		if !dark {
+			continue
+		}
+		x0 := (quietZone + i) * scale
+		for x := x0; x < x0+scale; x++ {
+			for y := 0; y < height; y++ {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func renderSVG(modules []bool, scale, height int) []byte {
+	width := (len(modules) + 2*quietZone) * scale
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		width, height, width, height)
+	b.WriteString(`<rect width="100%" height="100%" fill="#fff"/>`)
+
+	// Adjacent dark modules are merged into a single bar.
+	for i := 0; i < len(modules); {
+		if !modules[i] {
+			i++
+			continue
+		}
+		j := i
+		for j < len(modules) && modules[j] {
+			j++
+		}
+		fmt.Fprintf(&b, `<rect x="%d" width="%d" height="%d" fill="#000"/>`, (quietZone+i)*scale, (j-i)*scale, height)
+		i = j
+	}
+
+	b.WriteString("</svg>\n")
+
+	return []byte(b.String())
+}
+
+func toDigits(code string) ([]int, bool) {
+	digits := make([]int, len(code))
+	for i := range code {
+		if code[i] < '0' || code[i] > '9' {
+			return nil, false
+		}
+		digits[i] = int(code[i] - '0')
+	}
+	return digits, true
+}
+
+// checkDigit computes the GS1 check digit: data digits are weighted 3 and 1 alternately starting from the right.
+func checkDigit(digits []int) int {
+	sum := 0
+	for i := range digits {
+		d := digits[len(digits)-1-i]
+		if i%2 == 0 {
+			sum += 3 * d
+		} else {
+			sum += d
+		}
+	}
+	return (10 - sum%10) % 10
+}
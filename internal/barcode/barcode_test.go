@@ -0,0 +1,112 @@
+package barcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValid(t *testing.T) {
+	t.Parallel()
+
+	t.Run("IsValid ok", func(t *testing.T) {
+		t.Parallel()
+
+		require.True(t, IsValid("4006381333931"))
+		require.True(t, IsValid("96385074"))
+		require.True(t, IsValid("036000291452"))
+	})
+
+	t.Run("IsValid wrong check digit", func(t *testing.T) {
+		t.Parallel()
+
+		require.False(t, IsValid("4006381333932"))
+		require.False(t, IsValid("96385075"))
+		require.False(t, IsValid("036000291453"))
+	})
+
+	t.Run("IsValid wrong format", func(t *testing.T) {
+		t.Parallel()
+
+		require.False(t, IsValid(""))
+		require.False(t, IsValid("400638133393"))
+		require.False(t, IsValid("40063813339A1"))
+		require.False(t, IsValid("12345"))
+	})
+}
+
+func TestModules(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Modules EAN-13", func(t *testing.T) {
+		t.Parallel()
+
+		modules, err := Modules("4006381333931")
+		require.Nil(t, err)
+		require.Len(t, modules, 95)
+		require.Equal(t, []bool{true, false, true}, modules[:3])
+		require.Equal(t, []bool{true, false, true}, modules[92:])
+	})
+
+	t.Run("Modules UPC-A same as EAN-13 with leading zero", func(t *testing.T) {
+		t.Parallel()
+
+		upc, err := Modules("036000291452")
+		require.Nil(t, err)
+
+		ean, err := Modules("0036000291452")
+		require.Nil(t, err)
+
+		require.Equal(t, ean, upc)
+	})
+
+	t.Run("Modules EAN-8", func(t *testing.T) {
+		t.Parallel()
+
+		modules, err := Modules("96385074")
+		require.Nil(t, err)
+		require.Len(t, modules, 67)
+	})
+
+	t.Run("Modules invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Modules("4006381333932")
+		require.ErrorIs(t, err, ErrInvalidBarcode)
+	})
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Render PNG", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := Render("4006381333931", FormatPNG, 3, 50)
+		require.Nil(t, err)
+
+		img, err := png.Decode(bytes.NewReader(data))
+		require.Nil(t, err)
+		require.Equal(t, (95+2*quietZone)*3, img.Bounds().Dx())
+		require.Equal(t, 50, img.Bounds().Dy())
+	})
+
+	t.Run("Render SVG", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := Render("96385074", FormatSVG, 0, 0)
+		require.Nil(t, err)
+		require.True(t, strings.Contains(string(data), "<svg"))
+		require.True(t, strings.Contains(string(data), `height="80"`))
+	})
+
+	t.Run("Render unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Render("96385074", "gif", 0, 0)
+		require.NotNil(t, err)
+	})
+}
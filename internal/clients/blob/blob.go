@@ -0,0 +1,89 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"shopapi/internal/supports"
+)
+
+const (
+	KindFilesystem = "filesystem"
+	KindS3         = "s3"
+
+	storage_kind_secret_path  = "./secrets/blob_storage.txt"
+	fs_root_secret_path       = "./secrets/blob_fs_root.txt"
+	s3_endpoint_secret_path   = "./secrets/s3_endpoint.txt"
+	s3_region_secret_path     = "./secrets/s3_region.txt"
+	s3_bucket_secret_path     = "./secrets/s3_bucket.txt"
+	s3_access_key_secret_path = "./secrets/s3_access_key.txt"
+	s3_secret_key_secret_path = "./secrets/s3_secret_key.txt"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Storage keeps binary objects addressed by a key. Content is streamed both ways,
+// the reader returned by Get has to be closed by the caller.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStorage builds the storage chosen in the secrets: the local filesystem or an S3-compatible one.
+func NewStorage() (Storage, error) {
+	kind, err := supports.ReadSecret(storage_kind_secret_path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case KindFilesystem:
+		root, err := supports.ReadSecret(fs_root_secret_path)
+		if err != nil {
+			return nil, err
+		}
+		return NewFSStorage(root)
+	case KindS3:
+		return newS3StorageFromSecrets()
+	default:
+		return nil, fmt.Errorf("unknown blob storage '%s', expected %s/%s", kind, KindFilesystem, KindS3)
+	}
+}
+
+func newS3StorageFromSecrets() (*S3Storage, error) {
+	paths := []string{
+		s3_endpoint_secret_path,
+		s3_region_secret_path,
+		s3_bucket_secret_path,
+		s3_access_key_secret_path,
+		s3_secret_key_secret_path,
+	}
+
+	values := make([]string, len(paths))
+	for i, path := range paths {
+		value, err := supports.ReadSecret(path)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+
+	return NewS3Storage(values[0], values[1], values[2], values[3], values[4])
+}
+
+func checkKey(key string) error {
+	if key == "" || key == "." || key == ".." {
+		return ErrInvalidKey
+	}
+	for _, r := range key {
+		if r == '/' || r == '\\' {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
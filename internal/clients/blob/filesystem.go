@@ -0,0 +1,98 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	dirPerm  = 0o755
+	filePerm = 0o644
+)
+
+// FSStorage keeps blobs as files under the root directory, sharded by the first two key characters.
+type FSStorage struct {
+	root string
+}
+
+func NewFSStorage(root string) (*FSStorage, error) {
+	if err := os.MkdirAll(root, dirPerm); err != nil {
+		return nil, err
+	}
+	return &FSStorage{root: root}, nil
+}
+
+func (s *FSStorage) Put(_ context.Context, key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return err
+	}
+
+	// Written to a temporary file first so a reader never sees a partial blob.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), filePerm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FSStorage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *FSStorage) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *FSStorage) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+
+	shard := key[:min(len(key), 2)]
+
+	return filepath.Join(s.root, shard, key), nil
+}
@@ -0,0 +1,78 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readBlob(t *testing.T, s Storage, key string) []byte {
+	r, err := s.Get(context.Background(), key)
+	require.Nil(t, err)
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	require.Nil(t, err)
+
+	return data
+}
+
+func TestFSStorage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("FSStorage put get delete ok", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		root := t.TempDir()
+
+		s, err := NewFSStorage(root)
+		require.Nil(t, err)
+
+		data := []byte("image content")
+
+		require.Nil(t, s.Put(ctx, "abcdef", bytes.NewReader(data)))
+		require.FileExists(t, filepath.Join(root, "ab", "abcdef"))
+
+		require.Equal(t, data, readBlob(t, s, "abcdef"))
+
+		require.Nil(t, s.Put(ctx, "abcdef", bytes.NewReader([]byte("replaced"))))
+		require.Equal(t, []byte("replaced"), readBlob(t, s, "abcdef"))
+
+		require.Nil(t, s.Delete(ctx, "abcdef"))
+		_, err = s.Get(ctx, "abcdef")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		entries, err := os.ReadDir(filepath.Join(root, "ab"))
+		require.Nil(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("FSStorage not found", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := NewFSStorage(t.TempDir())
+		require.Nil(t, err)
+
+		_, err = s.Get(context.Background(), "missing")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		require.Nil(t, s.Delete(context.Background(), "missing"))
+	})
+
+	t.Run("FSStorage invalid key", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := NewFSStorage(t.TempDir())
+		require.Nil(t, err)
+
+		for _, key := range []string{"", "..", "../etc", "a/b"} {
+			require.ErrorIs(t, s.Put(context.Background(), key, bytes.NewReader([]byte("x"))), ErrInvalidKey, key)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+)
+
+// AddApiKey refuses the key with the roles that don't exist, the unknown ones are listed in the response.
+func (c *Client) AddApiKey(req *ds.IssueApiKeyRequest) (resp *ds.IssueApiKeyResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		unknown, err := qtx.GetUnknownRoles(ctx, req.Roles)
+		if err != nil {
+			return err
+		}
+
+		if len(unknown) > 0 {
+			resp = &ds.IssueApiKeyResponse{
+				Status:       ds.Status{Message: ds.StatusUnknownRole},
+				UnknownRoles: unknown,
+			}
+			return nil
+		}
+
+		key, err := qtx.InsertApiKey(ctx, sqlc.InsertApiKeyParams{
+			Uid:       req.Uid,
+			Name:      req.Name,
+			Prefix:    req.Prefix,
+			KeyHash:   req.KeyHash,
+			ExpiresAt: toNullTime(req.ExpiresAt),
+			Roles:     req.Roles,
+		})
+		if err != nil {
+			return err
+		}
+
+		resp = &ds.IssueApiKeyResponse{
+			ApiKey: fromDBApiKey(&key),
+		}
+		return audit(ctx, qtx, req.Audit, "AddApiKey", ds.AuditApiKey, key.Uid.String(), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (c *Client) GetApiKeys(req *ds.GetApiKeysRequest) (*ds.GetApiKeysResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	keys, err := c.db.Querier().GetApiKeys(ctx, req.IncludeRevoked)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ds.GetApiKeysResponse{
+		ApiKeys: make([]ds.ApiKey, 0, len(keys)),
+	}
+	for i := range keys {
+		resp.ApiKeys = append(resp.ApiKeys, *fromDBApiKey(&keys[i]))
+	}
+
+	return resp, nil
+}
+
+// RevokeApiKey keeps the key listed with its revoke time, revoking it again changes nothing.
+func (c *Client) RevokeApiKey(req *ds.RevokeApiKeyRequest) (resp *ds.RevokeApiKeyResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditApiKey, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		if _, err = qtx.RevokeApiKey(ctx, req.Uid); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			resp = &ds.RevokeApiKeyResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}
+			return nil
+		}
+
+		resp = &ds.RevokeApiKeyResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "RevokeApiKey", ds.AuditApiKey, req.Uid.String(), before)
+	})
+
+	return
+}
+
+// AuthenticateApiKey finds the active key by its hash and records its use.
+// The use is written at most once a minute, so requests with the same key don't all update its row.
+func (c *Client) AuthenticateApiKey(req *ds.AuthenticateApiKeyRequest) (*ds.AuthenticateApiKeyResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	q := c.db.Querier()
+	key, err := q.UseApiKey(ctx, req.KeyHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		key, err = q.GetActiveApiKey(ctx, req.KeyHash)
+	}
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return &ds.AuthenticateApiKeyResponse{
+			Status: ds.Status{Message: ds.StatusInvalidCredentials},
+		}, nil
+	}
+
+	return &ds.AuthenticateApiKeyResponse{
+		ApiKey: fromDBApiKey(&key),
+	}, nil
+}
+
+func fromDBApiKey(k *sqlc.ApiKey) *ds.ApiKey {
+	return &ds.ApiKey{
+		Uid:        k.Uid,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  fromNullTime(k.ExpiresAt),
+		LastUsedAt: fromNullTime(k.LastUsedAt),
+		RevokedAt:  fromNullTime(k.RevokedAt),
+		Roles:      k.Roles,
+	}
+}
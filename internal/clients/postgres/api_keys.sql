@@ -0,0 +1,32 @@
+-- name: InsertApiKey :one
+INSERT INTO api_keys (uid, name, prefix, key_hash, expires_at, roles)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetActiveApiKey :one
+SELECT *
+FROM api_keys k
+WHERE k.key_hash = $1
+    AND k.revoked_at IS NULL
+    AND (k.expires_at IS NULL OR k.expires_at > now());
+
+-- name: GetApiKeys :many
+SELECT *
+FROM api_keys k
+WHERE sqlc.arg(include_revoked)::bool OR k.revoked_at IS NULL
+ORDER BY k.created_at, k.uid;
+
+-- name: RevokeApiKey :one
+UPDATE api_keys k
+SET revoked_at = COALESCE(k.revoked_at, now())
+WHERE k.uid = $1
+RETURNING k.uid;
+
+-- name: UseApiKey :one
+UPDATE api_keys k
+SET last_used_at = now()
+WHERE k.key_hash = $1
+    AND k.revoked_at IS NULL
+    AND (k.expires_at IS NULL OR k.expires_at > now())
+    AND (k.last_used_at IS NULL OR k.last_used_at < now() - interval '1 minute')
+RETURNING *;
@@ -0,0 +1,236 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddApiKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddApiKey ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		expires := time.Now().Add(time.Hour)
+		req := &ds.IssueApiKeyRequest{
+			Uid:       uuid.New(),
+			Name:      "warehouse",
+			Prefix:    "sk_abcdef",
+			KeyHash:   "hash",
+			ExpiresAt: &expires,
+			Roles:     []string{"warehouse"},
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().GetUnknownRoles(gomock.Any(), req.Roles).Return(nil, nil)
+		tc.querierMock.EXPECT().InsertApiKey(gomock.Any(), sqlc.InsertApiKeyParams{
+			Uid:       req.Uid,
+			Name:      req.Name,
+			Prefix:    req.Prefix,
+			KeyHash:   req.KeyHash,
+			ExpiresAt: sql.NullTime{Time: expires, Valid: true},
+			Roles:     req.Roles,
+		}).Return(sqlc.ApiKey{
+			Uid:       req.Uid,
+			Name:      req.Name,
+			Prefix:    req.Prefix,
+			KeyHash:   req.KeyHash,
+			ExpiresAt: sql.NullTime{Time: expires, Valid: true},
+			Roles:     req.Roles,
+		}, nil)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.AddApiKey(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp.ApiKey)
+		require.Equal(t, req.Uid, resp.ApiKey.Uid)
+		require.Equal(t, &expires, resp.ApiKey.ExpiresAt)
+		require.Equal(t, req.Roles, resp.ApiKey.Roles)
+	})
+
+	t.Run("AddApiKey unknown role", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.IssueApiKeyRequest{Name: "warehouse", Roles: []string{"warehouse", "stock_keeper"}}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().GetUnknownRoles(gomock.Any(), req.Roles).Return([]string{"stock_keeper"}, nil)
+
+		resp, err := tc.client.AddApiKey(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.ApiKey)
+		require.Equal(t, ds.StatusUnknownRole, resp.GetStatus())
+		require.Equal(t, []string{"stock_keeper"}, resp.UnknownRoles)
+	})
+
+	t.Run("AddApiKey error on InsertApiKey", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().GetUnknownRoles(gomock.Any(), gomock.Any()).Return(nil, nil)
+		tc.querierMock.EXPECT().InsertApiKey(gomock.Any(), gomock.Any()).Return(sqlc.ApiKey{}, errTest)
+
+		resp, err := tc.client.AddApiKey(&ds.IssueApiKeyRequest{Name: "warehouse"})
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetApiKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetApiKeys ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		revoked := time.Now()
+		keys := []sqlc.ApiKey{
+			{Uid: uuid.New(), Name: "warehouse"},
+			{Uid: uuid.New(), Name: "old", RevokedAt: sql.NullTime{Time: revoked, Valid: true}},
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetApiKeys(gomock.Any(), true).Return(keys, nil)
+
+		resp, err := tc.client.GetApiKeys(&ds.GetApiKeysRequest{IncludeRevoked: true})
+		require.Nil(t, err)
+		require.Len(t, resp.ApiKeys, 2)
+		require.Nil(t, resp.ApiKeys[0].RevokedAt)
+		require.Equal(t, &revoked, resp.ApiKeys[1].RevokedAt)
+	})
+
+	t.Run("GetApiKeys error on GetApiKeys", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetApiKeys(gomock.Any(), false).Return(nil, errTest)
+
+		resp, err := tc.client.GetApiKeys(&ds.GetApiKeysRequest{})
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestRevokeApiKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RevokeApiKey ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().RevokeApiKey(gomock.Any(), uid).Return(uid, nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.RevokeApiKey(&ds.RevokeApiKeyRequest{Uid: uid})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("RevokeApiKey not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().RevokeApiKey(gomock.Any(), gomock.Any()).Return(uuid.Nil, sql.ErrNoRows)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.RevokeApiKey(&ds.RevokeApiKeyRequest{Uid: uuid.New()})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+}
+
+func TestAuthenticateApiKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AuthenticateApiKey ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		key := sqlc.ApiKey{Uid: uuid.New(), Name: "warehouse", KeyHash: "hash"}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().UseApiKey(gomock.Any(), "hash").Return(key, nil)
+
+		resp, err := tc.client.AuthenticateApiKey(&ds.AuthenticateApiKeyRequest{KeyHash: "hash"})
+		require.Nil(t, err)
+		require.Equal(t, "", resp.GetStatus())
+		require.Equal(t, key.Uid, resp.ApiKey.Uid)
+	})
+
+	t.Run("AuthenticateApiKey used within the last minute", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		key := sqlc.ApiKey{Uid: uuid.New(), Name: "warehouse", KeyHash: "hash"}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().UseApiKey(gomock.Any(), "hash").Return(sqlc.ApiKey{}, sql.ErrNoRows)
+		tc.querierMock.EXPECT().GetActiveApiKey(gomock.Any(), "hash").Return(key, nil)
+
+		resp, err := tc.client.AuthenticateApiKey(&ds.AuthenticateApiKeyRequest{KeyHash: "hash"})
+		require.Nil(t, err)
+		require.Equal(t, "", resp.GetStatus())
+		require.Equal(t, key.Uid, resp.ApiKey.Uid)
+	})
+
+	t.Run("AuthenticateApiKey unknown, revoked or expired", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().UseApiKey(gomock.Any(), "hash").Return(sqlc.ApiKey{}, sql.ErrNoRows)
+		tc.querierMock.EXPECT().GetActiveApiKey(gomock.Any(), "hash").Return(sqlc.ApiKey{}, sql.ErrNoRows)
+
+		resp, err := tc.client.AuthenticateApiKey(&ds.AuthenticateApiKeyRequest{KeyHash: "hash"})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusInvalidCredentials, resp.GetStatus())
+		require.Nil(t, resp.ApiKey)
+	})
+
+	t.Run("AuthenticateApiKey error on UseApiKey", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().UseApiKey(gomock.Any(), gomock.Any()).Return(sqlc.ApiKey{}, errTest)
+
+		resp, err := tc.client.AuthenticateApiKey(&ds.AuthenticateApiKeyRequest{KeyHash: "hash"})
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
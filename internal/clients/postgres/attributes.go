@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	ds "shopapi/internal/datastruct"
+)
+
+// validateAttributes checks product attribute values against the category schema.
+// It returns an empty string if values are valid and a status message otherwise.
+func validateAttributes(schema []ds.AttributeDefinition, values map[string]any) string {
+	defined := make(map[string]*ds.AttributeDefinition, len(schema))
+	for i := range schema {
+		def := &schema[i]
+		defined[def.Name] = def
+
+		if _, ok := values[def.Name]; !ok && def.Required {
+			return invalidAttributes("%s is required", def.Name)
+		}
+	}
+
+	for name, value := range values {
+		def, ok := defined[name]
+		if !ok {
+			return invalidAttributes("%s is not defined for the category", name)
+		}
+
+		str, ok := attributeString(def.Type, value)
+		if !ok {
+			return invalidAttributes("%s must be %s", name, def.Type)
+		}
+
+		if len(def.AllowedValues) != 0 && !slices.Contains(def.AllowedValues, str) {
+			return invalidAttributes("%s must be one of %s", name, strings.Join(def.AllowedValues, ", "))
+		}
+	}
+
+	return ""
+}
+
+func invalidAttributes(format string, args ...any) string {
+	return ds.StatusInvalidAttributes + ": " + fmt.Sprintf(format, args...)
+}
+
+// attributeString reports whether value has the given attribute type and returns its string form.
+func attributeString(attrType string, value any) (string, bool) {
+	switch attrType {
+	case ds.AttributeTypeString:
+		s, ok := value.(string)
+		return s, ok
+	case ds.AttributeTypeNumber:
+		f, ok := value.(float64)
+		return strconv.FormatFloat(f, 'f', -1, 64), ok
+	case ds.AttributeTypeInteger:
+		f, ok := value.(float64)
+		return strconv.FormatFloat(f, 'f', -1, 64), ok && f == math.Trunc(f)
+	case ds.AttributeTypeBoolean:
+		b, ok := value.(bool)
+		return strconv.FormatBool(b), ok
+	}
+	return "", false
+}
+
+// attributesFilter turns "name:value" filters into JSONB objects for the containment check, a product
+// matches if it contains one of them. Values are typed by the schemas of the categories defining the
+// attribute, so "size:42" matches a string size as well as a number one when categories differ.
+// A value none of the types accepts matches nothing and the result is empty then.
+func attributesFilter(filters []string, types map[string][]string) ([]json.RawMessage, error) {
+	combinations := []map[string]any{{}}
+	for _, f := range filters {
+		name, raw, _ := strings.Cut(f, ":")
+
+		values := attributeValues(types[name], raw)
+		next := make([]map[string]any, 0, len(combinations)*len(values))
+		for _, c := range combinations {
+			for _, v := range values {
+				filter := maps.Clone(c)
+				filter[name] = v
+				next = append(next, filter)
+			}
+		}
+		combinations = next
+	}
+
+	objects := make([]json.RawMessage, 0, len(combinations))
+	for _, c := range combinations {
+		object, err := json.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// attributeValues parses raw as each of the attribute types, an attribute no category defines is a string.
+func attributeValues(attrTypes []string, raw string) []any {
+	if len(attrTypes) == 0 {
+		return []any{raw}
+	}
+
+	values := make([]any, 0, len(attrTypes))
+	for _, t := range attrTypes {
+		var value any
+		switch t {
+		case ds.AttributeTypeString:
+			value = raw
+		case ds.AttributeTypeNumber, ds.AttributeTypeInteger:
+			f, err := strconv.ParseFloat(raw, 64)
+			if err != nil || t == ds.AttributeTypeInteger && f != math.Trunc(f) {
+				continue
+			}
+			value = f
+		case ds.AttributeTypeBoolean:
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				continue
+			}
+			value = b
+		default:
+			continue
+		}
+
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// attributeNames returns the names of the attributes in "name:value" filters.
+func attributeNames(filters []string) []string {
+	names := make([]string, 0, len(filters))
+	for _, f := range filters {
+		name, _, _ := strings.Cut(f, ":")
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func toDBAttributes(values map[string]any) (json.RawMessage, error) {
+	if values == nil {
+		values = map[string]any{}
+	}
+	return json.Marshal(values)
+}
+
+func toDBAttributeSchema(schema []ds.AttributeDefinition) (json.RawMessage, error) {
+	if schema == nil {
+		schema = []ds.AttributeDefinition{}
+	}
+	return json.Marshal(schema)
+}
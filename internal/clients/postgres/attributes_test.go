@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	ds "shopapi/internal/datastruct"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAttributes(t *testing.T) {
+	t.Parallel()
+
+	schema := []ds.AttributeDefinition{
+		{Name: "material", Type: ds.AttributeTypeString, AllowedValues: []string{"oak", "pine"}},
+		{Name: "length", Type: ds.AttributeTypeNumber, Unit: "m", Required: true},
+		{Name: "pieces", Type: ds.AttributeTypeInteger},
+		{Name: "treated", Type: ds.AttributeTypeBoolean},
+	}
+
+	t.Run("validateAttributes ok", func(t *testing.T) {
+		t.Parallel()
+
+		status := validateAttributes(schema, map[string]any{
+			"material": "oak",
+			"length":   2.5,
+			"pieces":   float64(4),
+			"treated":  true,
+		})
+		require.Empty(t, status)
+	})
+
+	t.Run("validateAttributes missing required", func(t *testing.T) {
+		t.Parallel()
+
+		status := validateAttributes(schema, map[string]any{"material": "oak"})
+		require.True(t, strings.HasPrefix(status, ds.StatusInvalidAttributes))
+		require.Contains(t, status, "length")
+	})
+
+	t.Run("validateAttributes unknown attribute", func(t *testing.T) {
+		t.Parallel()
+
+		status := validateAttributes(schema, map[string]any{"length": 1.0, "voltage": 220.0})
+		require.Contains(t, status, "voltage")
+	})
+
+	t.Run("validateAttributes wrong type", func(t *testing.T) {
+		t.Parallel()
+
+		require.NotEmpty(t, validateAttributes(schema, map[string]any{"length": "long"}))
+		require.NotEmpty(t, validateAttributes(schema, map[string]any{"length": 1.0, "pieces": 1.5}))
+		require.NotEmpty(t, validateAttributes(schema, map[string]any{"length": 1.0, "treated": "yes"}))
+	})
+
+	t.Run("validateAttributes not allowed value", func(t *testing.T) {
+		t.Parallel()
+
+		status := validateAttributes(schema, map[string]any{"length": 1.0, "material": "steel"})
+		require.Contains(t, status, "oak, pine")
+	})
+}
+
+func TestAttributesFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("attributesFilter typed values", func(t *testing.T) {
+		t.Parallel()
+
+		types := map[string][]string{
+			"material": {ds.AttributeTypeString},
+			"length":   {ds.AttributeTypeNumber},
+			"treated":  {ds.AttributeTypeBoolean},
+			"code":     {ds.AttributeTypeString},
+		}
+
+		filter, err := attributesFilter([]string{"material:oak", "length:2.5", "treated:true", "code:42"}, types)
+		require.Nil(t, err)
+		require.Len(t, filter, 1)
+		require.JSONEq(t, `{"material": "oak", "length": 2.5, "treated": true, "code": "42"}`, string(filter[0]))
+	})
+
+	t.Run("attributesFilter types differ by category", func(t *testing.T) {
+		t.Parallel()
+
+		types := map[string][]string{"size": {ds.AttributeTypeInteger, ds.AttributeTypeString}}
+
+		filter, err := attributesFilter([]string{"size:42"}, types)
+		require.Nil(t, err)
+		require.Len(t, filter, 2)
+		require.JSONEq(t, `{"size": 42}`, string(filter[0]))
+		require.JSONEq(t, `{"size": "42"}`, string(filter[1]))
+	})
+
+	t.Run("attributesFilter value of no type", func(t *testing.T) {
+		t.Parallel()
+
+		types := map[string][]string{"length": {ds.AttributeTypeNumber}}
+
+		filter, err := attributesFilter([]string{"length:long"}, types)
+		require.Nil(t, err)
+		require.Empty(t, filter)
+	})
+
+	t.Run("attributesFilter undefined attribute", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := attributesFilter([]string{"size:42"}, nil)
+		require.Nil(t, err)
+		require.Len(t, filter, 1)
+		require.JSONEq(t, `{"size": "42"}`, string(filter[0]))
+	})
+
+	t.Run("attributesFilter empty", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := attributesFilter(nil, nil)
+		require.Nil(t, err)
+		require.Len(t, filter, 1)
+		require.JSONEq(t, `{}`, string(filter[0]))
+	})
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// defaultAuditLimit is the page size of the audit entries if the limit isn't set, the largest one allowed.
+const defaultAuditLimit = 1000
+
+var nullSnapshot = json.RawMessage("null")
+
+// snapshot returns the entity as it is stored in the transaction, null if there is no such entity.
+// The id is passed typed as well, so the entities keyed by a uuid or a serial are looked up by their primary key.
+func snapshot(ctx context.Context, qtx IQuerier, entity, id string) (json.RawMessage, error) {
+	arg := sqlc.GetAuditSnapshotParams{
+		Entity:   entity,
+		EntityID: id,
+	}
+	if uid, err := uuid.Parse(id); err == nil {
+		arg.EntityUid = uuid.NullUUID{UUID: uid, Valid: true}
+	}
+	if num, err := strconv.ParseInt(id, 10, 64); err == nil {
+		arg.EntityNum = sql.NullInt64{Int64: num, Valid: true}
+	}
+
+	return qtx.GetAuditSnapshot(ctx, arg)
+}
+
+// audit records the change of the entity by the caller in the transaction making it, so a rolled back
+// change leaves no entry. The before snapshot is taken by the caller ahead of the change, nil if it was created.
+func audit(ctx context.Context, qtx IQuerier, a ds.Audit, action, entity, id string, before json.RawMessage) error {
+	after, err := snapshot(ctx, qtx, entity, id)
+	if err != nil {
+		return err
+	}
+
+	if before == nil {
+		before = nullSnapshot
+	}
+
+	return qtx.InsertAuditEntry(ctx, sqlc.InsertAuditEntryParams{
+		Action:      action,
+		Entity:      entity,
+		EntityID:    id,
+		Actor:       a.Actor,
+		ActorKind:   a.ActorKind,
+		ActorKey:    toNullUUID(a.ActorKey),
+		RequestID:   a.RequestID,
+		BeforeState: before,
+		AfterState:  after,
+	})
+}
+
+// snapshots takes the snapshots of the entities a single statement is going to change.
+func snapshots(ctx context.Context, qtx IQuerier, entity string, uids []uuid.UUID) ([]json.RawMessage, error) {
+	before := make([]json.RawMessage, 0, len(uids))
+	for _, uid := range uids {
+		s, err := snapshot(ctx, qtx, entity, uid.String())
+		if err != nil {
+			return nil, err
+		}
+		before = append(before, s)
+	}
+
+	return before, nil
+}
+
+// auditEach records the change of every entity with the snapshots taken by snapshots.
+func auditEach(ctx context.Context, qtx IQuerier, a ds.Audit, action, entity string, uids []uuid.UUID, before []json.RawMessage) error {
+	for i, uid := range uids {
+		if err := audit(ctx, qtx, a, action, entity, uid.String(), before[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) GetAudit(req *ds.GetAuditRequest) (*ds.GetAuditResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultAuditLimit
+	}
+
+	entries, err := c.db.Querier().GetAuditEntries(ctx, sqlc.GetAuditEntriesParams{
+		Entity:     req.Entity,
+		EntityID:   req.EntityId,
+		Actor:      req.Actor,
+		Action:     req.Action,
+		RequestID:  req.RequestId,
+		Since:      toNullTime(req.Since),
+		Until:      toNullTime(req.Until),
+		PageOffset: int32(req.Offset),
+		PageLimit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ds.GetAuditResponse{
+		Entries: make([]ds.AuditEntry, 0, len(entries)),
+	}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, ds.AuditEntry{
+			Id:        e.ID,
+			CreatedAt: e.CreatedAt,
+			Action:    e.Action,
+			Entity:    e.Entity,
+			EntityId:  e.EntityID,
+			ActorKind: e.ActorKind,
+			Actor:     e.Actor,
+			ActorKey:  fromNullUUID(e.ActorKey),
+			RequestId: e.RequestID,
+			Before:    e.BeforeState,
+			After:     e.AfterState,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,36 @@
+-- name: InsertAuditEntry :exec
+INSERT INTO audit_log (action, entity, entity_id, actor, actor_kind, actor_key, request_id, before_state, after_state)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetAuditSnapshot :one
+SELECT COALESCE(CASE sqlc.arg(entity)::text
+    WHEN 'client' THEN (SELECT to_jsonb(c) FROM client_details c WHERE c.uid = sqlc.narg(entity_uid)::uuid)
+    WHEN 'supplier' THEN (SELECT to_jsonb(s) FROM supplier_details s WHERE s.uid = sqlc.narg(entity_uid)::uuid)
+    WHEN 'product' THEN (SELECT to_jsonb(p) FROM products p WHERE p.uid = sqlc.narg(entity_uid)::uuid)
+    WHEN 'product_variant' THEN (SELECT to_jsonb(v) FROM product_variants v WHERE v.uid = sqlc.narg(entity_uid)::uuid)
+    WHEN 'variant_price' THEN (SELECT to_jsonb(p) FROM variant_prices p WHERE p.id = sqlc.narg(entity_num)::bigint)
+    WHEN 'product_images' THEN (
+        SELECT jsonb_agg(to_jsonb(pi) ORDER BY pi.position)
+        FROM product_images pi WHERE pi.product_id = sqlc.narg(entity_uid)::uuid)
+    WHEN 'product_document' THEN (SELECT to_jsonb(d) FROM product_documents d WHERE d.uid = sqlc.narg(entity_uid)::uuid)
+    WHEN 'image' THEN (SELECT to_jsonb(i) - 'image' FROM images i WHERE i.uid = sqlc.narg(entity_uid)::uuid)
+    WHEN 'category' THEN (SELECT to_jsonb(c) FROM categories c WHERE c.uid = sqlc.narg(entity_uid)::uuid)
+    WHEN 'api_key' THEN (SELECT to_jsonb(k) - 'key_hash' FROM api_keys k WHERE k.uid = sqlc.narg(entity_uid)::uuid)
+    WHEN 'role' THEN (SELECT to_jsonb(r) FROM roles r WHERE r.name = sqlc.arg(entity_id)::text)
+    WHEN 'exchange_rate' THEN (SELECT to_jsonb(r) FROM exchange_rates r WHERE r.currency = sqlc.arg(entity_id)::text)
+    WHEN 'promotion' THEN (SELECT to_jsonb(p) FROM promotions p WHERE p.uid = sqlc.narg(entity_uid)::uuid)
+END, 'null'::jsonb)::jsonb AS snapshot;
+
+-- name: GetAuditEntries :many
+SELECT *
+FROM audit_log a
+WHERE (sqlc.arg(entity)::text = '' OR a.entity = sqlc.arg(entity)::text)
+    AND (sqlc.arg(entity_id)::text = '' OR a.entity_id = sqlc.arg(entity_id)::text)
+    AND (sqlc.arg(actor)::text = '' OR a.actor = sqlc.arg(actor)::text)
+    AND (sqlc.arg(action)::text = '' OR a.action = sqlc.arg(action)::text)
+    AND (sqlc.arg(request_id)::text = '' OR a.request_id = sqlc.arg(request_id)::text)
+    AND (sqlc.narg(since)::timestamptz IS NULL OR a.created_at >= sqlc.narg(since)::timestamptz)
+    AND (sqlc.narg(until)::timestamptz IS NULL OR a.created_at < sqlc.narg(until)::timestamptz)
+ORDER BY a.id DESC
+OFFSET sqlc.arg(page_offset)::int
+LIMIT sqlc.arg(page_limit)::int;
@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var testSnapshot = json.RawMessage(`{"uid":"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"}`)
+
+// expectSnapshots expects n entities to be read before they are changed.
+func expectSnapshots(tc *TestClient, n int) {
+	tc.querierMock.EXPECT().GetAuditSnapshot(gomock.Any(), gomock.Any()).Return(testSnapshot, nil).Times(n)
+}
+
+// expectAudit expects n changes to be recorded, each one reads the entity after the change.
+func expectAudit(tc *TestClient, n int) {
+	expectSnapshots(tc, n)
+	tc.querierMock.EXPECT().InsertAuditEntry(gomock.Any(), gomock.Any()).Return(nil).Times(n)
+}
+
+func TestAudit(t *testing.T) {
+	t.Parallel()
+
+	keyUid := uuid.New()
+	a := ds.Audit{
+		RequestID: "req-1",
+		ActorKind: ds.PrincipalApiKey,
+		Actor:     "warehouse",
+		ActorKey:  &keyUid,
+	}
+
+	t.Run("audit records before and after", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		before := json.RawMessage(`{"name":"old"}`)
+		after := json.RawMessage(`{"name":"new"}`)
+
+		tc.querierMock.EXPECT().GetAuditSnapshot(gomock.Any(), sqlc.GetAuditSnapshotParams{
+			Entity:   ds.AuditSupplier,
+			EntityID: "id",
+		}).Return(after, nil)
+		tc.querierMock.EXPECT().InsertAuditEntry(gomock.Any(), sqlc.InsertAuditEntryParams{
+			Action:      "UpdateSupplierAddress",
+			Entity:      ds.AuditSupplier,
+			EntityID:    "id",
+			Actor:       "warehouse",
+			ActorKind:   ds.PrincipalApiKey,
+			ActorKey:    uuid.NullUUID{UUID: keyUid, Valid: true},
+			RequestID:   "req-1",
+			BeforeState: before,
+			AfterState:  after,
+		}).Return(nil)
+
+		err := audit(tc.ctx, tc.querierMock, a, "UpdateSupplierAddress", ds.AuditSupplier, "id", before)
+		require.Nil(t, err)
+	})
+
+	t.Run("audit records null before of created entity", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.querierMock.EXPECT().GetAuditSnapshot(gomock.Any(), gomock.Any()).Return(testSnapshot, nil)
+		tc.querierMock.EXPECT().InsertAuditEntry(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, arg sqlc.InsertAuditEntryParams) error {
+				require.Equal(t, nullSnapshot, arg.BeforeState)
+				require.Equal(t, testSnapshot, arg.AfterState)
+				return nil
+			})
+
+		err := audit(tc.ctx, tc.querierMock, a, "AddSupplier", ds.AuditSupplier, "id", nil)
+		require.Nil(t, err)
+	})
+
+	t.Run("snapshot looks up typed ids", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		tc.querierMock.EXPECT().GetAuditSnapshot(gomock.Any(), sqlc.GetAuditSnapshotParams{
+			Entity:    ds.AuditProduct,
+			EntityUid: uuid.NullUUID{UUID: uid, Valid: true},
+			EntityID:  uid.String(),
+		}).Return(testSnapshot, nil)
+		tc.querierMock.EXPECT().GetAuditSnapshot(gomock.Any(), sqlc.GetAuditSnapshotParams{
+			Entity:    ds.AuditVariantPrice,
+			EntityNum: sql.NullInt64{Int64: 42, Valid: true},
+			EntityID:  "42",
+		}).Return(testSnapshot, nil)
+
+		_, err := snapshot(tc.ctx, tc.querierMock, ds.AuditProduct, uid.String())
+		require.Nil(t, err)
+		_, err = snapshot(tc.ctx, tc.querierMock, ds.AuditVariantPrice, "42")
+		require.Nil(t, err)
+	})
+
+	t.Run("audit error on GetAuditSnapshot", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.querierMock.EXPECT().GetAuditSnapshot(gomock.Any(), gomock.Any()).Return(nil, errTest)
+
+		err := audit(tc.ctx, tc.querierMock, a, "AddSupplier", ds.AuditSupplier, "id", nil)
+		require.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("audit error on InsertAuditEntry", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.querierMock.EXPECT().GetAuditSnapshot(gomock.Any(), gomock.Any()).Return(testSnapshot, nil)
+		tc.querierMock.EXPECT().InsertAuditEntry(gomock.Any(), gomock.Any()).Return(errTest)
+
+		err := audit(tc.ctx, tc.querierMock, a, "AddSupplier", ds.AuditSupplier, "id", nil)
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestGetAudit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetAudit Ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		keyUid := uuid.New()
+		req := &ds.GetAuditRequest{
+			Entity:   ds.AuditSupplier,
+			EntityId: "id",
+			Since:    &since,
+			Limit:    10,
+			Offset:   20,
+		}
+
+		entries := []sqlc.AuditLog{
+			{
+				ID:          2,
+				CreatedAt:   since,
+				Action:      "DeleteSupplier",
+				Entity:      ds.AuditSupplier,
+				EntityID:    "id",
+				Actor:       "warehouse",
+				ActorKind:   ds.PrincipalApiKey,
+				ActorKey:    uuid.NullUUID{UUID: keyUid, Valid: true},
+				RequestID:   "req-1",
+				BeforeState: testSnapshot,
+				AfterState:  nullSnapshot,
+			},
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetAuditEntries(gomock.Any(), sqlc.GetAuditEntriesParams{
+			Entity:     ds.AuditSupplier,
+			EntityID:   "id",
+			Since:      sql.NullTime{Time: since, Valid: true},
+			PageOffset: 20,
+			PageLimit:  10,
+		}).Return(entries, nil)
+
+		resp, err := tc.client.GetAudit(req)
+		require.Nil(t, err)
+		require.Len(t, resp.Entries, 1)
+		require.Equal(t, int64(2), resp.Entries[0].Id)
+		require.Equal(t, "DeleteSupplier", resp.Entries[0].Action)
+		require.Equal(t, &keyUid, resp.Entries[0].ActorKey)
+		require.Equal(t, testSnapshot, resp.Entries[0].Before)
+		require.Equal(t, nullSnapshot, resp.Entries[0].After)
+	})
+
+	t.Run("GetAudit default limit", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetAuditEntries(gomock.Any(), sqlc.GetAuditEntriesParams{
+			PageLimit: defaultAuditLimit,
+		}).Return(nil, nil)
+
+		resp, err := tc.client.GetAudit(&ds.GetAuditRequest{})
+		require.Nil(t, err)
+		require.Empty(t, resp.Entries)
+	})
+
+	t.Run("GetAudit error on GetAuditEntries", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetAuditEntries(gomock.Any(), gomock.Any()).Return(nil, errTest)
+
+		resp, err := tc.client.GetAudit(&ds.GetAuditRequest{})
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+}
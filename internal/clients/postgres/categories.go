@@ -0,0 +1,280 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/supports"
+
+	"github.com/google/uuid"
+)
+
+func (c *Client) AddCategory(req *ds.AddCategoryRequest) (resp *ds.AddCategoryResponse, err error) {
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		if req.ParentUid != nil {
+			exists, err := qtx.IsCategoryExists(ctx, *req.ParentUid)
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				resp = &ds.AddCategoryResponse{
+					Status: ds.Status{Message: ds.StatusCategoryNoParent},
+				}
+				return nil
+			}
+		}
+
+		schema, err := toDBAttributeSchema(req.Attributes)
+		if err != nil {
+			return err
+		}
+
+		uid, err := qtx.InsertCategory(ctx, sqlc.InsertCategoryParams{
+			Uid:             supports.GetUUIDIfEmpty(req.Uid),
+			ParentID:        toNullUUID(req.ParentUid),
+			Name:            req.Name,
+			Slug:            categorySlug(req.Slug, req.Name),
+			SortOrder:       req.SortOrder,
+			AttributeSchema: schema,
+		})
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			resp = &ds.AddCategoryResponse{
+				Status: ds.Status{Message: ds.StatusAlreadyExists},
+			}
+			return nil
+		}
+
+		resp = &ds.AddCategoryResponse{Uid: &uid}
+		return audit(ctx, qtx, req.Audit, "AddCategory", ds.AuditCategory, uid.String(), nil)
+	})
+
+	return
+}
+
+func (c *Client) UpdateCategory(req *ds.UpdateCategoryRequest) (resp *ds.UpdateCategoryResponse, err error) {
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		if req.ParentUid != nil {
+			exists, err := qtx.IsCategoryExists(ctx, *req.ParentUid)
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				resp = &ds.UpdateCategoryResponse{
+					Status: ds.Status{Message: ds.StatusCategoryNoParent},
+				}
+				return nil
+			}
+
+			cycle, err := qtx.IsCategoryInSubtree(ctx, sqlc.IsCategoryInSubtreeParams{
+				RootUid: req.Uid,
+				Uid:     *req.ParentUid,
+			})
+			if err != nil {
+				return err
+			}
+
+			if cycle {
+				resp = &ds.UpdateCategoryResponse{
+					Status: ds.Status{Message: ds.StatusCategoryCycle},
+				}
+				return nil
+			}
+		}
+
+		schema, err := toDBAttributeSchema(req.Attributes)
+		if err != nil {
+			return err
+		}
+
+		before, err := snapshot(ctx, qtx, ds.AuditCategory, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		_, err = qtx.UpdateCategory(ctx, sqlc.UpdateCategoryParams{
+			ParentID:        toNullUUID(req.ParentUid),
+			Name:            req.Name,
+			Slug:            categorySlug(req.Slug, req.Name),
+			SortOrder:       req.SortOrder,
+			AttributeSchema: schema,
+			Uid:             req.Uid,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				resp = &ds.UpdateCategoryResponse{
+					Status: ds.Status{Message: ds.StatusNotFound},
+				}
+				return nil
+			}
+			if isUniqueViolation(err) {
+				resp = &ds.UpdateCategoryResponse{
+					Status: ds.Status{Message: ds.StatusAlreadyExists},
+				}
+				return nil
+			}
+			return err
+		}
+
+		resp = &ds.UpdateCategoryResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "UpdateCategory", ds.AuditCategory, req.Uid.String(), before)
+	})
+
+	return
+}
+
+func (c *Client) DeleteCategory(req *ds.DeleteCategoryRequest) (resp *ds.DeleteCategoryResponse, err error) {
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		inUse, err := qtx.IsCategoryInUse(ctx, req.Uid)
+		if err != nil {
+			return err
+		}
+
+		if inUse {
+			resp = &ds.DeleteCategoryResponse{
+				Status: ds.Status{Message: ds.StatusCategoryInUse},
+			}
+			return nil
+		}
+
+		before, err := snapshot(ctx, qtx, ds.AuditCategory, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		_, err = qtx.DeleteCategory(ctx, req.Uid)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				resp = &ds.DeleteCategoryResponse{
+					Status: ds.Status{Message: ds.StatusNotFound},
+				}
+				return nil
+			}
+			return err
+		}
+
+		resp = &ds.DeleteCategoryResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "DeleteCategory", ds.AuditCategory, req.Uid.String(), before)
+	})
+
+	return
+}
+
+func (c *Client) GetCategory(req *ds.GetCategoryRequest) (*ds.GetCategoryResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	category, err := c.db.Querier().GetCategory(ctx, req.Uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &ds.GetCategoryResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}, nil
+		}
+		return nil, err
+	}
+
+	resp := &ds.GetCategoryResponse{}
+	resp.Category, err = fromDBCategory(&category)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetCategoriesTree(req *ds.GetCategoriesTreeRequest) (*ds.GetCategoriesTreeResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	categories, err := c.db.Querier().GetAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := buildCategoriesTree(categories)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ds.GetCategoriesTreeResponse{
+		Categories: tree,
+	}, nil
+}
+
+// Categories come ordered by sort order, so children keep that order in the tree as well.
+func buildCategoriesTree(categories []sqlc.Category) ([]ds.CategoryNode, error) {
+	children := map[uuid.UUID][]*sqlc.Category{}
+	roots := make([]*sqlc.Category, 0)
+
+	for i := range categories {
+		category := &categories[i]
+		if category.ParentID.Valid {
+			children[category.ParentID.UUID] = append(children[category.ParentID.UUID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	var build func(level []*sqlc.Category) ([]ds.CategoryNode, error)
+	build = func(level []*sqlc.Category) ([]ds.CategoryNode, error) {
+		nodes := make([]ds.CategoryNode, len(level))
+		for i, category := range level {
+			node, err := fromDBCategory(category)
+			if err != nil {
+				return nil, err
+			}
+
+			nodeChildren, err := build(children[category.Uid])
+			if err != nil {
+				return nil, err
+			}
+
+			nodes[i] = ds.CategoryNode{
+				Category: *node,
+				Children: nodeChildren,
+			}
+		}
+		return nodes, nil
+	}
+
+	return build(roots)
+}
+
+func fromDBCategory(category *sqlc.Category) (*ds.Category, error) {
+	resp := &ds.Category{
+		Uid:       category.Uid,
+		ParentUid: fromNullUUID(category.ParentID),
+		Name:      category.Name,
+		Slug:      category.Slug,
+		SortOrder: category.SortOrder,
+	}
+
+	if err := json.Unmarshal(category.AttributeSchema, &resp.Attributes); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func categorySlug(slug, name string) string {
+	if slug != "" {
+		return supports.Slugify(slug)
+	}
+	return supports.Slugify(name)
+}
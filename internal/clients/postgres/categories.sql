@@ -0,0 +1,61 @@
+-- name: InsertCategory :one
+INSERT INTO categories (uid, parent_id, name, slug, sort_order, attribute_schema)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT DO NOTHING
+RETURNING uid;
+
+-- name: UpdateCategory :one
+UPDATE categories
+SET parent_id = $1, name = $2, slug = $3, sort_order = $4, attribute_schema = $5
+WHERE uid = $6
+RETURNING uid;
+
+-- name: DeleteCategory :one
+DELETE FROM categories
+WHERE uid = $1
+RETURNING uid;
+
+-- name: GetCategory :one
+SELECT *
+FROM categories
+WHERE uid = $1;
+
+-- name: GetAllCategories :many
+SELECT *
+FROM categories
+ORDER BY sort_order, name;
+
+-- name: GetCategoryAttributeSchema :one
+SELECT c.attribute_schema
+FROM categories c
+WHERE c.uid = $1;
+
+-- name: GetAttributeTypes :many
+WITH RECURSIVE category_tree AS (
+    SELECT c.uid FROM categories c WHERE c.uid = sqlc.narg(category_id)::uuid
+    UNION ALL
+    SELECT c.uid FROM categories c JOIN category_tree ct ON c.parent_id = ct.uid
+)
+SELECT DISTINCT d.name::text AS name, d.type::text AS type
+FROM categories c, jsonb_to_recordset(c.attribute_schema) AS d(name text, type text)
+WHERE (sqlc.narg(category_id)::uuid IS NULL OR c.uid IN (SELECT uid FROM category_tree))
+    AND d.name = ANY(sqlc.arg(names)::text[])
+ORDER BY name, type;
+
+-- name: IsCategoryExists :one
+SELECT EXISTS(SELECT 1 FROM categories c WHERE c.uid = $1)::bool AS is_exists;
+
+-- name: IsCategoryInSubtree :one
+WITH RECURSIVE subtree AS (
+    SELECT c.uid FROM categories c WHERE c.uid = sqlc.arg(root_uid)
+    UNION ALL
+    SELECT c.uid FROM categories c JOIN subtree s ON c.parent_id = s.uid
+)
+SELECT EXISTS(SELECT 1 FROM subtree WHERE uid = sqlc.arg(uid))::bool AS is_in_subtree;
+
+-- name: IsCategoryInUse :one
+SELECT (
+    EXISTS(SELECT 1 FROM categories c WHERE c.parent_id = sqlc.arg(uid))
+    OR
+    EXISTS(SELECT 1 FROM products p WHERE p.category_id = sqlc.arg(uid))
+)::bool AS is_in_use;
@@ -0,0 +1,445 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddCategory ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		parentUid := uuid.New()
+		req := &ds.AddCategoryRequest{
+			Category: ds.Category{
+				ParentUid: &parentUid,
+				Name:      "Wooden Beams ",
+			},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		checkInsert := func(_ context.Context, arg sqlc.InsertCategoryParams) (uuid.UUID, error) {
+			require.Equal(t, "wooden-beams", arg.Slug)
+			require.Equal(t, uuid.NullUUID{UUID: parentUid, Valid: true}, arg.ParentID)
+			return arg.Uid, nil
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsCategoryExists(gomock.Any(), parentUid).Return(true, nil)
+		tc.querierMock.EXPECT().InsertCategory(gomock.Any(), gomock.Any()).DoAndReturn(checkInsert)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.AddCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Uid)
+	})
+
+	t.Run("AddCategory no parent", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		parentUid := uuid.New()
+		req := &ds.AddCategoryRequest{
+			Category: ds.Category{
+				ParentUid: &parentUid,
+				Name:      "Name",
+			},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsCategoryExists(gomock.Any(), parentUid).Return(false, nil)
+
+		resp, err := tc.client.AddCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusCategoryNoParent)
+	})
+
+	t.Run("AddCategory already exists", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddCategoryRequest{
+			Category: ds.Category{Name: "Name"},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().InsertCategory(gomock.Any(), gomock.Any()).Return(uuid.Nil, sql.ErrNoRows)
+
+		resp, err := tc.client.AddCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusAlreadyExists)
+	})
+
+	t.Run("AddCategory error on InsertCategory", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddCategoryRequest{
+			Category: ds.Category{Name: "Name"},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().InsertCategory(gomock.Any(), gomock.Any()).Return(uuid.Nil, errTest)
+
+		resp, err := tc.client.AddCategory(req)
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestUpdateCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UpdateCategory ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		parentUid := uuid.New()
+		req := &ds.UpdateCategoryRequest{
+			Uid:       uuid.New(),
+			ParentUid: &parentUid,
+			Name:      "Name",
+			Slug:      "Custom Slug",
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsCategoryExists(gomock.Any(), parentUid).Return(true, nil)
+		tc.querierMock.EXPECT().IsCategoryInSubtree(gomock.Any(), sqlc.IsCategoryInSubtreeParams{
+			RootUid: req.Uid,
+			Uid:     parentUid,
+		}).Return(false, nil)
+		tc.querierMock.EXPECT().UpdateCategory(gomock.Any(), sqlc.UpdateCategoryParams{
+			ParentID:        uuid.NullUUID{UUID: parentUid, Valid: true},
+			Name:            req.Name,
+			Slug:            "custom-slug",
+			AttributeSchema: json.RawMessage(`[]`),
+			Uid:             req.Uid,
+		}).Return(req.Uid, nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.UpdateCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusOK)
+	})
+
+	t.Run("UpdateCategory cycle", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		parentUid := uuid.New()
+		req := &ds.UpdateCategoryRequest{
+			Uid:       uuid.New(),
+			ParentUid: &parentUid,
+			Name:      "Name",
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsCategoryExists(gomock.Any(), parentUid).Return(true, nil)
+		tc.querierMock.EXPECT().IsCategoryInSubtree(gomock.Any(), gomock.Any()).Return(true, nil)
+
+		resp, err := tc.client.UpdateCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusCategoryCycle)
+	})
+
+	t.Run("UpdateCategory not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.UpdateCategoryRequest{
+			Uid:  uuid.New(),
+			Name: "Name",
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Return(uuid.Nil, sql.ErrNoRows)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.UpdateCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
+	})
+
+	t.Run("UpdateCategory slug taken", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.UpdateCategoryRequest{
+			Uid:  uuid.New(),
+			Name: "Name",
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Return(uuid.Nil, &pq.Error{Code: uniqueViolationCode})
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.UpdateCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusAlreadyExists)
+	})
+
+	t.Run("UpdateCategory error on UpdateCategory", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.UpdateCategoryRequest{
+			Uid:  uuid.New(),
+			Name: "Name",
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Return(uuid.Nil, errTest)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.UpdateCategory(req)
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestDeleteCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteCategory ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.DeleteCategoryRequest{Uid: uuid.New()}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsCategoryInUse(gomock.Any(), req.Uid).Return(false, nil)
+		tc.querierMock.EXPECT().DeleteCategory(gomock.Any(), req.Uid).Return(req.Uid, nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.DeleteCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusOK)
+	})
+
+	t.Run("DeleteCategory in use", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.DeleteCategoryRequest{Uid: uuid.New()}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsCategoryInUse(gomock.Any(), req.Uid).Return(true, nil)
+
+		resp, err := tc.client.DeleteCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusCategoryInUse)
+	})
+
+	t.Run("DeleteCategory not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.DeleteCategoryRequest{Uid: uuid.New()}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsCategoryInUse(gomock.Any(), req.Uid).Return(false, nil)
+		tc.querierMock.EXPECT().DeleteCategory(gomock.Any(), req.Uid).Return(uuid.Nil, sql.ErrNoRows)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.DeleteCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
+	})
+
+	t.Run("DeleteCategory error on IsCategoryInUse", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.DeleteCategoryRequest{Uid: uuid.New()}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsCategoryInUse(gomock.Any(), req.Uid).Return(false, errTest)
+
+		resp, err := tc.client.DeleteCategory(req)
+		require.True(t, errors.Is(err, errTest))
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetCategory ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.GetCategoryRequest{Uid: uuid.New()}
+		res := sqlc.Category{
+			Uid:             req.Uid,
+			Name:            "Name",
+			Slug:            "name",
+			SortOrder:       3,
+			AttributeSchema: json.RawMessage(`[{"name": "voltage", "type": "number", "unit": "V"}]`),
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetCategory(gomock.Any(), req.Uid).Return(res, nil)
+
+		resp, err := tc.client.GetCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.Category.Uid, res.Uid)
+		require.Equal(t, resp.Category.Slug, res.Slug)
+		require.Nil(t, resp.Category.ParentUid)
+		require.Equal(t, resp.Category.Attributes[0].Name, "voltage")
+	})
+
+	t.Run("GetCategory not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.GetCategoryRequest{Uid: uuid.New()}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetCategory(gomock.Any(), req.Uid).Return(sqlc.Category{}, sql.ErrNoRows)
+
+		resp, err := tc.client.GetCategory(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
+	})
+}
+
+func TestGetCategoriesTree(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetCategoriesTree ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		root := uuid.New()
+		child := uuid.New()
+		res := []sqlc.Category{
+			{Uid: root, Name: "Construction", Slug: "construction", AttributeSchema: json.RawMessage(`[]`)},
+			{Uid: uuid.New(), Name: "Garden", Slug: "garden", SortOrder: 1, AttributeSchema: json.RawMessage(`[]`)},
+			{Uid: child, ParentID: uuid.NullUUID{UUID: root, Valid: true}, Name: "Beams", Slug: "beams", AttributeSchema: json.RawMessage(`[]`)},
+			{Uid: uuid.New(), ParentID: uuid.NullUUID{UUID: child, Valid: true}, Name: "Oak", Slug: "oak", AttributeSchema: json.RawMessage(`[]`)},
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetAllCategories(gomock.Any()).Return(res, nil)
+
+		resp, err := tc.client.GetCategoriesTree(&ds.GetCategoriesTreeRequest{})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Len(t, resp.Categories, 2)
+		require.Equal(t, resp.Categories[0].Slug, "construction")
+		require.Equal(t, resp.Categories[0].Children[0].Slug, "beams")
+		require.Equal(t, resp.Categories[0].Children[0].Children[0].Slug, "oak")
+		require.Empty(t, resp.Categories[1].Children)
+	})
+
+	t.Run("GetCategoriesTree error on GetAllCategories", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetAllCategories(gomock.Any()).Return(nil, errTest)
+
+		resp, err := tc.client.GetCategoriesTree(&ds.GetCategoriesTreeRequest{})
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
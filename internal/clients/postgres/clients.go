@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"shopapi/internal/clients/postgres/sqlc"
@@ -11,6 +12,8 @@ import (
 	"shopapi/internal/supports"
 )
 
+const defaultClientMinScore = 0.3
+
 func (c *Client) AddClient(req *ds.AddClientRequest) (resp *ds.AddClientResponse, err error) {
 	uid := supports.GetUUIDIfEmpty(req.Uid)
 
@@ -45,18 +48,21 @@ func (c *Client) AddClient(req *ds.AddClientRequest) (resp *ds.AddClientResponse
 		}
 
 		resp = &ds.AddClientResponse{Uid: &uid}
-		return nil
+		return audit(ctx, q, req.Audit, "AddClient", ds.AuditClient, uid.String(), nil)
 	})
 
 	return
 }
 
+// DeleteClient moves the client to the trash until the purge deletes it for good.
 func (c *Client) DeleteClient(req *ds.DeleteClientRequest) (resp *ds.DeleteClientResponse, err error) {
-
-	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, q IQuerier) error {
-
-		addressId, err := q.DeleteClient(ctx, req.Uid)
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditClient, req.Uid.String())
 		if err != nil {
+			return err
+		}
+
+		if _, err = qtx.DeleteClient(ctx, req.Uid); err != nil {
 			if !errors.Is(err, sql.ErrNoRows) {
 				return err
 			}
@@ -66,28 +72,37 @@ func (c *Client) DeleteClient(req *ds.DeleteClientRequest) (resp *ds.DeleteClien
 			return nil
 		}
 
-		clients, err := q.CalculateClientsWithAddress(ctx, addressId)
-		if err != nil {
-			return err
+		resp = &ds.DeleteClientResponse{
+			Status: ds.Status{Message: ds.StatusOK},
 		}
+		return audit(ctx, qtx, req.Audit, "DeleteClient", ds.AuditClient, req.Uid.String(), before)
+	})
 
-		suppliers, err := q.CalculateSuppliersWithAddress(ctx, addressId)
+	return
+}
+
+// RestoreClient takes the client out of the trash, restoring a client not deleted succeeds as well.
+func (c *Client) RestoreClient(req *ds.RestoreClientRequest) (resp *ds.RestoreClientResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditClient, req.Uid.String())
 		if err != nil {
 			return err
 		}
 
-		if clients == 0 && suppliers == 0 {
-			err := q.DeleteAddress(ctx, addressId)
-			if err != nil {
+		if _, err = qtx.RestoreClient(ctx, req.Uid); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
 				return err
 			}
+			resp = &ds.RestoreClientResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}
+			return nil
 		}
 
-		resp = &ds.DeleteClientResponse{
+		resp = &ds.RestoreClientResponse{
 			Status: ds.Status{Message: ds.StatusOK},
 		}
-
-		return nil
+		return audit(ctx, qtx, req.Audit, "RestoreClient", ds.AuditClient, req.Uid.String(), before)
 	})
 
 	return
@@ -102,95 +117,145 @@ func (c *Client) GetClients(req *ds.GetClientsRequest) (*ds.GetClientsResponse,
 	ctx, cancel := c.db.CtxWithCancel()
 	defer cancel()
 
-	if req.Limit == 0 && req.Offset == 0 {
-		clients, err = q.GetAllClients(ctx)
-		if err != nil {
-			return nil, err
-		}
-	} else {
+	switch {
+	case req.AsOf != nil && req.Limit == 0 && req.Offset == 0:
+		clients, err = q.GetAllClientsAsOf(ctx, sqlc.GetAllClientsAsOfParams{
+			AsOf:           *req.AsOf,
+			IncludeDeleted: req.IncludeDeleted,
+		})
+	case req.AsOf != nil:
+		clients, err = q.GetClientsPageAsOf(ctx, sqlc.GetClientsPageAsOfParams{
+			AsOf:           *req.AsOf,
+			IncludeDeleted: req.IncludeDeleted,
+			Offset:         int32(req.Offset),
+			Limit:          int32(req.Limit),
+		})
+	case req.Limit == 0 && req.Offset == 0:
+		clients, err = q.GetAllClients(ctx, req.IncludeDeleted)
+	default:
 		clients, err = q.GetClientsPage(ctx, sqlc.GetClientsPageParams{
-			Offset: int32(req.Offset),
-			Limit:  int32(req.Limit),
+			IncludeDeleted: req.IncludeDeleted,
+			Offset:         int32(req.Offset),
+			Limit:          int32(req.Limit),
 		})
-		if err != nil {
-			return nil, err
-		}
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	resp := &ds.GetClientsResponse{}
 	resp.Clients = make([]ds.Client, 0, len(clients))
-	for _, c := range clients {
-		resp.Clients = append(resp.Clients, ds.Client{
-			Birthday:         ds.DateOnly(c.Birthday),
-			RegistrationDate: ds.DateOnly(c.RegistrationDate),
-			Name:             c.ClientName,
-			Surname:          c.ClientSurname,
-			Gender:           ds.Gender(c.Gender),
-			Uid:              c.Uid,
-			Address: &ds.Address{
-				Country: c.Country,
-				City:    c.City,
-				Street:  c.Street,
-			},
-		})
+	for i := range clients {
+		resp.Clients = append(resp.Clients, fromDBClient(&clients[i]))
 	}
 
 	return resp, nil
 }
 
 func (c *Client) GetClientsByName(req *ds.GetClientsByNameRequest) (*ds.GetClientsByNameResponse, error) {
-	ctx, cancel := c.db.CtxWithCancel()
-	defer cancel()
+	query := strings.TrimSpace(supports.Concat(req.Name, " ", req.Surname))
+	altQuery := query
+	if req.Transliterate {
+		altQuery = supports.Transliterate(query)
+	}
+
+	minScore := req.MinScore
+	if minScore == 0 {
+		minScore = defaultClientMinScore
+	}
 
-	clients, err := c.db.Querier().GetClientsWithName(ctx, sqlc.GetClientsWithNameParams{
-		ClientName:    req.Name,
-		ClientSurname: req.Surname,
+	var clients []sqlc.SearchClientsByNameRow
+	err := c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) (err error) {
+		// The search filters by the <% operator for the trigram index to be used, it matches by the threshold
+		// set for the transaction.
+		if err = qtx.SetWordSimilarityThreshold(ctx, minScore); err != nil {
+			return err
+		}
+
+		clients, err = qtx.SearchClientsByName(ctx, sqlc.SearchClientsByNameParams{
+			Query:          query,
+			AltQuery:       altQuery,
+			IncludeDeleted: req.IncludeDeleted,
+			MinScore:       minScore,
+			PageOffset:     int32(req.Offset),
+			PageLimit:      int32(req.Limit),
+		})
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	resp := &ds.GetClientsByNameResponse{}
-	resp.Clients = make([]ds.Client, 0, len(clients))
+	resp.Clients = make([]ds.ClientMatch, 0, len(clients))
 	for _, c := range clients {
-		resp.Clients = append(resp.Clients, ds.Client{
-			Birthday:         ds.DateOnly(c.Birthday),
-			RegistrationDate: ds.DateOnly(c.RegistrationDate),
-			Name:             c.ClientName,
-			Surname:          c.ClientSurname,
-			Gender:           ds.Gender(c.Gender),
-			Uid:              c.Uid,
-			Address: &ds.Address{
-				Country: c.Country,
-				City:    c.City,
-				Street:  c.Street,
+		resp.Clients = append(resp.Clients, ds.ClientMatch{
+			Client: ds.Client{
+				Birthday:         ds.DateOnly(c.Birthday),
+				RegistrationDate: ds.DateOnly(c.RegistrationDate),
+				Name:             c.ClientName,
+				Surname:          c.ClientSurname,
+				Gender:           ds.Gender(c.Gender),
+				Uid:              c.Uid,
+				Address: &ds.Address{
+					Country: c.Country,
+					City:    c.City,
+					Street:  c.Street,
+				},
+				DeletedAt: fromNullTime(c.DeletedAt),
 			},
+			Score: c.Score,
 		})
 	}
 
 	return resp, nil
 }
 
-func (c *Client) PatchClientAddress(req *ds.PatchClientAddressRequest) (*ds.PatchClientAddressResponse, error) {
-	ctx, cancel := c.db.CtxWithCancel()
-	defer cancel()
+func (c *Client) PatchClientAddress(req *ds.PatchClientAddressRequest) (resp *ds.PatchClientAddressResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditClient, req.Uid.String())
+		if err != nil {
+			return err
+		}
 
-	_, err := c.db.Querier().UpdateClientAddress(ctx, sqlc.UpdateClientAddressParams{
-		Country: req.Address.Country,
-		City:    req.Address.City,
-		Street:  req.Address.Street,
-		Uid:     req.Uid,
-	})
-	if err != nil {
-		if !errors.Is(err, sql.ErrNoRows) {
-			return nil, err
+		_, err = qtx.UpdateClientAddress(ctx, sqlc.UpdateClientAddressParams{
+			Country: req.Address.Country,
+			City:    req.Address.City,
+			Street:  req.Address.Street,
+			Uid:     req.Uid,
+		})
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			resp = &ds.PatchClientAddressResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}
+			return nil
 		}
-		return &ds.PatchClientAddressResponse{
-			Status: ds.Status{Message: ds.StatusNotFound},
-		}, nil
-	}
 
-	return &ds.PatchClientAddressResponse{
-		Status: ds.Status{Message: ds.StatusOK},
-	}, nil
+		resp = &ds.PatchClientAddressResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "PatchClientAddress", ds.AuditClient, req.Uid.String(), before)
+	})
+
+	return
+}
+
+func fromDBClient(c *sqlc.ClientDetail) ds.Client {
+	return ds.Client{
+		Birthday:         ds.DateOnly(c.Birthday),
+		RegistrationDate: ds.DateOnly(c.RegistrationDate),
+		Name:             c.ClientName,
+		Surname:          c.ClientSurname,
+		Gender:           ds.Gender(c.Gender),
+		Uid:              c.Uid,
+		Address: &ds.Address{
+			Country: c.Country,
+			City:    c.City,
+			Street:  c.Street,
+		},
+		DeletedAt: fromNullTime(c.DeletedAt),
+	}
 }
@@ -2,7 +2,7 @@
 INSERT INTO addresses (country, city, street)
 VALUES ($1, $2, $3) ON CONFLICT (country, city, street)
 DO UPDATE SET 
-    country = EXCLUDED.country
+    country = EXCLUDED.country, orphaned_at = NULL
 RETURNING id;
 
 -- name: InsertClient :one
@@ -13,8 +13,28 @@ DO NOTHING
 RETURNING uid;
 
 -- name: DeleteClient :one
-DELETE FROM clients WHERE uid = $1
-RETURNING address_id;
+UPDATE clients
+SET deleted_at = now()
+WHERE uid = $1 AND deleted_at IS NULL
+RETURNING uid;
+
+-- name: RestoreClient :one
+UPDATE clients
+SET deleted_at = NULL
+WHERE uid = $1
+RETURNING uid;
+
+-- name: PurgeClients :many
+DELETE FROM clients c
+WHERE c.uid IN (
+    SELECT o.uid
+    FROM clients o
+    WHERE o.deleted_at <= sqlc.arg(deleted_before)::timestamptz
+    ORDER BY o.deleted_at, o.uid
+    LIMIT sqlc.arg(batch)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING c.uid;
 
 -- name: CalculateClientsWithAddress :one
 SELECT COUNT(*) as clientsAmount 
@@ -28,22 +48,39 @@ WHERE id = $1;
 -- name: GetAllClients :many
 SELECT *
 FROM client_details
+WHERE sqlc.arg(include_deleted)::bool OR deleted_at IS NULL
 ORDER BY uid;
 
 -- name: GetClientsPage :many
 SELECT *
 FROM client_details
+WHERE sqlc.arg(include_deleted)::bool OR deleted_at IS NULL
 ORDER BY uid
-OFFSET $1
-LIMIT $2;
+OFFSET sqlc.arg('offset')
+LIMIT sqlc.arg('limit');
 
--- name: GetClientsWithName :many
+-- name: SearchClientsByName :many
 SELECT *
-FROM client_details
-WHERE client_name = $1 AND client_surname = $2;
+FROM (
+    SELECT cd.*, GREATEST(
+        word_similarity(sqlc.arg(query)::text, cd.client_name || ' ' || cd.client_surname),
+        word_similarity(sqlc.arg(alt_query)::text, cd.client_name || ' ' || cd.client_surname)
+    )::float8 AS score
+    FROM client_details cd
+    WHERE (sqlc.arg(include_deleted)::bool OR cd.deleted_at IS NULL)
+        AND (sqlc.arg(query)::text <% (cd.client_name || ' ' || cd.client_surname)
+            OR sqlc.arg(alt_query)::text <% (cd.client_name || ' ' || cd.client_surname))
+) matches
+WHERE matches.score >= sqlc.arg(min_score)::float8
+ORDER BY matches.score DESC, matches.uid
+OFFSET sqlc.arg(page_offset)::int
+LIMIT NULLIF(sqlc.arg(page_limit)::int, 0);
+
+-- name: SetWordSimilarityThreshold :exec
+SELECT set_config('pg_trgm.word_similarity_threshold', sqlc.arg(threshold)::float8::text, true);
 
 -- name: UpdateClientAddress :one
 UPDATE addresses
 SET country = $1, city = $2, street = $3
-WHERE (SELECT address_id FROM clients WHERE uid = $4) = id
+WHERE (SELECT address_id FROM clients WHERE uid = $4 AND deleted_at IS NULL) = id
 RETURNING id;
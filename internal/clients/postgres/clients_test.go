@@ -46,6 +46,7 @@ func TestAddClient(t *testing.T) {
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
 		tc.querierMock.EXPECT().InsertAddress(gomock.Any(), gomock.Any()).Return(int32(1), nil)
 		tc.querierMock.EXPECT().InsertClient(gomock.Any(), gomock.Any()).Return(uid, nil)
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.AddClient(req)
 		require.Nil(t, err)
@@ -168,142 +169,98 @@ func TestDeleteClient(t *testing.T) {
 			Uid: uid,
 		}
 
-		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
-			return fn(tc.ctx, tc.querierMock)
-		}
-
-		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-
-		tc.querierMock.EXPECT().DeleteClient(gomock.Any(), gomock.Any()).Return(int32(11), nil)
-		tc.querierMock.EXPECT().CalculateClientsWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
-		tc.querierMock.EXPECT().CalculateSuppliersWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
-		tc.querierMock.EXPECT().DeleteAddress(gomock.Any(), gomock.Any()).Return(nil)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().DeleteClient(gomock.Any(), uid).Return(uid, nil)
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.DeleteClient(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
 	})
 
 	t.Run("DeleteClient error on DeleteClient", func(t *testing.T) {
 		t.Parallel()
 		tc := NewTestClient(t)
 
-		uid := uuid.New()
-
 		req := &ds.DeleteClientRequest{
-			Uid: uid,
+			Uid: uuid.New(),
 		}
 
-		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
-			return fn(tc.ctx, tc.querierMock)
-		}
-
-		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-
-		tc.querierMock.EXPECT().DeleteClient(gomock.Any(), gomock.Any()).Return(int32(0), errTest)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().DeleteClient(gomock.Any(), gomock.Any()).Return(uuid.Nil, errTest)
 
 		resp, err := tc.client.DeleteClient(req)
 		require.NotNil(t, err)
 		require.Nil(t, resp)
 	})
 
-	t.Run("DeleteClient error on CalculateClientsWithAddress", func(t *testing.T) {
+	t.Run("DeleteClient not found", func(t *testing.T) {
 		t.Parallel()
 		tc := NewTestClient(t)
 
-		uid := uuid.New()
-
 		req := &ds.DeleteClientRequest{
-			Uid: uid,
-		}
-
-		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
-			return fn(tc.ctx, tc.querierMock)
+			Uid: uuid.New(),
 		}
 
-		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-
-		tc.querierMock.EXPECT().DeleteClient(gomock.Any(), gomock.Any()).Return(int32(11), nil)
-		tc.querierMock.EXPECT().CalculateClientsWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), errTest)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().DeleteClient(gomock.Any(), gomock.Any()).Return(uuid.Nil, sql.ErrNoRows)
 
 		resp, err := tc.client.DeleteClient(req)
-		require.NotNil(t, err)
-		require.Nil(t, resp)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
 	})
+}
+
+func TestRestoreClient(t *testing.T) {
+	t.Parallel()
 
-	t.Run("DeleteClient error on CalculateSuppliersWithAddress", func(t *testing.T) {
+	t.Run("RestoreClient Ok", func(t *testing.T) {
 		t.Parallel()
 		tc := NewTestClient(t)
 
 		uid := uuid.New()
 
-		req := &ds.DeleteClientRequest{
-			Uid: uid,
-		}
-
-		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
-			return fn(tc.ctx, tc.querierMock)
-		}
-
-		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().RestoreClient(gomock.Any(), uid).Return(uid, nil)
+		expectAudit(tc, 1)
 
-		tc.querierMock.EXPECT().DeleteClient(gomock.Any(), gomock.Any()).Return(int32(11), nil)
-		tc.querierMock.EXPECT().CalculateClientsWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
-		tc.querierMock.EXPECT().CalculateSuppliersWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), errTest)
-
-		resp, err := tc.client.DeleteClient(req)
-		require.NotNil(t, err)
-		require.Nil(t, resp)
+		resp, err := tc.client.RestoreClient(&ds.RestoreClientRequest{Uid: uid})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
 	})
 
-	t.Run("DeleteClient error on DeleteAddress", func(t *testing.T) {
+	t.Run("RestoreClient error on RestoreClient", func(t *testing.T) {
 		t.Parallel()
 		tc := NewTestClient(t)
 
-		uid := uuid.New()
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().RestoreClient(gomock.Any(), gomock.Any()).Return(uuid.Nil, errTest)
 
-		req := &ds.DeleteClientRequest{
-			Uid: uid,
-		}
-
-		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
-			return fn(tc.ctx, tc.querierMock)
-		}
-
-		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-
-		tc.querierMock.EXPECT().DeleteClient(gomock.Any(), gomock.Any()).Return(int32(11), nil)
-		tc.querierMock.EXPECT().CalculateClientsWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
-		tc.querierMock.EXPECT().CalculateSuppliersWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
-		tc.querierMock.EXPECT().DeleteAddress(gomock.Any(), gomock.Any()).Return(errTest)
-
-		resp, err := tc.client.DeleteClient(req)
+		resp, err := tc.client.RestoreClient(&ds.RestoreClientRequest{Uid: uuid.New()})
 		require.NotNil(t, err)
 		require.Nil(t, resp)
 	})
 
-	t.Run("DeleteClient not found", func(t *testing.T) {
+	t.Run("RestoreClient not found", func(t *testing.T) {
 		t.Parallel()
 		tc := NewTestClient(t)
 
-		uid := uuid.New()
-
-		req := &ds.DeleteClientRequest{
-			Uid: uid,
-		}
-
-		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
-			return fn(tc.ctx, tc.querierMock)
-		}
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().RestoreClient(gomock.Any(), gomock.Any()).Return(uuid.Nil, sql.ErrNoRows)
 
-		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-
-		tc.querierMock.EXPECT().DeleteClient(gomock.Any(), gomock.Any()).Return(int32(0), sql.ErrNoRows)
-
-		resp, err := tc.client.DeleteClient(req)
+		resp, err := tc.client.RestoreClient(&ds.RestoreClientRequest{Uid: uuid.New()})
 		require.Nil(t, err)
 		require.NotNil(t, resp)
-		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
 	})
 }
 
@@ -336,7 +293,7 @@ func TestGetClients(t *testing.T) {
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
 		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().GetAllClients(gomock.Any()).Return(sqlcResp, nil)
+		tc.querierMock.EXPECT().GetAllClients(gomock.Any(), false).Return(sqlcResp, nil)
 
 		resp, err := tc.client.GetClients(req)
 		require.Nil(t, err)
@@ -352,6 +309,31 @@ func TestGetClients(t *testing.T) {
 		require.Equal(t, resp.Clients[0].Address.Street, sqlcResp[0].Street)
 	})
 
+	t.Run("GetClients including deleted", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		req := &ds.GetClientsRequest{
+			DeletedFlag: ds.DeletedFlag{IncludeDeleted: true},
+		}
+		deletedAt := time.Now()
+
+		sqlcResp := []sqlc.ClientDetail{
+			{Uid: uuid.New(), Gender: string(ds.Female), DeletedAt: sql.NullTime{Time: deletedAt, Valid: true}},
+			{Uid: uuid.New(), Gender: string(ds.Male)},
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetAllClients(gomock.Any(), true).Return(sqlcResp, nil)
+
+		resp, err := tc.client.GetClients(req)
+		require.Nil(t, err)
+		require.Len(t, resp.Clients, 2)
+		require.Equal(t, &deletedAt, resp.Clients[0].DeletedAt)
+		require.Nil(t, resp.Clients[1].DeletedAt)
+	})
+
 	t.Run("GetClients with NO offset and limit error", func(t *testing.T) {
 		t.Parallel()
 		tc := NewTestClient(t)
@@ -363,7 +345,7 @@ func TestGetClients(t *testing.T) {
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
 		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().GetAllClients(gomock.Any()).Return(nil, errTest)
+		tc.querierMock.EXPECT().GetAllClients(gomock.Any(), false).Return(nil, errTest)
 
 		resp, err := tc.client.GetClients(req)
 		require.NotNil(t, err)
@@ -450,7 +432,7 @@ func TestGetClientsByName(t *testing.T) {
 		}
 		uid := uuid.New()
 
-		sqlcResp := []sqlc.ClientDetail{
+		sqlcResp := []sqlc.SearchClientsByNameRow{
 			{
 				ClientName:       req.Name,
 				ClientSurname:    req.Surname,
@@ -461,21 +443,68 @@ func TestGetClientsByName(t *testing.T) {
 				Country:          "USA",
 				City:             "Redwood",
 				Street:           "1st AVE",
+				Score:            0.75,
 			},
 		}
 
-		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().GetClientsWithName(gomock.Any(), gomock.Any()).Return(sqlcResp, nil)
+		params := sqlc.SearchClientsByNameParams{
+			Query:    "Name Surname",
+			AltQuery: "Name Surname",
+			MinScore: defaultClientMinScore,
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().SetWordSimilarityThreshold(gomock.Any(), defaultClientMinScore).Return(nil)
+		tc.querierMock.EXPECT().SearchClientsByName(gomock.Any(), params).Return(sqlcResp, nil)
 
 		resp, err := tc.client.GetClientsByName(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
 		require.Equal(t, resp.Clients[0].Name, sqlcResp[0].ClientName)
 		require.Equal(t, resp.Clients[0].Surname, sqlcResp[0].ClientSurname)
+		require.Equal(t, resp.Clients[0].Score, sqlcResp[0].Score)
 	})
 
-	t.Run("GetClientsByName error on GetClientsWithName", func(t *testing.T) {
+	t.Run("GetClientsByName Ok transliterated", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		req := &ds.GetClientsByNameRequest{
+			Name:          "Василиса",
+			Surname:       "Кадык",
+			Transliterate: true,
+			MinScore:      0.5,
+			Limit:         10,
+			Offset:        20,
+		}
+
+		params := sqlc.SearchClientsByNameParams{
+			Query:      "Василиса Кадык",
+			AltQuery:   "Vasilisa Kadyk",
+			MinScore:   0.5,
+			PageOffset: 20,
+			PageLimit:  10,
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().SetWordSimilarityThreshold(gomock.Any(), 0.5).Return(nil)
+		tc.querierMock.EXPECT().SearchClientsByName(gomock.Any(), params).Return(nil, nil)
+
+		resp, err := tc.client.GetClientsByName(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Empty(t, resp.Clients)
+	})
+
+	t.Run("GetClientsByName error on SearchClientsByName", func(t *testing.T) {
 		t.Parallel()
 		tc := NewTestClient(t)
 
@@ -484,9 +513,13 @@ func TestGetClientsByName(t *testing.T) {
 			Surname: "Surname",
 		}
 
-		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().GetClientsWithName(gomock.Any(), gomock.Any()).Return(nil, errTest)
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().SetWordSimilarityThreshold(gomock.Any(), defaultClientMinScore).Return(nil)
+		tc.querierMock.EXPECT().SearchClientsByName(gomock.Any(), gomock.Any()).Return(nil, errTest)
 
 		resp, err := tc.client.GetClientsByName(req)
 		require.NotNil(t, err)
@@ -511,9 +544,10 @@ func TestPatchClientAddress(t *testing.T) {
 			},
 		}
 
-		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
 		tc.querierMock.EXPECT().UpdateClientAddress(gomock.Any(), gomock.Any()).Return(int32(0), nil)
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.PatchClientAddress(req)
 		require.Nil(t, err)
@@ -534,8 +568,8 @@ func TestPatchClientAddress(t *testing.T) {
 			},
 		}
 
-		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
 		tc.querierMock.EXPECT().UpdateClientAddress(gomock.Any(), gomock.Any()).Return(int32(0), errTest)
 
 		resp, err := tc.client.PatchClientAddress(req)
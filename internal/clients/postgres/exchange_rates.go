@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+)
+
+func (c *Client) SetExchangeRate(req *ds.SetExchangeRateRequest) (resp *ds.SetExchangeRateResponse, err error) {
+	rate, ok := new(big.Rat).SetString(req.Rate)
+	if !ok || rate.Sign() <= 0 {
+		return &ds.SetExchangeRateResponse{
+			Status: ds.Status{Message: ds.StatusInvalidRate},
+		}, nil
+	}
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditExchangeRate, req.Currency)
+		if err != nil {
+			return err
+		}
+
+		// The rate is kept as it's sent, NUMERIC has no limit on the digits.
+		err = qtx.SetExchangeRate(ctx, sqlc.SetExchangeRateParams{
+			Currency: req.Currency,
+			Rate:     req.Rate,
+		})
+		if err != nil {
+			return err
+		}
+
+		resp = &ds.SetExchangeRateResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "SetExchangeRate", ds.AuditExchangeRate, req.Currency, before)
+	})
+
+	return
+}
+
+func (c *Client) GetExchangeRates(_ *ds.GetExchangeRatesRequest) (*ds.GetExchangeRatesResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	rates, err := c.db.Querier().GetExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ds.GetExchangeRatesResponse{
+		Rates: make([]ds.ExchangeRate, len(rates)),
+	}
+	for i, r := range rates {
+		resp.Rates[i] = ds.ExchangeRate{
+			Currency:  r.Currency,
+			Rate:      r.Rate,
+			UpdatedAt: r.UpdatedAt,
+		}
+	}
+
+	return resp, nil
+}
+
+// convertPrices converts the variant prices of the products to the currency, the prices stay as they are if it's empty.
+// The status is StatusNoExchangeRate if a currency to convert from or to has no rate.
+func convertPrices(ctx context.Context, q IQuerier, currency string, products ...*ds.Product) (string, error) {
+	if currency == "" {
+		return "", nil
+	}
+
+	convert := converter(ctx, q, currency)
+	for _, p := range products {
+		for i := range p.Variants {
+			v := &p.Variants[i]
+
+			price, ok, err := convert(v.Price)
+			if err != nil || !ok {
+				return ds.StatusNoExchangeRate, err
+			}
+			v.Price = price
+
+			if v.RegularPrice == nil {
+				continue
+			}
+			regular, ok, err := convert(*v.RegularPrice)
+			if err != nil || !ok {
+				return ds.StatusNoExchangeRate, err
+			}
+			v.RegularPrice = &regular
+		}
+	}
+
+	return "", nil
+}
+
+// converter returns the conversion to the currency, it reports false if a currency to convert from or to has no rate.
+// The rates are read once on the first conversion of another currency.
+func converter(ctx context.Context, q IQuerier, currency string) func(money.Money) (money.Money, bool, error) {
+	var rates map[string]*big.Rat
+	return func(m money.Money) (money.Money, bool, error) {
+		if m.Currency() == currency {
+			return m, true, nil
+		}
+
+		if rates == nil {
+			var err error
+			if rates, err = exchangeRates(ctx, q); err != nil {
+				return money.Money{}, false, err
+			}
+		}
+
+		from, ok := rates[m.Currency()]
+		if !ok {
+			return money.Money{}, false, nil
+		}
+		to, ok := rates[currency]
+		if !ok {
+			return money.Money{}, false, nil
+		}
+
+		converted, err := m.Convert(currency, new(big.Rat).Quo(from, to))
+		return converted, err == nil, err
+	}
+}
+
+// exchangeRates returns the rates by currency, RUB is the unit they are set in.
+func exchangeRates(ctx context.Context, q IQuerier) (map[string]*big.Rat, error) {
+	rows, err := q.GetExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]*big.Rat, len(rows)+1)
+	rates[money.RUB] = big.NewRat(1, 1)
+	for _, r := range rows {
+		rate, ok := new(big.Rat).SetString(r.Rate)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s exchange rate %q", r.Currency, r.Rate)
+		}
+		rates[r.Currency] = rate
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,10 @@
+-- name: SetExchangeRate :exec
+INSERT INTO exchange_rates (currency, rate, updated_at)
+VALUES ($1, $2, now())
+ON CONFLICT (currency) DO UPDATE
+SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at;
+
+-- name: GetExchangeRates :many
+SELECT *
+FROM exchange_rates r
+ORDER BY r.currency;
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SetExchangeRate ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().SetExchangeRate(gomock.Any(), sqlc.SetExchangeRateParams{
+			Currency: "USD",
+			Rate:     "92.5",
+		}).Return(nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.SetExchangeRate(&ds.SetExchangeRateRequest{Currency: "USD", Rate: "92.5"})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("SetExchangeRate not positive", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		resp, err := tc.client.SetExchangeRate(&ds.SetExchangeRateRequest{Currency: "USD", Rate: "-1"})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusInvalidRate, resp.GetStatus())
+	})
+
+	t.Run("SetExchangeRate error", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().SetExchangeRate(gomock.Any(), gomock.Any()).Return(errTest)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.SetExchangeRate(&ds.SetExchangeRateRequest{Currency: "USD", Rate: "92.5"})
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetExchangeRates(t *testing.T) {
+	t.Parallel()
+
+	updated := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("GetExchangeRates ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetExchangeRates(gomock.Any()).Return([]sqlc.ExchangeRate{
+			{Currency: "USD", Rate: "92.5", UpdatedAt: updated},
+		}, nil)
+
+		resp, err := tc.client.GetExchangeRates(&ds.GetExchangeRatesRequest{})
+		require.Nil(t, err)
+		require.Equal(t, []ds.ExchangeRate{{Currency: "USD", Rate: "92.5", UpdatedAt: updated}}, resp.Rates)
+	})
+
+	t.Run("GetExchangeRates error", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetExchangeRates(gomock.Any()).Return(nil, errTest)
+
+		resp, err := tc.client.GetExchangeRates(&ds.GetExchangeRatesRequest{})
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestConvertPrices(t *testing.T) {
+	t.Parallel()
+
+	rates := []sqlc.ExchangeRate{{Currency: "USD", Rate: "90"}, {Currency: "EUR", Rate: "100"}}
+
+	t.Run("convertPrices through RUB", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.querierMock.EXPECT().GetExchangeRates(gomock.Any()).Return(rates, nil)
+
+		regular := money.New(35995, money.RUB)
+		product := &ds.Product{Variants: []ds.ProductVariant{
+			{Price: money.New(29995, money.RUB), RegularPrice: &regular},
+			{Price: money.New(1000, "EUR")},
+			{Price: money.New(500, "USD")},
+		}}
+
+		status, err := convertPrices(context.Background(), tc.querierMock, "USD", product)
+		require.Nil(t, err)
+		require.Empty(t, status)
+		require.Equal(t, money.New(333, "USD"), product.Variants[0].Price)
+		require.Equal(t, money.New(400, "USD"), *product.Variants[0].RegularPrice)
+		require.Equal(t, money.New(1111, "USD"), product.Variants[1].Price)
+		require.Equal(t, money.New(500, "USD"), product.Variants[2].Price)
+	})
+
+	t.Run("convertPrices no currency", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		product := &ds.Product{Variants: []ds.ProductVariant{{Price: money.New(29995, money.RUB)}}}
+
+		status, err := convertPrices(context.Background(), tc.querierMock, "", product)
+		require.Nil(t, err)
+		require.Empty(t, status)
+		require.Equal(t, money.New(29995, money.RUB), product.Variants[0].Price)
+	})
+
+	t.Run("convertPrices no rate", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.querierMock.EXPECT().GetExchangeRates(gomock.Any()).Return(rates, nil)
+
+		product := &ds.Product{Variants: []ds.ProductVariant{{Price: money.New(29995, money.RUB)}}}
+
+		status, err := convertPrices(context.Background(), tc.querierMock, "JPY", product)
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNoExchangeRate, status)
+	})
+}
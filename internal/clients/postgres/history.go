@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+
+	"github.com/google/uuid"
+)
+
+// defaultHistoryLimit is the number of versions returned if the limit isn't set.
+const defaultHistoryLimit = 1000
+
+// historyVariant is a product variant row as the product version keeps it.
+type historyVariant struct {
+	Uid            uuid.UUID       `json:"uid"`
+	ProductID      uuid.UUID       `json:"product_id"`
+	Sku            string          `json:"sku"`
+	Options        json.RawMessage `json:"options"`
+	Price          int64           `json:"price"`
+	Currency       string          `json:"currency"`
+	AvailableStock int64           `json:"available_stock"`
+	ImageID        uuid.NullUUID   `json:"image_id"`
+	Barcode        string          `json:"barcode"`
+}
+
+// historyImage is a product image row as the product version keeps it.
+type historyImage struct {
+	ProductID uuid.UUID `json:"product_id"`
+	ImageID   uuid.UUID `json:"image_id"`
+	Position  int32     `json:"position"`
+	AltText   string    `json:"alt_text"`
+	IsPrimary bool      `json:"is_primary"`
+}
+
+func (c *Client) getProductAsOf(ctx context.Context, req *ds.GetProductRequest) (*ds.GetProductResponse, error) {
+	res, err := c.db.Querier().GetProductAsOf(ctx, sqlc.GetProductAsOfParams{
+		Uid:            req.Uid,
+		AsOf:           *req.AsOf,
+		IncludeDeleted: req.IncludeDeleted,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &ds.GetProductResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	product, err := fromDBProductVersion(&sqlc.Product{
+		Uid:            res.Uid,
+		Name:           res.Name,
+		LastUpdateDate: res.LastUpdateDate,
+		SupplierID:     res.SupplierID,
+		CategoryID:     res.CategoryID,
+		Options:        res.Options,
+		Attributes:     res.Attributes,
+		Sku:            res.Sku,
+		Barcode:        res.Barcode,
+		DeletedAt:      res.DeletedAt,
+	}, res.Variants, res.Images)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = applyEffectivePrices(ctx, c.db.Querier(), req.AsOf, product); err != nil {
+		return nil, err
+	}
+
+	status, err := convertPrices(ctx, c.db.Querier(), req.Currency, product)
+	if err != nil {
+		return nil, err
+	}
+	if status != "" {
+		return &ds.GetProductResponse{
+			Status: ds.Status{Message: status},
+		}, nil
+	}
+
+	return &ds.GetProductResponse{
+		Product: product,
+	}, nil
+}
+
+func (c *Client) GetProductHistory(req *ds.GetHistoryRequest) (*ds.GetProductHistoryResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	versions, err := c.db.Querier().GetProductVersions(ctx, sqlc.GetProductVersionsParams{
+		Uid:        req.Uid,
+		PageOffset: int32(req.Offset),
+		PageLimit:  historyLimit(req.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ds.GetProductHistoryResponse{
+		Versions: make([]ds.ProductVersion, len(versions)),
+	}
+	if len(versions) == 0 && req.Offset == 0 {
+		resp.Status = ds.Status{Message: ds.StatusNotFound}
+	}
+	for i := range versions {
+		v := &versions[i]
+		product, err := fromDBProductVersion(&sqlc.Product{
+			Uid:            v.Uid,
+			Name:           v.Name,
+			LastUpdateDate: v.LastUpdateDate,
+			SupplierID:     v.SupplierID,
+			CategoryID:     v.CategoryID,
+			Options:        v.Options,
+			Attributes:     v.Attributes,
+			Sku:            v.Sku,
+			Barcode:        v.Barcode,
+			DeletedAt:      v.DeletedAt,
+		}, v.Variants, v.Images)
+		if err != nil {
+			return nil, err
+		}
+		resp.Versions[i] = ds.ProductVersion{
+			Version: fromDBVersion(v.Version, v.ValidFrom, v.ValidTo),
+			Product: *product,
+		}
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetSupplierHistory(req *ds.GetHistoryRequest) (*ds.GetSupplierHistoryResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	versions, err := c.db.Querier().GetSupplierVersions(ctx, sqlc.GetSupplierVersionsParams{
+		Uid:        req.Uid,
+		PageOffset: int32(req.Offset),
+		PageLimit:  historyLimit(req.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ds.GetSupplierHistoryResponse{
+		Versions: make([]ds.SupplierVersion, len(versions)),
+	}
+	if len(versions) == 0 && req.Offset == 0 {
+		resp.Status = ds.Status{Message: ds.StatusNotFound}
+	}
+	for i := range versions {
+		v := &versions[i]
+		resp.Versions[i] = ds.SupplierVersion{
+			Version: fromDBVersion(v.Version, v.ValidFrom, v.ValidTo),
+			Supplier: *fromDBSupplier(&sqlc.SupplierDetail{
+				Uid:         v.Uid,
+				Name:        v.Name,
+				PhoneNumber: v.PhoneNumber,
+				Country:     v.Country,
+				City:        v.City,
+				Street:      v.Street,
+				DeletedAt:   v.DeletedAt,
+			}),
+		}
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetClientHistory(req *ds.GetHistoryRequest) (*ds.GetClientHistoryResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	versions, err := c.db.Querier().GetClientVersions(ctx, sqlc.GetClientVersionsParams{
+		Uid:        req.Uid,
+		PageOffset: int32(req.Offset),
+		PageLimit:  historyLimit(req.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ds.GetClientHistoryResponse{
+		Versions: make([]ds.ClientVersion, len(versions)),
+	}
+	if len(versions) == 0 && req.Offset == 0 {
+		resp.Status = ds.Status{Message: ds.StatusNotFound}
+	}
+	for i := range versions {
+		v := &versions[i]
+		resp.Versions[i] = ds.ClientVersion{
+			Version: fromDBVersion(v.Version, v.ValidFrom, v.ValidTo),
+			Client: fromDBClient(&sqlc.ClientDetail{
+				ClientName:       v.ClientName,
+				ClientSurname:    v.ClientSurname,
+				Birthday:         v.Birthday,
+				Gender:           v.Gender,
+				Uid:              v.Uid,
+				RegistrationDate: v.RegistrationDate,
+				Country:          v.Country,
+				City:             v.City,
+				Street:           v.Street,
+				DeletedAt:        v.DeletedAt,
+			}),
+		}
+	}
+
+	return resp, nil
+}
+
+func historyLimit(limit int64) int32 {
+	if limit == 0 {
+		return defaultHistoryLimit
+	}
+	return int32(limit)
+}
+
+// historyCurrency returns the currency of a variant version, the ones recorded before the currencies are in RUB.
+func historyCurrency(currency string) string {
+	if currency == "" {
+		return money.RUB
+	}
+	return currency
+}
+
+func fromDBVersion(version int32, validFrom time.Time, validTo sql.NullTime) ds.Version {
+	return ds.Version{
+		Version:   version,
+		ValidFrom: validFrom,
+		ValidTo:   fromNullTime(validTo),
+	}
+}
+
+// fromDBProductVersion decodes the variants and images the product version keeps as JSON.
+func fromDBProductVersion(p *sqlc.Product, variantsJSON, imagesJSON json.RawMessage) (*ds.Product, error) {
+	var hv []historyVariant
+	if err := json.Unmarshal(variantsJSON, &hv); err != nil {
+		return nil, err
+	}
+
+	var hi []historyImage
+	if err := json.Unmarshal(imagesJSON, &hi); err != nil {
+		return nil, err
+	}
+
+	variants := make([]sqlc.ProductVariant, len(hv))
+	for i, v := range hv {
+		variants[i] = sqlc.ProductVariant{
+			Uid:            v.Uid,
+			ProductID:      v.ProductID,
+			Sku:            v.Sku,
+			Options:        v.Options,
+			Price:          v.Price,
+			Currency:       historyCurrency(v.Currency),
+			AvailableStock: v.AvailableStock,
+			ImageID:        v.ImageID,
+			Barcode:        toNullString(v.Barcode),
+		}
+	}
+
+	images := make([]sqlc.ProductImage, len(hi))
+	for i, img := range hi {
+		images[i] = sqlc.ProductImage(img)
+	}
+
+	return fromDBProduct(p, variants, images)
+}
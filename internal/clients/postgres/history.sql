@@ -0,0 +1,56 @@
+-- name: GetAllClientsAsOf :many
+SELECT h.client_name, h.client_surname, h.birthday, h.gender, h.uid, h.registration_date,
+    h.country, h.city, h.street, h.deleted_at
+FROM clients_history h
+WHERE h.valid_from <= sqlc.arg(as_of) AND (h.valid_to IS NULL OR h.valid_to > sqlc.arg(as_of))
+    AND (sqlc.arg(include_deleted)::bool OR h.deleted_at IS NULL)
+ORDER BY h.uid;
+
+-- name: GetClientsPageAsOf :many
+SELECT h.client_name, h.client_surname, h.birthday, h.gender, h.uid, h.registration_date,
+    h.country, h.city, h.street, h.deleted_at
+FROM clients_history h
+WHERE h.valid_from <= sqlc.arg(as_of) AND (h.valid_to IS NULL OR h.valid_to > sqlc.arg(as_of))
+    AND (sqlc.arg(include_deleted)::bool OR h.deleted_at IS NULL)
+ORDER BY h.uid
+OFFSET sqlc.arg('offset')
+LIMIT sqlc.arg('limit');
+
+-- name: GetSupplierAsOf :one
+SELECT h.uid, h.name, h.phone_number, h.country, h.city, h.street, h.deleted_at
+FROM suppliers_history h
+WHERE h.uid = sqlc.arg(uid)
+    AND h.valid_from <= sqlc.arg(as_of) AND (h.valid_to IS NULL OR h.valid_to > sqlc.arg(as_of))
+    AND (sqlc.arg(include_deleted)::bool OR h.deleted_at IS NULL);
+
+-- name: GetProductAsOf :one
+SELECT h.uid, h.name, h.last_update_date, h.supplier_id, h.category_id, h.options, h.attributes,
+    h.sku, h.barcode, h.deleted_at, h.variants, h.images
+FROM products_history h
+WHERE h.uid = sqlc.arg(uid)
+    AND h.valid_from <= sqlc.arg(as_of) AND (h.valid_to IS NULL OR h.valid_to > sqlc.arg(as_of))
+    AND (sqlc.arg(include_deleted)::bool OR h.deleted_at IS NULL);
+
+-- name: GetClientVersions :many
+SELECT *
+FROM clients_history
+WHERE uid = sqlc.arg(uid)
+ORDER BY version DESC
+OFFSET sqlc.arg(page_offset)::int
+LIMIT sqlc.arg(page_limit)::int;
+
+-- name: GetSupplierVersions :many
+SELECT *
+FROM suppliers_history
+WHERE uid = sqlc.arg(uid)
+ORDER BY version DESC
+OFFSET sqlc.arg(page_offset)::int
+LIMIT sqlc.arg(page_limit)::int;
+
+-- name: GetProductVersions :many
+SELECT *
+FROM products_history
+WHERE uid = sqlc.arg(uid)
+ORDER BY version DESC
+OFFSET sqlc.arg(page_offset)::int
+LIMIT sqlc.arg(page_limit)::int;
@@ -0,0 +1,329 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAsOf(t *testing.T) {
+	t.Parallel()
+
+	asOf := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("GetProduct as of", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		imageUid := uuid.New()
+		variantUid := uuid.New()
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock).Times(3)
+		tc.querierMock.EXPECT().GetProductAsOf(gomock.Any(), sqlc.GetProductAsOfParams{
+			Uid:            uid,
+			AsOf:           asOf,
+			IncludeDeleted: true,
+		}).Return(sqlc.GetProductAsOfRow{
+			Uid:        uid,
+			Name:       "beam",
+			Sku:        "BEAM",
+			Options:    json.RawMessage(`["length"]`),
+			Attributes: json.RawMessage(`{}`),
+			Variants: json.RawMessage(`[{"uid":"` + variantUid.String() + `","product_id":"` + uid.String() +
+				`","sku":"BEAM-2M","options":{"length":"2m"},"price":12050,"currency":"RUB","available_stock":7,"image_id":null,"barcode":null}]`),
+			Images: json.RawMessage(`[{"product_id":"` + uid.String() + `","image_id":"` + imageUid.String() +
+				`","position":0,"alt_text":"front","is_primary":true}]`),
+		}, nil)
+		tc.querierMock.EXPECT().GetEffectivePrices(gomock.Any(), sqlc.GetEffectivePricesParams{
+			At:         sql.NullTime{Time: asOf, Valid: true},
+			VariantIds: []uuid.UUID{variantUid},
+		}).Return([]sqlc.GetEffectivePricesRow{{VariantID: variantUid, Price: 11000, Currency: money.RUB,
+			RegularPrice: 11000, RegularCurrency: money.RUB}}, nil)
+
+		resp, err := tc.client.GetProduct(&ds.GetProductRequest{
+			DeletedFlag: ds.DeletedFlag{IncludeDeleted: true},
+			AsOfFlag:    ds.AsOfFlag{AsOf: &asOf},
+			Uid:         uid,
+		})
+		require.Nil(t, err)
+		require.Equal(t, "beam", resp.Product.Name)
+		require.Equal(t, []string{"length"}, resp.Product.Options)
+		require.Equal(t, imageUid, resp.Product.ImageUid)
+		require.Len(t, resp.Product.Images, 1)
+		require.Equal(t, "front", resp.Product.Images[0].AltText)
+		require.Len(t, resp.Product.Variants, 1)
+		require.Equal(t, variantUid, resp.Product.Variants[0].Uid)
+		require.Equal(t, "BEAM-2M", resp.Product.Variants[0].Sku)
+		require.Equal(t, int64(7), resp.Product.Variants[0].AvaliableStocks)
+		require.Equal(t, money.New(11000, money.RUB), resp.Product.Variants[0].Price)
+		require.Nil(t, resp.Product.Variants[0].RegularPrice)
+	})
+
+	t.Run("GetProduct as of not found", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetProductAsOf(gomock.Any(), gomock.Any()).Return(sqlc.GetProductAsOfRow{}, sql.ErrNoRows)
+
+		resp, err := tc.client.GetProduct(&ds.GetProductRequest{AsOfFlag: ds.AsOfFlag{AsOf: &asOf}, Uid: uuid.New()})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("GetProduct as of error on GetProductAsOf", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetProductAsOf(gomock.Any(), gomock.Any()).Return(sqlc.GetProductAsOfRow{}, errTest)
+
+		resp, err := tc.client.GetProduct(&ds.GetProductRequest{AsOfFlag: ds.AsOfFlag{AsOf: &asOf}, Uid: uuid.New()})
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+
+	t.Run("GetSupplier as of", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetSupplierAsOf(gomock.Any(), sqlc.GetSupplierAsOfParams{
+			Uid:  uid,
+			AsOf: asOf,
+		}).Return(sqlc.SupplierDetail{Uid: uid, Name: "name", City: "Seattle"}, nil)
+
+		resp, err := tc.client.GetSupplier(&ds.GetSupplierRequest{AsOfFlag: ds.AsOfFlag{AsOf: &asOf}, Uid: uid})
+		require.Nil(t, err)
+		require.Equal(t, "name", resp.Supplier.Name)
+		require.Equal(t, "Seattle", resp.Supplier.Address.City)
+	})
+
+	t.Run("GetSupplier as of not found", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetSupplierAsOf(gomock.Any(), gomock.Any()).Return(sqlc.SupplierDetail{}, sql.ErrNoRows)
+
+		resp, err := tc.client.GetSupplier(&ds.GetSupplierRequest{AsOfFlag: ds.AsOfFlag{AsOf: &asOf}, Uid: uuid.New()})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("GetClients as of", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetAllClientsAsOf(gomock.Any(), sqlc.GetAllClientsAsOfParams{
+			AsOf: asOf,
+		}).Return([]sqlc.ClientDetail{{ClientName: "Ivan", Street: "Lenina"}}, nil)
+
+		resp, err := tc.client.GetClients(&ds.GetClientsRequest{AsOfFlag: ds.AsOfFlag{AsOf: &asOf}})
+		require.Nil(t, err)
+		require.Len(t, resp.Clients, 1)
+		require.Equal(t, "Ivan", resp.Clients[0].Name)
+		require.Equal(t, "Lenina", resp.Clients[0].Address.Street)
+	})
+
+	t.Run("GetClients as of page", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetClientsPageAsOf(gomock.Any(), sqlc.GetClientsPageAsOfParams{
+			AsOf:           asOf,
+			IncludeDeleted: true,
+			Offset:         10,
+			Limit:          5,
+		}).Return(nil, nil)
+
+		resp, err := tc.client.GetClients(&ds.GetClientsRequest{
+			DeletedFlag: ds.DeletedFlag{IncludeDeleted: true},
+			AsOfFlag:    ds.AsOfFlag{AsOf: &asOf},
+			Offset:      10,
+			Limit:       5,
+		})
+		require.Nil(t, err)
+		require.Empty(t, resp.Clients)
+	})
+
+	t.Run("GetClients as of error on GetClientsPageAsOf", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetClientsPageAsOf(gomock.Any(), gomock.Any()).Return(nil, errTest)
+
+		resp, err := tc.client.GetClients(&ds.GetClientsRequest{AsOfFlag: ds.AsOfFlag{AsOf: &asOf}, Limit: 5})
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	t.Parallel()
+
+	validFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	validTo := validFrom.Add(time.Hour)
+
+	t.Run("GetProductHistory Ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetProductVersions(gomock.Any(), sqlc.GetProductVersionsParams{
+			Uid:        uid,
+			PageOffset: 1,
+			PageLimit:  2,
+		}).Return([]sqlc.ProductsHistory{
+			{
+				Uid:        uid,
+				Version:    3,
+				ValidFrom:  validTo,
+				Name:       "new",
+				Options:    json.RawMessage(`[]`),
+				Attributes: json.RawMessage(`{}`),
+				Variants:   json.RawMessage(`[]`),
+				Images:     json.RawMessage(`[]`),
+			},
+			{
+				Uid:        uid,
+				Version:    2,
+				ValidFrom:  validFrom,
+				ValidTo:    sql.NullTime{Time: validTo, Valid: true},
+				Name:       "old",
+				Options:    json.RawMessage(`[]`),
+				Attributes: json.RawMessage(`{}`),
+				Variants:   json.RawMessage(`[]`),
+				Images:     json.RawMessage(`[]`),
+			},
+		}, nil)
+
+		resp, err := tc.client.GetProductHistory(&ds.GetHistoryRequest{Uid: uid, Offset: 1, Limit: 2})
+		require.Nil(t, err)
+		require.Empty(t, resp.GetStatus())
+		require.Len(t, resp.Versions, 2)
+		require.Equal(t, int32(3), resp.Versions[0].Version.Version)
+		require.Nil(t, resp.Versions[0].ValidTo)
+		require.Equal(t, "new", resp.Versions[0].Product.Name)
+		require.Equal(t, validFrom, resp.Versions[1].ValidFrom)
+		require.Equal(t, &validTo, resp.Versions[1].ValidTo)
+		require.Equal(t, "old", resp.Versions[1].Product.Name)
+	})
+
+	t.Run("GetProductHistory broken variants", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetProductVersions(gomock.Any(), gomock.Any()).Return([]sqlc.ProductsHistory{
+			{Variants: json.RawMessage(`{`), Images: json.RawMessage(`[]`)},
+		}, nil)
+
+		resp, err := tc.client.GetProductHistory(&ds.GetHistoryRequest{Uid: uuid.New()})
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+
+	t.Run("GetProductHistory not found", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetProductVersions(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		resp, err := tc.client.GetProductHistory(&ds.GetHistoryRequest{Uid: uuid.New()})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("GetSupplierHistory default limit", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetSupplierVersions(gomock.Any(), sqlc.GetSupplierVersionsParams{
+			Uid:       uid,
+			PageLimit: defaultHistoryLimit,
+		}).Return([]sqlc.SuppliersHistory{{Uid: uid, Version: 1, ValidFrom: validFrom, Name: "name", City: "Seattle"}}, nil)
+
+		resp, err := tc.client.GetSupplierHistory(&ds.GetHistoryRequest{Uid: uid})
+		require.Nil(t, err)
+		require.Len(t, resp.Versions, 1)
+		require.Equal(t, "name", resp.Versions[0].Supplier.Name)
+		require.Equal(t, "Seattle", resp.Versions[0].Supplier.Address.City)
+	})
+
+	t.Run("GetSupplierHistory error on GetSupplierVersions", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetSupplierVersions(gomock.Any(), gomock.Any()).Return(nil, errTest)
+
+		resp, err := tc.client.GetSupplierHistory(&ds.GetHistoryRequest{Uid: uuid.New()})
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+
+	t.Run("GetClientHistory Ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetClientVersions(gomock.Any(), gomock.Any()).Return([]sqlc.ClientsHistory{
+			{Uid: uid, Version: 1, ValidFrom: validFrom, ClientName: "Ivan", Street: "Lenina"},
+		}, nil)
+
+		resp, err := tc.client.GetClientHistory(&ds.GetHistoryRequest{Uid: uid})
+		require.Nil(t, err)
+		require.Len(t, resp.Versions, 1)
+		require.Equal(t, uid, resp.Versions[0].Client.Uid)
+		require.Equal(t, "Ivan", resp.Versions[0].Client.Name)
+		require.Equal(t, "Lenina", resp.Versions[0].Client.Address.Street)
+	})
+
+	t.Run("GetClientHistory past the last page", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetClientVersions(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		resp, err := tc.client.GetClientHistory(&ds.GetHistoryRequest{Uid: uuid.New(), Offset: 10})
+		require.Nil(t, err)
+		require.Empty(t, resp.GetStatus())
+		require.Empty(t, resp.Versions)
+	})
+}
@@ -1,24 +1,72 @@
 package postgres
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"errors"
+	"hash"
+	"io"
 	"shopapi/internal/clients/postgres/sqlc"
 	ds "shopapi/internal/datastruct"
+	"shopapi/internal/imagemeta"
+	mimeManager "shopapi/internal/mime-manager"
 	"shopapi/internal/supports"
+
+	"github.com/google/uuid"
 )
 
+// blobTxOpt is used to count the references to blobs. The counters are changed by statements locking the blob row,
+// so concurrent uploads of the same content wait for each other instead of failing on serialization.
+var blobTxOpt = &sql.TxOptions{Isolation: sql.LevelReadCommitted}
+
+// AddImage streams the image into the blob storage, the metadata gathered on the way is inserted after.
+// The same content is stored once: if it is stored already, the image refers to that blob and the uploaded one is dropped.
 func (c *Client) AddImage(req *ds.AddImageRequest) (*ds.AddImageResponse, error) {
 	ctx, cancel := c.db.CtxWithCancel()
 	defer cancel()
 
 	uid := supports.GetUUIDIfEmpty(req.Uid)
 
-	uid, err := c.db.Querier().AddImage(ctx, sqlc.AddImageParams{
-		Uid:   uid,
-		Image: req.Image,
+	key, meta, err := c.putImage(ctx, req.Image)
+	if err != nil {
+		if status, ok := uploadStatus(err); ok {
+			return &ds.AddImageResponse{
+				Status: ds.Status{Message: status},
+			}, nil
+		}
+		return nil, err
+	}
+
+	var storedKey string
+	err = c.db.ExecTx(blobTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		k, err := acquireBlob(ctx, qtx, key, meta)
+		if err != nil {
+			return err
+		}
+		storedKey = k
+
+		uid, err = qtx.AddImage(ctx, sqlc.AddImageParams{
+			Uid:        uid,
+			Size:       meta.size,
+			Mime:       meta.mime,
+			Hash:       meta.hash(),
+			StorageKey: toNullString(storedKey),
+			Width:      int32(meta.image.Width),
+			Height:     int32(meta.image.Height),
+			Format:     meta.image.Format,
+		})
+		if err != nil {
+			return err
+		}
+
+		return audit(ctx, qtx, req.Audit, "AddImage", ds.AuditImage, uid.String(), nil)
 	})
 	if err != nil {
+		err = errors.Join(err, c.blobs.Delete(ctx, key))
 		if !errors.Is(err, sql.ErrNoRows) {
 			return nil, err
 		}
@@ -27,6 +75,10 @@ func (c *Client) AddImage(req *ds.AddImageRequest) (*ds.AddImageResponse, error)
 		}, nil
 	}
 
+	if storedKey != key {
+		c.dropBlob(ctx, toNullString(key))
+	}
+
 	return &ds.AddImageResponse{
 		Uid: &uid,
 	}, nil
@@ -36,11 +88,53 @@ func (c *Client) UpdateImage(req *ds.UpdateImageRequest) (*ds.UpdateImageRespons
 	ctx, cancel := c.db.CtxWithCancel()
 	defer cancel()
 
-	_, err := c.db.Querier().UpdateImage(ctx, sqlc.UpdateImageParams{
-		Uid:   req.Uid,
-		Image: req.Image,
+	key, meta, err := c.putImage(ctx, req.Image)
+	if err != nil {
+		if status, ok := uploadStatus(err); ok {
+			return &ds.UpdateImageResponse{
+				Status: ds.Status{Message: status},
+			}, nil
+		}
+		return nil, err
+	}
+
+	var storedKey string
+	var unusedKey sql.NullString
+	err = c.db.ExecTx(blobTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditImage, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		k, err := acquireBlob(ctx, qtx, key, meta)
+		if err != nil {
+			return err
+		}
+		storedKey = k
+
+		oldKey, err := qtx.UpdateImage(ctx, sqlc.UpdateImageParams{
+			Uid:        req.Uid,
+			Size:       meta.size,
+			Mime:       meta.mime,
+			Hash:       meta.hash(),
+			StorageKey: toNullString(storedKey),
+			Width:      int32(meta.image.Width),
+			Height:     int32(meta.image.Height),
+			Format:     meta.image.Format,
+		})
+		if err != nil {
+			return err
+		}
+
+		unusedKey, err = releaseBlob(ctx, qtx, oldKey)
+		if err != nil {
+			return err
+		}
+
+		return audit(ctx, qtx, req.Audit, "UpdateImage", ds.AuditImage, req.Uid.String(), before)
 	})
 	if err != nil {
+		err = errors.Join(err, c.blobs.Delete(ctx, key))
 		if !errors.Is(err, sql.ErrNoRows) {
 			return nil, err
 		}
@@ -49,16 +143,68 @@ func (c *Client) UpdateImage(req *ds.UpdateImageRequest) (*ds.UpdateImageRespons
 		}, nil
 	}
 
+	if storedKey != key {
+		c.dropBlob(ctx, toNullString(key))
+	}
+	c.dropBlob(ctx, unusedKey)
+
 	return &ds.UpdateImageResponse{
 		Status: ds.Status{Message: ds.StatusOK},
 	}, nil
 }
 
-func (c *Client) DeleteImage(req *ds.DeleteImageRequest) (*ds.DeleteImageResponse, error) {
-	ctx, cancel := c.db.CtxWithCancel()
-	defer cancel()
+// DeleteImage moves the image to the trash if no product refers to it, with the cascade policy the referring
+// products are moved along, otherwise they block the delete. The content is kept until the purge.
+func (c *Client) DeleteImage(req *ds.DeleteImageRequest) (resp *ds.DeleteImageResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		// Attaching keeps the image under the same lock, so no new product refers to it until the commit.
+		if _, err := qtx.LockImage(ctx, req.Uid); err != nil {
+			return err
+		}
+
+		products, err := qtx.GetImageProducts(ctx, req.Uid)
+		if err != nil {
+			return err
+		}
+
+		if len(products) > 0 {
+			if req.Policy != ds.DeleteCascade {
+				resp = &ds.DeleteImageResponse{
+					Status:   ds.Status{Message: ds.StatusConflict},
+					Products: products,
+				}
+				return nil
+			}
+
+			productsBefore, err := snapshots(ctx, qtx, ds.AuditProduct, products)
+			if err != nil {
+				return err
+			}
+			for _, uid := range products {
+				if _, err = qtx.DeleteProduct(ctx, uid); err != nil {
+					return err
+				}
+			}
+			if err = auditEach(ctx, qtx, req.Audit, "DeleteImage", ds.AuditProduct, products, productsBefore); err != nil {
+				return err
+			}
+		}
+
+		before, err := snapshot(ctx, qtx, ds.AuditImage, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		if _, err = qtx.DeleteImage(ctx, req.Uid); err != nil {
+			return err
+		}
 
-	_, err := c.db.Querier().DeleteImage(ctx, req.Uid)
+		resp = &ds.DeleteImageResponse{
+			Status:   ds.Status{Message: ds.StatusOK},
+			Products: products,
+		}
+		return audit(ctx, qtx, req.Audit, "DeleteImage", ds.AuditImage, req.Uid.String(), before)
+	})
 	if err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
 			return nil, err
@@ -68,17 +214,43 @@ func (c *Client) DeleteImage(req *ds.DeleteImageRequest) (*ds.DeleteImageRespons
 		}, nil
 	}
 
-	return &ds.DeleteImageResponse{
-		Status: ds.Status{Message: ds.StatusOK},
-	}, nil
+	return
+}
+
+// RestoreImage takes the image out of the trash, restoring an image not deleted succeeds as well.
+func (c *Client) RestoreImage(req *ds.RestoreImageRequest) (resp *ds.RestoreImageResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditImage, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		if _, err = qtx.RestoreImage(ctx, req.Uid); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			resp = &ds.RestoreImageResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}
+			return nil
+		}
+
+		resp = &ds.RestoreImageResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "RestoreImage", ds.AuditImage, req.Uid.String(), before)
+	})
+
+	return
 }
 
 func (c *Client) GetProductImage(req *ds.GetProductImageRequest) (*ds.GetProductImageResponse, error) {
+	// Canceled on error or when the streamed content is closed.
 	ctx, cancel := c.db.CtxWithCancel()
-	defer cancel()
 
 	img, err := c.db.Querier().GetProductImage(ctx, req.ProductUid)
 	if err != nil {
+		cancel()
 		if !errors.Is(err, sql.ErrNoRows) {
 			return nil, err
 		}
@@ -87,18 +259,32 @@ func (c *Client) GetProductImage(req *ds.GetProductImageRequest) (*ds.GetProduct
 		}, nil
 	}
 
+	content, err := c.imageContent(ctx, cancel, &img)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ds.GetProductImageResponse{
-		Image: img.Image,
-		Uid:   &img.Uid,
+		Uid: &img.Uid,
+		ImageContent: ds.ImageContent{
+			Content:    content,
+			Size:       img.Size,
+			Hash:       img.Hash,
+			ModifiedAt: img.UpdatedAt,
+		},
 	}, nil
 }
 
 func (c *Client) GetImage(req *ds.GetImageRequest) (*ds.GetImageResponse, error) {
+	// Canceled on error or when the streamed content is closed.
 	ctx, cancel := c.db.CtxWithCancel()
-	defer cancel()
 
-	img, err := c.db.Querier().GetImage(ctx, req.Uid)
+	img, err := c.db.Querier().GetImage(ctx, sqlc.GetImageParams{
+		Uid:            req.Uid,
+		IncludeDeleted: req.IncludeDeleted,
+	})
 	if err != nil {
+		cancel()
 		if !errors.Is(err, sql.ErrNoRows) {
 			return nil, err
 		}
@@ -107,8 +293,262 @@ func (c *Client) GetImage(req *ds.GetImageRequest) (*ds.GetImageResponse, error)
 		}, nil
 	}
 
+	content, err := c.imageContent(ctx, cancel, &img)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ds.GetImageResponse{
-		Uid:   &req.Uid,
-		Image: img,
+		Uid: &req.Uid,
+		ImageContent: ds.ImageContent{
+			Content:    content,
+			Size:       img.Size,
+			Hash:       img.Hash,
+			ModifiedAt: img.UpdatedAt,
+		},
+	}, nil
+}
+
+func (c *Client) GetImageMeta(req *ds.GetImageMetaRequest) (*ds.GetImageMetaResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	meta, err := c.db.Querier().GetImageMeta(ctx, sqlc.GetImageMetaParams{
+		Uid:            req.Uid,
+		IncludeDeleted: req.IncludeDeleted,
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return &ds.GetImageMetaResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}, nil
+	}
+
+	return &ds.GetImageMetaResponse{
+		Meta: &ds.ImageMeta{
+			Uid:        meta.Uid,
+			Format:     meta.Format,
+			Mime:       meta.Mime,
+			Width:      meta.Width,
+			Height:     meta.Height,
+			Size:       meta.Size,
+			Hash:       meta.Hash,
+			ModifiedAt: meta.UpdatedAt,
+			DeletedAt:  fromNullTime(meta.DeletedAt),
+		},
 	}, nil
 }
+
+// MoveImagesToBlobStorage moves the content of images still kept in postgres to the blob storage
+// by batches of given size. Returns the number of moved images.
+func (c *Client) MoveImagesToBlobStorage(batch int32) (int, error) {
+	moved := 0
+	for {
+		n, done, err := c.moveImagesBatch(batch)
+		moved += n
+		if err != nil || done {
+			return moved, err
+		}
+	}
+}
+
+// moveImagesBatch reports done when there are no more images to move.
+func (c *Client) moveImagesBatch(batch int32) (int, bool, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	images, err := c.db.Querier().GetInlineImages(ctx, batch)
+	if err != nil {
+		return 0, false, err
+	}
+
+	moved := 0
+	for i := range images {
+		key, meta, err := c.putImage(ctx, bytes.NewReader(images[i].Image))
+		if errors.Is(err, imagemeta.ErrCorrupt) {
+			// Kept as it is, the image was accepted before the content was validated.
+			key, meta, err = c.putBlob(ctx, bytes.NewReader(images[i].Image))
+		}
+		if err != nil {
+			return moved, false, err
+		}
+
+		var storedKey string
+		err = c.db.ExecTx(blobTxOpt, func(ctx context.Context, qtx IQuerier) error {
+			k, err := acquireBlob(ctx, qtx, key, meta)
+			if err != nil {
+				return err
+			}
+			storedKey = k
+
+			_, err = qtx.SetImageStorageKey(ctx, sqlc.SetImageStorageKeyParams{
+				StorageKey: toNullString(storedKey),
+				Size:       meta.size,
+				Mime:       meta.mime,
+				Hash:       meta.hash(),
+				Width:      int32(meta.image.Width),
+				Height:     int32(meta.image.Height),
+				Format:     meta.image.Format,
+				Uid:        images[i].Uid,
+			})
+			return err
+		})
+		if err != nil {
+			// The image is deleted or already moved by a concurrent run.
+			err = errors.Join(err, c.blobs.Delete(ctx, key))
+			if !errors.Is(err, sql.ErrNoRows) {
+				return moved, false, err
+			}
+			continue
+		}
+
+		if storedKey != key {
+			c.dropBlob(ctx, toNullString(key))
+		}
+
+		moved++
+	}
+
+	return moved, len(images) < int(batch), nil
+}
+
+// blobMeta is gathered while the content is streamed into the blob storage.
+type blobMeta struct {
+	mime   string
+	size   int64
+	digest hash.Hash
+	image  imagemeta.Meta
+}
+
+func (m *blobMeta) Write(p []byte) (int, error) {
+	m.size += int64(len(p))
+	return m.digest.Write(p)
+}
+
+func (m *blobMeta) hash() string {
+	return hex.EncodeToString(m.digest.Sum(nil))
+}
+
+// putImage strips the metadata of the image and decodes it on the way to the blob storage,
+// so a corrupt image is deleted and ErrCorrupt is returned.
+func (c *Client) putImage(ctx context.Context, r io.Reader) (string, *blobMeta, error) {
+	stripped := imagemeta.Strip(r)
+	defer stripped.Close()
+
+	validator := imagemeta.NewValidator()
+	key, meta, err := c.putBlob(ctx, io.TeeReader(stripped, validator))
+
+	image, errDecode := validator.Finish(err)
+	if err != nil {
+		return "", nil, err
+	}
+	if errDecode != nil {
+		return "", nil, errors.Join(errDecode, c.blobs.Delete(ctx, key))
+	}
+
+	meta.image = image
+
+	return key, meta, nil
+}
+
+// uploadStatus maps the errors of putImage and putBlob caused by the uploaded content.
+func uploadStatus(err error) (string, bool) {
+	switch {
+	case errors.Is(err, supports.ErrTooLarge):
+		return ds.StatusTooLarge, true
+	case errors.Is(err, supports.ErrRejected):
+		return ds.StatusRejected, true
+	case errors.Is(err, imagemeta.ErrCorrupt):
+		return ds.StatusImageCorrupt, true
+	}
+	return "", false
+}
+
+// putBlob streams r under a new key, so a failed update never overwrites content still referenced.
+// The mime type is sniffed from the head of the stream.
+func (c *Client) putBlob(ctx context.Context, r io.Reader) (string, *blobMeta, error) {
+	br := bufio.NewReaderSize(r, mimeManager.SniffLen)
+	head, err := br.Peek(mimeManager.SniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", nil, err
+	}
+
+	meta := &blobMeta{
+		mime:   mimeManager.DetectMimeType(head),
+		digest: sha256.New(),
+	}
+
+	key := uuid.NewString()
+	if err = c.blobs.Put(ctx, key, io.TeeReader(br, meta)); err != nil {
+		return "", nil, err
+	}
+
+	return key, meta, nil
+}
+
+// acquireBlob refers to the blob of the same content if it is stored already, otherwise to the uploaded one.
+// Returns the key of the blob to refer to.
+func acquireBlob(ctx context.Context, qtx IQuerier, key string, meta *blobMeta) (string, error) {
+	return qtx.AcquireBlob(ctx, sqlc.AcquireBlobParams{
+		StorageKey: key,
+		Hash:       meta.hash(),
+		Size:       meta.size,
+		Mime:       meta.mime,
+	})
+}
+
+// releaseBlob drops the reference of an image to the blob. The returned key is valid if no image refers
+// to the blob anymore, so its content has to be dropped after the commit.
+func releaseBlob(ctx context.Context, qtx IQuerier, key sql.NullString) (sql.NullString, error) {
+	if !key.Valid {
+		return sql.NullString{}, nil
+	}
+
+	refs, err := qtx.ReleaseBlob(ctx, key.String)
+	if err != nil || refs > 0 {
+		return sql.NullString{}, err
+	}
+
+	if err = qtx.DeleteUnusedBlob(ctx, key.String); err != nil {
+		return sql.NullString{}, err
+	}
+
+	return key, nil
+}
+
+// dropBlob removes content no longer referenced by the metadata. A failure only leaves
+// an unreferenced blob behind, so it doesn't fail the already committed request.
+func (c *Client) dropBlob(ctx context.Context, key sql.NullString) {
+	if key.Valid {
+		_ = c.blobs.Delete(ctx, key.String)
+	}
+}
+
+// imageContent streams the image content from the blob storage, or from postgres if it isn't moved yet.
+// The request context lives until the content is closed.
+func (c *Client) imageContent(ctx context.Context, cancel context.CancelFunc, img *sqlc.Image) (io.ReadCloser, error) {
+	if !img.StorageKey.Valid {
+		cancel()
+		return io.NopCloser(bytes.NewReader(img.Image)), nil
+	}
+
+	content, err := c.blobs.Get(ctx, img.StorageKey.String)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &cancelOnClose{ReadCloser: content, cancel: cancel}, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
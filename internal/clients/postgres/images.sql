@@ -1,24 +1,102 @@
 -- name: AddImage :one
-INSERT INTO images (uid, image)
-VALUES ($1, $2)
+INSERT INTO images (uid, size, mime, hash, storage_key, width, height, format)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 ON CONFLICT (uid) DO NOTHING
 RETURNING uid;
 
 -- name: UpdateImage :one
+WITH old AS (
+    SELECT o.uid, o.storage_key FROM images o WHERE o.uid = sqlc.arg(uid) AND o.deleted_at IS NULL FOR UPDATE
+)
+UPDATE images i
+SET size = sqlc.arg(size), mime = sqlc.arg(mime), hash = sqlc.arg(hash),
+    storage_key = sqlc.arg(storage_key), width = sqlc.arg(width), height = sqlc.arg(height),
+    format = sqlc.arg(format), image = NULL, updated_at = now()
+FROM old
+WHERE i.uid = old.uid
+RETURNING old.storage_key;
+
+-- name: DeleteImage :one
 UPDATE images
-SET image = $1
-WHERE uid = $2
+SET deleted_at = now()
+WHERE uid = $1 AND deleted_at IS NULL
 RETURNING uid;
 
--- name: DeleteImage :one
-DELETE FROM images
+-- name: RestoreImage :one
+UPDATE images
+SET deleted_at = NULL
 WHERE uid = $1
 RETURNING uid;
 
+-- name: PurgeImages :many
+DELETE FROM images i
+WHERE i.uid IN (
+    SELECT o.uid
+    FROM images o
+    WHERE o.deleted_at <= sqlc.arg(deleted_before)::timestamptz
+        AND NOT EXISTS (SELECT 1 FROM product_images pi WHERE pi.image_id = o.uid)
+        AND NOT EXISTS (SELECT 1 FROM product_variants pv WHERE pv.image_id = o.uid)
+    ORDER BY o.deleted_at, o.uid
+    LIMIT sqlc.arg(batch)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING i.uid, i.storage_key;
+
 -- name: GetProductImage :one
-SELECT * FROM images i
-WHERE i.uid = (SELECT image_id FROM products p WHERE p.uid = $1);
+SELECT i.* FROM images i
+JOIN product_images pi ON pi.image_id = i.uid
+WHERE pi.product_id = $1 AND pi.is_primary AND i.deleted_at IS NULL;
 
 -- name: GetImage :one
-SELECT image FROM images
-WHERE uid = $1;
\ No newline at end of file
+SELECT * FROM images
+WHERE uid = sqlc.arg(uid) AND (sqlc.arg(include_deleted)::bool OR deleted_at IS NULL);
+
+-- name: GetImageMeta :one
+SELECT uid, size, mime, hash, width, height, format, updated_at, deleted_at FROM images
+WHERE uid = sqlc.arg(uid) AND (sqlc.arg(include_deleted)::bool OR deleted_at IS NULL);
+
+-- name: GetInlineImages :many
+SELECT uid, image FROM images
+WHERE storage_key IS NULL
+ORDER BY uid
+LIMIT $1;
+
+-- name: AcquireBlob :one
+INSERT INTO blobs (storage_key, hash, size, mime, ref_count)
+VALUES ($1, $2, $3, $4, 1)
+ON CONFLICT (hash) DO UPDATE SET ref_count = blobs.ref_count + 1
+RETURNING storage_key;
+
+-- name: ReleaseBlob :one
+UPDATE blobs
+SET ref_count = ref_count - 1
+WHERE storage_key = $1
+RETURNING ref_count;
+
+-- name: DeleteUnusedBlob :exec
+DELETE FROM blobs
+WHERE storage_key = $1 AND ref_count = 0;
+
+-- name: SetImageStorageKey :one
+UPDATE images
+SET storage_key = $1, size = $2, mime = $3, hash = $4, width = $5, height = $6, format = $7, image = NULL
+WHERE uid = $8 AND storage_key IS NULL
+RETURNING uid;
+
+-- name: LockImage :one
+SELECT i.uid
+FROM images i
+WHERE i.uid = $1 AND i.deleted_at IS NULL
+FOR UPDATE;
+
+-- name: GetImageProducts :many
+SELECT pi.product_id
+FROM product_images pi
+JOIN products p ON p.uid = pi.product_id
+WHERE pi.image_id = $1 AND p.deleted_at IS NULL
+UNION
+SELECT pv.product_id
+FROM product_variants pv
+JOIN products p ON p.uid = pv.product_id
+WHERE pv.image_id = $1 AND p.deleted_at IS NULL
+ORDER BY product_id;
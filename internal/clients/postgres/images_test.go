@@ -1,18 +1,50 @@
 package postgres
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"shopapi/internal/clients/postgres/sqlc"
 	ds "shopapi/internal/datastruct"
 	"shopapi/internal/supports"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
+// consumeBlob reads the streamed content as a storage would.
+func consumeBlob(_ context.Context, _ string, r io.Reader) error {
+	_, err := io.Copy(io.Discard, r)
+	return err
+}
+
+// execTx runs the transaction on the querier mock.
+func execTx(tc *TestClient) func(*sql.TxOptions, func(context.Context, IQuerier) error) error {
+	return func(_ *sql.TxOptions, fn func(context.Context, IQuerier) error) error {
+		return fn(tc.ctx, tc.querierMock)
+	}
+}
+
+// acquireUploaded refers to the uploaded blob, as if its content isn't stored yet.
+func acquireUploaded(_ context.Context, arg sqlc.AcquireBlobParams) (string, error) {
+	return arg.StorageKey, nil
+}
+
+func readContent(t *testing.T, content io.ReadCloser) []byte {
+	data, err := io.ReadAll(content)
+	require.Nil(t, err)
+	require.Nil(t, content.Close())
+	return data
+}
+
 func TestAddImage(t *testing.T) {
 	t.Parallel()
 
@@ -24,18 +56,67 @@ func TestAddImage(t *testing.T) {
 		uid := uuid.New()
 		req := &ds.AddImageRequest{
 			Uid:   uid,
-			Image: supports.TestImage,
+			Image: bytes.NewReader(supports.TestImage),
 		}
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().AddImage(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded)
+		tc.querierMock.EXPECT().AddImage(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, arg sqlc.AddImageParams) (uuid.UUID, error) {
+				sum := sha256.Sum256(supports.TestImage)
+				require.Equal(t, int64(len(supports.TestImage)), arg.Size)
+				require.Equal(t, hex.EncodeToString(sum[:]), arg.Hash)
+				require.Equal(t, "image/jpeg", arg.Mime)
+				require.Equal(t, "jpeg", arg.Format)
+				require.Positive(t, arg.Width)
+				require.Positive(t, arg.Height)
+				return arg.Uid, nil
+			})
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.AddImage(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
 	})
 
+	t.Run("AddImage same content stored", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddImageRequest{
+			Image: bytes.NewReader(supports.TestImage),
+		}
+
+		var uploaded string
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, key string, r io.Reader) error {
+				uploaded = key
+				return consumeBlob(ctx, key, r)
+			})
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).Return("stored", nil)
+		tc.querierMock.EXPECT().AddImage(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, arg sqlc.AddImageParams) (uuid.UUID, error) {
+				require.Equal(t, sql.NullString{String: "stored", Valid: true}, arg.StorageKey)
+				return arg.Uid, nil
+			})
+		tc.blobMock.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, key string) error {
+				require.Equal(t, uploaded, key)
+				return nil
+			})
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.AddImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Uid)
+	})
+
 	t.Run("AddImage error on AddImage", func(t *testing.T) {
 		t.Parallel()
 
@@ -44,12 +125,15 @@ func TestAddImage(t *testing.T) {
 		uid := uuid.New()
 		req := &ds.AddImageRequest{
 			Uid:   uid,
-			Image: supports.TestImage,
+			Image: bytes.NewReader(supports.TestImage),
 		}
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded)
 		tc.querierMock.EXPECT().AddImage(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, errTest)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 
 		resp, err := tc.client.AddImage(req)
 		require.NotNil(t, err)
@@ -64,12 +148,15 @@ func TestAddImage(t *testing.T) {
 		uid := uuid.New()
 		req := &ds.AddImageRequest{
 			Uid:   uid,
-			Image: supports.TestImage,
+			Image: bytes.NewReader(supports.TestImage),
 		}
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded)
 		tc.querierMock.EXPECT().AddImage(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, sql.ErrNoRows)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 
 		resp, err := tc.client.AddImage(req)
 		require.Nil(t, err)
@@ -77,6 +164,64 @@ func TestAddImage(t *testing.T) {
 		require.Equal(t, resp.GetStatus(), ds.StatusAlreadyExists)
 
 	})
+
+	t.Run("AddImage corrupt", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddImageRequest{
+			Image: bytes.NewReader(supports.TestImage[:len(supports.TestImage)/2]),
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := tc.client.AddImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusImageCorrupt)
+	})
+
+	t.Run("AddImage rejected", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddImageRequest{
+			Image: io.MultiReader(
+				bytes.NewReader(supports.TestImage[:1024]),
+				iotest.ErrReader(fmt.Errorf("%w: test", supports.ErrRejected)),
+			),
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+
+		resp, err := tc.client.AddImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusRejected)
+	})
+
+	t.Run("AddImage too large", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddImageRequest{
+			Image: supports.LimitReader(bytes.NewReader(supports.TestImage), 1024),
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+
+		resp, err := tc.client.AddImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusTooLarge)
+	})
 }
 
 func TestUpdateImage(t *testing.T) {
@@ -89,16 +234,47 @@ func TestUpdateImage(t *testing.T) {
 		uid := uuid.New()
 		req := &ds.UpdateImageRequest{
 			Uid:   uid,
-			Image: supports.TestImage,
+			Image: bytes.NewReader(supports.TestImage),
 		}
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().UpdateImage(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded)
+		tc.querierMock.EXPECT().UpdateImage(gomock.Any(), gomock.Any()).Return(sql.NullString{String: "old", Valid: true}, nil)
+		tc.querierMock.EXPECT().ReleaseBlob(gomock.Any(), "old").Return(int32(0), nil)
+		tc.querierMock.EXPECT().DeleteUnusedBlob(gomock.Any(), "old").Return(nil)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), "old").Return(nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.UpdateImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("UpdateImage old content still used", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		req := &ds.UpdateImageRequest{
+			Uid:   uuid.New(),
+			Image: bytes.NewReader(supports.TestImage),
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded)
+		tc.querierMock.EXPECT().UpdateImage(gomock.Any(), gomock.Any()).Return(sql.NullString{String: "old", Valid: true}, nil)
+		tc.querierMock.EXPECT().ReleaseBlob(gomock.Any(), "old").Return(int32(1), nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.UpdateImage(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusOK)
 	})
 
 	t.Run("UpdateImage error on UpdateImage", func(t *testing.T) {
@@ -108,12 +284,16 @@ func TestUpdateImage(t *testing.T) {
 		uid := uuid.New()
 		req := &ds.UpdateImageRequest{
 			Uid:   uid,
-			Image: supports.TestImage,
+			Image: bytes.NewReader(supports.TestImage),
 		}
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().UpdateImage(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, errTest)
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded)
+		tc.querierMock.EXPECT().UpdateImage(gomock.Any(), gomock.Any()).Return(sql.NullString{}, errTest)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+		expectSnapshots(tc, 1)
 
 		resp, err := tc.client.UpdateImage(req)
 		require.NotNil(t, err)
@@ -127,12 +307,16 @@ func TestUpdateImage(t *testing.T) {
 		uid := uuid.New()
 		req := &ds.UpdateImageRequest{
 			Uid:   uid,
-			Image: supports.TestImage,
+			Image: bytes.NewReader(supports.TestImage),
 		}
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().UpdateImage(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, sql.ErrNoRows)
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded)
+		tc.querierMock.EXPECT().UpdateImage(gomock.Any(), gomock.Any()).Return(sql.NullString{}, sql.ErrNoRows)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+		expectSnapshots(tc, 1)
 
 		resp, err := tc.client.UpdateImage(req)
 		require.Nil(t, err)
@@ -153,13 +337,17 @@ func TestDeleteImage(t *testing.T) {
 			Uid: uid,
 		}
 
-		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().DeleteImage(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockImage(gomock.Any(), req.Uid).Return(req.Uid, nil)
+		tc.querierMock.EXPECT().GetImageProducts(gomock.Any(), req.Uid).Return(nil, nil)
+		tc.querierMock.EXPECT().DeleteImage(gomock.Any(), req.Uid).Return(req.Uid, nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.DeleteImage(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
 	})
 
 	t.Run("DeleteImage error on DeleteImage", func(t *testing.T) {
@@ -171,9 +359,11 @@ func TestDeleteImage(t *testing.T) {
 			Uid: uid,
 		}
 
-		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().DeleteImage(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, errTest)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockImage(gomock.Any(), req.Uid).Return(req.Uid, nil)
+		tc.querierMock.EXPECT().GetImageProducts(gomock.Any(), req.Uid).Return(nil, nil)
+		tc.querierMock.EXPECT().DeleteImage(gomock.Any(), gomock.Any()).Return(uuid.Nil, errTest)
+		expectSnapshots(tc, 1)
 
 		resp, err := tc.client.DeleteImage(req)
 		require.NotNil(t, err)
@@ -189,15 +379,116 @@ func TestDeleteImage(t *testing.T) {
 			Uid: uid,
 		}
 
-		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().DeleteImage(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, sql.ErrNoRows)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockImage(gomock.Any(), uid).Return(uuid.Nil, sql.ErrNoRows)
 
 		resp, err := tc.client.DeleteImage(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
 		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
 	})
+	t.Run("DeleteImage conflict on products", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		req := &ds.DeleteImageRequest{
+			Uid: uuid.New(),
+		}
+		products := []uuid.UUID{uuid.New()}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockImage(gomock.Any(), req.Uid).Return(req.Uid, nil)
+		tc.querierMock.EXPECT().GetImageProducts(gomock.Any(), req.Uid).Return(products, nil)
+
+		resp, err := tc.client.DeleteImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusConflict, resp.GetStatus())
+		require.Equal(t, products, resp.Products)
+	})
+
+	t.Run("DeleteImage cascade ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		req := &ds.DeleteImageRequest{
+			Uid:    uuid.New(),
+			Policy: ds.DeleteCascade,
+		}
+		product := uuid.New()
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockImage(gomock.Any(), req.Uid).Return(req.Uid, nil)
+		tc.querierMock.EXPECT().GetImageProducts(gomock.Any(), req.Uid).Return([]uuid.UUID{product}, nil)
+		tc.querierMock.EXPECT().DeleteProduct(gomock.Any(), product).Return(product, nil)
+		tc.querierMock.EXPECT().DeleteImage(gomock.Any(), req.Uid).Return(req.Uid, nil)
+		expectSnapshots(tc, 2)
+		expectAudit(tc, 2)
+
+		resp, err := tc.client.DeleteImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+		require.Equal(t, []uuid.UUID{product}, resp.Products)
+	})
+}
+
+func TestRestoreImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RestoreImage Ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		req := &ds.RestoreImageRequest{
+			Uid: uuid.New(),
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().RestoreImage(gomock.Any(), req.Uid).Return(req.Uid, nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.RestoreImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("RestoreImage not found", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		req := &ds.RestoreImageRequest{
+			Uid: uuid.New(),
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().RestoreImage(gomock.Any(), req.Uid).Return(uuid.Nil, sql.ErrNoRows)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.RestoreImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("RestoreImage error on RestoreImage", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		req := &ds.RestoreImageRequest{
+			Uid: uuid.New(),
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().RestoreImage(gomock.Any(), req.Uid).Return(uuid.Nil, errTest)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.RestoreImage(req)
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
 }
 
 func TestGetProductImage(t *testing.T) {
@@ -276,11 +567,23 @@ func TestGetImage(t *testing.T) {
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
 		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().GetImage(gomock.Any(), gomock.Any()).Return(supports.TestImage, nil)
+		updatedAt := time.Now()
+		tc.querierMock.EXPECT().GetImage(gomock.Any(), gomock.Any()).Return(sqlc.Image{
+			Uid:        uid,
+			StorageKey: sql.NullString{String: "key", Valid: true},
+			Hash:       "hash",
+			UpdatedAt:  updatedAt,
+		}, nil)
+		tc.blobMock.EXPECT().Get(gomock.Any(), "key").Return(io.NopCloser(bytes.NewReader(supports.TestImage)), nil)
 
 		resp, err := tc.client.GetImage(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
+		require.Equal(t, supports.TestImage, readContent(t, resp.Content))
+
+		hash, modified := resp.Validators()
+		require.Equal(t, "hash", hash)
+		require.Equal(t, updatedAt, modified)
 	})
 
 	t.Run("GetImage error on GetImage", func(t *testing.T) {
@@ -294,7 +597,7 @@ func TestGetImage(t *testing.T) {
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
 		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().GetImage(gomock.Any(), gomock.Any()).Return(nil, errTest)
+		tc.querierMock.EXPECT().GetImage(gomock.Any(), gomock.Any()).Return(sqlc.Image{}, errTest)
 
 		resp, err := tc.client.GetImage(req)
 		require.NotNil(t, err)
@@ -312,11 +615,205 @@ func TestGetImage(t *testing.T) {
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
 		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().GetImage(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+		tc.querierMock.EXPECT().GetImage(gomock.Any(), gomock.Any()).Return(sqlc.Image{}, sql.ErrNoRows)
 
 		resp, err := tc.client.GetImage(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
 		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
 	})
+	t.Run("GetImage error on Get", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		req := &ds.GetImageRequest{
+			Uid: uid,
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetImage(gomock.Any(), gomock.Any()).Return(sqlc.Image{
+			Uid:        uid,
+			StorageKey: sql.NullString{String: "key", Valid: true},
+		}, nil)
+		tc.blobMock.EXPECT().Get(gomock.Any(), "key").Return(nil, errTest)
+
+		resp, err := tc.client.GetImage(req)
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+
+	t.Run("GetImage not moved yet", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		req := &ds.GetImageRequest{
+			Uid: uid,
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetImage(gomock.Any(), gomock.Any()).Return(sqlc.Image{
+			Uid:   uid,
+			Image: supports.TestImage,
+		}, nil)
+
+		resp, err := tc.client.GetImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, supports.TestImage, readContent(t, resp.Content))
+	})
+}
+
+func TestGetImageMeta(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetImageMeta Ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetImageMeta(gomock.Any(), sqlc.GetImageMetaParams{Uid: uid}).Return(sqlc.GetImageMetaRow{
+			Uid:    uid,
+			Format: "png",
+			Width:  640,
+			Height: 480,
+		}, nil)
+
+		resp, err := tc.client.GetImageMeta(&ds.GetImageMetaRequest{Uid: uid})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, &ds.ImageMeta{Uid: uid, Format: "png", Width: 640, Height: 480}, resp.Meta)
+	})
+
+	t.Run("GetImageMeta not found", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetImageMeta(gomock.Any(), gomock.Any()).Return(sqlc.GetImageMetaRow{}, sql.ErrNoRows)
+
+		resp, err := tc.client.GetImageMeta(&ds.GetImageMetaRequest{Uid: uuid.New()})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
+	})
+
+	t.Run("GetImageMeta error on GetImageMeta", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetImageMeta(gomock.Any(), gomock.Any()).Return(sqlc.GetImageMetaRow{}, errTest)
+
+		resp, err := tc.client.GetImageMeta(&ds.GetImageMetaRequest{Uid: uuid.New()})
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestMoveImagesToBlobStorage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("MoveImagesToBlobStorage Ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {}).Times(2)
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock).Times(2)
+		tc.querierMock.EXPECT().GetInlineImages(gomock.Any(), int32(2)).Return([]sqlc.GetInlineImagesRow{
+			{Uid: uuid.New(), Image: supports.TestImage},
+			{Uid: uuid.New(), Image: supports.TestImage},
+		}, nil)
+		tc.querierMock.EXPECT().GetInlineImages(gomock.Any(), int32(2)).Return([]sqlc.GetInlineImagesRow{
+			{Uid: uuid.New(), Image: supports.TestImage},
+		}, nil)
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob).Times(3)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc)).Times(3)
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded).Times(3)
+		tc.querierMock.EXPECT().SetImageStorageKey(gomock.Any(), gomock.Any()).Return(uuid.New(), nil).Times(3)
+
+		moved, err := tc.client.MoveImagesToBlobStorage(2)
+		require.Nil(t, err)
+		require.Equal(t, 3, moved)
+	})
+
+	t.Run("MoveImagesToBlobStorage image deleted meanwhile", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetInlineImages(gomock.Any(), int32(2)).Return([]sqlc.GetInlineImagesRow{
+			{Uid: uuid.New(), Image: supports.TestImage},
+		}, nil)
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded)
+		tc.querierMock.EXPECT().SetImageStorageKey(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, sql.ErrNoRows)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+
+		moved, err := tc.client.MoveImagesToBlobStorage(2)
+		require.Nil(t, err)
+		require.Equal(t, 0, moved)
+	})
+
+	t.Run("MoveImagesToBlobStorage corrupt image kept", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetInlineImages(gomock.Any(), int32(2)).Return([]sqlc.GetInlineImagesRow{
+			{Uid: uuid.New(), Image: []byte("legacy content")},
+		}, nil)
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob).Times(2)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded)
+		tc.querierMock.EXPECT().SetImageStorageKey(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, arg sqlc.SetImageStorageKeyParams) (uuid.UUID, error) {
+				require.Empty(t, arg.Format)
+				require.Equal(t, int64(len("legacy content")), arg.Size)
+				return arg.Uid, nil
+			})
+
+		moved, err := tc.client.MoveImagesToBlobStorage(2)
+		require.Nil(t, err)
+		require.Equal(t, 1, moved)
+	})
+
+	t.Run("MoveImagesToBlobStorage error on Put", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetInlineImages(gomock.Any(), int32(2)).Return([]sqlc.GetInlineImagesRow{
+			{Uid: uuid.New(), Image: supports.TestImage},
+		}, nil)
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(errTest)
+
+		_, err := tc.client.MoveImagesToBlobStorage(2)
+		require.NotNil(t, err)
+	})
+
+	t.Run("MoveImagesToBlobStorage error on GetInlineImages", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetInlineImages(gomock.Any(), int32(2)).Return(nil, errTest)
+
+		_, err := tc.client.MoveImagesToBlobStorage(2)
+		require.NotNil(t, err)
+	})
 }
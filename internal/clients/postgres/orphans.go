@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"time"
+)
+
+const defaultOrphansBatch = 100
+
+// SweepOrphans collects the images no product or variant refers to and the addresses no client or supplier
+// refers to. A sweep marks the new orphans and deletes, by batches, the ones marked longer than the grace period
+// ago, so an image uploaded to be attached later survives until then. The dry run marks only, the orphans
+// past the grace period are listed instead of deleted.
+func (c *Client) SweepOrphans(req *ds.SweepOrphansRequest) (*ds.SweepOrphansResponse, error) {
+	resp := &ds.SweepOrphansResponse{
+		DryRun:    req.DryRun,
+		Images:    []ds.OrphanedImage{},
+		Addresses: []ds.OrphanedAddress{},
+	}
+
+	marked, err := c.markOrphans()
+	if err != nil {
+		return nil, err
+	}
+	resp.Marked = marked
+
+	orphanedBefore := time.Now().Add(-req.GracePeriod)
+	batch := req.Batch
+	if batch <= 0 {
+		batch = defaultOrphansBatch
+	}
+
+	if req.DryRun {
+		if err = c.listOrphans(orphanedBefore, resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	for {
+		n, err := c.deleteOrphansBatch(orphanedBefore, batch, resp)
+		if err != nil {
+			return nil, err
+		}
+		if n < int(batch) {
+			return resp, nil
+		}
+	}
+}
+
+// markOrphans clears the marks of rows referred again and marks the new orphans, returns the number of the new ones.
+func (c *Client) markOrphans() (marked int64, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		if _, err := qtx.UnmarkReferencedImages(ctx); err != nil {
+			return err
+		}
+		if _, err := qtx.UnmarkReferencedAddresses(ctx); err != nil {
+			return err
+		}
+
+		images, err := qtx.MarkOrphanedImages(ctx)
+		if err != nil {
+			return err
+		}
+		addresses, err := qtx.MarkOrphanedAddresses(ctx)
+		if err != nil {
+			return err
+		}
+
+		marked = images + addresses
+		return nil
+	})
+
+	return
+}
+
+func (c *Client) listOrphans(orphanedBefore time.Time, resp *ds.SweepOrphansResponse) error {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	images, err := c.db.Querier().GetOrphanedImages(ctx, orphanedBefore)
+	if err != nil {
+		return err
+	}
+	for _, img := range images {
+		resp.Images = append(resp.Images, ds.OrphanedImage{
+			Uid:        img.Uid,
+			Size:       img.Size,
+			OrphanedAt: img.OrphanedAt.Time,
+		})
+	}
+
+	addresses, err := c.db.Querier().GetOrphanedAddresses(ctx, orphanedBefore)
+	if err != nil {
+		return err
+	}
+	for _, a := range addresses {
+		resp.Addresses = append(resp.Addresses, ds.OrphanedAddress{
+			Id:         a.ID,
+			Country:    a.Country,
+			City:       a.City,
+			Street:     a.Street,
+			OrphanedAt: a.OrphanedAt.Time,
+		})
+	}
+
+	return nil
+}
+
+// deleteOrphansBatch deletes up to batch images and batch addresses, the content of the images is deleted
+// after the commit if no other image refers to it. Returns the largest number of rows deleted of a kind.
+func (c *Client) deleteOrphansBatch(orphanedBefore time.Time, batch int32, resp *ds.SweepOrphansResponse) (int, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	var images []sqlc.DeleteOrphanedImagesRow
+	var addresses []sqlc.DeleteOrphanedAddressesRow
+	var unusedKeys []sql.NullString
+	err := c.db.ExecTx(blobTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		unusedKeys = nil
+
+		var err error
+		images, err = qtx.DeleteOrphanedImages(ctx, sqlc.DeleteOrphanedImagesParams{
+			OrphanedBefore: orphanedBefore,
+			Batch:          batch,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, img := range images {
+			unusedKey, err := releaseBlob(ctx, qtx, img.StorageKey)
+			if err != nil {
+				return err
+			}
+			unusedKeys = append(unusedKeys, unusedKey)
+		}
+
+		addresses, err = qtx.DeleteOrphanedAddresses(ctx, sqlc.DeleteOrphanedAddressesParams{
+			OrphanedBefore: orphanedBefore,
+			Batch:          batch,
+		})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range unusedKeys {
+		c.dropBlob(ctx, key)
+	}
+
+	for _, img := range images {
+		resp.Images = append(resp.Images, ds.OrphanedImage{
+			Uid:        img.Uid,
+			Size:       img.Size,
+			OrphanedAt: img.OrphanedAt.Time,
+		})
+	}
+	for _, a := range addresses {
+		resp.Addresses = append(resp.Addresses, ds.OrphanedAddress{
+			Id:         a.ID,
+			Country:    a.Country,
+			City:       a.City,
+			Street:     a.Street,
+			OrphanedAt: a.OrphanedAt.Time,
+		})
+	}
+
+	return max(len(images), len(addresses)), nil
+}
@@ -0,0 +1,73 @@
+-- name: UnmarkReferencedImages :execrows
+UPDATE images i
+SET orphaned_at = NULL
+WHERE i.orphaned_at IS NOT NULL
+    AND (EXISTS (SELECT 1 FROM product_images pi WHERE pi.image_id = i.uid)
+        OR EXISTS (SELECT 1 FROM product_variants pv WHERE pv.image_id = i.uid));
+
+-- name: MarkOrphanedImages :execrows
+UPDATE images i
+SET orphaned_at = now()
+WHERE i.orphaned_at IS NULL
+    AND i.deleted_at IS NULL
+    AND NOT EXISTS (SELECT 1 FROM product_images pi WHERE pi.image_id = i.uid)
+    AND NOT EXISTS (SELECT 1 FROM product_variants pv WHERE pv.image_id = i.uid);
+
+-- name: GetOrphanedImages :many
+SELECT i.uid, i.size, i.orphaned_at
+FROM images i
+WHERE i.orphaned_at <= sqlc.arg(orphaned_before)::timestamptz AND i.deleted_at IS NULL
+ORDER BY i.orphaned_at, i.uid;
+
+-- name: DeleteOrphanedImages :many
+DELETE FROM images i
+WHERE i.uid IN (
+    SELECT o.uid
+    FROM images o
+    WHERE o.orphaned_at <= sqlc.arg(orphaned_before)::timestamptz AND o.deleted_at IS NULL
+    ORDER BY o.orphaned_at, o.uid
+    LIMIT sqlc.arg(batch)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING i.uid, i.size, i.storage_key, i.orphaned_at;
+
+-- name: KeepImage :one
+WITH kept AS (
+    UPDATE images i
+    SET orphaned_at = NULL
+    WHERE i.uid = $1 AND i.deleted_at IS NULL
+    RETURNING i.uid
+)
+SELECT EXISTS(SELECT 1 FROM kept)::bool AS is_exists;
+
+-- name: UnmarkReferencedAddresses :execrows
+UPDATE addresses a
+SET orphaned_at = NULL
+WHERE a.orphaned_at IS NOT NULL
+    AND (EXISTS (SELECT 1 FROM clients c WHERE c.address_id = a.id)
+        OR EXISTS (SELECT 1 FROM suppliers s WHERE s.address_id = a.id));
+
+-- name: MarkOrphanedAddresses :execrows
+UPDATE addresses a
+SET orphaned_at = now()
+WHERE a.orphaned_at IS NULL
+    AND NOT EXISTS (SELECT 1 FROM clients c WHERE c.address_id = a.id)
+    AND NOT EXISTS (SELECT 1 FROM suppliers s WHERE s.address_id = a.id);
+
+-- name: GetOrphanedAddresses :many
+SELECT a.id, a.country, a.city, a.street, a.orphaned_at
+FROM addresses a
+WHERE a.orphaned_at <= sqlc.arg(orphaned_before)::timestamptz
+ORDER BY a.orphaned_at, a.id;
+
+-- name: DeleteOrphanedAddresses :many
+DELETE FROM addresses a
+WHERE a.id IN (
+    SELECT o.id
+    FROM addresses o
+    WHERE o.orphaned_at <= sqlc.arg(orphaned_before)::timestamptz
+    ORDER BY o.orphaned_at, o.id
+    LIMIT sqlc.arg(batch)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING a.id, a.country, a.city, a.street, a.orphaned_at;
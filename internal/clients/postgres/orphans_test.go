@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepOrphans(t *testing.T) {
+	t.Parallel()
+
+	expectMark := func(tc *TestClient) {
+		tc.clientMock.EXPECT().ExecTx(defaultTxOpt, gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().UnmarkReferencedImages(gomock.Any()).Return(int64(1), nil)
+		tc.querierMock.EXPECT().UnmarkReferencedAddresses(gomock.Any()).Return(int64(0), nil)
+		tc.querierMock.EXPECT().MarkOrphanedImages(gomock.Any()).Return(int64(2), nil)
+		tc.querierMock.EXPECT().MarkOrphanedAddresses(gomock.Any()).Return(int64(1), nil)
+	}
+
+	t.Run("SweepOrphans dry run Ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		orphanedAt := time.Now().Add(-100 * time.Hour)
+		expectMark(tc)
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock).Times(2)
+		tc.querierMock.EXPECT().GetOrphanedImages(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, orphanedBefore time.Time) ([]sqlc.GetOrphanedImagesRow, error) {
+				require.WithinDuration(t, time.Now().Add(-72*time.Hour), orphanedBefore, time.Minute)
+				return []sqlc.GetOrphanedImagesRow{
+					{Uid: uid, Size: 512, OrphanedAt: sql.NullTime{Time: orphanedAt, Valid: true}},
+				}, nil
+			})
+		tc.querierMock.EXPECT().GetOrphanedAddresses(gomock.Any(), gomock.Any()).Return(
+			[]sqlc.GetOrphanedAddressesRow{
+				{ID: 7, Country: "Russia", City: "Moscow", Street: "Arbat", OrphanedAt: sql.NullTime{Time: orphanedAt, Valid: true}},
+			}, nil)
+
+		resp, err := tc.client.SweepOrphans(&ds.SweepOrphansRequest{DryRun: true, GracePeriod: 72 * time.Hour})
+		require.Nil(t, err)
+		require.True(t, resp.DryRun)
+		require.Equal(t, int64(3), resp.Marked)
+		require.Equal(t, []ds.OrphanedImage{{Uid: uid, Size: 512, OrphanedAt: orphanedAt}}, resp.Images)
+		require.Equal(t, []ds.OrphanedAddress{
+			{Id: 7, Country: "Russia", City: "Moscow", Street: "Arbat", OrphanedAt: orphanedAt},
+		}, resp.Addresses)
+	})
+
+	t.Run("SweepOrphans Ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		shared, unused := uuid.New(), uuid.New()
+		expectMark(tc)
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {}).Times(2)
+		tc.clientMock.EXPECT().ExecTx(blobTxOpt, gomock.Any()).DoAndReturn(execTx(tc)).Times(2)
+
+		// The first batch is full, so the sweep goes on until a batch falls short.
+		tc.querierMock.EXPECT().DeleteOrphanedImages(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, arg sqlc.DeleteOrphanedImagesParams) ([]sqlc.DeleteOrphanedImagesRow, error) {
+				require.Equal(t, int32(2), arg.Batch)
+				return []sqlc.DeleteOrphanedImagesRow{
+					{Uid: shared, Size: 10, StorageKey: sql.NullString{String: "shared", Valid: true}},
+					{Uid: unused, Size: 20, StorageKey: sql.NullString{String: "unused", Valid: true}},
+				}, nil
+			})
+		tc.querierMock.EXPECT().DeleteOrphanedImages(gomock.Any(), gomock.Any()).Return(nil, nil)
+		tc.querierMock.EXPECT().DeleteOrphanedAddresses(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+		tc.querierMock.EXPECT().ReleaseBlob(gomock.Any(), "shared").Return(int32(1), nil)
+		tc.querierMock.EXPECT().ReleaseBlob(gomock.Any(), "unused").Return(int32(0), nil)
+		tc.querierMock.EXPECT().DeleteUnusedBlob(gomock.Any(), "unused").Return(nil)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), "unused").Return(nil)
+
+		resp, err := tc.client.SweepOrphans(&ds.SweepOrphansRequest{GracePeriod: time.Hour, Batch: 2})
+		require.Nil(t, err)
+		require.False(t, resp.DryRun)
+		require.Len(t, resp.Images, 2)
+		require.Equal(t, shared, resp.Images[0].Uid)
+		require.Equal(t, unused, resp.Images[1].Uid)
+		require.Empty(t, resp.Addresses)
+	})
+
+	t.Run("SweepOrphans error on MarkOrphanedImages", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(defaultTxOpt, gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().UnmarkReferencedImages(gomock.Any()).Return(int64(0), nil)
+		tc.querierMock.EXPECT().UnmarkReferencedAddresses(gomock.Any()).Return(int64(0), nil)
+		tc.querierMock.EXPECT().MarkOrphanedImages(gomock.Any()).Return(int64(0), errTest)
+
+		resp, err := tc.client.SweepOrphans(&ds.SweepOrphansRequest{})
+		require.True(t, errors.Is(err, errTest))
+		require.Nil(t, resp)
+	})
+
+	t.Run("SweepOrphans error on ReleaseBlob", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		expectMark(tc)
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().ExecTx(blobTxOpt, gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().DeleteOrphanedImages(gomock.Any(), gomock.Any()).Return(
+			[]sqlc.DeleteOrphanedImagesRow{
+				{Uid: uuid.New(), StorageKey: sql.NullString{String: "key", Valid: true}},
+			}, nil)
+		tc.querierMock.EXPECT().ReleaseBlob(gomock.Any(), "key").Return(int32(0), errTest)
+
+		resp, err := tc.client.SweepOrphans(&ds.SweepOrphansRequest{})
+		require.True(t, errors.Is(err, errTest))
+		require.Nil(t, resp)
+	})
+}
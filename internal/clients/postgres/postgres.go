@@ -5,18 +5,22 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"shopapi/internal/clients/postgres/sqlc"
 	"shopapi/internal/supports"
 
-	_ "github.com/lib/pq"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const (
 	insertOneTime  = 1000
 	requestTimeout = time.Second * 5
-	kopecksInRUB   = 100
+
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
 
 	db_host_secret_path     = "./secrets/db_host.txt"
 	db_port_secret_path     = "./secrets/db_port.txt"
@@ -27,7 +31,10 @@ const (
 
 var defaultTxOpt = &sql.TxOptions{Isolation: sql.LevelRepeatableRead}
 
-//go:generate mockgen -source=postgres.go -destination=postgres_mock.go -package=postgres IDB,IQuerier
+// errRollback aborts ExecTx without reporting a failure, the response is expected to be already set.
+var errRollback = errors.New("rollback")
+
+//go:generate mockgen -source=postgres.go -destination=postgres_mock.go -package=postgres IDB,IQuerier,IBlobStorage
 
 type IQuerier interface {
 	sqlc.Querier
@@ -39,6 +46,13 @@ type IDB interface {
 	CtxWithCancel() (context.Context, context.CancelFunc)
 }
 
+// IBlobStorage keeps image content, postgres keeps only its metadata.
+type IBlobStorage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
 type DB struct {
 	ctx  context.Context
 	conn *sql.DB
@@ -46,7 +60,8 @@ type DB struct {
 }
 
 type Client struct {
-	db IDB
+	db    IDB
+	blobs IBlobStorage
 }
 
 func NewSQLConn(ctx context.Context) (*sql.DB, error) {
@@ -100,17 +115,18 @@ func NewSQLConn(ctx context.Context) (*sql.DB, error) {
 	return db, nil
 }
 
-func NewClient(ctx context.Context, conn *sql.DB) *Client {
+func NewClient(ctx context.Context, conn *sql.DB, blobs IBlobStorage) *Client {
 	return buildClient(&DB{
 		ctx:  ctx,
 		sqlc: sqlc.New(conn),
 		conn: conn,
-	})
+	}, blobs)
 }
 
-func buildClient(db IDB) *Client {
+func buildClient(db IDB, blobs IBlobStorage) *Client {
 	return &Client{
-		db: db,
+		db:    db,
+		blobs: blobs,
 	}
 }
 
@@ -154,10 +170,44 @@ func (db *DB) Querier() IQuerier {
 	return db.sqlc
 }
 
-func toDBPrice(price float64) int64 {
-	return int64(price * kopecksInRUB)
+func toNullUUID(uid *uuid.UUID) uuid.NullUUID {
+	if uid == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *uid, Valid: true}
+}
+
+func fromNullUUID(uid uuid.NullUUID) *uuid.UUID {
+	if !uid.Valid {
+		return nil
+	}
+	return &uid.UUID
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode
 }
 
-func fromDBPrice(price int64) float64 {
-	return float64(price) / kopecksInRUB
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode
 }
@@ -7,8 +7,11 @@ package postgres
 import (
 	context "context"
 	sql "database/sql"
+	json "encoding/json"
+	io "io"
 	reflect "reflect"
 	sqlc "shopapi/internal/clients/postgres/sqlc"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -37,393 +40,1872 @@ func (m *MockIQuerier) EXPECT() *MockIQuerierMockRecorder {
 	return m.recorder
 }
 
+// AcquireBlob mocks base method.
+func (m *MockIQuerier) AcquireBlob(ctx context.Context, arg sqlc.AcquireBlobParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireBlob", ctx, arg)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireBlob indicates an expected call of AcquireBlob.
+func (mr *MockIQuerierMockRecorder) AcquireBlob(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireBlob", reflect.TypeOf((*MockIQuerier)(nil).AcquireBlob), ctx, arg)
+}
+
 // AddImage mocks base method.
 func (m *MockIQuerier) AddImage(ctx context.Context, arg sqlc.AddImageParams) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddImage", ctx, arg)
+	ret := m.ctrl.Call(m, "AddImage", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddImage indicates an expected call of AddImage.
+func (mr *MockIQuerierMockRecorder) AddImage(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddImage", reflect.TypeOf((*MockIQuerier)(nil).AddImage), ctx, arg)
+}
+
+// AddProductDocument mocks base method.
+func (m *MockIQuerier) AddProductDocument(ctx context.Context, arg sqlc.AddProductDocumentParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProductDocument", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProductDocument indicates an expected call of AddProductDocument.
+func (mr *MockIQuerierMockRecorder) AddProductDocument(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductDocument", reflect.TypeOf((*MockIQuerier)(nil).AddProductDocument), ctx, arg)
+}
+
+// CalculateClientsWithAddress mocks base method.
+func (m *MockIQuerier) CalculateClientsWithAddress(ctx context.Context, addressID int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateClientsWithAddress", ctx, addressID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateClientsWithAddress indicates an expected call of CalculateClientsWithAddress.
+func (mr *MockIQuerierMockRecorder) CalculateClientsWithAddress(ctx, addressID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateClientsWithAddress", reflect.TypeOf((*MockIQuerier)(nil).CalculateClientsWithAddress), ctx, addressID)
+}
+
+// CalculateSuppliersWithAddress mocks base method.
+func (m *MockIQuerier) CalculateSuppliersWithAddress(ctx context.Context, addressID int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateSuppliersWithAddress", ctx, addressID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateSuppliersWithAddress indicates an expected call of CalculateSuppliersWithAddress.
+func (mr *MockIQuerierMockRecorder) CalculateSuppliersWithAddress(ctx, addressID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateSuppliersWithAddress", reflect.TypeOf((*MockIQuerier)(nil).CalculateSuppliersWithAddress), ctx, addressID)
+}
+
+// ClearProductPrimaryImage mocks base method.
+func (m *MockIQuerier) ClearProductPrimaryImage(ctx context.Context, productID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearProductPrimaryImage", ctx, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearProductPrimaryImage indicates an expected call of ClearProductPrimaryImage.
+func (mr *MockIQuerierMockRecorder) ClearProductPrimaryImage(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearProductPrimaryImage", reflect.TypeOf((*MockIQuerier)(nil).ClearProductPrimaryImage), ctx, productID)
+}
+
+// DecreaseVariantStock mocks base method.
+func (m *MockIQuerier) DecreaseVariantStock(ctx context.Context, arg sqlc.DecreaseVariantStockParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecreaseVariantStock", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecreaseVariantStock indicates an expected call of DecreaseVariantStock.
+func (mr *MockIQuerierMockRecorder) DecreaseVariantStock(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseVariantStock", reflect.TypeOf((*MockIQuerier)(nil).DecreaseVariantStock), ctx, arg)
+}
+
+// DeleteAddress mocks base method.
+func (m *MockIQuerier) DeleteAddress(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddress", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddress indicates an expected call of DeleteAddress.
+func (mr *MockIQuerierMockRecorder) DeleteAddress(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockIQuerier)(nil).DeleteAddress), ctx, id)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockIQuerier) DeleteCategory(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockIQuerierMockRecorder) DeleteCategory(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockIQuerier)(nil).DeleteCategory), ctx, uid)
+}
+
+// DeleteClient mocks base method.
+func (m *MockIQuerier) DeleteClient(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockIQuerierMockRecorder) DeleteClient(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockIQuerier)(nil).DeleteClient), ctx, uid)
+}
+
+// DeleteImage mocks base method.
+func (m *MockIQuerier) DeleteImage(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockIQuerierMockRecorder) DeleteImage(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockIQuerier)(nil).DeleteImage), ctx, uid)
+}
+
+// DeleteOrphanedAddresses mocks base method.
+func (m *MockIQuerier) DeleteOrphanedAddresses(ctx context.Context, arg sqlc.DeleteOrphanedAddressesParams) ([]sqlc.DeleteOrphanedAddressesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphanedAddresses", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.DeleteOrphanedAddressesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOrphanedAddresses indicates an expected call of DeleteOrphanedAddresses.
+func (mr *MockIQuerierMockRecorder) DeleteOrphanedAddresses(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedAddresses", reflect.TypeOf((*MockIQuerier)(nil).DeleteOrphanedAddresses), ctx, arg)
+}
+
+// DeleteOrphanedImages mocks base method.
+func (m *MockIQuerier) DeleteOrphanedImages(ctx context.Context, arg sqlc.DeleteOrphanedImagesParams) ([]sqlc.DeleteOrphanedImagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphanedImages", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.DeleteOrphanedImagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOrphanedImages indicates an expected call of DeleteOrphanedImages.
+func (mr *MockIQuerierMockRecorder) DeleteOrphanedImages(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedImages", reflect.TypeOf((*MockIQuerier)(nil).DeleteOrphanedImages), ctx, arg)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockIQuerier) DeleteProduct(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockIQuerierMockRecorder) DeleteProduct(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockIQuerier)(nil).DeleteProduct), ctx, uid)
+}
+
+// DeleteProductDocument mocks base method.
+func (m *MockIQuerier) DeleteProductDocument(ctx context.Context, uid uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductDocument", ctx, uid)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProductDocument indicates an expected call of DeleteProductDocument.
+func (mr *MockIQuerierMockRecorder) DeleteProductDocument(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductDocument", reflect.TypeOf((*MockIQuerier)(nil).DeleteProductDocument), ctx, uid)
+}
+
+// DeleteProductDocuments mocks base method.
+func (m *MockIQuerier) DeleteProductDocuments(ctx context.Context, productID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductDocuments", ctx, productID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProductDocuments indicates an expected call of DeleteProductDocuments.
+func (mr *MockIQuerierMockRecorder) DeleteProductDocuments(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductDocuments", reflect.TypeOf((*MockIQuerier)(nil).DeleteProductDocuments), ctx, productID)
+}
+
+// DeleteProductImage mocks base method.
+func (m *MockIQuerier) DeleteProductImage(ctx context.Context, arg sqlc.DeleteProductImageParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductImage", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProductImage indicates an expected call of DeleteProductImage.
+func (mr *MockIQuerierMockRecorder) DeleteProductImage(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductImage", reflect.TypeOf((*MockIQuerier)(nil).DeleteProductImage), ctx, arg)
+}
+
+// DeleteProductVariant mocks base method.
+func (m *MockIQuerier) DeleteProductVariant(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductVariant", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProductVariant indicates an expected call of DeleteProductVariant.
+func (mr *MockIQuerierMockRecorder) DeleteProductVariant(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductVariant", reflect.TypeOf((*MockIQuerier)(nil).DeleteProductVariant), ctx, uid)
+}
+
+// DeleteRole mocks base method.
+func (m *MockIQuerier) DeleteRole(ctx context.Context, name string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, name)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockIQuerierMockRecorder) DeleteRole(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockIQuerier)(nil).DeleteRole), ctx, name)
+}
+
+// DeleteSupplier mocks base method.
+func (m *MockIQuerier) DeleteSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSupplier", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSupplier indicates an expected call of DeleteSupplier.
+func (mr *MockIQuerierMockRecorder) DeleteSupplier(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupplier", reflect.TypeOf((*MockIQuerier)(nil).DeleteSupplier), ctx, uid)
+}
+
+// DeleteUnusedBlob mocks base method.
+func (m *MockIQuerier) DeleteUnusedBlob(ctx context.Context, storageKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnusedBlob", ctx, storageKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUnusedBlob indicates an expected call of DeleteUnusedBlob.
+func (mr *MockIQuerierMockRecorder) DeleteUnusedBlob(ctx, storageKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnusedBlob", reflect.TypeOf((*MockIQuerier)(nil).DeleteUnusedBlob), ctx, storageKey)
+}
+
+// GetActiveApiKey mocks base method.
+func (m *MockIQuerier) GetActiveApiKey(ctx context.Context, keyHash string) (sqlc.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveApiKey", ctx, keyHash)
+	ret0, _ := ret[0].(sqlc.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveApiKey indicates an expected call of GetActiveApiKey.
+func (mr *MockIQuerierMockRecorder) GetActiveApiKey(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveApiKey", reflect.TypeOf((*MockIQuerier)(nil).GetActiveApiKey), ctx, keyHash)
+}
+
+// GetAllCategories mocks base method.
+func (m *MockIQuerier) GetAllCategories(ctx context.Context) ([]sqlc.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCategories", ctx)
+	ret0, _ := ret[0].([]sqlc.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCategories indicates an expected call of GetAllCategories.
+func (mr *MockIQuerierMockRecorder) GetAllCategories(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockIQuerier)(nil).GetAllCategories), ctx)
+}
+
+// GetAllClients mocks base method.
+func (m *MockIQuerier) GetAllClients(ctx context.Context, includeDeleted bool) ([]sqlc.ClientDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllClients", ctx, includeDeleted)
+	ret0, _ := ret[0].([]sqlc.ClientDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllClients indicates an expected call of GetAllClients.
+func (mr *MockIQuerierMockRecorder) GetAllClients(ctx, includeDeleted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllClients", reflect.TypeOf((*MockIQuerier)(nil).GetAllClients), ctx, includeDeleted)
+}
+
+// GetAllClientsAsOf mocks base method.
+func (m *MockIQuerier) GetAllClientsAsOf(ctx context.Context, arg sqlc.GetAllClientsAsOfParams) ([]sqlc.ClientDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllClientsAsOf", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ClientDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllClientsAsOf indicates an expected call of GetAllClientsAsOf.
+func (mr *MockIQuerierMockRecorder) GetAllClientsAsOf(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllClientsAsOf", reflect.TypeOf((*MockIQuerier)(nil).GetAllClientsAsOf), ctx, arg)
+}
+
+// GetAllProducts mocks base method.
+func (m *MockIQuerier) GetAllProducts(ctx context.Context, arg sqlc.GetAllProductsParams) ([]sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllProducts", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllProducts indicates an expected call of GetAllProducts.
+func (mr *MockIQuerierMockRecorder) GetAllProducts(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockIQuerier)(nil).GetAllProducts), ctx, arg)
+}
+
+// GetAllSuppliers mocks base method.
+func (m *MockIQuerier) GetAllSuppliers(ctx context.Context, includeDeleted bool) ([]sqlc.SupplierDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSuppliers", ctx, includeDeleted)
+	ret0, _ := ret[0].([]sqlc.SupplierDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSuppliers indicates an expected call of GetAllSuppliers.
+func (mr *MockIQuerierMockRecorder) GetAllSuppliers(ctx, includeDeleted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSuppliers", reflect.TypeOf((*MockIQuerier)(nil).GetAllSuppliers), ctx, includeDeleted)
+}
+
+// GetApiKeys mocks base method.
+func (m *MockIQuerier) GetApiKeys(ctx context.Context, includeRevoked bool) ([]sqlc.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeys", ctx, includeRevoked)
+	ret0, _ := ret[0].([]sqlc.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeys indicates an expected call of GetApiKeys.
+func (mr *MockIQuerierMockRecorder) GetApiKeys(ctx, includeRevoked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeys", reflect.TypeOf((*MockIQuerier)(nil).GetApiKeys), ctx, includeRevoked)
+}
+
+// GetApplicablePromotions mocks base method.
+func (m *MockIQuerier) GetApplicablePromotions(ctx context.Context, arg sqlc.GetApplicablePromotionsParams) ([]sqlc.GetApplicablePromotionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicablePromotions", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.GetApplicablePromotionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicablePromotions indicates an expected call of GetApplicablePromotions.
+func (mr *MockIQuerierMockRecorder) GetApplicablePromotions(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicablePromotions", reflect.TypeOf((*MockIQuerier)(nil).GetApplicablePromotions), ctx, arg)
+}
+
+// GetAttributeTypes mocks base method.
+func (m *MockIQuerier) GetAttributeTypes(ctx context.Context, arg sqlc.GetAttributeTypesParams) ([]sqlc.GetAttributeTypesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttributeTypes", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.GetAttributeTypesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttributeTypes indicates an expected call of GetAttributeTypes.
+func (mr *MockIQuerierMockRecorder) GetAttributeTypes(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributeTypes", reflect.TypeOf((*MockIQuerier)(nil).GetAttributeTypes), ctx, arg)
+}
+
+// GetAuditEntries mocks base method.
+func (m *MockIQuerier) GetAuditEntries(ctx context.Context, arg sqlc.GetAuditEntriesParams) ([]sqlc.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEntries", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEntries indicates an expected call of GetAuditEntries.
+func (mr *MockIQuerierMockRecorder) GetAuditEntries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEntries", reflect.TypeOf((*MockIQuerier)(nil).GetAuditEntries), ctx, arg)
+}
+
+// GetAuditSnapshot mocks base method.
+func (m *MockIQuerier) GetAuditSnapshot(ctx context.Context, arg sqlc.GetAuditSnapshotParams) (json.RawMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditSnapshot", ctx, arg)
+	ret0, _ := ret[0].(json.RawMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditSnapshot indicates an expected call of GetAuditSnapshot.
+func (mr *MockIQuerierMockRecorder) GetAuditSnapshot(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditSnapshot", reflect.TypeOf((*MockIQuerier)(nil).GetAuditSnapshot), ctx, arg)
+}
+
+// GetCategory mocks base method.
+func (m *MockIQuerier) GetCategory(ctx context.Context, uid uuid.UUID) (sqlc.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", ctx, uid)
+	ret0, _ := ret[0].(sqlc.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockIQuerierMockRecorder) GetCategory(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockIQuerier)(nil).GetCategory), ctx, uid)
+}
+
+// GetCategoryAttributeSchema mocks base method.
+func (m *MockIQuerier) GetCategoryAttributeSchema(ctx context.Context, uid uuid.UUID) (json.RawMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryAttributeSchema", ctx, uid)
+	ret0, _ := ret[0].(json.RawMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryAttributeSchema indicates an expected call of GetCategoryAttributeSchema.
+func (mr *MockIQuerierMockRecorder) GetCategoryAttributeSchema(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryAttributeSchema", reflect.TypeOf((*MockIQuerier)(nil).GetCategoryAttributeSchema), ctx, uid)
+}
+
+// GetClientVersions mocks base method.
+func (m *MockIQuerier) GetClientVersions(ctx context.Context, arg sqlc.GetClientVersionsParams) ([]sqlc.ClientsHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientVersions", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ClientsHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientVersions indicates an expected call of GetClientVersions.
+func (mr *MockIQuerierMockRecorder) GetClientVersions(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientVersions", reflect.TypeOf((*MockIQuerier)(nil).GetClientVersions), ctx, arg)
+}
+
+// GetClientsPage mocks base method.
+func (m *MockIQuerier) GetClientsPage(ctx context.Context, arg sqlc.GetClientsPageParams) ([]sqlc.ClientDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientsPage", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ClientDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientsPage indicates an expected call of GetClientsPage.
+func (mr *MockIQuerierMockRecorder) GetClientsPage(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientsPage", reflect.TypeOf((*MockIQuerier)(nil).GetClientsPage), ctx, arg)
+}
+
+// GetClientsPageAsOf mocks base method.
+func (m *MockIQuerier) GetClientsPageAsOf(ctx context.Context, arg sqlc.GetClientsPageAsOfParams) ([]sqlc.ClientDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientsPageAsOf", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ClientDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientsPageAsOf indicates an expected call of GetClientsPageAsOf.
+func (mr *MockIQuerierMockRecorder) GetClientsPageAsOf(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientsPageAsOf", reflect.TypeOf((*MockIQuerier)(nil).GetClientsPageAsOf), ctx, arg)
+}
+
+// GetDanglingReferences mocks base method.
+func (m *MockIQuerier) GetDanglingReferences(ctx context.Context) ([]sqlc.GetDanglingReferencesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDanglingReferences", ctx)
+	ret0, _ := ret[0].([]sqlc.GetDanglingReferencesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDanglingReferences indicates an expected call of GetDanglingReferences.
+func (mr *MockIQuerierMockRecorder) GetDanglingReferences(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDanglingReferences", reflect.TypeOf((*MockIQuerier)(nil).GetDanglingReferences), ctx)
+}
+
+// GetEffectivePrices mocks base method.
+func (m *MockIQuerier) GetEffectivePrices(ctx context.Context, arg sqlc.GetEffectivePricesParams) ([]sqlc.GetEffectivePricesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectivePrices", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.GetEffectivePricesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectivePrices indicates an expected call of GetEffectivePrices.
+func (mr *MockIQuerierMockRecorder) GetEffectivePrices(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectivePrices", reflect.TypeOf((*MockIQuerier)(nil).GetEffectivePrices), ctx, arg)
+}
+
+// GetExchangeRates mocks base method.
+func (m *MockIQuerier) GetExchangeRates(ctx context.Context) ([]sqlc.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates", ctx)
+	ret0, _ := ret[0].([]sqlc.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockIQuerierMockRecorder) GetExchangeRates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockIQuerier)(nil).GetExchangeRates), ctx)
+}
+
+// GetImage mocks base method.
+func (m *MockIQuerier) GetImage(ctx context.Context, arg sqlc.GetImageParams) (sqlc.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImage", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImage indicates an expected call of GetImage.
+func (mr *MockIQuerierMockRecorder) GetImage(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImage", reflect.TypeOf((*MockIQuerier)(nil).GetImage), ctx, arg)
+}
+
+// GetImageMeta mocks base method.
+func (m *MockIQuerier) GetImageMeta(ctx context.Context, arg sqlc.GetImageMetaParams) (sqlc.GetImageMetaRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageMeta", ctx, arg)
+	ret0, _ := ret[0].(sqlc.GetImageMetaRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImageMeta indicates an expected call of GetImageMeta.
+func (mr *MockIQuerierMockRecorder) GetImageMeta(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageMeta", reflect.TypeOf((*MockIQuerier)(nil).GetImageMeta), ctx, arg)
+}
+
+// GetImageProducts mocks base method.
+func (m *MockIQuerier) GetImageProducts(ctx context.Context, imageID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageProducts", ctx, imageID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImageProducts indicates an expected call of GetImageProducts.
+func (mr *MockIQuerierMockRecorder) GetImageProducts(ctx, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageProducts", reflect.TypeOf((*MockIQuerier)(nil).GetImageProducts), ctx, imageID)
+}
+
+// GetImagesOfProducts mocks base method.
+func (m *MockIQuerier) GetImagesOfProducts(ctx context.Context, productIds []uuid.UUID) ([]sqlc.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImagesOfProducts", ctx, productIds)
+	ret0, _ := ret[0].([]sqlc.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImagesOfProducts indicates an expected call of GetImagesOfProducts.
+func (mr *MockIQuerierMockRecorder) GetImagesOfProducts(ctx, productIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImagesOfProducts", reflect.TypeOf((*MockIQuerier)(nil).GetImagesOfProducts), ctx, productIds)
+}
+
+// GetInlineImages mocks base method.
+func (m *MockIQuerier) GetInlineImages(ctx context.Context, limit int32) ([]sqlc.GetInlineImagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInlineImages", ctx, limit)
+	ret0, _ := ret[0].([]sqlc.GetInlineImagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInlineImages indicates an expected call of GetInlineImages.
+func (mr *MockIQuerierMockRecorder) GetInlineImages(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInlineImages", reflect.TypeOf((*MockIQuerier)(nil).GetInlineImages), ctx, limit)
+}
+
+// GetNextProductImagePosition mocks base method.
+func (m *MockIQuerier) GetNextProductImagePosition(ctx context.Context, productID uuid.UUID) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextProductImagePosition", ctx, productID)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextProductImagePosition indicates an expected call of GetNextProductImagePosition.
+func (mr *MockIQuerierMockRecorder) GetNextProductImagePosition(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextProductImagePosition", reflect.TypeOf((*MockIQuerier)(nil).GetNextProductImagePosition), ctx, productID)
+}
+
+// GetOrderClient mocks base method.
+func (m *MockIQuerier) GetOrderClient(ctx context.Context, orderID string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderClient", ctx, orderID)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderClient indicates an expected call of GetOrderClient.
+func (mr *MockIQuerierMockRecorder) GetOrderClient(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderClient", reflect.TypeOf((*MockIQuerier)(nil).GetOrderClient), ctx, orderID)
+}
+
+// GetOrphanedAddresses mocks base method.
+func (m *MockIQuerier) GetOrphanedAddresses(ctx context.Context, orphanedBefore time.Time) ([]sqlc.GetOrphanedAddressesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrphanedAddresses", ctx, orphanedBefore)
+	ret0, _ := ret[0].([]sqlc.GetOrphanedAddressesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrphanedAddresses indicates an expected call of GetOrphanedAddresses.
+func (mr *MockIQuerierMockRecorder) GetOrphanedAddresses(ctx, orphanedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrphanedAddresses", reflect.TypeOf((*MockIQuerier)(nil).GetOrphanedAddresses), ctx, orphanedBefore)
+}
+
+// GetOrphanedImages mocks base method.
+func (m *MockIQuerier) GetOrphanedImages(ctx context.Context, orphanedBefore time.Time) ([]sqlc.GetOrphanedImagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrphanedImages", ctx, orphanedBefore)
+	ret0, _ := ret[0].([]sqlc.GetOrphanedImagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrphanedImages indicates an expected call of GetOrphanedImages.
+func (mr *MockIQuerierMockRecorder) GetOrphanedImages(ctx, orphanedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrphanedImages", reflect.TypeOf((*MockIQuerier)(nil).GetOrphanedImages), ctx, orphanedBefore)
+}
+
+// GetProduct mocks base method.
+func (m *MockIQuerier) GetProduct(ctx context.Context, arg sqlc.GetProductParams) (sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockIQuerierMockRecorder) GetProduct(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockIQuerier)(nil).GetProduct), ctx, arg)
+}
+
+// GetProductAsOf mocks base method.
+func (m *MockIQuerier) GetProductAsOf(ctx context.Context, arg sqlc.GetProductAsOfParams) (sqlc.GetProductAsOfRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductAsOf", ctx, arg)
+	ret0, _ := ret[0].(sqlc.GetProductAsOfRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductAsOf indicates an expected call of GetProductAsOf.
+func (mr *MockIQuerierMockRecorder) GetProductAsOf(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductAsOf", reflect.TypeOf((*MockIQuerier)(nil).GetProductAsOf), ctx, arg)
+}
+
+// GetProductAttributeSchema mocks base method.
+func (m *MockIQuerier) GetProductAttributeSchema(ctx context.Context, uid uuid.UUID) (json.RawMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductAttributeSchema", ctx, uid)
+	ret0, _ := ret[0].(json.RawMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductAttributeSchema indicates an expected call of GetProductAttributeSchema.
+func (mr *MockIQuerierMockRecorder) GetProductAttributeSchema(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductAttributeSchema", reflect.TypeOf((*MockIQuerier)(nil).GetProductAttributeSchema), ctx, uid)
+}
+
+// GetProductByBarcode mocks base method.
+func (m *MockIQuerier) GetProductByBarcode(ctx context.Context, barcode sql.NullString) (sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByBarcode", ctx, barcode)
+	ret0, _ := ret[0].(sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductByBarcode indicates an expected call of GetProductByBarcode.
+func (mr *MockIQuerierMockRecorder) GetProductByBarcode(ctx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByBarcode", reflect.TypeOf((*MockIQuerier)(nil).GetProductByBarcode), ctx, barcode)
+}
+
+// GetProductBySku mocks base method.
+func (m *MockIQuerier) GetProductBySku(ctx context.Context, sku string) (sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductBySku", ctx, sku)
+	ret0, _ := ret[0].(sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductBySku indicates an expected call of GetProductBySku.
+func (mr *MockIQuerierMockRecorder) GetProductBySku(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductBySku", reflect.TypeOf((*MockIQuerier)(nil).GetProductBySku), ctx, sku)
+}
+
+// GetProductDocument mocks base method.
+func (m *MockIQuerier) GetProductDocument(ctx context.Context, uid uuid.UUID) (sqlc.ProductDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductDocument", ctx, uid)
+	ret0, _ := ret[0].(sqlc.ProductDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductDocument indicates an expected call of GetProductDocument.
+func (mr *MockIQuerierMockRecorder) GetProductDocument(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductDocument", reflect.TypeOf((*MockIQuerier)(nil).GetProductDocument), ctx, uid)
+}
+
+// GetProductDocuments mocks base method.
+func (m *MockIQuerier) GetProductDocuments(ctx context.Context, productID uuid.UUID) ([]sqlc.ProductDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductDocuments", ctx, productID)
+	ret0, _ := ret[0].([]sqlc.ProductDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductDocuments indicates an expected call of GetProductDocuments.
+func (mr *MockIQuerierMockRecorder) GetProductDocuments(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductDocuments", reflect.TypeOf((*MockIQuerier)(nil).GetProductDocuments), ctx, productID)
+}
+
+// GetProductImage mocks base method.
+func (m *MockIQuerier) GetProductImage(ctx context.Context, productID uuid.UUID) (sqlc.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductImage", ctx, productID)
+	ret0, _ := ret[0].(sqlc.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductImage indicates an expected call of GetProductImage.
+func (mr *MockIQuerierMockRecorder) GetProductImage(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImage", reflect.TypeOf((*MockIQuerier)(nil).GetProductImage), ctx, productID)
+}
+
+// GetProductImages mocks base method.
+func (m *MockIQuerier) GetProductImages(ctx context.Context, productID uuid.UUID) ([]sqlc.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductImages", ctx, productID)
+	ret0, _ := ret[0].([]sqlc.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductImages indicates an expected call of GetProductImages.
+func (mr *MockIQuerierMockRecorder) GetProductImages(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImages", reflect.TypeOf((*MockIQuerier)(nil).GetProductImages), ctx, productID)
+}
+
+// GetProductOptions mocks base method.
+func (m *MockIQuerier) GetProductOptions(ctx context.Context, uid uuid.UUID) (json.RawMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductOptions", ctx, uid)
+	ret0, _ := ret[0].(json.RawMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductOptions indicates an expected call of GetProductOptions.
+func (mr *MockIQuerierMockRecorder) GetProductOptions(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductOptions", reflect.TypeOf((*MockIQuerier)(nil).GetProductOptions), ctx, uid)
+}
+
+// GetProductVariants mocks base method.
+func (m *MockIQuerier) GetProductVariants(ctx context.Context, productID uuid.UUID) ([]sqlc.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductVariants", ctx, productID)
+	ret0, _ := ret[0].([]sqlc.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductVariants indicates an expected call of GetProductVariants.
+func (mr *MockIQuerierMockRecorder) GetProductVariants(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductVariants", reflect.TypeOf((*MockIQuerier)(nil).GetProductVariants), ctx, productID)
+}
+
+// GetProductVersions mocks base method.
+func (m *MockIQuerier) GetProductVersions(ctx context.Context, arg sqlc.GetProductVersionsParams) ([]sqlc.ProductsHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductVersions", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.ProductsHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductVersions indicates an expected call of GetProductVersions.
+func (mr *MockIQuerierMockRecorder) GetProductVersions(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductVersions", reflect.TypeOf((*MockIQuerier)(nil).GetProductVersions), ctx, arg)
+}
+
+// GetProductsPage mocks base method.
+func (m *MockIQuerier) GetProductsPage(ctx context.Context, arg sqlc.GetProductsPageParams) ([]sqlc.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsPage", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductsPage indicates an expected call of GetProductsPage.
+func (mr *MockIQuerierMockRecorder) GetProductsPage(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsPage", reflect.TypeOf((*MockIQuerier)(nil).GetProductsPage), ctx, arg)
+}
+
+// GetPromotions mocks base method.
+func (m *MockIQuerier) GetPromotions(ctx context.Context, includeExpired bool) ([]sqlc.GetPromotionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotions", ctx, includeExpired)
+	ret0, _ := ret[0].([]sqlc.GetPromotionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotions indicates an expected call of GetPromotions.
+func (mr *MockIQuerierMockRecorder) GetPromotions(ctx, includeExpired interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotions", reflect.TypeOf((*MockIQuerier)(nil).GetPromotions), ctx, includeExpired)
+}
+
+// GetPurgeableProducts mocks base method.
+func (m *MockIQuerier) GetPurgeableProducts(ctx context.Context, arg sqlc.GetPurgeableProductsParams) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurgeableProducts", ctx, arg)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurgeableProducts indicates an expected call of GetPurgeableProducts.
+func (mr *MockIQuerierMockRecorder) GetPurgeableProducts(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurgeableProducts", reflect.TypeOf((*MockIQuerier)(nil).GetPurgeableProducts), ctx, arg)
+}
+
+// GetRoleApiKeys mocks base method.
+func (m *MockIQuerier) GetRoleApiKeys(ctx context.Context, name string) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleApiKeys", ctx, name)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleApiKeys indicates an expected call of GetRoleApiKeys.
+func (mr *MockIQuerierMockRecorder) GetRoleApiKeys(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleApiKeys", reflect.TypeOf((*MockIQuerier)(nil).GetRoleApiKeys), ctx, name)
+}
+
+// GetRoles mocks base method.
+func (m *MockIQuerier) GetRoles(ctx context.Context) ([]sqlc.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoles", ctx)
+	ret0, _ := ret[0].([]sqlc.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoles indicates an expected call of GetRoles.
+func (mr *MockIQuerierMockRecorder) GetRoles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockIQuerier)(nil).GetRoles), ctx)
+}
+
+// GetRolesPermissions mocks base method.
+func (m *MockIQuerier) GetRolesPermissions(ctx context.Context, names []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolesPermissions", ctx, names)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolesPermissions indicates an expected call of GetRolesPermissions.
+func (mr *MockIQuerierMockRecorder) GetRolesPermissions(ctx, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolesPermissions", reflect.TypeOf((*MockIQuerier)(nil).GetRolesPermissions), ctx, names)
+}
+
+// GetSupplier mocks base method.
+func (m *MockIQuerier) GetSupplier(ctx context.Context, arg sqlc.GetSupplierParams) (sqlc.SupplierDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplier", ctx, arg)
+	ret0, _ := ret[0].(sqlc.SupplierDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplier indicates an expected call of GetSupplier.
+func (mr *MockIQuerierMockRecorder) GetSupplier(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockIQuerier)(nil).GetSupplier), ctx, arg)
+}
+
+// GetSupplierAsOf mocks base method.
+func (m *MockIQuerier) GetSupplierAsOf(ctx context.Context, arg sqlc.GetSupplierAsOfParams) (sqlc.SupplierDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplierAsOf", ctx, arg)
+	ret0, _ := ret[0].(sqlc.SupplierDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplierAsOf indicates an expected call of GetSupplierAsOf.
+func (mr *MockIQuerierMockRecorder) GetSupplierAsOf(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplierAsOf", reflect.TypeOf((*MockIQuerier)(nil).GetSupplierAsOf), ctx, arg)
+}
+
+// GetSupplierProducts mocks base method.
+func (m *MockIQuerier) GetSupplierProducts(ctx context.Context, supplierID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplierProducts", ctx, supplierID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplierProducts indicates an expected call of GetSupplierProducts.
+func (mr *MockIQuerierMockRecorder) GetSupplierProducts(ctx, supplierID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplierProducts", reflect.TypeOf((*MockIQuerier)(nil).GetSupplierProducts), ctx, supplierID)
+}
+
+// GetSupplierVersions mocks base method.
+func (m *MockIQuerier) GetSupplierVersions(ctx context.Context, arg sqlc.GetSupplierVersionsParams) ([]sqlc.SuppliersHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplierVersions", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.SuppliersHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplierVersions indicates an expected call of GetSupplierVersions.
+func (mr *MockIQuerierMockRecorder) GetSupplierVersions(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplierVersions", reflect.TypeOf((*MockIQuerier)(nil).GetSupplierVersions), ctx, arg)
+}
+
+// GetSuppliersPage mocks base method.
+func (m *MockIQuerier) GetSuppliersPage(ctx context.Context, arg sqlc.GetSuppliersPageParams) ([]sqlc.SupplierDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuppliersPage", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.SupplierDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuppliersPage indicates an expected call of GetSuppliersPage.
+func (mr *MockIQuerierMockRecorder) GetSuppliersPage(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuppliersPage", reflect.TypeOf((*MockIQuerier)(nil).GetSuppliersPage), ctx, arg)
+}
+
+// GetUnknownRoles mocks base method.
+func (m *MockIQuerier) GetUnknownRoles(ctx context.Context, names []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnknownRoles", ctx, names)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnknownRoles indicates an expected call of GetUnknownRoles.
+func (mr *MockIQuerierMockRecorder) GetUnknownRoles(ctx, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnknownRoles", reflect.TypeOf((*MockIQuerier)(nil).GetUnknownRoles), ctx, names)
+}
+
+// GetVariantPrices mocks base method.
+func (m *MockIQuerier) GetVariantPrices(ctx context.Context, variantID uuid.UUID) ([]sqlc.VariantPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantPrices", ctx, variantID)
+	ret0, _ := ret[0].([]sqlc.VariantPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantPrices indicates an expected call of GetVariantPrices.
+func (mr *MockIQuerierMockRecorder) GetVariantPrices(ctx, variantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantPrices", reflect.TypeOf((*MockIQuerier)(nil).GetVariantPrices), ctx, variantID)
+}
+
+// GetVariantsOfProducts mocks base method.
+func (m *MockIQuerier) GetVariantsOfProducts(ctx context.Context, productIds []uuid.UUID) ([]sqlc.ProductVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantsOfProducts", ctx, productIds)
+	ret0, _ := ret[0].([]sqlc.ProductVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantsOfProducts indicates an expected call of GetVariantsOfProducts.
+func (mr *MockIQuerierMockRecorder) GetVariantsOfProducts(ctx, productIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantsOfProducts", reflect.TypeOf((*MockIQuerier)(nil).GetVariantsOfProducts), ctx, productIds)
+}
+
+// HasDeletedReferences mocks base method.
+func (m *MockIQuerier) HasDeletedReferences(ctx context.Context, uid uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasDeletedReferences", ctx, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasDeletedReferences indicates an expected call of HasDeletedReferences.
+func (mr *MockIQuerierMockRecorder) HasDeletedReferences(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDeletedReferences", reflect.TypeOf((*MockIQuerier)(nil).HasDeletedReferences), ctx, uid)
+}
+
+// InsertAddress mocks base method.
+func (m *MockIQuerier) InsertAddress(ctx context.Context, arg sqlc.InsertAddressParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAddress", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAddress indicates an expected call of InsertAddress.
+func (mr *MockIQuerierMockRecorder) InsertAddress(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAddress", reflect.TypeOf((*MockIQuerier)(nil).InsertAddress), ctx, arg)
+}
+
+// InsertApiKey mocks base method.
+func (m *MockIQuerier) InsertApiKey(ctx context.Context, arg sqlc.InsertApiKeyParams) (sqlc.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertApiKey", ctx, arg)
+	ret0, _ := ret[0].(sqlc.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertApiKey indicates an expected call of InsertApiKey.
+func (mr *MockIQuerierMockRecorder) InsertApiKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertApiKey", reflect.TypeOf((*MockIQuerier)(nil).InsertApiKey), ctx, arg)
+}
+
+// InsertAuditEntry mocks base method.
+func (m *MockIQuerier) InsertAuditEntry(ctx context.Context, arg sqlc.InsertAuditEntryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAuditEntry", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAuditEntry indicates an expected call of InsertAuditEntry.
+func (mr *MockIQuerierMockRecorder) InsertAuditEntry(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuditEntry", reflect.TypeOf((*MockIQuerier)(nil).InsertAuditEntry), ctx, arg)
+}
+
+// InsertCategory mocks base method.
+func (m *MockIQuerier) InsertCategory(ctx context.Context, arg sqlc.InsertCategoryParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCategory", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertCategory indicates an expected call of InsertCategory.
+func (mr *MockIQuerierMockRecorder) InsertCategory(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCategory", reflect.TypeOf((*MockIQuerier)(nil).InsertCategory), ctx, arg)
+}
+
+// InsertClient mocks base method.
+func (m *MockIQuerier) InsertClient(ctx context.Context, arg sqlc.InsertClientParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertClient", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertClient indicates an expected call of InsertClient.
+func (mr *MockIQuerierMockRecorder) InsertClient(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertClient", reflect.TypeOf((*MockIQuerier)(nil).InsertClient), ctx, arg)
+}
+
+// InsertProduct mocks base method.
+func (m *MockIQuerier) InsertProduct(ctx context.Context, arg sqlc.InsertProductParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertProduct", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertProduct indicates an expected call of InsertProduct.
+func (mr *MockIQuerierMockRecorder) InsertProduct(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProduct", reflect.TypeOf((*MockIQuerier)(nil).InsertProduct), ctx, arg)
+}
+
+// InsertProductImage mocks base method.
+func (m *MockIQuerier) InsertProductImage(ctx context.Context, arg sqlc.InsertProductImageParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertProductImage", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertProductImage indicates an expected call of InsertProductImage.
+func (mr *MockIQuerierMockRecorder) InsertProductImage(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProductImage", reflect.TypeOf((*MockIQuerier)(nil).InsertProductImage), ctx, arg)
+}
+
+// InsertProductVariant mocks base method.
+func (m *MockIQuerier) InsertProductVariant(ctx context.Context, arg sqlc.InsertProductVariantParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertProductVariant", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertProductVariant indicates an expected call of InsertProductVariant.
+func (mr *MockIQuerierMockRecorder) InsertProductVariant(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProductVariant", reflect.TypeOf((*MockIQuerier)(nil).InsertProductVariant), ctx, arg)
+}
+
+// InsertPromotion mocks base method.
+func (m *MockIQuerier) InsertPromotion(ctx context.Context, arg sqlc.InsertPromotionParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPromotion", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertPromotion indicates an expected call of InsertPromotion.
+func (mr *MockIQuerierMockRecorder) InsertPromotion(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPromotion", reflect.TypeOf((*MockIQuerier)(nil).InsertPromotion), ctx, arg)
+}
+
+// InsertPromotionRedemption mocks base method.
+func (m *MockIQuerier) InsertPromotionRedemption(ctx context.Context, arg sqlc.InsertPromotionRedemptionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPromotionRedemption", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertPromotionRedemption indicates an expected call of InsertPromotionRedemption.
+func (mr *MockIQuerierMockRecorder) InsertPromotionRedemption(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPromotionRedemption", reflect.TypeOf((*MockIQuerier)(nil).InsertPromotionRedemption), ctx, arg)
+}
+
+// InsertRole mocks base method.
+func (m *MockIQuerier) InsertRole(ctx context.Context, arg sqlc.InsertRoleParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRole", ctx, arg)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertRole indicates an expected call of InsertRole.
+func (mr *MockIQuerierMockRecorder) InsertRole(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRole", reflect.TypeOf((*MockIQuerier)(nil).InsertRole), ctx, arg)
+}
+
+// InsertSupplier mocks base method.
+func (m *MockIQuerier) InsertSupplier(ctx context.Context, arg sqlc.InsertSupplierParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSupplier", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertSupplier indicates an expected call of InsertSupplier.
+func (mr *MockIQuerierMockRecorder) InsertSupplier(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSupplier", reflect.TypeOf((*MockIQuerier)(nil).InsertSupplier), ctx, arg)
+}
+
+// InsertVariantPrice mocks base method.
+func (m *MockIQuerier) InsertVariantPrice(ctx context.Context, arg sqlc.InsertVariantPriceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertVariantPrice", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertVariantPrice indicates an expected call of InsertVariantPrice.
+func (mr *MockIQuerierMockRecorder) InsertVariantPrice(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertVariantPrice", reflect.TypeOf((*MockIQuerier)(nil).InsertVariantPrice), ctx, arg)
+}
+
+// IsCategoryExists mocks base method.
+func (m *MockIQuerier) IsCategoryExists(ctx context.Context, uid uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCategoryExists", ctx, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCategoryExists indicates an expected call of IsCategoryExists.
+func (mr *MockIQuerierMockRecorder) IsCategoryExists(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCategoryExists", reflect.TypeOf((*MockIQuerier)(nil).IsCategoryExists), ctx, uid)
+}
+
+// IsCategoryInSubtree mocks base method.
+func (m *MockIQuerier) IsCategoryInSubtree(ctx context.Context, arg sqlc.IsCategoryInSubtreeParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCategoryInSubtree", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCategoryInSubtree indicates an expected call of IsCategoryInSubtree.
+func (mr *MockIQuerierMockRecorder) IsCategoryInSubtree(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCategoryInSubtree", reflect.TypeOf((*MockIQuerier)(nil).IsCategoryInSubtree), ctx, arg)
+}
+
+// IsCategoryInUse mocks base method.
+func (m *MockIQuerier) IsCategoryInUse(ctx context.Context, uid uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCategoryInUse", ctx, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCategoryInUse indicates an expected call of IsCategoryInUse.
+func (mr *MockIQuerierMockRecorder) IsCategoryInUse(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCategoryInUse", reflect.TypeOf((*MockIQuerier)(nil).IsCategoryInUse), ctx, uid)
+}
+
+// IsClientExists mocks base method.
+func (m *MockIQuerier) IsClientExists(ctx context.Context, uid uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsClientExists", ctx, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsClientExists indicates an expected call of IsClientExists.
+func (mr *MockIQuerierMockRecorder) IsClientExists(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsClientExists", reflect.TypeOf((*MockIQuerier)(nil).IsClientExists), ctx, uid)
+}
+
+// IsProductExists mocks base method.
+func (m *MockIQuerier) IsProductExists(ctx context.Context, uid uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsProductExists", ctx, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsProductExists indicates an expected call of IsProductExists.
+func (mr *MockIQuerierMockRecorder) IsProductExists(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProductExists", reflect.TypeOf((*MockIQuerier)(nil).IsProductExists), ctx, uid)
+}
+
+// KeepImage mocks base method.
+func (m *MockIQuerier) KeepImage(ctx context.Context, uid uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeepImage", ctx, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KeepImage indicates an expected call of KeepImage.
+func (mr *MockIQuerierMockRecorder) KeepImage(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeepImage", reflect.TypeOf((*MockIQuerier)(nil).KeepImage), ctx, uid)
+}
+
+// LockClient mocks base method.
+func (m *MockIQuerier) LockClient(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockClient", ctx, uid)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AddImage indicates an expected call of AddImage.
-func (mr *MockIQuerierMockRecorder) AddImage(ctx, arg interface{}) *gomock.Call {
+// LockClient indicates an expected call of LockClient.
+func (mr *MockIQuerierMockRecorder) LockClient(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddImage", reflect.TypeOf((*MockIQuerier)(nil).AddImage), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockClient", reflect.TypeOf((*MockIQuerier)(nil).LockClient), ctx, uid)
 }
 
-// CalculateClientsWithAddress mocks base method.
-func (m *MockIQuerier) CalculateClientsWithAddress(ctx context.Context, addressID int32) (int64, error) {
+// LockImage mocks base method.
+func (m *MockIQuerier) LockImage(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CalculateClientsWithAddress", ctx, addressID)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "LockImage", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CalculateClientsWithAddress indicates an expected call of CalculateClientsWithAddress.
-func (mr *MockIQuerierMockRecorder) CalculateClientsWithAddress(ctx, addressID interface{}) *gomock.Call {
+// LockImage indicates an expected call of LockImage.
+func (mr *MockIQuerierMockRecorder) LockImage(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateClientsWithAddress", reflect.TypeOf((*MockIQuerier)(nil).CalculateClientsWithAddress), ctx, addressID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockImage", reflect.TypeOf((*MockIQuerier)(nil).LockImage), ctx, uid)
 }
 
-// CalculateSuppliersWithAddress mocks base method.
-func (m *MockIQuerier) CalculateSuppliersWithAddress(ctx context.Context, addressID int32) (int64, error) {
+// LockPromotion mocks base method.
+func (m *MockIQuerier) LockPromotion(ctx context.Context, arg sqlc.LockPromotionParams) (sqlc.LockPromotionRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CalculateSuppliersWithAddress", ctx, addressID)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "LockPromotion", ctx, arg)
+	ret0, _ := ret[0].(sqlc.LockPromotionRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CalculateSuppliersWithAddress indicates an expected call of CalculateSuppliersWithAddress.
-func (mr *MockIQuerierMockRecorder) CalculateSuppliersWithAddress(ctx, addressID interface{}) *gomock.Call {
+// LockPromotion indicates an expected call of LockPromotion.
+func (mr *MockIQuerierMockRecorder) LockPromotion(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateSuppliersWithAddress", reflect.TypeOf((*MockIQuerier)(nil).CalculateSuppliersWithAddress), ctx, addressID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPromotion", reflect.TypeOf((*MockIQuerier)(nil).LockPromotion), ctx, arg)
 }
 
-// DecreaseProduct mocks base method.
-func (m *MockIQuerier) DecreaseProduct(ctx context.Context, arg sqlc.DecreaseProductParams) (int64, error) {
+// LockSupplier mocks base method.
+func (m *MockIQuerier) LockSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DecreaseProduct", ctx, arg)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "LockSupplier", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DecreaseProduct indicates an expected call of DecreaseProduct.
-func (mr *MockIQuerierMockRecorder) DecreaseProduct(ctx, arg interface{}) *gomock.Call {
+// LockSupplier indicates an expected call of LockSupplier.
+func (mr *MockIQuerierMockRecorder) LockSupplier(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseProduct", reflect.TypeOf((*MockIQuerier)(nil).DecreaseProduct), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSupplier", reflect.TypeOf((*MockIQuerier)(nil).LockSupplier), ctx, uid)
 }
 
-// DeleteAddress mocks base method.
-func (m *MockIQuerier) DeleteAddress(ctx context.Context, id int32) error {
+// LockVariant mocks base method.
+func (m *MockIQuerier) LockVariant(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAddress", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "LockVariant", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteAddress indicates an expected call of DeleteAddress.
-func (mr *MockIQuerierMockRecorder) DeleteAddress(ctx, id interface{}) *gomock.Call {
+// LockVariant indicates an expected call of LockVariant.
+func (mr *MockIQuerierMockRecorder) LockVariant(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddress", reflect.TypeOf((*MockIQuerier)(nil).DeleteAddress), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockVariant", reflect.TypeOf((*MockIQuerier)(nil).LockVariant), ctx, uid)
 }
 
-// DeleteClient mocks base method.
-func (m *MockIQuerier) DeleteClient(ctx context.Context, uid uuid.UUID) (int32, error) {
+// LockVariantStockForUpdate mocks base method.
+func (m *MockIQuerier) LockVariantStockForUpdate(ctx context.Context, uid uuid.UUID) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteClient", ctx, uid)
-	ret0, _ := ret[0].(int32)
+	ret := m.ctrl.Call(m, "LockVariantStockForUpdate", ctx, uid)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteClient indicates an expected call of DeleteClient.
-func (mr *MockIQuerierMockRecorder) DeleteClient(ctx, uid interface{}) *gomock.Call {
+// LockVariantStockForUpdate indicates an expected call of LockVariantStockForUpdate.
+func (mr *MockIQuerierMockRecorder) LockVariantStockForUpdate(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockIQuerier)(nil).DeleteClient), ctx, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockVariantStockForUpdate", reflect.TypeOf((*MockIQuerier)(nil).LockVariantStockForUpdate), ctx, uid)
 }
 
-// DeleteImage mocks base method.
-func (m *MockIQuerier) DeleteImage(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+// MarkOrphanedAddresses mocks base method.
+func (m *MockIQuerier) MarkOrphanedAddresses(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteImage", ctx, uid)
-	ret0, _ := ret[0].(uuid.UUID)
+	ret := m.ctrl.Call(m, "MarkOrphanedAddresses", ctx)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteImage indicates an expected call of DeleteImage.
-func (mr *MockIQuerierMockRecorder) DeleteImage(ctx, uid interface{}) *gomock.Call {
+// MarkOrphanedAddresses indicates an expected call of MarkOrphanedAddresses.
+func (mr *MockIQuerierMockRecorder) MarkOrphanedAddresses(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockIQuerier)(nil).DeleteImage), ctx, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOrphanedAddresses", reflect.TypeOf((*MockIQuerier)(nil).MarkOrphanedAddresses), ctx)
 }
 
-// DeleteProduct mocks base method.
-func (m *MockIQuerier) DeleteProduct(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+// MarkOrphanedImages mocks base method.
+func (m *MockIQuerier) MarkOrphanedImages(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", ctx, uid)
-	ret0, _ := ret[0].(uuid.UUID)
+	ret := m.ctrl.Call(m, "MarkOrphanedImages", ctx)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockIQuerierMockRecorder) DeleteProduct(ctx, uid interface{}) *gomock.Call {
+// MarkOrphanedImages indicates an expected call of MarkOrphanedImages.
+func (mr *MockIQuerierMockRecorder) MarkOrphanedImages(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockIQuerier)(nil).DeleteProduct), ctx, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOrphanedImages", reflect.TypeOf((*MockIQuerier)(nil).MarkOrphanedImages), ctx)
 }
 
-// DeleteSupplier mocks base method.
-func (m *MockIQuerier) DeleteSupplier(ctx context.Context, uid uuid.UUID) (int32, error) {
+// PromoteFirstProductImage mocks base method.
+func (m *MockIQuerier) PromoteFirstProductImage(ctx context.Context, productID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSupplier", ctx, uid)
-	ret0, _ := ret[0].(int32)
+	ret := m.ctrl.Call(m, "PromoteFirstProductImage", ctx, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PromoteFirstProductImage indicates an expected call of PromoteFirstProductImage.
+func (mr *MockIQuerierMockRecorder) PromoteFirstProductImage(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteFirstProductImage", reflect.TypeOf((*MockIQuerier)(nil).PromoteFirstProductImage), ctx, productID)
+}
+
+// PurgeClients mocks base method.
+func (m *MockIQuerier) PurgeClients(ctx context.Context, arg sqlc.PurgeClientsParams) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeClients", ctx, arg)
+	ret0, _ := ret[0].([]uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteSupplier indicates an expected call of DeleteSupplier.
-func (mr *MockIQuerierMockRecorder) DeleteSupplier(ctx, uid interface{}) *gomock.Call {
+// PurgeClients indicates an expected call of PurgeClients.
+func (mr *MockIQuerierMockRecorder) PurgeClients(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupplier", reflect.TypeOf((*MockIQuerier)(nil).DeleteSupplier), ctx, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeClients", reflect.TypeOf((*MockIQuerier)(nil).PurgeClients), ctx, arg)
 }
 
-// GetAllClients mocks base method.
-func (m *MockIQuerier) GetAllClients(ctx context.Context) ([]sqlc.ClientDetail, error) {
+// PurgeImages mocks base method.
+func (m *MockIQuerier) PurgeImages(ctx context.Context, arg sqlc.PurgeImagesParams) ([]sqlc.PurgeImagesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllClients", ctx)
-	ret0, _ := ret[0].([]sqlc.ClientDetail)
+	ret := m.ctrl.Call(m, "PurgeImages", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.PurgeImagesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllClients indicates an expected call of GetAllClients.
-func (mr *MockIQuerierMockRecorder) GetAllClients(ctx interface{}) *gomock.Call {
+// PurgeImages indicates an expected call of PurgeImages.
+func (mr *MockIQuerierMockRecorder) PurgeImages(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllClients", reflect.TypeOf((*MockIQuerier)(nil).GetAllClients), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeImages", reflect.TypeOf((*MockIQuerier)(nil).PurgeImages), ctx, arg)
 }
 
-// GetAllProducts mocks base method.
-func (m *MockIQuerier) GetAllProducts(ctx context.Context) ([]sqlc.Product, error) {
+// PurgeProduct mocks base method.
+func (m *MockIQuerier) PurgeProduct(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProducts", ctx)
-	ret0, _ := ret[0].([]sqlc.Product)
+	ret := m.ctrl.Call(m, "PurgeProduct", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllProducts indicates an expected call of GetAllProducts.
-func (mr *MockIQuerierMockRecorder) GetAllProducts(ctx interface{}) *gomock.Call {
+// PurgeProduct indicates an expected call of PurgeProduct.
+func (mr *MockIQuerierMockRecorder) PurgeProduct(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockIQuerier)(nil).GetAllProducts), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeProduct", reflect.TypeOf((*MockIQuerier)(nil).PurgeProduct), ctx, uid)
 }
 
-// GetAllSuppliers mocks base method.
-func (m *MockIQuerier) GetAllSuppliers(ctx context.Context) ([]sqlc.SupplierDetail, error) {
+// PurgeSuppliers mocks base method.
+func (m *MockIQuerier) PurgeSuppliers(ctx context.Context, arg sqlc.PurgeSuppliersParams) ([]uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllSuppliers", ctx)
-	ret0, _ := ret[0].([]sqlc.SupplierDetail)
+	ret := m.ctrl.Call(m, "PurgeSuppliers", ctx, arg)
+	ret0, _ := ret[0].([]uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllSuppliers indicates an expected call of GetAllSuppliers.
-func (mr *MockIQuerierMockRecorder) GetAllSuppliers(ctx interface{}) *gomock.Call {
+// PurgeSuppliers indicates an expected call of PurgeSuppliers.
+func (mr *MockIQuerierMockRecorder) PurgeSuppliers(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSuppliers", reflect.TypeOf((*MockIQuerier)(nil).GetAllSuppliers), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeSuppliers", reflect.TypeOf((*MockIQuerier)(nil).PurgeSuppliers), ctx, arg)
 }
 
-// GetClientsPage mocks base method.
-func (m *MockIQuerier) GetClientsPage(ctx context.Context, arg sqlc.GetClientsPageParams) ([]sqlc.ClientDetail, error) {
+// ReassignSupplierProducts mocks base method.
+func (m *MockIQuerier) ReassignSupplierProducts(ctx context.Context, arg sqlc.ReassignSupplierProductsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientsPage", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.ClientDetail)
+	ret := m.ctrl.Call(m, "ReassignSupplierProducts", ctx, arg)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientsPage indicates an expected call of GetClientsPage.
-func (mr *MockIQuerierMockRecorder) GetClientsPage(ctx, arg interface{}) *gomock.Call {
+// ReassignSupplierProducts indicates an expected call of ReassignSupplierProducts.
+func (mr *MockIQuerierMockRecorder) ReassignSupplierProducts(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientsPage", reflect.TypeOf((*MockIQuerier)(nil).GetClientsPage), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignSupplierProducts", reflect.TypeOf((*MockIQuerier)(nil).ReassignSupplierProducts), ctx, arg)
 }
 
-// GetClientsWithName mocks base method.
-func (m *MockIQuerier) GetClientsWithName(ctx context.Context, arg sqlc.GetClientsWithNameParams) ([]sqlc.ClientDetail, error) {
+// ReleaseBlob mocks base method.
+func (m *MockIQuerier) ReleaseBlob(ctx context.Context, storageKey string) (int32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClientsWithName", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.ClientDetail)
+	ret := m.ctrl.Call(m, "ReleaseBlob", ctx, storageKey)
+	ret0, _ := ret[0].(int32)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClientsWithName indicates an expected call of GetClientsWithName.
-func (mr *MockIQuerierMockRecorder) GetClientsWithName(ctx, arg interface{}) *gomock.Call {
+// ReleaseBlob indicates an expected call of ReleaseBlob.
+func (mr *MockIQuerierMockRecorder) ReleaseBlob(ctx, storageKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientsWithName", reflect.TypeOf((*MockIQuerier)(nil).GetClientsWithName), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseBlob", reflect.TypeOf((*MockIQuerier)(nil).ReleaseBlob), ctx, storageKey)
 }
 
-// GetImage mocks base method.
-func (m *MockIQuerier) GetImage(ctx context.Context, uid uuid.UUID) ([]byte, error) {
+// RestoreClient mocks base method.
+func (m *MockIQuerier) RestoreClient(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetImage", ctx, uid)
-	ret0, _ := ret[0].([]byte)
+	ret := m.ctrl.Call(m, "RestoreClient", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetImage indicates an expected call of GetImage.
-func (mr *MockIQuerierMockRecorder) GetImage(ctx, uid interface{}) *gomock.Call {
+// RestoreClient indicates an expected call of RestoreClient.
+func (mr *MockIQuerierMockRecorder) RestoreClient(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImage", reflect.TypeOf((*MockIQuerier)(nil).GetImage), ctx, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreClient", reflect.TypeOf((*MockIQuerier)(nil).RestoreClient), ctx, uid)
 }
 
-// GetProduct mocks base method.
-func (m *MockIQuerier) GetProduct(ctx context.Context, uid uuid.UUID) (sqlc.Product, error) {
+// RestoreImage mocks base method.
+func (m *MockIQuerier) RestoreImage(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProduct", ctx, uid)
-	ret0, _ := ret[0].(sqlc.Product)
+	ret := m.ctrl.Call(m, "RestoreImage", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProduct indicates an expected call of GetProduct.
-func (mr *MockIQuerierMockRecorder) GetProduct(ctx, uid interface{}) *gomock.Call {
+// RestoreImage indicates an expected call of RestoreImage.
+func (mr *MockIQuerierMockRecorder) RestoreImage(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockIQuerier)(nil).GetProduct), ctx, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreImage", reflect.TypeOf((*MockIQuerier)(nil).RestoreImage), ctx, uid)
 }
 
-// GetProductImage mocks base method.
-func (m *MockIQuerier) GetProductImage(ctx context.Context, uid uuid.UUID) (sqlc.Image, error) {
+// RestoreProduct mocks base method.
+func (m *MockIQuerier) RestoreProduct(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductImage", ctx, uid)
-	ret0, _ := ret[0].(sqlc.Image)
+	ret := m.ctrl.Call(m, "RestoreProduct", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductImage indicates an expected call of GetProductImage.
-func (mr *MockIQuerierMockRecorder) GetProductImage(ctx, uid interface{}) *gomock.Call {
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockIQuerierMockRecorder) RestoreProduct(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImage", reflect.TypeOf((*MockIQuerier)(nil).GetProductImage), ctx, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockIQuerier)(nil).RestoreProduct), ctx, uid)
 }
 
-// GetProductsPage mocks base method.
-func (m *MockIQuerier) GetProductsPage(ctx context.Context, arg sqlc.GetProductsPageParams) ([]sqlc.Product, error) {
+// RestoreSupplier mocks base method.
+func (m *MockIQuerier) RestoreSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductsPage", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.Product)
+	ret := m.ctrl.Call(m, "RestoreSupplier", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductsPage indicates an expected call of GetProductsPage.
-func (mr *MockIQuerierMockRecorder) GetProductsPage(ctx, arg interface{}) *gomock.Call {
+// RestoreSupplier indicates an expected call of RestoreSupplier.
+func (mr *MockIQuerierMockRecorder) RestoreSupplier(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsPage", reflect.TypeOf((*MockIQuerier)(nil).GetProductsPage), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSupplier", reflect.TypeOf((*MockIQuerier)(nil).RestoreSupplier), ctx, uid)
 }
 
-// GetSupplier mocks base method.
-func (m *MockIQuerier) GetSupplier(ctx context.Context, uid uuid.UUID) (sqlc.SupplierDetail, error) {
+// RevokeApiKey mocks base method.
+func (m *MockIQuerier) RevokeApiKey(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSupplier", ctx, uid)
-	ret0, _ := ret[0].(sqlc.SupplierDetail)
+	ret := m.ctrl.Call(m, "RevokeApiKey", ctx, uid)
+	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSupplier indicates an expected call of GetSupplier.
-func (mr *MockIQuerierMockRecorder) GetSupplier(ctx, uid interface{}) *gomock.Call {
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockIQuerierMockRecorder) RevokeApiKey(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockIQuerier)(nil).GetSupplier), ctx, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockIQuerier)(nil).RevokeApiKey), ctx, uid)
 }
 
-// GetSuppliersPage mocks base method.
-func (m *MockIQuerier) GetSuppliersPage(ctx context.Context, arg sqlc.GetSuppliersPageParams) ([]sqlc.SupplierDetail, error) {
+// SearchClientsByName mocks base method.
+func (m *MockIQuerier) SearchClientsByName(ctx context.Context, arg sqlc.SearchClientsByNameParams) ([]sqlc.SearchClientsByNameRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSuppliersPage", ctx, arg)
-	ret0, _ := ret[0].([]sqlc.SupplierDetail)
+	ret := m.ctrl.Call(m, "SearchClientsByName", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.SearchClientsByNameRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSuppliersPage indicates an expected call of GetSuppliersPage.
-func (mr *MockIQuerierMockRecorder) GetSuppliersPage(ctx, arg interface{}) *gomock.Call {
+// SearchClientsByName indicates an expected call of SearchClientsByName.
+func (mr *MockIQuerierMockRecorder) SearchClientsByName(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuppliersPage", reflect.TypeOf((*MockIQuerier)(nil).GetSuppliersPage), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchClientsByName", reflect.TypeOf((*MockIQuerier)(nil).SearchClientsByName), ctx, arg)
 }
 
-// InsertAddress mocks base method.
-func (m *MockIQuerier) InsertAddress(ctx context.Context, arg sqlc.InsertAddressParams) (int32, error) {
+// SetExchangeRate mocks base method.
+func (m *MockIQuerier) SetExchangeRate(ctx context.Context, arg sqlc.SetExchangeRateParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertAddress", ctx, arg)
-	ret0, _ := ret[0].(int32)
+	ret := m.ctrl.Call(m, "SetExchangeRate", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExchangeRate indicates an expected call of SetExchangeRate.
+func (mr *MockIQuerierMockRecorder) SetExchangeRate(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExchangeRate", reflect.TypeOf((*MockIQuerier)(nil).SetExchangeRate), ctx, arg)
+}
+
+// SetImageStorageKey mocks base method.
+func (m *MockIQuerier) SetImageStorageKey(ctx context.Context, arg sqlc.SetImageStorageKeyParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetImageStorageKey", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InsertAddress indicates an expected call of InsertAddress.
-func (mr *MockIQuerierMockRecorder) InsertAddress(ctx, arg interface{}) *gomock.Call {
+// SetImageStorageKey indicates an expected call of SetImageStorageKey.
+func (mr *MockIQuerierMockRecorder) SetImageStorageKey(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAddress", reflect.TypeOf((*MockIQuerier)(nil).InsertAddress), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetImageStorageKey", reflect.TypeOf((*MockIQuerier)(nil).SetImageStorageKey), ctx, arg)
 }
 
-// InsertClient mocks base method.
-func (m *MockIQuerier) InsertClient(ctx context.Context, arg sqlc.InsertClientParams) (uuid.UUID, error) {
+// SetProductImagePosition mocks base method.
+func (m *MockIQuerier) SetProductImagePosition(ctx context.Context, arg sqlc.SetProductImagePositionParams) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertClient", ctx, arg)
+	ret := m.ctrl.Call(m, "SetProductImagePosition", ctx, arg)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InsertClient indicates an expected call of InsertClient.
-func (mr *MockIQuerierMockRecorder) InsertClient(ctx, arg interface{}) *gomock.Call {
+// SetProductImagePosition indicates an expected call of SetProductImagePosition.
+func (mr *MockIQuerierMockRecorder) SetProductImagePosition(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertClient", reflect.TypeOf((*MockIQuerier)(nil).InsertClient), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductImagePosition", reflect.TypeOf((*MockIQuerier)(nil).SetProductImagePosition), ctx, arg)
 }
 
-// InsertProduct mocks base method.
-func (m *MockIQuerier) InsertProduct(ctx context.Context, arg sqlc.InsertProductParams) (uuid.UUID, error) {
+// SetProductPrimaryImage mocks base method.
+func (m *MockIQuerier) SetProductPrimaryImage(ctx context.Context, arg sqlc.SetProductPrimaryImageParams) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertProduct", ctx, arg)
+	ret := m.ctrl.Call(m, "SetProductPrimaryImage", ctx, arg)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InsertProduct indicates an expected call of InsertProduct.
-func (mr *MockIQuerierMockRecorder) InsertProduct(ctx, arg interface{}) *gomock.Call {
+// SetProductPrimaryImage indicates an expected call of SetProductPrimaryImage.
+func (mr *MockIQuerierMockRecorder) SetProductPrimaryImage(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProduct", reflect.TypeOf((*MockIQuerier)(nil).InsertProduct), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductPrimaryImage", reflect.TypeOf((*MockIQuerier)(nil).SetProductPrimaryImage), ctx, arg)
 }
 
-// InsertSupplier mocks base method.
-func (m *MockIQuerier) InsertSupplier(ctx context.Context, arg sqlc.InsertSupplierParams) (uuid.UUID, error) {
+// SetWordSimilarityThreshold mocks base method.
+func (m *MockIQuerier) SetWordSimilarityThreshold(ctx context.Context, threshold float64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertSupplier", ctx, arg)
+	ret := m.ctrl.Call(m, "SetWordSimilarityThreshold", ctx, threshold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWordSimilarityThreshold indicates an expected call of SetWordSimilarityThreshold.
+func (mr *MockIQuerierMockRecorder) SetWordSimilarityThreshold(ctx, threshold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWordSimilarityThreshold", reflect.TypeOf((*MockIQuerier)(nil).SetWordSimilarityThreshold), ctx, threshold)
+}
+
+// ShareSupplier mocks base method.
+func (m *MockIQuerier) ShareSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareSupplier", ctx, uid)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InsertSupplier indicates an expected call of InsertSupplier.
-func (mr *MockIQuerierMockRecorder) InsertSupplier(ctx, arg interface{}) *gomock.Call {
+// ShareSupplier indicates an expected call of ShareSupplier.
+func (mr *MockIQuerierMockRecorder) ShareSupplier(ctx, uid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSupplier", reflect.TypeOf((*MockIQuerier)(nil).InsertSupplier), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareSupplier", reflect.TypeOf((*MockIQuerier)(nil).ShareSupplier), ctx, uid)
 }
 
-// IsImageAndSupplierExists mocks base method.
-func (m *MockIQuerier) IsImageAndSupplierExists(ctx context.Context, arg sqlc.IsImageAndSupplierExistsParams) (bool, error) {
+// UnmarkReferencedAddresses mocks base method.
+func (m *MockIQuerier) UnmarkReferencedAddresses(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsImageAndSupplierExists", ctx, arg)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "UnmarkReferencedAddresses", ctx)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsImageAndSupplierExists indicates an expected call of IsImageAndSupplierExists.
-func (mr *MockIQuerierMockRecorder) IsImageAndSupplierExists(ctx, arg interface{}) *gomock.Call {
+// UnmarkReferencedAddresses indicates an expected call of UnmarkReferencedAddresses.
+func (mr *MockIQuerierMockRecorder) UnmarkReferencedAddresses(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsImageAndSupplierExists", reflect.TypeOf((*MockIQuerier)(nil).IsImageAndSupplierExists), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarkReferencedAddresses", reflect.TypeOf((*MockIQuerier)(nil).UnmarkReferencedAddresses), ctx)
 }
 
-// LockStockForUpdate mocks base method.
-func (m *MockIQuerier) LockStockForUpdate(ctx context.Context, uid uuid.UUID) (int64, error) {
+// UnmarkReferencedImages mocks base method.
+func (m *MockIQuerier) UnmarkReferencedImages(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LockStockForUpdate", ctx, uid)
+	ret := m.ctrl.Call(m, "UnmarkReferencedImages", ctx)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// LockStockForUpdate indicates an expected call of LockStockForUpdate.
-func (mr *MockIQuerierMockRecorder) LockStockForUpdate(ctx, uid interface{}) *gomock.Call {
+// UnmarkReferencedImages indicates an expected call of UnmarkReferencedImages.
+func (mr *MockIQuerierMockRecorder) UnmarkReferencedImages(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockStockForUpdate", reflect.TypeOf((*MockIQuerier)(nil).LockStockForUpdate), ctx, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarkReferencedImages", reflect.TypeOf((*MockIQuerier)(nil).UnmarkReferencedImages), ctx)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockIQuerier) UpdateCategory(ctx context.Context, arg sqlc.UpdateCategoryParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockIQuerierMockRecorder) UpdateCategory(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockIQuerier)(nil).UpdateCategory), ctx, arg)
 }
 
 // UpdateClientAddress mocks base method.
@@ -442,10 +1924,10 @@ func (mr *MockIQuerierMockRecorder) UpdateClientAddress(ctx, arg interface{}) *g
 }
 
 // UpdateImage mocks base method.
-func (m *MockIQuerier) UpdateImage(ctx context.Context, arg sqlc.UpdateImageParams) (uuid.UUID, error) {
+func (m *MockIQuerier) UpdateImage(ctx context.Context, arg sqlc.UpdateImageParams) (sql.NullString, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateImage", ctx, arg)
-	ret0, _ := ret[0].(uuid.UUID)
+	ret0, _ := ret[0].(sql.NullString)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -456,6 +1938,51 @@ func (mr *MockIQuerierMockRecorder) UpdateImage(ctx, arg interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImage", reflect.TypeOf((*MockIQuerier)(nil).UpdateImage), ctx, arg)
 }
 
+// UpdateProductAttributes mocks base method.
+func (m *MockIQuerier) UpdateProductAttributes(ctx context.Context, arg sqlc.UpdateProductAttributesParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductAttributes", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductAttributes indicates an expected call of UpdateProductAttributes.
+func (mr *MockIQuerierMockRecorder) UpdateProductAttributes(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductAttributes", reflect.TypeOf((*MockIQuerier)(nil).UpdateProductAttributes), ctx, arg)
+}
+
+// UpdatePromotion mocks base method.
+func (m *MockIQuerier) UpdatePromotion(ctx context.Context, arg sqlc.UpdatePromotionParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePromotion", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePromotion indicates an expected call of UpdatePromotion.
+func (mr *MockIQuerierMockRecorder) UpdatePromotion(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePromotion", reflect.TypeOf((*MockIQuerier)(nil).UpdatePromotion), ctx, arg)
+}
+
+// UpdateRole mocks base method.
+func (m *MockIQuerier) UpdateRole(ctx context.Context, arg sqlc.UpdateRoleParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, arg)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockIQuerierMockRecorder) UpdateRole(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockIQuerier)(nil).UpdateRole), ctx, arg)
+}
+
 // UpdateSupplierAddress mocks base method.
 func (m *MockIQuerier) UpdateSupplierAddress(ctx context.Context, arg sqlc.UpdateSupplierAddressParams) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -471,6 +1998,21 @@ func (mr *MockIQuerierMockRecorder) UpdateSupplierAddress(ctx, arg interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSupplierAddress", reflect.TypeOf((*MockIQuerier)(nil).UpdateSupplierAddress), ctx, arg)
 }
 
+// UseApiKey mocks base method.
+func (m *MockIQuerier) UseApiKey(ctx context.Context, keyHash string) (sqlc.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseApiKey", ctx, keyHash)
+	ret0, _ := ret[0].(sqlc.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseApiKey indicates an expected call of UseApiKey.
+func (mr *MockIQuerierMockRecorder) UseApiKey(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseApiKey", reflect.TypeOf((*MockIQuerier)(nil).UseApiKey), ctx, keyHash)
+}
+
 // MockIDB is a mock of IDB interface.
 type MockIDB struct {
 	ctrl     *gomock.Controller
@@ -536,3 +2078,69 @@ func (mr *MockIDBMockRecorder) Querier() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Querier", reflect.TypeOf((*MockIDB)(nil).Querier))
 }
+
+// MockIBlobStorage is a mock of IBlobStorage interface.
+type MockIBlobStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBlobStorageMockRecorder
+}
+
+// MockIBlobStorageMockRecorder is the mock recorder for MockIBlobStorage.
+type MockIBlobStorageMockRecorder struct {
+	mock *MockIBlobStorage
+}
+
+// NewMockIBlobStorage creates a new mock instance.
+func NewMockIBlobStorage(ctrl *gomock.Controller) *MockIBlobStorage {
+	mock := &MockIBlobStorage{ctrl: ctrl}
+	mock.recorder = &MockIBlobStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBlobStorage) EXPECT() *MockIBlobStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIBlobStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIBlobStorageMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIBlobStorage)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockIBlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIBlobStorageMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIBlobStorage)(nil).Get), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockIBlobStorage) Put(ctx context.Context, key string, r io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockIBlobStorageMockRecorder) Put(ctx, key, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockIBlobStorage)(nil).Put), ctx, key, r)
+}
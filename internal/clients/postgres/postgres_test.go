@@ -14,6 +14,7 @@ type TestClient struct {
 	ctx         context.Context
 	clientMock  *MockIDB
 	querierMock *MockIQuerier
+	blobMock    *MockIBlobStorage
 	client      *Client
 }
 
@@ -23,10 +24,11 @@ func NewTestClient(t *testing.T) *TestClient {
 	tc := &TestClient{
 		clientMock:  NewMockIDB(mc),
 		querierMock: NewMockIQuerier(mc),
+		blobMock:    NewMockIBlobStorage(mc),
 		ctx:         context.Background(),
 	}
 
-	tc.client = buildClient(tc.clientMock)
+	tc.client = buildClient(tc.clientMock, tc.blobMock)
 
 	return tc
 }
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+
+	"github.com/google/uuid"
+)
+
+func (c *Client) SetVariantPrice(req *ds.SetVariantPriceRequest) (resp *ds.SetVariantPriceResponse, err error) {
+	now := time.Now()
+	from := now
+	if req.EffectiveFrom != nil {
+		if req.EffectiveFrom.Before(now) {
+			return &ds.SetVariantPriceResponse{
+				Status: ds.Status{Message: ds.StatusPriceStartsInPast},
+			}, nil
+		}
+		from = *req.EffectiveFrom
+	}
+	if req.EffectiveTo != nil && !req.EffectiveTo.After(from) {
+		return &ds.SetVariantPriceResponse{
+			Status: ds.Status{Message: ds.StatusPriceEndsNotInTime},
+		}, nil
+	}
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		if _, err := qtx.LockVariant(ctx, req.VariantUid); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				resp = &ds.SetVariantPriceResponse{
+					Status: ds.Status{Message: ds.StatusNotFound},
+				}
+				return nil
+			}
+			return err
+		}
+
+		id, err := qtx.InsertVariantPrice(ctx, sqlc.InsertVariantPriceParams{
+			VariantID:     req.VariantUid,
+			Price:         req.Price.Minor(),
+			Currency:      req.Price.Currency(),
+			EffectiveFrom: from,
+			EffectiveTo:   toNullTime(req.EffectiveTo),
+		})
+		if err != nil {
+			return err
+		}
+
+		resp = &ds.SetVariantPriceResponse{Id: id}
+		return audit(ctx, qtx, req.Audit, "SetVariantPrice", ds.AuditVariantPrice, strconv.FormatInt(id, 10), nil)
+	})
+
+	return
+}
+
+func (c *Client) GetVariantPrices(req *ds.GetVariantPricesRequest) (*ds.GetVariantPricesResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	prices, err := c.db.Querier().GetVariantPrices(ctx, req.VariantUid)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every variant has the price it was added with, no prices means no variant.
+	if len(prices) == 0 {
+		return &ds.GetVariantPricesResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}, nil
+	}
+
+	resp := &ds.GetVariantPricesResponse{
+		Prices: make([]ds.VariantPrice, len(prices)),
+	}
+	for i, p := range prices {
+		resp.Prices[i] = ds.VariantPrice{
+			Id:            p.ID,
+			Price:         money.New(p.Price, p.Currency),
+			EffectiveFrom: p.EffectiveFrom,
+			EffectiveTo:   fromNullTime(p.EffectiveTo),
+			CreatedAt:     p.CreatedAt,
+		}
+	}
+
+	return resp, nil
+}
+
+// applyEffectivePrices sets the variants of the products to the prices in effect at the moment, now if it's nil.
+// A variant keeps the price it has if it's not found, as a variant long gone from a past version of the product.
+// The current prices carry the moment they change next, a past moment's prices don't change.
+func applyEffectivePrices(ctx context.Context, q IQuerier, at *time.Time, products ...*ds.Product) error {
+	var uids []uuid.UUID
+	for _, p := range products {
+		for i := range p.Variants {
+			uids = append(uids, p.Variants[i].Uid)
+		}
+	}
+	if len(uids) == 0 {
+		return nil
+	}
+
+	prices, err := q.GetEffectivePrices(ctx, sqlc.GetEffectivePricesParams{
+		At:         toNullTime(at),
+		VariantIds: uids,
+	})
+	if err != nil {
+		return err
+	}
+
+	byVariant := make(map[uuid.UUID]*sqlc.GetEffectivePricesRow, len(prices))
+	for i := range prices {
+		byVariant[prices[i].VariantID] = &prices[i]
+	}
+
+	for _, p := range products {
+		for i := range p.Variants {
+			v := &p.Variants[i]
+			price, ok := byVariant[v.Uid]
+			if !ok {
+				continue
+			}
+
+			v.Price = money.New(price.Price, price.Currency)
+			if price.EffectiveTo.Valid {
+				regular := money.New(price.RegularPrice, price.RegularCurrency)
+				v.RegularPrice = &regular
+				v.PriceEffectiveTo = fromNullTime(price.EffectiveTo)
+			}
+			if at == nil {
+				v.NextPriceChange = fromNullTime(price.NextChange)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+-- name: LockVariant :one
+SELECT v.uid
+FROM product_variants v
+JOIN products p ON p.uid = v.product_id
+WHERE v.uid = $1 AND p.deleted_at IS NULL
+FOR SHARE OF v;
+
+-- name: InsertVariantPrice :one
+INSERT INTO variant_prices (variant_id, price, currency, effective_from, effective_to)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id;
+
+-- name: GetVariantPrices :many
+SELECT *
+FROM variant_prices
+WHERE variant_id = $1
+ORDER BY effective_from DESC, id DESC;
+
+-- name: GetEffectivePrices :many
+SELECT v.uid AS variant_id,
+    COALESCE(t.price, r.price, v.price)::bigint AS price,
+    COALESCE(t.currency, r.currency, v.currency)::text AS currency,
+    COALESCE(r.price, v.price)::bigint AS regular_price,
+    COALESCE(r.currency, v.currency)::text AS regular_currency,
+    t.effective_to,
+    (SELECT min(b.at)
+        FROM variant_prices p, LATERAL (VALUES (p.effective_from), (p.effective_to)) AS b(at)
+        WHERE p.variant_id = v.uid AND b.at > COALESCE(sqlc.narg(at)::timestamptz, now()))::timestamptz AS next_change
+FROM product_variants v
+LEFT JOIN LATERAL (
+    SELECT p.price, p.currency
+    FROM variant_prices p
+    WHERE p.variant_id = v.uid AND p.effective_to IS NULL
+        AND p.effective_from <= COALESCE(sqlc.narg(at)::timestamptz, now())
+    ORDER BY p.effective_from DESC, p.id DESC
+    LIMIT 1
+) r ON true
+LEFT JOIN LATERAL (
+    SELECT p.price, p.currency, p.effective_to
+    FROM variant_prices p
+    WHERE p.variant_id = v.uid AND p.effective_to IS NOT NULL
+        AND p.effective_from <= COALESCE(sqlc.narg(at)::timestamptz, now())
+        AND p.effective_to > COALESCE(sqlc.narg(at)::timestamptz, now())
+    ORDER BY p.effective_from DESC, p.id DESC
+    LIMIT 1
+) t ON true
+WHERE v.uid = ANY(sqlc.arg(variant_ids)::uuid[]);
@@ -0,0 +1,258 @@
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetVariantPrice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SetVariantPrice temporary", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		from := time.Now().Add(time.Hour)
+		to := from.Add(24 * time.Hour)
+		req := &ds.SetVariantPriceRequest{
+			VariantUid:    uuid.New(),
+			Price:         money.New(24950, money.RUB),
+			EffectiveFrom: &from,
+			EffectiveTo:   &to,
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockVariant(gomock.Any(), req.VariantUid).Return(req.VariantUid, nil)
+		tc.querierMock.EXPECT().InsertVariantPrice(gomock.Any(), sqlc.InsertVariantPriceParams{
+			VariantID:     req.VariantUid,
+			Price:         24950,
+			Currency:      money.RUB,
+			EffectiveFrom: from,
+			EffectiveTo:   sql.NullTime{Time: to, Valid: true},
+		}).Return(int64(42), nil)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.SetVariantPrice(req)
+		require.Nil(t, err)
+		require.Empty(t, resp.GetStatus())
+		require.Equal(t, int64(42), resp.Id)
+	})
+
+	t.Run("SetVariantPrice regular from now", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		req := &ds.SetVariantPriceRequest{VariantUid: uuid.New(), Price: money.New(30000, money.RUB)}
+
+		before := time.Now()
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockVariant(gomock.Any(), req.VariantUid).Return(req.VariantUid, nil)
+		tc.querierMock.EXPECT().InsertVariantPrice(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, arg sqlc.InsertVariantPriceParams) (int64, error) {
+				require.Equal(t, int64(30000), arg.Price)
+				require.False(t, arg.EffectiveFrom.Before(before))
+				require.False(t, arg.EffectiveTo.Valid)
+				return 43, nil
+			})
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.SetVariantPrice(req)
+		require.Nil(t, err)
+		require.Equal(t, int64(43), resp.Id)
+	})
+
+	t.Run("SetVariantPrice starts in the past", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		from := time.Now().Add(-time.Hour)
+		resp, err := tc.client.SetVariantPrice(&ds.SetVariantPriceRequest{
+			VariantUid:    uuid.New(),
+			Price:         money.New(30000, money.RUB),
+			EffectiveFrom: &from,
+		})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusPriceStartsInPast, resp.GetStatus())
+	})
+
+	t.Run("SetVariantPrice ends before it starts", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		from := time.Now().Add(time.Hour)
+		to := from
+		resp, err := tc.client.SetVariantPrice(&ds.SetVariantPriceRequest{
+			VariantUid:    uuid.New(),
+			Price:         money.New(30000, money.RUB),
+			EffectiveFrom: &from,
+			EffectiveTo:   &to,
+		})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusPriceEndsNotInTime, resp.GetStatus())
+	})
+
+	t.Run("SetVariantPrice ended already", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		to := time.Now().Add(-time.Minute)
+		resp, err := tc.client.SetVariantPrice(&ds.SetVariantPriceRequest{
+			VariantUid:  uuid.New(),
+			Price:       money.New(30000, money.RUB),
+			EffectiveTo: &to,
+		})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusPriceEndsNotInTime, resp.GetStatus())
+	})
+
+	t.Run("SetVariantPrice variant not found", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockVariant(gomock.Any(), gomock.Any()).Return(uuid.Nil, sql.ErrNoRows)
+
+		resp, err := tc.client.SetVariantPrice(&ds.SetVariantPriceRequest{VariantUid: uuid.New(), Price: money.New(30000, money.RUB)})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("SetVariantPrice error on InsertVariantPrice", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockVariant(gomock.Any(), gomock.Any()).Return(uuid.Nil, nil)
+		tc.querierMock.EXPECT().InsertVariantPrice(gomock.Any(), gomock.Any()).Return(int64(0), errTest)
+
+		resp, err := tc.client.SetVariantPrice(&ds.SetVariantPriceRequest{VariantUid: uuid.New(), Price: money.New(30000, money.RUB)})
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetVariantPrices(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetVariantPrices Ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 1, 0)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetVariantPrices(gomock.Any(), uid).Return([]sqlc.VariantPrice{
+			{ID: 2, VariantID: uid, Price: 24950, Currency: money.RUB, EffectiveFrom: from, EffectiveTo: sql.NullTime{Time: to, Valid: true}},
+			{ID: 1, VariantID: uid, Price: 29999, Currency: money.RUB, EffectiveFrom: from.AddDate(-1, 0, 0)},
+		}, nil)
+
+		resp, err := tc.client.GetVariantPrices(&ds.GetVariantPricesRequest{VariantUid: uid})
+		require.Nil(t, err)
+		require.Len(t, resp.Prices, 2)
+		require.Equal(t, int64(2), resp.Prices[0].Id)
+		require.Equal(t, "249.50 RUB", resp.Prices[0].Price.String())
+		require.Equal(t, &to, resp.Prices[0].EffectiveTo)
+		require.Nil(t, resp.Prices[1].EffectiveTo)
+	})
+
+	t.Run("GetVariantPrices not found", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetVariantPrices(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		resp, err := tc.client.GetVariantPrices(&ds.GetVariantPricesRequest{VariantUid: uuid.New()})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("GetVariantPrices error on GetVariantPrices", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(tc.ctx, func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetVariantPrices(gomock.Any(), gomock.Any()).Return(nil, errTest)
+
+		resp, err := tc.client.GetVariantPrices(&ds.GetVariantPricesRequest{VariantUid: uuid.New()})
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+}
+
+func TestApplyEffectivePrices(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applyEffectivePrices keeps the price of an unknown variant", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		known, gone := uuid.New(), uuid.New()
+		product := &ds.Product{Variants: []ds.ProductVariant{{Uid: known, Price: money.New(1000, money.RUB)}, {Uid: gone, Price: money.New(2000, money.RUB)}}}
+
+		tc.querierMock.EXPECT().GetEffectivePrices(gomock.Any(), sqlc.GetEffectivePricesParams{
+			VariantIds: []uuid.UUID{known, gone},
+		}).Return([]sqlc.GetEffectivePricesRow{{VariantID: known, Price: 1500, Currency: "USD", RegularPrice: 1500, RegularCurrency: "USD"}}, nil)
+
+		err := applyEffectivePrices(tc.ctx, tc.querierMock, nil, product)
+		require.Nil(t, err)
+		require.Equal(t, money.New(1500, "USD"), product.Variants[0].Price)
+		require.Nil(t, product.Variants[0].RegularPrice)
+		require.Equal(t, money.New(2000, money.RUB), product.Variants[1].Price)
+	})
+
+	t.Run("applyEffectivePrices sets the next price change of the current prices", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		next := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+		row := sqlc.GetEffectivePricesRow{VariantID: uid, Price: 1000, Currency: money.RUB, RegularPrice: 1000,
+			RegularCurrency: money.RUB, NextChange: sql.NullTime{Time: next, Valid: true}}
+		tc.querierMock.EXPECT().GetEffectivePrices(gomock.Any(), gomock.Any()).Return([]sqlc.GetEffectivePricesRow{row}, nil).Times(2)
+
+		current := &ds.Product{Variants: []ds.ProductVariant{{Uid: uid}}}
+		err := applyEffectivePrices(tc.ctx, tc.querierMock, nil, current)
+		require.Nil(t, err)
+		require.Equal(t, &next, current.Variants[0].NextPriceChange)
+		require.Equal(t, &next, current.StaleAt())
+
+		at := next.Add(-time.Hour)
+		past := &ds.Product{Variants: []ds.ProductVariant{{Uid: uid}}}
+		err = applyEffectivePrices(tc.ctx, tc.querierMock, &at, past)
+		require.Nil(t, err)
+		require.Nil(t, past.Variants[0].NextPriceChange)
+	})
+
+	t.Run("applyEffectivePrices without variants", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		err := applyEffectivePrices(tc.ctx, tc.querierMock, nil, &ds.Product{})
+		require.Nil(t, err)
+	})
+
+	t.Run("applyEffectivePrices error on GetEffectivePrices", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.querierMock.EXPECT().GetEffectivePrices(gomock.Any(), gomock.Any()).Return(nil, errTest)
+
+		err := applyEffectivePrices(tc.ctx, tc.querierMock, nil, &ds.Product{Variants: []ds.ProductVariant{{Uid: uuid.New()}}})
+		require.ErrorIs(t, err, errTest)
+	})
+}
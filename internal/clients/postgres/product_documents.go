@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/supports"
+)
+
+// AddProductDocument streams the document into the blob storage, the same content is stored once as images are.
+func (c *Client) AddProductDocument(req *ds.AddProductDocumentRequest) (*ds.AddProductDocumentResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	uid := supports.GetUUIDIfEmpty(req.Uid)
+
+	key, meta, err := c.putBlob(ctx, req.Document)
+	if err != nil {
+		if status, ok := uploadStatus(err); ok {
+			return &ds.AddProductDocumentResponse{
+				Status: ds.Status{Message: status},
+			}, nil
+		}
+		return nil, err
+	}
+
+	var resp *ds.AddProductDocumentResponse
+	var storedKey string
+	err = c.db.ExecTx(blobTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		exists, err := qtx.IsProductExists(ctx, req.ProductUid)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			resp = &ds.AddProductDocumentResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}
+			return nil
+		}
+
+		k, err := acquireBlob(ctx, qtx, key, meta)
+		if err != nil {
+			return err
+		}
+		storedKey = k
+
+		uid, err = qtx.AddProductDocument(ctx, sqlc.AddProductDocumentParams{
+			Uid:        uid,
+			ProductID:  req.ProductUid,
+			Title:      req.Title,
+			StorageKey: storedKey,
+			Size:       meta.size,
+			Mime:       meta.mime,
+			Hash:       meta.hash(),
+		})
+		if err != nil {
+			return err
+		}
+
+		resp = &ds.AddProductDocumentResponse{
+			Uid: &uid,
+		}
+		return audit(ctx, qtx, req.Audit, "AddProductDocument", ds.AuditProductDocument, uid.String(), nil)
+	})
+	if err != nil {
+		err = errors.Join(err, c.blobs.Delete(ctx, key))
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return &ds.AddProductDocumentResponse{
+			Status: ds.Status{Message: ds.StatusAlreadyExists},
+		}, nil
+	}
+
+	// The uploaded blob isn't referred if the content is stored already or the product is not found.
+	if storedKey != key {
+		c.dropBlob(ctx, toNullString(key))
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetProductDocument(req *ds.GetProductDocumentRequest) (*ds.GetProductDocumentResponse, error) {
+	// Canceled on error or when the streamed content is closed.
+	ctx, cancel := c.db.CtxWithCancel()
+
+	doc, err := c.db.Querier().GetProductDocument(ctx, req.Uid)
+	if err != nil {
+		cancel()
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return &ds.GetProductDocumentResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}, nil
+	}
+
+	content, err := c.blobs.Get(ctx, doc.StorageKey)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &ds.GetProductDocumentResponse{
+		Uid: &doc.Uid,
+		DocumentContent: ds.DocumentContent{
+			Content:    &cancelOnClose{ReadCloser: content, cancel: cancel},
+			Size:       doc.Size,
+			Hash:       doc.Hash,
+			ModifiedAt: doc.CreatedAt,
+		},
+	}, nil
+}
+
+func (c *Client) GetProductDocuments(req *ds.GetProductDocumentsRequest) (*ds.GetProductDocumentsResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	exists, err := c.db.Querier().IsProductExists(ctx, req.ProductUid)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &ds.GetProductDocumentsResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}, nil
+	}
+
+	docs, err := c.db.Querier().GetProductDocuments(ctx, req.ProductUid)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ds.GetProductDocumentsResponse{
+		Documents: make([]ds.ProductDocument, 0, len(docs)),
+	}
+	for i := range docs {
+		resp.Documents = append(resp.Documents, fromDBProductDocument(&docs[i]))
+	}
+
+	return resp, nil
+}
+
+// DeleteProductDocument deletes the content only if nothing else refers to it.
+func (c *Client) DeleteProductDocument(req *ds.DeleteProductDocumentRequest) (*ds.DeleteProductDocumentResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	var unusedKey sql.NullString
+	err := c.db.ExecTx(blobTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditProductDocument, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		key, err := qtx.DeleteProductDocument(ctx, req.Uid)
+		if err != nil {
+			return err
+		}
+
+		unusedKey, err = releaseBlob(ctx, qtx, toNullString(key))
+		if err != nil {
+			return err
+		}
+
+		return audit(ctx, qtx, req.Audit, "DeleteProductDocument", ds.AuditProductDocument, req.Uid.String(), before)
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return &ds.DeleteProductDocumentResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}, nil
+	}
+
+	c.dropBlob(ctx, unusedKey)
+
+	return &ds.DeleteProductDocumentResponse{
+		Status: ds.Status{Message: ds.StatusOK},
+	}, nil
+}
+
+func fromDBProductDocument(doc *sqlc.ProductDocument) ds.ProductDocument {
+	return ds.ProductDocument{
+		Uid:       doc.Uid,
+		Title:     doc.Title,
+		Mime:      doc.Mime,
+		Size:      doc.Size,
+		Hash:      doc.Hash,
+		CreatedAt: doc.CreatedAt,
+	}
+}
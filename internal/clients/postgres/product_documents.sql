@@ -0,0 +1,26 @@
+-- name: AddProductDocument :one
+INSERT INTO product_documents (uid, product_id, title, storage_key, size, mime, hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (uid) DO NOTHING
+RETURNING uid;
+
+-- name: GetProductDocument :one
+SELECT *
+FROM product_documents pd
+WHERE pd.uid = $1;
+
+-- name: GetProductDocuments :many
+SELECT *
+FROM product_documents pd
+WHERE pd.product_id = $1
+ORDER BY pd.created_at, pd.uid;
+
+-- name: DeleteProductDocument :one
+DELETE FROM product_documents pd
+WHERE pd.uid = $1
+RETURNING pd.storage_key;
+
+-- name: DeleteProductDocuments :many
+DELETE FROM product_documents pd
+WHERE pd.product_id = $1
+RETURNING pd.storage_key;
@@ -0,0 +1,277 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"io"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/supports"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var testDocument = []byte("%PDF-1.7\n1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n%%EOF\n")
+
+func TestAddProductDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddProductDocument Ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		req := &ds.AddProductDocumentRequest{
+			Uid:        uid,
+			ProductUid: uuid.New(),
+			Title:      "Spec sheet",
+			Document:   bytes.NewReader(testDocument),
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().IsProductExists(gomock.Any(), req.ProductUid).Return(true, nil)
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded)
+		tc.querierMock.EXPECT().AddProductDocument(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, arg sqlc.AddProductDocumentParams) (uuid.UUID, error) {
+				sum := sha256.Sum256(testDocument)
+				require.Equal(t, req.ProductUid, arg.ProductID)
+				require.Equal(t, "Spec sheet", arg.Title)
+				require.Equal(t, int64(len(testDocument)), arg.Size)
+				require.Equal(t, hex.EncodeToString(sum[:]), arg.Hash)
+				require.Equal(t, "application/pdf", arg.Mime)
+				return arg.Uid, nil
+			})
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.AddProductDocument(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, &uid, resp.Uid)
+	})
+
+	t.Run("AddProductDocument product not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductDocumentRequest{
+			ProductUid: uuid.New(),
+			Document:   bytes.NewReader(testDocument),
+		}
+
+		var uploaded string
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, key string, r io.Reader) error {
+				uploaded = key
+				return consumeBlob(ctx, key, r)
+			})
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().IsProductExists(gomock.Any(), req.ProductUid).Return(false, nil)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, key string) error {
+				require.Equal(t, uploaded, key)
+				return nil
+			})
+
+		resp, err := tc.client.AddProductDocument(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("AddProductDocument rejected", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductDocumentRequest{
+			Document: io.MultiReader(bytes.NewReader(testDocument), iotest.ErrReader(supports.ErrRejected)),
+		}
+
+		// The short document is rejected while its head is sniffed, before it is stored.
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+
+		resp, err := tc.client.AddProductDocument(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusRejected, resp.GetStatus())
+	})
+
+	t.Run("AddProductDocument error on AddProductDocument", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductDocumentRequest{
+			ProductUid: uuid.New(),
+			Document:   bytes.NewReader(testDocument),
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.blobMock.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(consumeBlob)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().IsProductExists(gomock.Any(), req.ProductUid).Return(true, nil)
+		tc.querierMock.EXPECT().AcquireBlob(gomock.Any(), gomock.Any()).DoAndReturn(acquireUploaded)
+		tc.querierMock.EXPECT().AddProductDocument(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, errTest)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp, err := tc.client.AddProductDocument(req)
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetProductDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductDocument Ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		createdAt := time.Now()
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetProductDocument(gomock.Any(), uid).Return(sqlc.ProductDocument{
+			Uid:        uid,
+			StorageKey: "key",
+			Size:       int64(len(testDocument)),
+			Hash:       "hash",
+			CreatedAt:  createdAt,
+		}, nil)
+		tc.blobMock.EXPECT().Get(gomock.Any(), "key").Return(io.NopCloser(bytes.NewReader(testDocument)), nil)
+
+		resp, err := tc.client.GetProductDocument(&ds.GetProductDocumentRequest{Uid: uid})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, testDocument, readContent(t, resp.Content))
+
+		hash, modified := resp.Validators()
+		require.Equal(t, "hash", hash)
+		require.Equal(t, createdAt, modified)
+	})
+
+	t.Run("GetProductDocument not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetProductDocument(gomock.Any(), gomock.Any()).Return(sqlc.ProductDocument{}, sql.ErrNoRows)
+
+		resp, err := tc.client.GetProductDocument(&ds.GetProductDocumentRequest{Uid: uuid.New()})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("GetProductDocument error on Get", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetProductDocument(gomock.Any(), gomock.Any()).Return(sqlc.ProductDocument{StorageKey: "key"}, nil)
+		tc.blobMock.EXPECT().Get(gomock.Any(), "key").Return(nil, errTest)
+
+		resp, err := tc.client.GetProductDocument(&ds.GetProductDocumentRequest{Uid: uuid.New()})
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetProductDocuments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductDocuments Ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		productUid := uuid.New()
+		doc := sqlc.ProductDocument{Uid: uuid.New(), ProductID: productUid, Title: "Spec sheet", Mime: "application/pdf"}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock).Times(2)
+		tc.querierMock.EXPECT().IsProductExists(gomock.Any(), productUid).Return(true, nil)
+		tc.querierMock.EXPECT().GetProductDocuments(gomock.Any(), productUid).Return([]sqlc.ProductDocument{doc}, nil)
+
+		resp, err := tc.client.GetProductDocuments(&ds.GetProductDocumentsRequest{ProductUid: productUid})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, []ds.ProductDocument{fromDBProductDocument(&doc)}, resp.Documents)
+	})
+
+	t.Run("GetProductDocuments not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().IsProductExists(gomock.Any(), gomock.Any()).Return(false, nil)
+
+		resp, err := tc.client.GetProductDocuments(&ds.GetProductDocumentsRequest{ProductUid: uuid.New()})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+}
+
+func TestDeleteProductDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteProductDocument Ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().DeleteProductDocument(gomock.Any(), uid).Return("key", nil)
+		tc.querierMock.EXPECT().ReleaseBlob(gomock.Any(), "key").Return(int32(0), nil)
+		tc.querierMock.EXPECT().DeleteUnusedBlob(gomock.Any(), "key").Return(nil)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), "key").Return(nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.DeleteProductDocument(&ds.DeleteProductDocumentRequest{Uid: uid})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("DeleteProductDocument not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().DeleteProductDocument(gomock.Any(), gomock.Any()).Return("", sql.ErrNoRows)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.DeleteProductDocument(&ds.DeleteProductDocumentRequest{Uid: uuid.New()})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+}
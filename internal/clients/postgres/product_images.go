@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+
+	"github.com/google/uuid"
+)
+
+func (c *Client) AttachProductImage(req *ds.AttachProductImageRequest) (resp *ds.AttachProductImageResponse, err error) {
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		exists, err := qtx.IsProductExists(ctx, req.ProductUid)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			resp = &ds.AttachProductImageResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}
+			return nil
+		}
+
+		// The image is kept from the orphans sweep until the attachment is committed.
+		exists, err = qtx.KeepImage(ctx, req.ImageUid)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			resp = &ds.AttachProductImageResponse{
+				Status: ds.Status{Message: ds.StatusAttachProductImageWithNoImage},
+			}
+			return nil
+		}
+
+		before, err := snapshot(ctx, qtx, ds.AuditProductImages, req.ProductUid.String())
+		if err != nil {
+			return err
+		}
+
+		position, err := qtx.GetNextProductImagePosition(ctx, req.ProductUid)
+		if err != nil {
+			return err
+		}
+
+		if req.Primary {
+			if err = qtx.ClearProductPrimaryImage(ctx, req.ProductUid); err != nil {
+				return err
+			}
+		}
+
+		_, err = qtx.InsertProductImage(ctx, sqlc.InsertProductImageParams{
+			ProductID: req.ProductUid,
+			ImageID:   req.ImageUid,
+			Position:  position,
+			AltText:   req.AltText,
+			IsPrimary: req.Primary,
+		})
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			// The previous primary image may be already cleared, so the transaction must not be committed.
+			resp = &ds.AttachProductImageResponse{
+				Status: ds.Status{Message: ds.StatusAlreadyExists},
+			}
+			return errRollback
+		}
+
+		resp = &ds.AttachProductImageResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "AttachProductImage", ds.AuditProductImages, req.ProductUid.String(), before)
+	})
+	if errors.Is(err, errRollback) {
+		err = nil
+	}
+
+	return
+}
+
+func (c *Client) DetachProductImage(req *ds.DetachProductImageRequest) (resp *ds.DetachProductImageResponse, err error) {
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		images, err := qtx.GetProductImages(ctx, req.ProductUid)
+		if err != nil {
+			return err
+		}
+
+		if !containsProductImage(images, req.ImageUid) {
+			resp = &ds.DetachProductImageResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}
+			return nil
+		}
+
+		// A product always keeps at least one image to serve as the primary one.
+		if len(images) == 1 {
+			resp = &ds.DetachProductImageResponse{
+				Status: ds.Status{Message: ds.StatusDetachOnlyProductImage},
+			}
+			return nil
+		}
+
+		before, err := snapshot(ctx, qtx, ds.AuditProductImages, req.ProductUid.String())
+		if err != nil {
+			return err
+		}
+
+		wasPrimary, err := qtx.DeleteProductImage(ctx, sqlc.DeleteProductImageParams{
+			ProductID: req.ProductUid,
+			ImageID:   req.ImageUid,
+		})
+		if err != nil {
+			return err
+		}
+
+		if wasPrimary {
+			if err = qtx.PromoteFirstProductImage(ctx, req.ProductUid); err != nil {
+				return err
+			}
+		}
+
+		resp = &ds.DetachProductImageResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "DetachProductImage", ds.AuditProductImages, req.ProductUid.String(), before)
+	})
+
+	return
+}
+
+func (c *Client) ReorderProductImages(req *ds.ReorderProductImagesRequest) (resp *ds.ReorderProductImagesResponse, err error) {
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		images, err := qtx.GetProductImages(ctx, req.ProductUid)
+		if err != nil {
+			return err
+		}
+
+		if len(images) == 0 {
+			resp = &ds.ReorderProductImagesResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}
+			return nil
+		}
+
+		if !sameProductImages(images, req.ImageUids) ||
+			(req.PrimaryUid != nil && !containsProductImage(images, *req.PrimaryUid)) {
+			resp = &ds.ReorderProductImagesResponse{
+				Status: ds.Status{Message: ds.StatusProductImagesMismatch},
+			}
+			return nil
+		}
+
+		before, err := snapshot(ctx, qtx, ds.AuditProductImages, req.ProductUid.String())
+		if err != nil {
+			return err
+		}
+
+		for i, uid := range req.ImageUids {
+			_, err = qtx.SetProductImagePosition(ctx, sqlc.SetProductImagePositionParams{
+				Position:  int32(i),
+				ProductID: req.ProductUid,
+				ImageID:   uid,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if req.PrimaryUid != nil {
+			if err = qtx.ClearProductPrimaryImage(ctx, req.ProductUid); err != nil {
+				return err
+			}
+
+			_, err = qtx.SetProductPrimaryImage(ctx, sqlc.SetProductPrimaryImageParams{
+				ProductID: req.ProductUid,
+				ImageID:   *req.PrimaryUid,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		resp = &ds.ReorderProductImagesResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "ReorderProductImages", ds.AuditProductImages, req.ProductUid.String(), before)
+	})
+
+	return
+}
+
+func containsProductImage(images []sqlc.ProductImage, uid uuid.UUID) bool {
+	for i := range images {
+		if images[i].ImageID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// sameProductImages reports whether uids lists exactly the images attached to the product.
+func sameProductImages(images []sqlc.ProductImage, uids []uuid.UUID) bool {
+	if len(images) != len(uids) {
+		return false
+	}
+
+	for _, uid := range uids {
+		if !containsProductImage(images, uid) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func fromDBProductImage(img *sqlc.ProductImage) ds.ProductImage {
+	return ds.ProductImage{
+		ImageUid: img.ImageID,
+		Position: img.Position,
+		AltText:  img.AltText,
+		Primary:  img.IsPrimary,
+	}
+}
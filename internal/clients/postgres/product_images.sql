@@ -0,0 +1,54 @@
+-- name: InsertProductImage :one
+INSERT INTO product_images (product_id, image_id, position, alt_text, is_primary)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT DO NOTHING
+RETURNING image_id;
+
+-- name: GetProductImages :many
+SELECT *
+FROM product_images pi
+WHERE pi.product_id = $1
+ORDER BY pi.position, pi.image_id;
+
+-- name: GetImagesOfProducts :many
+SELECT *
+FROM product_images pi
+WHERE pi.product_id = ANY(sqlc.arg(product_ids)::uuid[])
+ORDER BY pi.product_id, pi.position, pi.image_id;
+
+-- name: GetNextProductImagePosition :one
+SELECT (COALESCE(MAX(pi.position), -1) + 1)::int AS position
+FROM product_images pi
+WHERE pi.product_id = $1;
+
+-- name: ClearProductPrimaryImage :exec
+UPDATE product_images
+SET is_primary = FALSE
+WHERE product_id = $1 AND is_primary;
+
+-- name: SetProductPrimaryImage :one
+UPDATE product_images
+SET is_primary = TRUE
+WHERE product_id = $1 AND image_id = $2
+RETURNING image_id;
+
+-- name: PromoteFirstProductImage :exec
+UPDATE product_images
+SET is_primary = TRUE
+WHERE product_id = $1 AND image_id = (
+    SELECT pi.image_id FROM product_images pi
+    WHERE pi.product_id = $1
+    ORDER BY pi.position, pi.image_id
+    LIMIT 1
+);
+
+-- name: SetProductImagePosition :one
+UPDATE product_images
+SET position = $1
+WHERE product_id = $2 AND image_id = $3
+RETURNING image_id;
+
+-- name: DeleteProductImage :one
+DELETE FROM product_images
+WHERE product_id = $1 AND image_id = $2
+RETURNING is_primary;
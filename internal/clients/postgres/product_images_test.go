@@ -0,0 +1,352 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachProductImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AttachProductImage ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AttachProductImageRequest{
+			ProductUid: uuid.New(),
+			ImageUid:   uuid.New(),
+			AltText:    "alt",
+			Primary:    true,
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsProductExists(gomock.Any(), req.ProductUid).Return(true, nil)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), req.ImageUid).Return(true, nil)
+		tc.querierMock.EXPECT().GetNextProductImagePosition(gomock.Any(), req.ProductUid).Return(int32(2), nil)
+		tc.querierMock.EXPECT().ClearProductPrimaryImage(gomock.Any(), req.ProductUid).Return(nil)
+		tc.querierMock.EXPECT().InsertProductImage(gomock.Any(), sqlc.InsertProductImageParams{
+			ProductID: req.ProductUid,
+			ImageID:   req.ImageUid,
+			Position:  2,
+			AltText:   req.AltText,
+			IsPrimary: true,
+		}).Return(req.ImageUid, nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.AttachProductImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusOK)
+	})
+
+	t.Run("AttachProductImage not found product", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AttachProductImageRequest{
+			ProductUid: uuid.New(),
+			ImageUid:   uuid.New(),
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsProductExists(gomock.Any(), req.ProductUid).Return(false, nil)
+
+		resp, err := tc.client.AttachProductImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
+	})
+
+	t.Run("AttachProductImage not exists image", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AttachProductImageRequest{
+			ProductUid: uuid.New(),
+			ImageUid:   uuid.New(),
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsProductExists(gomock.Any(), req.ProductUid).Return(true, nil)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), req.ImageUid).Return(false, nil)
+
+		resp, err := tc.client.AttachProductImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusAttachProductImageWithNoImage)
+	})
+
+	t.Run("AttachProductImage already attached", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AttachProductImageRequest{
+			ProductUid: uuid.New(),
+			ImageUid:   uuid.New(),
+			Primary:    true,
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			err := fn(tc.ctx, tc.querierMock)
+			require.ErrorIs(t, err, errRollback)
+			return err
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsProductExists(gomock.Any(), req.ProductUid).Return(true, nil)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), req.ImageUid).Return(true, nil)
+		tc.querierMock.EXPECT().GetNextProductImagePosition(gomock.Any(), req.ProductUid).Return(int32(1), nil)
+		tc.querierMock.EXPECT().ClearProductPrimaryImage(gomock.Any(), req.ProductUid).Return(nil)
+		tc.querierMock.EXPECT().InsertProductImage(gomock.Any(), gomock.Any()).Return(uuid.Nil, sql.ErrNoRows)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.AttachProductImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusAlreadyExists)
+	})
+
+	t.Run("AttachProductImage error on IsProductExists", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AttachProductImageRequest{
+			ProductUid: uuid.New(),
+			ImageUid:   uuid.New(),
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().IsProductExists(gomock.Any(), req.ProductUid).Return(false, errTest)
+
+		resp, err := tc.client.AttachProductImage(req)
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+}
+
+func TestDetachProductImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DetachProductImage primary ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.DetachProductImageRequest{
+			ProductUid: uuid.New(),
+			ImageUid:   uuid.New(),
+		}
+
+		images := []sqlc.ProductImage{
+			{ProductID: req.ProductUid, ImageID: req.ImageUid, Position: 0, IsPrimary: true},
+			{ProductID: req.ProductUid, ImageID: uuid.New(), Position: 1},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductImages(gomock.Any(), req.ProductUid).Return(images, nil)
+		tc.querierMock.EXPECT().DeleteProductImage(gomock.Any(), sqlc.DeleteProductImageParams{
+			ProductID: req.ProductUid,
+			ImageID:   req.ImageUid,
+		}).Return(true, nil)
+		tc.querierMock.EXPECT().PromoteFirstProductImage(gomock.Any(), req.ProductUid).Return(nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.DetachProductImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusOK)
+	})
+
+	t.Run("DetachProductImage only image", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.DetachProductImageRequest{
+			ProductUid: uuid.New(),
+			ImageUid:   uuid.New(),
+		}
+
+		images := []sqlc.ProductImage{
+			{ProductID: req.ProductUid, ImageID: req.ImageUid, IsPrimary: true},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductImages(gomock.Any(), req.ProductUid).Return(images, nil)
+
+		resp, err := tc.client.DetachProductImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusDetachOnlyProductImage)
+	})
+
+	t.Run("DetachProductImage not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.DetachProductImageRequest{
+			ProductUid: uuid.New(),
+			ImageUid:   uuid.New(),
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductImages(gomock.Any(), req.ProductUid).Return(nil, nil)
+
+		resp, err := tc.client.DetachProductImage(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
+	})
+}
+
+func TestReorderProductImages(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ReorderProductImages ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		productUid := uuid.New()
+		first, second := uuid.New(), uuid.New()
+
+		req := &ds.ReorderProductImagesRequest{
+			ProductUid: productUid,
+			ImageUids:  []uuid.UUID{second, first},
+			PrimaryUid: &second,
+		}
+
+		images := []sqlc.ProductImage{
+			{ProductID: productUid, ImageID: first, Position: 0, IsPrimary: true},
+			{ProductID: productUid, ImageID: second, Position: 1},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductImages(gomock.Any(), productUid).Return(images, nil)
+		tc.querierMock.EXPECT().SetProductImagePosition(gomock.Any(), sqlc.SetProductImagePositionParams{
+			Position: 0, ProductID: productUid, ImageID: second,
+		}).Return(second, nil)
+		tc.querierMock.EXPECT().SetProductImagePosition(gomock.Any(), sqlc.SetProductImagePositionParams{
+			Position: 1, ProductID: productUid, ImageID: first,
+		}).Return(first, nil)
+		tc.querierMock.EXPECT().ClearProductPrimaryImage(gomock.Any(), productUid).Return(nil)
+		tc.querierMock.EXPECT().SetProductPrimaryImage(gomock.Any(), sqlc.SetProductPrimaryImageParams{
+			ProductID: productUid,
+			ImageID:   second,
+		}).Return(second, nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.ReorderProductImages(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusOK)
+	})
+
+	t.Run("ReorderProductImages mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		productUid := uuid.New()
+
+		req := &ds.ReorderProductImagesRequest{
+			ProductUid: productUid,
+			ImageUids:  []uuid.UUID{uuid.New()},
+		}
+
+		images := []sqlc.ProductImage{
+			{ProductID: productUid, ImageID: uuid.New(), IsPrimary: true},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductImages(gomock.Any(), productUid).Return(images, nil)
+
+		resp, err := tc.client.ReorderProductImages(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusProductImagesMismatch)
+	})
+
+	t.Run("ReorderProductImages error on SetProductImagePosition", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		productUid := uuid.New()
+		imageUid := uuid.New()
+
+		req := &ds.ReorderProductImagesRequest{
+			ProductUid: productUid,
+			ImageUids:  []uuid.UUID{imageUid},
+		}
+
+		images := []sqlc.ProductImage{
+			{ProductID: productUid, ImageID: imageUid, IsPrimary: true},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductImages(gomock.Any(), productUid).Return(images, nil)
+		tc.querierMock.EXPECT().SetProductImagePosition(gomock.Any(), gomock.Any()).Return(uuid.Nil, errTest)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.ReorderProductImages(req)
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+	"shopapi/internal/supports"
+
+	"github.com/google/uuid"
+)
+
+func (c *Client) AddProductVariant(req *ds.AddProductVariantRequest) (resp *ds.AddProductVariantResponse, err error) {
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		rawOptions, err := qtx.GetProductOptions(ctx, req.ProductUid)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				resp = &ds.AddProductVariantResponse{
+					Status: ds.Status{Message: ds.StatusNotFound},
+				}
+				return nil
+			}
+			return err
+		}
+
+		var options []string
+		if err = json.Unmarshal(rawOptions, &options); err != nil {
+			return err
+		}
+
+		status, err := checkVariant(ctx, qtx, options, &req.ProductVariant)
+		if err != nil {
+			return err
+		}
+		if status != "" {
+			resp = &ds.AddProductVariantResponse{
+				Status: ds.Status{Message: status},
+			}
+			return nil
+		}
+
+		uid, err := insertVariant(ctx, qtx, req.ProductUid, &req.ProductVariant)
+		if err != nil {
+			if isUniqueViolation(err) {
+				// The sku or barcode is used by another product, the failed insert aborts the transaction.
+				resp = &ds.AddProductVariantResponse{
+					Status: ds.Status{Message: ds.StatusAlreadyExists},
+				}
+				return errRollback
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			resp = &ds.AddProductVariantResponse{
+				Status: ds.Status{Message: ds.StatusAlreadyExists},
+			}
+			return nil
+		}
+
+		resp = &ds.AddProductVariantResponse{Uid: &uid}
+
+		return audit(ctx, qtx, req.Audit, "AddProductVariant", ds.AuditProductVariant, uid.String(), nil)
+	})
+	if errors.Is(err, errRollback) {
+		err = nil
+	}
+
+	return
+}
+
+func (c *Client) DeleteProductVariant(req *ds.DeleteProductVariantRequest) (resp *ds.DeleteProductVariantResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditProductVariant, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		if _, err = qtx.DeleteProductVariant(ctx, req.Uid); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			resp = &ds.DeleteProductVariantResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}
+			return nil
+		}
+
+		resp = &ds.DeleteProductVariantResponse{Status: ds.Status{Message: ds.StatusOK}}
+		return audit(ctx, qtx, req.Audit, "DeleteProductVariant", ds.AuditProductVariant, req.Uid.String(), before)
+	})
+
+	return
+}
+
+// checkVariant returns a non-empty status if the variant can't be attached to a product with given option axes.
+func checkVariant(ctx context.Context, qtx IQuerier, axes []string, v *ds.ProductVariant) (string, error) {
+	if !matchesOptionAxes(axes, v.Options) {
+		return ds.StatusVariantOptionsMismatch, nil
+	}
+
+	if v.ImageUid == nil {
+		return "", nil
+	}
+
+	// The image row stays locked until the variant is committed, so the orphans sweep skips it.
+	exists, err := qtx.KeepImage(ctx, *v.ImageUid)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return ds.StatusAddVariantWithNoImage, nil
+	}
+
+	return "", nil
+}
+
+func insertVariant(ctx context.Context, qtx IQuerier, productUid uuid.UUID, v *ds.ProductVariant) (uuid.UUID, error) {
+	options := v.Options
+	if options == nil {
+		options = map[string]string{}
+	}
+
+	rawOptions, err := json.Marshal(options)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return qtx.InsertProductVariant(ctx, sqlc.InsertProductVariantParams{
+		Uid:            supports.GetUUIDIfEmpty(v.Uid),
+		ProductID:      productUid,
+		Sku:            v.Sku,
+		Options:        rawOptions,
+		Price:          v.Price.Minor(),
+		Currency:       v.Price.Currency(),
+		AvailableStock: v.AvaliableStocks,
+		ImageID:        toNullUUID(v.ImageUid),
+		Barcode:        toNullString(v.Barcode),
+	})
+}
+
+func matchesOptionAxes(axes []string, options map[string]string) bool {
+	if len(axes) != len(options) {
+		return false
+	}
+
+	for _, axis := range axes {
+		if _, ok := options[axis]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func fromDBProductVariant(v *sqlc.ProductVariant) (*ds.ProductVariant, error) {
+	variant := &ds.ProductVariant{
+		Uid:             v.Uid,
+		Sku:             v.Sku,
+		Barcode:         v.Barcode.String,
+		Price:           money.New(v.Price, v.Currency),
+		AvaliableStocks: v.AvailableStock,
+		ImageUid:        fromNullUUID(v.ImageID),
+	}
+
+	if err := json.Unmarshal(v.Options, &variant.Options); err != nil {
+		return nil, err
+	}
+
+	return variant, nil
+}
@@ -0,0 +1,36 @@
+-- name: InsertProductVariant :one
+INSERT INTO product_variants (uid, product_id, sku, options,
+    price, currency, available_stock, image_id, barcode)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT DO NOTHING
+RETURNING uid;
+
+-- name: GetProductVariants :many
+SELECT *
+FROM product_variants v
+WHERE v.product_id = $1
+ORDER BY v.sku;
+
+-- name: GetVariantsOfProducts :many
+SELECT *
+FROM product_variants v
+WHERE v.product_id = ANY(sqlc.arg(product_ids)::uuid[])
+ORDER BY v.product_id, v.sku;
+
+-- name: LockVariantStockForUpdate :one
+SELECT v.available_stock
+FROM product_variants v
+JOIN products p ON p.uid = v.product_id
+WHERE v.uid = $1 AND p.deleted_at IS NULL
+FOR UPDATE OF v;
+
+-- name: DecreaseVariantStock :one
+UPDATE product_variants
+SET available_stock = available_stock - sqlc.arg(amount)
+WHERE uid = sqlc.arg(uid)
+RETURNING available_stock;
+
+-- name: DeleteProductVariant :one
+DELETE FROM product_variants v
+WHERE v.uid = $1
+RETURNING v.uid;
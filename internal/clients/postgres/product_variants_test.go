@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddProductVariant(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddProductVariant ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductVariantRequest{
+			ProductUid: uuid.New(),
+			ProductVariant: ds.ProductVariant{
+				Sku:             "sku",
+				Options:         map[string]string{"size": "L", "colour": "red"},
+				Price:           money.New(29995, money.RUB),
+				AvaliableStocks: 10,
+			},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductOptions(gomock.Any(), req.ProductUid).Return(json.RawMessage(`["size","colour"]`), nil)
+		tc.querierMock.EXPECT().InsertProductVariant(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.AddProductVariant(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Uid)
+	})
+
+	t.Run("AddProductVariant sku of another product", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductVariantRequest{
+			ProductUid: uuid.New(),
+			ProductVariant: ds.ProductVariant{
+				Sku:     "BEAM-OAK",
+				Options: map[string]string{"size": "L"},
+				Price:   money.New(29995, money.RUB),
+			},
+		}
+
+		var txErr error
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ *sql.TxOptions, fn func(context.Context, IQuerier) error) error {
+				txErr = fn(tc.ctx, tc.querierMock)
+				return txErr
+			})
+		tc.querierMock.EXPECT().GetProductOptions(gomock.Any(), req.ProductUid).Return(json.RawMessage(`["size"]`), nil)
+		tc.querierMock.EXPECT().InsertProductVariant(gomock.Any(), gomock.Any()).Return(uuid.Nil, &pq.Error{Code: uniqueViolationCode})
+
+		resp, err := tc.client.AddProductVariant(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusAlreadyExists, resp.GetStatus())
+		require.ErrorIs(t, txErr, errRollback)
+	})
+
+	t.Run("AddProductVariant no product", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductVariantRequest{
+			ProductUid: uuid.New(),
+			ProductVariant: ds.ProductVariant{
+				Sku: "sku",
+			},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductOptions(gomock.Any(), req.ProductUid).Return(nil, sql.ErrNoRows)
+
+		resp, err := tc.client.AddProductVariant(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
+	})
+
+	t.Run("AddProductVariant options mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductVariantRequest{
+			ProductUid: uuid.New(),
+			ProductVariant: ds.ProductVariant{
+				Sku:     "sku",
+				Options: map[string]string{"size": "L", "weight": "2kg"},
+			},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductOptions(gomock.Any(), req.ProductUid).Return(json.RawMessage(`["size","colour"]`), nil)
+
+		resp, err := tc.client.AddProductVariant(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusVariantOptionsMismatch)
+	})
+
+	t.Run("AddProductVariant already exists", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductVariantRequest{
+			ProductUid: uuid.New(),
+			ProductVariant: ds.ProductVariant{
+				Sku: "sku",
+			},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductOptions(gomock.Any(), req.ProductUid).Return(json.RawMessage(`[]`), nil)
+		tc.querierMock.EXPECT().InsertProductVariant(gomock.Any(), gomock.Any()).Return(uuid.Nil, sql.ErrNoRows)
+
+		resp, err := tc.client.AddProductVariant(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusAlreadyExists)
+	})
+
+	t.Run("AddProductVariant error on InsertProductVariant", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductVariantRequest{
+			ProductUid: uuid.New(),
+			ProductVariant: ds.ProductVariant{
+				Sku: "sku",
+			},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductOptions(gomock.Any(), req.ProductUid).Return(json.RawMessage(`[]`), nil)
+		tc.querierMock.EXPECT().InsertProductVariant(gomock.Any(), gomock.Any()).Return(uuid.Nil, errTest)
+
+		resp, err := tc.client.AddProductVariant(req)
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestDeleteProductVariant(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteProductVariant ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.DeleteProductVariantRequest{Uid: uuid.New()}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().DeleteProductVariant(gomock.Any(), req.Uid).Return(req.Uid, nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.DeleteProductVariant(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusOK)
+	})
+
+	t.Run("DeleteProductVariant not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.DeleteProductVariantRequest{Uid: uuid.New()}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().DeleteProductVariant(gomock.Any(), req.Uid).Return(uuid.Nil, sql.ErrNoRows)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.DeleteProductVariant(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
+	})
+}
@@ -3,24 +3,36 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"shopapi/internal/clients/postgres/sqlc"
 	ds "shopapi/internal/datastruct"
 	"shopapi/internal/supports"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 func (c *Client) AddProduct(req *ds.AddProductRequest) (resp *ds.AddProductResponse, err error) {
 
 	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
-		exists, err := qtx.IsImageAndSupplierExists(ctx, sqlc.IsImageAndSupplierExistsParams{
-			ImageUid:    req.ImageUid,
-			SupplierUid: req.SupplierUid,
-		})
+		// Keeping the image clears its orphan mark and locks it until the commit, so the orphans sweep skips it.
+		exists, err := qtx.KeepImage(ctx, req.ImageUid)
 		if err != nil {
 			return err
 		}
 
+		// The foreign key checks the supplier exists, the share lock holds it out of the trash until the commit.
+		if exists {
+			_, err = qtx.ShareSupplier(ctx, req.SupplierUid)
+			if err != nil {
+				if !errors.Is(err, sql.ErrNoRows) {
+					return err
+				}
+				exists = false
+			}
+		}
+
 		if !exists {
 			resp = &ds.AddProductResponse{
 				Status: ds.Status{Message: ds.StatusAddProductWithNoImageOrSupplier},
@@ -28,19 +40,80 @@ func (c *Client) AddProduct(req *ds.AddProductRequest) (resp *ds.AddProductRespo
 			return nil
 		}
 
+		rawSchema, err := qtx.GetCategoryAttributeSchema(ctx, req.CategoryUid)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			resp = &ds.AddProductResponse{
+				Status: ds.Status{Message: ds.StatusAddProductWithNoCategory},
+			}
+			return nil
+		}
+
+		var schema []ds.AttributeDefinition
+		if err = json.Unmarshal(rawSchema, &schema); err != nil {
+			return err
+		}
+
+		if status := validateAttributes(schema, req.Attributes); status != "" {
+			resp = &ds.AddProductResponse{
+				Status: ds.Status{Message: status},
+			}
+			return nil
+		}
+
+		for i := range req.Variants {
+			status, err := checkVariant(ctx, qtx, req.Options, &req.Variants[i])
+			if err != nil {
+				return err
+			}
+			if status != "" {
+				resp = &ds.AddProductResponse{
+					Status: ds.Status{Message: status},
+				}
+				return nil
+			}
+		}
+
+		options, err := toDBOptions(req.Options)
+		if err != nil {
+			return err
+		}
+
+		attributes, err := toDBAttributes(req.Attributes)
+		if err != nil {
+			return err
+		}
+
 		lastUpdate := supports.GetNowIfZero(time.Time(req.LastUpdateDate))
 
 		uid, err := qtx.InsertProduct(ctx, sqlc.InsertProductParams{
 			Uid:            supports.GetUUIDIfEmpty(req.Uid),
 			Name:           req.Name,
-			Category:       req.Category,
-			Price:          toDBPrice(req.Price),
-			AvailableStock: req.AvaliableStocks,
+			CategoryID:     req.CategoryUid,
+			Options:        options,
 			LastUpdateDate: lastUpdate,
 			SupplierID:     req.SupplierUid,
-			ImageID:        req.ImageUid,
+			Attributes:     attributes,
+			Sku:            req.Sku,
+			Barcode:        toNullString(req.Barcode),
 		})
 		if err != nil {
+			if isForeignKeyViolation(err) {
+				// The failed insert aborts the transaction, so it can only be rolled back.
+				resp = &ds.AddProductResponse{
+					Status: ds.Status{Message: ds.StatusAddProductWithNoImageOrSupplier},
+				}
+				return errRollback
+			}
+			if isUniqueViolation(err) {
+				// The sku or barcode is used by a variant of another product.
+				resp = &ds.AddProductResponse{
+					Status: ds.Status{Message: ds.StatusAlreadyExists},
+				}
+				return errRollback
+			}
 			if !errors.Is(err, sql.ErrNoRows) {
 				return err
 			}
@@ -50,10 +123,36 @@ func (c *Client) AddProduct(req *ds.AddProductRequest) (resp *ds.AddProductRespo
 			return nil
 		}
 
+		_, err = qtx.InsertProductImage(ctx, sqlc.InsertProductImageParams{
+			ProductID: uid,
+			ImageID:   req.ImageUid,
+			IsPrimary: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i := range req.Variants {
+			_, err = insertVariant(ctx, qtx, uid, &req.Variants[i])
+			if err != nil {
+				if !errors.Is(err, sql.ErrNoRows) && !isUniqueViolation(err) {
+					return err
+				}
+				// The product itself is already inserted, so the transaction must not be committed.
+				resp = &ds.AddProductResponse{
+					Status: ds.Status{Message: ds.StatusAlreadyExists},
+				}
+				return errRollback
+			}
+		}
+
 		resp = &ds.AddProductResponse{Uid: &uid}
 
-		return nil
+		return audit(ctx, qtx, req.Audit, "AddProduct", ds.AuditProduct, uid.String(), nil)
 	})
+	if errors.Is(err, errRollback) {
+		err = nil
+	}
 
 	return
 }
@@ -61,7 +160,7 @@ func (c *Client) AddProduct(req *ds.AddProductRequest) (resp *ds.AddProductRespo
 func (c *Client) DecreaseProducts(req *ds.DecreaseProductsRequest) (resp *ds.DecreaseProductsResponse, err error) {
 
 	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
-		left, err := qtx.LockStockForUpdate(ctx, req.Uid)
+		left, err := qtx.LockVariantStockForUpdate(ctx, req.VariantUid)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				resp = &ds.DecreaseProductsResponse{
@@ -81,9 +180,14 @@ func (c *Client) DecreaseProducts(req *ds.DecreaseProductsRequest) (resp *ds.Dec
 			return nil
 		}
 
-		left, err = qtx.DecreaseProduct(ctx, sqlc.DecreaseProductParams{
+		before, err := snapshot(ctx, qtx, ds.AuditProductVariant, req.VariantUid.String())
+		if err != nil {
+			return err
+		}
+
+		left, err = qtx.DecreaseVariantStock(ctx, sqlc.DecreaseVariantStockParams{
 			Amount: req.Amount,
-			Uid:    req.Uid,
+			Uid:    req.VariantUid,
 		})
 		if err != nil {
 			return err
@@ -93,7 +197,7 @@ func (c *Client) DecreaseProducts(req *ds.DecreaseProductsRequest) (resp *ds.Dec
 			Left: &left,
 		}
 
-		return nil
+		return audit(ctx, qtx, req.Audit, "DecreaseProducts", ds.AuditProductVariant, req.VariantUid.String(), before)
 	})
 
 	return
@@ -103,7 +207,39 @@ func (c *Client) GetProduct(req *ds.GetProductRequest) (*ds.GetProductResponse,
 	ctx, cancel := c.db.CtxWithCancel()
 	defer cancel()
 
-	res, err := c.db.Querier().GetProduct(ctx, req.Uid)
+	if req.AsOf != nil {
+		return c.getProductAsOf(ctx, req)
+	}
+
+	res, err := c.db.Querier().GetProduct(ctx, sqlc.GetProductParams{
+		Uid:            req.Uid,
+		IncludeDeleted: req.IncludeDeleted,
+	})
+
+	return c.getProductResponse(ctx, req.Currency, &res, err)
+}
+
+func (c *Client) GetProductBySku(req *ds.GetProductBySkuRequest) (*ds.GetProductResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	res, err := c.db.Querier().GetProductBySku(ctx, req.Sku)
+
+	return c.getProductResponse(ctx, req.Currency, &res, err)
+}
+
+func (c *Client) GetProductByBarcode(req *ds.GetProductByBarcodeRequest) (*ds.GetProductResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	res, err := c.db.Querier().GetProductByBarcode(ctx, toNullString(req.Barcode))
+
+	return c.getProductResponse(ctx, req.Currency, &res, err)
+}
+
+// getProductResponse completes a single product lookup result with the product variants and their current prices
+// in the currency, the ones they are set in if it's empty.
+func (c *Client) getProductResponse(ctx context.Context, currency string, res *sqlc.Product, err error) (*ds.GetProductResponse, error) {
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return &ds.GetProductResponse{
@@ -114,17 +250,37 @@ func (c *Client) GetProduct(req *ds.GetProductRequest) (*ds.GetProductResponse,
 		}
 	}
 
+	variants, err := c.db.Querier().GetProductVariants(ctx, res.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := c.db.Querier().GetProductImages(ctx, res.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := fromDBProduct(res, variants, images)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = applyEffectivePrices(ctx, c.db.Querier(), nil, product); err != nil {
+		return nil, err
+	}
+
+	status, err := convertPrices(ctx, c.db.Querier(), currency, product)
+	if err != nil {
+		return nil, err
+	}
+	if status != "" {
+		return &ds.GetProductResponse{
+			Status: ds.Status{Message: status},
+		}, nil
+	}
+
 	return &ds.GetProductResponse{
-		Product: &ds.Product{
-			Uid:             res.Uid,
-			SupplierUid:     res.SupplierID,
-			ImageUid:        res.ImageID,
-			LastUpdateDate:  ds.DateOnly(res.LastUpdateDate),
-			Name:            res.Name,
-			Category:        res.Category,
-			Price:           fromDBPrice(res.Price),
-			AvaliableStocks: res.AvailableStock,
-		},
+		Product: product,
 	}, nil
 }
 
@@ -132,57 +288,274 @@ func (c *Client) GetProducts(req *ds.GetProductsRequest) (*ds.GetProductsRespons
 	ctx, cancel := c.db.CtxWithCancel()
 	defer cancel()
 
-	var err error
+	categoryID := uuid.NullUUID{UUID: req.CategoryUid, Valid: req.CategoryUid != uuid.Nil}
+
+	var types map[string][]string
+	if len(req.Attributes) != 0 {
+		rows, err := c.db.Querier().GetAttributeTypes(ctx, sqlc.GetAttributeTypesParams{
+			CategoryID: categoryID,
+			Names:      attributeNames(req.Attributes),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		types = make(map[string][]string, len(rows))
+		for _, r := range rows {
+			types[r.Name] = append(types[r.Name], r.Type)
+		}
+	}
+
+	attributes, err := attributesFilter(req.Attributes, types)
+	if err != nil {
+		return nil, err
+	}
+
 	var products []sqlc.Product
 	if req.Limit == 0 && req.Offset == 0 {
-		products, err = c.db.Querier().GetAllProducts(ctx)
+		products, err = c.db.Querier().GetAllProducts(ctx, sqlc.GetAllProductsParams{
+			CategoryID:     categoryID,
+			Attributes:     attributes,
+			IncludeDeleted: req.IncludeDeleted,
+		})
 		if err != nil {
 			return nil, err
 		}
 	} else {
 		products, err = c.db.Querier().GetProductsPage(ctx, sqlc.GetProductsPageParams{
-			Offset: int32(req.Offset),
-			Limit:  int32(req.Limit),
+			CategoryID:     categoryID,
+			Attributes:     attributes,
+			IncludeDeleted: req.IncludeDeleted,
+			PageOffset:     int32(req.Offset),
+			PageLimit:      int32(req.Limit),
 		})
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	uids := make([]uuid.UUID, len(products))
+	for i := range products {
+		uids[i] = products[i].Uid
+	}
+
+	variants, err := c.db.Querier().GetVariantsOfProducts(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	variantsByProduct := make(map[uuid.UUID][]sqlc.ProductVariant, len(products))
+	for _, v := range variants {
+		variantsByProduct[v.ProductID] = append(variantsByProduct[v.ProductID], v)
+	}
+
+	images, err := c.db.Querier().GetImagesOfProducts(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	imagesByProduct := make(map[uuid.UUID][]sqlc.ProductImage, len(products))
+	for _, img := range images {
+		imagesByProduct[img.ProductID] = append(imagesByProduct[img.ProductID], img)
+	}
+
 	resp := &ds.GetProductsResponse{
 		Products: make([]ds.Product, len(products)),
 	}
 	for i := range products {
 		p := &products[i]
-		resp.Products[i] = ds.Product{
-			Uid:             p.Uid,
-			SupplierUid:     p.SupplierID,
-			ImageUid:        p.ImageID,
-			LastUpdateDate:  ds.DateOnly(p.LastUpdateDate),
-			Name:            p.Name,
-			Category:        p.Category,
-			Price:           fromDBPrice(p.Price),
-			AvaliableStocks: p.AvailableStock,
+		product, err := fromDBProduct(p, variantsByProduct[p.Uid], imagesByProduct[p.Uid])
+		if err != nil {
+			return nil, err
 		}
+		resp.Products[i] = *product
+	}
+
+	page := make([]*ds.Product, len(resp.Products))
+	for i := range resp.Products {
+		page[i] = &resp.Products[i]
+	}
+	if err = applyEffectivePrices(ctx, c.db.Querier(), nil, page...); err != nil {
+		return nil, err
+	}
+
+	status, err := convertPrices(ctx, c.db.Querier(), req.Currency, page...)
+	if err != nil {
+		return nil, err
+	}
+	if status != "" {
+		return &ds.GetProductsResponse{
+			Status: ds.Status{Message: status},
+		}, nil
 	}
 
 	return resp, nil
 }
 
-func (c *Client) DeleteProduct(req *ds.DeleteProductRequest) (*ds.DeleteProductResponse, error) {
-	ctx, cancel := c.db.CtxWithCancel()
-	defer cancel()
+func (c *Client) UpdateProductAttributes(req *ds.UpdateProductAttributesRequest) (resp *ds.UpdateProductAttributesResponse, err error) {
 
-	_, err := c.db.Querier().DeleteProduct(ctx, req.Uid)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return &ds.DeleteProductResponse{
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		rawSchema, err := qtx.GetProductAttributeSchema(ctx, req.Uid)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				resp = &ds.UpdateProductAttributesResponse{
+					Status: ds.Status{Message: ds.StatusNotFound},
+				}
+				return nil
+			}
+			return err
+		}
+
+		var schema []ds.AttributeDefinition
+		if err = json.Unmarshal(rawSchema, &schema); err != nil {
+			return err
+		}
+
+		if status := validateAttributes(schema, req.Attributes); status != "" {
+			resp = &ds.UpdateProductAttributesResponse{
+				Status: ds.Status{Message: status},
+			}
+			return nil
+		}
+
+		attributes, err := toDBAttributes(req.Attributes)
+		if err != nil {
+			return err
+		}
+
+		before, err := snapshot(ctx, qtx, ds.AuditProduct, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		_, err = qtx.UpdateProductAttributes(ctx, sqlc.UpdateProductAttributesParams{
+			Attributes:     attributes,
+			LastUpdateDate: time.Now(),
+			Uid:            req.Uid,
+		})
+		if err != nil {
+			return err
+		}
+
+		resp = &ds.UpdateProductAttributesResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "UpdateProductAttributes", ds.AuditProduct, req.Uid.String(), before)
+	})
+
+	return
+}
+
+// DeleteProduct moves the product to the trash, its variants, images and documents are kept until the purge.
+func (c *Client) DeleteProduct(req *ds.DeleteProductRequest) (resp *ds.DeleteProductResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditProduct, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		if _, err = qtx.DeleteProduct(ctx, req.Uid); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			resp = &ds.DeleteProductResponse{
 				Status: ds.Status{Message: ds.StatusNotFound},
-			}, nil
-		} else {
+			}
+			return nil
+		}
+
+		resp = &ds.DeleteProductResponse{Status: ds.Status{Message: ds.StatusOK}}
+		return audit(ctx, qtx, req.Audit, "DeleteProduct", ds.AuditProduct, req.Uid.String(), before)
+	})
+
+	return
+}
+
+// RestoreProduct takes the product out of the trash, restoring a product not deleted succeeds as well.
+// A product whose supplier or image is in the trash can't be restored before them.
+func (c *Client) RestoreProduct(req *ds.RestoreProductRequest) (resp *ds.RestoreProductResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		hasDeleted, err := qtx.HasDeletedReferences(ctx, req.Uid)
+		if err != nil {
+			return err
+		}
+
+		if hasDeleted {
+			resp = &ds.RestoreProductResponse{
+				Status: ds.Status{Message: ds.StatusRestoreWithDeletedReference},
+			}
+			return nil
+		}
+
+		before, err := snapshot(ctx, qtx, ds.AuditProduct, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		if _, err = qtx.RestoreProduct(ctx, req.Uid); err != nil {
+			return err
+		}
+
+		resp = &ds.RestoreProductResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "RestoreProduct", ds.AuditProduct, req.Uid.String(), before)
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
 			return nil, err
 		}
+		return &ds.RestoreProductResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}, nil
 	}
 
-	return &ds.DeleteProductResponse{Status: ds.Status{Message: ds.StatusOK}}, nil
+	return
+}
+
+func fromDBProduct(p *sqlc.Product, variants []sqlc.ProductVariant, images []sqlc.ProductImage) (*ds.Product, error) {
+	product := &ds.Product{
+		Uid:            p.Uid,
+		SupplierUid:    p.SupplierID,
+		LastUpdateDate: ds.DateOnly(p.LastUpdateDate),
+		Name:           p.Name,
+		Sku:            p.Sku,
+		Barcode:        p.Barcode.String,
+		CategoryUid:    p.CategoryID,
+		Variants:       make([]ds.ProductVariant, len(variants)),
+		Images:         make([]ds.ProductImage, len(images)),
+		DeletedAt:      fromNullTime(p.DeletedAt),
+	}
+
+	for i := range images {
+		product.Images[i] = fromDBProductImage(&images[i])
+		if images[i].IsPrimary {
+			product.ImageUid = images[i].ImageID
+		}
+	}
+
+	if err := json.Unmarshal(p.Options, &product.Options); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(p.Attributes, &product.Attributes); err != nil {
+		return nil, err
+	}
+
+	for i := range variants {
+		variant, err := fromDBProductVariant(&variants[i])
+		if err != nil {
+			return nil, err
+		}
+		product.Variants[i] = *variant
+	}
+
+	return product, nil
+}
+
+func toDBOptions(options []string) (json.RawMessage, error) {
+	if options == nil {
+		options = []string{}
+	}
+	return json.Marshal(options)
 }
@@ -1,48 +1,112 @@
 -- name: InsertProduct :one
-INSERT INTO products (uid, name, category, price,
-    available_stock, last_update_date, supplier_id, image_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-ON CONFLICT (uid)
+INSERT INTO products (uid, name, category_id, options,
+    last_update_date, supplier_id, attributes, sku, barcode)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT
 DO NOTHING
 RETURNING uid;
 
--- name: LockStockForUpdate :one
-SELECT available_stock
-FROM products
-WHERE uid = $1
-FOR UPDATE;
+-- name: IsProductExists :one
+SELECT EXISTS(SELECT 1 FROM products p WHERE p.uid = $1 AND p.deleted_at IS NULL)::bool AS is_exists;
 
--- name: DecreaseProduct :one
+-- name: GetProductOptions :one
+SELECT p.options
+FROM products p
+WHERE p.uid = $1 AND p.deleted_at IS NULL;
+
+-- name: GetProductAttributeSchema :one
+SELECT c.attribute_schema
+FROM products p
+JOIN categories c ON c.uid = p.category_id
+WHERE p.uid = $1 AND p.deleted_at IS NULL;
+
+-- name: UpdateProductAttributes :one
 UPDATE products
-SET available_stock = available_stock - sqlc.arg(amount)
-WHERE uid = sqlc.arg(uid)
-RETURNING available_stock;
+SET attributes = $1, last_update_date = $2
+WHERE uid = $3 AND deleted_at IS NULL
+RETURNING uid;
 
 -- name: GetProduct :one
 SELECT *
 FROM products p
-WHERE p.uid = $1;
+WHERE p.uid = sqlc.arg(uid) AND (sqlc.arg(include_deleted)::bool OR p.deleted_at IS NULL);
+
+-- name: GetProductBySku :one
+SELECT p.*
+FROM products p
+WHERE (p.sku = sqlc.arg(sku)
+    OR p.uid = (SELECT v.product_id FROM product_variants v WHERE v.sku = sqlc.arg(sku)))
+    AND p.deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetProductByBarcode :one
+SELECT p.*
+FROM products p
+WHERE (p.barcode = sqlc.arg(barcode)
+    OR p.uid = (SELECT v.product_id FROM product_variants v WHERE v.barcode = sqlc.arg(barcode)))
+    AND p.deleted_at IS NULL
+LIMIT 1;
 
 -- name: GetAllProducts :many
-SELECT *
+WITH RECURSIVE category_tree AS (
+    SELECT c.uid FROM categories c WHERE c.uid = sqlc.narg(category_id)::uuid
+    UNION ALL
+    SELECT c.uid FROM categories c JOIN category_tree ct ON c.parent_id = ct.uid
+)
+SELECT p.*
 FROM products p
+WHERE (sqlc.narg(category_id)::uuid IS NULL OR p.category_id IN (SELECT uid FROM category_tree))
+    AND p.attributes @> ANY(sqlc.arg(attributes)::jsonb[])
+    AND (sqlc.arg(include_deleted)::bool OR p.deleted_at IS NULL)
 ORDER BY p.uid;
 
 -- name: GetProductsPage :many
-SELECT *
+WITH RECURSIVE category_tree AS (
+    SELECT c.uid FROM categories c WHERE c.uid = sqlc.narg(category_id)::uuid
+    UNION ALL
+    SELECT c.uid FROM categories c JOIN category_tree ct ON c.parent_id = ct.uid
+)
+SELECT p.*
 FROM products p
+WHERE (sqlc.narg(category_id)::uuid IS NULL OR p.category_id IN (SELECT uid FROM category_tree))
+    AND p.attributes @> ANY(sqlc.arg(attributes)::jsonb[])
+    AND (sqlc.arg(include_deleted)::bool OR p.deleted_at IS NULL)
 ORDER BY p.uid
-OFFSET $1
-LIMIT $2;
+OFFSET sqlc.arg(page_offset)
+LIMIT sqlc.arg(page_limit);
 
 -- name: DeleteProduct :one
-DELETE FROM products p
+UPDATE products p
+SET deleted_at = now()
+WHERE p.uid = $1 AND p.deleted_at IS NULL
+RETURNING p.uid;
+
+-- name: RestoreProduct :one
+UPDATE products p
+SET deleted_at = NULL
 WHERE p.uid = $1
 RETURNING p.uid;
 
--- name: IsImageAndSupplierExists :one
+-- name: HasDeletedReferences :one
 SELECT (
-    EXISTS(SELECT 1 FROM images i WHERE i.uid = sqlc.arg(image_uid))
-    AND
-    EXISTS(SELECT 1 FROM suppliers s WHERE s.uid = sqlc.arg(supplier_uid))
-)::bool AS is_exists;
\ No newline at end of file
+    EXISTS(SELECT 1 FROM suppliers s WHERE s.uid = p.supplier_id AND s.deleted_at IS NOT NULL)
+    OR EXISTS(SELECT 1 FROM product_images pi JOIN images i ON i.uid = pi.image_id
+        WHERE pi.product_id = p.uid AND i.deleted_at IS NOT NULL)
+    OR EXISTS(SELECT 1 FROM product_variants pv JOIN images i ON i.uid = pv.image_id
+        WHERE pv.product_id = p.uid AND i.deleted_at IS NOT NULL)
+)::bool AS has_deleted
+FROM products p
+WHERE p.uid = $1;
+
+-- name: GetPurgeableProducts :many
+SELECT p.uid
+FROM products p
+WHERE p.deleted_at <= sqlc.arg(deleted_before)::timestamptz
+ORDER BY p.deleted_at, p.uid
+LIMIT sqlc.arg(batch)
+FOR UPDATE SKIP LOCKED;
+
+-- name: PurgeProduct :one
+DELETE FROM products p
+WHERE p.uid = $1
+RETURNING p.uid;
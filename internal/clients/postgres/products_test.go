@@ -3,13 +3,16 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"shopapi/internal/clients/postgres/sqlc"
 	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,14 +27,13 @@ func TestAddProduct(t *testing.T) {
 		uid := uuid.New()
 		req := &ds.AddProductRequest{
 			Product: ds.Product{
-				Uid:             uid,
-				SupplierUid:     uuid.New(),
-				ImageUid:        uuid.New(),
-				LastUpdateDate:  ds.DateOnly(ds.DateOnlyFromString("10.12.2020")),
-				Name:            "Name",
-				Category:        "Category",
-				Price:           299.95,
-				AvaliableStocks: 123,
+				Uid:            uid,
+				SupplierUid:    uuid.New(),
+				ImageUid:       uuid.New(),
+				LastUpdateDate: ds.DateOnly(ds.DateOnlyFromString("10.12.2020")),
+				Name:           "Name",
+				CategoryUid:    uuid.New(),
+				Variants:       []ds.ProductVariant{{Sku: "sku", Price: money.New(29995, money.RUB), AvaliableStocks: 123}},
 			},
 		}
 
@@ -40,15 +42,24 @@ func TestAddProduct(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().IsImageAndSupplierExists(gomock.Any(), gomock.Any()).Return(true, nil)
-		tc.querierMock.EXPECT().InsertProduct(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), gomock.Any()).Return(true, nil)
+		tc.querierMock.EXPECT().ShareSupplier(gomock.Any(), req.SupplierUid).Return(req.SupplierUid, nil)
+		tc.querierMock.EXPECT().GetCategoryAttributeSchema(gomock.Any(), gomock.Any()).Return(json.RawMessage(`[]`), nil)
+		tc.querierMock.EXPECT().InsertProduct(gomock.Any(), gomock.Any()).Return(uid, nil)
+		tc.querierMock.EXPECT().InsertProductImage(gomock.Any(), sqlc.InsertProductImageParams{
+			ProductID: uid,
+			ImageID:   req.ImageUid,
+			IsPrimary: true,
+		}).Return(req.ImageUid, nil)
+		tc.querierMock.EXPECT().InsertProductVariant(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.AddProduct(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
 	})
 
-	t.Run("AddProduct false on IsImageAndSupplierExists", func(t *testing.T) {
+	t.Run("AddProduct false on KeepImage", func(t *testing.T) {
 		t.Parallel()
 
 		tc := NewTestClient(t)
@@ -56,14 +67,13 @@ func TestAddProduct(t *testing.T) {
 		uid := uuid.New()
 		req := &ds.AddProductRequest{
 			Product: ds.Product{
-				Uid:             uid,
-				SupplierUid:     uuid.New(),
-				ImageUid:        uuid.New(),
-				LastUpdateDate:  ds.DateOnly(ds.DateOnlyFromString("10.12.2020")),
-				Name:            "Name",
-				Category:        "Category",
-				Price:           299.95,
-				AvaliableStocks: 123,
+				Uid:            uid,
+				SupplierUid:    uuid.New(),
+				ImageUid:       uuid.New(),
+				LastUpdateDate: ds.DateOnly(ds.DateOnlyFromString("10.12.2020")),
+				Name:           "Name",
+				CategoryUid:    uuid.New(),
+				Variants:       []ds.ProductVariant{{Sku: "sku", Price: money.New(29995, money.RUB), AvaliableStocks: 123}},
 			},
 		}
 
@@ -72,7 +82,7 @@ func TestAddProduct(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().IsImageAndSupplierExists(gomock.Any(), gomock.Any()).Return(false, nil)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), gomock.Any()).Return(false, nil)
 
 		resp, err := tc.client.AddProduct(req)
 		require.Nil(t, err)
@@ -80,7 +90,7 @@ func TestAddProduct(t *testing.T) {
 		require.Equal(t, resp.GetStatus(), ds.StatusAddProductWithNoImageOrSupplier)
 	})
 
-	t.Run("AddProduct error on IsImageAndSupplierExists", func(t *testing.T) {
+	t.Run("AddProduct error on KeepImage", func(t *testing.T) {
 		t.Parallel()
 
 		tc := NewTestClient(t)
@@ -88,14 +98,13 @@ func TestAddProduct(t *testing.T) {
 		uid := uuid.New()
 		req := &ds.AddProductRequest{
 			Product: ds.Product{
-				Uid:             uid,
-				SupplierUid:     uuid.New(),
-				ImageUid:        uuid.New(),
-				LastUpdateDate:  ds.DateOnly(ds.DateOnlyFromString("10.12.2020")),
-				Name:            "Name",
-				Category:        "Category",
-				Price:           299.95,
-				AvaliableStocks: 123,
+				Uid:            uid,
+				SupplierUid:    uuid.New(),
+				ImageUid:       uuid.New(),
+				LastUpdateDate: ds.DateOnly(ds.DateOnlyFromString("10.12.2020")),
+				Name:           "Name",
+				CategoryUid:    uuid.New(),
+				Variants:       []ds.ProductVariant{{Sku: "sku", Price: money.New(29995, money.RUB), AvaliableStocks: 123}},
 			},
 		}
 
@@ -104,7 +113,7 @@ func TestAddProduct(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().IsImageAndSupplierExists(gomock.Any(), gomock.Any()).Return(false, errTest)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), gomock.Any()).Return(false, errTest)
 
 		resp, err := tc.client.AddProduct(req)
 		require.NotNil(t, err)
@@ -119,14 +128,13 @@ func TestAddProduct(t *testing.T) {
 		uid := uuid.New()
 		req := &ds.AddProductRequest{
 			Product: ds.Product{
-				Uid:             uid,
-				SupplierUid:     uuid.New(),
-				ImageUid:        uuid.New(),
-				LastUpdateDate:  ds.DateOnly(ds.DateOnlyFromString("10.12.2020")),
-				Name:            "Name",
-				Category:        "Category",
-				Price:           299.95,
-				AvaliableStocks: 123,
+				Uid:            uid,
+				SupplierUid:    uuid.New(),
+				ImageUid:       uuid.New(),
+				LastUpdateDate: ds.DateOnly(ds.DateOnlyFromString("10.12.2020")),
+				Name:           "Name",
+				CategoryUid:    uuid.New(),
+				Variants:       []ds.ProductVariant{{Sku: "sku", Price: money.New(29995, money.RUB), AvaliableStocks: 123}},
 			},
 		}
 
@@ -135,13 +143,49 @@ func TestAddProduct(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().IsImageAndSupplierExists(gomock.Any(), gomock.Any()).Return(true, nil)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), gomock.Any()).Return(true, nil)
+		tc.querierMock.EXPECT().ShareSupplier(gomock.Any(), req.SupplierUid).Return(req.SupplierUid, nil)
+		tc.querierMock.EXPECT().GetCategoryAttributeSchema(gomock.Any(), gomock.Any()).Return(json.RawMessage(`[]`), nil)
 		tc.querierMock.EXPECT().InsertProduct(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, errTest)
 
 		resp, err := tc.client.AddProduct(req)
 		require.NotNil(t, err)
 		require.Nil(t, resp)
 	})
+
+	t.Run("AddProduct supplier not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductRequest{
+			Product: ds.Product{
+				Uid:            uuid.New(),
+				SupplierUid:    uuid.New(),
+				ImageUid:       uuid.New(),
+				LastUpdateDate: ds.DateOnly(ds.DateOnlyFromString("10.12.2020")),
+				Name:           "Name",
+				CategoryUid:    uuid.New(),
+			},
+		}
+
+		var txErr error
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ *sql.TxOptions, fn func(context.Context, IQuerier) error) error {
+				txErr = fn(tc.ctx, tc.querierMock)
+				return txErr
+			})
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), req.ImageUid).Return(true, nil)
+		tc.querierMock.EXPECT().ShareSupplier(gomock.Any(), req.SupplierUid).Return(req.SupplierUid, nil)
+		tc.querierMock.EXPECT().GetCategoryAttributeSchema(gomock.Any(), gomock.Any()).Return(json.RawMessage(`[]`), nil)
+		tc.querierMock.EXPECT().InsertProduct(gomock.Any(), gomock.Any()).Return(uuid.Nil, &pq.Error{Code: foreignKeyViolationCode})
+
+		resp, err := tc.client.AddProduct(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusAddProductWithNoImageOrSupplier, resp.GetStatus())
+		require.ErrorIs(t, txErr, errRollback)
+	})
 }
 
 func TestDecreaseProducts(t *testing.T) {
@@ -154,8 +198,8 @@ func TestDecreaseProducts(t *testing.T) {
 
 		uid := uuid.New()
 		req := &ds.DecreaseProductsRequest{
-			Uid:    uid,
-			Amount: 10,
+			VariantUid: uid,
+			Amount:     10,
 		}
 
 		left := int64(20)
@@ -166,8 +210,10 @@ func TestDecreaseProducts(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().LockStockForUpdate(gomock.Any(), gomock.Any()).Return(left, nil)
-		tc.querierMock.EXPECT().DecreaseProduct(gomock.Any(), gomock.Any()).Return(shouldLeft, nil)
+		tc.querierMock.EXPECT().LockVariantStockForUpdate(gomock.Any(), gomock.Any()).Return(left, nil)
+		tc.querierMock.EXPECT().DecreaseVariantStock(gomock.Any(), gomock.Any()).Return(shouldLeft, nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.DecreaseProducts(req)
 		require.Nil(t, err)
@@ -182,8 +228,8 @@ func TestDecreaseProducts(t *testing.T) {
 
 		uid := uuid.New()
 		req := &ds.DecreaseProductsRequest{
-			Uid:    uid,
-			Amount: 10,
+			VariantUid: uid,
+			Amount:     10,
 		}
 
 		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
@@ -191,7 +237,7 @@ func TestDecreaseProducts(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().LockStockForUpdate(gomock.Any(), gomock.Any()).Return(int64(0), sql.ErrNoRows)
+		tc.querierMock.EXPECT().LockVariantStockForUpdate(gomock.Any(), gomock.Any()).Return(int64(0), sql.ErrNoRows)
 
 		resp, err := tc.client.DecreaseProducts(req)
 		require.Nil(t, err)
@@ -199,15 +245,15 @@ func TestDecreaseProducts(t *testing.T) {
 		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
 	})
 
-	t.Run("DecreaseProducts error on LockStockForUpdate", func(t *testing.T) {
+	t.Run("DecreaseProducts error on LockVariantStockForUpdate", func(t *testing.T) {
 		t.Parallel()
 
 		tc := NewTestClient(t)
 
 		uid := uuid.New()
 		req := &ds.DecreaseProductsRequest{
-			Uid:    uid,
-			Amount: 10,
+			VariantUid: uid,
+			Amount:     10,
 		}
 
 		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
@@ -215,7 +261,7 @@ func TestDecreaseProducts(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().LockStockForUpdate(gomock.Any(), gomock.Any()).Return(int64(0), errTest)
+		tc.querierMock.EXPECT().LockVariantStockForUpdate(gomock.Any(), gomock.Any()).Return(int64(0), errTest)
 
 		resp, err := tc.client.DecreaseProducts(req)
 		require.NotNil(t, err)
@@ -229,8 +275,8 @@ func TestDecreaseProducts(t *testing.T) {
 
 		uid := uuid.New()
 		req := &ds.DecreaseProductsRequest{
-			Uid:    uid,
-			Amount: 30,
+			VariantUid: uid,
+			Amount:     30,
 		}
 
 		left := int64(20)
@@ -241,7 +287,7 @@ func TestDecreaseProducts(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().LockStockForUpdate(gomock.Any(), gomock.Any()).Return(left, nil)
+		tc.querierMock.EXPECT().LockVariantStockForUpdate(gomock.Any(), gomock.Any()).Return(left, nil)
 
 		resp, err := tc.client.DecreaseProducts(req)
 		require.Nil(t, err)
@@ -250,15 +296,15 @@ func TestDecreaseProducts(t *testing.T) {
 		require.Equal(t, resp.GetStatus(), ds.StatusDecreaseProductsFailed)
 	})
 
-	t.Run("DecreaseProducts error on DecreaseProduct", func(t *testing.T) {
+	t.Run("DecreaseProducts error on DecreaseVariantStock", func(t *testing.T) {
 		t.Parallel()
 
 		tc := NewTestClient(t)
 
 		uid := uuid.New()
 		req := &ds.DecreaseProductsRequest{
-			Uid:    uid,
-			Amount: 10,
+			VariantUid: uid,
+			Amount:     10,
 		}
 
 		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
@@ -266,8 +312,9 @@ func TestDecreaseProducts(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().LockStockForUpdate(gomock.Any(), gomock.Any()).Return(int64(10), nil)
-		tc.querierMock.EXPECT().DecreaseProduct(gomock.Any(), gomock.Any()).Return(int64(0), errTest)
+		tc.querierMock.EXPECT().LockVariantStockForUpdate(gomock.Any(), gomock.Any()).Return(int64(10), nil)
+		tc.querierMock.EXPECT().DecreaseVariantStock(gomock.Any(), gomock.Any()).Return(int64(0), errTest)
+		expectSnapshots(tc, 1)
 
 		resp, err := tc.client.DecreaseProducts(req)
 		require.NotNil(t, err)
@@ -293,29 +340,65 @@ func TestGetProduct(t *testing.T) {
 		res := sqlc.Product{
 			Uid:            uid,
 			Name:           "Name",
-			Category:       "category",
-			Price:          29999,
-			AvailableStock: 10,
+			CategoryID:     uuid.New(),
+			Options:        json.RawMessage(`["size"]`),
+			Attributes:     json.RawMessage(`{"material": "oak"}`),
 			LastUpdateDate: updTime,
 			SupplierID:     uuid.New(),
-			ImageID:        uuid.New(),
 		}
 
+		variants := []sqlc.ProductVariant{
+			{
+				Uid:            uuid.New(),
+				ProductID:      uid,
+				Sku:            "sku",
+				Options:        json.RawMessage(`{"size": "L"}`),
+				Price:          29999,
+				Currency:       money.RUB,
+				AvailableStock: 10,
+			},
+		}
+
+		images := []sqlc.ProductImage{
+			{ProductID: uid, ImageID: uuid.New(), IsPrimary: true},
+		}
+
+		saleEnds := time.Now().Add(time.Hour)
+
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock).Times(5)
 		tc.querierMock.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Return(res, nil)
+		tc.querierMock.EXPECT().GetProductVariants(gomock.Any(), uid).Return(variants, nil)
+		tc.querierMock.EXPECT().GetProductImages(gomock.Any(), uid).Return(images, nil)
+		tc.querierMock.EXPECT().GetEffectivePrices(gomock.Any(), sqlc.GetEffectivePricesParams{
+			VariantIds: []uuid.UUID{variants[0].Uid},
+		}).Return([]sqlc.GetEffectivePricesRow{{
+			VariantID:       variants[0].Uid,
+			Price:           24999,
+			Currency:        money.RUB,
+			RegularPrice:    variants[0].Price,
+			RegularCurrency: variants[0].Currency,
+			EffectiveTo:     sql.NullTime{Time: saleEnds, Valid: true},
+		}}, nil)
 
 		resp, err := tc.client.GetProduct(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
 		require.Equal(t, resp.Product.Uid, res.Uid)
 		require.Equal(t, resp.Product.Name, res.Name)
-		require.Equal(t, resp.Product.Category, res.Category)
-		require.Equal(t, resp.Product.Price, fromDBPrice(res.Price))
-		require.Equal(t, resp.Product.AvaliableStocks, res.AvailableStock)
+		require.Equal(t, resp.Product.CategoryUid, res.CategoryID)
+		require.Equal(t, resp.Product.Options, []string{"size"})
+		require.Equal(t, resp.Product.Attributes, map[string]any{"material": "oak"})
+		require.Equal(t, resp.Product.Variants[0].Uid, variants[0].Uid)
+		require.Equal(t, resp.Product.Variants[0].Options, map[string]string{"size": "L"})
+		require.Equal(t, resp.Product.Variants[0].Price, money.New(24999, money.RUB))
+		require.Equal(t, *resp.Product.Variants[0].RegularPrice, money.New(variants[0].Price, variants[0].Currency))
+		require.Equal(t, resp.Product.Variants[0].PriceEffectiveTo, &saleEnds)
+		require.Equal(t, resp.Product.Variants[0].AvaliableStocks, variants[0].AvailableStock)
 		require.Equal(t, resp.Product.LastUpdateDate, ds.DateOnly(res.LastUpdateDate))
 		require.Equal(t, resp.Product.SupplierUid, res.SupplierID)
-		require.Equal(t, resp.Product.ImageUid, res.ImageID)
+		require.Equal(t, resp.Product.ImageUid, images[0].ImageID)
+		require.Equal(t, resp.Product.Images[0].ImageUid, images[0].ImageID)
 	})
 
 	t.Run("GetProduct not found", func(t *testing.T) {
@@ -363,6 +446,137 @@ func TestGetProduct(t *testing.T) {
 	})
 }
 
+func TestGetProductBySku(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductBySku ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		req := &ds.GetProductBySkuRequest{
+			Sku: "BEAM-OAK-2M",
+		}
+
+		res := sqlc.Product{
+			Uid:        uid,
+			Name:       "Name",
+			Sku:        "BEAM-OAK",
+			Options:    json.RawMessage(`[]`),
+			Attributes: json.RawMessage(`{}`),
+		}
+
+		variants := []sqlc.ProductVariant{
+			{
+				Uid:       uuid.New(),
+				ProductID: uid,
+				Sku:       "BEAM-OAK-2M",
+				Barcode:   sql.NullString{String: "4006381333931", Valid: true},
+				Options:   json.RawMessage(`{}`),
+			},
+		}
+
+		images := []sqlc.ProductImage{
+			{ProductID: uid, ImageID: uuid.New(), IsPrimary: true},
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock).Times(5)
+		tc.querierMock.EXPECT().GetProductBySku(gomock.Any(), req.Sku).Return(res, nil)
+		tc.querierMock.EXPECT().GetProductVariants(gomock.Any(), uid).Return(variants, nil)
+		tc.querierMock.EXPECT().GetProductImages(gomock.Any(), uid).Return(images, nil)
+		tc.querierMock.EXPECT().GetEffectivePrices(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		resp, err := tc.client.GetProductBySku(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.Product.Uid, uid)
+		require.Equal(t, resp.Product.Sku, res.Sku)
+		require.Empty(t, resp.Product.Barcode)
+		require.Equal(t, resp.Product.Variants[0].Sku, req.Sku)
+		require.Equal(t, resp.Product.Variants[0].Barcode, "4006381333931")
+	})
+
+	t.Run("GetProductBySku not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.GetProductBySkuRequest{
+			Sku: "BEAM-OAK-2M",
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetProductBySku(gomock.Any(), req.Sku).Return(sqlc.Product{}, sql.ErrNoRows)
+
+		resp, err := tc.client.GetProductBySku(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
+	})
+}
+
+func TestGetProductByBarcode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductByBarcode ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		req := &ds.GetProductByBarcodeRequest{
+			Barcode: "4006381333931",
+		}
+
+		res := sqlc.Product{
+			Uid:        uid,
+			Name:       "Name",
+			Sku:        "BEAM-OAK",
+			Barcode:    sql.NullString{String: req.Barcode, Valid: true},
+			Options:    json.RawMessage(`[]`),
+			Attributes: json.RawMessage(`{}`),
+		}
+
+		images := []sqlc.ProductImage{
+			{ProductID: uid, ImageID: uuid.New(), IsPrimary: true},
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock).Times(5)
+		tc.querierMock.EXPECT().GetProductByBarcode(gomock.Any(), toNullString(req.Barcode)).Return(res, nil)
+		tc.querierMock.EXPECT().GetProductVariants(gomock.Any(), uid).Return(nil, nil)
+		tc.querierMock.EXPECT().GetProductImages(gomock.Any(), uid).Return(images, nil)
+
+		resp, err := tc.client.GetProductByBarcode(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.Product.Uid, uid)
+		require.Equal(t, resp.Product.Barcode, req.Barcode)
+	})
+
+	t.Run("GetProductByBarcode error on variants", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.GetProductByBarcodeRequest{
+			Barcode: "4006381333931",
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock).Times(2)
+		tc.querierMock.EXPECT().GetProductByBarcode(gomock.Any(), gomock.Any()).Return(sqlc.Product{}, nil)
+		tc.querierMock.EXPECT().GetProductVariants(gomock.Any(), gomock.Any()).Return(nil, errTest)
+
+		resp, err := tc.client.GetProductByBarcode(req)
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+}
+
 func TestGetProducts(t *testing.T) {
 	t.Parallel()
 
@@ -383,30 +597,55 @@ func TestGetProducts(t *testing.T) {
 			{
 				Uid:            uid,
 				Name:           "Name",
-				Category:       "category",
-				Price:          29999,
-				AvailableStock: 10,
+				CategoryID:     uuid.New(),
+				Options:        json.RawMessage(`["size"]`),
+				Attributes:     json.RawMessage(`{"material": "oak"}`),
 				LastUpdateDate: updTime,
 				SupplierID:     uuid.New(),
-				ImageID:        uuid.New(),
 			},
 		}
 
+		variants := []sqlc.ProductVariant{
+			{
+				Uid:            uuid.New(),
+				ProductID:      uid,
+				Sku:            "sku",
+				Options:        json.RawMessage(`{"size": "L"}`),
+				Price:          29999,
+				Currency:       money.RUB,
+				AvailableStock: 10,
+			},
+		}
+
+		images := []sqlc.ProductImage{
+			{ProductID: uid, ImageID: uuid.New(), IsPrimary: true},
+		}
+
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock).Times(5)
 		tc.querierMock.EXPECT().GetProductsPage(gomock.Any(), gomock.Any()).Return(res, nil)
+		tc.querierMock.EXPECT().GetVariantsOfProducts(gomock.Any(), []uuid.UUID{uid}).Return(variants, nil)
+		tc.querierMock.EXPECT().GetImagesOfProducts(gomock.Any(), []uuid.UUID{uid}).Return(images, nil)
+		tc.querierMock.EXPECT().GetEffectivePrices(gomock.Any(), gomock.Any()).Return([]sqlc.GetEffectivePricesRow{{
+			VariantID:       variants[0].Uid,
+			Price:           variants[0].Price,
+			Currency:        variants[0].Currency,
+			RegularPrice:    variants[0].Price,
+			RegularCurrency: variants[0].Currency,
+		}}, nil)
 
 		resp, err := tc.client.GetProducts(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
 		require.Equal(t, resp.Products[0].Uid, res[0].Uid)
 		require.Equal(t, resp.Products[0].Name, res[0].Name)
-		require.Equal(t, resp.Products[0].Category, res[0].Category)
-		require.Equal(t, resp.Products[0].Price, fromDBPrice(res[0].Price))
-		require.Equal(t, resp.Products[0].AvaliableStocks, res[0].AvailableStock)
+		require.Equal(t, resp.Products[0].CategoryUid, res[0].CategoryID)
+		require.Equal(t, resp.Products[0].Variants[0].Uid, variants[0].Uid)
+		require.Equal(t, resp.Products[0].Variants[0].Price, money.New(variants[0].Price, variants[0].Currency))
+		require.Equal(t, resp.Products[0].Variants[0].AvaliableStocks, variants[0].AvailableStock)
 		require.Equal(t, resp.Products[0].LastUpdateDate, ds.DateOnly(res[0].LastUpdateDate))
 		require.Equal(t, resp.Products[0].SupplierUid, res[0].SupplierID)
-		require.Equal(t, resp.Products[0].ImageUid, res[0].ImageID)
+		require.Equal(t, resp.Products[0].ImageUid, images[0].ImageID)
 	})
 
 	t.Run("GetProducts error on GetProductsPage", func(t *testing.T) {
@@ -444,30 +683,55 @@ func TestGetProducts(t *testing.T) {
 			{
 				Uid:            uid,
 				Name:           "Name",
-				Category:       "category",
-				Price:          29999,
-				AvailableStock: 10,
+				CategoryID:     uuid.New(),
+				Options:        json.RawMessage(`["size"]`),
+				Attributes:     json.RawMessage(`{"material": "oak"}`),
 				LastUpdateDate: updTime,
 				SupplierID:     uuid.New(),
-				ImageID:        uuid.New(),
 			},
 		}
 
+		variants := []sqlc.ProductVariant{
+			{
+				Uid:            uuid.New(),
+				ProductID:      uid,
+				Sku:            "sku",
+				Options:        json.RawMessage(`{"size": "L"}`),
+				Price:          29999,
+				Currency:       money.RUB,
+				AvailableStock: 10,
+			},
+		}
+
+		images := []sqlc.ProductImage{
+			{ProductID: uid, ImageID: uuid.New(), IsPrimary: true},
+		}
+
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().GetAllProducts(gomock.Any()).Return(res, nil)
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock).Times(5)
+		tc.querierMock.EXPECT().GetAllProducts(gomock.Any(), gomock.Any()).Return(res, nil)
+		tc.querierMock.EXPECT().GetVariantsOfProducts(gomock.Any(), []uuid.UUID{uid}).Return(variants, nil)
+		tc.querierMock.EXPECT().GetImagesOfProducts(gomock.Any(), []uuid.UUID{uid}).Return(images, nil)
+		tc.querierMock.EXPECT().GetEffectivePrices(gomock.Any(), gomock.Any()).Return([]sqlc.GetEffectivePricesRow{{
+			VariantID:       variants[0].Uid,
+			Price:           variants[0].Price,
+			Currency:        variants[0].Currency,
+			RegularPrice:    variants[0].Price,
+			RegularCurrency: variants[0].Currency,
+		}}, nil)
 
 		resp, err := tc.client.GetProducts(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
 		require.Equal(t, resp.Products[0].Uid, res[0].Uid)
 		require.Equal(t, resp.Products[0].Name, res[0].Name)
-		require.Equal(t, resp.Products[0].Category, res[0].Category)
-		require.Equal(t, resp.Products[0].Price, fromDBPrice(res[0].Price))
-		require.Equal(t, resp.Products[0].AvaliableStocks, res[0].AvailableStock)
+		require.Equal(t, resp.Products[0].CategoryUid, res[0].CategoryID)
+		require.Equal(t, resp.Products[0].Variants[0].Uid, variants[0].Uid)
+		require.Equal(t, resp.Products[0].Variants[0].Price, money.New(variants[0].Price, variants[0].Currency))
+		require.Equal(t, resp.Products[0].Variants[0].AvaliableStocks, variants[0].AvailableStock)
 		require.Equal(t, resp.Products[0].LastUpdateDate, ds.DateOnly(res[0].LastUpdateDate))
 		require.Equal(t, resp.Products[0].SupplierUid, res[0].SupplierID)
-		require.Equal(t, resp.Products[0].ImageUid, res[0].ImageID)
+		require.Equal(t, resp.Products[0].ImageUid, images[0].ImageID)
 	})
 
 	t.Run("GetProducts no offset and limit error on GetAllProducts", func(t *testing.T) {
@@ -481,7 +745,37 @@ func TestGetProducts(t *testing.T) {
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
 		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().GetAllProducts(gomock.Any()).Return(res, errTest)
+		tc.querierMock.EXPECT().GetAllProducts(gomock.Any(), gomock.Any()).Return(res, errTest)
+
+		resp, err := tc.client.GetProducts(req)
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+
+	t.Run("GetProducts attributes typed by category schema", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		categoryUid := uuid.New()
+		req := &ds.GetProductsRequest{
+			CategoryUid: categoryUid,
+			Attributes:  []string{"size:42"},
+		}
+
+		checkGetAll := func(_ context.Context, arg sqlc.GetAllProductsParams) ([]sqlc.Product, error) {
+			require.Len(t, arg.Attributes, 1)
+			require.JSONEq(t, `{"size": "42"}`, string(arg.Attributes[0]))
+			return nil, errTest
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock).Times(2)
+		tc.querierMock.EXPECT().GetAttributeTypes(gomock.Any(), sqlc.GetAttributeTypesParams{
+			CategoryID: uuid.NullUUID{UUID: categoryUid, Valid: true},
+			Names:      []string{"size"},
+		}).Return([]sqlc.GetAttributeTypesRow{{Name: "size", Type: ds.AttributeTypeString}}, nil)
+		tc.querierMock.EXPECT().GetAllProducts(gomock.Any(), gomock.Any()).DoAndReturn(checkGetAll)
 
 		resp, err := tc.client.GetProducts(req)
 		require.NotNil(t, err)
@@ -502,14 +796,15 @@ func TestDeleteProduct(t *testing.T) {
 			Uid: uid,
 		}
 
-		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().DeleteProduct(gomock.Any(), gomock.Any()).Return(uid, nil)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().DeleteProduct(gomock.Any(), uid).Return(uid, nil)
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.DeleteProduct(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
-
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
 	})
 
 	t.Run("DeleteProduct not found", func(t *testing.T) {
@@ -517,20 +812,18 @@ func TestDeleteProduct(t *testing.T) {
 
 		tc := NewTestClient(t)
 
-		uid := uuid.New()
 		req := &ds.DeleteProductRequest{
-			Uid: uid,
+			Uid: uuid.New(),
 		}
 
-		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().DeleteProduct(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, sql.ErrNoRows)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().DeleteProduct(gomock.Any(), req.Uid).Return(uuid.UUID{}, sql.ErrNoRows)
 
 		resp, err := tc.client.DeleteProduct(req)
 		require.Nil(t, err)
 		require.NotNil(t, resp)
-		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
-
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
 	})
 
 	t.Run("DeleteProduct error on DeleteProduct", func(t *testing.T) {
@@ -538,17 +831,322 @@ func TestDeleteProduct(t *testing.T) {
 
 		tc := NewTestClient(t)
 
-		uid := uuid.New()
 		req := &ds.DeleteProductRequest{
-			Uid: uid,
+			Uid: uuid.New(),
 		}
 
-		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
-		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().DeleteProduct(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, errTest)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().DeleteProduct(gomock.Any(), req.Uid).Return(uuid.UUID{}, errTest)
 
 		resp, err := tc.client.DeleteProduct(req)
 		require.NotNil(t, err)
 		require.Nil(t, resp)
 	})
 }
+
+func TestRestoreProduct(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RestoreProduct ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.RestoreProductRequest{
+			Uid: uuid.New(),
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().HasDeletedReferences(gomock.Any(), req.Uid).Return(false, nil)
+		tc.querierMock.EXPECT().RestoreProduct(gomock.Any(), req.Uid).Return(req.Uid, nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.RestoreProduct(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("RestoreProduct with deleted reference", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.RestoreProductRequest{
+			Uid: uuid.New(),
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().HasDeletedReferences(gomock.Any(), req.Uid).Return(true, nil)
+
+		resp, err := tc.client.RestoreProduct(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusRestoreWithDeletedReference, resp.GetStatus())
+	})
+
+	t.Run("RestoreProduct not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.RestoreProductRequest{
+			Uid: uuid.New(),
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().HasDeletedReferences(gomock.Any(), req.Uid).Return(false, nil)
+		tc.querierMock.EXPECT().RestoreProduct(gomock.Any(), req.Uid).Return(uuid.Nil, sql.ErrNoRows)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.RestoreProduct(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("RestoreProduct error on HasDeletedReferences", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.RestoreProductRequest{
+			Uid: uuid.New(),
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().HasDeletedReferences(gomock.Any(), req.Uid).Return(false, errTest)
+
+		resp, err := tc.client.RestoreProduct(req)
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestAddProductVariants(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddProduct options mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductRequest{
+			Product: ds.Product{
+				SupplierUid: uuid.New(),
+				ImageUid:    uuid.New(),
+				Name:        "Name",
+				CategoryUid: uuid.New(),
+				Options:     []string{"size", "colour"},
+				Variants: []ds.ProductVariant{
+					{Sku: "sku", Options: map[string]string{"size": "L"}, Price: money.New(29995, money.RUB), AvaliableStocks: 123},
+				},
+			},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), gomock.Any()).Return(true, nil)
+		tc.querierMock.EXPECT().ShareSupplier(gomock.Any(), req.SupplierUid).Return(req.SupplierUid, nil)
+		tc.querierMock.EXPECT().GetCategoryAttributeSchema(gomock.Any(), gomock.Any()).Return(json.RawMessage(`[]`), nil)
+
+		resp, err := tc.client.AddProduct(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusVariantOptionsMismatch)
+	})
+
+	t.Run("AddProduct no variant image", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		imageUid := uuid.New()
+		req := &ds.AddProductRequest{
+			Product: ds.Product{
+				SupplierUid: uuid.New(),
+				ImageUid:    uuid.New(),
+				Name:        "Name",
+				CategoryUid: uuid.New(),
+				Variants: []ds.ProductVariant{
+					{Sku: "sku", ImageUid: &imageUid, Price: money.New(29995, money.RUB), AvaliableStocks: 123},
+				},
+			},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), gomock.Any()).Return(true, nil)
+		tc.querierMock.EXPECT().ShareSupplier(gomock.Any(), req.SupplierUid).Return(req.SupplierUid, nil)
+		tc.querierMock.EXPECT().GetCategoryAttributeSchema(gomock.Any(), gomock.Any()).Return(json.RawMessage(`[]`), nil)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), imageUid).Return(false, nil)
+
+		resp, err := tc.client.AddProduct(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusAddVariantWithNoImage)
+	})
+
+	t.Run("AddProduct variant already exists", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductRequest{
+			Product: ds.Product{
+				SupplierUid: uuid.New(),
+				ImageUid:    uuid.New(),
+				Name:        "Name",
+				CategoryUid: uuid.New(),
+				Options:     []string{"size"},
+				Variants: []ds.ProductVariant{
+					{Sku: "sku", Options: map[string]string{"size": "L"}, Price: money.New(29995, money.RUB), AvaliableStocks: 123},
+				},
+			},
+		}
+
+		var txErr error
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			txErr = fn(tc.ctx, tc.querierMock)
+			return txErr
+		}
+
+		checkInsert := func(_ context.Context, arg sqlc.InsertProductVariantParams) (uuid.UUID, error) {
+			require.JSONEq(t, `{"size": "L"}`, string(arg.Options))
+			return uuid.Nil, sql.ErrNoRows
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), gomock.Any()).Return(true, nil)
+		tc.querierMock.EXPECT().ShareSupplier(gomock.Any(), req.SupplierUid).Return(req.SupplierUid, nil)
+		tc.querierMock.EXPECT().GetCategoryAttributeSchema(gomock.Any(), gomock.Any()).Return(json.RawMessage(`[]`), nil)
+		tc.querierMock.EXPECT().InsertProduct(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
+		tc.querierMock.EXPECT().InsertProductImage(gomock.Any(), gomock.Any()).Return(uuid.New(), nil)
+		tc.querierMock.EXPECT().InsertProductVariant(gomock.Any(), gomock.Any()).DoAndReturn(checkInsert)
+
+		resp, err := tc.client.AddProduct(req)
+		require.Nil(t, err)
+		require.ErrorIs(t, txErr, errRollback)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusAlreadyExists)
+	})
+}
+
+func TestUpdateProductAttributes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UpdateProductAttributes ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.UpdateProductAttributesRequest{
+			Uid:        uuid.New(),
+			Attributes: map[string]any{"voltage": 220.0},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		checkUpdate := func(_ context.Context, arg sqlc.UpdateProductAttributesParams) (uuid.UUID, error) {
+			require.JSONEq(t, `{"voltage": 220}`, string(arg.Attributes))
+			return arg.Uid, nil
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductAttributeSchema(gomock.Any(), req.Uid).
+			Return(json.RawMessage(`[{"name": "voltage", "type": "number", "unit": "V", "required": true}]`), nil)
+		tc.querierMock.EXPECT().UpdateProductAttributes(gomock.Any(), gomock.Any()).DoAndReturn(checkUpdate)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.UpdateProductAttributes(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusOK)
+	})
+
+	t.Run("UpdateProductAttributes invalid", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.UpdateProductAttributesRequest{
+			Uid:        uuid.New(),
+			Attributes: map[string]any{"voltage": "high"},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductAttributeSchema(gomock.Any(), req.Uid).
+			Return(json.RawMessage(`[{"name": "voltage", "type": "number"}]`), nil)
+
+		resp, err := tc.client.UpdateProductAttributes(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Contains(t, resp.GetStatus(), ds.StatusInvalidAttributes)
+	})
+
+	t.Run("UpdateProductAttributes not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.UpdateProductAttributesRequest{Uid: uuid.New()}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().GetProductAttributeSchema(gomock.Any(), req.Uid).Return(nil, sql.ErrNoRows)
+
+		resp, err := tc.client.UpdateProductAttributes(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusNotFound)
+	})
+
+	t.Run("AddProduct invalid attributes", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.AddProductRequest{
+			Product: ds.Product{
+				SupplierUid: uuid.New(),
+				ImageUid:    uuid.New(),
+				Name:        "Name",
+				CategoryUid: uuid.New(),
+				Variants:    []ds.ProductVariant{{Sku: "sku", Price: money.New(29995, money.RUB), AvaliableStocks: 123}},
+			},
+		}
+
+		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
+			return fn(tc.ctx, tc.querierMock)
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		tc.querierMock.EXPECT().KeepImage(gomock.Any(), gomock.Any()).Return(true, nil)
+		tc.querierMock.EXPECT().ShareSupplier(gomock.Any(), req.SupplierUid).Return(req.SupplierUid, nil)
+		tc.querierMock.EXPECT().GetCategoryAttributeSchema(gomock.Any(), req.CategoryUid).
+			Return(json.RawMessage(`[{"name": "material", "type": "string", "required": true}]`), nil)
+
+		resp, err := tc.client.AddProduct(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Contains(t, resp.GetStatus(), ds.StatusInvalidAttributes)
+	})
+}
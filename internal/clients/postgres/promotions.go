@@ -0,0 +1,407 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+	"shopapi/internal/supports"
+
+	"github.com/google/uuid"
+)
+
+func (c *Client) AddPromotion(req *ds.AddPromotionRequest) (resp *ds.AddPromotionResponse, err error) {
+	if status := checkPromotion(&req.Promotion); status != "" {
+		return &ds.AddPromotionResponse{
+			Status: ds.Status{Message: status},
+		}, nil
+	}
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		p := toDBPromotion(&req.Promotion)
+
+		uid, err := qtx.InsertPromotion(ctx, sqlc.InsertPromotionParams{
+			Uid:            supports.GetUUIDIfEmpty(req.Uid),
+			Name:           p.Name,
+			Code:           p.Code,
+			Kind:           p.Kind,
+			Percent:        p.Percent,
+			Amount:         p.Amount,
+			Currency:       p.Currency,
+			ProductIds:     p.ProductIds,
+			CategoryIds:    p.CategoryIds,
+			SupplierIds:    p.SupplierIds,
+			StartsAt:       p.StartsAt,
+			EndsAt:         p.EndsAt,
+			UsageLimit:     p.UsageLimit,
+			PerClientLimit: p.PerClientLimit,
+			Stackable:      p.Stackable,
+			Priority:       p.Priority,
+		})
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			resp = &ds.AddPromotionResponse{
+				Status: ds.Status{Message: ds.StatusAlreadyExists},
+			}
+			return nil
+		}
+
+		resp = &ds.AddPromotionResponse{Uid: &uid}
+		return audit(ctx, qtx, req.Audit, "AddPromotion", ds.AuditPromotion, uid.String(), nil)
+	})
+
+	return
+}
+
+// UpdatePromotion replaces the promotion as a whole, the redemptions made stay counted against its limits.
+func (c *Client) UpdatePromotion(req *ds.UpdatePromotionRequest) (resp *ds.UpdatePromotionResponse, err error) {
+	if status := checkPromotion(&req.Promotion); status != "" {
+		return &ds.UpdatePromotionResponse{
+			Status: ds.Status{Message: status},
+		}, nil
+	}
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditPromotion, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
+		p := toDBPromotion(&req.Promotion)
+
+		_, err = qtx.UpdatePromotion(ctx, sqlc.UpdatePromotionParams{
+			Name:           p.Name,
+			Code:           p.Code,
+			Kind:           p.Kind,
+			Percent:        p.Percent,
+			Amount:         p.Amount,
+			Currency:       p.Currency,
+			ProductIds:     p.ProductIds,
+			CategoryIds:    p.CategoryIds,
+			SupplierIds:    p.SupplierIds,
+			StartsAt:       p.StartsAt,
+			EndsAt:         p.EndsAt,
+			UsageLimit:     p.UsageLimit,
+			PerClientLimit: p.PerClientLimit,
+			Stackable:      p.Stackable,
+			Priority:       p.Priority,
+			Uid:            req.Uid,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				resp = &ds.UpdatePromotionResponse{
+					Status: ds.Status{Message: ds.StatusNotFound},
+				}
+				return nil
+			}
+			if isUniqueViolation(err) {
+				resp = &ds.UpdatePromotionResponse{
+					Status: ds.Status{Message: ds.StatusAlreadyExists},
+				}
+				return nil
+			}
+			return err
+		}
+
+		resp = &ds.UpdatePromotionResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "UpdatePromotion", ds.AuditPromotion, req.Uid.String(), before)
+	})
+
+	return
+}
+
+func (c *Client) GetPromotions(req *ds.GetPromotionsRequest) (*ds.GetPromotionsResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	promotions, err := c.db.Querier().GetPromotions(ctx, req.IncludeExpired)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ds.GetPromotionsResponse{
+		Promotions: make([]ds.Promotion, len(promotions)),
+	}
+	for i, p := range promotions {
+		resp.Promotions[i] = fromDBPromotion(&p.Promotion, p.Uses)
+	}
+
+	return resp, nil
+}
+
+// GetApplicablePromotions returns the promotions in effect at the moment without a code and the one with the code,
+// the limits are not checked here, ClientUses and Uses tell how much of them is left. The fixed amounts are converted
+// to the currency of the request, a promotion without a code is left out if its currency has no rate, while the
+// one with the code is answered StatusNoExchangeRate, so that the entered code isn't dropped silently.
+func (c *Client) GetApplicablePromotions(req *ds.GetApplicablePromotionsRequest) (*ds.GetApplicablePromotionsResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	q := c.db.Querier()
+
+	exists, err := q.IsClientExists(ctx, req.ClientUid)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &ds.GetApplicablePromotionsResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}, nil
+	}
+
+	at := req.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	promotions, err := q.GetApplicablePromotions(ctx, sqlc.GetApplicablePromotionsParams{
+		OrderID:  req.OrderID,
+		ClientID: req.ClientUid,
+		Code:     toNullString(normalizePromoCode(req.Code)),
+		At:       at,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ds.GetApplicablePromotionsResponse{
+		Promotions: make([]ds.ApplicablePromotion, 0, len(promotions)),
+	}
+	convert := converter(ctx, q, req.Currency)
+	for _, p := range promotions {
+		promotion := ds.ApplicablePromotion{
+			Promotion:    fromDBPromotion(&p.Promotion, p.Uses),
+			CategoryTree: p.CategoryTree,
+			ClientUses:   p.ClientUses,
+		}
+
+		if promotion.Amount != nil && req.Currency != "" {
+			amount, ok, err := convert(*promotion.Amount)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				if promotion.Code != "" {
+					return &ds.GetApplicablePromotionsResponse{
+						Status: ds.Status{Message: ds.StatusNoExchangeRate},
+					}, nil
+				}
+				continue
+			}
+			promotion.Amount = &amount
+		}
+
+		resp.Promotions = append(resp.Promotions, promotion)
+	}
+
+	return resp, nil
+}
+
+// RedeemPromotions counts the uses of the promotions by the client. The promotions stay locked until the uses
+// are added, so that the concurrent orders can't go past the limits, none of the uses is added if one of them would.
+// The uses are counted once per order, a retried order is answered StatusOK without counting them again,
+// while the order id already redeemed by another client is refused.
+func (c *Client) RedeemPromotions(req *ds.RedeemPromotionsRequest) (resp *ds.RedeemPromotionsResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		if _, err := qtx.LockClient(ctx, req.ClientUid); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				resp = &ds.RedeemPromotionsResponse{
+					Status: ds.Status{Message: ds.StatusNotFound},
+				}
+				return nil
+			}
+			return err
+		}
+
+		orderClient, err := qtx.GetOrderClient(ctx, req.OrderID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if err == nil {
+			status := ds.StatusOK
+			if orderClient != req.ClientUid {
+				status = ds.StatusOrderOfAnotherClient
+			}
+			resp = &ds.RedeemPromotionsResponse{
+				Status: ds.Status{Message: status},
+			}
+			return nil
+		}
+
+		// The promotions are locked in the order of their uids, so that two orders with the same promotions
+		// can't deadlock waiting for each other.
+		redemptions := slices.Clone(req.Redemptions)
+		slices.SortFunc(redemptions, func(a, b ds.Redemption) int {
+			return bytes.Compare(a.PromotionUid[:], b.PromotionUid[:])
+		})
+
+		for _, r := range redemptions {
+			limits, err := qtx.LockPromotion(ctx, sqlc.LockPromotionParams{
+				OrderID:  req.OrderID,
+				ClientID: req.ClientUid,
+				Uid:      r.PromotionUid,
+			})
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					resp = &ds.RedeemPromotionsResponse{
+						Status: ds.Status{Message: ds.StatusNotFound},
+					}
+					return nil
+				}
+				return err
+			}
+
+			if limits.UsageLimit.Valid && limits.Uses >= int64(limits.UsageLimit.Int32) ||
+				limits.PerClientLimit.Valid && limits.ClientUses >= int64(limits.PerClientLimit.Int32) {
+				resp = &ds.RedeemPromotionsResponse{
+					Status: ds.Status{Message: ds.StatusPromotionExhausted},
+				}
+				return nil
+			}
+		}
+
+		for _, r := range redemptions {
+			_, err := qtx.InsertPromotionRedemption(ctx, sqlc.InsertPromotionRedemptionParams{
+				PromotionID: r.PromotionUid,
+				ClientID:    req.ClientUid,
+				OrderID:     req.OrderID,
+				Discount:    r.Discount.Minor(),
+				Currency:    r.Discount.Currency(),
+			})
+			if err != nil {
+				// The same order is redeemed by a concurrent request, it's counted once by that one.
+				if isUniqueViolation(err) {
+					resp = &ds.RedeemPromotionsResponse{
+						Status: ds.Status{Message: ds.StatusOK},
+					}
+					return errRollback
+				}
+				return err
+			}
+
+			err = audit(ctx, qtx, req.Audit, "RedeemPromotion", ds.AuditPromotion, r.PromotionUid.String(), nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		resp = &ds.RedeemPromotionsResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return nil
+	})
+	if errors.Is(err, errRollback) {
+		err = nil
+	}
+
+	return
+}
+
+// checkPromotion returns the status the promotion is refused with, empty for a valid one.
+func checkPromotion(p *ds.Promotion) string {
+	switch p.Kind {
+	case ds.PromotionPercent:
+		if p.Percent < 1 || p.Percent > 100 || p.Amount != nil {
+			return ds.StatusInvalidDiscount
+		}
+	case ds.PromotionFixed:
+		if p.Percent != 0 || p.Amount == nil || p.Amount.Minor() <= 0 {
+			return ds.StatusInvalidDiscount
+		}
+	}
+
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return ds.StatusPromotionEndsNotInTime
+	}
+
+	return ""
+}
+
+// normalizePromoCode makes the codes case insensitive, they are kept in upper case.
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func toDBPromotion(p *ds.Promotion) sqlc.Promotion {
+	amount := money.New(0, money.RUB)
+	if p.Amount != nil {
+		amount = *p.Amount
+	}
+
+	return sqlc.Promotion{
+		Name:           p.Name,
+		Code:           toNullString(normalizePromoCode(p.Code)),
+		Kind:           p.Kind,
+		Percent:        p.Percent,
+		Amount:         amount.Minor(),
+		Currency:       amount.Currency(),
+		ProductIds:     nonNilUUIDs(p.ProductUids),
+		CategoryIds:    nonNilUUIDs(p.CategoryUids),
+		SupplierIds:    nonNilUUIDs(p.SupplierUids),
+		StartsAt:       toNullTime(p.StartsAt),
+		EndsAt:         toNullTime(p.EndsAt),
+		UsageLimit:     toNullInt32(p.UsageLimit),
+		PerClientLimit: toNullInt32(p.PerClientLimit),
+		Stackable:      p.Stackable,
+		Priority:       p.Priority,
+	}
+}
+
+func fromDBPromotion(p *sqlc.Promotion, uses int64) ds.Promotion {
+	promotion := ds.Promotion{
+		Uid:            p.Uid,
+		Name:           p.Name,
+		Code:           p.Code.String,
+		Kind:           p.Kind,
+		Percent:        p.Percent,
+		ProductUids:    p.ProductIds,
+		CategoryUids:   p.CategoryIds,
+		SupplierUids:   p.SupplierIds,
+		StartsAt:       fromNullTime(p.StartsAt),
+		EndsAt:         fromNullTime(p.EndsAt),
+		UsageLimit:     fromNullInt32(p.UsageLimit),
+		PerClientLimit: fromNullInt32(p.PerClientLimit),
+		Stackable:      p.Stackable,
+		Priority:       p.Priority,
+		Uses:           uses,
+	}
+	if p.Kind == ds.PromotionFixed {
+		amount := money.New(p.Amount, p.Currency)
+		promotion.Amount = &amount
+	}
+
+	return promotion
+}
+
+// nonNilUUIDs keeps the empty scope an empty array, a NULL one would match nothing.
+func nonNilUUIDs(uids []uuid.UUID) []uuid.UUID {
+	if uids == nil {
+		return []uuid.UUID{}
+	}
+	return uids
+}
+
+func toNullInt32(n *int32) sql.NullInt32 {
+	if n == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *n, Valid: true}
+}
+
+func fromNullInt32(n sql.NullInt32) *int32 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int32
+}
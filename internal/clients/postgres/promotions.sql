@@ -0,0 +1,73 @@
+-- name: InsertPromotion :one
+INSERT INTO promotions (uid, name, code, kind, percent, amount, currency, product_ids, category_ids,
+    supplier_ids, starts_at, ends_at, usage_limit, per_client_limit, stackable, priority)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+ON CONFLICT DO NOTHING
+RETURNING uid;
+
+-- name: UpdatePromotion :one
+UPDATE promotions
+SET name = $1, code = $2, kind = $3, percent = $4, amount = $5, currency = $6, product_ids = $7,
+    category_ids = $8, supplier_ids = $9, starts_at = $10, ends_at = $11, usage_limit = $12,
+    per_client_limit = $13, stackable = $14, priority = $15
+WHERE uid = $16
+RETURNING uid;
+
+-- name: GetPromotions :many
+SELECT sqlc.embed(p),
+    (SELECT count(*) FROM promotion_redemptions r WHERE r.promotion_id = p.uid)::bigint AS uses
+FROM promotions p
+WHERE sqlc.arg(include_expired)::bool OR p.ends_at IS NULL OR p.ends_at > now()
+ORDER BY p.priority DESC, p.created_at, p.uid;
+
+-- name: GetApplicablePromotions :many
+SELECT sqlc.embed(p),
+    ARRAY(
+        WITH RECURSIVE category_tree AS (
+            SELECT c.uid FROM categories c WHERE c.uid = ANY(p.category_ids)
+            UNION
+            SELECT c.uid FROM categories c JOIN category_tree ct ON c.parent_id = ct.uid
+        )
+        SELECT uid FROM category_tree
+    )::uuid[] AS category_tree,
+    (SELECT count(*) FROM promotion_redemptions r
+        WHERE r.promotion_id = p.uid AND r.order_id <> sqlc.arg(order_id)::text)::bigint AS uses,
+    (SELECT count(*) FROM promotion_redemptions r
+        WHERE r.promotion_id = p.uid AND r.order_id <> sqlc.arg(order_id)::text
+            AND r.client_id = sqlc.arg(client_id))::bigint AS client_uses
+FROM promotions p
+WHERE (p.code IS NULL OR p.code = sqlc.narg(code)::text)
+    AND (p.starts_at IS NULL OR p.starts_at <= sqlc.arg(at)::timestamptz)
+    AND (p.ends_at IS NULL OR p.ends_at > sqlc.arg(at)::timestamptz)
+ORDER BY p.priority DESC, p.created_at, p.uid;
+
+-- name: IsClientExists :one
+SELECT EXISTS(SELECT 1 FROM clients c WHERE c.uid = $1 AND c.deleted_at IS NULL)::bool AS is_exists;
+
+-- name: LockClient :one
+SELECT c.uid
+FROM clients c
+WHERE c.uid = $1 AND c.deleted_at IS NULL
+FOR SHARE;
+
+-- name: LockPromotion :one
+SELECT p.usage_limit, p.per_client_limit,
+    (SELECT count(*) FROM promotion_redemptions r
+        WHERE r.promotion_id = p.uid AND r.order_id <> sqlc.arg(order_id)::text)::bigint AS uses,
+    (SELECT count(*) FROM promotion_redemptions r
+        WHERE r.promotion_id = p.uid AND r.order_id <> sqlc.arg(order_id)::text
+            AND r.client_id = sqlc.arg(client_id))::bigint AS client_uses
+FROM promotions p
+WHERE p.uid = sqlc.arg(uid)
+FOR UPDATE OF p;
+
+-- name: InsertPromotionRedemption :one
+INSERT INTO promotion_redemptions (promotion_id, client_id, order_id, discount, currency)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id;
+
+-- name: GetOrderClient :one
+SELECT r.client_id
+FROM promotion_redemptions r
+WHERE r.order_id = $1
+LIMIT 1;
@@ -0,0 +1,497 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPromotion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddPromotion ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		productUid := uuid.New()
+		limit := int32(1)
+		req := &ds.AddPromotionRequest{Promotion: ds.Promotion{
+			Uid:            uid,
+			Name:           "Spring sale",
+			Code:           " spring25 ",
+			Kind:           ds.PromotionPercent,
+			Percent:        25,
+			ProductUids:    []uuid.UUID{productUid},
+			PerClientLimit: &limit,
+		}}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().InsertPromotion(gomock.Any(), sqlc.InsertPromotionParams{
+			Uid:            uid,
+			Name:           "Spring sale",
+			Code:           sql.NullString{String: "SPRING25", Valid: true},
+			Kind:           ds.PromotionPercent,
+			Percent:        25,
+			Currency:       money.RUB,
+			ProductIds:     []uuid.UUID{productUid},
+			CategoryIds:    []uuid.UUID{},
+			SupplierIds:    []uuid.UUID{},
+			PerClientLimit: sql.NullInt32{Int32: 1, Valid: true},
+		}).Return(uid, nil)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.AddPromotion(req)
+		require.Nil(t, err)
+		require.Empty(t, resp.GetStatus())
+		require.Equal(t, uid, *resp.Uid)
+	})
+
+	t.Run("AddPromotion fixed", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		amount := money.New(10000, "USD")
+		req := &ds.AddPromotionRequest{Promotion: ds.Promotion{
+			Name:   "Ten off",
+			Kind:   ds.PromotionFixed,
+			Amount: &amount,
+		}}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().InsertPromotion(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, arg sqlc.InsertPromotionParams) (uuid.UUID, error) {
+				require.Equal(t, int64(10000), arg.Amount)
+				require.Equal(t, "USD", arg.Currency)
+				require.False(t, arg.Code.Valid)
+				return arg.Uid, nil
+			})
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.AddPromotion(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp.Uid)
+	})
+
+	t.Run("AddPromotion invalid discount", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		amount := money.New(10000, money.RUB)
+		for _, p := range []ds.Promotion{
+			{Name: "Zero", Kind: ds.PromotionPercent},
+			{Name: "Both", Kind: ds.PromotionPercent, Percent: 10, Amount: &amount},
+			{Name: "No amount", Kind: ds.PromotionFixed},
+			{Name: "Both", Kind: ds.PromotionFixed, Percent: 10, Amount: &amount},
+		} {
+			resp, err := tc.client.AddPromotion(&ds.AddPromotionRequest{Promotion: p})
+			require.Nil(t, err)
+			require.Equal(t, ds.StatusInvalidDiscount, resp.GetStatus())
+		}
+	})
+
+	t.Run("AddPromotion ends not in time", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		starts := time.Now()
+		resp, err := tc.client.AddPromotion(&ds.AddPromotionRequest{Promotion: ds.Promotion{
+			Name:     "Spring sale",
+			Kind:     ds.PromotionPercent,
+			Percent:  25,
+			StartsAt: &starts,
+			EndsAt:   &starts,
+		}})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusPromotionEndsNotInTime, resp.GetStatus())
+	})
+
+	t.Run("AddPromotion already exists", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().InsertPromotion(gomock.Any(), gomock.Any()).Return(uuid.Nil, sql.ErrNoRows)
+
+		resp, err := tc.client.AddPromotion(&ds.AddPromotionRequest{Promotion: ds.Promotion{
+			Name: "Spring sale", Code: "SPRING25", Kind: ds.PromotionPercent, Percent: 25,
+		}})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusAlreadyExists, resp.GetStatus())
+	})
+}
+
+func TestUpdatePromotion(t *testing.T) {
+	t.Parallel()
+
+	req := &ds.UpdatePromotionRequest{Promotion: ds.Promotion{
+		Uid: uuid.New(), Name: "Spring sale", Code: "SPRING25", Kind: ds.PromotionPercent, Percent: 30,
+	}}
+
+	t.Run("UpdatePromotion ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().UpdatePromotion(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, arg sqlc.UpdatePromotionParams) (uuid.UUID, error) {
+				require.Equal(t, req.Uid, arg.Uid)
+				require.Equal(t, int32(30), arg.Percent)
+				return arg.Uid, nil
+			})
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.UpdatePromotion(req)
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("UpdatePromotion not found", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().UpdatePromotion(gomock.Any(), gomock.Any()).Return(uuid.Nil, sql.ErrNoRows)
+
+		resp, err := tc.client.UpdatePromotion(req)
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("UpdatePromotion code taken", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().UpdatePromotion(gomock.Any(), gomock.Any()).
+			Return(uuid.Nil, &pq.Error{Code: uniqueViolationCode})
+
+		resp, err := tc.client.UpdatePromotion(req)
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusAlreadyExists, resp.GetStatus())
+	})
+}
+
+func TestGetPromotions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetPromotions ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetPromotions(gomock.Any(), true).Return([]sqlc.GetPromotionsRow{{
+			Promotion: sqlc.Promotion{
+				Uid:        uid,
+				Name:       "Ten off",
+				Kind:       ds.PromotionFixed,
+				Amount:     10000,
+				Currency:   money.RUB,
+				UsageLimit: sql.NullInt32{Int32: 100, Valid: true},
+			},
+			Uses: 7,
+		}}, nil)
+
+		resp, err := tc.client.GetPromotions(&ds.GetPromotionsRequest{IncludeExpired: true})
+		require.Nil(t, err)
+
+		amount := money.New(10000, money.RUB)
+		limit := int32(100)
+		require.Equal(t, []ds.Promotion{{
+			Uid:        uid,
+			Name:       "Ten off",
+			Kind:       ds.PromotionFixed,
+			Amount:     &amount,
+			UsageLimit: &limit,
+			Uses:       7,
+		}}, resp.Promotions)
+	})
+
+	t.Run("GetPromotions error", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetPromotions(gomock.Any(), false).Return(nil, errTest)
+
+		resp, err := tc.client.GetPromotions(&ds.GetPromotionsRequest{})
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetApplicablePromotions(t *testing.T) {
+	t.Parallel()
+
+	clientUid := uuid.New()
+
+	t.Run("GetApplicablePromotions ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		at := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+		categoryUid, subcategoryUid := uuid.New(), uuid.New()
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().IsClientExists(gomock.Any(), clientUid).Return(true, nil)
+		tc.querierMock.EXPECT().GetApplicablePromotions(gomock.Any(), sqlc.GetApplicablePromotionsParams{
+			ClientID: clientUid,
+			Code:     sql.NullString{String: "SPRING25", Valid: true},
+			At:       at,
+		}).Return([]sqlc.GetApplicablePromotionsRow{{
+			Promotion: sqlc.Promotion{
+				Name:        "Spring sale",
+				Code:        sql.NullString{String: "SPRING25", Valid: true},
+				Kind:        ds.PromotionPercent,
+				Percent:     25,
+				Currency:    money.RUB,
+				CategoryIds: []uuid.UUID{categoryUid},
+			},
+			CategoryTree: []uuid.UUID{categoryUid, subcategoryUid},
+			Uses:         3,
+			ClientUses:   1,
+		}}, nil)
+
+		resp, err := tc.client.GetApplicablePromotions(&ds.GetApplicablePromotionsRequest{
+			ClientUid: clientUid,
+			Code:      "spring25",
+			At:        at,
+		})
+		require.Nil(t, err)
+		require.Len(t, resp.Promotions, 1)
+		require.Equal(t, "SPRING25", resp.Promotions[0].Code)
+		require.Nil(t, resp.Promotions[0].Amount)
+		require.Equal(t, []uuid.UUID{categoryUid, subcategoryUid}, resp.Promotions[0].CategoryTree)
+		require.Equal(t, int64(3), resp.Promotions[0].Uses)
+		require.Equal(t, int64(1), resp.Promotions[0].ClientUses)
+	})
+
+	t.Run("GetApplicablePromotions converts fixed amounts", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().IsClientExists(gomock.Any(), clientUid).Return(true, nil)
+		tc.querierMock.EXPECT().GetApplicablePromotions(gomock.Any(), gomock.Any()).Return([]sqlc.GetApplicablePromotionsRow{
+			{Promotion: sqlc.Promotion{Name: "Ten off", Kind: ds.PromotionFixed, Amount: 100000, Currency: money.RUB}},
+			{Promotion: sqlc.Promotion{Name: "Yen off", Kind: ds.PromotionFixed, Amount: 500, Currency: "JPY"}},
+			{Promotion: sqlc.Promotion{Name: "Dollar off", Kind: ds.PromotionFixed, Amount: 100, Currency: "USD"}},
+		}, nil)
+		tc.querierMock.EXPECT().GetExchangeRates(gomock.Any()).Return([]sqlc.ExchangeRate{
+			{Currency: "USD", Rate: "100"},
+		}, nil)
+
+		resp, err := tc.client.GetApplicablePromotions(&ds.GetApplicablePromotionsRequest{
+			ClientUid: clientUid,
+			Currency:  "USD",
+		})
+		require.Nil(t, err)
+		require.Len(t, resp.Promotions, 2)
+		require.Equal(t, "Ten off", resp.Promotions[0].Name)
+		require.Equal(t, money.New(1000, "USD"), *resp.Promotions[0].Amount)
+		require.Equal(t, "Dollar off", resp.Promotions[1].Name)
+		require.Equal(t, money.New(100, "USD"), *resp.Promotions[1].Amount)
+	})
+
+	t.Run("GetApplicablePromotions code without exchange rate", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().IsClientExists(gomock.Any(), clientUid).Return(true, nil)
+		tc.querierMock.EXPECT().GetApplicablePromotions(gomock.Any(), gomock.Any()).Return([]sqlc.GetApplicablePromotionsRow{{
+			Promotion: sqlc.Promotion{
+				Name:     "Yen off",
+				Code:     sql.NullString{String: "YEN", Valid: true},
+				Kind:     ds.PromotionFixed,
+				Amount:   500,
+				Currency: "JPY",
+			},
+		}}, nil)
+		tc.querierMock.EXPECT().GetExchangeRates(gomock.Any()).Return(nil, nil)
+
+		resp, err := tc.client.GetApplicablePromotions(&ds.GetApplicablePromotionsRequest{
+			ClientUid: clientUid,
+			Code:      "YEN",
+			Currency:  money.RUB,
+		})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNoExchangeRate, resp.GetStatus())
+	})
+
+	t.Run("GetApplicablePromotions no client", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().IsClientExists(gomock.Any(), clientUid).Return(false, nil)
+
+		resp, err := tc.client.GetApplicablePromotions(&ds.GetApplicablePromotionsRequest{ClientUid: clientUid})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+}
+
+func TestRedeemPromotions(t *testing.T) {
+	t.Parallel()
+
+	clientUid := uuid.New()
+	// The request lists the promotions against the order of their uids, they are locked sorted.
+	first := uuid.MustParse("f85a189d-d173-42e2-8e00-54395234d93d")
+	second := uuid.MustParse("376de312-5bcb-4320-8ba3-bd2050548229")
+	req := &ds.RedeemPromotionsRequest{
+		ClientUid: clientUid,
+		OrderID:   "ORD-1",
+		Redemptions: []ds.Redemption{
+			{PromotionUid: first, Discount: money.New(7499, money.RUB)},
+			{PromotionUid: second, Discount: money.New(10000, money.RUB)},
+		},
+	}
+
+	t.Run("RedeemPromotions ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockClient(gomock.Any(), clientUid).Return(clientUid, nil)
+		tc.querierMock.EXPECT().GetOrderClient(gomock.Any(), "ORD-1").Return(uuid.Nil, sql.ErrNoRows)
+		gomock.InOrder(
+			tc.querierMock.EXPECT().LockPromotion(gomock.Any(), sqlc.LockPromotionParams{
+				OrderID:  "ORD-1",
+				ClientID: clientUid,
+				Uid:      second,
+			}).
+				Return(sqlc.LockPromotionRow{}, nil),
+			tc.querierMock.EXPECT().LockPromotion(gomock.Any(), sqlc.LockPromotionParams{
+				OrderID:  "ORD-1",
+				ClientID: clientUid,
+				Uid:      first,
+			}).
+				Return(sqlc.LockPromotionRow{UsageLimit: sql.NullInt32{Int32: 10, Valid: true}, Uses: 9}, nil),
+		)
+		tc.querierMock.EXPECT().InsertPromotionRedemption(gomock.Any(), sqlc.InsertPromotionRedemptionParams{
+			PromotionID: first,
+			ClientID:    clientUid,
+			OrderID:     "ORD-1",
+			Discount:    7499,
+			Currency:    money.RUB,
+		}).Return(int64(1), nil)
+		tc.querierMock.EXPECT().InsertPromotionRedemption(gomock.Any(), sqlc.InsertPromotionRedemptionParams{
+			PromotionID: second,
+			ClientID:    clientUid,
+			OrderID:     "ORD-1",
+			Discount:    10000,
+			Currency:    money.RUB,
+		}).Return(int64(2), nil)
+		expectAudit(tc, 2)
+
+		resp, err := tc.client.RedeemPromotions(req)
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("RedeemPromotions order already redeemed", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockClient(gomock.Any(), clientUid).Return(clientUid, nil)
+		tc.querierMock.EXPECT().GetOrderClient(gomock.Any(), "ORD-1").Return(clientUid, nil)
+
+		resp, err := tc.client.RedeemPromotions(req)
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("RedeemPromotions order of another client", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockClient(gomock.Any(), clientUid).Return(clientUid, nil)
+		tc.querierMock.EXPECT().GetOrderClient(gomock.Any(), "ORD-1").Return(uuid.New(), nil)
+
+		resp, err := tc.client.RedeemPromotions(req)
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusOrderOfAnotherClient, resp.GetStatus())
+	})
+
+	t.Run("RedeemPromotions order redeemed concurrently", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockClient(gomock.Any(), clientUid).Return(clientUid, nil)
+		tc.querierMock.EXPECT().GetOrderClient(gomock.Any(), "ORD-1").Return(uuid.Nil, sql.ErrNoRows)
+		tc.querierMock.EXPECT().LockPromotion(gomock.Any(), gomock.Any()).Return(sqlc.LockPromotionRow{}, nil).Times(2)
+		tc.querierMock.EXPECT().InsertPromotionRedemption(gomock.Any(), gomock.Any()).
+			Return(int64(0), &pq.Error{Code: uniqueViolationCode})
+
+		resp, err := tc.client.RedeemPromotions(req)
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("RedeemPromotions per client limit", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockClient(gomock.Any(), clientUid).Return(clientUid, nil)
+		tc.querierMock.EXPECT().GetOrderClient(gomock.Any(), "ORD-1").Return(uuid.Nil, sql.ErrNoRows)
+		tc.querierMock.EXPECT().LockPromotion(gomock.Any(), gomock.Any()).
+			Return(sqlc.LockPromotionRow{}, nil)
+		tc.querierMock.EXPECT().LockPromotion(gomock.Any(), gomock.Any()).
+			Return(sqlc.LockPromotionRow{PerClientLimit: sql.NullInt32{Int32: 1, Valid: true}, ClientUses: 1}, nil)
+
+		resp, err := tc.client.RedeemPromotions(req)
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusPromotionExhausted, resp.GetStatus())
+	})
+
+	t.Run("RedeemPromotions no client", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockClient(gomock.Any(), clientUid).Return(uuid.Nil, sql.ErrNoRows)
+
+		resp, err := tc.client.RedeemPromotions(req)
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("RedeemPromotions error", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockClient(gomock.Any(), clientUid).Return(clientUid, nil)
+		tc.querierMock.EXPECT().GetOrderClient(gomock.Any(), "ORD-1").Return(uuid.Nil, sql.ErrNoRows)
+		tc.querierMock.EXPECT().LockPromotion(gomock.Any(), gomock.Any()).Return(sqlc.LockPromotionRow{}, errTest)
+
+		resp, err := tc.client.RedeemPromotions(req)
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+}
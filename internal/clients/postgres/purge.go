@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const defaultPurgeBatch = 100
+
+// PurgeDeleted deletes for good, by batches, the products, images, suppliers and clients moved to the trash
+// longer than the retention period ago. The products go first, so the suppliers and images they refer to
+// are purged by the same call. The addresses left unused are collected by the orphans sweep.
+func (c *Client) PurgeDeleted(req *ds.PurgeDeletedRequest) (*ds.PurgeDeletedResponse, error) {
+	resp := &ds.PurgeDeletedResponse{
+		Products:  []uuid.UUID{},
+		Images:    []uuid.UUID{},
+		Suppliers: []uuid.UUID{},
+		Clients:   []uuid.UUID{},
+	}
+
+	deletedBefore := time.Now().Add(-req.Retention)
+	batch := req.Batch
+	if batch <= 0 {
+		batch = defaultPurgeBatch
+	}
+
+	for {
+		n, err := c.purgeBatch(deletedBefore, batch, resp)
+		if err != nil {
+			return nil, err
+		}
+		if n < int(batch) {
+			return resp, nil
+		}
+	}
+}
+
+// purgeBatch purges up to batch rows of each kind, the content of the documents and images is deleted
+// after the commit if nothing else refers to it. Returns the largest number of rows purged of a kind.
+func (c *Client) purgeBatch(deletedBefore time.Time, batch int32, resp *ds.PurgeDeletedResponse) (int, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	var products, suppliers, clients []uuid.UUID
+	var images []sqlc.PurgeImagesRow
+	var unusedKeys []sql.NullString
+	err := c.db.ExecTx(blobTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		unusedKeys = nil
+
+		var err error
+		products, err = qtx.GetPurgeableProducts(ctx, sqlc.GetPurgeableProductsParams{
+			DeletedBefore: deletedBefore,
+			Batch:         batch,
+		})
+		if err != nil {
+			return err
+		}
+
+		if unusedKeys, err = purgeProducts(ctx, qtx, products); err != nil {
+			return err
+		}
+
+		images, err = qtx.PurgeImages(ctx, sqlc.PurgeImagesParams{
+			DeletedBefore: deletedBefore,
+			Batch:         batch,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, img := range images {
+			unusedKey, err := releaseBlob(ctx, qtx, img.StorageKey)
+			if err != nil {
+				return err
+			}
+			unusedKeys = append(unusedKeys, unusedKey)
+		}
+
+		suppliers, err = qtx.PurgeSuppliers(ctx, sqlc.PurgeSuppliersParams{
+			DeletedBefore: deletedBefore,
+			Batch:         batch,
+		})
+		if err != nil {
+			return err
+		}
+
+		clients, err = qtx.PurgeClients(ctx, sqlc.PurgeClientsParams{
+			DeletedBefore: deletedBefore,
+			Batch:         batch,
+		})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range unusedKeys {
+		c.dropBlob(ctx, key)
+	}
+
+	resp.Products = append(resp.Products, products...)
+	for _, img := range images {
+		resp.Images = append(resp.Images, img.Uid)
+	}
+	resp.Suppliers = append(resp.Suppliers, suppliers...)
+	resp.Clients = append(resp.Clients, clients...)
+
+	return max(len(products), len(images), len(suppliers), len(clients)), nil
+}
+
+// purgeProducts deletes the products with their documents, returns the keys of the document blobs
+// left unused to be dropped after the commit. The variants and image links go with the products.
+func purgeProducts(ctx context.Context, qtx IQuerier, uids []uuid.UUID) ([]sql.NullString, error) {
+	var unusedKeys []sql.NullString
+	for _, uid := range uids {
+		keys, err := qtx.DeleteProductDocuments(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			unusedKey, err := releaseBlob(ctx, qtx, toNullString(key))
+			if err != nil {
+				return nil, err
+			}
+			unusedKeys = append(unusedKeys, unusedKey)
+		}
+
+		if _, err = qtx.PurgeProduct(ctx, uid); err != nil {
+			return nil, err
+		}
+	}
+
+	return unusedKeys, nil
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeDeleted(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PurgeDeleted ok", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		product, image, supplier, client := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().ExecTx(blobTxOpt, gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().GetPurgeableProducts(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, arg sqlc.GetPurgeableProductsParams) ([]uuid.UUID, error) {
+				require.WithinDuration(t, time.Now().Add(-time.Hour), arg.DeletedBefore, time.Minute)
+				require.Equal(t, int32(defaultPurgeBatch), arg.Batch)
+				return []uuid.UUID{product}, nil
+			})
+		tc.querierMock.EXPECT().DeleteProductDocuments(gomock.Any(), product).Return([]string{"doc"}, nil)
+		tc.querierMock.EXPECT().ReleaseBlob(gomock.Any(), "doc").Return(int32(1), nil)
+		tc.querierMock.EXPECT().PurgeProduct(gomock.Any(), product).Return(product, nil)
+		tc.querierMock.EXPECT().PurgeImages(gomock.Any(), gomock.Any()).Return([]sqlc.PurgeImagesRow{
+			{Uid: image, StorageKey: sql.NullString{String: "img", Valid: true}},
+		}, nil)
+		tc.querierMock.EXPECT().ReleaseBlob(gomock.Any(), "img").Return(int32(0), nil)
+		tc.querierMock.EXPECT().DeleteUnusedBlob(gomock.Any(), "img").Return(nil)
+		tc.querierMock.EXPECT().PurgeSuppliers(gomock.Any(), gomock.Any()).Return([]uuid.UUID{supplier}, nil)
+		tc.querierMock.EXPECT().PurgeClients(gomock.Any(), gomock.Any()).Return([]uuid.UUID{client}, nil)
+		tc.blobMock.EXPECT().Delete(gomock.Any(), "img").Return(nil)
+
+		resp, err := tc.client.PurgeDeleted(&ds.PurgeDeletedRequest{Retention: time.Hour})
+		require.Nil(t, err)
+		require.Equal(t, &ds.PurgeDeletedResponse{
+			Products:  []uuid.UUID{product},
+			Images:    []uuid.UUID{image},
+			Suppliers: []uuid.UUID{supplier},
+			Clients:   []uuid.UUID{client},
+		}, resp)
+	})
+
+	t.Run("PurgeDeleted next batch", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {}).Times(2)
+		tc.clientMock.EXPECT().ExecTx(blobTxOpt, gomock.Any()).DoAndReturn(execTx(tc)).Times(2)
+		tc.querierMock.EXPECT().GetPurgeableProducts(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+		tc.querierMock.EXPECT().PurgeImages(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+		tc.querierMock.EXPECT().PurgeSuppliers(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+		gomock.InOrder(
+			tc.querierMock.EXPECT().PurgeClients(gomock.Any(), gomock.Any()).Return([]uuid.UUID{uid}, nil),
+			tc.querierMock.EXPECT().PurgeClients(gomock.Any(), gomock.Any()).Return(nil, nil),
+		)
+
+		resp, err := tc.client.PurgeDeleted(&ds.PurgeDeletedRequest{Retention: time.Hour, Batch: 1})
+		require.Nil(t, err)
+		require.Empty(t, resp.Products)
+		require.Equal(t, []uuid.UUID{uid}, resp.Clients)
+	})
+
+	t.Run("PurgeDeleted error on PurgeSuppliers", func(t *testing.T) {
+		t.Parallel()
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().ExecTx(blobTxOpt, gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().GetPurgeableProducts(gomock.Any(), gomock.Any()).Return(nil, nil)
+		tc.querierMock.EXPECT().PurgeImages(gomock.Any(), gomock.Any()).Return(nil, nil)
+		tc.querierMock.EXPECT().PurgeSuppliers(gomock.Any(), gomock.Any()).Return(nil, errTest)
+
+		resp, err := tc.client.PurgeDeleted(&ds.PurgeDeletedRequest{Retention: time.Hour})
+		require.ErrorIs(t, err, errTest)
+		require.Nil(t, resp)
+	})
+}
@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	ds "shopapi/internal/datastruct"
+)
+
+// GetDanglingReferences lists the products referring to missing suppliers or images,
+// they have to be fixed before the foreign keys migration is applied.
+func (c *Client) GetDanglingReferences() ([]ds.DanglingReference, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	rows, err := c.db.Querier().GetDanglingReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]ds.DanglingReference, len(rows))
+	for i, r := range rows {
+		refs[i] = ds.DanglingReference{
+			Reference:  r.Reference,
+			ProductUid: r.ProductID,
+			MissingUid: r.MissingID,
+		}
+	}
+
+	return refs, nil
+}
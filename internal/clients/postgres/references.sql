@@ -0,0 +1,14 @@
+-- name: GetDanglingReferences :many
+SELECT 'products.supplier_id'::text AS reference, p.uid AS product_id, p.supplier_id AS missing_id
+FROM products p
+WHERE NOT EXISTS (SELECT 1 FROM suppliers s WHERE s.uid = p.supplier_id)
+UNION ALL
+SELECT 'product_images.image_id'::text, pi.product_id, pi.image_id
+FROM product_images pi
+WHERE NOT EXISTS (SELECT 1 FROM images i WHERE i.uid = pi.image_id)
+UNION ALL
+SELECT 'product_variants.image_id'::text, pv.product_id, pv.image_id
+FROM product_variants pv
+WHERE pv.image_id IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM images i WHERE i.uid = pv.image_id)
+ORDER BY reference, product_id;
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDanglingReferences(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetDanglingReferences Ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		product, supplier := uuid.New(), uuid.New()
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetDanglingReferences(gomock.Any()).Return([]sqlc.GetDanglingReferencesRow{
+			{Reference: "products.supplier_id", ProductID: product, MissingID: supplier},
+		}, nil)
+
+		refs, err := tc.client.GetDanglingReferences()
+		require.Nil(t, err)
+		require.Equal(t, []ds.DanglingReference{
+			{Reference: "products.supplier_id", ProductUid: product, MissingUid: supplier},
+		}, refs)
+	})
+
+	t.Run("GetDanglingReferences error on GetDanglingReferences", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetDanglingReferences(gomock.Any()).Return(nil, errTest)
+
+		refs, err := tc.client.GetDanglingReferences()
+		require.True(t, errors.Is(err, errTest))
+		require.Nil(t, refs)
+	})
+}
@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+)
+
+func (c *Client) GetRoles(_ *ds.GetRolesRequest) (*ds.GetRolesResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	roles, err := c.db.Querier().GetRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ds.GetRolesResponse{
+		Roles: make([]ds.Role, 0, len(roles)),
+	}
+	for _, r := range roles {
+		resp.Roles = append(resp.Roles, ds.Role{
+			Name:        r.Name,
+			Description: r.Description,
+			Permissions: r.Permissions,
+		})
+	}
+
+	return resp, nil
+}
+
+func (c *Client) AddRole(req *ds.AddRoleRequest) (resp *ds.AddRoleResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		_, err := qtx.InsertRole(ctx, sqlc.InsertRoleParams{
+			Name:        req.Name,
+			Description: req.Description,
+			Permissions: req.Permissions,
+		})
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			resp = &ds.AddRoleResponse{
+				Status: ds.Status{Message: ds.StatusAlreadyExists},
+			}
+			return nil
+		}
+
+		resp = &ds.AddRoleResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "AddRole", ds.AuditRole, req.Name, nil)
+	})
+
+	return
+}
+
+func (c *Client) UpdateRole(req *ds.UpdateRoleRequest) (resp *ds.UpdateRoleResponse, err error) {
+	if req.Name == ds.RoleAdmin {
+		return &ds.UpdateRoleResponse{
+			Status: ds.Status{Message: ds.StatusRoleProtected},
+		}, nil
+	}
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditRole, req.Name)
+		if err != nil {
+			return err
+		}
+
+		_, err = qtx.UpdateRole(ctx, sqlc.UpdateRoleParams{
+			Name:        req.Name,
+			Description: req.Description,
+			Permissions: req.Permissions,
+		})
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			resp = &ds.UpdateRoleResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}
+			return nil
+		}
+
+		resp = &ds.UpdateRoleResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "UpdateRole", ds.AuditRole, req.Name, before)
+	})
+
+	return
+}
+
+// DeleteRole refuses to delete the role assigned to active API keys and lists them,
+// the keys have to be revoked or reissued first. The revoked keys keep the role name.
+func (c *Client) DeleteRole(req *ds.DeleteRoleRequest) (resp *ds.DeleteRoleResponse, err error) {
+	if req.Name == ds.RoleAdmin {
+		return &ds.DeleteRoleResponse{
+			Status: ds.Status{Message: ds.StatusRoleProtected},
+		}, nil
+	}
+
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		keys, err := qtx.GetRoleApiKeys(ctx, req.Name)
+		if err != nil {
+			return err
+		}
+
+		if len(keys) > 0 {
+			resp = &ds.DeleteRoleResponse{
+				Status:  ds.Status{Message: ds.StatusRoleInUse},
+				ApiKeys: keys,
+			}
+			return nil
+		}
+
+		before, err := snapshot(ctx, qtx, ds.AuditRole, req.Name)
+		if err != nil {
+			return err
+		}
+
+		if _, err = qtx.DeleteRole(ctx, req.Name); err != nil {
+			return err
+		}
+
+		resp = &ds.DeleteRoleResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "DeleteRole", ds.AuditRole, req.Name, before)
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return &ds.DeleteRoleResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}, nil
+	}
+
+	return
+}
+
+// GetPermissions returns the permissions granted by the roles, the unknown roles grant nothing.
+func (c *Client) GetPermissions(req *ds.GetPermissionsRequest) (*ds.GetPermissionsResponse, error) {
+	ctx, cancel := c.db.CtxWithCancel()
+	defer cancel()
+
+	permissions, err := c.db.Querier().GetRolesPermissions(ctx, req.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ds.GetPermissionsResponse{
+		Permissions: permissions,
+	}, nil
+}
@@ -0,0 +1,39 @@
+-- name: GetRoles :many
+SELECT *
+FROM roles r
+ORDER BY r.name;
+
+-- name: InsertRole :one
+INSERT INTO roles (name, description, permissions)
+VALUES ($1, $2, $3)
+ON CONFLICT (name) DO NOTHING
+RETURNING name;
+
+-- name: UpdateRole :one
+UPDATE roles r
+SET description = $2, permissions = $3
+WHERE r.name = $1
+RETURNING r.name;
+
+-- name: DeleteRole :one
+DELETE FROM roles r
+WHERE r.name = $1
+RETURNING r.name;
+
+-- name: GetRoleApiKeys :many
+SELECT k.uid
+FROM api_keys k
+WHERE sqlc.arg(name)::text = ANY(k.roles) AND k.revoked_at IS NULL
+ORDER BY k.uid;
+
+-- name: GetRolesPermissions :many
+SELECT DISTINCT unnest(r.permissions)::text AS permission
+FROM roles r
+WHERE r.name = ANY(sqlc.arg(names)::text[])
+ORDER BY permission;
+
+-- name: GetUnknownRoles :many
+SELECT u.name::text AS name
+FROM unnest(sqlc.arg(names)::text[]) AS u(name)
+WHERE NOT EXISTS (SELECT 1 FROM roles r WHERE r.name = u.name)
+ORDER BY u.name;
@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"shopapi/internal/clients/postgres/sqlc"
+	ds "shopapi/internal/datastruct"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRoles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetRoles ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetRoles(gomock.Any()).Return([]sqlc.Role{
+			{Name: ds.RoleAdmin, Permissions: []string{ds.PermissionAll}},
+			{Name: "viewer", Description: "Reads the catalog", Permissions: []string{ds.PermissionProductsRead}},
+		}, nil)
+
+		resp, err := tc.client.GetRoles(&ds.GetRolesRequest{})
+		require.Nil(t, err)
+		require.Equal(t, []ds.Role{
+			{Name: ds.RoleAdmin, Permissions: []string{ds.PermissionAll}},
+			{Name: "viewer", Description: "Reads the catalog", Permissions: []string{ds.PermissionProductsRead}},
+		}, resp.Roles)
+	})
+
+	t.Run("GetRoles error", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetRoles(gomock.Any()).Return(nil, errTest)
+
+		resp, err := tc.client.GetRoles(&ds.GetRolesRequest{})
+		require.NotNil(t, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestAddRole(t *testing.T) {
+	t.Parallel()
+
+	role := ds.Role{Name: "auditor", Permissions: []string{ds.PermissionClientsRead}}
+
+	t.Run("AddRole ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().InsertRole(gomock.Any(), sqlc.InsertRoleParams{
+			Name:        role.Name,
+			Permissions: role.Permissions,
+		}).Return(role.Name, nil)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.AddRole(&ds.AddRoleRequest{Role: role})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("AddRole already exists", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().InsertRole(gomock.Any(), gomock.Any()).Return("", sql.ErrNoRows)
+
+		resp, err := tc.client.AddRole(&ds.AddRoleRequest{Role: role})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusAlreadyExists, resp.GetStatus())
+	})
+}
+
+func TestUpdateRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UpdateRole ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		req := &ds.UpdateRoleRequest{Role: ds.Role{Name: "viewer", Permissions: []string{ds.PermissionProductsRead}}}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().UpdateRole(gomock.Any(), sqlc.UpdateRoleParams{
+			Name:        req.Name,
+			Permissions: req.Permissions,
+		}).Return(req.Name, nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.UpdateRole(req)
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("UpdateRole not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().UpdateRole(gomock.Any(), gomock.Any()).Return("", sql.ErrNoRows)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.UpdateRole(&ds.UpdateRoleRequest{Role: ds.Role{Name: "auditor"}})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("UpdateRole admin", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		resp, err := tc.client.UpdateRole(&ds.UpdateRoleRequest{Role: ds.Role{Name: ds.RoleAdmin}})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusRoleProtected, resp.GetStatus())
+	})
+}
+
+func TestDeleteRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteRole ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().GetRoleApiKeys(gomock.Any(), "crm").Return(nil, nil)
+		tc.querierMock.EXPECT().DeleteRole(gomock.Any(), "crm").Return("crm", nil)
+		expectSnapshots(tc, 1)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.DeleteRole(&ds.DeleteRoleRequest{Name: "crm"})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("DeleteRole in use", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		keys := []uuid.UUID{uuid.New()}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().GetRoleApiKeys(gomock.Any(), "crm").Return(keys, nil)
+
+		resp, err := tc.client.DeleteRole(&ds.DeleteRoleRequest{Name: "crm"})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusRoleInUse, resp.GetStatus())
+		require.Equal(t, keys, resp.ApiKeys)
+	})
+
+	t.Run("DeleteRole not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().GetRoleApiKeys(gomock.Any(), "auditor").Return(nil, nil)
+		tc.querierMock.EXPECT().DeleteRole(gomock.Any(), "auditor").Return("", sql.ErrNoRows)
+		expectSnapshots(tc, 1)
+
+		resp, err := tc.client.DeleteRole(&ds.DeleteRoleRequest{Name: "auditor"})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("DeleteRole admin", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		resp, err := tc.client.DeleteRole(&ds.DeleteRoleRequest{Name: ds.RoleAdmin})
+		require.Nil(t, err)
+		require.Equal(t, ds.StatusRoleProtected, resp.GetStatus())
+	})
+}
+
+func TestGetPermissions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetPermissions ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		roles := []string{"viewer", "crm"}
+		permissions := []string{ds.PermissionClientsRead, ds.PermissionProductsRead}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetRolesPermissions(gomock.Any(), roles).Return(permissions, nil)
+
+		resp, err := tc.client.GetPermissions(&ds.GetPermissionsRequest{Roles: roles})
+		require.Nil(t, err)
+		require.Equal(t, permissions, resp.Permissions)
+	})
+}
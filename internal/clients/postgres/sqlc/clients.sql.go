@@ -7,6 +7,7 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -36,25 +37,27 @@ func (q *Queries) DeleteAddress(ctx context.Context, id int32) error {
 }
 
 const deleteClient = `-- name: DeleteClient :one
-DELETE FROM clients WHERE uid = $1
-RETURNING address_id
+UPDATE clients
+SET deleted_at = now()
+WHERE uid = $1 AND deleted_at IS NULL
+RETURNING uid
 `
 
-func (q *Queries) DeleteClient(ctx context.Context, uid uuid.UUID) (int32, error) {
+func (q *Queries) DeleteClient(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
 	row := q.db.QueryRowContext(ctx, deleteClient, uid)
-	var address_id int32
-	err := row.Scan(&address_id)
-	return address_id, err
+	err := row.Scan(&uid)
+	return uid, err
 }
 
 const getAllClients = `-- name: GetAllClients :many
-SELECT client_name, client_surname, birthday, gender, uid, registration_date, country, city, street
+SELECT client_name, client_surname, birthday, gender, uid, registration_date, country, city, street, deleted_at
 FROM client_details
+WHERE $1::bool OR deleted_at IS NULL
 ORDER BY uid
 `
 
-func (q *Queries) GetAllClients(ctx context.Context) ([]ClientDetail, error) {
-	rows, err := q.db.QueryContext(ctx, getAllClients)
+func (q *Queries) GetAllClients(ctx context.Context, includeDeleted bool) ([]ClientDetail, error) {
+	rows, err := q.db.QueryContext(ctx, getAllClients, includeDeleted)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +75,7 @@ func (q *Queries) GetAllClients(ctx context.Context) ([]ClientDetail, error) {
 			&i.Country,
 			&i.City,
 			&i.Street,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -87,64 +91,22 @@ func (q *Queries) GetAllClients(ctx context.Context) ([]ClientDetail, error) {
 }
 
 const getClientsPage = `-- name: GetClientsPage :many
-SELECT client_name, client_surname, birthday, gender, uid, registration_date, country, city, street
+SELECT client_name, client_surname, birthday, gender, uid, registration_date, country, city, street, deleted_at
 FROM client_details
+WHERE $1::bool OR deleted_at IS NULL
 ORDER BY uid
-OFFSET $1
-LIMIT $2
+OFFSET $2
+LIMIT $3
 `
 
 type GetClientsPageParams struct {
-	Offset int32
-	Limit  int32
+	IncludeDeleted bool
+	Offset         int32
+	Limit          int32
 }
 
 func (q *Queries) GetClientsPage(ctx context.Context, arg GetClientsPageParams) ([]ClientDetail, error) {
-	rows, err := q.db.QueryContext(ctx, getClientsPage, arg.Offset, arg.Limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []ClientDetail
-	for rows.Next() {
-		var i ClientDetail
-		if err := rows.Scan(
-			&i.ClientName,
-			&i.ClientSurname,
-			&i.Birthday,
-			&i.Gender,
-			&i.Uid,
-			&i.RegistrationDate,
-			&i.Country,
-			&i.City,
-			&i.Street,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
-const getClientsWithName = `-- name: GetClientsWithName :many
-SELECT client_name, client_surname, birthday, gender, uid, registration_date, country, city, street
-FROM client_details
-WHERE client_name = $1 AND client_surname = $2
-`
-
-type GetClientsWithNameParams struct {
-	ClientName    string
-	ClientSurname string
-}
-
-func (q *Queries) GetClientsWithName(ctx context.Context, arg GetClientsWithNameParams) ([]ClientDetail, error) {
-	rows, err := q.db.QueryContext(ctx, getClientsWithName, arg.ClientName, arg.ClientSurname)
+	rows, err := q.db.QueryContext(ctx, getClientsPage, arg.IncludeDeleted, arg.Offset, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +124,7 @@ func (q *Queries) GetClientsWithName(ctx context.Context, arg GetClientsWithName
 			&i.Country,
 			&i.City,
 			&i.Street,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -180,7 +143,7 @@ const insertAddress = `-- name: InsertAddress :one
 INSERT INTO addresses (country, city, street)
 VALUES ($1, $2, $3) ON CONFLICT (country, city, street)
 DO UPDATE SET 
-    country = EXCLUDED.country
+    country = EXCLUDED.country, orphaned_at = NULL
 RETURNING id
 `
 
@@ -230,10 +193,156 @@ func (q *Queries) InsertClient(ctx context.Context, arg InsertClientParams) (uui
 	return uid, err
 }
 
+const purgeClients = `-- name: PurgeClients :many
+DELETE FROM clients c
+WHERE c.uid IN (
+    SELECT o.uid
+    FROM clients o
+    WHERE o.deleted_at <= $1::timestamptz
+    ORDER BY o.deleted_at, o.uid
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING c.uid
+`
+
+type PurgeClientsParams struct {
+	DeletedBefore time.Time
+	Batch         int32
+}
+
+func (q *Queries) PurgeClients(ctx context.Context, arg PurgeClientsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, purgeClients, arg.DeletedBefore, arg.Batch)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var uid uuid.UUID
+		if err := rows.Scan(&uid); err != nil {
+			return nil, err
+		}
+		items = append(items, uid)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreClient = `-- name: RestoreClient :one
+UPDATE clients
+SET deleted_at = NULL
+WHERE uid = $1
+RETURNING uid
+`
+
+func (q *Queries) RestoreClient(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, restoreClient, uid)
+	err := row.Scan(&uid)
+	return uid, err
+}
+
+const searchClientsByName = `-- name: SearchClientsByName :many
+SELECT client_name, client_surname, birthday, gender, uid, registration_date, country, city, street, deleted_at, score
+FROM (
+    SELECT cd.client_name, cd.client_surname, cd.birthday, cd.gender, cd.uid, cd.registration_date, cd.country, cd.city, cd.street, cd.deleted_at, GREATEST(
+        word_similarity($1::text, cd.client_name || ' ' || cd.client_surname),
+        word_similarity($2::text, cd.client_name || ' ' || cd.client_surname)
+    )::float8 AS score
+    FROM client_details cd
+    WHERE ($3::bool OR cd.deleted_at IS NULL)
+        AND ($1::text <% (cd.client_name || ' ' || cd.client_surname)
+            OR $2::text <% (cd.client_name || ' ' || cd.client_surname))
+) matches
+WHERE matches.score >= $4::float8
+ORDER BY matches.score DESC, matches.uid
+OFFSET $5::int
+LIMIT NULLIF($6::int, 0)
+`
+
+type SearchClientsByNameParams struct {
+	Query          string
+	AltQuery       string
+	IncludeDeleted bool
+	MinScore       float64
+	PageOffset     int32
+	PageLimit      int32
+}
+
+type SearchClientsByNameRow struct {
+	ClientName       string
+	ClientSurname    string
+	Birthday         time.Time
+	Gender           string
+	Uid              uuid.UUID
+	RegistrationDate time.Time
+	Country          string
+	City             string
+	Street           string
+	DeletedAt        sql.NullTime
+	Score            float64
+}
+
+func (q *Queries) SearchClientsByName(ctx context.Context, arg SearchClientsByNameParams) ([]SearchClientsByNameRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchClientsByName,
+		arg.Query,
+		arg.AltQuery,
+		arg.IncludeDeleted,
+		arg.MinScore,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchClientsByNameRow
+	for rows.Next() {
+		var i SearchClientsByNameRow
+		if err := rows.Scan(
+			&i.ClientName,
+			&i.ClientSurname,
+			&i.Birthday,
+			&i.Gender,
+			&i.Uid,
+			&i.RegistrationDate,
+			&i.Country,
+			&i.City,
+			&i.Street,
+			&i.DeletedAt,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setWordSimilarityThreshold = `-- name: SetWordSimilarityThreshold :exec
+SELECT set_config('pg_trgm.word_similarity_threshold', $1::float8::text, true)
+`
+
+func (q *Queries) SetWordSimilarityThreshold(ctx context.Context, threshold float64) error {
+	_, err := q.db.ExecContext(ctx, setWordSimilarityThreshold, threshold)
+	return err
+}
+
 const updateClientAddress = `-- name: UpdateClientAddress :one
 UPDATE addresses
 SET country = $1, city = $2, street = $3
-WHERE (SELECT address_id FROM clients WHERE uid = $4) = id
+WHERE (SELECT address_id FROM clients WHERE uid = $4 AND deleted_at IS NULL) = id
 RETURNING id
 `
 
@@ -7,32 +7,76 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const acquireBlob = `-- name: AcquireBlob :one
+INSERT INTO blobs (storage_key, hash, size, mime, ref_count)
+VALUES ($1, $2, $3, $4, 1)
+ON CONFLICT (hash) DO UPDATE SET ref_count = blobs.ref_count + 1
+RETURNING storage_key
+`
+
+type AcquireBlobParams struct {
+	StorageKey string
+	Hash       string
+	Size       int64
+	Mime       string
+}
+
+func (q *Queries) AcquireBlob(ctx context.Context, arg AcquireBlobParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, acquireBlob,
+		arg.StorageKey,
+		arg.Hash,
+		arg.Size,
+		arg.Mime,
+	)
+	var storage_key string
+	err := row.Scan(&storage_key)
+	return storage_key, err
+}
+
 const addImage = `-- name: AddImage :one
-INSERT INTO images (uid, image)
-VALUES ($1, $2)
+INSERT INTO images (uid, size, mime, hash, storage_key, width, height, format)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 ON CONFLICT (uid) DO NOTHING
 RETURNING uid
 `
 
 type AddImageParams struct {
-	Uid   uuid.UUID
-	Image []byte
+	Uid        uuid.UUID
+	Size       int64
+	Mime       string
+	Hash       string
+	StorageKey sql.NullString
+	Width      int32
+	Height     int32
+	Format     string
 }
 
 func (q *Queries) AddImage(ctx context.Context, arg AddImageParams) (uuid.UUID, error) {
-	row := q.db.QueryRowContext(ctx, addImage, arg.Uid, arg.Image)
+	row := q.db.QueryRowContext(ctx, addImage,
+		arg.Uid,
+		arg.Size,
+		arg.Mime,
+		arg.Hash,
+		arg.StorageKey,
+		arg.Width,
+		arg.Height,
+		arg.Format,
+	)
 	var uid uuid.UUID
 	err := row.Scan(&uid)
 	return uid, err
 }
 
 const deleteImage = `-- name: DeleteImage :one
-DELETE FROM images
-WHERE uid = $1
+UPDATE images
+SET deleted_at = now()
+WHERE uid = $1 AND deleted_at IS NULL
 RETURNING uid
 `
 
@@ -42,45 +86,340 @@ func (q *Queries) DeleteImage(ctx context.Context, uid uuid.UUID) (uuid.UUID, er
 	return uid, err
 }
 
+const deleteUnusedBlob = `-- name: DeleteUnusedBlob :exec
+DELETE FROM blobs
+WHERE storage_key = $1 AND ref_count = 0
+`
+
+func (q *Queries) DeleteUnusedBlob(ctx context.Context, storageKey string) error {
+	_, err := q.db.ExecContext(ctx, deleteUnusedBlob, storageKey)
+	return err
+}
+
 const getImage = `-- name: GetImage :one
-SELECT image FROM images
-WHERE uid = $1
+SELECT uid, image, size, mime, hash, storage_key, updated_at, width, height, format, orphaned_at, deleted_at FROM images
+WHERE uid = $1 AND ($2::bool OR deleted_at IS NULL)
 `
 
-func (q *Queries) GetImage(ctx context.Context, uid uuid.UUID) ([]byte, error) {
-	row := q.db.QueryRowContext(ctx, getImage, uid)
-	var image []byte
-	err := row.Scan(&image)
-	return image, err
+type GetImageParams struct {
+	Uid            uuid.UUID
+	IncludeDeleted bool
+}
+
+func (q *Queries) GetImage(ctx context.Context, arg GetImageParams) (Image, error) {
+	row := q.db.QueryRowContext(ctx, getImage, arg.Uid, arg.IncludeDeleted)
+	var i Image
+	err := row.Scan(
+		&i.Uid,
+		&i.Image,
+		&i.Size,
+		&i.Mime,
+		&i.Hash,
+		&i.StorageKey,
+		&i.UpdatedAt,
+		&i.Width,
+		&i.Height,
+		&i.Format,
+		&i.OrphanedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getImageMeta = `-- name: GetImageMeta :one
+SELECT uid, size, mime, hash, width, height, format, updated_at, deleted_at FROM images
+WHERE uid = $1 AND ($2::bool OR deleted_at IS NULL)
+`
+
+type GetImageMetaParams struct {
+	Uid            uuid.UUID
+	IncludeDeleted bool
+}
+
+type GetImageMetaRow struct {
+	Uid       uuid.UUID
+	Size      int64
+	Mime      string
+	Hash      string
+	Width     int32
+	Height    int32
+	Format    string
+	UpdatedAt time.Time
+	DeletedAt sql.NullTime
+}
+
+func (q *Queries) GetImageMeta(ctx context.Context, arg GetImageMetaParams) (GetImageMetaRow, error) {
+	row := q.db.QueryRowContext(ctx, getImageMeta, arg.Uid, arg.IncludeDeleted)
+	var i GetImageMetaRow
+	err := row.Scan(
+		&i.Uid,
+		&i.Size,
+		&i.Mime,
+		&i.Hash,
+		&i.Width,
+		&i.Height,
+		&i.Format,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getImageProducts = `-- name: GetImageProducts :many
+SELECT pi.product_id
+FROM product_images pi
+JOIN products p ON p.uid = pi.product_id
+WHERE pi.image_id = $1 AND p.deleted_at IS NULL
+UNION
+SELECT pv.product_id
+FROM product_variants pv
+JOIN products p ON p.uid = pv.product_id
+WHERE pv.image_id = $1 AND p.deleted_at IS NULL
+ORDER BY product_id
+`
+
+func (q *Queries) GetImageProducts(ctx context.Context, imageID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getImageProducts, imageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var product_id uuid.UUID
+		if err := rows.Scan(&product_id); err != nil {
+			return nil, err
+		}
+		items = append(items, product_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInlineImages = `-- name: GetInlineImages :many
+SELECT uid, image FROM images
+WHERE storage_key IS NULL
+ORDER BY uid
+LIMIT $1
+`
+
+type GetInlineImagesRow struct {
+	Uid   uuid.UUID
+	Image []byte
+}
+
+func (q *Queries) GetInlineImages(ctx context.Context, limit int32) ([]GetInlineImagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getInlineImages, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetInlineImagesRow
+	for rows.Next() {
+		var i GetInlineImagesRow
+		if err := rows.Scan(&i.Uid, &i.Image); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getProductImage = `-- name: GetProductImage :one
-SELECT uid, image FROM images i
-WHERE i.uid = (SELECT image_id FROM products p WHERE p.uid = $1)
+SELECT i.uid, i.image, i.size, i.mime, i.hash, i.storage_key, i.updated_at, i.width, i.height, i.format, i.orphaned_at, i.deleted_at FROM images i
+JOIN product_images pi ON pi.image_id = i.uid
+WHERE pi.product_id = $1 AND pi.is_primary AND i.deleted_at IS NULL
 `
 
-func (q *Queries) GetProductImage(ctx context.Context, uid uuid.UUID) (Image, error) {
-	row := q.db.QueryRowContext(ctx, getProductImage, uid)
+func (q *Queries) GetProductImage(ctx context.Context, productID uuid.UUID) (Image, error) {
+	row := q.db.QueryRowContext(ctx, getProductImage, productID)
 	var i Image
-	err := row.Scan(&i.Uid, &i.Image)
+	err := row.Scan(
+		&i.Uid,
+		&i.Image,
+		&i.Size,
+		&i.Mime,
+		&i.Hash,
+		&i.StorageKey,
+		&i.UpdatedAt,
+		&i.Width,
+		&i.Height,
+		&i.Format,
+		&i.OrphanedAt,
+		&i.DeletedAt,
+	)
 	return i, err
 }
 
-const updateImage = `-- name: UpdateImage :one
+const lockImage = `-- name: LockImage :one
+SELECT i.uid
+FROM images i
+WHERE i.uid = $1 AND i.deleted_at IS NULL
+FOR UPDATE
+`
+
+func (q *Queries) LockImage(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, lockImage, uid)
+	err := row.Scan(&uid)
+	return uid, err
+}
+
+const purgeImages = `-- name: PurgeImages :many
+DELETE FROM images i
+WHERE i.uid IN (
+    SELECT o.uid
+    FROM images o
+    WHERE o.deleted_at <= $1::timestamptz
+        AND NOT EXISTS (SELECT 1 FROM product_images pi WHERE pi.image_id = o.uid)
+        AND NOT EXISTS (SELECT 1 FROM product_variants pv WHERE pv.image_id = o.uid)
+    ORDER BY o.deleted_at, o.uid
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING i.uid, i.storage_key
+`
+
+type PurgeImagesParams struct {
+	DeletedBefore time.Time
+	Batch         int32
+}
+
+type PurgeImagesRow struct {
+	Uid        uuid.UUID
+	StorageKey sql.NullString
+}
+
+func (q *Queries) PurgeImages(ctx context.Context, arg PurgeImagesParams) ([]PurgeImagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, purgeImages, arg.DeletedBefore, arg.Batch)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PurgeImagesRow
+	for rows.Next() {
+		var i PurgeImagesRow
+		if err := rows.Scan(&i.Uid, &i.StorageKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const releaseBlob = `-- name: ReleaseBlob :one
+UPDATE blobs
+SET ref_count = ref_count - 1
+WHERE storage_key = $1
+RETURNING ref_count
+`
+
+func (q *Queries) ReleaseBlob(ctx context.Context, storageKey string) (int32, error) {
+	row := q.db.QueryRowContext(ctx, releaseBlob, storageKey)
+	var ref_count int32
+	err := row.Scan(&ref_count)
+	return ref_count, err
+}
+
+const restoreImage = `-- name: RestoreImage :one
+UPDATE images
+SET deleted_at = NULL
+WHERE uid = $1
+RETURNING uid
+`
+
+func (q *Queries) RestoreImage(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, restoreImage, uid)
+	err := row.Scan(&uid)
+	return uid, err
+}
+
+const setImageStorageKey = `-- name: SetImageStorageKey :one
 UPDATE images
-SET image = $1
-WHERE uid = $2
+SET storage_key = $1, size = $2, mime = $3, hash = $4, width = $5, height = $6, format = $7, image = NULL
+WHERE uid = $8 AND storage_key IS NULL
 RETURNING uid
 `
 
-type UpdateImageParams struct {
-	Image []byte
-	Uid   uuid.UUID
+type SetImageStorageKeyParams struct {
+	StorageKey sql.NullString
+	Size       int64
+	Mime       string
+	Hash       string
+	Width      int32
+	Height     int32
+	Format     string
+	Uid        uuid.UUID
 }
 
-func (q *Queries) UpdateImage(ctx context.Context, arg UpdateImageParams) (uuid.UUID, error) {
-	row := q.db.QueryRowContext(ctx, updateImage, arg.Image, arg.Uid)
+func (q *Queries) SetImageStorageKey(ctx context.Context, arg SetImageStorageKeyParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, setImageStorageKey,
+		arg.StorageKey,
+		arg.Size,
+		arg.Mime,
+		arg.Hash,
+		arg.Width,
+		arg.Height,
+		arg.Format,
+		arg.Uid,
+	)
 	var uid uuid.UUID
 	err := row.Scan(&uid)
 	return uid, err
 }
+
+const updateImage = `-- name: UpdateImage :one
+WITH old AS (
+    SELECT o.uid, o.storage_key FROM images o WHERE o.uid = $1 AND o.deleted_at IS NULL FOR UPDATE
+)
+UPDATE images i
+SET size = $2, mime = $3, hash = $4,
+    storage_key = $5, width = $6, height = $7,
+    format = $8, image = NULL, updated_at = now()
+FROM old
+WHERE i.uid = old.uid
+RETURNING old.storage_key
+`
+
+type UpdateImageParams struct {
+	Uid        uuid.UUID
+	Size       int64
+	Mime       string
+	Hash       string
+	StorageKey sql.NullString
+	Width      int32
+	Height     int32
+	Format     string
+}
+
+func (q *Queries) UpdateImage(ctx context.Context, arg UpdateImageParams) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, updateImage,
+		arg.Uid,
+		arg.Size,
+		arg.Mime,
+		arg.Hash,
+		arg.StorageKey,
+		arg.Width,
+		arg.Height,
+		arg.Format,
+	)
+	var storage_key sql.NullString
+	err := row.Scan(&storage_key)
+	return storage_key, err
+}
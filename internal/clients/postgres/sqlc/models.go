@@ -5,16 +5,62 @@
 package sqlc
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Address struct {
-	ID      int32
-	Country string
-	City    string
-	Street  string
+	ID         int32
+	Country    string
+	City       string
+	Street     string
+	OrphanedAt sql.NullTime
+}
+
+type ApiKey struct {
+	Uid        uuid.UUID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	CreatedAt  time.Time
+	ExpiresAt  sql.NullTime
+	LastUsedAt sql.NullTime
+	RevokedAt  sql.NullTime
+	Roles      []string
+}
+
+type AuditLog struct {
+	ID          int64
+	CreatedAt   time.Time
+	Action      string
+	Entity      string
+	EntityID    string
+	Actor       string
+	ActorKind   string
+	ActorKey    uuid.NullUUID
+	RequestID   string
+	BeforeState json.RawMessage
+	AfterState  json.RawMessage
+}
+
+type Blob struct {
+	StorageKey string
+	Hash       string
+	Size       int64
+	Mime       string
+	RefCount   int32
+}
+
+type Category struct {
+	Uid             uuid.UUID
+	ParentID        uuid.NullUUID
+	Name            string
+	Slug            string
+	SortOrder       int32
+	AttributeSchema json.RawMessage
 }
 
 type Client struct {
@@ -25,6 +71,7 @@ type Client struct {
 	Gender           string
 	RegistrationDate time.Time
 	AddressID        int32
+	DeletedAt        sql.NullTime
 }
 
 type ClientDetail struct {
@@ -37,22 +84,144 @@ type ClientDetail struct {
 	Country          string
 	City             string
 	Street           string
+	DeletedAt        sql.NullTime
+}
+
+type ClientsHistory struct {
+	Uid              uuid.UUID
+	Version          int32
+	ValidFrom        time.Time
+	ValidTo          sql.NullTime
+	TxID             int64
+	ClientName       string
+	ClientSurname    string
+	Birthday         time.Time
+	Gender           string
+	RegistrationDate time.Time
+	Country          string
+	City             string
+	Street           string
+	DeletedAt        sql.NullTime
+}
+
+type ExchangeRate struct {
+	Currency  string
+	Rate      string
+	UpdatedAt time.Time
 }
 
 type Image struct {
-	Uid   uuid.UUID
-	Image []byte
+	Uid        uuid.UUID
+	Image      []byte
+	Size       int64
+	Mime       string
+	Hash       string
+	StorageKey sql.NullString
+	UpdatedAt  time.Time
+	Width      int32
+	Height     int32
+	Format     string
+	OrphanedAt sql.NullTime
+	DeletedAt  sql.NullTime
 }
 
 type Product struct {
 	Uid            uuid.UUID
 	Name           string
-	Category       string
+	LastUpdateDate time.Time
+	SupplierID     uuid.UUID
+	CategoryID     uuid.UUID
+	Options        json.RawMessage
+	Attributes     json.RawMessage
+	Sku            string
+	Barcode        sql.NullString
+	DeletedAt      sql.NullTime
+}
+
+type ProductDocument struct {
+	Uid        uuid.UUID
+	ProductID  uuid.UUID
+	Title      string
+	StorageKey string
+	Size       int64
+	Mime       string
+	Hash       string
+	CreatedAt  time.Time
+}
+
+type ProductImage struct {
+	ProductID uuid.UUID
+	ImageID   uuid.UUID
+	Position  int32
+	AltText   string
+	IsPrimary bool
+}
+
+type ProductVariant struct {
+	Uid            uuid.UUID
+	ProductID      uuid.UUID
+	Sku            string
+	Options        json.RawMessage
 	Price          int64
 	AvailableStock int64
+	ImageID        uuid.NullUUID
+	Barcode        sql.NullString
+	Currency       string
+}
+
+type ProductsHistory struct {
+	Uid            uuid.UUID
+	Version        int32
+	ValidFrom      time.Time
+	ValidTo        sql.NullTime
+	TxID           int64
+	Name           string
 	LastUpdateDate time.Time
 	SupplierID     uuid.UUID
-	ImageID        uuid.UUID
+	CategoryID     uuid.UUID
+	Options        json.RawMessage
+	Attributes     json.RawMessage
+	Sku            string
+	Barcode        sql.NullString
+	DeletedAt      sql.NullTime
+	Variants       json.RawMessage
+	Images         json.RawMessage
+}
+
+type Promotion struct {
+	Uid            uuid.UUID
+	Name           string
+	Code           sql.NullString
+	Kind           string
+	Percent        int32
+	Amount         int64
+	Currency       string
+	ProductIds     []uuid.UUID
+	CategoryIds    []uuid.UUID
+	SupplierIds    []uuid.UUID
+	StartsAt       sql.NullTime
+	EndsAt         sql.NullTime
+	UsageLimit     sql.NullInt32
+	PerClientLimit sql.NullInt32
+	Stackable      bool
+	Priority       int32
+	CreatedAt      time.Time
+}
+
+type PromotionRedemption struct {
+	ID          int64
+	PromotionID uuid.UUID
+	ClientID    uuid.UUID
+	OrderID     string
+	Discount    int64
+	Currency    string
+	RedeemedAt  time.Time
+}
+
+type Role struct {
+	Name        string
+	Description string
+	Permissions []string
 }
 
 type Supplier struct {
@@ -60,6 +229,7 @@ type Supplier struct {
 	Name        string
 	PhoneNumber string
 	AddressID   int32
+	DeletedAt   sql.NullTime
 }
 
 type SupplierDetail struct {
@@ -69,4 +239,29 @@ type SupplierDetail struct {
 	Country     string
 	City        string
 	Street      string
+	DeletedAt   sql.NullTime
+}
+
+type SuppliersHistory struct {
+	Uid         uuid.UUID
+	Version     int32
+	ValidFrom   time.Time
+	ValidTo     sql.NullTime
+	TxID        int64
+	Name        string
+	PhoneNumber string
+	Country     string
+	City        string
+	Street      string
+	DeletedAt   sql.NullTime
+}
+
+type VariantPrice struct {
+	ID            int64
+	VariantID     uuid.UUID
+	Price         int64
+	EffectiveFrom time.Time
+	EffectiveTo   sql.NullTime
+	CreatedAt     time.Time
+	Currency      string
 }
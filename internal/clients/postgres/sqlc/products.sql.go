@@ -7,33 +7,18 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
-const decreaseProduct = `-- name: DecreaseProduct :one
-UPDATE products
-SET available_stock = available_stock - $1
-WHERE uid = $2
-RETURNING available_stock
-`
-
-type DecreaseProductParams struct {
-	Amount int64
-	Uid    uuid.UUID
-}
-
-func (q *Queries) DecreaseProduct(ctx context.Context, arg DecreaseProductParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, decreaseProduct, arg.Amount, arg.Uid)
-	var available_stock int64
-	err := row.Scan(&available_stock)
-	return available_stock, err
-}
-
 const deleteProduct = `-- name: DeleteProduct :one
-DELETE FROM products p
-WHERE p.uid = $1
+UPDATE products p
+SET deleted_at = now()
+WHERE p.uid = $1 AND p.deleted_at IS NULL
 RETURNING p.uid
 `
 
@@ -44,13 +29,27 @@ func (q *Queries) DeleteProduct(ctx context.Context, uid uuid.UUID) (uuid.UUID,
 }
 
 const getAllProducts = `-- name: GetAllProducts :many
-SELECT uid, name, category, price, available_stock, last_update_date, supplier_id, image_id
+WITH RECURSIVE category_tree AS (
+    SELECT c.uid FROM categories c WHERE c.uid = $1::uuid
+    UNION ALL
+    SELECT c.uid FROM categories c JOIN category_tree ct ON c.parent_id = ct.uid
+)
+SELECT p.uid, p.name, p.last_update_date, p.supplier_id, p.category_id, p.options, p.attributes, p.sku, p.barcode, p.deleted_at
 FROM products p
+WHERE ($1::uuid IS NULL OR p.category_id IN (SELECT uid FROM category_tree))
+    AND p.attributes @> ANY($2::jsonb[])
+    AND ($3::bool OR p.deleted_at IS NULL)
 ORDER BY p.uid
 `
 
-func (q *Queries) GetAllProducts(ctx context.Context) ([]Product, error) {
-	rows, err := q.db.QueryContext(ctx, getAllProducts)
+type GetAllProductsParams struct {
+	CategoryID     uuid.NullUUID
+	Attributes     []json.RawMessage
+	IncludeDeleted bool
+}
+
+func (q *Queries) GetAllProducts(ctx context.Context, arg GetAllProductsParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, getAllProducts, arg.CategoryID, pq.Array(arg.Attributes), arg.IncludeDeleted)
 	if err != nil {
 		return nil, err
 	}
@@ -61,12 +60,14 @@ func (q *Queries) GetAllProducts(ctx context.Context) ([]Product, error) {
 		if err := rows.Scan(
 			&i.Uid,
 			&i.Name,
-			&i.Category,
-			&i.Price,
-			&i.AvailableStock,
 			&i.LastUpdateDate,
 			&i.SupplierID,
-			&i.ImageID,
+			&i.CategoryID,
+			&i.Options,
+			&i.Attributes,
+			&i.Sku,
+			&i.Barcode,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -82,42 +83,147 @@ func (q *Queries) GetAllProducts(ctx context.Context) ([]Product, error) {
 }
 
 const getProduct = `-- name: GetProduct :one
-SELECT uid, name, category, price, available_stock, last_update_date, supplier_id, image_id
+SELECT uid, name, last_update_date, supplier_id, category_id, options, attributes, sku, barcode, deleted_at
 FROM products p
-WHERE p.uid = $1
+WHERE p.uid = $1 AND ($2::bool OR p.deleted_at IS NULL)
 `
 
-func (q *Queries) GetProduct(ctx context.Context, uid uuid.UUID) (Product, error) {
-	row := q.db.QueryRowContext(ctx, getProduct, uid)
+type GetProductParams struct {
+	Uid            uuid.UUID
+	IncludeDeleted bool
+}
+
+func (q *Queries) GetProduct(ctx context.Context, arg GetProductParams) (Product, error) {
+	row := q.db.QueryRowContext(ctx, getProduct, arg.Uid, arg.IncludeDeleted)
 	var i Product
 	err := row.Scan(
 		&i.Uid,
 		&i.Name,
-		&i.Category,
-		&i.Price,
-		&i.AvailableStock,
 		&i.LastUpdateDate,
 		&i.SupplierID,
-		&i.ImageID,
+		&i.CategoryID,
+		&i.Options,
+		&i.Attributes,
+		&i.Sku,
+		&i.Barcode,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
+const getProductAttributeSchema = `-- name: GetProductAttributeSchema :one
+SELECT c.attribute_schema
+FROM products p
+JOIN categories c ON c.uid = p.category_id
+WHERE p.uid = $1 AND p.deleted_at IS NULL
+`
+
+func (q *Queries) GetProductAttributeSchema(ctx context.Context, uid uuid.UUID) (json.RawMessage, error) {
+	row := q.db.QueryRowContext(ctx, getProductAttributeSchema, uid)
+	var attribute_schema json.RawMessage
+	err := row.Scan(&attribute_schema)
+	return attribute_schema, err
+}
+
+const getProductByBarcode = `-- name: GetProductByBarcode :one
+SELECT p.uid, p.name, p.last_update_date, p.supplier_id, p.category_id, p.options, p.attributes, p.sku, p.barcode, p.deleted_at
+FROM products p
+WHERE (p.barcode = $1
+    OR p.uid = (SELECT v.product_id FROM product_variants v WHERE v.barcode = $1))
+    AND p.deleted_at IS NULL
+LIMIT 1
+`
+
+func (q *Queries) GetProductByBarcode(ctx context.Context, barcode sql.NullString) (Product, error) {
+	row := q.db.QueryRowContext(ctx, getProductByBarcode, barcode)
+	var i Product
+	err := row.Scan(
+		&i.Uid,
+		&i.Name,
+		&i.LastUpdateDate,
+		&i.SupplierID,
+		&i.CategoryID,
+		&i.Options,
+		&i.Attributes,
+		&i.Sku,
+		&i.Barcode,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getProductBySku = `-- name: GetProductBySku :one
+SELECT p.uid, p.name, p.last_update_date, p.supplier_id, p.category_id, p.options, p.attributes, p.sku, p.barcode, p.deleted_at
+FROM products p
+WHERE (p.sku = $1
+    OR p.uid = (SELECT v.product_id FROM product_variants v WHERE v.sku = $1))
+    AND p.deleted_at IS NULL
+LIMIT 1
+`
+
+func (q *Queries) GetProductBySku(ctx context.Context, sku string) (Product, error) {
+	row := q.db.QueryRowContext(ctx, getProductBySku, sku)
+	var i Product
+	err := row.Scan(
+		&i.Uid,
+		&i.Name,
+		&i.LastUpdateDate,
+		&i.SupplierID,
+		&i.CategoryID,
+		&i.Options,
+		&i.Attributes,
+		&i.Sku,
+		&i.Barcode,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getProductOptions = `-- name: GetProductOptions :one
+SELECT p.options
+FROM products p
+WHERE p.uid = $1 AND p.deleted_at IS NULL
+`
+
+func (q *Queries) GetProductOptions(ctx context.Context, uid uuid.UUID) (json.RawMessage, error) {
+	row := q.db.QueryRowContext(ctx, getProductOptions, uid)
+	var options json.RawMessage
+	err := row.Scan(&options)
+	return options, err
+}
+
 const getProductsPage = `-- name: GetProductsPage :many
-SELECT uid, name, category, price, available_stock, last_update_date, supplier_id, image_id
+WITH RECURSIVE category_tree AS (
+    SELECT c.uid FROM categories c WHERE c.uid = $1::uuid
+    UNION ALL
+    SELECT c.uid FROM categories c JOIN category_tree ct ON c.parent_id = ct.uid
+)
+SELECT p.uid, p.name, p.last_update_date, p.supplier_id, p.category_id, p.options, p.attributes, p.sku, p.barcode, p.deleted_at
 FROM products p
+WHERE ($1::uuid IS NULL OR p.category_id IN (SELECT uid FROM category_tree))
+    AND p.attributes @> ANY($2::jsonb[])
+    AND ($3::bool OR p.deleted_at IS NULL)
 ORDER BY p.uid
-OFFSET $1
-LIMIT $2
+OFFSET $4
+LIMIT $5
 `
 
 type GetProductsPageParams struct {
-	Offset int32
-	Limit  int32
+	CategoryID     uuid.NullUUID
+	Attributes     []json.RawMessage
+	IncludeDeleted bool
+	PageOffset     int32
+	PageLimit      int32
 }
 
 func (q *Queries) GetProductsPage(ctx context.Context, arg GetProductsPageParams) ([]Product, error) {
-	rows, err := q.db.QueryContext(ctx, getProductsPage, arg.Offset, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, getProductsPage,
+		arg.CategoryID,
+		pq.Array(arg.Attributes),
+		arg.IncludeDeleted,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -128,12 +234,14 @@ func (q *Queries) GetProductsPage(ctx context.Context, arg GetProductsPageParams
 		if err := rows.Scan(
 			&i.Uid,
 			&i.Name,
-			&i.Category,
-			&i.Price,
-			&i.AvailableStock,
 			&i.LastUpdateDate,
 			&i.SupplierID,
-			&i.ImageID,
+			&i.CategoryID,
+			&i.Options,
+			&i.Attributes,
+			&i.Sku,
+			&i.Barcode,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -148,11 +256,67 @@ func (q *Queries) GetProductsPage(ctx context.Context, arg GetProductsPageParams
 	return items, nil
 }
 
+const getPurgeableProducts = `-- name: GetPurgeableProducts :many
+SELECT p.uid
+FROM products p
+WHERE p.deleted_at <= $1::timestamptz
+ORDER BY p.deleted_at, p.uid
+LIMIT $2
+FOR UPDATE SKIP LOCKED
+`
+
+type GetPurgeableProductsParams struct {
+	DeletedBefore time.Time
+	Batch         int32
+}
+
+func (q *Queries) GetPurgeableProducts(ctx context.Context, arg GetPurgeableProductsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getPurgeableProducts, arg.DeletedBefore, arg.Batch)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var uid uuid.UUID
+		if err := rows.Scan(&uid); err != nil {
+			return nil, err
+		}
+		items = append(items, uid)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const hasDeletedReferences = `-- name: HasDeletedReferences :one
+SELECT (
+    EXISTS(SELECT 1 FROM suppliers s WHERE s.uid = p.supplier_id AND s.deleted_at IS NOT NULL)
+    OR EXISTS(SELECT 1 FROM product_images pi JOIN images i ON i.uid = pi.image_id
+        WHERE pi.product_id = p.uid AND i.deleted_at IS NOT NULL)
+    OR EXISTS(SELECT 1 FROM product_variants pv JOIN images i ON i.uid = pv.image_id
+        WHERE pv.product_id = p.uid AND i.deleted_at IS NOT NULL)
+)::bool AS has_deleted
+FROM products p
+WHERE p.uid = $1
+`
+
+func (q *Queries) HasDeletedReferences(ctx context.Context, uid uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, hasDeletedReferences, uid)
+	var has_deleted bool
+	err := row.Scan(&has_deleted)
+	return has_deleted, err
+}
+
 const insertProduct = `-- name: InsertProduct :one
-INSERT INTO products (uid, name, category, price,
-    available_stock, last_update_date, supplier_id, image_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-ON CONFLICT (uid)
+INSERT INTO products (uid, name, category_id, options,
+    last_update_date, supplier_id, attributes, sku, barcode)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT
 DO NOTHING
 RETURNING uid
 `
@@ -160,60 +324,84 @@ RETURNING uid
 type InsertProductParams struct {
 	Uid            uuid.UUID
 	Name           string
-	Category       string
-	Price          int64
-	AvailableStock int64
+	CategoryID     uuid.UUID
+	Options        json.RawMessage
 	LastUpdateDate time.Time
 	SupplierID     uuid.UUID
-	ImageID        uuid.UUID
+	Attributes     json.RawMessage
+	Sku            string
+	Barcode        sql.NullString
 }
 
 func (q *Queries) InsertProduct(ctx context.Context, arg InsertProductParams) (uuid.UUID, error) {
 	row := q.db.QueryRowContext(ctx, insertProduct,
 		arg.Uid,
 		arg.Name,
-		arg.Category,
-		arg.Price,
-		arg.AvailableStock,
+		arg.CategoryID,
+		arg.Options,
 		arg.LastUpdateDate,
 		arg.SupplierID,
-		arg.ImageID,
+		arg.Attributes,
+		arg.Sku,
+		arg.Barcode,
 	)
 	var uid uuid.UUID
 	err := row.Scan(&uid)
 	return uid, err
 }
 
-const isImageAndSupplierExists = `-- name: IsImageAndSupplierExists :one
-SELECT (
-    EXISTS(SELECT 1 FROM images i WHERE i.uid = $1)
-    AND
-    EXISTS(SELECT 1 FROM suppliers s WHERE s.uid = $2)
-)::bool AS is_exists
+const isProductExists = `-- name: IsProductExists :one
+SELECT EXISTS(SELECT 1 FROM products p WHERE p.uid = $1 AND p.deleted_at IS NULL)::bool AS is_exists
 `
 
-type IsImageAndSupplierExistsParams struct {
-	ImageUid    uuid.UUID
-	SupplierUid uuid.UUID
-}
-
-func (q *Queries) IsImageAndSupplierExists(ctx context.Context, arg IsImageAndSupplierExistsParams) (bool, error) {
-	row := q.db.QueryRowContext(ctx, isImageAndSupplierExists, arg.ImageUid, arg.SupplierUid)
+func (q *Queries) IsProductExists(ctx context.Context, uid uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isProductExists, uid)
 	var is_exists bool
 	err := row.Scan(&is_exists)
 	return is_exists, err
 }
 
-const lockStockForUpdate = `-- name: LockStockForUpdate :one
-SELECT available_stock
-FROM products
-WHERE uid = $1
-FOR UPDATE
+const purgeProduct = `-- name: PurgeProduct :one
+DELETE FROM products p
+WHERE p.uid = $1
+RETURNING p.uid
+`
+
+func (q *Queries) PurgeProduct(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, purgeProduct, uid)
+	err := row.Scan(&uid)
+	return uid, err
+}
+
+const restoreProduct = `-- name: RestoreProduct :one
+UPDATE products p
+SET deleted_at = NULL
+WHERE p.uid = $1
+RETURNING p.uid
+`
+
+func (q *Queries) RestoreProduct(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, restoreProduct, uid)
+	err := row.Scan(&uid)
+	return uid, err
+}
+
+const updateProductAttributes = `-- name: UpdateProductAttributes :one
+UPDATE products
+SET attributes = $1, last_update_date = $2
+WHERE uid = $3 AND deleted_at IS NULL
+RETURNING uid
 `
 
-func (q *Queries) LockStockForUpdate(ctx context.Context, uid uuid.UUID) (int64, error) {
-	row := q.db.QueryRowContext(ctx, lockStockForUpdate, uid)
-	var available_stock int64
-	err := row.Scan(&available_stock)
-	return available_stock, err
+type UpdateProductAttributesParams struct {
+	Attributes     json.RawMessage
+	LastUpdateDate time.Time
+	Uid            uuid.UUID
+}
+
+func (q *Queries) UpdateProductAttributes(ctx context.Context, arg UpdateProductAttributesParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, updateProductAttributes, arg.Attributes, arg.LastUpdateDate, arg.Uid)
+	var uid uuid.UUID
+	err := row.Scan(&uid)
+	return uid, err
 }
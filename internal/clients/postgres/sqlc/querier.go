@@ -6,40 +6,146 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AcquireBlob(ctx context.Context, arg AcquireBlobParams) (string, error)
 	AddImage(ctx context.Context, arg AddImageParams) (uuid.UUID, error)
+	AddProductDocument(ctx context.Context, arg AddProductDocumentParams) (uuid.UUID, error)
 	CalculateClientsWithAddress(ctx context.Context, addressID int32) (int64, error)
 	CalculateSuppliersWithAddress(ctx context.Context, addressID int32) (int64, error)
-	DecreaseProduct(ctx context.Context, arg DecreaseProductParams) (int64, error)
+	ClearProductPrimaryImage(ctx context.Context, productID uuid.UUID) error
+	DecreaseVariantStock(ctx context.Context, arg DecreaseVariantStockParams) (int64, error)
 	DeleteAddress(ctx context.Context, id int32) error
-	DeleteClient(ctx context.Context, uid uuid.UUID) (int32, error)
+	DeleteCategory(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	DeleteClient(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
 	DeleteImage(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	DeleteOrphanedAddresses(ctx context.Context, arg DeleteOrphanedAddressesParams) ([]DeleteOrphanedAddressesRow, error)
+	DeleteOrphanedImages(ctx context.Context, arg DeleteOrphanedImagesParams) ([]DeleteOrphanedImagesRow, error)
 	DeleteProduct(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
-	DeleteSupplier(ctx context.Context, uid uuid.UUID) (int32, error)
-	GetAllClients(ctx context.Context) ([]ClientDetail, error)
-	GetAllProducts(ctx context.Context) ([]Product, error)
-	GetAllSuppliers(ctx context.Context) ([]SupplierDetail, error)
+	DeleteProductDocument(ctx context.Context, uid uuid.UUID) (string, error)
+	DeleteProductDocuments(ctx context.Context, productID uuid.UUID) ([]string, error)
+	DeleteProductImage(ctx context.Context, arg DeleteProductImageParams) (bool, error)
+	DeleteProductVariant(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	DeleteRole(ctx context.Context, name string) (string, error)
+	DeleteSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	DeleteUnusedBlob(ctx context.Context, storageKey string) error
+	GetActiveApiKey(ctx context.Context, keyHash string) (ApiKey, error)
+	GetAllCategories(ctx context.Context) ([]Category, error)
+	GetAllClients(ctx context.Context, includeDeleted bool) ([]ClientDetail, error)
+	GetAllClientsAsOf(ctx context.Context, arg GetAllClientsAsOfParams) ([]ClientDetail, error)
+	GetAllProducts(ctx context.Context, arg GetAllProductsParams) ([]Product, error)
+	GetAllSuppliers(ctx context.Context, includeDeleted bool) ([]SupplierDetail, error)
+	GetApiKeys(ctx context.Context, includeRevoked bool) ([]ApiKey, error)
+	GetApplicablePromotions(ctx context.Context, arg GetApplicablePromotionsParams) ([]GetApplicablePromotionsRow, error)
+	GetAttributeTypes(ctx context.Context, arg GetAttributeTypesParams) ([]GetAttributeTypesRow, error)
+	GetAuditEntries(ctx context.Context, arg GetAuditEntriesParams) ([]AuditLog, error)
+	GetAuditSnapshot(ctx context.Context, arg GetAuditSnapshotParams) (json.RawMessage, error)
+	GetCategory(ctx context.Context, uid uuid.UUID) (Category, error)
+	GetCategoryAttributeSchema(ctx context.Context, uid uuid.UUID) (json.RawMessage, error)
+	GetClientVersions(ctx context.Context, arg GetClientVersionsParams) ([]ClientsHistory, error)
 	GetClientsPage(ctx context.Context, arg GetClientsPageParams) ([]ClientDetail, error)
-	GetClientsWithName(ctx context.Context, arg GetClientsWithNameParams) ([]ClientDetail, error)
-	GetImage(ctx context.Context, uid uuid.UUID) ([]byte, error)
-	GetProduct(ctx context.Context, uid uuid.UUID) (Product, error)
-	GetProductImage(ctx context.Context, uid uuid.UUID) (Image, error)
+	GetClientsPageAsOf(ctx context.Context, arg GetClientsPageAsOfParams) ([]ClientDetail, error)
+	GetDanglingReferences(ctx context.Context) ([]GetDanglingReferencesRow, error)
+	GetEffectivePrices(ctx context.Context, arg GetEffectivePricesParams) ([]GetEffectivePricesRow, error)
+	GetExchangeRates(ctx context.Context) ([]ExchangeRate, error)
+	GetImage(ctx context.Context, arg GetImageParams) (Image, error)
+	GetImageMeta(ctx context.Context, arg GetImageMetaParams) (GetImageMetaRow, error)
+	GetImageProducts(ctx context.Context, imageID uuid.UUID) ([]uuid.UUID, error)
+	GetImagesOfProducts(ctx context.Context, productIds []uuid.UUID) ([]ProductImage, error)
+	GetInlineImages(ctx context.Context, limit int32) ([]GetInlineImagesRow, error)
+	GetNextProductImagePosition(ctx context.Context, productID uuid.UUID) (int32, error)
+	GetOrderClient(ctx context.Context, orderID string) (uuid.UUID, error)
+	GetOrphanedAddresses(ctx context.Context, orphanedBefore time.Time) ([]GetOrphanedAddressesRow, error)
+	GetOrphanedImages(ctx context.Context, orphanedBefore time.Time) ([]GetOrphanedImagesRow, error)
+	GetProduct(ctx context.Context, arg GetProductParams) (Product, error)
+	GetProductAsOf(ctx context.Context, arg GetProductAsOfParams) (GetProductAsOfRow, error)
+	GetProductAttributeSchema(ctx context.Context, uid uuid.UUID) (json.RawMessage, error)
+	GetProductByBarcode(ctx context.Context, barcode sql.NullString) (Product, error)
+	GetProductBySku(ctx context.Context, sku string) (Product, error)
+	GetProductDocument(ctx context.Context, uid uuid.UUID) (ProductDocument, error)
+	GetProductDocuments(ctx context.Context, productID uuid.UUID) ([]ProductDocument, error)
+	GetProductImage(ctx context.Context, productID uuid.UUID) (Image, error)
+	GetProductImages(ctx context.Context, productID uuid.UUID) ([]ProductImage, error)
+	GetProductOptions(ctx context.Context, uid uuid.UUID) (json.RawMessage, error)
+	GetProductVariants(ctx context.Context, productID uuid.UUID) ([]ProductVariant, error)
+	GetProductVersions(ctx context.Context, arg GetProductVersionsParams) ([]ProductsHistory, error)
 	GetProductsPage(ctx context.Context, arg GetProductsPageParams) ([]Product, error)
-	GetSupplier(ctx context.Context, uid uuid.UUID) (SupplierDetail, error)
+	GetPromotions(ctx context.Context, includeExpired bool) ([]GetPromotionsRow, error)
+	GetPurgeableProducts(ctx context.Context, arg GetPurgeableProductsParams) ([]uuid.UUID, error)
+	GetRoleApiKeys(ctx context.Context, name string) ([]uuid.UUID, error)
+	GetRoles(ctx context.Context) ([]Role, error)
+	GetRolesPermissions(ctx context.Context, names []string) ([]string, error)
+	GetSupplier(ctx context.Context, arg GetSupplierParams) (SupplierDetail, error)
+	GetSupplierAsOf(ctx context.Context, arg GetSupplierAsOfParams) (SupplierDetail, error)
+	GetSupplierProducts(ctx context.Context, supplierID uuid.UUID) ([]uuid.UUID, error)
+	GetSupplierVersions(ctx context.Context, arg GetSupplierVersionsParams) ([]SuppliersHistory, error)
 	GetSuppliersPage(ctx context.Context, arg GetSuppliersPageParams) ([]SupplierDetail, error)
+	GetUnknownRoles(ctx context.Context, names []string) ([]string, error)
+	GetVariantPrices(ctx context.Context, variantID uuid.UUID) ([]VariantPrice, error)
+	GetVariantsOfProducts(ctx context.Context, productIds []uuid.UUID) ([]ProductVariant, error)
+	HasDeletedReferences(ctx context.Context, uid uuid.UUID) (bool, error)
 	InsertAddress(ctx context.Context, arg InsertAddressParams) (int32, error)
+	InsertApiKey(ctx context.Context, arg InsertApiKeyParams) (ApiKey, error)
+	InsertAuditEntry(ctx context.Context, arg InsertAuditEntryParams) error
+	InsertCategory(ctx context.Context, arg InsertCategoryParams) (uuid.UUID, error)
 	InsertClient(ctx context.Context, arg InsertClientParams) (uuid.UUID, error)
 	InsertProduct(ctx context.Context, arg InsertProductParams) (uuid.UUID, error)
+	InsertProductImage(ctx context.Context, arg InsertProductImageParams) (uuid.UUID, error)
+	InsertProductVariant(ctx context.Context, arg InsertProductVariantParams) (uuid.UUID, error)
+	InsertPromotion(ctx context.Context, arg InsertPromotionParams) (uuid.UUID, error)
+	InsertPromotionRedemption(ctx context.Context, arg InsertPromotionRedemptionParams) (int64, error)
+	InsertRole(ctx context.Context, arg InsertRoleParams) (string, error)
 	InsertSupplier(ctx context.Context, arg InsertSupplierParams) (uuid.UUID, error)
-	IsImageAndSupplierExists(ctx context.Context, arg IsImageAndSupplierExistsParams) (bool, error)
-	LockStockForUpdate(ctx context.Context, uid uuid.UUID) (int64, error)
+	InsertVariantPrice(ctx context.Context, arg InsertVariantPriceParams) (int64, error)
+	IsCategoryExists(ctx context.Context, uid uuid.UUID) (bool, error)
+	IsCategoryInSubtree(ctx context.Context, arg IsCategoryInSubtreeParams) (bool, error)
+	IsCategoryInUse(ctx context.Context, uid uuid.UUID) (bool, error)
+	IsClientExists(ctx context.Context, uid uuid.UUID) (bool, error)
+	IsProductExists(ctx context.Context, uid uuid.UUID) (bool, error)
+	KeepImage(ctx context.Context, uid uuid.UUID) (bool, error)
+	LockClient(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	LockImage(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	LockPromotion(ctx context.Context, arg LockPromotionParams) (LockPromotionRow, error)
+	LockSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	LockVariant(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	LockVariantStockForUpdate(ctx context.Context, uid uuid.UUID) (int64, error)
+	MarkOrphanedAddresses(ctx context.Context) (int64, error)
+	MarkOrphanedImages(ctx context.Context) (int64, error)
+	PromoteFirstProductImage(ctx context.Context, productID uuid.UUID) error
+	PurgeClients(ctx context.Context, arg PurgeClientsParams) ([]uuid.UUID, error)
+	PurgeImages(ctx context.Context, arg PurgeImagesParams) ([]PurgeImagesRow, error)
+	PurgeProduct(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	PurgeSuppliers(ctx context.Context, arg PurgeSuppliersParams) ([]uuid.UUID, error)
+	ReassignSupplierProducts(ctx context.Context, arg ReassignSupplierProductsParams) (int64, error)
+	ReleaseBlob(ctx context.Context, storageKey string) (int32, error)
+	RestoreClient(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	RestoreImage(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	RestoreProduct(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	RestoreSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	RevokeApiKey(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	SearchClientsByName(ctx context.Context, arg SearchClientsByNameParams) ([]SearchClientsByNameRow, error)
+	SetExchangeRate(ctx context.Context, arg SetExchangeRateParams) error
+	SetImageStorageKey(ctx context.Context, arg SetImageStorageKeyParams) (uuid.UUID, error)
+	SetProductImagePosition(ctx context.Context, arg SetProductImagePositionParams) (uuid.UUID, error)
+	SetProductPrimaryImage(ctx context.Context, arg SetProductPrimaryImageParams) (uuid.UUID, error)
+	SetWordSimilarityThreshold(ctx context.Context, threshold float64) error
+	ShareSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error)
+	UnmarkReferencedAddresses(ctx context.Context) (int64, error)
+	UnmarkReferencedImages(ctx context.Context) (int64, error)
+	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (uuid.UUID, error)
 	UpdateClientAddress(ctx context.Context, arg UpdateClientAddressParams) (int32, error)
-	UpdateImage(ctx context.Context, arg UpdateImageParams) (uuid.UUID, error)
+	UpdateImage(ctx context.Context, arg UpdateImageParams) (sql.NullString, error)
+	UpdateProductAttributes(ctx context.Context, arg UpdateProductAttributesParams) (uuid.UUID, error)
+	UpdatePromotion(ctx context.Context, arg UpdatePromotionParams) (uuid.UUID, error)
+	UpdateRole(ctx context.Context, arg UpdateRoleParams) (string, error)
 	UpdateSupplierAddress(ctx context.Context, arg UpdateSupplierAddressParams) (uuid.UUID, error)
+	UseApiKey(ctx context.Context, keyHash string) (ApiKey, error)
 }
 
 var _ Querier = (*Queries)(nil)
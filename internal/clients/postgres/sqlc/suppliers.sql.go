@@ -7,6 +7,7 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -25,26 +26,27 @@ func (q *Queries) CalculateSuppliersWithAddress(ctx context.Context, addressID i
 }
 
 const deleteSupplier = `-- name: DeleteSupplier :one
-DELETE FROM suppliers s
-WHERE s.uid = $1
-RETURNING s.address_id
+UPDATE suppliers s
+SET deleted_at = now()
+WHERE s.uid = $1 AND s.deleted_at IS NULL
+RETURNING s.uid
 `
 
-func (q *Queries) DeleteSupplier(ctx context.Context, uid uuid.UUID) (int32, error) {
+func (q *Queries) DeleteSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
 	row := q.db.QueryRowContext(ctx, deleteSupplier, uid)
-	var address_id int32
-	err := row.Scan(&address_id)
-	return address_id, err
+	err := row.Scan(&uid)
+	return uid, err
 }
 
 const getAllSuppliers = `-- name: GetAllSuppliers :many
-SELECT uid, name, phone_number, country, city, street
+SELECT uid, name, phone_number, country, city, street, deleted_at
 FROM supplier_details
+WHERE $1::bool OR deleted_at IS NULL
 ORDER BY uid
 `
 
-func (q *Queries) GetAllSuppliers(ctx context.Context) ([]SupplierDetail, error) {
-	rows, err := q.db.QueryContext(ctx, getAllSuppliers)
+func (q *Queries) GetAllSuppliers(ctx context.Context, includeDeleted bool) ([]SupplierDetail, error) {
+	rows, err := q.db.QueryContext(ctx, getAllSuppliers, includeDeleted)
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +61,7 @@ func (q *Queries) GetAllSuppliers(ctx context.Context) ([]SupplierDetail, error)
 			&i.Country,
 			&i.City,
 			&i.Street,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -74,13 +77,18 @@ func (q *Queries) GetAllSuppliers(ctx context.Context) ([]SupplierDetail, error)
 }
 
 const getSupplier = `-- name: GetSupplier :one
-SELECT uid, name, phone_number, country, city, street
+SELECT uid, name, phone_number, country, city, street, deleted_at
 FROM supplier_details
-WHERE uid = $1
+WHERE uid = $1 AND ($2::bool OR deleted_at IS NULL)
 `
 
-func (q *Queries) GetSupplier(ctx context.Context, uid uuid.UUID) (SupplierDetail, error) {
-	row := q.db.QueryRowContext(ctx, getSupplier, uid)
+type GetSupplierParams struct {
+	Uid            uuid.UUID
+	IncludeDeleted bool
+}
+
+func (q *Queries) GetSupplier(ctx context.Context, arg GetSupplierParams) (SupplierDetail, error) {
+	row := q.db.QueryRowContext(ctx, getSupplier, arg.Uid, arg.IncludeDeleted)
 	var i SupplierDetail
 	err := row.Scan(
 		&i.Uid,
@@ -89,25 +97,58 @@ func (q *Queries) GetSupplier(ctx context.Context, uid uuid.UUID) (SupplierDetai
 		&i.Country,
 		&i.City,
 		&i.Street,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
+const getSupplierProducts = `-- name: GetSupplierProducts :many
+SELECT p.uid
+FROM products p
+WHERE p.supplier_id = $1 AND p.deleted_at IS NULL
+ORDER BY p.uid
+`
+
+func (q *Queries) GetSupplierProducts(ctx context.Context, supplierID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getSupplierProducts, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var uid uuid.UUID
+		if err := rows.Scan(&uid); err != nil {
+			return nil, err
+		}
+		items = append(items, uid)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getSuppliersPage = `-- name: GetSuppliersPage :many
-SELECT uid, name, phone_number, country, city, street
+SELECT uid, name, phone_number, country, city, street, deleted_at
 FROM supplier_details
+WHERE $1::bool OR deleted_at IS NULL
 ORDER BY uid
-OFFSET $1
-LIMIT $2
+OFFSET $2
+LIMIT $3
 `
 
 type GetSuppliersPageParams struct {
-	Offset int32
-	Limit  int32
+	IncludeDeleted bool
+	Offset         int32
+	Limit          int32
 }
 
 func (q *Queries) GetSuppliersPage(ctx context.Context, arg GetSuppliersPageParams) ([]SupplierDetail, error) {
-	rows, err := q.db.QueryContext(ctx, getSuppliersPage, arg.Offset, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, getSuppliersPage, arg.IncludeDeleted, arg.Offset, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +163,7 @@ func (q *Queries) GetSuppliersPage(ctx context.Context, arg GetSuppliersPagePara
 			&i.Country,
 			&i.City,
 			&i.Street,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -163,10 +205,110 @@ func (q *Queries) InsertSupplier(ctx context.Context, arg InsertSupplierParams)
 	return uid, err
 }
 
+const lockSupplier = `-- name: LockSupplier :one
+SELECT s.uid
+FROM suppliers s
+WHERE s.uid = $1 AND s.deleted_at IS NULL
+FOR UPDATE
+`
+
+func (q *Queries) LockSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, lockSupplier, uid)
+	err := row.Scan(&uid)
+	return uid, err
+}
+
+const purgeSuppliers = `-- name: PurgeSuppliers :many
+DELETE FROM suppliers s
+WHERE s.uid IN (
+    SELECT o.uid
+    FROM suppliers o
+    WHERE o.deleted_at <= $1::timestamptz
+        AND NOT EXISTS (SELECT 1 FROM products p WHERE p.supplier_id = o.uid)
+    ORDER BY o.deleted_at, o.uid
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING s.uid
+`
+
+type PurgeSuppliersParams struct {
+	DeletedBefore time.Time
+	Batch         int32
+}
+
+func (q *Queries) PurgeSuppliers(ctx context.Context, arg PurgeSuppliersParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, purgeSuppliers, arg.DeletedBefore, arg.Batch)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var uid uuid.UUID
+		if err := rows.Scan(&uid); err != nil {
+			return nil, err
+		}
+		items = append(items, uid)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reassignSupplierProducts = `-- name: ReassignSupplierProducts :execrows
+UPDATE products
+SET supplier_id = $1
+WHERE supplier_id = $2
+`
+
+type ReassignSupplierProductsParams struct {
+	ToSupplierUid   uuid.UUID
+	FromSupplierUid uuid.UUID
+}
+
+func (q *Queries) ReassignSupplierProducts(ctx context.Context, arg ReassignSupplierProductsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reassignSupplierProducts, arg.ToSupplierUid, arg.FromSupplierUid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreSupplier = `-- name: RestoreSupplier :one
+UPDATE suppliers s
+SET deleted_at = NULL
+WHERE s.uid = $1
+RETURNING s.uid
+`
+
+func (q *Queries) RestoreSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, restoreSupplier, uid)
+	err := row.Scan(&uid)
+	return uid, err
+}
+
+const shareSupplier = `-- name: ShareSupplier :one
+SELECT s.uid
+FROM suppliers s
+WHERE s.uid = $1 AND s.deleted_at IS NULL
+FOR SHARE
+`
+
+func (q *Queries) ShareSupplier(ctx context.Context, uid uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, shareSupplier, uid)
+	err := row.Scan(&uid)
+	return uid, err
+}
+
 const updateSupplierAddress = `-- name: UpdateSupplierAddress :one
 UPDATE suppliers
 SET address_id = $1
-WHERE uid = $2
+WHERE uid = $2 AND deleted_at IS NULL
 RETURNING uid
 `
 
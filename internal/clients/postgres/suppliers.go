@@ -37,7 +37,7 @@ func (c *Client) AddSupplier(req *ds.AddSupplierRequest) (resp *ds.AddSupplierRe
 		}
 		resp = &ds.AddSupplierResponse{Uid: &uid}
 
-		return nil
+		return audit(ctx, qtx, req.Audit, "AddSupplier", ds.AuditSupplier, uid.String(), nil)
 	})
 
 	return
@@ -46,6 +46,11 @@ func (c *Client) AddSupplier(req *ds.AddSupplierRequest) (resp *ds.AddSupplierRe
 func (c *Client) UpdateSupplierAddress(req *ds.UpdateSupplierAddressRequest) (resp *ds.UpdateSupplierAddressResponse, err error) {
 
 	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditSupplier, req.Uid.String())
+		if err != nil {
+			return err
+		}
+
 		addressId, err := qtx.InsertAddress(ctx, sqlc.InsertAddressParams{
 			Country: req.Address.Country,
 			City:    req.Address.City,
@@ -92,45 +97,132 @@ func (c *Client) UpdateSupplierAddress(req *ds.UpdateSupplierAddressRequest) (re
 			Status: ds.Status{Message: ds.StatusOK},
 		}
 
-		return nil
+		return audit(ctx, qtx, req.Audit, "UpdateSupplierAddress", ds.AuditSupplier, req.Uid.String(), before)
 	})
 
 	return
 }
 
+// DeleteSupplier moves the supplier to the trash if no product refers to it, otherwise the request policy decides:
+// the referring products block the delete, are moved to the trash too or are reassigned to another supplier.
+// The products already in the trash are reassigned as well, so that they can be restored.
 func (c *Client) DeleteSupplier(req *ds.DeleteSupplierRequest) (resp *ds.DeleteSupplierResponse, err error) {
-
 	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
-		addressId, err := qtx.DeleteSupplier(ctx, req.Uid)
+		// The lock holds off new products of the supplier until the commit.
+		if _, err := qtx.LockSupplier(ctx, req.Uid); err != nil {
+			return err
+		}
+
+		products, err := qtx.GetSupplierProducts(ctx, req.Uid)
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if len(products) > 0 {
+			switch req.Policy {
+			case ds.DeleteCascade:
+				productsBefore, err := snapshots(ctx, qtx, ds.AuditProduct, products)
+				if err != nil {
+					return err
+				}
+				for _, uid := range products {
+					if _, err = qtx.DeleteProduct(ctx, uid); err != nil {
+						return err
+					}
+				}
+				if err = auditEach(ctx, qtx, req.Audit, "DeleteSupplier", ds.AuditProduct, products, productsBefore); err != nil {
+					return err
+				}
+			case ds.DeleteReassign:
+				// The deleted supplier can't take its own products, so it counts as missing too.
+				if req.ReassignTo == req.Uid {
+					err = sql.ErrNoRows
+				} else {
+					_, err = qtx.ShareSupplier(ctx, req.ReassignTo)
+				}
+				if err != nil {
+					if !errors.Is(err, sql.ErrNoRows) {
+						return err
+					}
+					resp = &ds.DeleteSupplierResponse{
+						Status: ds.Status{Message: ds.StatusReassignSupplierNotFound},
+					}
+					return nil
+				}
+
+				productsBefore, err := snapshots(ctx, qtx, ds.AuditProduct, products)
+				if err != nil {
+					return err
+				}
+				_, err = qtx.ReassignSupplierProducts(ctx, sqlc.ReassignSupplierProductsParams{
+					ToSupplierUid:   req.ReassignTo,
+					FromSupplierUid: req.Uid,
+				})
+				if err != nil {
+					return err
+				}
+				if err = auditEach(ctx, qtx, req.Audit, "DeleteSupplier", ds.AuditProduct, products, productsBefore); err != nil {
+					return err
+				}
+			default:
 				resp = &ds.DeleteSupplierResponse{
-					Status: ds.Status{Message: ds.StatusNotFound},
+					Status:   ds.Status{Message: ds.StatusConflict},
+					Products: products,
 				}
 				return nil
 			}
-			return err
 		}
 
-		suppliers, err := qtx.CalculateSuppliersWithAddress(ctx, addressId)
+		before, err := snapshot(ctx, qtx, ds.AuditSupplier, req.Uid.String())
 		if err != nil {
 			return err
 		}
 
-		clients, err := qtx.CalculateClientsWithAddress(ctx, addressId)
+		if _, err = qtx.DeleteSupplier(ctx, req.Uid); err != nil {
+			return err
+		}
+
+		resp = &ds.DeleteSupplierResponse{
+			Status:   ds.Status{Message: ds.StatusOK},
+			Products: products,
+		}
+		return audit(ctx, qtx, req.Audit, "DeleteSupplier", ds.AuditSupplier, req.Uid.String(), before)
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return &ds.DeleteSupplierResponse{
+			Status: ds.Status{Message: ds.StatusNotFound},
+		}, nil
+	}
+
+	return
+}
+
+// RestoreSupplier takes the supplier out of the trash, restoring a supplier not deleted succeeds as well.
+// The products moved to the trash with the supplier are restored one by one.
+func (c *Client) RestoreSupplier(req *ds.RestoreSupplierRequest) (resp *ds.RestoreSupplierResponse, err error) {
+	err = c.db.ExecTx(defaultTxOpt, func(ctx context.Context, qtx IQuerier) error {
+		before, err := snapshot(ctx, qtx, ds.AuditSupplier, req.Uid.String())
 		if err != nil {
 			return err
 		}
 
-		if suppliers == 0 && clients == 0 {
-			err := qtx.DeleteAddress(ctx, addressId)
-			if err != nil {
+		if _, err = qtx.RestoreSupplier(ctx, req.Uid); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
 				return err
 			}
+			resp = &ds.RestoreSupplierResponse{
+				Status: ds.Status{Message: ds.StatusNotFound},
+			}
+			return nil
 		}
 
-		resp = &ds.DeleteSupplierResponse{Status: ds.Status{Message: ds.StatusOK}}
-		return nil
+		resp = &ds.RestoreSupplierResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+		return audit(ctx, qtx, req.Audit, "RestoreSupplier", ds.AuditSupplier, req.Uid.String(), before)
 	})
 
 	return
@@ -143,14 +235,15 @@ func (c *Client) GetSuppliers(req *ds.GetSuppliersRequest) (*ds.GetSuppliersResp
 	var err error
 	var suppliers []sqlc.SupplierDetail
 	if req.Limit == 0 && req.Offset == 0 {
-		suppliers, err = c.db.Querier().GetAllSuppliers(ctx)
+		suppliers, err = c.db.Querier().GetAllSuppliers(ctx, req.IncludeDeleted)
 		if err != nil {
 			return nil, err
 		}
 	} else {
 		suppliers, err = c.db.Querier().GetSuppliersPage(ctx, sqlc.GetSuppliersPageParams{
-			Offset: int32(req.Offset),
-			Limit:  int32(req.Limit),
+			IncludeDeleted: req.IncludeDeleted,
+			Offset:         int32(req.Offset),
+			Limit:          int32(req.Limit),
 		})
 		if err != nil {
 			return nil, err
@@ -161,17 +254,7 @@ func (c *Client) GetSuppliers(req *ds.GetSuppliersRequest) (*ds.GetSuppliersResp
 		Suppliers: make([]ds.Supplier, len(suppliers)),
 	}
 	for i := range suppliers {
-		s := &suppliers[i]
-		resp.Suppliers[i] = ds.Supplier{
-			Uid:         s.Uid,
-			Name:        s.Name,
-			PhoneNumber: ds.PhoneNumber(s.PhoneNumber),
-			Address: &ds.Address{
-				Country: s.Country,
-				City:    s.City,
-				Street:  s.Street,
-			},
-		}
+		resp.Suppliers[i] = *fromDBSupplier(&suppliers[i])
 	}
 
 	return resp, nil
@@ -181,7 +264,20 @@ func (c *Client) GetSupplier(req *ds.GetSupplierRequest) (*ds.GetSupplierRespons
 	ctx, cancel := c.db.CtxWithCancel()
 	defer cancel()
 
-	s, err := c.db.Querier().GetSupplier(ctx, req.Uid)
+	var s sqlc.SupplierDetail
+	var err error
+	if req.AsOf != nil {
+		s, err = c.db.Querier().GetSupplierAsOf(ctx, sqlc.GetSupplierAsOfParams{
+			Uid:            req.Uid,
+			AsOf:           *req.AsOf,
+			IncludeDeleted: req.IncludeDeleted,
+		})
+	} else {
+		s, err = c.db.Querier().GetSupplier(ctx, sqlc.GetSupplierParams{
+			Uid:            req.Uid,
+			IncludeDeleted: req.IncludeDeleted,
+		})
+	}
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return &ds.GetSupplierResponse{
@@ -193,15 +289,20 @@ func (c *Client) GetSupplier(req *ds.GetSupplierRequest) (*ds.GetSupplierRespons
 	}
 
 	return &ds.GetSupplierResponse{
-		Supplier: &ds.Supplier{
-			Uid:         s.Uid,
-			Name:        s.Name,
-			PhoneNumber: ds.PhoneNumber(s.PhoneNumber),
-			Address: &ds.Address{
-				Country: s.Country,
-				City:    s.City,
-				Street:  s.Street,
-			},
-		},
+		Supplier: fromDBSupplier(&s),
 	}, nil
 }
+
+func fromDBSupplier(s *sqlc.SupplierDetail) *ds.Supplier {
+	return &ds.Supplier{
+		Uid:         s.Uid,
+		Name:        s.Name,
+		PhoneNumber: ds.PhoneNumber(s.PhoneNumber),
+		Address: &ds.Address{
+			Country: s.Country,
+			City:    s.City,
+			Street:  s.Street,
+		},
+		DeletedAt: fromNullTime(s.DeletedAt),
+	}
+}
@@ -8,13 +8,33 @@ RETURNING uid;
 -- name: UpdateSupplierAddress :one
 UPDATE suppliers
 SET address_id = $1
-WHERE uid = $2
+WHERE uid = $2 AND deleted_at IS NULL
 RETURNING uid;
 
 -- name: DeleteSupplier :one
-DELETE FROM suppliers s
+UPDATE suppliers s
+SET deleted_at = now()
+WHERE s.uid = $1 AND s.deleted_at IS NULL
+RETURNING s.uid;
+
+-- name: RestoreSupplier :one
+UPDATE suppliers s
+SET deleted_at = NULL
 WHERE s.uid = $1
-RETURNING s.address_id;
+RETURNING s.uid;
+
+-- name: PurgeSuppliers :many
+DELETE FROM suppliers s
+WHERE s.uid IN (
+    SELECT o.uid
+    FROM suppliers o
+    WHERE o.deleted_at <= sqlc.arg(deleted_before)::timestamptz
+        AND NOT EXISTS (SELECT 1 FROM products p WHERE p.supplier_id = o.uid)
+    ORDER BY o.deleted_at, o.uid
+    LIMIT sqlc.arg(batch)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING s.uid;
 
 -- name: CalculateSuppliersWithAddress :one
 SELECT COUNT(*) as suppliersAmount
@@ -24,16 +44,41 @@ WHERE address_id = $1;
 -- name: GetAllSuppliers :many
 SELECT *
 FROM supplier_details
+WHERE sqlc.arg(include_deleted)::bool OR deleted_at IS NULL
 ORDER BY uid;
 
 -- name: GetSuppliersPage :many
 SELECT *
 FROM supplier_details
+WHERE sqlc.arg(include_deleted)::bool OR deleted_at IS NULL
 ORDER BY uid
-OFFSET $1
-LIMIT $2;
+OFFSET sqlc.arg('offset')
+LIMIT sqlc.arg('limit');
 
 -- name: GetSupplier :one
 SELECT *
 FROM supplier_details
-WHERE uid = $1;
+WHERE uid = sqlc.arg(uid) AND (sqlc.arg(include_deleted)::bool OR deleted_at IS NULL);
+
+-- name: LockSupplier :one
+SELECT s.uid
+FROM suppliers s
+WHERE s.uid = $1 AND s.deleted_at IS NULL
+FOR UPDATE;
+
+-- name: ShareSupplier :one
+SELECT s.uid
+FROM suppliers s
+WHERE s.uid = $1 AND s.deleted_at IS NULL
+FOR SHARE;
+
+-- name: GetSupplierProducts :many
+SELECT p.uid
+FROM products p
+WHERE p.supplier_id = $1 AND p.deleted_at IS NULL
+ORDER BY p.uid;
+
+-- name: ReassignSupplierProducts :execrows
+UPDATE products
+SET supplier_id = sqlc.arg(to_supplier_uid)
+WHERE supplier_id = sqlc.arg(from_supplier_uid);
@@ -6,6 +6,7 @@ import (
 	"shopapi/internal/clients/postgres/sqlc"
 	ds "shopapi/internal/datastruct"
 	"testing"
+	"time"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -43,6 +44,7 @@ func TestAddSupplier(t *testing.T) {
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
 		tc.querierMock.EXPECT().InsertAddress(gomock.Any(), gomock.Any()).Return(addressId, nil)
 		tc.querierMock.EXPECT().InsertSupplier(gomock.Any(), gomock.Any()).Return(uid, nil)
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.AddSupplier(req)
 		require.Nil(t, err)
@@ -140,8 +142,10 @@ func TestUpdateSupplierAddress(t *testing.T) {
 		addressId := int32(20)
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		expectSnapshots(tc, 1)
 		tc.querierMock.EXPECT().InsertAddress(gomock.Any(), gomock.Any()).Return(addressId, nil)
 		tc.querierMock.EXPECT().UpdateSupplierAddress(gomock.Any(), gomock.Any()).Return(uid, nil)
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.UpdateSupplierAddress(req)
 		require.Nil(t, err)
@@ -168,6 +172,7 @@ func TestUpdateSupplierAddress(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		expectSnapshots(tc, 1)
 		tc.querierMock.EXPECT().InsertAddress(gomock.Any(), gomock.Any()).Return(int32(0), errTest)
 
 		resp, err := tc.client.UpdateSupplierAddress(req)
@@ -197,6 +202,7 @@ func TestUpdateSupplierAddress(t *testing.T) {
 		addressId := int32(20)
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		expectSnapshots(tc, 1)
 		tc.querierMock.EXPECT().InsertAddress(gomock.Any(), gomock.Any()).Return(addressId, nil)
 		tc.querierMock.EXPECT().UpdateSupplierAddress(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, errTest)
 
@@ -227,6 +233,7 @@ func TestUpdateSupplierAddress(t *testing.T) {
 		addressId := int32(20)
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		expectSnapshots(tc, 1)
 		tc.querierMock.EXPECT().InsertAddress(gomock.Any(), gomock.Any()).Return(addressId, nil)
 		tc.querierMock.EXPECT().UpdateSupplierAddress(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, sql.ErrNoRows)
 		tc.querierMock.EXPECT().CalculateSuppliersWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
@@ -261,6 +268,7 @@ func TestUpdateSupplierAddress(t *testing.T) {
 		addressId := int32(20)
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		expectSnapshots(tc, 1)
 		tc.querierMock.EXPECT().InsertAddress(gomock.Any(), gomock.Any()).Return(addressId, nil)
 		tc.querierMock.EXPECT().UpdateSupplierAddress(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, sql.ErrNoRows)
 		tc.querierMock.EXPECT().CalculateSuppliersWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), errTest)
@@ -292,6 +300,7 @@ func TestUpdateSupplierAddress(t *testing.T) {
 		addressId := int32(20)
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		expectSnapshots(tc, 1)
 		tc.querierMock.EXPECT().InsertAddress(gomock.Any(), gomock.Any()).Return(addressId, nil)
 		tc.querierMock.EXPECT().UpdateSupplierAddress(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, sql.ErrNoRows)
 		tc.querierMock.EXPECT().CalculateSuppliersWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
@@ -324,6 +333,7 @@ func TestUpdateSupplierAddress(t *testing.T) {
 		addressId := int32(20)
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
+		expectSnapshots(tc, 1)
 		tc.querierMock.EXPECT().InsertAddress(gomock.Any(), gomock.Any()).Return(addressId, nil)
 		tc.querierMock.EXPECT().UpdateSupplierAddress(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, sql.ErrNoRows)
 		tc.querierMock.EXPECT().CalculateSuppliersWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
@@ -353,13 +363,12 @@ func TestDeleteSupplier(t *testing.T) {
 			return fn(tc.ctx, tc.querierMock)
 		}
 
-		addressId := int32(20)
-
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().DeleteSupplier(gomock.Any(), gomock.Any()).Return(addressId, nil)
-		tc.querierMock.EXPECT().CalculateSuppliersWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
-		tc.querierMock.EXPECT().CalculateClientsWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
-		tc.querierMock.EXPECT().DeleteAddress(gomock.Any(), gomock.Any()).Return(nil)
+		tc.querierMock.EXPECT().LockSupplier(gomock.Any(), uid).Return(uid, nil)
+		tc.querierMock.EXPECT().GetSupplierProducts(gomock.Any(), uid).Return(nil, nil)
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().DeleteSupplier(gomock.Any(), uid).Return(uid, nil)
+		expectAudit(tc, 1)
 
 		resp, err := tc.client.DeleteSupplier(req)
 		require.Nil(t, err)
@@ -381,7 +390,7 @@ func TestDeleteSupplier(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().DeleteSupplier(gomock.Any(), gomock.Any()).Return(int32(0), sql.ErrNoRows)
+		tc.querierMock.EXPECT().LockSupplier(gomock.Any(), uid).Return(uuid.Nil, sql.ErrNoRows)
 
 		resp, err := tc.client.DeleteSupplier(req)
 		require.Nil(t, err)
@@ -404,87 +413,189 @@ func TestDeleteSupplier(t *testing.T) {
 		}
 
 		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().DeleteSupplier(gomock.Any(), gomock.Any()).Return(int32(0), errTest)
+		tc.querierMock.EXPECT().LockSupplier(gomock.Any(), uid).Return(uid, nil)
+		tc.querierMock.EXPECT().GetSupplierProducts(gomock.Any(), uid).Return(nil, nil)
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().DeleteSupplier(gomock.Any(), uid).Return(uuid.Nil, errTest)
 
 		resp, err := tc.client.DeleteSupplier(req)
 		require.NotNil(t, err)
 		require.Nil(t, resp)
 	})
 
-	t.Run("DeleteSupplier error on CalculateSuppliersWithAddress", func(t *testing.T) {
+	t.Run("DeleteSupplier conflict on products", func(t *testing.T) {
 		t.Parallel()
 
 		tc := NewTestClient(t)
 
 		uid := uuid.New()
+		products := []uuid.UUID{uuid.New(), uuid.New()}
 		req := &ds.DeleteSupplierRequest{
 			Uid: uid,
 		}
 
-		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
-			return fn(tc.ctx, tc.querierMock)
-		}
-
-		addressId := int32(20)
-
-		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().DeleteSupplier(gomock.Any(), gomock.Any()).Return(addressId, nil)
-		tc.querierMock.EXPECT().CalculateSuppliersWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), errTest)
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockSupplier(gomock.Any(), uid).Return(uid, nil)
+		tc.querierMock.EXPECT().GetSupplierProducts(gomock.Any(), uid).Return(products, nil)
 
 		resp, err := tc.client.DeleteSupplier(req)
-		require.NotNil(t, err)
-		require.Nil(t, resp)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusConflict, resp.GetStatus())
+		require.Equal(t, products, resp.Products)
 	})
 
-	t.Run("DeleteSupplier error on CalculateClientsWithAddress", func(t *testing.T) {
+	t.Run("DeleteSupplier cascade ok", func(t *testing.T) {
 		t.Parallel()
 
 		tc := NewTestClient(t)
 
 		uid := uuid.New()
+		product := uuid.New()
 		req := &ds.DeleteSupplierRequest{
-			Uid: uid,
+			Uid:    uid,
+			Policy: ds.DeleteCascade,
 		}
 
-		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
-			return fn(tc.ctx, tc.querierMock)
-		}
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockSupplier(gomock.Any(), uid).Return(uid, nil)
+		tc.querierMock.EXPECT().GetSupplierProducts(gomock.Any(), uid).Return([]uuid.UUID{product}, nil)
+		expectSnapshots(tc, 2)
+		tc.querierMock.EXPECT().DeleteProduct(gomock.Any(), product).Return(product, nil)
+		tc.querierMock.EXPECT().DeleteSupplier(gomock.Any(), uid).Return(uid, nil)
+		expectAudit(tc, 2)
 
-		addressId := int32(20)
+		resp, err := tc.client.DeleteSupplier(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+		require.Equal(t, []uuid.UUID{product}, resp.Products)
+	})
 
-		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().DeleteSupplier(gomock.Any(), gomock.Any()).Return(addressId, nil)
-		tc.querierMock.EXPECT().CalculateSuppliersWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
-		tc.querierMock.EXPECT().CalculateClientsWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), errTest)
+	t.Run("DeleteSupplier reassign ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		uid, to := uuid.New(), uuid.New()
+		product := uuid.New()
+		req := &ds.DeleteSupplierRequest{
+			Uid:        uid,
+			Policy:     ds.DeleteReassign,
+			ReassignTo: to,
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockSupplier(gomock.Any(), uid).Return(uid, nil)
+		tc.querierMock.EXPECT().GetSupplierProducts(gomock.Any(), uid).Return([]uuid.UUID{product}, nil)
+		expectSnapshots(tc, 2)
+		tc.querierMock.EXPECT().ShareSupplier(gomock.Any(), to).Return(to, nil)
+		tc.querierMock.EXPECT().ReassignSupplierProducts(gomock.Any(), sqlc.ReassignSupplierProductsParams{
+			ToSupplierUid:   to,
+			FromSupplierUid: uid,
+		}).Return(int64(1), nil)
+		tc.querierMock.EXPECT().DeleteSupplier(gomock.Any(), uid).Return(uid, nil)
+		expectAudit(tc, 2)
 
 		resp, err := tc.client.DeleteSupplier(req)
-		require.NotNil(t, err)
-		require.Nil(t, resp)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+		require.Equal(t, []uuid.UUID{product}, resp.Products)
 	})
 
-	t.Run("DeleteSupplier error on DeleteAddress", func(t *testing.T) {
+	t.Run("DeleteSupplier reassign to not found", func(t *testing.T) {
 		t.Parallel()
 
 		tc := NewTestClient(t)
 
-		uid := uuid.New()
+		uid, to := uuid.New(), uuid.New()
 		req := &ds.DeleteSupplierRequest{
-			Uid: uid,
+			Uid:        uid,
+			Policy:     ds.DeleteReassign,
+			ReassignTo: to,
 		}
 
-		txExec := func(opts *sql.TxOptions, fn func(ctx context.Context, q IQuerier) error) error {
-			return fn(tc.ctx, tc.querierMock)
-		}
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockSupplier(gomock.Any(), uid).Return(uid, nil)
+		tc.querierMock.EXPECT().GetSupplierProducts(gomock.Any(), uid).Return([]uuid.UUID{uuid.New()}, nil)
+		tc.querierMock.EXPECT().ShareSupplier(gomock.Any(), to).Return(uuid.Nil, sql.ErrNoRows)
 
-		addressId := int32(20)
+		resp, err := tc.client.DeleteSupplier(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusReassignSupplierNotFound, resp.GetStatus())
+	})
 
-		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(txExec)
-		tc.querierMock.EXPECT().DeleteSupplier(gomock.Any(), gomock.Any()).Return(addressId, nil)
-		tc.querierMock.EXPECT().CalculateSuppliersWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
-		tc.querierMock.EXPECT().CalculateClientsWithAddress(gomock.Any(), gomock.Any()).Return(int64(0), nil)
-		tc.querierMock.EXPECT().DeleteAddress(gomock.Any(), gomock.Any()).Return(errTest)
+	t.Run("DeleteSupplier reassign to itself", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		req := &ds.DeleteSupplierRequest{
+			Uid:        uid,
+			Policy:     ds.DeleteReassign,
+			ReassignTo: uid,
+		}
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		tc.querierMock.EXPECT().LockSupplier(gomock.Any(), uid).Return(uid, nil)
+		tc.querierMock.EXPECT().GetSupplierProducts(gomock.Any(), uid).Return([]uuid.UUID{uuid.New()}, nil)
 
 		resp, err := tc.client.DeleteSupplier(req)
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusReassignSupplierNotFound, resp.GetStatus())
+	})
+}
+
+func TestRestoreSupplier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RestoreSupplier ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().RestoreSupplier(gomock.Any(), uid).Return(uid, nil)
+		expectAudit(tc, 1)
+
+		resp, err := tc.client.RestoreSupplier(&ds.RestoreSupplierRequest{Uid: uid})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusOK, resp.GetStatus())
+	})
+
+	t.Run("RestoreSupplier not found", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().RestoreSupplier(gomock.Any(), gomock.Any()).Return(uuid.Nil, sql.ErrNoRows)
+
+		resp, err := tc.client.RestoreSupplier(&ds.RestoreSupplierRequest{Uid: uuid.New()})
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("RestoreSupplier error on RestoreSupplier", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		tc.clientMock.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(execTx(tc))
+		expectSnapshots(tc, 1)
+		tc.querierMock.EXPECT().RestoreSupplier(gomock.Any(), gomock.Any()).Return(uuid.Nil, errTest)
+
+		resp, err := tc.client.RestoreSupplier(&ds.RestoreSupplierRequest{Uid: uuid.New()})
 		require.NotNil(t, err)
 		require.Nil(t, resp)
 	})
@@ -572,7 +683,7 @@ func TestGetSuppliers(t *testing.T) {
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
 		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().GetAllSuppliers(gomock.Any()).Return(res, nil)
+		tc.querierMock.EXPECT().GetAllSuppliers(gomock.Any(), false).Return(res, nil)
 
 		resp, err := tc.client.GetSuppliers(req)
 		require.Nil(t, err)
@@ -596,7 +707,7 @@ func TestGetSuppliers(t *testing.T) {
 
 		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
 		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
-		tc.querierMock.EXPECT().GetAllSuppliers(gomock.Any()).Return(res, errTest)
+		tc.querierMock.EXPECT().GetAllSuppliers(gomock.Any(), false).Return(res, errTest)
 
 		resp, err := tc.client.GetSuppliers(req)
 		require.NotNil(t, err)
@@ -641,6 +752,28 @@ func TestGetSupplier(t *testing.T) {
 		require.Equal(t, resp.Supplier.Address.Street, res.Street)
 	})
 
+	t.Run("GetSupplier deleted", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		uid := uuid.New()
+		deletedAt := time.Now()
+		req := &ds.GetSupplierRequest{
+			DeletedFlag: ds.DeletedFlag{IncludeDeleted: true},
+			Uid:         uid,
+		}
+
+		tc.clientMock.EXPECT().CtxWithCancel().Return(context.Background(), func() {})
+		tc.clientMock.EXPECT().Querier().Return(tc.querierMock)
+		tc.querierMock.EXPECT().GetSupplier(gomock.Any(), sqlc.GetSupplierParams{Uid: uid, IncludeDeleted: true}).
+			Return(sqlc.SupplierDetail{Uid: uid, DeletedAt: sql.NullTime{Time: deletedAt, Valid: true}}, nil)
+
+		resp, err := tc.client.GetSupplier(req)
+		require.Nil(t, err)
+		require.Equal(t, &deletedAt, resp.Supplier.DeletedAt)
+	})
+
 	t.Run("GetSupplier error on GetSupplier", func(t *testing.T) {
 		t.Parallel()
 
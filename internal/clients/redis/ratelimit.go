@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+
+	"shopapi/internal/ratelimit"
+	"shopapi/internal/supports"
+)
+
+const rateLimitPrefix = "ratelimit:"
+
+// takeToken refills the bucket by the Redis clock, so the replicas agree on the time, and takes a token.
+// The bucket expires once it would be full again. The tokens are returned as a string, Lua numbers are
+// truncated to integers in the reply.
+const takeToken = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) * 1000 / rate) + 1000)
+return {allowed, tostring(tokens)}
+`
+
+// Allow takes a request from the bucket shared by all the replicas.
+func (c *Client) Allow(key string, l ratelimit.Limit) (ratelimit.Result, error) {
+	ctx, cancel := getCtx()
+	defer cancel()
+
+	reply, err := c.client.Eval(ctx, takeToken, []string{supports.Concat(rateLimitPrefix, key)}, l.Rate, l.Burst).Slice()
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	if len(reply) != 2 {
+		return ratelimit.Result{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+
+	allowed, ok := reply[0].(int64)
+	if !ok {
+		return ratelimit.Result{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+
+	left, ok := reply[1].(string)
+	if !ok {
+		return ratelimit.Result{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+
+	tokens, err := strconv.ParseFloat(left, 64)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	return ratelimit.NewResult(allowed == 1, tokens, l), nil
+}
@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"shopapi/internal/ratelimit"
+
+	"github.com/golang/mock/gomock"
+	go_redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllow(t *testing.T) {
+	t.Parallel()
+
+	l := ratelimit.Limit{Rate: 1, Burst: 5}
+
+	t.Run("Allow ok", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		cmd := go_redis.NewCmd(tc.ctx)
+		cmd.SetVal([]any{int64(1), "3.5"})
+
+		tc.redisMock.EXPECT().Eval(gomock.Any(), takeToken, []string{"ratelimit:default:ip:10.0.0.1"}, l.Rate, l.Burst).
+			Return(cmd)
+
+		res, err := tc.client.Allow("default:ip:10.0.0.1", l)
+		require.Nil(t, err)
+		require.True(t, res.Allowed)
+		require.Equal(t, 3, res.Remaining)
+		require.Equal(t, 5, res.Limit)
+	})
+
+	t.Run("Allow refused", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		cmd := go_redis.NewCmd(tc.ctx)
+		cmd.SetVal([]any{int64(0), "0.25"})
+
+		tc.redisMock.EXPECT().Eval(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(cmd)
+
+		res, err := tc.client.Allow("key", l)
+		require.Nil(t, err)
+		require.False(t, res.Allowed)
+		require.Equal(t, 750*time.Millisecond, res.RetryAfter)
+	})
+
+	t.Run("Allow error on Eval", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		cmd := go_redis.NewCmd(tc.ctx)
+		cmd.SetErr(errors.New("error"))
+
+		tc.redisMock.EXPECT().Eval(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(cmd)
+
+		_, err := tc.client.Allow("key", l)
+		require.NotNil(t, err)
+	})
+
+	t.Run("Allow unexpected reply", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		cmd := go_redis.NewCmd(tc.ctx)
+		cmd.SetVal([]any{int64(1)})
+
+		tc.redisMock.EXPECT().Eval(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(cmd)
+
+		_, err := tc.client.Allow("key", l)
+		require.NotNil(t, err)
+	})
+}
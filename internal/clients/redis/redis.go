@@ -86,6 +86,21 @@ func (c *Client) Read(key string, v any) (bool, error) {
 }
 
 func (c *Client) Write(key string, v any) error {
+	return c.set(key, v, defaultExpiration)
+}
+
+// WriteUntil writes the value expiring at the moment, or after the default expiration if it comes earlier.
+// The value already stale isn't written.
+func (c *Client) WriteUntil(key string, v any, until time.Time) error {
+	expiration := time.Until(until)
+	if expiration <= 0 {
+		return nil
+	}
+
+	return c.set(key, v, min(expiration, defaultExpiration))
+}
+
+func (c *Client) set(key string, v any, expiration time.Duration) error {
 	ctx, cancel := getCtx()
 	defer cancel()
 
@@ -94,7 +109,7 @@ func (c *Client) Write(key string, v any) error {
 		return err
 	}
 
-	return c.client.Set(ctx, key, data, defaultExpiration).Err()
+	return c.client.Set(ctx, key, data, expiration).Err()
 }
 
 func getCtx() (context.Context, context.CancelFunc) {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	go_redis "github.com/redis/go-redis/v9"
@@ -111,3 +112,47 @@ func TestWrite(t *testing.T) {
 		require.NotNil(t, err)
 	})
 }
+
+func TestWriteUntil(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WriteUntil expires at the moment", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		scmd := go_redis.NewStatusCmd(tc.ctx)
+
+		tc.redisMock.EXPECT().Set(gomock.Any(), "key", gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, _ any, expiration time.Duration) *go_redis.StatusCmd {
+				require.Greater(t, expiration, time.Duration(0))
+				require.LessOrEqual(t, expiration, time.Minute)
+				return scmd
+			})
+
+		err := tc.client.WriteUntil("key", &TestValue{}, time.Now().Add(time.Minute))
+		require.Nil(t, err)
+	})
+
+	t.Run("WriteUntil keeps the default expiration", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		scmd := go_redis.NewStatusCmd(tc.ctx)
+
+		tc.redisMock.EXPECT().Set(gomock.Any(), "key", gomock.Any(), defaultExpiration).Return(scmd)
+
+		err := tc.client.WriteUntil("key", &TestValue{}, time.Now().Add(time.Hour))
+		require.Nil(t, err)
+	})
+
+	t.Run("WriteUntil skips the stale value", func(t *testing.T) {
+		t.Parallel()
+
+		tc := NewTestClient(t)
+
+		err := tc.client.WriteUntil("key", &TestValue{}, time.Now().Add(-time.Second))
+		require.Nil(t, err)
+	})
+}
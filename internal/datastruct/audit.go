@@ -0,0 +1,74 @@
+package datastruct
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entities the audit entries refer to, the product images are recorded as the whole set of the product.
+const (
+	AuditClient          = "client"
+	AuditSupplier        = "supplier"
+	AuditProduct         = "product"
+	AuditProductVariant  = "product_variant"
+	AuditVariantPrice    = "variant_price"
+	AuditProductImages   = "product_images"
+	AuditProductDocument = "product_document"
+	AuditImage           = "image"
+	AuditCategory        = "category"
+	AuditApiKey          = "api_key"
+	AuditRole            = "role"
+	AuditExchangeRate    = "exchange_rate"
+	AuditPromotion       = "promotion"
+)
+
+// ActorMigrator records the changes made by the migrator, they have no request and no authenticated caller.
+const ActorMigrator = "migrator"
+
+// Audit is the caller of a mutating request, the API sets it and the storage records it with the change.
+type Audit struct {
+	RequestID string     `json:"-" schema:"-"`
+	ActorKind string     `json:"-" schema:"-"`
+	Actor     string     `json:"-" schema:"-"`
+	ActorKey  *uuid.UUID `json:"-" schema:"-"`
+	// Granted are the permissions of the caller's roles, a caller can't hand out the ones it lacks.
+	Granted []string `json:"-" schema:"-"`
+}
+
+func (a *Audit) SetAudit(audit Audit) {
+	*a = audit
+}
+
+type AuditEntry struct {
+	Id        int64      `json:"id" example:"1024"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-01-02T15:04:05Z"`
+	Action    string     `json:"action" example:"DeleteSupplier"`
+	Entity    string     `json:"entity" example:"supplier"`
+	EntityId  string     `json:"entity_id" example:"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"`
+	ActorKind string     `json:"actor_kind" example:"api_key"`
+	Actor     string     `json:"actor" example:"warehouse"`
+	ActorKey  *uuid.UUID `json:"actor_key,omitempty" example:"9a0c6a52-3c57-4c61-9c1e-7b3c1c8a5b21"`
+	RequestId string     `json:"request_id" example:"2f1e7c9a-55b0-4c1e-8a3d-0f6b9d2c4e11"`
+	// Before and After are the entity as stored, null if it didn't exist.
+	Before json.RawMessage `json:"before" swaggertype:"object"`
+	After  json.RawMessage `json:"after" swaggertype:"object"`
+}
+
+type GetAuditRequest struct {
+	Entity    string     `schema:"entity" validate:"omitempty,oneof=client supplier product product_variant variant_price product_images product_document image category api_key role exchange_rate promotion" example:"supplier"`
+	EntityId  string     `schema:"entity_id" example:"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"`
+	Actor     string     `schema:"actor" example:"warehouse"`
+	Action    string     `schema:"action" example:"DeleteSupplier"`
+	RequestId string     `schema:"request_id" example:"2f1e7c9a-55b0-4c1e-8a3d-0f6b9d2c4e11"`
+	Since     *time.Time `schema:"since" example:"2025-01-01T00:00:00Z"`
+	Until     *time.Time `schema:"until" example:"2025-02-01T00:00:00Z"`
+	Limit     int64      `schema:"limit" validate:"gte=0,lte=1000" example:"100"`
+	Offset    int64      `schema:"offset" validate:"gte=0" example:"0"`
+}
+
+type GetAuditResponse struct {
+	Status
+	Entries []AuditEntry `json:"entries"`
+}
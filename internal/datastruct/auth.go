@@ -0,0 +1,86 @@
+package datastruct
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusUnauthorized       = "authentication required"
+	StatusInvalidCredentials = "invalid api key or token"
+)
+
+const (
+	PrincipalApiKey = "api_key"
+	PrincipalToken  = "token"
+)
+
+// Principal is the caller the request is authenticated as: a service by its API key
+// or a user of the admin UI by the subject of the token. Permissions are granted by its roles.
+type Principal struct {
+	Kind        string     `json:"kind" example:"api_key"`
+	Subject     string     `json:"subject" example:"warehouse"`
+	ApiKeyUid   *uuid.UUID `json:"api_key_uid,omitempty" example:"9a0c6a52-3c57-4c61-9c1e-7b3c1c8a5b21"`
+	Roles       []string   `json:"roles" example:"warehouse"`
+	Permissions []string   `json:"-"`
+}
+
+type ApiKey struct {
+	Uid        uuid.UUID  `json:"uid" example:"9a0c6a52-3c57-4c61-9c1e-7b3c1c8a5b21"`
+	Name       string     `json:"name" example:"warehouse"`
+	Prefix     string     `json:"prefix" example:"sk_Xq3v9a"`
+	CreatedAt  time.Time  `json:"created_at" example:"2025-01-02T15:04:05Z"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" example:"2026-01-02T15:04:05Z"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2025-01-03T10:00:00Z"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" example:"2025-02-01T12:00:00Z"`
+	Roles      []string   `json:"roles" example:"warehouse"`
+}
+
+type IssueApiKeyRequest struct {
+	Audit
+	Name      string     `json:"name" validate:"required" example:"warehouse"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2026-01-02T15:04:05Z"`
+	Roles     []string   `json:"roles" validate:"required,min=1" example:"warehouse"`
+	// Set by the service, the key itself never reaches the storage.
+	Uid     uuid.UUID `json:"-"`
+	Prefix  string    `json:"-"`
+	KeyHash string    `json:"-"`
+}
+
+type IssueApiKeyResponse struct {
+	Status
+	UnknownRoles       []string `json:"unknown_roles,omitempty" example:"stock_keeper"`
+	MissingPermissions []string `json:"missing_permissions,omitempty" example:"roles:manage"`
+	ApiKey             *ApiKey  `json:"api_key,omitempty"`
+	// Key is shown only in this response, it can't be read again.
+	Key string `json:"key,omitempty" example:"sk_Xq3v9aL0mZ8yT2nR5bW7cK1dF4gH6jP9sU3vE0xA2iO"`
+}
+
+type GetApiKeysRequest struct {
+	IncludeRevoked bool `schema:"include_revoked" example:"true"`
+}
+
+type GetApiKeysResponse struct {
+	Status
+	ApiKeys []ApiKey `json:"api_keys"`
+}
+
+type RevokeApiKeyRequest struct {
+	Audit
+	Uid uuid.UUID `json:"uid" validate:"required" example:"9a0c6a52-3c57-4c61-9c1e-7b3c1c8a5b21"`
+}
+
+type RevokeApiKeyResponse struct {
+	Status
+}
+
+type AuthenticateApiKeyRequest struct {
+	Key     string
+	KeyHash string
+}
+
+type AuthenticateApiKeyResponse struct {
+	Status
+	ApiKey *ApiKey
+}
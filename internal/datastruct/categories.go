@@ -0,0 +1,97 @@
+package datastruct
+
+import "github.com/google/uuid"
+
+const (
+	StatusCategoryInUse    = "category has subcategories or products"
+	StatusCategoryCycle    = "category can not be moved under itself or its subcategory"
+	StatusCategoryNoParent = "not exists parent category"
+)
+
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeInteger = "integer"
+	AttributeTypeBoolean = "boolean"
+)
+
+type AttributeDefinition struct {
+	Name          string   `json:"name" validate:"required" example:"voltage"`
+	Type          string   `json:"type" validate:"required,oneof=string number integer boolean" example:"number"`
+	Unit          string   `json:"unit,omitempty" example:"V"`
+	Required      bool     `json:"required" example:"true"`
+	AllowedValues []string `json:"allowed_values,omitempty" example:"110,220"`
+}
+
+type Category struct {
+	Uid        uuid.UUID             `json:"uid" example:"0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"`
+	ParentUid  *uuid.UUID            `json:"parent_id,omitempty" example:"5e0c9d7a-1f7b-4f43-8f0e-6c2b2a1d9e21"`
+	Name       string                `json:"name" validate:"required" example:"Construction"`
+	Slug       string                `json:"slug" example:"construction"`
+	SortOrder  int32                 `json:"sort_order" example:"0"`
+	Attributes []AttributeDefinition `json:"attributes,omitempty" validate:"unique=Name,dive"`
+}
+
+type CategoryNode struct {
+	Category
+	Children []CategoryNode `json:"children"`
+}
+
+type AddCategoryRequest struct {
+	Audit
+	Category
+	AvoidCacheFlag
+}
+
+type AddCategoryResponse struct {
+	Status
+	CachedStatus
+	Uid *uuid.UUID `json:"uid,omitempty"`
+}
+
+type UpdateCategoryRequest struct {
+	Audit
+	AvoidCacheFlag
+	Uid        uuid.UUID             `json:"uid" validate:"required" example:"0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"`
+	ParentUid  *uuid.UUID            `json:"parent_id,omitempty" example:"5e0c9d7a-1f7b-4f43-8f0e-6c2b2a1d9e21"`
+	Name       string                `json:"name" validate:"required" example:"Construction"`
+	Slug       string                `json:"slug" example:"construction"`
+	SortOrder  int32                 `json:"sort_order" example:"0"`
+	Attributes []AttributeDefinition `json:"attributes,omitempty" validate:"unique=Name,dive"`
+}
+
+type UpdateCategoryResponse struct {
+	Status
+	CachedStatus
+}
+
+type DeleteCategoryRequest struct {
+	Audit
+	AvoidCacheFlag
+	Uid uuid.UUID `json:"uid" validate:"required" example:"0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"`
+}
+
+type DeleteCategoryResponse struct {
+	Status
+	CachedStatus
+}
+
+type GetCategoryRequest struct {
+	AvoidCacheFlag
+	Uid uuid.UUID `schema:"uid" validate:"required" example:"0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"`
+}
+
+type GetCategoryResponse struct {
+	Status
+	CachedStatus
+	Category *Category `json:"category,omitempty"`
+}
+
+type GetCategoriesTreeRequest struct {
+	AvoidCacheFlag
+}
+
+type GetCategoriesTreeResponse struct {
+	CachedStatus
+	Categories []CategoryNode `json:"categories"`
+}
@@ -3,6 +3,7 @@ package datastruct
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -30,16 +31,18 @@ func (g *Gender) MarshalJSON() ([]byte, error) {
 }
 
 type Client struct {
-	Uid              uuid.UUID `json:"uid" example:"4988150e-1c82-490f-8c07-ee74ace2dd14"`
-	Birthday         DateOnly  `json:"birthday" validate:"required" example:"10.12.2011"`
-	RegistrationDate DateOnly  `json:"registration_date" validate:"required" example:"30/01/2026"`
-	Name             string    `json:"client_name" validate:"required" example:"Vasilisa"`
-	Surname          string    `json:"client_surname" validate:"required" example:"Kadyk"`
-	Gender           Gender    `json:"gender" validate:"required" example:"female"`
-	Address          *Address  `json:"address" validate:"required"`
+	Uid              uuid.UUID  `json:"uid" example:"4988150e-1c82-490f-8c07-ee74ace2dd14"`
+	Birthday         DateOnly   `json:"birthday" validate:"required" example:"10.12.2011"`
+	RegistrationDate DateOnly   `json:"registration_date" validate:"required" example:"30/01/2026"`
+	Name             string     `json:"client_name" validate:"required" example:"Vasilisa"`
+	Surname          string     `json:"client_surname" validate:"required" example:"Kadyk"`
+	Gender           Gender     `json:"gender" validate:"required" example:"female"`
+	Address          *Address   `json:"address" validate:"required"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty" example:"2026-01-30T10:00:00Z"`
 }
 
 type AddClientRequest struct {
+	Audit
 	Client
 	AvoidCacheFlag
 }
@@ -57,6 +60,7 @@ type Address struct {
 }
 
 type DeleteClientRequest struct {
+	Audit
 	AvoidCacheFlag
 	Uid uuid.UUID `json:"uid" validate:"required" example:"4988150e-1c82-490f-8c07-ee74ace2dd14"`
 }
@@ -66,19 +70,42 @@ type DeleteClientResponse struct {
 	CachedStatus
 }
 
+type RestoreClientRequest struct {
+	Audit
+	AvoidCacheFlag
+	Uid uuid.UUID `json:"uid" validate:"required" example:"4988150e-1c82-490f-8c07-ee74ace2dd14"`
+}
+
+type RestoreClientResponse struct {
+	Status
+	CachedStatus
+}
+
 type GetClientsByNameRequest struct {
 	AvoidCacheFlag
-	Name    string `schema:"client_name" validate:"required" example:"Vasilisa"`
-	Surname string `schema:"client_surname" validate:"required" example:"Kadyk"`
+	DeletedFlag
+	Name          string  `schema:"client_name" validate:"required" example:"Vasilisa"`
+	Surname       string  `schema:"client_surname" validate:"required" example:"Kadyk"`
+	Transliterate bool    `schema:"transliterate" example:"true"`
+	MinScore      float64 `schema:"min_score" validate:"gte=0,lte=1" example:"0.3"`
+	Limit         int64   `schema:"limit" validate:"gte=0" example:"10"`
+	Offset        int64   `schema:"offset" validate:"gte=0" example:"0"`
+}
+
+type ClientMatch struct {
+	Client
+	Score float64 `json:"score" example:"0.86"`
 }
 
 type GetClientsByNameResponse struct {
 	CachedStatus
-	Clients []Client `json:"clients"`
+	Clients []ClientMatch `json:"clients"`
 }
 
 type GetClientsRequest struct {
 	AvoidCacheFlag
+	DeletedFlag
+	AsOfFlag
 	Limit  int64 `schema:"limit" example:"10"`
 	Offset int64 `schema:"offset" example:"0"`
 }
@@ -89,6 +116,7 @@ type GetClientsResponse struct {
 }
 
 type PatchClientAddressRequest struct {
+	Audit
 	AvoidCacheFlag
 	Uid     uuid.UUID `json:"uid" validate:"required" example:"4988150e-1c82-490f-8c07-ee74ace2dd14"`
 	Address *Address  `json:"address" validate:"required"`
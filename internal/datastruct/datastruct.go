@@ -31,11 +31,35 @@ func (a *AvoidCacheFlag) AvoidCache() bool {
 	return a.Flag
 }
 
+// DeletedFlag asks to include the deleted resources, waiting in the trash to be purged, into the response.
+type DeletedFlag struct {
+	IncludeDeleted bool `schema:"include_deleted" json:"-" example:"true"`
+}
+
+// AsOfFlag asks for the resource as it was at the given moment instead of as it is now.
+type AsOfFlag struct {
+	AsOf *time.Time `schema:"as_of" json:"-" example:"2025-01-01T00:00:00Z"`
+}
+
+// CurrencyFlag asks for the prices converted to the currency at the current exchange rates.
+type CurrencyFlag struct {
+	Currency string `schema:"currency" json:"-" validate:"omitempty,iso4217" example:"USD"`
+}
+
+// FileDisposition asks to show the returned file in the browser instead of downloading it.
+type FileDisposition struct {
+	Inline bool `schema:"inline" json:"-" example:"true"`
+}
+
 const (
-	StatusNotFound      = "resource not found"
-	StatusServiceError  = "service failed exec request"
-	StatusAlreadyExists = "resource already exists"
-	StatusOK            = "Success"
+	StatusNotFound        = "resource not found"
+	StatusServiceError    = "service failed exec request"
+	StatusAlreadyExists   = "resource already exists"
+	StatusTooLarge        = "content is too large"
+	StatusRejected        = "content is rejected by scanner"
+	StatusConflict        = "resource is referenced by products"
+	StatusTooManyRequests = "too many requests"
+	StatusOK              = "Success"
 
 	OffsetParam        = "offset"
 	LimitParam         = "limit"
@@ -43,6 +67,17 @@ const (
 	ClientSurnameParam = "client_surname"
 )
 
+// DeletePolicy sets what happens to the products referring to a deleted resource. Restrict, the default,
+// refuses the delete with the list of the referring products, cascade deletes them and reassign moves them
+// to another resource.
+type DeletePolicy string
+
+const (
+	DeleteRestrict DeletePolicy = "restrict"
+	DeleteCascade  DeletePolicy = "cascade"
+	DeleteReassign DeletePolicy = "reassign"
+)
+
 var dateFormats = []string{
 	"2006-01-02",
 	"2006/01/02",
@@ -0,0 +1,88 @@
+package datastruct
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductDocument is a document attached to the product, such as its spec sheet.
+type ProductDocument struct {
+	Uid       uuid.UUID `json:"uid" example:"8a3c1e4f-2b7d-4c5e-9f1a-6d2e8b0c4a17"`
+	Title     string    `json:"title" example:"Oak beam spec sheet"`
+	Mime      string    `json:"mime" example:"application/pdf"`
+	Size      int64     `json:"size" example:"524288"`
+	Hash      string    `json:"hash" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	CreatedAt time.Time `json:"created_at" example:"2024-05-01T10:00:00Z"`
+}
+
+type AddProductDocumentRequest struct {
+	Audit
+	AvoidCacheFlag
+	Uid        uuid.UUID `schema:"uid" example:"8a3c1e4f-2b7d-4c5e-9f1a-6d2e8b0c4a17"`
+	ProductUid uuid.UUID `schema:"product_id" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	Title      string    `schema:"title" validate:"required,max=200" example:"Oak beam spec sheet"`
+	Document   io.Reader `file:"document" json:"-" validate:"required"`
+}
+
+type AddProductDocumentResponse struct {
+	Status
+	CachedStatus
+	Uid *uuid.UUID `json:"uid,omitempty"`
+}
+
+// DocumentContent is the document streamed from storage, it has to be closed by its reader.
+// Document is empty, it only marks the file type of the response.
+type DocumentContent struct {
+	Document   []byte        `file:"document" json:"-"`
+	Content    io.ReadCloser `json:"-"`
+	Size       int64         `json:"-"`
+	Hash       string        `json:"-"`
+	ModifiedAt time.Time     `json:"-"`
+}
+
+// Stream returns the streamed content and its size, zero size means unknown.
+func (c *DocumentContent) Stream() (io.ReadCloser, int64) {
+	return c.Content, c.Size
+}
+
+// Validators returns the hash of the streamed content and the modification time used by conditional requests.
+func (c *DocumentContent) Validators() (string, time.Time) {
+	return c.Hash, c.ModifiedAt
+}
+
+type GetProductDocumentRequest struct {
+	AvoidCacheFlag
+	FileDisposition
+	Uid uuid.UUID `schema:"uid" validate:"required" example:"8a3c1e4f-2b7d-4c5e-9f1a-6d2e8b0c4a17"`
+}
+
+type GetProductDocumentResponse struct {
+	Status
+	CachedStatus
+	DocumentContent
+	Uid *uuid.UUID `asFileName:"true" json:"uid,omitempty"`
+}
+
+type GetProductDocumentsRequest struct {
+	AvoidCacheFlag
+	ProductUid uuid.UUID `schema:"product_id" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+}
+
+type GetProductDocumentsResponse struct {
+	Status
+	CachedStatus
+	Documents []ProductDocument `json:"documents"`
+}
+
+type DeleteProductDocumentRequest struct {
+	Audit
+	AvoidCacheFlag
+	Uid uuid.UUID `json:"uid" validate:"required" example:"8a3c1e4f-2b7d-4c5e-9f1a-6d2e8b0c4a17"`
+}
+
+type DeleteProductDocumentResponse struct {
+	Status
+	CachedStatus
+}
@@ -0,0 +1,51 @@
+package datastruct
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetHistoryRequest asks for the versions of a product, supplier or client, the newest first.
+type GetHistoryRequest struct {
+	Uid    uuid.UUID `schema:"uid" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	Limit  int64     `schema:"limit" validate:"gte=0,lte=1000" example:"100"`
+	Offset int64     `schema:"offset" validate:"gte=0" example:"0"`
+}
+
+// Version is the time span the entity stayed unchanged, the current version has no ValidTo.
+type Version struct {
+	Version   int32      `json:"version" example:"3"`
+	ValidFrom time.Time  `json:"valid_from" example:"2025-01-01T00:00:00Z"`
+	ValidTo   *time.Time `json:"valid_to,omitempty" example:"2025-02-01T00:00:00Z"`
+}
+
+type ProductVersion struct {
+	Version
+	Product Product `json:"product"`
+}
+
+type SupplierVersion struct {
+	Version
+	Supplier Supplier `json:"supplier"`
+}
+
+type ClientVersion struct {
+	Version
+	Client Client `json:"client"`
+}
+
+type GetProductHistoryResponse struct {
+	Status
+	Versions []ProductVersion `json:"versions"`
+}
+
+type GetSupplierHistoryResponse struct {
+	Status
+	Versions []SupplierVersion `json:"versions"`
+}
+
+type GetClientHistoryResponse struct {
+	Status
+	Versions []ClientVersion `json:"versions"`
+}
@@ -1,11 +1,44 @@
 package datastruct
 
-import "github.com/google/uuid"
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusAttachProductImageWithNoImage = "not exists image"
+	StatusDetachOnlyProductImage        = "can't detach the only product image"
+	StatusProductImagesMismatch         = "image ids do not match product images"
+	StatusImageNotResizable             = "image can not be resized"
+	StatusImageCorrupt                  = "image is corrupt"
+)
+
+// ImageVariant requests a resized copy of the image, the original is returned when width and height are empty.
+type ImageVariant struct {
+	Width  int    `schema:"w" validate:"omitempty,min=1,max=2000" example:"200"`
+	Height int    `schema:"h" validate:"omitempty,min=1,max=2000" example:"200"`
+	Fit    string `schema:"fit" validate:"omitempty,oneof=cover contain fill" example:"cover"`
+	Format string `schema:"format" validate:"omitempty,oneof=jpeg png" example:"jpeg"`
+}
+
+func (v *ImageVariant) IsOriginal() bool {
+	return v.Width == 0 && v.Height == 0
+}
+
+type ProductImage struct {
+	ImageUid uuid.UUID `json:"image_id" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
+	Position int32     `json:"position" example:"0"`
+	AltText  string    `json:"alt_text,omitempty" example:"Oak beam, side view"`
+	Primary  bool      `json:"primary" example:"true"`
+}
 
 type AddImageRequest struct {
+	Audit
 	AvoidCacheFlag
 	Uid   uuid.UUID `schema:"uid" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
-	Image []byte    `file:"image" validate:"required"`
+	Image io.Reader `file:"image" json:"-" validate:"required"`
 }
 
 type AddImageResponse struct {
@@ -15,9 +48,10 @@ type AddImageResponse struct {
 }
 
 type UpdateImageRequest struct {
+	Audit
 	AvoidCacheFlag
 	Uid   uuid.UUID `schema:"uid" validate:"required" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
-	Image []byte    `file:"image" validate:"required"`
+	Image io.Reader `file:"image" json:"-" validate:"required"`
 }
 
 type UpdateImageResponse struct {
@@ -26,35 +60,139 @@ type UpdateImageResponse struct {
 }
 
 type DeleteImageRequest struct {
+	Audit
 	AvoidCacheFlag
-	Uid uuid.UUID `json:"uid" validate:"required" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
+	Uid    uuid.UUID    `json:"uid" validate:"required" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
+	Policy DeletePolicy `json:"policy" validate:"omitempty,oneof=restrict cascade" example:"cascade"`
 }
 
+// DeleteImageResponse lists the products blocking the delete, or the ones deleted with it.
 type DeleteImageResponse struct {
 	Status
 	CachedStatus
+	Products []uuid.UUID `json:"products,omitempty"`
+}
+
+type RestoreImageRequest struct {
+	Audit
+	AvoidCacheFlag
+	Uid uuid.UUID `json:"uid" validate:"required" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
+}
+
+type RestoreImageResponse struct {
+	Status
+	CachedStatus
+}
+
+// ImageContent is either the loaded Image, which can be cached, or the Content streamed from storage.
+// The Content has to be closed by its reader. Hash is the hash of the streamed content.
+type ImageContent struct {
+	Image      []byte        `file:"image" json:"image,omitempty"`
+	Content    io.ReadCloser `json:"-"`
+	Size       int64         `json:"-"`
+	Hash       string        `json:"-"`
+	ModifiedAt time.Time     `json:"modified_at,omitzero"`
+}
+
+// Stream returns the streamed content and its size, zero size means unknown.
+func (c *ImageContent) Stream() (io.ReadCloser, int64) {
+	return c.Content, c.Size
+}
+
+// Validators returns the hash of the streamed content and the modification time used by conditional requests.
+func (c *ImageContent) Validators() (string, time.Time) {
+	return c.Hash, c.ModifiedAt
 }
 
 type GetProductImageRequest struct {
 	AvoidCacheFlag
+	FileDisposition
+	ImageVariant
 	ProductUid uuid.UUID `schema:"product_uid" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
 }
 
 type GetProductImageResponse struct {
 	Status
 	CachedStatus
-	Uid   *uuid.UUID `asFileName:"true" json:"uid,omitempty"`
-	Image []byte     `file:"image" json:"image,omitempty"`
+	ImageContent
+	Uid *uuid.UUID `asFileName:"true" json:"uid,omitempty"`
 }
 
 type GetImageRequest struct {
 	AvoidCacheFlag
+	DeletedFlag
+	FileDisposition
+	ImageVariant
 	Uid uuid.UUID `schema:"uid" validate:"required" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
 }
 
 type GetImageResponse struct {
 	Status
 	CachedStatus
-	Uid   *uuid.UUID `asFileName:"true" json:"uid,omitempty"`
-	Image []byte     `file:"image" json:"image,omitempty"`
+	ImageContent
+	Uid *uuid.UUID `asFileName:"true" json:"uid,omitempty"`
+}
+
+// ImageMeta is recorded when the image is uploaded, the size and format are empty for images uploaded before.
+type ImageMeta struct {
+	Uid        uuid.UUID  `json:"uid" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
+	Format     string     `json:"format,omitempty" example:"jpeg"`
+	Mime       string     `json:"mime" example:"image/jpeg"`
+	Width      int32      `json:"width,omitempty" example:"1920"`
+	Height     int32      `json:"height,omitempty" example:"1080"`
+	Size       int64      `json:"size" example:"524288"`
+	Hash       string     `json:"hash" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	ModifiedAt time.Time  `json:"modified_at" example:"2024-05-01T10:00:00Z"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty" example:"2026-01-30T10:00:00Z"`
+}
+
+type GetImageMetaRequest struct {
+	AvoidCacheFlag
+	DeletedFlag
+	Uid uuid.UUID `schema:"uid" validate:"required" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
+}
+
+type GetImageMetaResponse struct {
+	Status
+	CachedStatus
+	Meta *ImageMeta `json:"meta,omitempty"`
+}
+
+type AttachProductImageRequest struct {
+	Audit
+	AvoidCacheFlag
+	ProductUid uuid.UUID `json:"product_id" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	ImageUid   uuid.UUID `json:"image_id" validate:"required" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
+	AltText    string    `json:"alt_text" example:"Oak beam, side view"`
+	Primary    bool      `json:"primary" example:"false"`
+}
+
+type AttachProductImageResponse struct {
+	Status
+	CachedStatus
+}
+
+type DetachProductImageRequest struct {
+	Audit
+	AvoidCacheFlag
+	ProductUid uuid.UUID `json:"product_id" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	ImageUid   uuid.UUID `json:"image_id" validate:"required" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
+}
+
+type DetachProductImageResponse struct {
+	Status
+	CachedStatus
+}
+
+type ReorderProductImagesRequest struct {
+	Audit
+	AvoidCacheFlag
+	ProductUid uuid.UUID   `json:"product_id" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	ImageUids  []uuid.UUID `json:"image_ids" validate:"required,min=1,unique"`
+	PrimaryUid *uuid.UUID  `json:"primary_image_id,omitempty" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
+}
+
+type ReorderProductImagesResponse struct {
+	Status
+	CachedStatus
 }
@@ -0,0 +1,38 @@
+package datastruct
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SweepOrphansRequest asks to mark the images and addresses nothing refers to and to delete the ones
+// marked longer than GracePeriod ago. The dry run only reports them.
+type SweepOrphansRequest struct {
+	DryRun      bool
+	GracePeriod time.Duration
+	Batch       int32
+}
+
+type OrphanedImage struct {
+	Uid        uuid.UUID `json:"uid"`
+	Size       int64     `json:"size"`
+	OrphanedAt time.Time `json:"orphaned_at"`
+}
+
+type OrphanedAddress struct {
+	Id         int32     `json:"id"`
+	Country    string    `json:"country"`
+	City       string    `json:"city"`
+	Street     string    `json:"street"`
+	OrphanedAt time.Time `json:"orphaned_at"`
+}
+
+// SweepOrphansResponse lists the orphans deleted by the sweep, or to be deleted in the dry run.
+// Marked is the number of rows found orphaned by this sweep, they are deleted by a later one.
+type SweepOrphansResponse struct {
+	DryRun    bool              `json:"dry_run"`
+	Marked    int64             `json:"marked"`
+	Images    []OrphanedImage   `json:"images"`
+	Addresses []OrphanedAddress `json:"addresses"`
+}
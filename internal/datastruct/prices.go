@@ -0,0 +1,73 @@
+package datastruct
+
+import (
+	"time"
+
+	"shopapi/internal/money"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusPriceStartsInPast  = "effective_from can't be in the past"
+	StatusPriceEndsNotInTime = "effective_to has to be after effective_from"
+	StatusNoExchangeRate     = "no exchange rate for the currency"
+	StatusInvalidRate        = "rate has to be a positive decimal"
+)
+
+// VariantPrice is a price of the variant, the regular one has no EffectiveTo.
+type VariantPrice struct {
+	Id            int64       `json:"id" example:"42"`
+	Price         money.Money `json:"price" swaggertype:"string" example:"299.95 RUB"`
+	EffectiveFrom time.Time   `json:"effective_from" example:"2025-01-01T00:00:00Z"`
+	EffectiveTo   *time.Time  `json:"effective_to,omitempty" example:"2025-02-01T00:00:00Z"`
+	CreatedAt     time.Time   `json:"created_at" example:"2024-12-20T10:00:00Z"`
+}
+
+// SetVariantPriceRequest sets the regular price of the variant from EffectiveFrom, now if it's not set.
+// With EffectiveTo the price is temporary and the regular one is back after it.
+type SetVariantPriceRequest struct {
+	Audit
+	VariantUid    uuid.UUID   `json:"variant_uid" validate:"required" example:"5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"`
+	Price         money.Money `json:"price" validate:"required,gt=0" swaggertype:"string" example:"249.95 RUB"`
+	EffectiveFrom *time.Time  `json:"effective_from,omitempty" example:"2025-01-01T00:00:00Z"`
+	EffectiveTo   *time.Time  `json:"effective_to,omitempty" example:"2025-02-01T00:00:00Z"`
+}
+
+type SetVariantPriceResponse struct {
+	Status
+	Id int64 `json:"id,omitempty" example:"42"`
+}
+
+type GetVariantPricesRequest struct {
+	VariantUid uuid.UUID `schema:"variant_uid" validate:"required" example:"5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"`
+}
+
+type GetVariantPricesResponse struct {
+	Status
+	Prices []VariantPrice `json:"prices"`
+}
+
+// ExchangeRate is the amount of RUB paid for a unit of the currency.
+type ExchangeRate struct {
+	Currency  string    `json:"currency" example:"USD"`
+	Rate      string    `json:"rate" example:"92.5"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-01-01T00:00:00Z"`
+}
+
+type SetExchangeRateRequest struct {
+	Audit
+	Currency string `json:"currency" validate:"required,iso4217,ne=RUB" example:"USD"`
+	Rate     string `json:"rate" validate:"required,numeric" example:"92.5"`
+}
+
+type SetExchangeRateResponse struct {
+	Status
+}
+
+type GetExchangeRatesRequest struct{}
+
+type GetExchangeRatesResponse struct {
+	Status
+	Rates []ExchangeRate `json:"rates"`
+}
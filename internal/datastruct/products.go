@@ -1,26 +1,72 @@
 package datastruct
 
 import (
+	"time"
+
+	"shopapi/internal/money"
+
 	"github.com/google/uuid"
 )
 
 const (
 	StatusDecreaseProductsFailed          = "not enough to decrease"
 	StatusAddProductWithNoImageOrSupplier = "not exists image or supplier"
+	StatusAddProductWithNoCategory        = "not exists category"
+	StatusAddVariantWithNoImage           = "not exists variant image"
+	StatusVariantOptionsMismatch          = "variant options do not match product options"
+	StatusInvalidAttributes               = "invalid attributes"
+	StatusRestoreWithDeletedReference     = "product supplier or image is deleted"
 )
 
 type Product struct {
-	Uid             uuid.UUID `json:"uid" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
-	SupplierUid     uuid.UUID `json:"supplier_id" validate:"required" example:"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"`
-	ImageUid        uuid.UUID `json:"image_id" validate:"required" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
-	LastUpdateDate  DateOnly  `json:"last_update_date" example:"31.01.2026"`
-	Name            string    `json:"name" validate:"required" example:"Wooden beam"`
-	Category        string    `json:"category" validate:"required" example:"construction"`
-	Price           float64   `json:"price" validate:"required" example:"299.95"`
-	AvaliableStocks int64     `json:"available_stock" validate:"required" example:"1023"`
+	Uid            uuid.UUID        `json:"uid" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	SupplierUid    uuid.UUID        `json:"supplier_id" validate:"required" example:"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"`
+	ImageUid       uuid.UUID        `json:"image_id" validate:"required" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
+	LastUpdateDate DateOnly         `json:"last_update_date" example:"31.01.2026"`
+	Name           string           `json:"name" validate:"required" example:"Wooden beam"`
+	Sku            string           `json:"sku" validate:"required" example:"BEAM-OAK"`
+	Barcode        string           `json:"barcode,omitempty" validate:"omitempty,barcode" example:"4006381333931"`
+	CategoryUid    uuid.UUID        `json:"category_id" validate:"required" example:"0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"`
+	Options        []string         `json:"options,omitempty" example:"size,colour"`
+	Variants       []ProductVariant `json:"variants" validate:"required,min=1,dive"`
+	Attributes     map[string]any   `json:"attributes,omitempty"`
+	Images         []ProductImage   `json:"images,omitempty"`
+	DeletedAt      *time.Time       `json:"deleted_at,omitempty" example:"2026-01-30T10:00:00Z"`
+}
+
+// StaleAt is the next moment a price of the variants changes, nil if none is scheduled.
+func (p *Product) StaleAt() *time.Time {
+	var at *time.Time
+	for i := range p.Variants {
+		at = earliest(at, p.Variants[i].NextPriceChange)
+	}
+	return at
+}
+
+func earliest(a, b *time.Time) *time.Time {
+	if a == nil || b != nil && b.Before(*a) {
+		return b
+	}
+	return a
+}
+
+type ProductVariant struct {
+	Uid             uuid.UUID         `json:"uid" example:"5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"`
+	Sku             string            `json:"sku" validate:"required" example:"BEAM-OAK-2M"`
+	Barcode         string            `json:"barcode,omitempty" validate:"omitempty,barcode" example:"4006381333924"`
+	Options         map[string]string `json:"options,omitempty"`
+	Price           money.Money       `json:"price" validate:"required,gt=0" swaggertype:"string" example:"299.95 RUB"`
+	AvaliableStocks int64             `json:"available_stock" validate:"required" example:"1023"`
+	ImageUid        *uuid.UUID        `json:"image_id,omitempty" example:"376de312-5bcb-4320-8ba3-bd2050548229"`
+	// RegularPrice and PriceEffectiveTo are returned while a temporary price is in effect, Price is the temporary one then.
+	RegularPrice     *money.Money `json:"regular_price,omitempty" validate:"isdefault" swaggertype:"string" example:"349.95 RUB"`
+	PriceEffectiveTo *time.Time   `json:"price_effective_to,omitempty" validate:"isdefault" example:"2025-02-01T00:00:00Z"`
+	// NextPriceChange is the next moment a scheduled price of the variant starts or ends, it's not returned.
+	NextPriceChange *time.Time `json:"-"`
 }
 
 type AddProductRequest struct {
+	Audit
 	Product
 	AvoidCacheFlag
 }
@@ -32,8 +78,9 @@ type AddProductResponse struct {
 }
 
 type DecreaseProductsRequest struct {
-	Uid    uuid.UUID `json:"uid" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
-	Amount int64     `json:"amount" validate:"required" example:"3"`
+	Audit
+	VariantUid uuid.UUID `json:"variant_id" validate:"required" example:"5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"`
+	Amount     int64     `json:"amount" validate:"required" example:"3"`
 }
 
 type DecreaseProductsResponse struct {
@@ -43,6 +90,9 @@ type DecreaseProductsResponse struct {
 
 type GetProductRequest struct {
 	AvoidCacheFlag
+	DeletedFlag
+	AsOfFlag
+	CurrencyFlag
 	Uid uuid.UUID `schema:"uid" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
 }
 
@@ -52,18 +102,65 @@ type GetProductResponse struct {
 	Product *Product `json:"product,omitempty"`
 }
 
+// StaleAt is the next moment a price of the product changes, nil if none is scheduled.
+func (r *GetProductResponse) StaleAt() *time.Time {
+	if r.Product == nil {
+		return nil
+	}
+	return r.Product.StaleAt()
+}
+
+type GetProductBySkuRequest struct {
+	AvoidCacheFlag
+	CurrencyFlag
+	Sku string `schema:"sku" validate:"required" example:"BEAM-OAK-2M"`
+}
+
+type GetProductByBarcodeRequest struct {
+	AvoidCacheFlag
+	CurrencyFlag
+	Barcode string `schema:"barcode" validate:"required,barcode" example:"4006381333931"`
+}
+
 type GetProductsRequest struct {
 	AvoidCacheFlag
-	Limit  int64 `schema:"limit" example:"10"`
-	Offset int64 `schema:"offset" example:"0"`
+	DeletedFlag
+	CurrencyFlag
+	Limit       int64     `schema:"limit" example:"10"`
+	Offset      int64     `schema:"offset" example:"0"`
+	CategoryUid uuid.UUID `schema:"category_id" example:"0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"`
+	Attributes  []string  `schema:"attr" validate:"dive,contains=:" example:"material:oak"`
 }
 
 type GetProductsResponse struct {
+	Status
 	CachedStatus
 	Products []Product `json:"products"`
 }
 
+// StaleAt is the next moment a price of the products changes, nil if none is scheduled.
+func (r *GetProductsResponse) StaleAt() *time.Time {
+	var at *time.Time
+	for i := range r.Products {
+		at = earliest(at, r.Products[i].StaleAt())
+	}
+	return at
+}
+
+type UpdateProductAttributesRequest struct {
+	Audit
+	AvoidCacheFlag
+	Uid        uuid.UUID      `json:"uid" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+type UpdateProductAttributesResponse struct {
+	Status
+	CachedStatus
+}
+
 type DeleteProductRequest struct {
+	Audit
 	AvoidCacheFlag
 	Uid uuid.UUID `json:"uid" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
 }
@@ -72,3 +169,60 @@ type DeleteProductResponse struct {
 	Status
 	CachedStatus
 }
+
+type RestoreProductRequest struct {
+	Audit
+	AvoidCacheFlag
+	Uid uuid.UUID `json:"uid" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+}
+
+type RestoreProductResponse struct {
+	Status
+	CachedStatus
+}
+
+type AddProductVariantRequest struct {
+	Audit
+	AvoidCacheFlag
+	ProductUid uuid.UUID `json:"product_id" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	ProductVariant
+}
+
+type AddProductVariantResponse struct {
+	Status
+	CachedStatus
+	Uid *uuid.UUID `json:"uid,omitempty"`
+}
+
+type DeleteProductVariantRequest struct {
+	Audit
+	AvoidCacheFlag
+	Uid uuid.UUID `json:"uid" validate:"required" example:"5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"`
+}
+
+type DeleteProductVariantResponse struct {
+	Status
+	CachedStatus
+}
+
+type GetBarcodeRequest struct {
+	AvoidCacheFlag
+	FileDisposition
+	Code   string `schema:"code" validate:"required,barcode" example:"4006381333931"`
+	Format string `schema:"format" validate:"omitempty,oneof=png svg" example:"svg"`
+	Scale  int    `schema:"scale" validate:"omitempty,min=1,max=10" example:"2"`
+	Height int    `schema:"height" validate:"omitempty,min=10,max=1000" example:"80"`
+}
+
+type GetBarcodeResponse struct {
+	Status
+	CachedStatus
+	Image []byte `file:"barcode" json:"image,omitempty"`
+}
+
+// DanglingReference is a product referring to a supplier or an image that doesn't exist.
+type DanglingReference struct {
+	Reference  string    `json:"reference" example:"products.supplier_id"`
+	ProductUid uuid.UUID `json:"product_uid" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	MissingUid uuid.UUID `json:"missing_uid" example:"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"`
+}
@@ -0,0 +1,167 @@
+package datastruct
+
+import (
+	"time"
+
+	"shopapi/internal/money"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusInvalidDiscount        = "percent promotion needs percent from 1 to 100, fixed one a positive amount"
+	StatusPromotionEndsNotInTime = "ends_at has to be after starts_at"
+	StatusInvalidPromoCode       = "promo code is not valid"
+	StatusPromoCodeExhausted     = "promo code usage limit is reached"
+	StatusPromotionExhausted     = "promotion usage limit is reached"
+	StatusVariantRequired        = "variant_id is required for a product with several variants"
+	StatusOrderOfAnotherClient   = "order_id is already redeemed by another client"
+)
+
+const (
+	PromotionPercent = "percent"
+	PromotionFixed   = "fixed"
+)
+
+// Promotion takes Percent or a fixed Amount off each unit of the products it's scoped to: the listed products,
+// the products of the listed categories with their subcategories and of the listed suppliers, all of them
+// if nothing is listed. A promotion with Code applies only to the baskets the code is given for.
+// Stackable promotions combine with each other, the others apply alone.
+type Promotion struct {
+	Uid            uuid.UUID    `json:"uid" example:"3f1c2b9e-5d4a-4e8f-9b7c-1a2d3e4f5a6b"`
+	Name           string       `json:"name" validate:"required" example:"Spring sale"`
+	Code           string       `json:"code,omitempty" example:"SPRING25"`
+	Kind           string       `json:"kind" validate:"required,oneof=percent fixed" example:"percent"`
+	Percent        int32        `json:"percent,omitempty" validate:"min=0,max=100" example:"25"`
+	Amount         *money.Money `json:"amount,omitempty" swaggertype:"string" example:"100 RUB"`
+	ProductUids    []uuid.UUID  `json:"product_ids,omitempty" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	CategoryUids   []uuid.UUID  `json:"category_ids,omitempty" example:"0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"`
+	SupplierUids   []uuid.UUID  `json:"supplier_ids,omitempty" example:"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"`
+	StartsAt       *time.Time   `json:"starts_at,omitempty" example:"2025-03-01T00:00:00Z"`
+	EndsAt         *time.Time   `json:"ends_at,omitempty" example:"2025-04-01T00:00:00Z"`
+	UsageLimit     *int32       `json:"usage_limit,omitempty" validate:"omitempty,min=1" example:"1000"`
+	PerClientLimit *int32       `json:"per_client_limit,omitempty" validate:"omitempty,min=1" example:"1"`
+	Stackable      bool         `json:"stackable" example:"false"`
+	Priority       int32        `json:"priority" example:"10"`
+	Uses           int64        `json:"uses" validate:"isdefault" example:"42"`
+}
+
+type AddPromotionRequest struct {
+	Audit
+	Promotion
+}
+
+type AddPromotionResponse struct {
+	Status
+	Uid *uuid.UUID `json:"uid,omitempty"`
+}
+
+type UpdatePromotionRequest struct {
+	Audit
+	Promotion
+}
+
+type UpdatePromotionResponse struct {
+	Status
+}
+
+type GetPromotionsRequest struct {
+	IncludeExpired bool `schema:"include_expired" example:"true"`
+}
+
+type GetPromotionsResponse struct {
+	Status
+	Promotions []Promotion `json:"promotions"`
+}
+
+// ApplicablePromotion is a promotion in effect with what's needed to apply it to a basket of the client.
+type ApplicablePromotion struct {
+	Promotion
+	// CategoryTree is the scope categories with all their subcategories.
+	CategoryTree []uuid.UUID
+	ClientUses   int64
+}
+
+// GetApplicablePromotionsRequest asks for the fixed amounts converted to the Currency, they stay as they are if it's empty.
+// The uses by the OrderID aren't counted, so that a retried order is priced as it was the first time.
+type GetApplicablePromotionsRequest struct {
+	ClientUid uuid.UUID
+	OrderID   string
+	Code      string
+	Currency  string
+	At        time.Time
+}
+
+type GetApplicablePromotionsResponse struct {
+	Status
+	Promotions []ApplicablePromotion
+}
+
+// Redemption is a use of the promotion with the discount it gave.
+type Redemption struct {
+	PromotionUid uuid.UUID
+	Discount     money.Money
+}
+
+// RedeemPromotionsRequest counts the uses once per OrderID, the order already redeemed is answered StatusOK again.
+type RedeemPromotionsRequest struct {
+	Audit
+	ClientUid   uuid.UUID
+	OrderID     string
+	Redemptions []Redemption
+}
+
+type RedeemPromotionsResponse struct {
+	Status
+}
+
+// BasketItem is a product in the basket, the variant may be omitted for a product with a single one.
+type BasketItem struct {
+	ProductUid uuid.UUID  `json:"product_id" validate:"required" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	VariantUid *uuid.UUID `json:"variant_id,omitempty" example:"5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"`
+	Quantity   int64      `json:"quantity" validate:"required,min=1" example:"2"`
+}
+
+type PriceBasketRequest struct {
+	ClientUid uuid.UUID    `json:"client_id" validate:"required" example:"b0ad3d3c-8a0b-4a8e-9f7e-1c5d4c3b2a10"`
+	Code      string       `json:"code,omitempty" example:"SPRING25"`
+	Currency  string       `json:"currency,omitempty" validate:"omitempty,iso4217" example:"RUB"`
+	Items     []BasketItem `json:"items" validate:"required,min=1,dive"`
+}
+
+// RedeemBasketRequest prices the basket of a placed order and counts the applied promotions against their limits.
+// The OrderID makes the request idempotent, the retried order isn't counted again.
+type RedeemBasketRequest struct {
+	Audit
+	PriceBasketRequest
+	OrderID string `json:"order_id" validate:"required,max=128" example:"ORD-2025-000123"`
+}
+
+type AppliedPromotion struct {
+	Uid      uuid.UUID   `json:"uid" example:"3f1c2b9e-5d4a-4e8f-9b7c-1a2d3e4f5a6b"`
+	Name     string      `json:"name" example:"Spring sale"`
+	Code     string      `json:"code,omitempty" example:"SPRING25"`
+	Discount money.Money `json:"discount" swaggertype:"string" example:"74.99 RUB"`
+}
+
+type BasketLine struct {
+	ProductUid uuid.UUID          `json:"product_id" example:"c85a189d-d173-42e2-8e00-54395234d93d"`
+	VariantUid uuid.UUID          `json:"variant_id" example:"5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"`
+	Sku        string             `json:"sku" example:"BEAM-OAK-2M"`
+	Quantity   int64              `json:"quantity" example:"2"`
+	UnitPrice  money.Money        `json:"unit_price" swaggertype:"string" example:"299.95 RUB"`
+	Subtotal   money.Money        `json:"subtotal" swaggertype:"string" example:"599.90 RUB"`
+	Discount   money.Money        `json:"discount" swaggertype:"string" example:"149.98 RUB"`
+	Total      money.Money        `json:"total" swaggertype:"string" example:"449.92 RUB"`
+	Promotions []AppliedPromotion `json:"promotions,omitempty"`
+}
+
+// PriceBasketResponse has the discount of every promotion on every line and, in Promotions, over the basket.
+type PriceBasketResponse struct {
+	Status
+	Lines      []BasketLine       `json:"lines,omitempty"`
+	Subtotal   money.Money        `json:"subtotal" swaggertype:"string" example:"599.90 RUB"`
+	Discount   money.Money        `json:"discount" swaggertype:"string" example:"149.98 RUB"`
+	Total      money.Money        `json:"total" swaggertype:"string" example:"449.92 RUB"`
+	Promotions []AppliedPromotion `json:"promotions,omitempty"`
+}
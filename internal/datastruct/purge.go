@@ -0,0 +1,22 @@
+package datastruct
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PurgeDeletedRequest asks to delete for good the resources deleted longer than Retention ago.
+type PurgeDeletedRequest struct {
+	Retention time.Duration
+	Batch     int32
+}
+
+// PurgeDeletedResponse lists the purged resources. A deleted supplier or image still referred to
+// by a product waits for the product to be purged first.
+type PurgeDeletedResponse struct {
+	Products  []uuid.UUID `json:"products"`
+	Images    []uuid.UUID `json:"images"`
+	Suppliers []uuid.UUID `json:"suppliers"`
+	Clients   []uuid.UUID `json:"clients"`
+}
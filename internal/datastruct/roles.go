@@ -0,0 +1,118 @@
+package datastruct
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusForbidden     = "permission denied"
+	StatusUnknownRole   = "unknown role"
+	StatusRoleInUse     = "role is assigned to api keys"
+	StatusRoleProtected = "admin role can not be changed"
+)
+
+// RoleAdmin is the role every permission is granted to, it can't be changed or deleted
+// so there is always a way to manage the others.
+const RoleAdmin = "admin"
+
+// Permissions granted by the roles, the write permissions don't include the read ones.
+const (
+	PermissionAll              = "*"
+	PermissionClientsRead      = "clients:read"
+	PermissionClientsWrite     = "clients:write"
+	PermissionProductsRead     = "products:read"
+	PermissionProductsWrite    = "products:write"
+	PermissionStockWrite       = "stock:write"
+	PermissionSuppliersRead    = "suppliers:read"
+	PermissionSuppliersWrite   = "suppliers:write"
+	PermissionImagesRead       = "images:read"
+	PermissionImagesWrite      = "images:write"
+	PermissionCategoriesRead   = "categories:read"
+	PermissionCategoriesWrite  = "categories:write"
+	PermissionApiKeysManage    = "api_keys:manage"
+	PermissionRolesManage      = "roles:manage"
+	PermissionAuditRead        = "audit:read"
+	PermissionPromotionsRead   = "promotions:read"
+	PermissionPromotionsWrite  = "promotions:write"
+	PermissionPromotionsRedeem = "promotions:redeem"
+)
+
+// Can reports whether the caller's roles grant the permission.
+func (p *Principal) Can(permission string) bool {
+	return slices.Contains(p.Permissions, PermissionAll) || slices.Contains(p.Permissions, permission)
+}
+
+// MissingPermissions returns the wanted permissions the granted ones don't include, "*" is covered only by "*".
+func MissingPermissions(granted, wanted []string) []string {
+	if slices.Contains(granted, PermissionAll) {
+		return nil
+	}
+
+	var missing []string
+	for _, p := range wanted {
+		if !slices.Contains(granted, p) && !slices.Contains(missing, p) {
+			missing = append(missing, p)
+		}
+	}
+	return missing
+}
+
+type Role struct {
+	Name        string   `json:"name" validate:"required" example:"catalog_manager"`
+	Description string   `json:"description" example:"Manages the catalog"`
+	Permissions []string `json:"permissions" validate:"required,dive,oneof=* clients:read clients:write products:read products:write stock:write suppliers:read suppliers:write images:read images:write categories:read categories:write api_keys:manage roles:manage audit:read promotions:read promotions:write promotions:redeem" example:"products:read,products:write"`
+}
+
+// ForbiddenResponse names the permission the caller lacks.
+type ForbiddenResponse struct {
+	Status
+	Permission string `json:"permission" example:"products:write"`
+}
+
+type GetRolesRequest struct{}
+
+type GetRolesResponse struct {
+	Status
+	Roles []Role `json:"roles"`
+}
+
+type AddRoleRequest struct {
+	Audit
+	Role
+}
+
+type AddRoleResponse struct {
+	Status
+	MissingPermissions []string `json:"missing_permissions,omitempty" example:"roles:manage"`
+}
+
+type UpdateRoleRequest struct {
+	Audit
+	Role
+}
+
+type UpdateRoleResponse struct {
+	Status
+	MissingPermissions []string `json:"missing_permissions,omitempty" example:"roles:manage"`
+}
+
+type DeleteRoleRequest struct {
+	Audit
+	Name string `json:"name" validate:"required" example:"catalog_manager"`
+}
+
+type DeleteRoleResponse struct {
+	Status
+	ApiKeys []uuid.UUID `json:"api_keys,omitempty"`
+}
+
+type GetPermissionsRequest struct {
+	Roles []string
+}
+
+type GetPermissionsResponse struct {
+	Status
+	Permissions []string
+}
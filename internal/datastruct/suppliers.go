@@ -4,10 +4,15 @@ import (
 	"fmt"
 	"shopapi/internal/supports"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const (
+	StatusReassignSupplierNotFound = "not exists supplier to reassign products to"
+)
+
 type PhoneNumber string
 
 func (pn *PhoneNumber) UnmarshalJSON(b []byte) error {
@@ -31,9 +36,11 @@ type Supplier struct {
 	PhoneNumber PhoneNumber `json:"phone_number" validate:"required" example:"+79336579933 RU"`
 	Name        string      `json:"name" validate:"required" example:"Vasilisa&Drozzhi .ltd"`
 	Address     *Address    `json:"address" validate:"required"`
+	DeletedAt   *time.Time  `json:"deleted_at,omitempty" example:"2026-01-30T10:00:00Z"`
 }
 
 type AddSupplierRequest struct {
+	Audit
 	Supplier
 	AvoidCacheFlag
 }
@@ -45,6 +52,7 @@ type AddSupplierResponse struct {
 }
 
 type UpdateSupplierAddressRequest struct {
+	Audit
 	AvoidCacheFlag
 	Uid     uuid.UUID `json:"uid" validate:"required"  example:"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"`
 	Address *Address  `json:"address" validate:"required"`
@@ -56,17 +64,34 @@ type UpdateSupplierAddressResponse struct {
 }
 
 type DeleteSupplierRequest struct {
+	Audit
 	AvoidCacheFlag
-	Uid uuid.UUID `json:"uid" validate:"required" example:"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"`
+	Uid        uuid.UUID    `json:"uid" validate:"required" example:"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"`
+	Policy     DeletePolicy `json:"policy" validate:"omitempty,oneof=restrict cascade reassign" example:"reassign"`
+	ReassignTo uuid.UUID    `json:"reassign_to" validate:"required_if=Policy reassign" example:"2f1d5a0e-8c4b-4e7a-9b3d-6a5c4e3f2d1b"`
 }
 
+// DeleteSupplierResponse lists the products blocking the delete, or the ones deleted or reassigned with it.
 type DeleteSupplierResponse struct {
 	Status
 	CachedStatus
+	Products []uuid.UUID `json:"products,omitempty"`
+}
+
+type RestoreSupplierRequest struct {
+	Audit
+	AvoidCacheFlag
+	Uid uuid.UUID `json:"uid" validate:"required" example:"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"`
+}
+
+type RestoreSupplierResponse struct {
+	Status
+	CachedStatus
 }
 
 type GetSuppliersRequest struct {
 	AvoidCacheFlag
+	DeletedFlag
 	Limit  int64 `json:"limit" example:"10"`
 	Offset int64 `json:"offset" example:"0"`
 }
@@ -78,6 +103,8 @@ type GetSuppliersResponse struct {
 
 type GetSupplierRequest struct {
 	AvoidCacheFlag
+	DeletedFlag
+	AsOfFlag
 	Uid uuid.UUID `schema:"uid" validate:"required" example:"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"`
 }
 
@@ -24,9 +24,17 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/client": {
+        "/api-key": {
             "post": {
-                "description": "Добавление клиента. Если клиент существует вернется uid существующего клиента.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выпускает ключ API для вызовов между сервисами. Ключ возвращается только в этом ответе, сервис хранит лишь его хеш.\nКлюч передается в заголовке X-API-Key. Без expires_at ключ действует до отзыва.\nПрава ключа задаются ролями, с неизвестными ролями ключ не выпускается и они перечисляются в unknown_roles.\nКлюч с правами, которых нет у вызывающего, не выпускается с 403, недостающие права перечисляются в missing_permissions.",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,17 +42,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Auth"
                 ],
-                "summary": "Добавление клиента",
+                "summary": "Выпуск ключа API",
                 "parameters": [
                     {
-                        "description": "Информация о клиенте",
+                        "description": "Название ключа, роли и срок действия",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/datastruct.AddClientRequest"
+                            "$ref": "#/definitions/datastruct.IssueApiKeyRequest"
                         }
                     }
                 ],
@@ -52,7 +60,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.AddClientResponse"
+                            "$ref": "#/definitions/datastruct.IssueApiKeyResponse"
                         }
                     },
                     "400": {
@@ -61,6 +69,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/datastruct.Status"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -70,7 +96,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Удаляет клиента по его uid",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отзывает ключ API, запросы с ним сразу перестают приниматься. Отозванный ключ остается в списке с revoked_at.",
                 "consumes": [
                     "application/json"
                 ],
@@ -78,17 +112,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Auth"
                 ],
-                "summary": "Удаление клиента",
+                "summary": "Отзыв ключа API",
                 "parameters": [
                     {
-                        "description": "uid клиента",
+                        "description": "uid",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteClientRequest"
+                            "$ref": "#/definitions/datastruct.RevokeApiKeyRequest"
                         }
                     }
                 ],
@@ -96,59 +130,37 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteClientResponse"
+                            "$ref": "#/definitions/datastruct.RevokeApiKeyResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteClientResponse"
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/datastruct.Status"
                         }
-                    }
-                }
-            }
-        },
-        "/client/address": {
-            "patch": {
-                "description": "Обновляет адрес клиента по его uid",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Client"
-                ],
-                "summary": "Обновляет адрес клиента",
-                "parameters": [
-                    {
-                        "description": "uid и адрес",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.PatchClientAddressRequest"
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.PatchClientAddressResponse"
+                            "$ref": "#/definitions/datastruct.RevokeApiKeyResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.PatchClientAddressResponse"
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
@@ -160,38 +172,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients": {
+        "/api-keys": {
             "get": {
-                "description": "Возвращает список клиентов. Если offset limit равны 0, вернет список всех клиентов",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает выпущенные ключи API без самих ключей: название, начало ключа, сроки и время последнего использования.\nОтозванные ключи возвращаются с include_revoked=true.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Auth"
                 ],
-                "summary": "Возвращает список клиентов",
+                "summary": "Список ключей API",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "0",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "10",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "true",
-                        "description": "avoid_cache",
-                        "name": "avoid_cache",
+                        "type": "boolean",
+                        "description": "Включить отозванные ключи",
+                        "name": "include_revoked",
                         "in": "query"
                     }
                 ],
@@ -199,7 +202,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetClientsResponse"
+                            "$ref": "#/definitions/datastruct.GetApiKeysResponse"
                         }
                     },
                     "400": {
@@ -208,6 +211,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/datastruct.Status"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -217,39 +238,92 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/named": {
+        "/audit": {
             "get": {
-                "description": "Возвращает клиентов по имени и фамилии",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Возвращает записи журнала изменений, новые первыми. Запись хранит состояние сущности до и после\nизменения, кто и каким запросом его сделал. Без limit возвращается не больше 1000 записей.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Auth"
                 ],
-                "summary": "Возвращает клиентов по имени и фамилии",
+                "summary": "Журнал изменений",
                 "parameters": [
                     {
+                        "enum": [
+                            "client",
+                            "supplier",
+                            "product",
+                            "product_variant",
+                            "variant_price",
+                            "product_images",
+                            "product_document",
+                            "image",
+                            "category",
+                            "api_key",
+                            "role",
+                            "exchange_rate",
+                            "promotion"
+                        ],
                         "type": "string",
-                        "example": "Vasilisa",
-                        "description": "client_name",
-                        "name": "client_name",
+                        "description": "Тип сущности",
+                        "name": "entity",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "example": "Kadyk",
-                        "description": "client_surname",
-                        "name": "client_surname",
+                        "description": "Идентификатор сущности, для роли - ее название",
+                        "name": "entity_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "example": "true",
-                        "description": "avoid_cache",
-                        "name": "avoid_cache",
+                        "description": "Имя API ключа или субъект токена",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Операция, например DeleteSupplier",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Идентификатор запроса из заголовка X-Request-ID",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода, RFC 3339",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода, RFC 3339",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
                         "in": "query"
                     }
                 ],
@@ -257,7 +331,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetClientsByNameResponse"
+                            "$ref": "#/definitions/datastruct.GetAuditResponse"
                         }
                     },
                     "400": {
@@ -266,6 +340,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/datastruct.Status"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -275,31 +367,81 @@ const docTemplate = `{
                 }
             }
         },
-        "/image": {
+        "/barcode": {
             "get": {
-                "description": "Возвращает изображение",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает изображение штрихкода EAN-8, EAN-13 или UPC-A для печати ценников. Формат png (по умолчанию) или svg.\nОтдаёт ETag: по If-None-Match отвечает 304, по Range отдаёт один диапазон байт.",
                 "produces": [
-                    "application/octet-stream"
+                    "image/png",
+                    "image/svg+xml"
                 ],
                 "tags": [
-                    "Image"
+                    "Product"
                 ],
-                "summary": "Возвращает изображение",
+                "summary": "Возвращает изображение штрихкода",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"376de312-5bcb-4320-8ba3-bd2050548229\"",
-                        "description": "uid",
-                        "name": "uid",
+                        "example": "\"4006381333931\"",
+                        "description": "code",
+                        "name": "code",
                         "in": "query",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "example": "svg",
+                        "description": "format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2",
+                        "description": "scale",
+                        "name": "scale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "80",
+                        "description": "height",
+                        "name": "height",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "показать в браузере вместо скачивания",
+                        "name": "inline",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "example": "true",
                         "description": "avoid_cache",
                         "name": "avoid_cache",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag полученного ранее файла",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "bytes=0-1023",
+                        "description": "диапазон байт",
+                        "name": "Range",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -307,12 +449,48 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "type": "file"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "хеш содержимого"
+                            }
                         }
                     },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "не изменилось"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetImageResponse"
+                            "$ref": "#/definitions/datastruct.GetBarcodeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "416": {
+                        "description": "диапазон вне файла"
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
@@ -322,53 +500,81 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/basket/price": {
             "post": {
-                "description": "добавляет новое изображение",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Считает корзину клиента по текущим ценам в валюте currency (по умолчанию RUB) с действующими акциями.\nК строке применяется лучшая из акций или все суммируемые вместе, если их скидка больше. Суммируемые\nприменяются по priority, каждая к остатку. Акции, исчерпавшие лимиты, пропускаются, исчерпанный промокод\nотклоняется с 409. Фиксированные скидки пересчитываются в валюту корзины по курсу, акция без курса\nпропускается, а промокод без курса отклоняется с 400. Использования не учитываются.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Image"
+                    "Promotion"
                 ],
-                "summary": "Добавляет новое изображение",
+                "summary": "Расчет корзины",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"376de312-5bcb-4320-8ba3-bd2050548229\"",
-                        "description": "uid",
-                        "name": "uid",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Файл изображения",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "true",
-                        "description": "avoid_cache",
-                        "name": "avoid_cache",
-                        "in": "query"
+                        "description": "Клиент, промокод и позиции корзины",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.PriceBasketRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.AddImageResponse"
+                            "$ref": "#/definitions/datastruct.PriceBasketResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.AddImageResponse"
+                            "$ref": "#/definitions/datastruct.PriceBasketResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.PriceBasketResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.PriceBasketResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
@@ -378,9 +584,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "удаляет изображение",
+            }
+        },
+        "/basket/redeem": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Считает корзину оформленного заказа как /basket/price и учитывает примененные акции в их лимитах.\nЕсли акция исчерпала лимит после расчета, ничего не учитывается и возвращается 409, корзину нужно пересчитать.\nИспользования учитываются один раз на order_id, повторный запрос того же заказа возвращает 200 и не учитывает их снова.\norder_id, уже оформленный другим клиентом, отклоняется с 409.",
                 "consumes": [
                     "application/json"
                 ],
@@ -388,17 +604,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Image"
+                    "Promotion"
                 ],
-                "summary": "удаляет изображение",
+                "summary": "Оформление корзины",
                 "parameters": [
                     {
-                        "description": "uid",
+                        "description": "Заказ, клиент, промокод и позиции корзины",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteImageRequest"
+                            "$ref": "#/definitions/datastruct.RedeemBasketRequest"
                         }
                     }
                 ],
@@ -406,70 +622,43 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteImageResponse"
+                            "$ref": "#/definitions/datastruct.PriceBasketResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteImageResponse"
+                            "$ref": "#/definitions/datastruct.PriceBasketResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/datastruct.Status"
                         }
-                    }
-                }
-            },
-            "patch": {
-                "description": "обновить существующее изображение",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Image"
-                ],
-                "summary": "обновить изображение",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "\"376de312-5bcb-4320-8ba3-bd2050548229\"",
-                        "description": "uid",
-                        "name": "uid",
-                        "in": "formData",
-                        "required": true
                     },
-                    {
-                        "type": "file",
-                        "description": "Файл изображения",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "example": "true",
-                        "description": "avoid_cache",
-                        "name": "avoid_cache",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.UpdateImageResponse"
+                            "$ref": "#/definitions/datastruct.PriceBasketResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.UpdateImageResponse"
+                            "$ref": "#/definitions/datastruct.PriceBasketResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
@@ -481,25 +670,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/image/product": {
+        "/categories/tree": {
             "get": {
-                "description": "Возвращает изображение продукта",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает все категории в виде дерева. Категории одного уровня отсортированы по sort_order и названию.",
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "Image"
+                    "Category"
                 ],
-                "summary": "Возвращает изображение продукта",
+                "summary": "Возвращает дерево категорий",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "example": "\"c85a189d-d173-42e2-8e00-54395234d93d\"",
-                        "description": "product_uid",
-                        "name": "product_uid",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "example": "true",
@@ -512,13 +701,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/datastruct.GetCategoriesTreeResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetProductImageResponse"
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
@@ -530,20 +737,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/product": {
+        "/category": {
             "get": {
-                "description": "Возвращает продукт.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает категорию.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "Возвращает продукт",
+                "summary": "Возвращает категорию",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"c85a189d-d173-42e2-8e00-54395234d93d\"",
+                        "example": "\"0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10\"",
                         "description": "uid",
                         "name": "uid",
                         "in": "query",
@@ -561,13 +776,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetProductResponse"
+                            "$ref": "#/definitions/datastruct.GetCategoryResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetProductResponse"
+                            "$ref": "#/definitions/datastruct.GetCategoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
@@ -579,7 +812,15 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Добавление продукта. Если продукт существует, обновится цена, количество в наличии, дата обновления и вернется uid этого продукта.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавление категории. Если slug не указан, он будет сформирован из названия. parent_id задает родительскую категорию. attributes задает схему атрибутов продуктов категории: тип (string, number, integer, boolean), единицу измерения, обязательность и допустимые значения.",
                 "consumes": [
                     "application/json"
                 ],
@@ -587,17 +828,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "Добавление продукта",
+                "summary": "Добавление категории",
                 "parameters": [
                     {
-                        "description": "Информация о продукте",
+                        "description": "Информация о категории",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/datastruct.AddProductRequest"
+                            "$ref": "#/definitions/datastruct.AddCategoryRequest"
                         }
                     }
                 ],
@@ -605,13 +846,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.AddProductResponse"
+                            "$ref": "#/definitions/datastruct.AddCategoryResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.AddProductResponse"
+                            "$ref": "#/definitions/datastruct.AddCategoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
@@ -623,7 +882,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Удаление продукта.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаление категории. Категорию с подкатегориями или продуктами удалить нельзя.",
                 "consumes": [
                     "application/json"
                 ],
@@ -631,9 +898,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "Удаление продукта",
+                "summary": "Удаление категории",
                 "parameters": [
                     {
                         "description": "uid",
@@ -641,7 +908,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteProductRequest"
+                            "$ref": "#/definitions/datastruct.DeleteCategoryRequest"
                         }
                     }
                 ],
@@ -649,13 +916,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteProductResponse"
+                            "$ref": "#/definitions/datastruct.DeleteCategoryResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteProductResponse"
+                            "$ref": "#/definitions/datastruct.DeleteCategoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
@@ -667,7 +952,15 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Убавление количества продукта.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновление названия, slug, порядка сортировки, схемы атрибутов и родителя категории. Категорию нельзя перенести в саму себя или в ее подкатегорию.",
                 "consumes": [
                     "application/json"
                 ],
@@ -675,17 +968,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "Убавление количества продукта",
+                "summary": "Обновление категории",
                 "parameters": [
                     {
-                        "description": "uid и уколичество",
+                        "description": "Информация о категории",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DecreaseProductsRequest"
+                            "$ref": "#/definitions/datastruct.UpdateCategoryRequest"
                         }
                     }
                 ],
@@ -693,13 +986,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DecreaseProductsResponse"
+                            "$ref": "#/definitions/datastruct.UpdateCategoryResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DecreaseProductsResponse"
+                            "$ref": "#/definitions/datastruct.UpdateCategoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
@@ -711,46 +1022,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
-            "get": {
-                "description": "Возвращает список продуктов. Если offset limit равны 0, вернет список всех",
+        "/client": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавление клиента. Если клиент существует вернется uid существующего клиента.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Client"
                 ],
-                "summary": "Возвращает список продуктов",
+                "summary": "Добавление клиента",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "0",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "10",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "true",
-                        "description": "avoid_cache",
-                        "name": "avoid_cache",
-                        "in": "query"
+                        "description": "Информация о клиенте",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddClientRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetProductsResponse"
+                            "$ref": "#/definitions/datastruct.AddClientResponse"
                         }
                     },
                     "400": {
@@ -759,65 +1067,114 @@ const docTemplate = `{
                             "$ref": "#/definitions/datastruct.Status"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/datastruct.Status"
                         }
-                    }
-                }
-            }
-        },
-        "/supplier": {
-            "get": {
-                "description": "Возвращает поставщика",
-                "produces": [
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет клиента по его uid. Клиент переносится в корзину и удаляется окончательно\nпо истечении срока хранения, до этого его можно восстановить.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Supplier"
+                    "Client"
                 ],
-                "summary": "Возвращает поставщика",
+                "summary": "Удаление клиента",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4\"",
-                        "description": "uid",
-                        "name": "uid",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "true",
-                        "description": "avoid_cache",
-                        "name": "avoid_cache",
-                        "in": "query"
+                        "description": "uid клиента",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteClientRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetSupplierResponse"
+                            "$ref": "#/definitions/datastruct.DeleteClientResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetSupplierResponse"
+                            "$ref": "#/definitions/datastruct.DeleteClientResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetSupplierResponse"
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Добавление поставщика. Если поставщик существует, вернется uid этого поставщика.",
+            }
+        },
+        "/client/address": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет адрес клиента по его uid",
                 "consumes": [
                     "application/json"
                 ],
@@ -825,17 +1182,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Supplier"
+                    "Client"
                 ],
-                "summary": "Добавление поставщика",
+                "summary": "Обновляет адрес клиента",
                 "parameters": [
                     {
-                        "description": "Информация о поставщике",
+                        "description": "uid и адрес",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/datastruct.AddSupplierRequest"
+                            "$ref": "#/definitions/datastruct.PatchClientAddressRequest"
                         }
                     }
                 ],
@@ -843,71 +1200,133 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.AddSupplierResponse"
+                            "$ref": "#/definitions/datastruct.PatchClientAddressResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.AddSupplierResponse"
+                            "$ref": "#/definitions/datastruct.PatchClientAddressResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.AddSupplierResponse"
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Удаление поставщика",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/client/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Возвращает версии клиента, новые первыми. Без limit возвращается не больше 1000 версий.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Supplier"
+                    "Client"
                 ],
-                "summary": "Удаление поставщика",
+                "summary": "История клиента",
                 "parameters": [
                     {
+                        "type": "string",
+                        "example": "\"4988150e-1c82-490f-8c07-ee74ace2dd14\"",
                         "description": "uid",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteSupplierRequest"
-                        }
+                        "name": "uid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество версий",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteSupplierResponse"
+                            "$ref": "#/definitions/datastruct.GetClientHistoryResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteSupplierResponse"
+                            "$ref": "#/definitions/datastruct.GetClientHistoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.DeleteSupplierResponse"
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     }
                 }
             }
         },
-        "/supplier/address": {
-            "patch": {
-                "description": "Обновление адреса поставщика",
+        "/client/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Восстанавливает удаленного клиента из корзины. Восстановление не удаленного клиента тоже успешно.",
                 "consumes": [
                     "application/json"
                 ],
@@ -915,17 +1334,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Supplier"
+                    "Client"
                 ],
-                "summary": "Обновление адреса поставщика",
+                "summary": "Восстановление клиента",
                 "parameters": [
                     {
-                        "description": "uid и адрес",
+                        "description": "uid клиента",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/datastruct.UpdateSupplierAddressRequest"
+                            "$ref": "#/definitions/datastruct.RestoreClientRequest"
                         }
                     }
                 ],
@@ -933,34 +1352,60 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.UpdateSupplierAddressResponse"
+                            "$ref": "#/definitions/datastruct.RestoreClientResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.UpdateSupplierAddressResponse"
+                            "$ref": "#/definitions/datastruct.RestoreClientResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.UpdateSupplierAddressResponse"
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     }
                 }
             }
         },
-        "/suppliers": {
+        "/clients": {
             "get": {
-                "description": "Возвращает поставщиков",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список клиентов. Если offset limit равны 0, вернет список всех клиентов.\nС include_deleted=true вернет также клиентов из корзины.\nС as_of вернет клиентов такими, какими они были в этот момент.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Supplier"
+                    "Client"
                 ],
-                "summary": "Возвращает поставщиков",
+                "summary": "Возвращает список клиентов",
                 "parameters": [
                     {
                         "type": "string",
@@ -978,316 +1423,6121 @@ const docTemplate = `{
                         "in": "query",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "include_deleted",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "example": "true",
                         "description": "avoid_cache",
                         "name": "avoid_cache",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-01-01T00:00:00Z\"",
+                        "description": "Момент времени, RFC 3339",
+                        "name": "as_of",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetSuppliersResponse"
+                            "$ref": "#/definitions/datastruct.GetClientsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetSuppliersResponse"
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/datastruct.GetSuppliersResponse"
+                            "$ref": "#/definitions/datastruct.Status"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "datastruct.AddClientRequest": {
-            "type": "object",
-            "required": [
-                "address",
-                "birthday",
-                "client_name",
-                "client_surname",
-                "gender",
-                "registration_date"
-            ],
-            "properties": {
-                "address": {
-                    "$ref": "#/definitions/datastruct.Address"
-                },
-                "avoid_cache": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "birthday": {
-                    "type": "string",
-                    "example": "10.12.2011"
-                },
-                "client_name": {
-                    "type": "string",
-                    "example": "Vasilisa"
-                },
-                "client_surname": {
-                    "type": "string",
-                    "example": "Kadyk"
-                },
-                "gender": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/datastruct.Gender"
+        },
+        "/clients/named": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Нечеткий поиск клиентов по имени и фамилии (pg_trgm). Клиенты отсортированы по убыванию score. С transliterate=true ищет также в транслитерации кириллица/латиница. Если offset limit равны 0, вернет всех найденных клиентов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Нечеткий поиск клиентов по имени и фамилии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "Vasilisa",
+                        "description": "client_name",
+                        "name": "client_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Kadyk",
+                        "description": "client_surname",
+                        "name": "client_surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "transliterate",
+                        "name": "transliterate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "0.3",
+                        "description": "min_score",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "0",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "10",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "include_deleted",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetClientsByNameResponse"
                         }
-                    ],
-                    "example": "female"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/currency/rate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Устанавливает, сколько рублей стоит единица валюты. Курс между двумя другими валютами считается через рубль.\nПродукты с параметром currency возвращаются с ценами, переведенными по этим курсам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Установка курса валюты",
+                "parameters": [
+                    {
+                        "description": "Код валюты и курс",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.SetExchangeRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.SetExchangeRateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.SetExchangeRateResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/currency/rates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает курсы валют к рублю.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Курсы валют",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetExchangeRatesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/image": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает изображение. Если указаны w или h, возвращает вариант заданного размера.\nОтдаёт ETag и Last-Modified: по If-None-Match или If-Modified-Since отвечает 304, по Range отдаёт один диапазон байт.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif",
+                    "image/webp",
+                    "image/avif",
+                    "image/heic",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Image"
+                ],
+                "summary": "Возвращает изображение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"376de312-5bcb-4320-8ba3-bd2050548229\"",
+                        "description": "uid",
+                        "name": "uid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 200,
+                        "description": "ширина варианта, до 2000",
+                        "name": "w",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 200,
+                        "description": "высота варианта, до 2000",
+                        "name": "h",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "cover",
+                        "description": "вписывание: cover (по умолчанию), contain, fill",
+                        "name": "fit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "jpeg",
+                        "description": "формат варианта: jpeg или png, webp не кодируется",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "показать в браузере вместо скачивания",
+                        "name": "inline",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "вернуть и изображение из корзины",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag полученного ранее файла",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "bytes=0-1023",
+                        "description": "диапазон байт",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "хеш содержимого"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "время изменения"
+                            }
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "не изменилось"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetImageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "416": {
+                        "description": "диапазон вне файла"
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "добавляет новое изображение. Файл передаётся потоком, поэтому uid должен идти в форме перед ним. Размер ограничен настройкой max_image_size.\nДопустимые форматы задаются настройкой upload_types: по умолчанию jpeg, png, webp, gif, avif, heic и svg.\nМетаданные (EXIF, XMP, комментарии) удаляются, кроме ориентации jpeg. Из svg удаляются скрипты, обработчики событий и встроенные документы. Файл, который не декодируется как изображение, отклоняется с 400.\nПеред сохранением файл проверяется сканерами (сигнатура формата, число пикселей, вложенная разметка или архив, антивирус clamd), отклонённый файл помещается в карантин и возвращается 400.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Image"
+                ],
+                "summary": "Добавляет новое изображение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"376de312-5bcb-4320-8ba3-bd2050548229\"",
+                        "description": "uid",
+                        "name": "uid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл изображения",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddImageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddImageResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "удаляет изображение. Если изображение используется товарами, по умолчанию (policy=restrict) вернется 409\nсо списком этих товаров, с policy=cascade товары удаляются вместе с изображением.\nУдаленные изображение и товары переносятся в корзину, содержимое изображения удаляется по истечении срока хранения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Image"
+                ],
+                "summary": "удаляет изображение",
+                "parameters": [
+                    {
+                        "description": "uid",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteImageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteImageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteImageResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "обновить существующее изображение. Файл передаётся потоком, поэтому uid должен идти в форме перед ним. Размер ограничен настройкой max_image_size.\nДопустимые форматы задаются настройкой upload_types: по умолчанию jpeg, png, webp, gif, avif, heic и svg.\nМетаданные (EXIF, XMP, комментарии) удаляются, кроме ориентации jpeg. Из svg удаляются скрипты, обработчики событий и встроенные документы. Файл, который не декодируется как изображение, отклоняется с 400.\nПеред сохранением файл проверяется сканерами (сигнатура формата, число пикселей, вложенная разметка или архив, антивирус clamd), отклонённый файл помещается в карантин и возвращается 400.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Image"
+                ],
+                "summary": "обновить изображение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"376de312-5bcb-4320-8ba3-bd2050548229\"",
+                        "description": "uid",
+                        "name": "uid",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл изображения",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateImageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateImageResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/image/meta": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает формат, размеры в пикселях, размер файла и хеш изображения. Размеры и формат пусты для изображений, загруженных до их учёта.\nС include_deleted=true вернет и метаданные изображения из корзины, с временем удаления deleted_at.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Image"
+                ],
+                "summary": "Возвращает метаданные изображения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"376de312-5bcb-4320-8ba3-bd2050548229\"",
+                        "description": "uid",
+                        "name": "uid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "include_deleted",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetImageMetaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetImageMetaResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/image/product": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает основное изображение продукта. Если указаны w или h, возвращает уменьшенный вариант.\nОтдаёт ETag и Last-Modified: по If-None-Match или If-Modified-Since отвечает 304, по Range отдаёт один диапазон байт.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif",
+                    "image/webp",
+                    "image/avif",
+                    "image/heic",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Image"
+                ],
+                "summary": "Возвращает изображение продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"c85a189d-d173-42e2-8e00-54395234d93d\"",
+                        "description": "product_uid",
+                        "name": "product_uid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 200,
+                        "description": "ширина варианта, до 2000",
+                        "name": "w",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 200,
+                        "description": "высота варианта, до 2000",
+                        "name": "h",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "cover",
+                        "description": "вписывание: cover (по умолчанию), contain, fill",
+                        "name": "fit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "jpeg",
+                        "description": "формат варианта: jpeg или png, webp не кодируется",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "показать в браузере вместо скачивания",
+                        "name": "inline",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag полученного ранее файла",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "bytes=0-1023",
+                        "description": "диапазон байт",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "хеш содержимого"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "время изменения"
+                            }
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "не изменилось"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductImageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "416": {
+                        "description": "диапазон вне файла"
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Прикрепляет загруженное изображение к продукту последним в списке. Если primary равен true, изображение становится основным.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Image"
+                ],
+                "summary": "Прикрепляет изображение к продукту",
+                "parameters": [
+                    {
+                        "description": "product_id, image_id",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AttachProductImageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AttachProductImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AttachProductImageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Открепляет изображение от продукта. Единственное изображение продукта открепить нельзя. Если открепляется основное изображение, основным становится первое из оставшихся.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Image"
+                ],
+                "summary": "Открепляет изображение от продукта",
+                "parameters": [
+                    {
+                        "description": "product_id, image_id",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DetachProductImageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DetachProductImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DetachProductImageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/image/product/order": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Задает порядок изображений продукта. image_ids должен содержать все прикрепленные изображения продукта. Если указан primary_image_id, это изображение становится основным.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Image"
+                ],
+                "summary": "Меняет порядок изображений продукта",
+                "parameters": [
+                    {
+                        "description": "product_id, image_ids",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ReorderProductImagesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ReorderProductImagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ReorderProductImagesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/image/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "восстанавливает удаленное изображение из корзины. Товары, удаленные вместе с ним, восстанавливаются отдельно.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Image"
+                ],
+                "summary": "восстанавливает изображение",
+                "parameters": [
+                    {
+                        "description": "uid",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.RestoreImageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.RestoreImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.RestoreImageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/product": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает продукт. С include_deleted=true вернет и продукт из корзины.\nС as_of вернет продукт с вариантами и изображениями таким, каким он был в этот момент.\nЦены возвращаются строкой с валютой, например \"299.95 RUB\". С currency вернет их в этой валюте по текущим курсам.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Возвращает продукт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"c85a189d-d173-42e2-8e00-54395234d93d\"",
+                        "description": "uid",
+                        "name": "uid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "include_deleted",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-01-01T00:00:00Z\"",
+                        "description": "Момент времени, RFC 3339",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD",
+                        "description": "Код валюты ISO 4217, цены переводятся в нее по курсам валют",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавление продукта вместе с его вариантами. Опции каждого варианта должны совпадать с осями опций продукта (options). Атрибуты проверяются по схеме атрибутов категории.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Добавление продукта",
+                "parameters": [
+                    {
+                        "description": "Информация о продукте",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddProductResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаление продукта. Продукт переносится в корзину вместе с вариантами, изображениями и документами\nи удаляется окончательно по истечении срока хранения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Удаление продукта",
+                "parameters": [
+                    {
+                        "description": "uid",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteProductResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Убавление количества варианта продукта.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Убавление количества варианта продукта",
+                "parameters": [
+                    {
+                        "description": "uid варианта и количество",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DecreaseProductsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DecreaseProductsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DecreaseProductsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/attributes": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет атрибуты продукта. Значения проверяются по схеме атрибутов категории продукта.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Обновление атрибутов продукта",
+                "parameters": [
+                    {
+                        "description": "uid и атрибуты",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateProductAttributesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateProductAttributesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateProductAttributesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/barcode": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает продукт по штрихкоду EAN-8, EAN-13 или UPC-A продукта или любого из его вариантов.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Возвращает продукт по штрихкоду",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"4006381333931\"",
+                        "description": "barcode",
+                        "name": "barcode",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD",
+                        "description": "Код валюты ISO 4217, цены переводятся в нее по курсам валют",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/document": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает файл документа продукта.\nОтдаёт ETag и Last-Modified: по If-None-Match или If-Modified-Since отвечает 304, по Range отдаёт один диапазон байт.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Возвращает документ продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8a3c1e4f-2b7d-4c5e-9f1a-6d2e8b0c4a17\"",
+                        "description": "uid",
+                        "name": "uid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "показать в браузере вместо скачивания",
+                        "name": "inline",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag полученного ранее файла",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "bytes=0-1023",
+                        "description": "диапазон байт",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "хеш содержимого"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "время добавления"
+                            }
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "не изменилось"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductDocumentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "416": {
+                        "description": "диапазон вне файла"
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет документ продукта, например спецификацию в pdf. Файл передаётся потоком, поэтому поля формы должны идти перед ним. Размер ограничен настройкой max_image_size.\nДопустимые типы файлов задаются настройкой upload_types. Перед сохранением файл проверяется сканерами (сигнатура формата, активное содержимое pdf, антивирус clamd), отклонённый файл помещается в карантин и возвращается 400.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Добавляет документ продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8a3c1e4f-2b7d-4c5e-9f1a-6d2e8b0c4a17\"",
+                        "description": "uid",
+                        "name": "uid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"c85a189d-d173-42e2-8e00-54395234d93d\"",
+                        "description": "product_id",
+                        "name": "product_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Oak beam spec sheet\"",
+                        "description": "название",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл документа",
+                        "name": "document",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddProductDocumentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddProductDocumentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddProductDocumentResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "удаляет документ продукта",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "удаляет документ продукта",
+                "parameters": [
+                    {
+                        "description": "uid",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteProductDocumentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteProductDocumentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteProductDocumentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/documents": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает документы продукта в порядке добавления: название, тип, размер и хеш файла.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Возвращает список документов продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"c85a189d-d173-42e2-8e00-54395234d93d\"",
+                        "description": "product_id",
+                        "name": "product_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductDocumentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductDocumentsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает версии продукта, новые первыми. Версия - состояние продукта вместе с вариантами и изображениями\nс valid_from до valid_to, у текущей версии valid_to нет. Без limit возвращается не больше 1000 версий.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "История продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"c85a189d-d173-42e2-8e00-54395234d93d\"",
+                        "description": "uid",
+                        "name": "uid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество версий",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductHistoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Восстанавливает удаленный продукт из корзины. Если его поставщик или изображение в корзине, вернется 409,\nих нужно восстановить раньше.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Восстановление продукта",
+                "parameters": [
+                    {
+                        "description": "uid",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.RestoreProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.RestoreProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.RestoreProductResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.RestoreProductResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/sku": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает продукт по артикулу (SKU) продукта или любого из его вариантов.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Возвращает продукт по артикулу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BEAM-OAK-2M\"",
+                        "description": "sku",
+                        "name": "sku",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD",
+                        "description": "Код валюты ISO 4217, цены переводятся в нее по курсам валют",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/variant": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавление варианта к существующему продукту. Опции варианта должны совпадать с осями опций продукта.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Добавление варианта продукта",
+                "parameters": [
+                    {
+                        "description": "Информация о варианте",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddProductVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddProductVariantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddProductVariantResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаление варианта продукта.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Удаление варианта продукта",
+                "parameters": [
+                    {
+                        "description": "uid",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteProductVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteProductVariantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteProductVariantResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/variant/price": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Устанавливает обычную цену варианта с effective_from, без него - сразу. С effective_to цена временная:\nпока она действует, продукт возвращается с ней и с обычной ценой в regular_price, после нее снова действует обычная.\nПрежние цены остаются в истории цен варианта.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Установка цены варианта",
+                "parameters": [
+                    {
+                        "description": "uid варианта, цена и период ее действия",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.SetVariantPriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.SetVariantPriceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.SetVariantPriceResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.SetVariantPriceResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/variant/prices": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает прошлые, текущие и запланированные цены варианта, поздние первыми.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "История цен варианта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11\"",
+                        "description": "uid варианта",
+                        "name": "variant_uid",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetVariantPricesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetVariantPricesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetVariantPricesResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список продуктов. Если offset limit равны 0, вернет список всех. Если указан category_id, вернет продукты этой категории и всех ее подкатегорий. Параметр attr в формате name:value фильтрует по значениям атрибутов, может повторяться.\nС include_deleted=true вернет также продукты из корзины.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Возвращает список продуктов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "0",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "10",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10\"",
+                        "description": "category_id",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "material:oak",
+                        "description": "attr",
+                        "name": "attr",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "include_deleted",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD",
+                        "description": "Код валюты ISO 4217, цены переводятся в нее по курсам валют",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetProductsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotion": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет акцию со скидкой в процентах (kind percent) или фиксированной суммой с каждой единицы (kind fixed).\nАкция действует на перечисленные продукты, на продукты категорий с их подкатегориями и поставщиков,\nбез них - на все продукты. Акция с code применяется только к корзине с этим промокодом.\nСуммируемые (stackable) акции применяются вместе, остальные - по одной.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Добавление акции",
+                "parameters": [
+                    {
+                        "description": "Акция",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddPromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddPromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddPromotionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет акцию целиком, сделанные использования остаются в ее лимитах. Акция завершается установкой ends_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Обновление акции",
+                "parameters": [
+                    {
+                        "description": "Акция с uid",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdatePromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdatePromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdatePromotionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdatePromotionResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает действующие и запланированные акции с числом их использований, старшие по priority первыми.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Список акций",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Вернуть и завершенные акции",
+                        "name": "include_expired",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetPromotionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/role": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет роль с набором прав. Права на запись не включают права на чтение.\nРоль может дать только права, которые есть у вызывающего, иначе возвращается 403 с missing_permissions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Добавление роли",
+                "parameters": [
+                    {
+                        "description": "Название, описание и права роли",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddRoleResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет роль. Роль, назначенную действующим ключам API, удалить нельзя, такие ключи перечисляются в api_keys. Роль admin удалить нельзя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Удаление роли",
+                "parameters": [
+                    {
+                        "description": "Название роли",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteRoleResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteRoleResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет описание и права роли, новые права действуют со следующего запроса. Роль admin изменить нельзя.\nРоль может дать только права, которые есть у вызывающего, иначе возвращается 403 с missing_permissions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Обновление роли",
+                "parameters": [
+                    {
+                        "description": "Название, описание и права роли",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateRoleResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateRoleResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает роли с их правами. Право \"*\" дает доступ ко всем маршрутам.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Список ролей",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetRolesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает поставщика. С include_deleted=true вернет и поставщика из корзины.\nС as_of вернет поставщика таким, каким он был в этот момент.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Возвращает поставщика",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4\"",
+                        "description": "uid",
+                        "name": "uid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "include_deleted",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2025-01-01T00:00:00Z\"",
+                        "description": "Момент времени, RFC 3339",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetSupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetSupplierResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetSupplierResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавление поставщика. Если поставщик существует, вернется uid этого поставщика.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Добавление поставщика",
+                "parameters": [
+                    {
+                        "description": "Информация о поставщике",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddSupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddSupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddSupplierResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.AddSupplierResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаление поставщика. Если у поставщика есть товары, по умолчанию (policy=restrict) вернется 409\nсо списком этих товаров, с policy=cascade товары удаляются вместе с поставщиком,\nс policy=reassign они передаются поставщику reassign_to.\nУдаленные поставщик и товары переносятся в корзину и удаляются окончательно по истечении срока хранения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Удаление поставщика",
+                "parameters": [
+                    {
+                        "description": "uid",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteSupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteSupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteSupplierResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteSupplierResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.DeleteSupplierResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/address": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновление адреса поставщика",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Обновление адреса поставщика",
+                "parameters": [
+                    {
+                        "description": "uid и адрес",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateSupplierAddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateSupplierAddressResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateSupplierAddressResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.UpdateSupplierAddressResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает версии поставщика, новые первыми. Адрес поставщика меняется вместе с его версией.\nБез limit возвращается не больше 1000 версий.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "История поставщика",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4\"",
+                        "description": "uid",
+                        "name": "uid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество версий",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetSupplierHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetSupplierHistoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Восстанавливает удаленного поставщика из корзины. Товары, удаленные вместе с ним, восстанавливаются отдельно.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Восстановление поставщика",
+                "parameters": [
+                    {
+                        "description": "uid",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.RestoreSupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.RestoreSupplierResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.RestoreSupplierResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.RestoreSupplierResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает поставщиков. С include_deleted=true вернет также поставщиков из корзины.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Возвращает поставщиков",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "0",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "10",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "include_deleted",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "true",
+                        "description": "avoid_cache",
+                        "name": "avoid_cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetSuppliersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetSuppliersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.ForbiddenResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/datastruct.GetSuppliersResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "datastruct.AddCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/datastruct.AttributeDefinition"
+                    }
+                },
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Construction"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "5e0c9d7a-1f7b-4f43-8f0e-6c2b2a1d9e21"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "construction"
+                },
+                "sort_order": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"
+                }
+            }
+        },
+        "datastruct.AddCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "datastruct.AddClientRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "birthday",
+                "client_name",
+                "client_surname",
+                "gender",
+                "registration_date"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/datastruct.Address"
+                },
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "birthday": {
+                    "type": "string",
+                    "example": "10.12.2011"
+                },
+                "client_name": {
+                    "type": "string",
+                    "example": "Vasilisa"
+                },
+                "client_surname": {
+                    "type": "string",
+                    "example": "Kadyk"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2026-01-30T10:00:00Z"
+                },
+                "gender": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/datastruct.Gender"
+                        }
+                    ],
+                    "example": "female"
+                },
+                "registration_date": {
+                    "type": "string",
+                    "example": "30/01/2026"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "4988150e-1c82-490f-8c07-ee74ace2dd14"
+                }
+            }
+        },
+        "datastruct.AddClientResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "datastruct.AddImageResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "datastruct.AddProductDocumentResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "datastruct.AddProductRequest": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "image_id",
+                "name",
+                "sku",
+                "supplier_id",
+                "variants"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "barcode": {
+                    "type": "string",
+                    "example": "4006381333931"
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2026-01-30T10:00:00Z"
+                },
+                "image_id": {
+                    "type": "string",
+                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.ProductImage"
+                    }
+                },
+                "last_update_date": {
+                    "type": "string",
+                    "example": "31.01.2026"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Wooden beam"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "size",
+                        "colour"
+                    ]
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "BEAM-OAK"
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                },
+                "variants": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/datastruct.ProductVariant"
+                    }
+                }
+            }
+        },
+        "datastruct.AddProductResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "datastruct.AddProductVariantRequest": {
+            "type": "object",
+            "required": [
+                "available_stock",
+                "price",
+                "product_id",
+                "sku"
+            ],
+            "properties": {
+                "available_stock": {
+                    "type": "integer",
+                    "example": 1023
+                },
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "barcode": {
+                    "type": "string",
+                    "example": "4006381333924"
+                },
+                "image_id": {
+                    "type": "string",
+                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "type": "string",
+                    "example": "299.95 RUB"
+                },
+                "price_effective_to": {
+                    "type": "string",
+                    "example": "2025-02-01T00:00:00Z"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                },
+                "regular_price": {
+                    "description": "RegularPrice and PriceEffectiveTo are returned while a temporary price is in effect, Price is the temporary one then.",
+                    "type": "string",
+                    "example": "349.95 RUB"
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "BEAM-OAK-2M"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"
+                }
+            }
+        },
+        "datastruct.AddProductVariantResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "datastruct.AddPromotionRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "100 RUB"
+                },
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"
+                    ]
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SPRING25"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-04-01T00:00:00Z"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ],
+                    "example": "percent"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Spring sale"
+                },
+                "per_client_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "percent": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 25
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "c85a189d-d173-42e2-8e00-54395234d93d"
+                    ]
+                },
+                "stackable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "supplier_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
+                    ]
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "3f1c2b9e-5d4a-4e8f-9b7c-1a2d3e4f5a6b"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1000
+                },
+                "uses": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "datastruct.AddPromotionResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "datastruct.AddRoleRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Manages the catalog"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "catalog_manager"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "products:read",
+                        "products:write"
+                    ]
+                }
+            }
+        },
+        "datastruct.AddRoleResponse": {
+            "type": "object",
+            "properties": {
+                "missing_permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "roles:manage"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.AddSupplierRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "name",
+                "phone_number"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/datastruct.Address"
+                },
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2026-01-30T10:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Vasilisa\u0026Drozzhi .ltd"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "+79336579933 RU"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
+                }
+            }
+        },
+        "datastruct.AddSupplierResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "datastruct.Address": {
+            "type": "object",
+            "required": [
+                "city",
+                "country",
+                "street"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "Seattle"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "USA"
+                },
+                "street": {
+                    "type": "string",
+                    "example": "12th Ave E"
+                }
+            }
+        },
+        "datastruct.ApiKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-02T15:04:05Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-02T15:04:05Z"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2025-01-03T10:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "warehouse"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "sk_Xq3v9a"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2025-02-01T12:00:00Z"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "warehouse"
+                    ]
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "9a0c6a52-3c57-4c61-9c1e-7b3c1c8a5b21"
+                }
+            }
+        },
+        "datastruct.AppliedPromotion": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "SPRING25"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "74.99 RUB"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Spring sale"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "3f1c2b9e-5d4a-4e8f-9b7c-1a2d3e4f5a6b"
+                }
+            }
+        },
+        "datastruct.AttachProductImageRequest": {
+            "type": "object",
+            "required": [
+                "image_id",
+                "product_id"
+            ],
+            "properties": {
+                "alt_text": {
+                    "type": "string",
+                    "example": "Oak beam, side view"
+                },
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "image_id": {
+                    "type": "string",
+                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
+                },
+                "primary": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                }
+            }
+        },
+        "datastruct.AttachProductImageResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.AttributeDefinition": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "allowed_values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "110",
+                        "220"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "voltage"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "number",
+                        "integer",
+                        "boolean"
+                    ],
+                    "example": "number"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "V"
+                }
+            }
+        },
+        "datastruct.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "DeleteSupplier"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "warehouse"
+                },
+                "actor_key": {
+                    "type": "string",
+                    "example": "9a0c6a52-3c57-4c61-9c1e-7b3c1c8a5b21"
+                },
+                "actor_kind": {
+                    "type": "string",
+                    "example": "api_key"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "description": "Before and After are the entity as stored, null if it didn't exist.",
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-02T15:04:05Z"
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "supplier"
+                },
+                "entity_id": {
+                    "type": "string",
+                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "2f1e7c9a-55b0-4c1e-8a3d-0f6b9d2c4e11"
+                }
+            }
+        },
+        "datastruct.BasketItem": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string",
+                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"
+                }
+            }
+        },
+        "datastruct.BasketLine": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "string",
+                    "example": "149.98 RUB"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.AppliedPromotion"
+                    }
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "BEAM-OAK-2M"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "599.90 RUB"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "449.92 RUB"
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "299.95 RUB"
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"
+                }
+            }
+        },
+        "datastruct.Category": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/datastruct.AttributeDefinition"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Construction"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "5e0c9d7a-1f7b-4f43-8f0e-6c2b2a1d9e21"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "construction"
+                },
+                "sort_order": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"
+                }
+            }
+        },
+        "datastruct.CategoryNode": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/datastruct.AttributeDefinition"
+                    }
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.CategoryNode"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Construction"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "5e0c9d7a-1f7b-4f43-8f0e-6c2b2a1d9e21"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "construction"
+                },
+                "sort_order": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"
+                }
+            }
+        },
+        "datastruct.Client": {
+            "type": "object",
+            "required": [
+                "address",
+                "birthday",
+                "client_name",
+                "client_surname",
+                "gender",
+                "registration_date"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/datastruct.Address"
+                },
+                "birthday": {
+                    "type": "string",
+                    "example": "10.12.2011"
+                },
+                "client_name": {
+                    "type": "string",
+                    "example": "Vasilisa"
+                },
+                "client_surname": {
+                    "type": "string",
+                    "example": "Kadyk"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2026-01-30T10:00:00Z"
+                },
+                "gender": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/datastruct.Gender"
+                        }
+                    ],
+                    "example": "female"
+                },
+                "registration_date": {
+                    "type": "string",
+                    "example": "30/01/2026"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "4988150e-1c82-490f-8c07-ee74ace2dd14"
+                }
+            }
+        },
+        "datastruct.ClientMatch": {
+            "type": "object",
+            "required": [
+                "address",
+                "birthday",
+                "client_name",
+                "client_surname",
+                "gender",
+                "registration_date"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/datastruct.Address"
+                },
+                "birthday": {
+                    "type": "string",
+                    "example": "10.12.2011"
+                },
+                "client_name": {
+                    "type": "string",
+                    "example": "Vasilisa"
+                },
+                "client_surname": {
+                    "type": "string",
+                    "example": "Kadyk"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2026-01-30T10:00:00Z"
+                },
+                "gender": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/datastruct.Gender"
+                        }
+                    ],
+                    "example": "female"
+                },
+                "registration_date": {
+                    "type": "string",
+                    "example": "30/01/2026"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.86
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "4988150e-1c82-490f-8c07-ee74ace2dd14"
+                }
+            }
+        },
+        "datastruct.ClientVersion": {
+            "type": "object",
+            "properties": {
+                "client": {
+                    "$ref": "#/definitions/datastruct.Client"
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "valid_to": {
+                    "type": "string",
+                    "example": "2025-02-01T00:00:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "datastruct.DecreaseProductsRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "variant_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "variant_id": {
+                    "type": "string",
+                    "example": "5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"
+                }
+            }
+        },
+        "datastruct.DecreaseProductsResponse": {
+            "type": "object",
+            "properties": {
+                "left": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.DeleteCategoryRequest": {
+            "type": "object",
+            "required": [
+                "uid"
+            ],
+            "properties": {
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"
+                }
+            }
+        },
+        "datastruct.DeleteCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.DeleteClientRequest": {
+            "type": "object",
+            "required": [
+                "uid"
+            ],
+            "properties": {
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "4988150e-1c82-490f-8c07-ee74ace2dd14"
+                }
+            }
+        },
+        "datastruct.DeleteClientResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.DeleteImageRequest": {
+            "type": "object",
+            "required": [
+                "uid"
+            ],
+            "properties": {
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "policy": {
+                    "enum": [
+                        "restrict",
+                        "cascade"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/datastruct.DeletePolicy"
+                        }
+                    ],
+                    "example": "cascade"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
+                }
+            }
+        },
+        "datastruct.DeleteImageResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.DeletePolicy": {
+            "type": "string",
+            "enum": [
+                "restrict",
+                "cascade",
+                "reassign"
+            ],
+            "x-enum-varnames": [
+                "DeleteRestrict",
+                "DeleteCascade",
+                "DeleteReassign"
+            ]
+        },
+        "datastruct.DeleteProductDocumentRequest": {
+            "type": "object",
+            "required": [
+                "uid"
+            ],
+            "properties": {
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "8a3c1e4f-2b7d-4c5e-9f1a-6d2e8b0c4a17"
+                }
+            }
+        },
+        "datastruct.DeleteProductDocumentResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.DeleteProductRequest": {
+            "type": "object",
+            "required": [
+                "uid"
+            ],
+            "properties": {
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                }
+            }
+        },
+        "datastruct.DeleteProductResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.DeleteProductVariantRequest": {
+            "type": "object",
+            "required": [
+                "uid"
+            ],
+            "properties": {
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"
+                }
+            }
+        },
+        "datastruct.DeleteProductVariantResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.DeleteRoleRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "catalog_manager"
+                }
+            }
+        },
+        "datastruct.DeleteRoleResponse": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.DeleteSupplierRequest": {
+            "type": "object",
+            "required": [
+                "uid"
+            ],
+            "properties": {
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "policy": {
+                    "enum": [
+                        "restrict",
+                        "cascade",
+                        "reassign"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/datastruct.DeletePolicy"
+                        }
+                    ],
+                    "example": "reassign"
+                },
+                "reassign_to": {
+                    "type": "string",
+                    "example": "2f1d5a0e-8c4b-4e7a-9b3d-6a5c4e3f2d1b"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
+                }
+            }
+        },
+        "datastruct.DeleteSupplierResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.DetachProductImageRequest": {
+            "type": "object",
+            "required": [
+                "image_id",
+                "product_id"
+            ],
+            "properties": {
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "image_id": {
+                    "type": "string",
+                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
+                },
+                "product_id": {
+                    "type": "string",
+                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                }
+            }
+        },
+        "datastruct.DetachProductImageResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.ExchangeRate": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "92.5"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                }
+            }
+        },
+        "datastruct.ForbiddenResponse": {
+            "type": "object",
+            "properties": {
+                "permission": {
+                    "type": "string",
+                    "example": "products:write"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.Gender": {
+            "type": "string",
+            "enum": [
+                "male",
+                "female"
+            ],
+            "x-enum-varnames": [
+                "Male",
+                "Female"
+            ]
+        },
+        "datastruct.GetApiKeysResponse": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.ApiKey"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.GetAuditResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.AuditEntry"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.GetBarcodeResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.GetCategoriesTreeResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.CategoryNode"
+                    }
+                }
+            }
+        },
+        "datastruct.GetCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "category": {
+                    "$ref": "#/definitions/datastruct.Category"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.GetClientHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.ClientVersion"
+                    }
+                }
+            }
+        },
+        "datastruct.GetClientsByNameResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "clients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.ClientMatch"
+                    }
+                }
+            }
+        },
+        "datastruct.GetClientsResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "clients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.Client"
+                    }
+                }
+            }
+        },
+        "datastruct.GetExchangeRatesResponse": {
+            "type": "object",
+            "properties": {
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.ExchangeRate"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.GetImageMetaResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "meta": {
+                    "$ref": "#/definitions/datastruct.ImageMeta"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.GetImageResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "modified_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "datastruct.GetProductDocumentResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "datastruct.GetProductDocumentsResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.ProductDocument"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.GetProductHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.ProductVersion"
+                    }
+                }
+            }
+        },
+        "datastruct.GetProductImageResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "modified_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "datastruct.GetProductResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "product": {
+                    "$ref": "#/definitions/datastruct.Product"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.GetProductsResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.Product"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.GetPromotionsResponse": {
+            "type": "object",
+            "properties": {
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.Promotion"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.GetRolesResponse": {
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.Role"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.GetSupplierHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.SupplierVersion"
+                    }
+                }
+            }
+        },
+        "datastruct.GetSupplierResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "supplier": {
+                    "$ref": "#/definitions/datastruct.Supplier"
+                }
+            }
+        },
+        "datastruct.GetSuppliersResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "suppliers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.Supplier"
+                    }
+                }
+            }
+        },
+        "datastruct.GetVariantPricesResponse": {
+            "type": "object",
+            "properties": {
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.VariantPrice"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.ImageMeta": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2026-01-30T10:00:00Z"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "jpeg"
+                },
+                "hash": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 1080
+                },
+                "mime": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "modified_at": {
+                    "type": "string",
+                    "example": "2024-05-01T10:00:00Z"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 524288
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 1920
+                }
+            }
+        },
+        "datastruct.IssueApiKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "roles"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-02T15:04:05Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "warehouse"
+                },
+                "roles": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "warehouse"
+                    ]
+                }
+            }
+        },
+        "datastruct.IssueApiKeyResponse": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "$ref": "#/definitions/datastruct.ApiKey"
+                },
+                "key": {
+                    "description": "Key is shown only in this response, it can't be read again.",
+                    "type": "string",
+                    "example": "sk_Xq3v9aL0mZ8yT2nR5bW7cK1dF4gH6jP9sU3vE0xA2iO"
+                },
+                "missing_permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "roles:manage"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "unknown_roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "stock_keeper"
+                    ]
+                }
+            }
+        },
+        "datastruct.PatchClientAddressRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "uid"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/datastruct.Address"
+                },
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "4988150e-1c82-490f-8c07-ee74ace2dd14"
+                }
+            }
+        },
+        "datastruct.PatchClientAddressResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.PriceBasketRequest": {
+            "type": "object",
+            "required": [
+                "client_id",
+                "items"
+            ],
+            "properties": {
+                "client_id": {
+                    "type": "string",
+                    "example": "b0ad3d3c-8a0b-4a8e-9f7e-1c5d4c3b2a10"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SPRING25"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/datastruct.BasketItem"
+                    }
+                }
+            }
+        },
+        "datastruct.PriceBasketResponse": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "string",
+                    "example": "149.98 RUB"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.BasketLine"
+                    }
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.AppliedPromotion"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "599.90 RUB"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "449.92 RUB"
+                }
+            }
+        },
+        "datastruct.Product": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "image_id",
+                "name",
+                "sku",
+                "supplier_id",
+                "variants"
+            ],
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "barcode": {
+                    "type": "string",
+                    "example": "4006381333931"
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2026-01-30T10:00:00Z"
+                },
+                "image_id": {
+                    "type": "string",
+                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datastruct.ProductImage"
+                    }
+                },
+                "last_update_date": {
+                    "type": "string",
+                    "example": "31.01.2026"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Wooden beam"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "size",
+                        "colour"
+                    ]
                 },
-                "registration_date": {
+                "sku": {
                     "type": "string",
-                    "example": "30/01/2026"
+                    "example": "BEAM-OAK"
+                },
+                "supplier_id": {
+                    "type": "string",
+                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
                 },
                 "uid": {
                     "type": "string",
-                    "example": "4988150e-1c82-490f-8c07-ee74ace2dd14"
+                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                },
+                "variants": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/datastruct.ProductVariant"
+                    }
                 }
             }
         },
-        "datastruct.AddClientResponse": {
+        "datastruct.ProductDocument": {
             "type": "object",
             "properties": {
-                "cached": {
-                    "type": "boolean",
-                    "example": false
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-05-01T10:00:00Z"
                 },
-                "status": {
+                "hash": {
                     "type": "string",
-                    "example": "status message"
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "mime": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 524288
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Oak beam spec sheet"
                 },
                 "uid": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "8a3c1e4f-2b7d-4c5e-9f1a-6d2e8b0c4a17"
                 }
             }
         },
-        "datastruct.AddImageResponse": {
+        "datastruct.ProductImage": {
             "type": "object",
             "properties": {
-                "cached": {
-                    "type": "boolean",
-                    "example": false
+                "alt_text": {
+                    "type": "string",
+                    "example": "Oak beam, side view"
                 },
-                "status": {
+                "image_id": {
                     "type": "string",
-                    "example": "status message"
+                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
                 },
-                "uid": {
-                    "type": "string"
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "primary": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "datastruct.AddProductRequest": {
+        "datastruct.ProductVariant": {
             "type": "object",
             "required": [
                 "available_stock",
-                "category",
-                "image_id",
-                "name",
                 "price",
-                "supplier_id"
+                "sku"
             ],
             "properties": {
                 "available_stock": {
                     "type": "integer",
                     "example": 1023
                 },
-                "avoid_cache": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "category": {
+                "barcode": {
                     "type": "string",
-                    "example": "construction"
+                    "example": "4006381333924"
                 },
                 "image_id": {
                     "type": "string",
                     "example": "376de312-5bcb-4320-8ba3-bd2050548229"
                 },
-                "last_update_date": {
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "price": {
                     "type": "string",
-                    "example": "31.01.2026"
+                    "example": "299.95 RUB"
                 },
-                "name": {
+                "price_effective_to": {
                     "type": "string",
-                    "example": "Wooden beam"
+                    "example": "2025-02-01T00:00:00Z"
                 },
-                "price": {
-                    "type": "number",
-                    "example": 299.95
+                "regular_price": {
+                    "description": "RegularPrice and PriceEffectiveTo are returned while a temporary price is in effect, Price is the temporary one then.",
+                    "type": "string",
+                    "example": "349.95 RUB"
                 },
-                "supplier_id": {
+                "sku": {
                     "type": "string",
-                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
+                    "example": "BEAM-OAK-2M"
                 },
                 "uid": {
                     "type": "string",
-                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                    "example": "5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"
                 }
             }
         },
-        "datastruct.AddProductResponse": {
+        "datastruct.ProductVersion": {
             "type": "object",
             "properties": {
-                "cached": {
-                    "type": "boolean",
-                    "example": false
+                "product": {
+                    "$ref": "#/definitions/datastruct.Product"
                 },
-                "status": {
+                "valid_from": {
                     "type": "string",
-                    "example": "status message"
+                    "example": "2025-01-01T00:00:00Z"
                 },
-                "uid": {
-                    "type": "string"
+                "valid_to": {
+                    "type": "string",
+                    "example": "2025-02-01T00:00:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "datastruct.AddSupplierRequest": {
+        "datastruct.Promotion": {
             "type": "object",
             "required": [
-                "address",
-                "name",
-                "phone_number"
+                "kind",
+                "name"
             ],
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/datastruct.Address"
+                "amount": {
+                    "type": "string",
+                    "example": "100 RUB"
                 },
-                "avoid_cache": {
-                    "type": "boolean",
-                    "example": true
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"
+                    ]
                 },
-                "name": {
+                "code": {
                     "type": "string",
-                    "example": "Vasilisa\u0026Drozzhi .ltd"
+                    "example": "SPRING25"
                 },
-                "phone_number": {
+                "ends_at": {
                     "type": "string",
-                    "example": "+79336579933 RU"
+                    "example": "2025-04-01T00:00:00Z"
                 },
-                "uid": {
+                "kind": {
                     "type": "string",
-                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
-                }
-            }
-        },
-        "datastruct.AddSupplierResponse": {
-            "type": "object",
-            "properties": {
-                "cached": {
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ],
+                    "example": "percent"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Spring sale"
+                },
+                "per_client_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "percent": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 25
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "c85a189d-d173-42e2-8e00-54395234d93d"
+                    ]
+                },
+                "stackable": {
                     "type": "boolean",
                     "example": false
                 },
-                "status": {
+                "starts_at": {
                     "type": "string",
-                    "example": "status message"
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "supplier_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
+                    ]
                 },
                 "uid": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "3f1c2b9e-5d4a-4e8f-9b7c-1a2d3e4f5a6b"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1000
+                },
+                "uses": {
+                    "type": "integer",
+                    "example": 42
                 }
             }
         },
-        "datastruct.Address": {
+        "datastruct.RedeemBasketRequest": {
             "type": "object",
             "required": [
-                "city",
-                "country",
-                "street"
+                "client_id",
+                "items",
+                "order_id"
             ],
             "properties": {
-                "city": {
+                "client_id": {
                     "type": "string",
-                    "example": "Seattle"
+                    "example": "b0ad3d3c-8a0b-4a8e-9f7e-1c5d4c3b2a10"
                 },
-                "country": {
+                "code": {
                     "type": "string",
-                    "example": "USA"
+                    "example": "SPRING25"
                 },
-                "street": {
+                "currency": {
                     "type": "string",
-                    "example": "12th Ave E"
+                    "example": "RUB"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/datastruct.BasketItem"
+                    }
+                },
+                "order_id": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "ORD-2025-000123"
                 }
             }
         },
-        "datastruct.Client": {
+        "datastruct.ReorderProductImagesRequest": {
             "type": "object",
             "required": [
-                "address",
-                "birthday",
-                "client_name",
-                "client_surname",
-                "gender",
-                "registration_date"
+                "image_ids",
+                "product_id"
             ],
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/datastruct.Address"
-                },
-                "birthday": {
-                    "type": "string",
-                    "example": "10.12.2011"
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "client_name": {
-                    "type": "string",
-                    "example": "Vasilisa"
+                "image_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "client_surname": {
+                "primary_image_id": {
                     "type": "string",
-                    "example": "Kadyk"
-                },
-                "gender": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/datastruct.Gender"
-                        }
-                    ],
-                    "example": "female"
+                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
                 },
-                "registration_date": {
+                "product_id": {
                     "type": "string",
-                    "example": "30/01/2026"
+                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                }
+            }
+        },
+        "datastruct.ReorderProductImagesResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "uid": {
+                "status": {
                     "type": "string",
-                    "example": "4988150e-1c82-490f-8c07-ee74ace2dd14"
+                    "example": "status message"
                 }
             }
         },
-        "datastruct.DecreaseProductsRequest": {
+        "datastruct.RestoreClientRequest": {
             "type": "object",
             "required": [
-                "amount",
                 "uid"
             ],
             "properties": {
-                "amount": {
-                    "type": "integer",
-                    "example": 3
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
                 },
                 "uid": {
                     "type": "string",
-                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                    "example": "4988150e-1c82-490f-8c07-ee74ace2dd14"
                 }
             }
         },
-        "datastruct.DecreaseProductsResponse": {
+        "datastruct.RestoreClientResponse": {
             "type": "object",
             "properties": {
-                "left": {
-                    "type": "integer"
+                "cached": {
+                    "type": "boolean",
+                    "example": false
                 },
                 "status": {
                     "type": "string",
@@ -1295,7 +7545,7 @@ const docTemplate = `{
                 }
             }
         },
-        "datastruct.DeleteClientRequest": {
+        "datastruct.RestoreImageRequest": {
             "type": "object",
             "required": [
                 "uid"
@@ -1307,11 +7557,11 @@ const docTemplate = `{
                 },
                 "uid": {
                     "type": "string",
-                    "example": "4988150e-1c82-490f-8c07-ee74ace2dd14"
+                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
                 }
             }
         },
-        "datastruct.DeleteClientResponse": {
+        "datastruct.RestoreImageResponse": {
             "type": "object",
             "properties": {
                 "cached": {
@@ -1324,7 +7574,7 @@ const docTemplate = `{
                 }
             }
         },
-        "datastruct.DeleteImageRequest": {
+        "datastruct.RestoreProductRequest": {
             "type": "object",
             "required": [
                 "uid"
@@ -1336,11 +7586,11 @@ const docTemplate = `{
                 },
                 "uid": {
                     "type": "string",
-                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
+                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
                 }
             }
         },
-        "datastruct.DeleteImageResponse": {
+        "datastruct.RestoreProductResponse": {
             "type": "object",
             "properties": {
                 "cached": {
@@ -1353,7 +7603,7 @@ const docTemplate = `{
                 }
             }
         },
-        "datastruct.DeleteProductRequest": {
+        "datastruct.RestoreSupplierRequest": {
             "type": "object",
             "required": [
                 "uid"
@@ -1365,11 +7615,11 @@ const docTemplate = `{
                 },
                 "uid": {
                     "type": "string",
-                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
                 }
             }
         },
-        "datastruct.DeleteProductResponse": {
+        "datastruct.RestoreSupplierResponse": {
             "type": "object",
             "properties": {
                 "cached": {
@@ -1382,152 +7632,217 @@ const docTemplate = `{
                 }
             }
         },
-        "datastruct.DeleteSupplierRequest": {
+        "datastruct.RevokeApiKeyRequest": {
+            "type": "object",
+            "required": [
+                "uid"
+            ],
+            "properties": {
+                "uid": {
+                    "type": "string",
+                    "example": "9a0c6a52-3c57-4c61-9c1e-7b3c1c8a5b21"
+                }
+            }
+        },
+        "datastruct.RevokeApiKeyResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "status message"
+                }
+            }
+        },
+        "datastruct.Role": {
+            "type": "object",
+            "required": [
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Manages the catalog"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "catalog_manager"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "products:read",
+                        "products:write"
+                    ]
+                }
+            }
+        },
+        "datastruct.SetExchangeRateRequest": {
             "type": "object",
             "required": [
-                "uid"
+                "currency",
+                "rate"
             ],
             "properties": {
-                "avoid_cache": {
-                    "type": "boolean",
-                    "example": true
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
                 },
-                "uid": {
+                "rate": {
                     "type": "string",
-                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
+                    "example": "92.5"
                 }
             }
         },
-        "datastruct.DeleteSupplierResponse": {
+        "datastruct.SetExchangeRateResponse": {
             "type": "object",
             "properties": {
-                "cached": {
-                    "type": "boolean",
-                    "example": false
-                },
                 "status": {
                     "type": "string",
                     "example": "status message"
                 }
             }
         },
-        "datastruct.Gender": {
-            "type": "string",
-            "enum": [
-                "male",
-                "female"
-            ],
-            "x-enum-varnames": [
-                "Male",
-                "Female"
-            ]
-        },
-        "datastruct.GetClientsByNameResponse": {
+        "datastruct.SetVariantPriceRequest": {
             "type": "object",
+            "required": [
+                "price",
+                "variant_uid"
+            ],
             "properties": {
-                "cached": {
-                    "type": "boolean",
-                    "example": false
+                "effective_from": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
                 },
-                "clients": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/datastruct.Client"
-                    }
+                "effective_to": {
+                    "type": "string",
+                    "example": "2025-02-01T00:00:00Z"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "249.95 RUB"
+                },
+                "variant_uid": {
+                    "type": "string",
+                    "example": "5d1b2c4a-93c1-4f55-8d0c-2a0b2f6e4c11"
                 }
             }
         },
-        "datastruct.GetClientsResponse": {
+        "datastruct.SetVariantPriceResponse": {
             "type": "object",
             "properties": {
-                "cached": {
-                    "type": "boolean",
-                    "example": false
+                "id": {
+                    "type": "integer",
+                    "example": 42
                 },
-                "clients": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/datastruct.Client"
-                    }
+                "status": {
+                    "type": "string",
+                    "example": "status message"
                 }
             }
         },
-        "datastruct.GetImageResponse": {
+        "datastruct.Status": {
             "type": "object",
             "properties": {
-                "cached": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "image": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
                 "status": {
                     "type": "string",
                     "example": "status message"
-                },
-                "uid": {
-                    "type": "string"
                 }
             }
         },
-        "datastruct.GetProductImageResponse": {
+        "datastruct.Supplier": {
             "type": "object",
+            "required": [
+                "address",
+                "name",
+                "phone_number"
+            ],
             "properties": {
-                "cached": {
-                    "type": "boolean",
-                    "example": false
+                "address": {
+                    "$ref": "#/definitions/datastruct.Address"
                 },
-                "image": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2026-01-30T10:00:00Z"
                 },
-                "status": {
+                "name": {
                     "type": "string",
-                    "example": "status message"
+                    "example": "Vasilisa\u0026Drozzhi .ltd"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "+79336579933 RU"
                 },
                 "uid": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
                 }
             }
         },
-        "datastruct.GetProductResponse": {
+        "datastruct.SupplierVersion": {
             "type": "object",
             "properties": {
-                "cached": {
-                    "type": "boolean",
-                    "example": false
+                "supplier": {
+                    "$ref": "#/definitions/datastruct.Supplier"
                 },
-                "product": {
-                    "$ref": "#/definitions/datastruct.Product"
+                "valid_from": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
                 },
-                "status": {
+                "valid_to": {
                     "type": "string",
-                    "example": "status message"
+                    "example": "2025-02-01T00:00:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "datastruct.GetProductsResponse": {
+        "datastruct.UpdateCategoryRequest": {
             "type": "object",
+            "required": [
+                "name",
+                "uid"
+            ],
             "properties": {
-                "cached": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "products": {
+                "attributes": {
                     "type": "array",
+                    "uniqueItems": true,
                     "items": {
-                        "$ref": "#/definitions/datastruct.Product"
+                        "$ref": "#/definitions/datastruct.AttributeDefinition"
                     }
+                },
+                "avoid_cache": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Construction"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "5e0c9d7a-1f7b-4f43-8f0e-6c2b2a1d9e21"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "construction"
+                },
+                "sort_order": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"
                 }
             }
         },
-        "datastruct.GetSupplierResponse": {
+        "datastruct.UpdateCategoryResponse": {
             "type": "object",
             "properties": {
                 "cached": {
@@ -1537,36 +7852,31 @@ const docTemplate = `{
                 "status": {
                     "type": "string",
                     "example": "status message"
-                },
-                "supplier": {
-                    "$ref": "#/definitions/datastruct.Supplier"
                 }
             }
         },
-        "datastruct.GetSuppliersResponse": {
+        "datastruct.UpdateImageResponse": {
             "type": "object",
             "properties": {
                 "cached": {
                     "type": "boolean",
                     "example": false
                 },
-                "suppliers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/datastruct.Supplier"
-                    }
+                "status": {
+                    "type": "string",
+                    "example": "status message"
                 }
             }
         },
-        "datastruct.PatchClientAddressRequest": {
+        "datastruct.UpdateProductAttributesRequest": {
             "type": "object",
             "required": [
-                "address",
                 "uid"
             ],
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/datastruct.Address"
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {}
                 },
                 "avoid_cache": {
                     "type": "boolean",
@@ -1574,11 +7884,11 @@ const docTemplate = `{
                 },
                 "uid": {
                     "type": "string",
-                    "example": "4988150e-1c82-490f-8c07-ee74ace2dd14"
+                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
                 }
             }
         },
-        "datastruct.PatchClientAddressResponse": {
+        "datastruct.UpdateProductAttributesResponse": {
             "type": "object",
             "properties": {
                 "cached": {
@@ -1591,52 +7901,103 @@ const docTemplate = `{
                 }
             }
         },
-        "datastruct.Product": {
+        "datastruct.UpdatePromotionRequest": {
             "type": "object",
             "required": [
-                "available_stock",
-                "category",
-                "image_id",
-                "name",
-                "price",
-                "supplier_id"
+                "kind",
+                "name"
             ],
             "properties": {
-                "available_stock": {
-                    "type": "integer",
-                    "example": 1023
+                "amount": {
+                    "type": "string",
+                    "example": "100 RUB"
                 },
-                "category": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "0b6f3e2c-6a43-4c1b-9a4e-2f0f4a4c8f10"
+                    ]
+                },
+                "code": {
                     "type": "string",
-                    "example": "construction"
+                    "example": "SPRING25"
                 },
-                "image_id": {
+                "ends_at": {
                     "type": "string",
-                    "example": "376de312-5bcb-4320-8ba3-bd2050548229"
+                    "example": "2025-04-01T00:00:00Z"
                 },
-                "last_update_date": {
+                "kind": {
                     "type": "string",
-                    "example": "31.01.2026"
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ],
+                    "example": "percent"
                 },
                 "name": {
                     "type": "string",
-                    "example": "Wooden beam"
+                    "example": "Spring sale"
                 },
-                "price": {
-                    "type": "number",
-                    "example": 299.95
+                "per_client_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
                 },
-                "supplier_id": {
+                "percent": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 25
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "c85a189d-d173-42e2-8e00-54395234d93d"
+                    ]
+                },
+                "stackable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "starts_at": {
                     "type": "string",
-                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "supplier_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
+                    ]
                 },
                 "uid": {
                     "type": "string",
-                    "example": "c85a189d-d173-42e2-8e00-54395234d93d"
+                    "example": "3f1c2b9e-5d4a-4e8f-9b7c-1a2d3e4f5a6b"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1000
+                },
+                "uses": {
+                    "type": "integer",
+                    "example": 42
                 }
             }
         },
-        "datastruct.Status": {
+        "datastruct.UpdatePromotionResponse": {
             "type": "object",
             "properties": {
                 "status": {
@@ -1645,37 +8006,44 @@ const docTemplate = `{
                 }
             }
         },
-        "datastruct.Supplier": {
+        "datastruct.UpdateRoleRequest": {
             "type": "object",
             "required": [
-                "address",
                 "name",
-                "phone_number"
+                "permissions"
             ],
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/datastruct.Address"
-                },
-                "name": {
+                "description": {
                     "type": "string",
-                    "example": "Vasilisa\u0026Drozzhi .ltd"
+                    "example": "Manages the catalog"
                 },
-                "phone_number": {
+                "name": {
                     "type": "string",
-                    "example": "+79336579933 RU"
+                    "example": "catalog_manager"
                 },
-                "uid": {
-                    "type": "string",
-                    "example": "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "products:read",
+                        "products:write"
+                    ]
                 }
             }
         },
-        "datastruct.UpdateImageResponse": {
+        "datastruct.UpdateRoleResponse": {
             "type": "object",
             "properties": {
-                "cached": {
-                    "type": "boolean",
-                    "example": false
+                "missing_permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "roles:manage"
+                    ]
                 },
                 "status": {
                     "type": "string",
@@ -1715,6 +8083,45 @@ const docTemplate = `{
                     "example": "status message"
                 }
             }
+        },
+        "datastruct.VariantPrice": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-12-20T10:00:00Z"
+                },
+                "effective_from": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "effective_to": {
+                    "type": "string",
+                    "example": "2025-02-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "price": {
+                    "type": "string",
+                    "example": "299.95 RUB"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Ключ API для вызовов между сервисами, выпускается через POST /api-key.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT админки, подписанный HS256, в виде \"Bearer \u003ctoken\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
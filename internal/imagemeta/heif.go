@@ -0,0 +1,139 @@
+package imagemeta
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+type isoBox struct {
+	kind string
+	data []byte
+}
+
+// isoBoxes splits the data into ISO base media boxes, a box not fitting the data fails
+// with io.ErrUnexpectedEOF, the boxes before it are returned.
+func isoBoxes(data []byte) ([]isoBox, error) {
+	var boxes []isoBox
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return boxes, io.ErrUnexpectedEOF
+		}
+
+		size := uint64(binary.BigEndian.Uint32(data[:4]))
+		kind := string(data[4:8])
+		header := uint64(8)
+		switch size {
+		case 0:
+			// The last box lasts to the end.
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return boxes, io.ErrUnexpectedEOF
+			}
+			size = binary.BigEndian.Uint64(data[8:16])
+			header = 16
+		}
+
+		if size < header {
+			return boxes, errors.New("box is smaller than its header")
+		}
+		if size > uint64(len(data)) {
+			return boxes, io.ErrUnexpectedEOF
+		}
+
+		boxes = append(boxes, isoBox{kind: kind, data: data[header:size]})
+		data = data[size:]
+	}
+
+	return boxes, nil
+}
+
+func findBox(boxes []isoBox, kind string) (isoBox, bool) {
+	for _, b := range boxes {
+		if b.kind == kind {
+			return b, true
+		}
+	}
+	return isoBox{}, false
+}
+
+// decodeHEIFConfig checks the boxes of the whole image fit it and reads its size.
+func decodeHEIFConfig(r io.Reader, format string) (Meta, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	boxes, err := isoBoxes(data)
+	if err != nil {
+		return Meta{}, corrupt(format, err)
+	}
+
+	return heifConfig(boxes, format)
+}
+
+// heifHeadConfig reads the size of the image from its head, the boxes following the meta one may not fit it.
+func heifHeadConfig(r io.Reader, format string) (Meta, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	boxes, err := isoBoxes(data)
+	if _, ok := findBox(boxes, "meta"); !ok && err != nil {
+		return Meta{}, corrupt(format, err)
+	}
+
+	return heifConfig(boxes, format)
+}
+
+// heifConfig reads the size of the image from the spatial extents properties, which are in the meta box
+// as meta/iprp/ipco/ispe. The largest one is taken, the others are of thumbnails and tiles.
+func heifConfig(boxes []isoBox, format string) (Meta, error) {
+	if len(boxes) == 0 || boxes[0].kind != "ftyp" {
+		return Meta{}, corrupt(format, errors.New("no ftyp box"))
+	}
+
+	meta, ok := findBox(boxes, "meta")
+	// The meta box is a full one, its children follow the version and flags.
+	if !ok || len(meta.data) < 4 {
+		return Meta{}, corrupt(format, errors.New("no meta box"))
+	}
+
+	properties := meta.data[4:]
+	for _, kind := range []string{"iprp", "ipco"} {
+		children, err := isoBoxes(properties)
+		if err != nil {
+			return Meta{}, corrupt(format, err)
+		}
+		box, ok := findBox(children, kind)
+		if !ok {
+			return Meta{}, corrupt(format, errors.New("no "+kind+" box"))
+		}
+		properties = box.data
+	}
+
+	children, err := isoBoxes(properties)
+	if err != nil {
+		return Meta{}, corrupt(format, err)
+	}
+
+	result := Meta{Format: format}
+	for _, b := range children {
+		if b.kind != "ispe" || len(b.data) < 12 {
+			continue
+		}
+		width := uint64(binary.BigEndian.Uint32(b.data[4:8]))
+		height := uint64(binary.BigEndian.Uint32(b.data[8:12]))
+		if width*height > uint64(result.Width)*uint64(result.Height) {
+			result.Width, result.Height = int(width), int(height)
+		}
+	}
+
+	if result.Width == 0 || result.Height == 0 {
+		return Meta{}, corrupt(format, errors.New("no image size"))
+	}
+
+	return result, nil
+}
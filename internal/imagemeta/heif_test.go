@@ -0,0 +1,84 @@
+package imagemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newBox(kind string, data ...[]byte) []byte {
+	content := bytes.Join(data, nil)
+	box := binary.BigEndian.AppendUint32(nil, uint32(8+len(content)))
+	return append(append(box, kind...), content...)
+}
+
+func newSpatialExtents(width, height uint32) []byte {
+	data := binary.BigEndian.AppendUint32(make([]byte, 4), width)
+	return newBox("ispe", binary.BigEndian.AppendUint32(data, height))
+}
+
+// newHEIF builds an image with the ftyp, meta and mdat boxes, the meta one has the spatial extents
+// of the image and of its thumbnail.
+func newHEIF(brand string) []byte {
+	ipco := newBox("ipco", newSpatialExtents(64, 48), newSpatialExtents(640, 480))
+	meta := newBox("meta", make([]byte, 4), newBox("hdlr", make([]byte, 24)), newBox("iprp", ipco))
+
+	return bytes.Join([][]byte{
+		newBox("ftyp", []byte(brand+"\x00\x00\x00\x00mif1miaf")),
+		meta,
+		newBox("mdat", bytes.Repeat([]byte{0xaa}, 1000)),
+	}, nil)
+}
+
+func TestDecodeHEIF(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DecodeHEIF ok", func(t *testing.T) {
+		t.Parallel()
+
+		for brand, format := range map[string]string{"avif": FormatAVIF, "heic": FormatHEIC} {
+			image := newHEIF(brand)
+
+			meta, err := Decode(bytes.NewReader(image))
+			require.Nil(t, err)
+			require.Equal(t, Meta{Width: 640, Height: 480, Format: format}, meta)
+
+			meta, err = DecodeConfig(bytes.NewReader(image[:len(image)-100]))
+			require.Nil(t, err)
+			require.Equal(t, Meta{Width: 640, Height: 480, Format: format}, meta)
+		}
+	})
+
+	t.Run("DecodeHEIF error on truncated image", func(t *testing.T) {
+		t.Parallel()
+
+		image := newHEIF("avif")
+
+		_, err := Decode(bytes.NewReader(image[:len(image)-100]))
+		require.ErrorIs(t, err, ErrCorrupt)
+
+		_, err = DecodeConfig(bytes.NewReader(image[:40]))
+		require.ErrorIs(t, err, ErrCorrupt)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("DecodeHEIF error on no size", func(t *testing.T) {
+		t.Parallel()
+
+		image := append(newBox("ftyp", []byte("avif\x00\x00\x00\x00mif1")), newBox("meta", make([]byte, 4))...)
+
+		_, err := Decode(bytes.NewReader(image))
+		require.ErrorIs(t, err, ErrCorrupt)
+	})
+
+	t.Run("Exceeds", func(t *testing.T) {
+		t.Parallel()
+
+		require.False(t, Meta{Width: 100, Height: 100}.Exceeds(10000))
+		require.True(t, Meta{Width: 101, Height: 100}.Exceeds(10000))
+		require.True(t, Meta{Width: 1 << 32, Height: 1 << 32}.Exceeds(10000))
+	})
+}
@@ -0,0 +1,152 @@
+package imagemeta
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+
+	mimeManager "shopapi/internal/mime-manager"
+	"shopapi/internal/thumbnail"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+	FormatWebP = "webp"
+	FormatAVIF = "avif"
+	FormatHEIC = "heic"
+	FormatHEIF = "heif"
+	FormatSVG  = "svg"
+)
+
+var mimeFormats = map[string]string{
+	"image/jpeg":    FormatJPEG,
+	"image/png":     FormatPNG,
+	"image/gif":     FormatGIF,
+	"image/webp":    FormatWebP,
+	"image/avif":    FormatAVIF,
+	"image/heic":    FormatHEIC,
+	"image/heif":    FormatHEIF,
+	"image/svg+xml": FormatSVG,
+}
+
+// ErrCorrupt is returned for content that passes the mime sniffing but is not a valid image.
+var ErrCorrupt = errors.New("image is corrupt")
+
+// Meta is recorded when the image is uploaded. The size of a vector image is zero unless it is set
+// by its attributes.
+type Meta struct {
+	Width  int
+	Height int
+	Format string
+}
+
+// Exceeds checks the image has more pixels than the limit, the sides are checked first so their product
+// can't overflow.
+func (m Meta) Exceeds(maxPixels int) bool {
+	return m.Width > maxPixels || m.Height > maxPixels || m.Width*m.Height > maxPixels
+}
+
+// FormatOf returns the image format sniffed from the head of the content, empty if it is not an image.
+func FormatOf(head []byte) string {
+	return mimeFormats[mimeManager.DetectMimeType(head)]
+}
+
+// Decode decodes the whole image to validate it and returns its metadata. Webp, AVIF and HEIC images
+// are validated by their headers only, as there are no decoders for them, SVG is parsed as XML.
+func Decode(r io.Reader) (Meta, error) {
+	br := bufio.NewReaderSize(r, mimeManager.SniffLen)
+	magic, _ := br.Peek(mimeManager.SniffLen)
+
+	switch format := FormatOf(magic); format {
+	case FormatWebP:
+		return decodeWebPConfig(br)
+	case FormatAVIF, FormatHEIC, FormatHEIF:
+		return decodeHEIFConfig(br, format)
+	case FormatSVG:
+		return decodeSVG(br)
+	}
+
+	// The head read for the config is replayed to decode the whole image.
+	var config bytes.Buffer
+	cfg, format, err := image.DecodeConfig(io.TeeReader(br, &config))
+	if err != nil {
+		return Meta{}, fmt.Errorf("%w: %w", ErrCorrupt, err)
+	}
+	meta := Meta{Width: cfg.Width, Height: cfg.Height, Format: format}
+	if cfg.Width == 0 || cfg.Height == 0 || meta.Exceeds(thumbnail.MaxSourcePixels) {
+		return Meta{}, fmt.Errorf("%w: image is %dx%d", ErrCorrupt, cfg.Width, cfg.Height)
+	}
+
+	if _, _, err = image.Decode(io.MultiReader(&config, br)); err != nil {
+		return Meta{}, fmt.Errorf("%w: %w", ErrCorrupt, err)
+	}
+
+	return meta, nil
+}
+
+// DecodeConfig reads the format and size of the image from its head, the rest of it is not validated.
+func DecodeConfig(r io.Reader) (Meta, error) {
+	br := bufio.NewReaderSize(r, mimeManager.SniffLen)
+	magic, _ := br.Peek(mimeManager.SniffLen)
+
+	switch format := FormatOf(magic); format {
+	case FormatWebP:
+		return webpHeadConfig(br)
+	case FormatAVIF, FormatHEIC, FormatHEIF:
+		return heifHeadConfig(br, format)
+	case FormatSVG:
+		return svgHeadConfig(br)
+	}
+
+	cfg, format, err := image.DecodeConfig(br)
+	if err != nil {
+		return Meta{}, fmt.Errorf("%w: %w", ErrCorrupt, err)
+	}
+
+	return Meta{Width: cfg.Width, Height: cfg.Height, Format: format}, nil
+}
+
+// Validator decodes the image written to it, so the content streamed elsewhere is validated on the way.
+type Validator struct {
+	pw   *io.PipeWriter
+	done chan struct{}
+	meta Meta
+	err  error
+}
+
+func NewValidator() *Validator {
+	pr, pw := io.Pipe()
+	v := &Validator{
+		pw:   pw,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(v.done)
+		v.meta, v.err = Decode(pr)
+		// The rest is drained, so the writer never blocks on a failed or finished decoding.
+		_, _ = io.Copy(io.Discard, pr)
+	}()
+
+	return v
+}
+
+func (v *Validator) Write(p []byte) (int, error) {
+	return v.pw.Write(p)
+}
+
+// Finish ends the content and waits for the decoding result. writeErr is the error of the writing side,
+// which fails the decoding of the incomplete content.
+func (v *Validator) Finish(writeErr error) (Meta, error) {
+	v.pw.CloseWithError(writeErr)
+	<-v.done
+	return v.meta, v.err
+}
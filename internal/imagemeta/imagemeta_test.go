@@ -0,0 +1,117 @@
+package imagemeta
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"shopapi/internal/supports"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Decode ok", func(t *testing.T) {
+		t.Parallel()
+
+		meta, err := Decode(bytes.NewReader(supports.TestImage))
+		require.Nil(t, err)
+		require.Equal(t, FormatJPEG, meta.Format)
+		require.Positive(t, meta.Width)
+		require.Positive(t, meta.Height)
+	})
+
+	t.Run("Decode error on truncated image", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Decode(bytes.NewReader(supports.TestImage[:len(supports.TestImage)/2]))
+		require.ErrorIs(t, err, ErrCorrupt)
+	})
+
+	t.Run("Decode error on sniffed only image", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Decode(bytes.NewReader([]byte("\x89PNG\r\n\x1a\nnot a png")))
+		require.ErrorIs(t, err, ErrCorrupt)
+
+		_, err = Decode(bytes.NewReader([]byte("RIFF\x04\x00\x00\x00WEBP")))
+		require.ErrorIs(t, err, ErrCorrupt)
+	})
+}
+
+func TestDecodeConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DecodeConfig ok", func(t *testing.T) {
+		t.Parallel()
+
+		full, err := Decode(bytes.NewReader(supports.TestImage))
+		require.Nil(t, err)
+
+		meta, err := DecodeConfig(bytes.NewReader(supports.TestImage[:len(supports.TestImage)/2]))
+		require.Nil(t, err)
+		require.Equal(t, full, meta)
+	})
+
+	t.Run("DecodeConfig webp ok", func(t *testing.T) {
+		t.Parallel()
+
+		meta, err := DecodeConfig(bytes.NewReader(newWebP()[:30]))
+		require.Nil(t, err)
+		require.Equal(t, Meta{Width: 40, Height: 20, Format: FormatWebP}, meta)
+	})
+
+	t.Run("DecodeConfig error on truncated head", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := DecodeConfig(bytes.NewReader(supports.TestImage[:20]))
+		require.ErrorIs(t, err, ErrCorrupt)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+		_, err = DecodeConfig(bytes.NewReader(newWebP()[:20]))
+		require.ErrorIs(t, err, ErrCorrupt)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+func TestValidator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Validator ok", func(t *testing.T) {
+		t.Parallel()
+
+		v := NewValidator()
+		_, err := io.Copy(v, bytes.NewReader(supports.TestImage))
+		require.Nil(t, err)
+
+		meta, err := v.Finish(nil)
+		require.Nil(t, err)
+		require.Equal(t, FormatJPEG, meta.Format)
+	})
+
+	t.Run("Validator error on corrupt image", func(t *testing.T) {
+		t.Parallel()
+
+		v := NewValidator()
+		_, err := io.Copy(v, bytes.NewReader([]byte("\xff\xd8\xff\xe0 not a jpeg at all")))
+		require.Nil(t, err)
+
+		_, err = v.Finish(nil)
+		require.ErrorIs(t, err, ErrCorrupt)
+	})
+
+	t.Run("Validator error on write error", func(t *testing.T) {
+		t.Parallel()
+
+		errWrite := errors.New("write")
+		v := NewValidator()
+		_, err := v.Write(supports.TestImage[:100])
+		require.Nil(t, err)
+
+		_, err = v.Finish(errWrite)
+		require.ErrorIs(t, err, errWrite)
+	})
+}
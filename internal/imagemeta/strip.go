@@ -0,0 +1,292 @@
+package imagemeta
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	mimeManager "shopapi/internal/mime-manager"
+)
+
+const (
+	jpegMarker      = 0xff
+	jpegSOI         = 0xd8
+	jpegEOI         = 0xd9
+	jpegSOS         = 0xda
+	jpegTEM         = 0x01
+	jpegRST0        = 0xd0
+	jpegRST7        = 0xd7
+	jpegAPP0        = 0xe0
+	jpegAPP1        = 0xe1
+	jpegAPP2        = 0xe2
+	jpegAPP14       = 0xee
+	jpegAPP15       = 0xef
+	jpegCOM         = 0xfe
+	exifOrientation = 0x0112
+
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+var (
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+	exifHeader   = []byte("Exif\x00\x00")
+
+	// Text, time and EXIF chunks of png, the rest is needed to render the image.
+	pngMetadataChunks = map[string]bool{
+		"eXIf": true,
+		"tEXt": true,
+		"zTXt": true,
+		"iTXt": true,
+		"tIME": true,
+	}
+)
+
+// Strip returns the image without metadata: EXIF, XMP, IPTC and comments of jpeg, text and EXIF chunks of png,
+// EXIF and XMP chunks of webp. The jpeg orientation is kept, otherwise photos would be shown rotated.
+// SVG is sanitized, so it can't run scripts when it is opened. Other content is returned as it is.
+// The returned reader fails with ErrCorrupt on a malformed image and has to be closed.
+func Strip(r io.Reader) io.ReadCloser {
+	br := bufio.NewReaderSize(r, mimeManager.SniffLen)
+	magic, _ := br.Peek(mimeManager.SniffLen)
+
+	var strip func(*bufio.Reader, io.Writer) error
+	switch FormatOf(magic) {
+	case FormatJPEG:
+		strip = stripJPEG
+	case FormatPNG:
+		strip = stripPNG
+	case FormatWebP:
+		strip = stripWebP
+	case FormatSVG:
+		strip = sanitizeSVG
+	default:
+		return io.NopCloser(br)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(strip(br, pw))
+	}()
+
+	return pr
+}
+
+func corrupt(format string, err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("%w: %s: %w", ErrCorrupt, format, err)
+}
+
+// stripJPEG copies the segments up to the first scan, dropping the application segments other than
+// JFIF, ICC profile and Adobe ones, which are needed to render the image. The rest is copied as it is.
+func stripJPEG(r *bufio.Reader, w io.Writer) error {
+	if _, err := io.CopyN(w, r, 2); err != nil {
+		return err
+	}
+
+	for {
+		var marker [2]byte
+		if _, err := io.ReadFull(r, marker[:1]); err != nil {
+			return corrupt(FormatJPEG, err)
+		}
+		if marker[0] != jpegMarker {
+			return corrupt(FormatJPEG, fmt.Errorf("expected marker, got 0x%x", marker[0]))
+		}
+		// Markers may be padded with fill bytes.
+		for marker[1] = jpegMarker; marker[1] == jpegMarker; {
+			b, err := r.ReadByte()
+			if err != nil {
+				return corrupt(FormatJPEG, err)
+			}
+			marker[1] = b
+		}
+
+		m := marker[1]
+		if m == jpegSOS || m == jpegEOI {
+			if _, err := w.Write(marker[:]); err != nil {
+				return err
+			}
+			_, err := io.Copy(w, r)
+			return err
+		}
+		if m == jpegTEM || m >= jpegRST0 && m <= jpegRST7 {
+			if _, err := w.Write(marker[:]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var size [2]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return corrupt(FormatJPEG, err)
+		}
+		length := int64(binary.BigEndian.Uint16(size[:])) - 2
+		if length < 0 {
+			return corrupt(FormatJPEG, fmt.Errorf("segment 0x%x of negative length", m))
+		}
+
+		if !isJPEGMetadata(m) {
+			if _, err := w.Write(append(marker[:], size[:]...)); err != nil {
+				return err
+			}
+			if _, err := io.CopyN(w, r, length); err != nil {
+				return corrupt(FormatJPEG, err)
+			}
+			continue
+		}
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return corrupt(FormatJPEG, err)
+		}
+		if m != jpegAPP1 {
+			continue
+		}
+		if orientation := exifOrientationOf(payload); orientation > 1 {
+			if _, err := w.Write(orientationSegment(orientation)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func isJPEGMetadata(marker byte) bool {
+	switch marker {
+	case jpegAPP0, jpegAPP2, jpegAPP14:
+		return false
+	case jpegCOM:
+		return true
+	}
+	return marker >= jpegAPP0 && marker <= jpegAPP15
+}
+
+// exifOrientationOf reads the orientation tag of the first IFD, zero means there is no such tag.
+func exifOrientationOf(payload []byte) uint16 {
+	tiff, ok := bytes.CutPrefix(payload, exifHeader)
+	if !ok || len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == exifOrientation {
+			return order.Uint16(tiff[entry+8:])
+		}
+	}
+
+	return 0
+}
+
+// orientationSegment is the EXIF segment with the orientation tag only.
+func orientationSegment(orientation uint16) []byte {
+	tiff := []byte{
+		'M', 'M', 0, 42, 0, 0, 0, 8, // header, the first IFD follows it
+		0, 1, // one entry
+		0x01, 0x12, 0, 3, 0, 0, 0, 1, byte(orientation >> 8), byte(orientation), 0, 0, // SHORT orientation
+		0, 0, 0, 0, // no next IFD
+	}
+
+	segment := []byte{jpegMarker, jpegAPP1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(2+len(exifHeader)+len(tiff)))
+	segment = append(segment, exifHeader...)
+
+	return append(segment, tiff...)
+}
+
+// stripPNG copies the chunks except the metadata ones, the content after the end chunk is copied as it is.
+func stripPNG(r *bufio.Reader, w io.Writer) error {
+	if _, err := io.CopyN(w, r, int64(len(pngSignature))); err != nil {
+		return err
+	}
+
+	for {
+		var head [8]byte
+		if _, err := io.ReadFull(r, head[:]); err != nil {
+			return corrupt(FormatPNG, err)
+		}
+
+		length := int64(binary.BigEndian.Uint32(head[:4]))
+		kind := string(head[4:])
+
+		// The data is followed by the checksum.
+		if pngMetadataChunks[kind] {
+			if _, err := io.CopyN(io.Discard, r, length+4); err != nil {
+				return corrupt(FormatPNG, err)
+			}
+			continue
+		}
+
+		if _, err := w.Write(head[:]); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(w, r, length+4); err != nil {
+			return corrupt(FormatPNG, err)
+		}
+
+		if kind == "IEND" {
+			_, err := io.Copy(w, r)
+			return err
+		}
+	}
+}
+
+// stripWebP rewrites the image without EXIF and XMP chunks. It is read whole, as the RIFF header holds its size.
+func stripWebP(r *bufio.Reader, w io.Writer) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	chunks, err := webpChunks(data)
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	out.Write(data[:12])
+	for _, c := range chunks {
+		switch c.kind {
+		case "EXIF", "XMP ":
+			continue
+		case "VP8X":
+			if len(c.data) > 0 {
+				c.data[0] &^= webpFlagEXIF | webpFlagXMP
+			}
+		}
+		out.WriteString(c.kind)
+		_ = binary.Write(&out, binary.LittleEndian, uint32(len(c.data)))
+		out.Write(c.data)
+		if len(c.data)%2 == 1 {
+			out.WriteByte(0)
+		}
+	}
+
+	result := out.Bytes()
+	binary.LittleEndian.PutUint32(result[4:8], uint32(len(result)-8))
+
+	_, err = w.Write(result)
+	return err
+}
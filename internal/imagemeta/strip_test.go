@@ -0,0 +1,167 @@
+package imagemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("GPS 55.7558N 37.6173E")
+
+func newTestImage() image.Image {
+	return image.NewRGBA(image.Rect(0, 0, 40, 20))
+}
+
+func encodeJPEG(t *testing.T) []byte {
+	var b bytes.Buffer
+	require.Nil(t, jpeg.Encode(&b, newTestImage(), nil))
+	return b.Bytes()
+}
+
+func encodePNG(t *testing.T) []byte {
+	var b bytes.Buffer
+	require.Nil(t, png.Encode(&b, newTestImage()))
+	return b.Bytes()
+}
+
+// exifSegment is an APP1 segment with the orientation tag followed by the secret.
+func exifSegment(orientation uint16) []byte {
+	tiff := []byte{'I', 'I', 42, 0, 8, 0, 0, 0, 1, 0}
+	tiff = append(tiff, 0x12, 0x01, 3, 0, 1, 0, 0, 0, byte(orientation), byte(orientation>>8), 0, 0)
+	tiff = append(tiff, 0, 0, 0, 0)
+	tiff = append(tiff, secret...)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// withSegment inserts the segment right after the start of the jpeg.
+func withSegment(data, segment []byte) []byte {
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func pngChunk(kind string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, kind...)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+// newWebP builds a lossless webp with an extended header and the EXIF chunk.
+func newWebP() []byte {
+	var body []byte
+	vp8x := []byte{webpFlagEXIF, 0, 0, 0, 39, 0, 0, 19, 0, 0}
+	body = append(body, "VP8X"...)
+	body = binary.LittleEndian.AppendUint32(body, uint32(len(vp8x)))
+	body = append(body, vp8x...)
+
+	vp8l := []byte{0x2f}
+	vp8l = binary.LittleEndian.AppendUint32(vp8l, 39|19<<14)
+	body = append(body, "VP8L"...)
+	body = binary.LittleEndian.AppendUint32(body, uint32(len(vp8l)))
+	body = append(body, vp8l...)
+	body = append(body, 0)
+
+	body = append(body, "EXIF"...)
+	body = binary.LittleEndian.AppendUint32(body, uint32(len(secret)))
+	body = append(body, secret...)
+	if len(secret)%2 == 1 {
+		body = append(body, 0)
+	}
+
+	data := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)+4))...)
+	data = append(data, "WEBP"...)
+	return append(data, body...)
+}
+
+func strip(t *testing.T, data []byte) ([]byte, error) {
+	r := Strip(bytes.NewReader(data))
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func TestStrip(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Strip jpeg keeps orientation", func(t *testing.T) {
+		t.Parallel()
+
+		data := withSegment(encodeJPEG(t), exifSegment(6))
+		data = withSegment(data, []byte("\xff\xfe\x00\x09comment"))
+
+		out, err := strip(t, data)
+		require.Nil(t, err)
+		require.NotContains(t, string(out), string(secret))
+		require.NotContains(t, string(out), "comment")
+
+		i := bytes.Index(out, []byte("Exif\x00\x00"))
+		require.Positive(t, i)
+		require.Equal(t, uint16(6), exifOrientationOf(out[i:]))
+
+		meta, err := Decode(bytes.NewReader(out))
+		require.Nil(t, err)
+		require.Equal(t, Meta{Width: 40, Height: 20, Format: FormatJPEG}, meta)
+	})
+
+	t.Run("Strip jpeg drops default orientation", func(t *testing.T) {
+		t.Parallel()
+
+		src := encodeJPEG(t)
+		out, err := strip(t, withSegment(src, exifSegment(1)))
+		require.Nil(t, err)
+		require.Equal(t, src, out)
+	})
+
+	t.Run("Strip png", func(t *testing.T) {
+		t.Parallel()
+
+		src := encodePNG(t)
+		data := append(append([]byte{}, src[:33]...), pngChunk("tEXt", secret)...)
+		data = append(data, src[33:]...)
+
+		out, err := strip(t, data)
+		require.Nil(t, err)
+		require.Equal(t, src, out)
+	})
+
+	t.Run("Strip webp", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := strip(t, newWebP())
+		require.Nil(t, err)
+		require.NotContains(t, string(out), string(secret))
+		require.Equal(t, byte(0), out[20]&webpFlagEXIF)
+		require.Equal(t, uint32(len(out)-8), binary.LittleEndian.Uint32(out[4:8]))
+
+		meta, err := Decode(bytes.NewReader(out))
+		require.Nil(t, err)
+		require.Equal(t, Meta{Width: 40, Height: 20, Format: FormatWebP}, meta)
+	})
+
+	t.Run("Strip other content as it is", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := strip(t, []byte("plain text"))
+		require.Nil(t, err)
+		require.Equal(t, []byte("plain text"), out)
+	})
+
+	t.Run("Strip error on truncated jpeg", func(t *testing.T) {
+		t.Parallel()
+
+		data := withSegment(encodeJPEG(t), exifSegment(6))
+		_, err := strip(t, data[:20])
+		require.ErrorIs(t, err, ErrCorrupt)
+	})
+}
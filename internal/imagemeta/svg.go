@@ -0,0 +1,218 @@
+package imagemeta
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// The elements dropped with their content: scripts, embedded documents and metadata.
+	svgDroppedElements = map[string]bool{
+		"script":        true,
+		"handler":       true,
+		"listener":      true,
+		"foreignobject": true,
+		"iframe":        true,
+		"object":        true,
+		"embed":         true,
+		"metadata":      true,
+	}
+
+	// An attribute with such a value is dropped, it may be a link, an animated value or a style url.
+	svgScriptSchemes = []string{"javascript:", "vbscript:"}
+
+	// The data urls allowed in links, the rest may hold documents with scripts.
+	svgDataImages = []string{"data:image/png", "data:image/jpeg", "data:image/gif", "data:image/webp"}
+
+	// Unlike xml.EscapeText the whitespace is kept as it is.
+	svgTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	svgAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// decodeSVG parses the whole image and reads its size from the root element.
+func decodeSVG(r io.Reader) (Meta, error) {
+	d := xml.NewDecoder(r)
+
+	meta, err := svgRootConfig(d)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	for {
+		if _, err = d.Token(); err == io.EOF {
+			return meta, nil
+		}
+		if err != nil {
+			return Meta{}, corrupt(FormatSVG, err)
+		}
+	}
+}
+
+// svgHeadConfig reads the size of the image from the root element, the rest is not parsed.
+func svgHeadConfig(r io.Reader) (Meta, error) {
+	return svgRootConfig(xml.NewDecoder(r))
+}
+
+// svgRootConfig reads the tokens up to the root element, which has to be svg, its size is set by
+// the width and height in pixels or by the view box.
+func svgRootConfig(d *xml.Decoder) (Meta, error) {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return Meta{}, corrupt(FormatSVG, err)
+		}
+
+		root, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root.Name.Local != "svg" {
+			return Meta{}, corrupt(FormatSVG, fmt.Errorf("root element is '%s'", root.Name.Local))
+		}
+
+		meta := Meta{Format: FormatSVG}
+		var viewBox string
+		for _, attr := range root.Attr {
+			switch attr.Name.Local {
+			case "width":
+				meta.Width = svgLength(attr.Value)
+			case "height":
+				meta.Height = svgLength(attr.Value)
+			case "viewBox":
+				viewBox = attr.Value
+			}
+		}
+
+		if meta.Width == 0 || meta.Height == 0 {
+			box := strings.FieldsFunc(viewBox, func(r rune) bool { return r == ' ' || r == ',' })
+			if len(box) == 4 {
+				meta.Width, meta.Height = svgLength(box[2]), svgLength(box[3])
+			}
+		}
+
+		return meta, nil
+	}
+}
+
+// svgLength parses the length in pixels, zero is returned for relative units and malformed values.
+func svgLength(value string) int {
+	n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "px"), 64)
+	if err != nil || n <= 0 || n > math.MaxInt32 {
+		return 0
+	}
+	return int(math.Round(n))
+}
+
+// sanitizeSVG rewrites the image without scripts, event handlers, script links, embedded documents,
+// comments, the doctype and processing instructions other than the XML declaration.
+func sanitizeSVG(r *bufio.Reader, w io.Writer) error {
+	d := xml.NewDecoder(r)
+	bw := bufio.NewWriter(w)
+
+	// The raw tokens keep the namespace prefixes, so the nesting is checked here.
+	var open []xml.Name
+	skipped := 0
+	for {
+		token, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return corrupt(FormatSVG, err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			open = append(open, t.Name)
+			if skipped > 0 || svgDroppedElements[strings.ToLower(t.Name.Local)] {
+				skipped++
+				continue
+			}
+			writeSVGStart(bw, t)
+		case xml.EndElement:
+			if len(open) == 0 || open[len(open)-1] != t.Name {
+				return corrupt(FormatSVG, fmt.Errorf("unexpected end element '%s'", t.Name.Local))
+			}
+			open = open[:len(open)-1]
+			if skipped > 0 {
+				skipped--
+				continue
+			}
+			bw.WriteString("</" + svgName(t.Name) + ">")
+		case xml.CharData:
+			if skipped == 0 {
+				_, _ = svgTextEscaper.WriteString(bw, string(t))
+			}
+		case xml.ProcInst:
+			if t.Target == "xml" && skipped == 0 {
+				bw.WriteString("<?xml " + string(t.Inst) + "?>")
+			}
+		}
+	}
+
+	if len(open) != 0 {
+		return corrupt(FormatSVG, errors.New("unclosed elements"))
+	}
+
+	return bw.Flush()
+}
+
+func writeSVGStart(w *bufio.Writer, t xml.StartElement) {
+	w.WriteString("<" + svgName(t.Name))
+	for _, attr := range t.Attr {
+		if !isSafeSVGAttr(attr) {
+			continue
+		}
+		w.WriteString(" " + svgName(attr.Name) + `="`)
+		_, _ = svgAttrEscaper.WriteString(w, attr.Value)
+		w.WriteString(`"`)
+	}
+	w.WriteString(">")
+}
+
+func svgName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// isSafeSVGAttr drops the event handlers and the values with script urls, the links may refer
+// to the raster data urls only.
+func isSafeSVGAttr(attr xml.Attr) bool {
+	local := strings.ToLower(attr.Name.Local)
+	if strings.HasPrefix(local, "on") {
+		return false
+	}
+
+	// The browsers skip the whitespace and control characters in urls.
+	value := strings.ToLower(strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, attr.Value))
+
+	for _, scheme := range svgScriptSchemes {
+		if strings.Contains(value, scheme) {
+			return false
+		}
+	}
+
+	if local == "href" && strings.HasPrefix(value, "data:") {
+		for _, prefix := range svgDataImages {
+			if strings.HasPrefix(value, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,102 @@
+package imagemeta
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const unsafeSVG = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN" "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd">
+<!-- exported -->
+<?xml-stylesheet href="https://example.com/style.css"?>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="40px" height="20" onload="alert(1)">
+<metadata><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">author</rdf:RDF></metadata>
+<script type="text/javascript">alert("x &amp; y")</script>
+<a xlink:href=" java&#x09;script:alert(1)"><rect width="10" height="10" fill="red" onClick="alert(1)"/></a>
+<a href="https://example.com"><text x="0" y="15">5 &lt; 6</text></a>
+<image href="data:image/png;base64,AAAA"/>
+<image href="data:image/svg+xml;base64,AAAA"/>
+<set attributeName="href" to="javascript:alert(1)"/>
+<foreignObject><html><body>page</body></html></foreignObject>
+</svg>`
+
+func sanitize(t *testing.T, svg string) ([]byte, error) {
+	out := Strip(bytes.NewReader([]byte(svg)))
+	defer out.Close()
+
+	data, err := io.ReadAll(out)
+	t.Log(string(data))
+	return data, err
+}
+
+func TestSanitizeSVG(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SanitizeSVG ok", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := sanitize(t, unsafeSVG)
+		require.Nil(t, err)
+
+		for _, unsafe := range []string{"script", "alert", "onload", "onClick", "metadata", "author",
+			"DOCTYPE", "exported", "stylesheet", "svg+xml", "foreignObject", "page"} {
+			require.NotContains(t, string(out), unsafe)
+		}
+
+		for _, kept := range []string{`<?xml version="1.0" encoding="UTF-8"?>`, `xmlns:xlink="http://www.w3.org/1999/xlink"`,
+			`<rect width="10" height="10" fill="red">`, `<a href="https://example.com">`, `5 &lt; 6`,
+			`<image href="data:image/png;base64,AAAA">`} {
+			require.Contains(t, string(out), kept)
+		}
+
+		meta, err := Decode(bytes.NewReader(out))
+		require.Nil(t, err)
+		require.Equal(t, Meta{Width: 40, Height: 20, Format: FormatSVG}, meta)
+	})
+
+	t.Run("SanitizeSVG error on malformed svg", func(t *testing.T) {
+		t.Parallel()
+
+		for _, svg := range []string{`<svg><g></svg>`, `<svg><g>`, `<svg><g></g>&unknown;</svg>`} {
+			_, err := sanitize(t, svg)
+			require.ErrorIs(t, err, ErrCorrupt, svg)
+		}
+	})
+}
+
+func TestDecodeSVG(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DecodeSVG ok", func(t *testing.T) {
+		t.Parallel()
+
+		cases := []struct {
+			svg  string
+			meta Meta
+		}{
+			{svg: `<svg width="10.4" height="20px"></svg>`, meta: Meta{Width: 10, Height: 20, Format: FormatSVG}},
+			{svg: `<svg width="100%" viewBox="0,0,300,150"></svg>`, meta: Meta{Width: 300, Height: 150, Format: FormatSVG}},
+			{svg: `<svg width="10em"></svg>`, meta: Meta{Format: FormatSVG}},
+		}
+
+		for _, c := range cases {
+			meta, err := Decode(bytes.NewReader([]byte(c.svg)))
+			require.Nil(t, err, c.svg)
+			require.Equal(t, c.meta, meta, c.svg)
+
+			meta, err = DecodeConfig(bytes.NewReader([]byte(c.svg)))
+			require.Nil(t, err, c.svg)
+			require.Equal(t, c.meta, meta, c.svg)
+		}
+	})
+
+	t.Run("DecodeSVG error on malformed svg", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Decode(bytes.NewReader([]byte(`<svg width="10"><g></svg>`)))
+		require.ErrorIs(t, err, ErrCorrupt)
+	})
+}
@@ -0,0 +1,103 @@
+package imagemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var vp8StartCode = []byte{0x9d, 0x01, 0x2a}
+
+type webpChunk struct {
+	kind string
+	data []byte
+}
+
+func isWebP(magic []byte) bool {
+	return len(magic) >= 12 && string(magic[:4]) == "RIFF" && string(magic[8:12]) == "WEBP"
+}
+
+// webpChunks splits the RIFF container into chunks, checking their sizes fit it.
+func webpChunks(data []byte) ([]webpChunk, error) {
+	if !isWebP(data) {
+		return nil, corrupt(FormatWebP, errors.New("no RIFF header"))
+	}
+
+	size := int(binary.LittleEndian.Uint32(data[4:8]))
+	if size+8 > len(data) || size < 4 {
+		return nil, corrupt(FormatWebP, io.ErrUnexpectedEOF)
+	}
+
+	var chunks []webpChunk
+	for rest := data[12 : size+8]; len(rest) > 0; {
+		if len(rest) < 8 {
+			return nil, corrupt(FormatWebP, io.ErrUnexpectedEOF)
+		}
+		n := int(binary.LittleEndian.Uint32(rest[4:8]))
+		if n > len(rest)-8 {
+			return nil, corrupt(FormatWebP, io.ErrUnexpectedEOF)
+		}
+
+		chunks = append(chunks, webpChunk{kind: string(rest[:4]), data: rest[8 : 8+n]})
+		rest = rest[min(len(rest), 8+n+n%2):]
+	}
+
+	if len(chunks) == 0 {
+		return nil, corrupt(FormatWebP, errors.New("no chunks"))
+	}
+
+	return chunks, nil
+}
+
+// decodeWebPConfig reads the size of the image from the header of its first chunk.
+func decodeWebPConfig(r io.Reader) (Meta, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	chunks, err := webpChunks(data)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	return webpConfig(chunks[0])
+}
+
+// webpConfig reads the size of the image from the header of the first chunk, which holds it.
+func webpConfig(c webpChunk) (Meta, error) {
+	meta := Meta{Format: FormatWebP}
+	switch {
+	case c.kind == "VP8 " && len(c.data) >= 10 && bytes.Equal(c.data[3:6], vp8StartCode):
+		meta.Width = int(binary.LittleEndian.Uint16(c.data[6:8]) & 0x3fff)
+		meta.Height = int(binary.LittleEndian.Uint16(c.data[8:10]) & 0x3fff)
+	case c.kind == "VP8L" && len(c.data) >= 5 && c.data[0] == 0x2f:
+		bits := binary.LittleEndian.Uint32(c.data[1:5])
+		meta.Width = int(bits&0x3fff) + 1
+		meta.Height = int(bits>>14&0x3fff) + 1
+	case c.kind == "VP8X" && len(c.data) >= 10:
+		meta.Width = int(uint32(c.data[4])|uint32(c.data[5])<<8|uint32(c.data[6])<<16) + 1
+		meta.Height = int(uint32(c.data[7])|uint32(c.data[8])<<8|uint32(c.data[9])<<16) + 1
+	default:
+		return Meta{}, corrupt(FormatWebP, fmt.Errorf("unexpected first chunk '%s'", c.kind))
+	}
+
+	if meta.Width == 0 || meta.Height == 0 {
+		return Meta{}, corrupt(FormatWebP, errors.New("zero size"))
+	}
+
+	return meta, nil
+}
+
+// webpHeadConfig reads the size of the image from its head only, the chunk sizes are not checked
+// against the whole content.
+func webpHeadConfig(r io.Reader) (Meta, error) {
+	var head [30]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return Meta{}, corrupt(FormatWebP, err)
+	}
+
+	return webpConfig(webpChunk{kind: string(head[12:16]), data: head[20:]})
+}
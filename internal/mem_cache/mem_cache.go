@@ -1,18 +1,28 @@
 package mem_cache
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Cache struct {
 	storage map[string][]byte
+	expires map[string]time.Time
 }
 
 func NewCache() *Cache {
 	return &Cache{
 		storage: map[string][]byte{},
+		expires: map[string]time.Time{},
 	}
 }
 
 func (c *Cache) Read(key string, v any) (bool, error) {
+	if until, ok := c.expires[key]; ok && !time.Now().Before(until) {
+		delete(c.storage, key)
+		delete(c.expires, key)
+	}
+
 	data, ok := c.storage[key]
 	if !ok {
 		return false, nil
@@ -32,6 +42,21 @@ func (c *Cache) Write(key string, v any) error {
 		return err
 	}
 	c.storage[key] = data
+	delete(c.expires, key)
+
+	return nil
+}
+
+// WriteUntil writes the value read until the moment, the value already stale isn't written.
+func (c *Cache) WriteUntil(key string, v any, until time.Time) error {
+	if !time.Now().Before(until) {
+		return nil
+	}
+
+	if err := c.Write(key, v); err != nil {
+		return err
+	}
+	c.expires[key] = until
 
 	return nil
 }
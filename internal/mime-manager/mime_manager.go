@@ -1,13 +1,42 @@
 package mime_manager
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"mime"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
 )
 
+const (
+	// SniffLen is the most bytes DetectMimeType considers.
+	SniffLen     = 512
+	svgMimeType  = "image/svg+xml"
+	avifMimeType = "image/avif"
+	heicMimeType = "image/heic"
+	heifMimeType = "image/heif"
+)
+
+// The types are missing in the builtin table of the mime package and may be missing in the system one.
+var extraExtensionTypes = map[string]string{
+	".svg":  svgMimeType,
+	".avif": avifMimeType,
+	".heic": heicMimeType,
+	".heif": heifMimeType,
+}
+
+func init() {
+	for ext, mimeType := range extraExtensionTypes {
+		if err := mime.AddExtensionType(ext, mimeType); err != nil {
+			panic(err)
+		}
+	}
+}
+
 var mutex sync.RWMutex
 
 type ExtensionAllowed map[string]bool
@@ -18,7 +47,7 @@ type MimeAllowed map[string]bool
 
 var allowedMimeForFileType = map[string]MimeAllowed{}
 
-// The extensions have to be with a leading dot, as in ".html". Panics if no mime type assocoated with extension.
+// The extensions have to be with a leading dot, as in ".html". Panics if no mime type associated with extension.
 func AddAllowedExtensions(fileType string, extensions []string) {
 	mutex.Lock()
 	defer mutex.Unlock()
@@ -34,7 +63,7 @@ func AddAllowedExtensions(fileType string, extensions []string) {
 		ext := strings.ToLower(s)
 		mime := mime.TypeByExtension(ext)
 		if mime == "" {
-			panic(fmt.Errorf("no mime type assocoated with %s", ext))
+			panic(fmt.Errorf("no mime type associated with %s", ext))
 		}
 		allowedExtensionsForFileType[fileType][ext] = true
 		allowedMimeForFileType[fileType][mime] = true
@@ -42,8 +71,76 @@ func AddAllowedExtensions(fileType string, extensions []string) {
 
 }
 
+// SetAllowedExtensions replaces the allowed extensions of the file type, so the whitelist can be changed
+// at runtime. Unlike AddAllowedExtensions it fails on an extension without mime type and keeps the old ones.
+func SetAllowedExtensions(fileType string, extensions []string) error {
+	allowedExtensions := ExtensionAllowed{}
+	allowedMime := MimeAllowed{}
+
+	for _, s := range extensions {
+		ext := strings.ToLower(s)
+		mimeType := mime.TypeByExtension(ext)
+		if mimeType == "" {
+			return fmt.Errorf("no mime type associated with %s", ext)
+		}
+		allowedExtensions[ext] = true
+		allowedMime[mimeType] = true
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	allowedExtensionsForFileType[fileType] = allowedExtensions
+	allowedMimeForFileType[fileType] = allowedMime
+
+	return nil
+}
+
+// AllowedExtensions returns the sorted allowed extensions of the file type.
+func AllowedExtensions(fileType string) []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	var extensions []string
+	for ext := range allowedExtensionsForFileType[fileType] {
+		extensions = append(extensions, ext)
+	}
+	slices.Sort(extensions)
+
+	return extensions
+}
+
+// ParseAllowedExtensions reads the allowed extensions per file type, a line per type as "image: .jpg .png".
+// Empty lines and lines starting with # are skipped.
+func ParseAllowedExtensions(r io.Reader) (map[string][]string, error) {
+	allowed := map[string][]string{}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fileType, extensions, ok := strings.Cut(text, ":")
+		fileType = strings.TrimSpace(fileType)
+		if !ok || fileType == "" {
+			return nil, fmt.Errorf("line %d: expected 'file type: .ext ...', got '%s'", line, text)
+		}
+
+		for _, ext := range strings.Fields(extensions) {
+			if !strings.HasPrefix(ext, ".") {
+				return nil, fmt.Errorf("line %d: extension '%s' has to start with a dot", line, ext)
+			}
+			allowed[fileType] = append(allowed[fileType], ext)
+		}
+	}
+
+	return allowed, scanner.Err()
+}
+
 func GetFileExtension(data []byte, fileType string) (string, error) {
-	mimeType := detectMimeType(data)
+	mimeType := DetectMimeType(data)
 	err := IsMimeAllowed(mimeType, fileType)
 	if err != nil {
 		return "", err
@@ -72,7 +169,7 @@ func GetFileExtension(data []byte, fileType string) (string, error) {
 }
 
 func IsFileAllowed(data []byte, fileType string) error {
-	mimeType := detectMimeType(data)
+	mimeType := DetectMimeType(data)
 	err := IsMimeAllowed(mimeType, fileType)
 	if err != nil {
 		return err
@@ -97,6 +194,93 @@ func IsMimeAllowed(mimeType, fileType string) error {
 	return nil
 }
 
-func detectMimeType(data []byte) string {
-	return http.DetectContentType(data)
+// DetectMimeType returns the mime type of data sniffed by its content. Besides the types of
+// http.DetectContentType, such as jpeg, png, gif, webp and pdf, it recognizes AVIF, HEIC and SVG.
+func DetectMimeType(data []byte) string {
+	head := data[:min(len(data), SniffLen)]
+
+	if mimeType := detectISOMedia(head); mimeType != "" {
+		return mimeType
+	}
+
+	mimeType := http.DetectContentType(head)
+
+	// SVG is sniffed as plain text, XML or, when it starts with a comment, as HTML.
+	if strings.HasPrefix(mimeType, "text/") && isSVG(head) {
+		return svgMimeType
+	}
+
+	return mimeType
+}
+
+// The brands of the ISO base media file type box of the HEIF images, AVIF ones are HEIF coded by AV1.
+var isoMediaBrands = map[string]string{
+	"avif": avifMimeType,
+	"avis": avifMimeType,
+	"heic": heicMimeType,
+	"heix": heicMimeType,
+	"heim": heicMimeType,
+	"heis": heicMimeType,
+	"hevc": heicMimeType,
+	"hevx": heicMimeType,
+	"mif1": heifMimeType,
+	"msf1": heifMimeType,
+}
+
+// detectISOMedia recognizes the HEIF images by the brands of their ftyp box, the major brand goes first
+// and the more specific AVIF and HEIC compatible brands win over the generic HEIF ones.
+func detectISOMedia(head []byte) string {
+	if len(head) < 16 || string(head[4:8]) != "ftyp" {
+		return ""
+	}
+
+	size := int(head[0])<<24 | int(head[1])<<16 | int(head[2])<<8 | int(head[3])
+	box := head[:min(len(head), max(size, 16))]
+
+	found := isoMediaBrands[string(box[8:12])]
+	if found == avifMimeType || found == heicMimeType {
+		return found
+	}
+
+	// The minor version is followed by the compatible brands.
+	for i := 16; i+4 <= len(box); i += 4 {
+		switch brand := isoMediaBrands[string(box[i:i+4])]; brand {
+		case avifMimeType, heicMimeType:
+			return brand
+		case heifMimeType:
+			found = brand
+		}
+	}
+
+	return found
+}
+
+// isSVG checks the root element is svg, skipping the XML declaration, comments and the doctype before it.
+func isSVG(head []byte) bool {
+	rest := bytes.TrimPrefix(head, []byte("\xef\xbb\xbf"))
+	for {
+		rest = bytes.TrimLeft(rest, " \t\r\n")
+		switch {
+		case bytes.HasPrefix(rest, []byte("<?")):
+			rest = skipPast(rest, "?>")
+		case bytes.HasPrefix(rest, []byte("<!--")):
+			rest = skipPast(rest, "-->")
+		case bytes.HasPrefix(rest, []byte("<!")):
+			rest = skipPast(rest, ">")
+		default:
+			name, ok := bytes.CutPrefix(rest, []byte("<svg"))
+			return ok && (len(name) == 0 || bytes.IndexByte([]byte(" \t\r\n/>"), name[0]) >= 0)
+		}
+		if rest == nil {
+			return false
+		}
+	}
+}
+
+func skipPast(data []byte, end string) []byte {
+	i := bytes.Index(data, []byte(end))
+	if i < 0 {
+		return nil
+	}
+	return data[i+len(end):]
 }
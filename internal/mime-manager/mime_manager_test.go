@@ -2,6 +2,7 @@ package mime_manager
 
 import (
 	"shopapi/internal/supports"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -48,4 +49,107 @@ func TestMimeNabager(t *testing.T) {
 			require.Nil(t, err)
 		})
 	})
+
+	t.Run("GetFileExtension svg", func(t *testing.T) {
+		t.Parallel()
+
+		svg := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"></svg>`)
+
+		AddAllowedExtensions("svg", []string{".svg"})
+		ext, err := GetFileExtension(svg, "svg")
+		require.Nil(t, err)
+		require.Equal(t, ext, ".svg")
+	})
+}
+
+// isoMedia builds the ftyp box with the major and compatible brands.
+func isoMedia(major string, compatible ...string) []byte {
+	box := []byte{0, 0, 0, byte(16 + 4*len(compatible))}
+	box = append(box, "ftyp"+major+"\x00\x00\x00\x00"...)
+	for _, brand := range compatible {
+		box = append(box, brand...)
+	}
+	return append(box, "\x00\x00\x00\x08meta"...)
+}
+
+func TestDetectMimeType(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		data []byte
+		mime string
+	}{
+		{name: "jpeg", data: supports.TestImage, mime: "image/jpeg"},
+		{name: "gif", data: []byte("GIF89a\x01\x00\x01\x00"), mime: "image/gif"},
+		{name: "pdf", data: []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n"), mime: "application/pdf"},
+		{name: "avif", data: isoMedia("avif", "mif1", "miaf"), mime: avifMimeType},
+		{name: "avif compatible", data: isoMedia("mif1", "miaf", "avif"), mime: avifMimeType},
+		{name: "heic", data: isoMedia("heic", "mif1"), mime: heicMimeType},
+		{name: "heif", data: isoMedia("mif1", "miaf"), mime: heifMimeType},
+		{name: "mp4", data: isoMedia("isom", "mp41"), mime: "video/mp4"},
+		{name: "svg", data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), mime: svgMimeType},
+		{name: "svg with prolog", data: []byte("\xef\xbb\xbf<?xml version=\"1.0\"?>\n<!-- drawn -->\n" +
+			`<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN" "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd">` +
+			"\n<svg width=\"10\"></svg>"), mime: svgMimeType},
+		{name: "svg in html", data: []byte(`<!-- page --><html><svg></svg></html>`), mime: "text/html; charset=utf-8"},
+		{name: "svg like", data: []byte(`<svgx></svgx>`), mime: "text/plain; charset=utf-8"},
+	}
+
+	for _, c := range cases {
+		t.Run("DetectMimeType "+c.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, c.mime, DetectMimeType(c.data))
+		})
+	}
+}
+
+func TestSetAllowedExtensions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SetAllowedExtensions ok", func(t *testing.T) {
+		t.Parallel()
+
+		require.Nil(t, SetAllowedExtensions("set", []string{".PDF", ".heic"}))
+		require.Equal(t, []string{".heic", ".pdf"}, AllowedExtensions("set"))
+		require.Nil(t, IsFileAllowed(isoMedia("heic"), "set"))
+
+		require.Nil(t, SetAllowedExtensions("set", []string{".avif"}))
+		require.Equal(t, []string{".avif"}, AllowedExtensions("set"))
+		require.NotNil(t, IsFileAllowed(isoMedia("heic"), "set"))
+	})
+
+	t.Run("SetAllowedExtensions error on unknown extension", func(t *testing.T) {
+		t.Parallel()
+
+		require.Nil(t, SetAllowedExtensions("set unknown", []string{".png"}))
+		require.NotNil(t, SetAllowedExtensions("set unknown", []string{".jpeg", ".unknown"}))
+		require.Equal(t, []string{".png"}, AllowedExtensions("set unknown"))
+	})
+}
+
+func TestParseAllowedExtensions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ParseAllowedExtensions ok", func(t *testing.T) {
+		t.Parallel()
+
+		allowed, err := ParseAllowedExtensions(strings.NewReader("# uploads\nimage: .jpg .png\n\ndocument:.pdf\nimage: .webp\n"))
+		require.Nil(t, err)
+		require.Equal(t, map[string][]string{
+			"image":    {".jpg", ".png", ".webp"},
+			"document": {".pdf"},
+		}, allowed)
+	})
+
+	t.Run("ParseAllowedExtensions error", func(t *testing.T) {
+		t.Parallel()
+
+		for _, config := range []string{"image .jpg", ": .jpg", "image: jpg"} {
+			_, err := ParseAllowedExtensions(strings.NewReader(config))
+			require.NotNil(t, err, config)
+		}
+	})
 }
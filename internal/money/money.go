@@ -0,0 +1,258 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RUB is the currency of the amounts without one, the exchange rates are set in it too.
+const RUB = "RUB"
+
+const defaultExponent = 2
+
+var (
+	ErrInvalidAmount   = errors.New("invalid amount of money")
+	ErrInvalidCurrency = errors.New("invalid currency code")
+	ErrTooPrecise      = errors.New("amount has more fraction digits than the currency has")
+	ErrOverflow        = errors.New("amount of money is out of range")
+)
+
+// exponents are the ISO 4217 minor unit exponents differing from two.
+var exponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// Money is an exact amount in minor units of the currency, kopecks for RUB.
+// The zero value has no currency and means no amount at all.
+type Money struct {
+	minor    int64
+	currency string
+}
+
+// New returns the amount of minor units of the currency.
+func New(minor int64, currency string) Money {
+	return Money{minor: minor, currency: currency}
+}
+
+// Exponent returns the number of fraction digits of the currency.
+func Exponent(currency string) int {
+	if e, ok := exponents[currency]; ok {
+		return e
+	}
+	return defaultExponent
+}
+
+// IsCurrency reports whether code looks like an ISO 4217 code, three uppercase latin letters.
+func IsCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if code[i] < 'A' || code[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse reads an amount followed by the currency code, like "299.95 RUB".
+func Parse(s string) (Money, error) {
+	amount, currency, ok := strings.Cut(strings.TrimSpace(s), " ")
+	if !ok {
+		return Money{}, ErrInvalidCurrency
+	}
+	return ParseIn(amount, strings.TrimSpace(currency))
+}
+
+// ParseIn reads a decimal amount of the currency, like "299.95". The amount is never rounded,
+// more fraction digits than the currency has is an error.
+func ParseIn(amount, currency string) (Money, error) {
+	if !IsCurrency(currency) {
+		return Money{}, ErrInvalidCurrency
+	}
+
+	sign := ""
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = "-", amount[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(amount, ".")
+	if whole == "" || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, ErrInvalidAmount
+	}
+
+	exp := Exponent(currency)
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > exp {
+		return Money{}, ErrTooPrecise
+	}
+	fraction += strings.Repeat("0", exp-len(fraction))
+
+	minor, err := strconv.ParseInt(sign+whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, ErrOverflow
+	}
+
+	return Money{minor: minor, currency: currency}, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Minor returns the amount in minor units of the currency.
+func (m Money) Minor() int64 {
+	return m.minor
+}
+
+func (m Money) Currency() string {
+	return m.currency
+}
+
+func (m Money) IsZero() bool {
+	return m.currency == ""
+}
+
+// Amount returns the decimal amount without the currency, like "299.95".
+func (m Money) Amount() string {
+	exp := Exponent(m.currency)
+	digits := strconv.FormatInt(m.minor, 10)
+
+	sign := ""
+	if m.minor < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func (m Money) String() string {
+	if m.IsZero() {
+		return ""
+	}
+	return m.Amount() + " " + m.currency
+}
+
+// Add returns the sum of the amounts, both are of the currency of m.
+func (m Money) Add(o Money) Money {
+	return Money{minor: m.minor + o.minor, currency: m.currency}
+}
+
+// Sub returns the difference of the amounts, both are of the currency of m.
+func (m Money) Sub(o Money) Money {
+	return Money{minor: m.minor - o.minor, currency: m.currency}
+}
+
+// Mul returns the amount n times.
+func (m Money) Mul(n int64) (Money, error) {
+	minor := new(big.Int).Mul(big.NewInt(m.minor), big.NewInt(n))
+	if !minor.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{minor: minor.Int64(), currency: m.currency}, nil
+}
+
+// Percent returns the percent of the amount rounded half away from zero to the minor units.
+func (m Money) Percent(percent int64) (Money, error) {
+	minor, err := round(new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(m.minor), big.NewInt(percent)), big.NewInt(100)))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{minor: minor, currency: m.currency}, nil
+}
+
+// Convert returns the amount in the currency at the rate, the units of the currency for a unit of the amount currency.
+// The result is rounded half away from zero to the minor units of the currency.
+func (m Money) Convert(currency string, rate *big.Rat) (Money, error) {
+	if currency == m.currency {
+		return m, nil
+	}
+
+	amount := new(big.Rat).SetFrac(big.NewInt(m.minor), pow10(Exponent(m.currency)))
+	amount.Mul(amount, rate)
+	amount.Mul(amount, new(big.Rat).SetInt(pow10(Exponent(currency))))
+
+	minor, err := round(amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{minor: minor, currency: currency}, nil
+}
+
+// round rounds r half away from zero.
+func round(r *big.Rat) (int64, error) {
+	num := new(big.Int).Abs(r.Num())
+	quo, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(r.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		quo.Neg(quo)
+	}
+
+	if !quo.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return quo.Int64(), nil
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
+
+// MarshalJSON writes the money as a string with the currency, the zero value as null.
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON reads a string with the currency, like "299.95 RUB". A bare number, as the prices were sent before
+// the currencies, is an amount of RUB and is read from its decimal text, so it's exact too.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{}
+		return nil
+	}
+
+	var (
+		v   Money
+		err error
+	)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err = json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		v, err = Parse(s)
+	} else {
+		v, err = ParseIn(string(data), RUB)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", data, err)
+	}
+
+	*m = v
+	return nil
+}
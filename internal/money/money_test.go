@@ -0,0 +1,190 @@
+package money
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Parse exact", func(t *testing.T) {
+		t.Parallel()
+
+		m, err := Parse("299.95 RUB")
+		require.Nil(t, err)
+		require.Equal(t, New(29995, RUB), m)
+
+		m, err = Parse("249.95 RUB")
+		require.Nil(t, err)
+		require.Equal(t, int64(24995), m.Minor())
+	})
+
+	t.Run("Parse currency exponents", func(t *testing.T) {
+		t.Parallel()
+
+		m, err := Parse("300 JPY")
+		require.Nil(t, err)
+		require.Equal(t, New(300, "JPY"), m)
+
+		m, err = Parse("1.5 KWD")
+		require.Nil(t, err)
+		require.Equal(t, New(1500, "KWD"), m)
+
+		m, err = Parse("-0.05 USD")
+		require.Nil(t, err)
+		require.Equal(t, New(-5, "USD"), m)
+
+		m, err = Parse("10.500 EUR")
+		require.Nil(t, err)
+		require.Equal(t, New(1050, "EUR"), m)
+	})
+
+	t.Run("Parse errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Parse("299.95")
+		require.ErrorIs(t, err, ErrInvalidCurrency)
+
+		_, err = Parse("299.95 rub")
+		require.ErrorIs(t, err, ErrInvalidCurrency)
+
+		_, err = Parse("299.955 RUB")
+		require.ErrorIs(t, err, ErrTooPrecise)
+
+		_, err = Parse("1.5 JPY")
+		require.ErrorIs(t, err, ErrTooPrecise)
+
+		_, err = Parse(".5 RUB")
+		require.ErrorIs(t, err, ErrInvalidAmount)
+
+		_, err = Parse("3e2 RUB")
+		require.ErrorIs(t, err, ErrInvalidAmount)
+
+		_, err = Parse("99999999999999999999 RUB")
+		require.ErrorIs(t, err, ErrOverflow)
+	})
+}
+
+func TestString(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "299.95 RUB", New(29995, RUB).String())
+	require.Equal(t, "0.05 USD", New(5, "USD").String())
+	require.Equal(t, "-0.05 USD", New(-5, "USD").String())
+	require.Equal(t, "300 JPY", New(300, "JPY").String())
+	require.Equal(t, "1.500 KWD", New(1500, "KWD").String())
+	require.Equal(t, "", Money{}.String())
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Convert rounds half away from zero", func(t *testing.T) {
+		t.Parallel()
+
+		m, err := New(100, "USD").Convert(RUB, big.NewRat(925, 10))
+		require.Nil(t, err)
+		require.Equal(t, New(9250, RUB), m)
+
+		m, err = New(29995, RUB).Convert("USD", big.NewRat(1, 90))
+		require.Nil(t, err)
+		require.Equal(t, New(333, "USD"), m)
+
+		m, err = New(-25, RUB).Convert("JPY", big.NewRat(2, 1))
+		require.Nil(t, err)
+		require.Equal(t, New(-1, "JPY"), m)
+	})
+
+	t.Run("Convert to the same currency", func(t *testing.T) {
+		t.Parallel()
+
+		m, err := New(29995, RUB).Convert(RUB, big.NewRat(2, 1))
+		require.Nil(t, err)
+		require.Equal(t, New(29995, RUB), m)
+	})
+
+	t.Run("Convert overflow", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(1<<62, "JPY").Convert("KWD", big.NewRat(100, 1))
+		require.ErrorIs(t, err, ErrOverflow)
+	})
+}
+
+func TestArithmetic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Add and Sub", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, New(35000, RUB), New(29995, RUB).Add(New(5005, RUB)))
+		require.Equal(t, New(24995, RUB), New(29995, RUB).Sub(New(5000, RUB)))
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		t.Parallel()
+
+		m, err := New(29995, RUB).Mul(3)
+		require.Nil(t, err)
+		require.Equal(t, New(89985, RUB), m)
+
+		_, err = New(1<<62, RUB).Mul(4)
+		require.ErrorIs(t, err, ErrOverflow)
+	})
+
+	t.Run("Percent rounds half away from zero", func(t *testing.T) {
+		t.Parallel()
+
+		m, err := New(29995, RUB).Percent(10)
+		require.Nil(t, err)
+		require.Equal(t, New(3000, RUB), m)
+
+		m, err = New(29994, RUB).Percent(10)
+		require.Nil(t, err)
+		require.Equal(t, New(2999, RUB), m)
+
+		m, err = New(333, "JPY").Percent(15)
+		require.Nil(t, err)
+		require.Equal(t, New(50, "JPY"), m)
+	})
+}
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("JSON round trip", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(struct {
+			Price   Money  `json:"price"`
+			Regular *Money `json:"regular,omitempty"`
+			None    Money  `json:"none"`
+		}{Price: New(29995, RUB)})
+		require.Nil(t, err)
+		require.JSONEq(t, `{"price":"299.95 RUB","none":null}`, string(data))
+
+		var m Money
+		require.Nil(t, json.Unmarshal([]byte(`"12.5 USD"`), &m))
+		require.Equal(t, New(1250, "USD"), m)
+	})
+
+	t.Run("JSON number is exact RUB", func(t *testing.T) {
+		t.Parallel()
+
+		var m Money
+		require.Nil(t, json.Unmarshal([]byte(`299.95`), &m))
+		require.Equal(t, New(29995, RUB), m)
+	})
+
+	t.Run("JSON errors", func(t *testing.T) {
+		t.Parallel()
+
+		var m Money
+		require.ErrorIs(t, json.Unmarshal([]byte(`"12.555 USD"`), &m), ErrTooPrecise)
+		require.ErrorIs(t, json.Unmarshal([]byte(`1e3`), &m), ErrInvalidAmount)
+	})
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepSize is the number of buckets the memory limiter holds before it drops the full ones.
+const sweepSize = 10000
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+// Memory keeps the buckets in the process, it is meant for running locally with a single replica.
+type Memory struct {
+	mutex   sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+func (m *Memory) Allow(key string, l Limit) (Result, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := m.now()
+
+	b, ok := m.buckets[key]
+	if !ok {
+		if len(m.buckets) >= sweepSize {
+			m.sweep(now)
+		}
+		b = &bucket{tokens: float64(l.Burst), updated: now}
+		m.buckets[key] = b
+	}
+
+	b.tokens = l.refill(b.tokens, now.Sub(b.updated))
+	b.updated = now
+	b.limit = l
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	return NewResult(allowed, b.tokens, l), nil
+}
+
+// sweep drops the buckets that have refilled, a new bucket starts full anyway.
+func (m *Memory) sweep(now time.Time) {
+	for key, b := range m.buckets {
+		if b.limit.refill(b.tokens, now.Sub(b.updated)) >= float64(b.limit.Burst) {
+			delete(m.buckets, key)
+		}
+	}
+}
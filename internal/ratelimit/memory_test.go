@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMemory(now *time.Time) *Memory {
+	m := NewMemory()
+	m.now = func() time.Time { return *now }
+	return m
+}
+
+func TestMemoryAllow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Allow burst then refuse", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(1700000000, 0)
+		m := newTestMemory(&now)
+		l := Limit{Rate: 1, Burst: 3}
+
+		for i := 2; i >= 0; i-- {
+			res, err := m.Allow("client", l)
+			require.Nil(t, err)
+			require.True(t, res.Allowed)
+			require.Equal(t, i, res.Remaining)
+			require.Equal(t, 3, res.Limit)
+		}
+
+		res, err := m.Allow("client", l)
+		require.Nil(t, err)
+		require.False(t, res.Allowed)
+		require.Equal(t, 0, res.Remaining)
+		require.Equal(t, time.Second, res.RetryAfter)
+		require.Equal(t, 3*time.Second, res.Reset)
+	})
+
+	t.Run("Allow after refill", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(1700000000, 0)
+		m := newTestMemory(&now)
+		l := PerMinute(6, 1)
+
+		res, _ := m.Allow("client", l)
+		require.True(t, res.Allowed)
+
+		now = now.Add(5 * time.Second)
+		res, _ = m.Allow("client", l)
+		require.False(t, res.Allowed)
+		require.Equal(t, 5*time.Second, res.RetryAfter)
+
+		now = now.Add(5 * time.Second)
+		res, _ = m.Allow("client", l)
+		require.True(t, res.Allowed)
+	})
+
+	t.Run("Allow keys apart", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(1700000000, 0)
+		m := newTestMemory(&now)
+		l := Limit{Rate: 1, Burst: 1}
+
+		res, _ := m.Allow("first", l)
+		require.True(t, res.Allowed)
+		res, _ = m.Allow("second", l)
+		require.True(t, res.Allowed)
+		res, _ = m.Allow("first", l)
+		require.False(t, res.Allowed)
+	})
+
+	t.Run("Allow refill is capped by burst", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(1700000000, 0)
+		m := newTestMemory(&now)
+		l := Limit{Rate: 1, Burst: 2}
+
+		m.Allow("client", l)
+		now = now.Add(time.Hour)
+
+		res, _ := m.Allow("client", l)
+		require.True(t, res.Allowed)
+		require.Equal(t, 1, res.Remaining)
+	})
+}
+
+func TestMemorySweep(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	m := newTestMemory(&now)
+	l := Limit{Rate: 1, Burst: 2}
+
+	m.Allow("idle", l)
+	m.Allow("busy", l)
+	now = now.Add(time.Minute)
+	m.Allow("busy", l)
+	m.Allow("busy", l)
+
+	m.sweep(now)
+	require.NotContains(t, m.buckets, "idle")
+	require.Contains(t, m.buckets, "busy")
+}
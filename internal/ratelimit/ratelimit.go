@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Limit is a token bucket: it holds up to Burst requests and refills by Rate requests a second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute is the limit of n requests a minute with the burst of up to burst requests at once.
+func PerMinute(n, burst int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: burst}
+}
+
+// Result is the outcome of taking a request from the bucket.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is the time until the next request is allowed, zero if it is allowed now.
+	RetryAfter time.Duration
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+}
+
+// NewResult describes the bucket holding tokens after the request is taken or refused.
+func NewResult(allowed bool, tokens float64, l Limit) Result {
+	res := Result{
+		Allowed:   allowed,
+		Limit:     l.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     l.refillTime(float64(l.Burst) - tokens),
+	}
+
+	if !allowed {
+		res.RetryAfter = l.refillTime(1 - tokens)
+	}
+
+	return res
+}
+
+// refill returns the tokens in the bucket after the elapsed time, never more than the burst.
+func (l Limit) refill(tokens float64, elapsed time.Duration) float64 {
+	if elapsed > 0 {
+		tokens += elapsed.Seconds() * l.Rate
+	}
+	return math.Min(tokens, float64(l.Burst))
+}
+
+func (l Limit) refillTime(tokens float64) time.Duration {
+	if tokens <= 0 || l.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens / l.Rate * float64(time.Second)))
+}
@@ -0,0 +1,132 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	clamdScannerName = "clamd"
+	// clamdChunkSize is the most bytes sent in a chunk, clamd buffers a chunk whole.
+	clamdChunkSize = 64 << 10
+
+	clamdInstream  = "zINSTREAM\x00"
+	clamdStreamTag = "stream: "
+	clamdFound     = " FOUND"
+	clamdOK        = "OK"
+)
+
+// ClamdScanner sends the upload to a clamd compatible daemon by the INSTREAM command,
+// the daemon may be stubbed by anything listening on the socket and answering the same way.
+type ClamdScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamdScanner builds the scanner for the daemon address as "unix:///path/to/clamd.sock"
+// or "tcp://host:port". The timeout bounds the whole scan of an upload.
+func NewClamdScanner(address string, timeout time.Duration) (*ClamdScanner, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("clamd address '%s': %w", address, err)
+	}
+
+	s := &ClamdScanner{network: u.Scheme, timeout: timeout}
+	switch u.Scheme {
+	case "unix":
+		s.address = u.Path
+	case "tcp":
+		s.address = u.Host
+	default:
+		return nil, fmt.Errorf("clamd address '%s' has to be unix:// or tcp://", address)
+	}
+
+	return s, nil
+}
+
+func (s *ClamdScanner) Name() string {
+	return clamdScannerName
+}
+
+func (s *ClamdScanner) NewScan(ctx context.Context) (Scan, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if _, err = conn.Write([]byte(clamdInstream)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &clamdScan{conn: conn}, nil
+}
+
+// clamdScan streams the content as chunks prefixed with their length, the zero length one ends it.
+type clamdScan struct {
+	conn net.Conn
+}
+
+func (s *clamdScan) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		chunk := p[:min(len(p), clamdChunkSize)]
+		if err := s.writeChunk(chunk); err != nil {
+			return written, err
+		}
+		written += len(chunk)
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
+
+func (s *clamdScan) writeChunk(chunk []byte) error {
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(chunk)))
+	if _, err := s.conn.Write(size[:]); err != nil {
+		return err
+	}
+	_, err := s.conn.Write(chunk)
+	return err
+}
+
+// Finish ends the stream and reads the verdict: "stream: OK", "stream: <signature> FOUND"
+// or an error message.
+func (s *clamdScan) Finish() error {
+	if err := s.writeChunk(nil); err != nil {
+		return err
+	}
+
+	reply, err := bufio.NewReader(s.conn).ReadBytes(0)
+	if err != nil && len(reply) == 0 {
+		return fmt.Errorf("clamd reply: %w", err)
+	}
+
+	verdict := strings.TrimPrefix(string(bytes.TrimRight(reply, "\x00\n")), clamdStreamTag)
+	switch {
+	case verdict == clamdOK:
+		return nil
+	case strings.HasSuffix(verdict, clamdFound):
+		return Reject(clamdScannerName, strings.TrimSuffix(verdict, clamdFound))
+	default:
+		return fmt.Errorf("clamd: %s", verdict)
+	}
+}
+
+func (s *clamdScan) Close() error {
+	return s.conn.Close()
+}
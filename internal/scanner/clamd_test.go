@@ -0,0 +1,118 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"shopapi/internal/supports"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stubClamd answers the INSTREAM commands on a unix socket, the content is passed to the verdict func.
+func stubClamd(t *testing.T, verdict func([]byte) string) string {
+	path := filepath.Join(t.TempDir(), "clamd.sock")
+	listener, err := net.Listen("unix", path)
+	require.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveClamd(conn, verdict)
+		}
+	}()
+
+	return "unix://" + path
+}
+
+func serveClamd(conn net.Conn, verdict func([]byte) string) {
+	defer conn.Close()
+
+	command := make([]byte, len(clamdInstream))
+	if _, err := io.ReadFull(conn, command); err != nil || string(command) != clamdInstream {
+		return
+	}
+
+	var content bytes.Buffer
+	for {
+		var size [4]byte
+		if _, err := io.ReadFull(conn, size[:]); err != nil {
+			return
+		}
+		n := binary.BigEndian.Uint32(size[:])
+		if n == 0 {
+			break
+		}
+		if _, err := io.CopyN(&content, conn, int64(n)); err != nil {
+			return
+		}
+	}
+
+	_, _ = conn.Write([]byte(verdict(content.Bytes()) + "\x00"))
+}
+
+func TestClamdScanner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ClamdScanner ok", func(t *testing.T) {
+		t.Parallel()
+
+		var scanned []byte
+		address := stubClamd(t, func(content []byte) string {
+			scanned = bytes.Clone(content)
+			return "stream: OK"
+		})
+
+		s, err := NewClamdScanner(address, time.Second)
+		require.Nil(t, err)
+
+		require.Nil(t, scanContent(t, s, supports.TestImage))
+		require.Equal(t, supports.TestImage, scanned)
+	})
+
+	t.Run("ClamdScanner error on found", func(t *testing.T) {
+		t.Parallel()
+
+		address := stubClamd(t, func([]byte) string {
+			return "stream: Eicar-Test-Signature FOUND"
+		})
+
+		s, err := NewClamdScanner(address, time.Second)
+		require.Nil(t, err)
+
+		err = scanContent(t, s, supports.TestImage)
+		require.ErrorIs(t, err, supports.ErrRejected)
+		require.ErrorContains(t, err, "clamd: Eicar-Test-Signature")
+	})
+
+	t.Run("ClamdScanner error on daemon error", func(t *testing.T) {
+		t.Parallel()
+
+		address := stubClamd(t, func([]byte) string {
+			return "INSTREAM size limit exceeded. ERROR"
+		})
+
+		s, err := NewClamdScanner(address, time.Second)
+		require.Nil(t, err)
+
+		err = scanContent(t, s, supports.TestImage)
+		require.NotNil(t, err)
+		require.NotErrorIs(t, err, supports.ErrRejected)
+	})
+
+	t.Run("ClamdScanner error on address", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewClamdScanner("http://clamav:3310", time.Second)
+		require.NotNil(t, err)
+	})
+}
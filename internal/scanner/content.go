@@ -0,0 +1,206 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"shopapi/internal/imagemeta"
+	mimeManager "shopapi/internal/mime-manager"
+)
+
+const (
+	contentScannerName = "content"
+	formatPDF          = "pdf"
+	// headLimit is the most bytes buffered to find the image size, the jpeg one follows its metadata.
+	headLimit = 256 << 10
+	// tailLimit is the most bytes at the end of the image searched for an archive appended to it.
+	tailLimit = 4 << 10
+)
+
+var (
+	// The markup makes an image a polyglot, which a browser may take for a page or a script.
+	// The patterns are lower case, the content is matched case insensitively.
+	markupPatterns = [][]byte{
+		[]byte("<script"),
+		[]byte("<html"),
+		[]byte("<iframe"),
+		[]byte("<?php"),
+		[]byte("javascript:"),
+	}
+
+	// The actions of a pdf run when it is opened, the names are case sensitive.
+	pdfActivePatterns = [][]byte{
+		[]byte("/JavaScript"),
+		[]byte("/Launch"),
+		[]byte("/EmbeddedFile"),
+	}
+
+	pdfMagic = []byte("%PDF-")
+
+	// The end of the central directory of a zip archive, an archive appended to a file
+	// is still opened by the zip tools.
+	zipEndSignature = []byte("PK\x05\x06")
+)
+
+// ContentScanner is the built-in scanner. It checks the upload starts with the magic bytes of a known
+// image or document format and the image header agrees with them, that the raster image size is within
+// the pixel limit, so a small file can't expand into a decompression bomb, and that the file carries
+// neither an archive nor markup or pdf actions, which would make it a polyglot file. SVG is markup itself,
+// it is sanitized when it is stored.
+type ContentScanner struct {
+	maxPixels int
+}
+
+func NewContentScanner(maxPixels int) *ContentScanner {
+	return &ContentScanner{maxPixels: maxPixels}
+}
+
+func (s *ContentScanner) Name() string {
+	return contentScannerName
+}
+
+func (s *ContentScanner) NewScan(context.Context) (Scan, error) {
+	return &contentScan{maxPixels: s.maxPixels}, nil
+}
+
+type contentScan struct {
+	maxPixels int
+	// head is the content written until the format and the image size are found.
+	head    []byte
+	format  string
+	checked bool
+	// window is the end of the content written, the patterns split between writes are matched in it.
+	window []byte
+}
+
+func (s *contentScan) Write(p []byte) (int, error) {
+	n := len(p)
+	if s.format == "" {
+		s.head = append(s.head, p...)
+		if err := s.checkFormat(false); err != nil {
+			return 0, err
+		}
+		if s.format == "" {
+			return len(p), nil
+		}
+		// The content written so far is matched at once.
+		p = s.head
+	} else if !s.checked {
+		s.head = append(s.head, p[:min(len(p), headLimit-len(s.head))]...)
+	}
+
+	if !s.checked {
+		if err := s.checkHead(false); err != nil {
+			return 0, err
+		}
+	}
+
+	data := append(s.window, p...)
+	if err := s.checkPatterns(data); err != nil {
+		return 0, err
+	}
+	s.window = append(s.window[:0], data[max(0, len(data)-tailLimit):]...)
+
+	return n, nil
+}
+
+func (s *contentScan) Finish() error {
+	if s.format == "" {
+		if err := s.checkFormat(true); err != nil {
+			return err
+		}
+		if err := s.checkPatterns(s.head); err != nil {
+			return err
+		}
+		s.window = s.head
+	}
+
+	if !s.checked {
+		if err := s.checkHead(true); err != nil {
+			return err
+		}
+	}
+
+	if bytes.Contains(s.window, zipEndSignature) {
+		return Reject(contentScannerName, "archive appended to the file")
+	}
+
+	return nil
+}
+
+func (s *contentScan) Close() error {
+	return nil
+}
+
+// checkFormat finds the format by the magic bytes once the head is long enough to sniff it.
+func (s *contentScan) checkFormat(last bool) error {
+	if bytes.HasPrefix(s.head, pdfMagic) {
+		s.format = formatPDF
+		return nil
+	}
+
+	if len(s.head) < mimeManager.SniffLen && !last {
+		return nil
+	}
+
+	s.format = imagemeta.FormatOf(s.head)
+	if s.format == "" {
+		return Reject(contentScannerName, "unknown file format")
+	}
+
+	return nil
+}
+
+// checkHead checks the image header and size once the head holds them, an incomplete head
+// is rejected only when the content is over or the head is full.
+func (s *contentScan) checkHead(last bool) error {
+	if s.format == formatPDF {
+		s.checked = true
+		s.head = nil
+		return nil
+	}
+
+	meta, err := imagemeta.DecodeConfig(bytes.NewReader(s.head))
+	if errors.Is(err, io.ErrUnexpectedEOF) && !last && len(s.head) < headLimit {
+		return nil
+	}
+	s.checked = true
+	s.head = nil
+
+	switch {
+	case err != nil:
+		return Reject(contentScannerName, "image header is malformed")
+	case meta.Format != s.format:
+		return Reject(contentScannerName, fmt.Sprintf("%s header in %s image", meta.Format, s.format))
+	case s.format != imagemeta.FormatSVG && meta.Exceeds(s.maxPixels):
+		return Reject(contentScannerName, fmt.Sprintf("image is %dx%d, more than %d pixels",
+			meta.Width, meta.Height, s.maxPixels))
+	}
+
+	return nil
+}
+
+func (s *contentScan) checkPatterns(data []byte) error {
+	switch s.format {
+	case imagemeta.FormatSVG:
+		return nil
+	case formatPDF:
+		for _, p := range pdfActivePatterns {
+			if bytes.Contains(data, p) {
+				return Reject(contentScannerName, fmt.Sprintf("action '%s' in the document", p))
+			}
+		}
+		return nil
+	}
+
+	lower := bytes.ToLower(data)
+	for _, p := range markupPatterns {
+		if bytes.Contains(lower, p) {
+			return Reject(contentScannerName, fmt.Sprintf("markup '%s' in the image", p))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"shopapi/internal/supports"
+	"shopapi/internal/thumbnail"
+
+	"github.com/stretchr/testify/require"
+)
+
+// scanContent writes the content to a new scan in small pieces, as it comes from the network.
+func scanContent(t *testing.T, s Scanner, content []byte) error {
+	scan, err := s.NewScan(context.Background())
+	require.Nil(t, err)
+	defer scan.Close()
+
+	for r := bytes.NewReader(content); r.Len() > 0; {
+		if _, err = io.CopyN(scan, r, 1000); err != nil && err != io.EOF {
+			return err
+		}
+	}
+
+	return scan.Finish()
+}
+
+func TestContentScanner(t *testing.T) {
+	t.Parallel()
+
+	s := NewContentScanner(thumbnail.MaxSourcePixels)
+
+	t.Run("ContentScanner ok", func(t *testing.T) {
+		t.Parallel()
+
+		require.Nil(t, scanContent(t, s, supports.TestImage))
+	})
+
+	t.Run("ContentScanner error on unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		err := scanContent(t, s, []byte("GIF87a but not really an image"))
+		require.ErrorIs(t, err, supports.ErrRejected)
+
+		err = scanContent(t, s, []byte("plain text"))
+		require.ErrorIs(t, err, supports.ErrRejected)
+		require.ErrorContains(t, err, "unknown file format")
+	})
+
+	t.Run("ContentScanner svg ok", func(t *testing.T) {
+		t.Parallel()
+
+		// The scripts of svg are removed by the sanitizing when it is stored.
+		svg := `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"><script>alert(1)</script></svg>`
+		require.Nil(t, scanContent(t, s, []byte(svg)))
+	})
+
+	t.Run("ContentScanner pdf ok", func(t *testing.T) {
+		t.Parallel()
+
+		pdf := "%PDF-1.7\n1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n%%EOF\n"
+		require.Nil(t, scanContent(t, s, []byte(pdf)))
+	})
+
+	t.Run("ContentScanner error on pdf action", func(t *testing.T) {
+		t.Parallel()
+
+		pdf := "%PDF-1.7\n" + strings.Repeat(" ", 990) + "1 0 obj << /Type /Action /S /JavaScript /JS (app.alert(1)) >> endobj\n"
+
+		err := scanContent(t, s, []byte(pdf))
+		require.ErrorIs(t, err, supports.ErrRejected)
+		require.ErrorContains(t, err, "/JavaScript")
+	})
+
+	t.Run("ContentScanner error on too many pixels", func(t *testing.T) {
+		t.Parallel()
+
+		err := scanContent(t, NewContentScanner(10), supports.TestImage)
+		require.ErrorIs(t, err, supports.ErrRejected)
+		require.ErrorContains(t, err, "more than 10 pixels")
+	})
+
+	t.Run("ContentScanner error on markup", func(t *testing.T) {
+		t.Parallel()
+
+		// The markup is split between the writes.
+		content := append(bytes.Clone(supports.TestImage[:998]), "<ScRiPt>alert(1)</script>"...)
+		content = append(content, supports.TestImage[998:]...)
+
+		err := scanContent(t, s, content)
+		require.ErrorIs(t, err, supports.ErrRejected)
+		require.ErrorContains(t, err, "<script")
+	})
+
+	t.Run("ContentScanner error on appended archive", func(t *testing.T) {
+		t.Parallel()
+
+		content := append(bytes.Clone(supports.TestImage), "PK\x05\x06\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"...)
+
+		err := scanContent(t, s, content)
+		require.ErrorIs(t, err, supports.ErrRejected)
+		require.ErrorContains(t, err, "archive")
+	})
+}
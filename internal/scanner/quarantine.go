@@ -0,0 +1,80 @@
+package scanner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	dirPerm      = 0o700
+	filePerm     = 0o600
+	recordExt    = ".json"
+	spoolPattern = ".spool-*"
+)
+
+// Record describes the quarantined upload, it is kept next to the content as <id>.json.
+type Record struct {
+	ID         uuid.UUID `json:"id"`
+	FileName   string    `json:"file_name"`
+	Reason     string    `json:"reason"`
+	Size       int64     `json:"size"`
+	RejectedAt time.Time `json:"rejected_at"`
+}
+
+// Quarantine keeps the rejected uploads in the directory for review, they are never served.
+type Quarantine struct {
+	dir string
+}
+
+func NewQuarantine(dir string) (*Quarantine, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, err
+	}
+	return &Quarantine{dir: dir}, nil
+}
+
+// spool creates the file the upload is copied to while it is scanned.
+func (q *Quarantine) spool() (*os.File, error) {
+	return os.CreateTemp(q.dir, spoolPattern)
+}
+
+// keep moves the spooled upload into the quarantine under a new id and writes its record.
+func (q *Quarantine) keep(spool *os.File, record *Record) error {
+	defer os.Remove(spool.Name())
+
+	if err := spool.Close(); err != nil {
+		return err
+	}
+
+	record.ID = uuid.New()
+	path := filepath.Join(q.dir, record.ID.String())
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(path+recordExt, data, filePerm); err != nil {
+		return err
+	}
+
+	return os.Rename(spool.Name(), path)
+}
+
+// Get returns the record of the quarantined upload.
+func (q *Quarantine) Get(id uuid.UUID) (*Record, error) {
+	data, err := os.ReadFile(filepath.Join(q.dir, id.String()+recordExt))
+	if err != nil {
+		return nil, err
+	}
+
+	record := &Record{}
+	if err = json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
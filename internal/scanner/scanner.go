@@ -0,0 +1,246 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"shopapi/internal/supports"
+	"shopapi/internal/thumbnail"
+)
+
+const (
+	defaultQuarantineDir = "./data/quarantine"
+	clamdTimeout         = time.Second * 30
+
+	clamd_address_secret_path  = "./secrets/clamd_address.txt"
+	quarantine_dir_secret_path = "./secrets/quarantine_dir.txt"
+)
+
+// Scanner checks the uploaded content on its way to the storage.
+type Scanner interface {
+	Name() string
+	// NewScan starts the scan of a single upload.
+	NewScan(ctx context.Context) (Scan, error)
+}
+
+// Scan is written the uploaded content as it is read. Write and Finish fail with an error wrapping
+// supports.ErrRejected when the content is rejected, other errors are failures of the scanner itself.
+type Scan interface {
+	io.Writer
+	// Finish is called when the whole content is written.
+	Finish() error
+	// Close releases the scan, it may be called before the content ends.
+	Close() error
+}
+
+// Reject is the error of a scanner rejecting the content for the reason.
+func Reject(scanner, reason string) error {
+	return fmt.Errorf("%w: %s: %s", supports.ErrRejected, scanner, reason)
+}
+
+// Guard scans the uploads by the scanners before they are stored and quarantines the rejected ones.
+type Guard struct {
+	scanners   []Scanner
+	quarantine *Quarantine
+	onReject   func(Record)
+}
+
+// NewGuard builds the guard running the scanners in order. The rejected uploads are dropped
+// if quarantine is nil.
+func NewGuard(quarantine *Quarantine, scanners ...Scanner) *Guard {
+	return &Guard{
+		scanners:   scanners,
+		quarantine: quarantine,
+		onReject:   func(Record) {},
+	}
+}
+
+// NewGuardFromSecrets builds the guard with the built-in scanner, the clamd one if its address is set
+// and the quarantine in the configured directory.
+func NewGuardFromSecrets() (*Guard, error) {
+	dir, err := readOptionalSecret(quarantine_dir_secret_path, defaultQuarantineDir)
+	if err != nil {
+		return nil, err
+	}
+
+	quarantine, err := NewQuarantine(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	scanners := []Scanner{NewContentScanner(thumbnail.MaxSourcePixels)}
+
+	address, err := readOptionalSecret(clamd_address_secret_path, "")
+	if err != nil {
+		return nil, err
+	}
+	if address != "" {
+		clamd, err := NewClamdScanner(address, clamdTimeout)
+		if err != nil {
+			return nil, err
+		}
+		scanners = append(scanners, clamd)
+	}
+
+	return NewGuard(quarantine, scanners...), nil
+}
+
+func readOptionalSecret(path, value string) (string, error) {
+	secret, err := supports.ReadSecret(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return value, nil
+	}
+	return secret, err
+}
+
+// OnReject sets the callback called with the record of each rejected upload.
+func (g *Guard) OnReject(fn func(Record)) {
+	g.onReject = fn
+}
+
+// Reader returns the content of r checked by the scanners as it is read. Instead of the end
+// of the content it fails with the rejection error, so the storage reading it never keeps
+// a rejected upload. The scans are released when ctx is done.
+func (g *Guard) Reader(ctx context.Context, name string, r io.Reader) (io.Reader, error) {
+	gr := &guardedReader{
+		guard: g,
+		name:  name,
+		src:   r,
+	}
+
+	for _, s := range g.scanners {
+		scan, err := s.NewScan(ctx)
+		if err != nil {
+			gr.release()
+			return nil, fmt.Errorf("scanner %s: %w", s.Name(), err)
+		}
+		gr.scans = append(gr.scans, scan)
+	}
+
+	if g.quarantine != nil {
+		spool, err := g.quarantine.spool()
+		if err != nil {
+			gr.release()
+			return nil, err
+		}
+		gr.spool = spool
+	}
+
+	context.AfterFunc(ctx, func() {
+		gr.mutex.Lock()
+		defer gr.mutex.Unlock()
+		gr.release()
+	})
+
+	return gr, nil
+}
+
+// guardedReader writes the content read to the scans and to the spool, which is quarantined
+// on rejection and removed otherwise.
+type guardedReader struct {
+	mutex sync.Mutex
+	guard *Guard
+	name  string
+	src   io.Reader
+	scans []Scan
+	spool *os.File
+	size  int64
+	err   error
+}
+
+func (r *guardedReader) Read(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	n, err := r.src.Read(p)
+	if n > 0 {
+		if failed := r.write(p[:n]); failed != nil {
+			return 0, r.fail(failed)
+		}
+	}
+
+	if err == io.EOF {
+		for _, scan := range r.scans {
+			if failed := scan.Finish(); failed != nil {
+				return 0, r.fail(failed)
+			}
+		}
+		r.release()
+		r.err = io.EOF
+	} else if err != nil {
+		return n, r.fail(err)
+	}
+
+	return n, err
+}
+
+func (r *guardedReader) write(p []byte) error {
+	r.size += int64(len(p))
+	if r.spool != nil {
+		if _, err := r.spool.Write(p); err != nil {
+			return err
+		}
+	}
+
+	for _, scan := range r.scans {
+		if _, err := scan.Write(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fail ends the reading with err, a rejected upload is quarantined whole first.
+func (r *guardedReader) fail(err error) error {
+	r.err = err
+	if errors.Is(err, supports.ErrRejected) {
+		r.reject(err)
+	}
+	r.release()
+	return err
+}
+
+func (r *guardedReader) reject(err error) {
+	record := Record{
+		FileName:   r.name,
+		Reason:     err.Error(),
+		RejectedAt: time.Now().UTC(),
+	}
+
+	if r.spool != nil {
+		// The rest of the upload is kept as well, the source is limited by the caller.
+		n, _ := io.Copy(r.spool, r.src)
+		record.Size = r.size + n
+
+		if keepErr := r.guard.quarantine.keep(r.spool, &record); keepErr != nil {
+			record.Reason += ", not quarantined: " + keepErr.Error()
+		}
+		r.spool = nil
+	}
+
+	r.guard.onReject(record)
+}
+
+// release closes the scans and removes the spool unless it is quarantined, it is safe to call twice.
+func (r *guardedReader) release() {
+	for _, scan := range r.scans {
+		_ = scan.Close()
+	}
+	r.scans = nil
+
+	if r.spool != nil {
+		_ = r.spool.Close()
+		_ = os.Remove(r.spool.Name())
+		r.spool = nil
+	}
+}
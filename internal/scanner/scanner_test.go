@@ -0,0 +1,154 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"shopapi/internal/supports"
+	"shopapi/internal/thumbnail"
+
+	"github.com/stretchr/testify/require"
+)
+
+// failingScanner fails the scan when it ends.
+type failingScanner struct {
+	err error
+}
+
+func (s *failingScanner) Name() string {
+	return "failing"
+}
+
+func (s *failingScanner) NewScan(context.Context) (Scan, error) {
+	return s, nil
+}
+
+func (s *failingScanner) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (s *failingScanner) Finish() error {
+	return s.err
+}
+
+func (s *failingScanner) Close() error {
+	return nil
+}
+
+func newTestGuard(t *testing.T, scanners ...Scanner) (*Guard, string) {
+	dir := t.TempDir()
+	quarantine, err := NewQuarantine(dir)
+	require.Nil(t, err)
+
+	scanners = append([]Scanner{NewContentScanner(thumbnail.MaxSourcePixels)}, scanners...)
+	return NewGuard(quarantine, scanners...), dir
+}
+
+func TestGuard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Guard ok", func(t *testing.T) {
+		t.Parallel()
+
+		guard, dir := newTestGuard(t)
+
+		r, err := guard.Reader(context.Background(), "some.jpeg", bytes.NewReader(supports.TestImage))
+		require.Nil(t, err)
+
+		data, err := io.ReadAll(r)
+		require.Nil(t, err)
+		require.Equal(t, supports.TestImage, data)
+
+		entries, err := os.ReadDir(dir)
+		require.Nil(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("Guard rejected quarantined", func(t *testing.T) {
+		t.Parallel()
+
+		guard, dir := newTestGuard(t)
+		var rejected Record
+		guard.OnReject(func(rec Record) { rejected = rec })
+
+		content := append(bytes.Clone(supports.TestImage[:100]), "<?php echo 1; ?>"...)
+		content = append(content, supports.TestImage[100:]...)
+
+		r, err := guard.Reader(context.Background(), "some.jpeg", bytes.NewReader(content))
+		require.Nil(t, err)
+
+		_, err = io.ReadAll(r)
+		require.ErrorIs(t, err, supports.ErrRejected)
+		require.Equal(t, "some.jpeg", rejected.FileName)
+		require.Equal(t, int64(len(content)), rejected.Size)
+		require.Contains(t, rejected.Reason, "<?php")
+
+		kept, err := os.ReadFile(filepath.Join(dir, rejected.ID.String()))
+		require.Nil(t, err)
+		require.Equal(t, content, kept)
+
+		quarantine, err := NewQuarantine(dir)
+		require.Nil(t, err)
+		record, err := quarantine.Get(rejected.ID)
+		require.Nil(t, err)
+		require.Equal(t, rejected.Reason, record.Reason)
+	})
+
+	t.Run("Guard rejected at the end", func(t *testing.T) {
+		t.Parallel()
+
+		guard, _ := newTestGuard(t, &failingScanner{err: Reject("failing", "test")})
+
+		r, err := guard.Reader(context.Background(), "some.jpeg", bytes.NewReader(supports.TestImage))
+		require.Nil(t, err)
+
+		_, err = io.ReadAll(r)
+		require.ErrorIs(t, err, supports.ErrRejected)
+
+		// The error is kept for the next reads.
+		_, err = r.Read(make([]byte, 1))
+		require.ErrorIs(t, err, supports.ErrRejected)
+	})
+
+	t.Run("Guard error on scanner failure", func(t *testing.T) {
+		t.Parallel()
+
+		errScanner := errors.New("scanner")
+		guard, dir := newTestGuard(t, &failingScanner{err: errScanner})
+		guard.OnReject(func(Record) { t.Error("failure is not a rejection") })
+
+		r, err := guard.Reader(context.Background(), "some.jpeg", bytes.NewReader(supports.TestImage))
+		require.Nil(t, err)
+
+		_, err = io.ReadAll(r)
+		require.ErrorIs(t, err, errScanner)
+
+		entries, err := os.ReadDir(dir)
+		require.Nil(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("Guard spool removed when done", func(t *testing.T) {
+		t.Parallel()
+
+		guard, dir := newTestGuard(t)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		r, err := guard.Reader(ctx, "some.jpeg", bytes.NewReader(supports.TestImage))
+		require.Nil(t, err)
+		_, err = r.Read(make([]byte, 100))
+		require.Nil(t, err)
+
+		cancel()
+		require.Eventually(t, func() bool {
+			entries, err := os.ReadDir(dir)
+			return err == nil && len(entries) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	ds "shopapi/internal/datastruct"
+)
+
+// GetAudit bypasses the cache, the entries are appended by every change.
+func (s *Service) GetAudit(req *ds.GetAuditRequest) *ds.GetAuditResponse {
+	resp, err := s.auditStorage.GetAudit(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on GetAudit", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetAudit", resp.GetStatus())
+
+	return resp
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"testing"
+
+	ds "shopapi/internal/datastruct"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAudit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetAudit ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetAuditRequest{Entity: ds.AuditSupplier, EntityId: "609ccf6f-7fb4-44bd-aa77-bc9e0e7572b4"}
+		res := &ds.GetAuditResponse{Entries: []ds.AuditEntry{{Id: 1, Action: "AddSupplier", Entity: ds.AuditSupplier}}}
+
+		s.auditStorageMock.EXPECT().GetAudit(req).Return(res, nil)
+
+		resp := s.srv.GetAudit(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("GetAudit error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.auditStorageMock.EXPECT().GetAudit(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetAudit(&ds.GetAuditRequest{})
+		require.Nil(t, resp)
+	})
+}
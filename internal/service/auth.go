@@ -0,0 +1,91 @@
+package service
+
+import (
+	"shopapi/internal/auth"
+	ds "shopapi/internal/datastruct"
+
+	"github.com/google/uuid"
+)
+
+// The API keys bypass the cache: a cached issue would hand out the same key twice,
+// and a cached lookup would keep a revoked key working.
+
+// IssueApiKey refuses the key with the roles granting permissions the caller lacks, they are listed in the response.
+func (s *Service) IssueApiKey(req *ds.IssueApiKeyRequest) *ds.IssueApiKeyResponse {
+	granted, err := s.authStorage.GetPermissions(&ds.GetPermissionsRequest{Roles: req.Roles})
+	if err != nil {
+		s.logger.ErrorKV("failed on IssueApiKey", "message", err.Error())
+		return nil
+	}
+
+	if missing := ds.MissingPermissions(req.Granted, granted.Permissions); len(missing) > 0 {
+		resp := &ds.IssueApiKeyResponse{
+			Status:             ds.Status{Message: ds.StatusForbidden},
+			MissingPermissions: missing,
+		}
+		s.logHandlerStatus("IssueApiKey", resp.GetStatus())
+		return resp
+	}
+
+	key, err := auth.NewAPIKey()
+	if err != nil {
+		s.logger.ErrorKV("failed generating api key", "message", err.Error())
+		return nil
+	}
+
+	req.Uid = uuid.New()
+	req.Prefix = auth.ShownPrefix(key)
+	req.KeyHash = auth.HashAPIKey(key)
+
+	resp, err := s.authStorage.AddApiKey(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on IssueApiKey", "message", err.Error())
+		return nil
+	}
+
+	if resp.ApiKey != nil {
+		resp.Key = key
+		s.logger.InfoKV("api key issued", "uid", resp.ApiKey.Uid.String(), "name", resp.ApiKey.Name)
+	}
+
+	s.logHandlerStatus("IssueApiKey", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) GetApiKeys(req *ds.GetApiKeysRequest) *ds.GetApiKeysResponse {
+	resp, err := s.authStorage.GetApiKeys(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on GetApiKeys", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetApiKeys", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) RevokeApiKey(req *ds.RevokeApiKeyRequest) *ds.RevokeApiKeyResponse {
+	resp, err := s.authStorage.RevokeApiKey(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on RevokeApiKey", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("RevokeApiKey", resp.GetStatus())
+
+	return resp
+}
+
+// AuthenticateApiKey looks the key up by its hash, the key itself isn't logged or stored.
+func (s *Service) AuthenticateApiKey(req *ds.AuthenticateApiKeyRequest) *ds.AuthenticateApiKeyResponse {
+	req.KeyHash = auth.HashAPIKey(req.Key)
+
+	resp, err := s.authStorage.AuthenticateApiKey(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on AuthenticateApiKey", "message", err.Error())
+		return nil
+	}
+
+	return resp
+}
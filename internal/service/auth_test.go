@@ -0,0 +1,177 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"shopapi/internal/auth"
+	ds "shopapi/internal/datastruct"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueApiKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("IssueApiKey ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.IssueApiKeyRequest{
+			Audit: ds.Audit{Granted: []string{ds.PermissionAll}},
+			Name:  "warehouse",
+			Roles: []string{"warehouse"},
+		}
+
+		var stored *ds.IssueApiKeyRequest
+		s.authStorageMock.EXPECT().GetPermissions(&ds.GetPermissionsRequest{Roles: req.Roles}).
+			Return(&ds.GetPermissionsResponse{Permissions: []string{ds.PermissionStockWrite}}, nil)
+		s.authStorageMock.EXPECT().AddApiKey(gomock.Any()).DoAndReturn(
+			func(r *ds.IssueApiKeyRequest) (*ds.IssueApiKeyResponse, error) {
+				stored = r
+				return &ds.IssueApiKeyResponse{
+					ApiKey: &ds.ApiKey{Uid: r.Uid, Name: r.Name, Prefix: r.Prefix},
+				}, nil
+			})
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.IssueApiKey(req)
+		require.NotNil(t, resp)
+		require.True(t, strings.HasPrefix(resp.Key, auth.KeyPrefix))
+		require.Equal(t, auth.HashAPIKey(resp.Key), stored.KeyHash)
+		require.True(t, strings.HasPrefix(resp.Key, stored.Prefix))
+		require.NotEqual(t, uuid.Nil, stored.Uid)
+	})
+
+	t.Run("IssueApiKey error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.authStorageMock.EXPECT().GetPermissions(gomock.Any()).Return(&ds.GetPermissionsResponse{}, nil)
+		s.authStorageMock.EXPECT().AddApiKey(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.IssueApiKey(&ds.IssueApiKeyRequest{Name: "warehouse"})
+		require.Nil(t, resp)
+	})
+
+	t.Run("IssueApiKey permissions the caller lacks", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.IssueApiKeyRequest{
+			Audit: ds.Audit{Granted: []string{ds.PermissionApiKeysManage, ds.PermissionProductsRead}},
+			Name:  "backdoor",
+			Roles: []string{ds.RoleAdmin},
+		}
+
+		s.authStorageMock.EXPECT().GetPermissions(gomock.Any()).
+			Return(&ds.GetPermissionsResponse{Permissions: []string{ds.PermissionAll}}, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.IssueApiKey(req)
+		require.NotNil(t, resp)
+		require.Equal(t, ds.StatusForbidden, resp.GetStatus())
+		require.Equal(t, []string{ds.PermissionAll}, resp.MissingPermissions)
+		require.Empty(t, resp.Key)
+	})
+}
+
+func TestGetApiKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetApiKeys ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetApiKeysRequest{}
+		res := &ds.GetApiKeysResponse{ApiKeys: []ds.ApiKey{{Name: "warehouse"}}}
+
+		s.authStorageMock.EXPECT().GetApiKeys(req).Return(res, nil)
+
+		resp := s.srv.GetApiKeys(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("GetApiKeys error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.authStorageMock.EXPECT().GetApiKeys(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetApiKeys(&ds.GetApiKeysRequest{})
+		require.Nil(t, resp)
+	})
+}
+
+func TestRevokeApiKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RevokeApiKey ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.RevokeApiKeyRequest{Uid: uuid.New()}
+		res := &ds.RevokeApiKeyResponse{Status: ds.Status{Message: ds.StatusOK}}
+
+		s.authStorageMock.EXPECT().RevokeApiKey(req).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RevokeApiKey(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("RevokeApiKey error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.authStorageMock.EXPECT().RevokeApiKey(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RevokeApiKey(&ds.RevokeApiKeyRequest{Uid: uuid.New()})
+		require.Nil(t, resp)
+	})
+}
+
+func TestAuthenticateApiKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AuthenticateApiKey ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		res := &ds.AuthenticateApiKeyResponse{ApiKey: &ds.ApiKey{Name: "warehouse"}}
+
+		s.authStorageMock.EXPECT().AuthenticateApiKey(gomock.Any()).DoAndReturn(
+			func(r *ds.AuthenticateApiKeyRequest) (*ds.AuthenticateApiKeyResponse, error) {
+				require.Equal(t, auth.HashAPIKey("sk_key"), r.KeyHash)
+				return res, nil
+			})
+
+		resp := s.srv.AuthenticateApiKey(&ds.AuthenticateApiKeyRequest{Key: "sk_key"})
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("AuthenticateApiKey error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.authStorageMock.EXPECT().AuthenticateApiKey(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.AuthenticateApiKey(&ds.AuthenticateApiKeyRequest{Key: "sk_key"})
+		require.Nil(t, resp)
+	})
+}
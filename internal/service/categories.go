@@ -0,0 +1,82 @@
+package service
+
+import (
+	"encoding/json"
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/supports"
+)
+
+func (s *Service) AddCategory(req *ds.AddCategoryRequest) *ds.AddCategoryResponse {
+	reqKey, err := json.Marshal(req.Category)
+	if err != nil {
+		s.logger.ErrorKV("failed making cache key", "message", err.Error(), "data", *req)
+		req.AvoidCacheFlag.Flag = true
+	}
+	key := makeCacheKey("AddCategory", req.Uid.String(), supports.GetHash(reqKey))
+
+	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.AddCategoryResponse, error) {
+		return s.categoryStorage.AddCategory(req)
+	})
+	if err != nil {
+		s.logger.ErrorKV("failed on AddCategory", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("AddCategory", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) UpdateCategory(req *ds.UpdateCategoryRequest) *ds.UpdateCategoryResponse {
+	resp, err := s.categoryStorage.UpdateCategory(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on UpdateCategory", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("UpdateCategory", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) DeleteCategory(req *ds.DeleteCategoryRequest) *ds.DeleteCategoryResponse {
+	resp, err := s.categoryStorage.DeleteCategory(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on DeleteCategory", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("DeleteCategory", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) GetCategory(req *ds.GetCategoryRequest) *ds.GetCategoryResponse {
+	key := makeCacheKey("GetCategory", req.Uid.String())
+
+	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetCategoryResponse, error) {
+		return s.categoryStorage.GetCategory(req)
+	})
+	if err != nil {
+		s.logger.ErrorKV("failed on GetCategory", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetCategory", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) GetCategoriesTree(req *ds.GetCategoriesTreeRequest) *ds.GetCategoriesTreeResponse {
+	key := makeCacheKey("GetCategoriesTree")
+
+	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetCategoriesTreeResponse, error) {
+		return s.categoryStorage.GetCategoriesTree(req)
+	})
+	if err != nil {
+		s.logger.ErrorKV("failed on GetCategoriesTree", "message", err.Error())
+		return nil
+	}
+
+	return resp
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	ds "shopapi/internal/datastruct"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddCategory ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.AddCategoryRequest{}
+		res := &ds.AddCategoryResponse{}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+		s.categoryStorageMock.EXPECT().AddCategory(gomock.Any()).Return(res, nil)
+
+		resp := s.srv.AddCategory(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("AddCategory error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.AddCategoryRequest{}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.categoryStorageMock.EXPECT().AddCategory(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.AddCategory(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestUpdateCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UpdateCategory ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.UpdateCategoryRequest{}
+		res := &ds.UpdateCategoryResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.categoryStorageMock.EXPECT().UpdateCategory(gomock.Any()).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.UpdateCategory(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("UpdateCategory back to an earlier body", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestServiceWithCache(t)
+
+		a := &ds.UpdateCategoryRequest{Name: "Beams"}
+		b := &ds.UpdateCategoryRequest{Name: "Timber"}
+		ok := &ds.UpdateCategoryResponse{Status: ds.Status{Message: ds.StatusOK}}
+
+		gomock.InOrder(
+			s.categoryStorageMock.EXPECT().UpdateCategory(a).Return(ok, nil),
+			s.categoryStorageMock.EXPECT().UpdateCategory(b).Return(ok, nil),
+			s.categoryStorageMock.EXPECT().UpdateCategory(a).Return(ok, nil),
+		)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All()).Times(3)
+
+		for _, req := range []*ds.UpdateCategoryRequest{a, b, a} {
+			require.Equal(t, ds.StatusOK, s.srv.UpdateCategory(req).GetStatus())
+		}
+	})
+
+	t.Run("UpdateCategory error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.UpdateCategoryRequest{}
+
+		s.categoryStorageMock.EXPECT().UpdateCategory(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.UpdateCategory(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestDeleteCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteCategory ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.DeleteCategoryRequest{}
+		res := &ds.DeleteCategoryResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.categoryStorageMock.EXPECT().DeleteCategory(gomock.Any()).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.DeleteCategory(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("DeleteCategory repeated after the category is freed", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestServiceWithCache(t)
+
+		req := &ds.DeleteCategoryRequest{}
+
+		gomock.InOrder(
+			s.categoryStorageMock.EXPECT().DeleteCategory(req).
+				Return(&ds.DeleteCategoryResponse{Status: ds.Status{Message: ds.StatusCategoryInUse}}, nil),
+			s.categoryStorageMock.EXPECT().DeleteCategory(req).
+				Return(&ds.DeleteCategoryResponse{Status: ds.Status{Message: ds.StatusOK}}, nil),
+		)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All()).Times(2)
+
+		require.Equal(t, ds.StatusCategoryInUse, s.srv.DeleteCategory(req).GetStatus())
+		require.Equal(t, ds.StatusOK, s.srv.DeleteCategory(req).GetStatus())
+	})
+
+	t.Run("DeleteCategory error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.DeleteCategoryRequest{}
+
+		s.categoryStorageMock.EXPECT().DeleteCategory(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.DeleteCategory(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetCategory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetCategory ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetCategoryRequest{}
+		res := &ds.GetCategoryResponse{}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+		s.categoryStorageMock.EXPECT().GetCategory(gomock.Any()).Return(res, nil)
+
+		resp := s.srv.GetCategory(req)
+		require.NotNil(t, resp)
+		require.False(t, resp.Cached)
+	})
+
+	t.Run("GetCategory error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetCategoryRequest{}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.categoryStorageMock.EXPECT().GetCategory(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetCategory(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetCategoriesTree(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetCategoriesTree cached ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetCategoriesTreeRequest{}
+
+		getCached := func(key string, v any) (bool, error) {
+			target := v.(**ds.GetCategoriesTreeResponse)
+			*target = &ds.GetCategoriesTreeResponse{
+				Categories: []ds.CategoryNode{{Category: ds.Category{Name: "name"}}},
+			}
+			return true, nil
+		}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).DoAndReturn(getCached)
+
+		resp := s.srv.GetCategoriesTree(req)
+		require.NotNil(t, resp)
+		require.True(t, resp.Cached)
+		require.Equal(t, resp.Categories[0].Name, "name")
+	})
+
+	t.Run("GetCategoriesTree error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetCategoriesTreeRequest{}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.categoryStorageMock.EXPECT().GetCategoriesTree(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetCategoriesTree(req)
+		require.Nil(t, resp)
+	})
+}
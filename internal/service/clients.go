@@ -27,11 +27,7 @@ func (s *Service) AddClient(req *ds.AddClientRequest) *ds.AddClientResponse {
 }
 
 func (s *Service) DeleteClient(req *ds.DeleteClientRequest) *ds.DeleteClientResponse {
-	key := makeCacheKey("DeleteClient", req.Uid.String())
-
-	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.DeleteClientResponse, error) {
-		return s.clientStorage.DeleteClient(req)
-	})
+	resp, err := s.clientStorage.DeleteClient(req)
 	if err != nil {
 		s.logger.ErrorKV("failed on DeleteClient", "message", err.Error())
 		return nil
@@ -42,8 +38,21 @@ func (s *Service) DeleteClient(req *ds.DeleteClientRequest) *ds.DeleteClientResp
 	return resp
 }
 
+func (s *Service) RestoreClient(req *ds.RestoreClientRequest) *ds.RestoreClientResponse {
+	resp, err := s.clientStorage.RestoreClient(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on RestoreClient", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("RestoreClient", resp.GetStatus())
+
+	return resp
+}
+
 func (s *Service) GetClients(req *ds.GetClientsRequest) *ds.GetClientsResponse {
-	key := makeCacheKey("GetClients", strconv.FormatInt(req.Limit, 10), strconv.FormatInt(req.Offset, 10))
+	key := makeCacheKey("GetClients", strconv.FormatInt(req.Limit, 10), strconv.FormatInt(req.Offset, 10),
+		strconv.FormatBool(req.IncludeDeleted), asOfKey(req.AsOf))
 
 	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetClientsResponse, error) {
 		return s.clientStorage.GetClients(req)
@@ -57,7 +66,11 @@ func (s *Service) GetClients(req *ds.GetClientsRequest) *ds.GetClientsResponse {
 }
 
 func (s *Service) GetClientsByName(req *ds.GetClientsByNameRequest) *ds.GetClientsByNameResponse {
-	key := makeCacheKey("GetClientsByName", req.Name, req.Surname)
+	key := makeCacheKey("GetClientsByName", req.Name, req.Surname,
+		strconv.FormatBool(req.Transliterate),
+		strconv.FormatFloat(req.MinScore, 'f', -1, 64),
+		strconv.FormatInt(req.Limit, 10), strconv.FormatInt(req.Offset, 10),
+		strconv.FormatBool(req.IncludeDeleted))
 
 	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetClientsByNameResponse, error) {
 		return s.clientStorage.GetClientsByName(req)
@@ -56,8 +56,6 @@ func TestDeleteClient(t *testing.T) {
 
 		res := &ds.DeleteClientResponse{}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
-		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
 		s.clientStorageMock.EXPECT().DeleteClient(gomock.Any()).Return(res, nil)
 
 		resp := s.srv.DeleteClient(req)
@@ -71,7 +69,6 @@ func TestDeleteClient(t *testing.T) {
 
 		req := &ds.DeleteClientRequest{}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
 		s.clientStorageMock.EXPECT().DeleteClient(gomock.Any()).Return(nil, errTest)
 		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
 
@@ -80,6 +77,42 @@ func TestDeleteClient(t *testing.T) {
 	})
 }
 
+func TestRestoreClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RestoreClient ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.RestoreClientRequest{}
+
+		res := &ds.RestoreClientResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.clientStorageMock.EXPECT().RestoreClient(gomock.Any()).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RestoreClient(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("RestoreClient error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.RestoreClientRequest{}
+
+		s.clientStorageMock.EXPECT().RestoreClient(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RestoreClient(req)
+		require.Nil(t, resp)
+	})
+}
+
 func TestGetClients(t *testing.T) {
 	t.Parallel()
 
@@ -151,8 +184,8 @@ func TestGetClientsByName(t *testing.T) {
 		getCached := func(key string, v any) (bool, error) {
 			targetPtr := v.(**ds.GetClientsByNameResponse)
 			mockResp := &ds.GetClientsByNameResponse{
-				Clients: []ds.Client{
-					{Name: name, Surname: surname},
+				Clients: []ds.ClientMatch{
+					{Client: ds.Client{Name: name, Surname: surname}},
 				},
 			}
 
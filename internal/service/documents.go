@@ -0,0 +1,65 @@
+package service
+
+import (
+	ds "shopapi/internal/datastruct"
+)
+
+// AddProductDocument isn't cached, the streamed document can't be keyed before it is stored.
+func (s *Service) AddProductDocument(req *ds.AddProductDocumentRequest) *ds.AddProductDocumentResponse {
+	resp, err := s.productStorage.AddProductDocument(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on AddProductDocument", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("AddProductDocument", resp.GetStatus())
+
+	return resp
+}
+
+// GetProductDocument streams the document without caching.
+func (s *Service) GetProductDocument(req *ds.GetProductDocumentRequest) *ds.GetProductDocumentResponse {
+	resp, err := s.productStorage.GetProductDocument(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on GetProductDocument", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetProductDocument", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) GetProductDocuments(req *ds.GetProductDocumentsRequest) *ds.GetProductDocumentsResponse {
+	key := makeCacheKey("GetProductDocuments", req.ProductUid.String())
+
+	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetProductDocumentsResponse, error) {
+		return s.productStorage.GetProductDocuments(req)
+	})
+
+	if err != nil {
+		s.logger.ErrorKV("failed on GetProductDocuments", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetProductDocuments", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) DeleteProductDocument(req *ds.DeleteProductDocumentRequest) *ds.DeleteProductDocumentResponse {
+	key := makeCacheKey("DeleteProductDocument", req.Uid.String())
+
+	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.DeleteProductDocumentResponse, error) {
+		return s.productStorage.DeleteProductDocument(req)
+	})
+
+	if err != nil {
+		s.logger.ErrorKV("failed on DeleteProductDocument", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("DeleteProductDocument", resp.GetStatus())
+
+	return resp
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"bytes"
+	"io"
+	ds "shopapi/internal/datastruct"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddProductDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddProductDocument ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		uid := uuid.New()
+		req := &ds.AddProductDocumentRequest{ProductUid: uuid.New()}
+
+		s.productStorageMock.EXPECT().AddProductDocument(req).Return(&ds.AddProductDocumentResponse{Uid: &uid}, nil)
+
+		resp := s.srv.AddProductDocument(req)
+		require.NotNil(t, resp)
+		require.Equal(t, &uid, resp.Uid)
+	})
+
+	t.Run("AddProductDocument error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.AddProductDocumentRequest{}
+
+		s.productStorageMock.EXPECT().AddProductDocument(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.AddProductDocument(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetProductDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductDocument ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetProductDocumentRequest{Uid: uuid.New()}
+
+		// The streamed document is returned as it is, without the cache.
+		content := io.NopCloser(bytes.NewReader([]byte("%PDF-1.7")))
+		res := &ds.GetProductDocumentResponse{
+			DocumentContent: ds.DocumentContent{Content: content},
+		}
+
+		s.productStorageMock.EXPECT().GetProductDocument(req).Return(res, nil)
+
+		resp := s.srv.GetProductDocument(req)
+		require.NotNil(t, resp)
+		require.Equal(t, content, resp.Content)
+	})
+
+	t.Run("GetProductDocument error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetProductDocumentRequest{}
+
+		s.productStorageMock.EXPECT().GetProductDocument(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetProductDocument(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetProductDocuments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductDocuments ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetProductDocumentsRequest{ProductUid: uuid.New()}
+
+		res := &ds.GetProductDocumentsResponse{
+			Documents: []ds.ProductDocument{{Uid: uuid.New(), Title: "Spec sheet"}},
+		}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.productStorageMock.EXPECT().GetProductDocuments(req).Return(res, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp := s.srv.GetProductDocuments(req)
+		require.NotNil(t, resp)
+		require.Equal(t, res.Documents, resp.Documents)
+	})
+
+	t.Run("GetProductDocuments error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetProductDocumentsRequest{}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.productStorageMock.EXPECT().GetProductDocuments(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetProductDocuments(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestDeleteProductDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteProductDocument ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.DeleteProductDocumentRequest{}
+
+		res := &ds.DeleteProductDocumentResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+		s.productStorageMock.EXPECT().DeleteProductDocument(gomock.Any()).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.DeleteProductDocument(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("DeleteProductDocument error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.DeleteProductDocumentRequest{}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.productStorageMock.EXPECT().DeleteProductDocument(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.DeleteProductDocument(req)
+		require.Nil(t, resp)
+	})
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	ds "shopapi/internal/datastruct"
+)
+
+// GetProductHistory bypasses the cache, every change of the product adds a version.
+func (s *Service) GetProductHistory(req *ds.GetHistoryRequest) *ds.GetProductHistoryResponse {
+	resp, err := s.productStorage.GetProductHistory(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on GetProductHistory", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetProductHistory", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) GetSupplierHistory(req *ds.GetHistoryRequest) *ds.GetSupplierHistoryResponse {
+	resp, err := s.supplierStorage.GetSupplierHistory(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on GetSupplierHistory", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetSupplierHistory", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) GetClientHistory(req *ds.GetHistoryRequest) *ds.GetClientHistoryResponse {
+	resp, err := s.clientStorage.GetClientHistory(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on GetClientHistory", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetClientHistory", resp.GetStatus())
+
+	return resp
+}
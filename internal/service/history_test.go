@@ -0,0 +1,99 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	ds "shopapi/internal/datastruct"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductHistory ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetHistoryRequest{Uid: uuid.New()}
+		res := &ds.GetProductHistoryResponse{Versions: []ds.ProductVersion{{Version: ds.Version{Version: 2}}}}
+
+		s.productStorageMock.EXPECT().GetProductHistory(req).Return(res, nil)
+
+		resp := s.srv.GetProductHistory(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("GetProductHistory error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.productStorageMock.EXPECT().GetProductHistory(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetProductHistory(&ds.GetHistoryRequest{})
+		require.Nil(t, resp)
+	})
+
+	t.Run("GetSupplierHistory not found", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		res := &ds.GetSupplierHistoryResponse{Status: ds.Status{Message: ds.StatusNotFound}}
+
+		s.supplierStorageMock.EXPECT().GetSupplierHistory(gomock.Any()).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetSupplierHistory(&ds.GetHistoryRequest{Uid: uuid.New()})
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("GetClientHistory error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.clientStorageMock.EXPECT().GetClientHistory(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetClientHistory(&ds.GetHistoryRequest{})
+		require.Nil(t, resp)
+	})
+}
+
+func TestAsOfCacheKey(t *testing.T) {
+	t.Parallel()
+
+	uid := uuid.New()
+	asOf := time.Date(2025, 1, 1, 3, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+
+	t.Run("GetProduct as of has its own cache key", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetProductRequest{AsOfFlag: ds.AsOfFlag{AsOf: &asOf}, Uid: uid}
+		key := makeCacheKey("GetProduct", uid.String(), "false", "2025-01-01T00:00:00Z", "")
+
+		s.cacheMock.EXPECT().Read(key, gomock.Any()).Return(false, nil)
+		s.productStorageMock.EXPECT().GetProduct(req).Return(&ds.GetProductResponse{}, nil)
+		s.cacheMock.EXPECT().Write(key, gomock.Any()).Return(nil)
+
+		resp := s.srv.GetProduct(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("GetSupplier now and as of differ", func(t *testing.T) {
+		t.Parallel()
+
+		now := makeCacheKey("GetSupplier", uid.String(), "false", asOfKey(nil))
+		past := makeCacheKey("GetSupplier", uid.String(), "false", asOfKey(&asOf))
+		require.NotEqual(t, now, past)
+	})
+}
@@ -1,16 +1,16 @@
 package service
 
 import (
+	"errors"
+	"io"
 	ds "shopapi/internal/datastruct"
-	"shopapi/internal/supports"
+	"shopapi/internal/thumbnail"
+	"strconv"
 )
 
+// AddImage isn't cached, the streamed image can't be keyed before it is stored.
 func (s *Service) AddImage(req *ds.AddImageRequest) *ds.AddImageResponse {
-	key := makeCacheKey("AddImage", req.Uid.String(), supports.GetHash(req.Image))
-
-	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.AddImageResponse, error) {
-		return s.imageStorage.AddImage(req)
-	})
+	resp, err := s.imageStorage.AddImage(req)
 	if err != nil {
 		s.logger.ErrorKV("failed on AddImage", "message", err.Error())
 		return nil
@@ -19,13 +19,9 @@ func (s *Service) AddImage(req *ds.AddImageRequest) *ds.AddImageResponse {
 	return resp
 }
 
+// UpdateImage isn't cached, the streamed image can't be keyed before it is stored.
 func (s *Service) UpdateImage(req *ds.UpdateImageRequest) *ds.UpdateImageResponse {
-	key := makeCacheKey("UpdateImage", req.Uid.String(), supports.GetHash(req.Image))
-
-	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.UpdateImageResponse, error) {
-		return s.imageStorage.UpdateImage(req)
-	})
-
+	resp, err := s.imageStorage.UpdateImage(req)
 	if err != nil {
 		s.logger.ErrorKV("failed on UpdateImage", "message", err.Error())
 		return nil
@@ -37,12 +33,7 @@ func (s *Service) UpdateImage(req *ds.UpdateImageRequest) *ds.UpdateImageRespons
 }
 
 func (s *Service) DeleteImage(req *ds.DeleteImageRequest) *ds.DeleteImageResponse {
-	key := makeCacheKey("DeleteImage", req.Uid.String())
-
-	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.DeleteImageResponse, error) {
-		return s.imageStorage.DeleteImage(req)
-	})
-
+	resp, err := s.imageStorage.DeleteImage(req)
 	if err != nil {
 		s.logger.ErrorKV("failed on DeleteImage", "message", err.Error())
 		return nil
@@ -53,12 +44,34 @@ func (s *Service) DeleteImage(req *ds.DeleteImageRequest) *ds.DeleteImageRespons
 	return resp
 }
 
-func (s *Service) GetProductImage(req *ds.GetProductImageRequest) (resp *ds.GetProductImageResponse) {
-	key := makeCacheKey("GetProductImage", req.ProductUid.String())
+func (s *Service) RestoreImage(req *ds.RestoreImageRequest) *ds.RestoreImageResponse {
+	resp, err := s.imageStorage.RestoreImage(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on RestoreImage", "message", err.Error())
+		return nil
+	}
 
-	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetProductImageResponse, error) {
-		return s.imageStorage.GetProductImage(req)
-	})
+	s.logHandlerStatus("RestoreImage", resp.GetStatus())
+
+	return resp
+}
+
+// GetProductImage streams the original image without caching, only resized variants are cached.
+func (s *Service) GetProductImage(req *ds.GetProductImageRequest) (resp *ds.GetProductImageResponse) {
+	var err error
+	if req.IsOriginal() {
+		resp, err = s.imageStorage.GetProductImage(req)
+	} else {
+		key := makeCacheKey("GetProductImage", req.ProductUid.String(), variantCacheKey(&req.ImageVariant))
+
+		resp, err = execWithCache(s, key, req.AvoidCache(), func() (*ds.GetProductImageResponse, error) {
+			resp, err := s.imageStorage.GetProductImage(req)
+			if err != nil || resp.Content == nil {
+				return resp, err
+			}
+			return resp, loadVariant(&req.ImageVariant, &resp.ImageContent, &resp.Status)
+		})
+	}
 
 	if err != nil {
 		s.logger.ErrorKV("failed on GetProductImage", "message", err.Error())
@@ -70,12 +83,22 @@ func (s *Service) GetProductImage(req *ds.GetProductImageRequest) (resp *ds.GetP
 	return
 }
 
+// GetImage streams the original image without caching, only resized variants are cached.
 func (s *Service) GetImage(req *ds.GetImageRequest) (resp *ds.GetImageResponse) {
-	key := makeCacheKey("GetImage", req.Uid.String())
-
-	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetImageResponse, error) {
-		return s.imageStorage.GetImage(req)
-	})
+	var err error
+	if req.IsOriginal() {
+		resp, err = s.imageStorage.GetImage(req)
+	} else {
+		key := makeCacheKey("GetImage", req.Uid.String(), variantCacheKey(&req.ImageVariant), strconv.FormatBool(req.IncludeDeleted))
+
+		resp, err = execWithCache(s, key, req.AvoidCache(), func() (*ds.GetImageResponse, error) {
+			resp, err := s.imageStorage.GetImage(req)
+			if err != nil || resp.Content == nil {
+				return resp, err
+			}
+			return resp, loadVariant(&req.ImageVariant, &resp.ImageContent, &resp.Status)
+		})
+	}
 
 	if err != nil {
 		s.logger.ErrorKV("failed on GetImage", "message", err.Error())
@@ -86,3 +109,95 @@ func (s *Service) GetImage(req *ds.GetImageRequest) (resp *ds.GetImageResponse)
 
 	return
 }
+
+func (s *Service) GetImageMeta(req *ds.GetImageMetaRequest) *ds.GetImageMetaResponse {
+	key := makeCacheKey("GetImageMeta", req.Uid.String(), strconv.FormatBool(req.IncludeDeleted))
+
+	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetImageMetaResponse, error) {
+		return s.imageStorage.GetImageMeta(req)
+	})
+
+	if err != nil {
+		s.logger.ErrorKV("failed on GetImageMeta", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetImageMeta", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) AttachProductImage(req *ds.AttachProductImageRequest) *ds.AttachProductImageResponse {
+	resp, err := s.imageStorage.AttachProductImage(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on AttachProductImage", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("AttachProductImage", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) DetachProductImage(req *ds.DetachProductImageRequest) *ds.DetachProductImageResponse {
+	resp, err := s.imageStorage.DetachProductImage(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on DetachProductImage", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("DetachProductImage", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) ReorderProductImages(req *ds.ReorderProductImagesRequest) *ds.ReorderProductImagesResponse {
+	resp, err := s.imageStorage.ReorderProductImages(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on ReorderProductImages", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("ReorderProductImages", resp.GetStatus())
+
+	return resp
+}
+
+func variantCacheKey(variant *ds.ImageVariant) string {
+	if variant.IsOriginal() {
+		return ""
+	}
+	return makeCacheKey(strconv.Itoa(variant.Width), strconv.Itoa(variant.Height), variant.Fit, variant.Format)
+}
+
+// loadVariant reads the streamed content and replaces it by the requested variant. An image that can't
+// be resized is reported by the status, so the original isn't returned instead of the variant.
+func loadVariant(variant *ds.ImageVariant, content *ds.ImageContent, status *ds.Status) error {
+	data, err := io.ReadAll(content.Content)
+	err = errors.Join(err, content.Content.Close())
+	content.Content = nil
+	if err != nil {
+		return err
+	}
+
+	data, err = thumbnail.Resize(data, thumbnail.Options{
+		Width:  variant.Width,
+		Height: variant.Height,
+		Fit:    variant.Fit,
+		Format: variant.Format,
+	})
+	if err != nil {
+		if !errors.Is(err, thumbnail.ErrUnsupported) {
+			return err
+		}
+		status.Message = ds.StatusImageNotResizable
+		return nil
+	}
+
+	// The variant keeps the modification time of the original, its hash differs though.
+	content.Image = data
+	content.Size = int64(len(data))
+	content.Hash = ""
+
+	return nil
+}
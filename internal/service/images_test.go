@@ -1,9 +1,12 @@
 package service
 
 import (
+	"bytes"
+	"io"
 	ds "shopapi/internal/datastruct"
 	"shopapi/internal/supports"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -22,8 +25,6 @@ func TestAddImage(t *testing.T) {
 
 		res := &ds.AddImageResponse{}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
-		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
 		s.imageStorageMock.EXPECT().AddImage(gomock.Any()).Return(res, nil)
 
 		resp := s.srv.AddImage(req)
@@ -37,7 +38,6 @@ func TestAddImage(t *testing.T) {
 
 		req := &ds.AddImageRequest{}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
 		s.imageStorageMock.EXPECT().AddImage(gomock.Any()).Return(nil, errTest)
 		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
 
@@ -60,8 +60,6 @@ func TestUpdateImage(t *testing.T) {
 			Status: ds.Status{Message: "status"},
 		}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
-		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
 		s.imageStorageMock.EXPECT().UpdateImage(gomock.Any()).Return(res, nil)
 		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
 
@@ -76,7 +74,6 @@ func TestUpdateImage(t *testing.T) {
 
 		req := &ds.UpdateImageRequest{}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
 		s.imageStorageMock.EXPECT().UpdateImage(gomock.Any()).Return(nil, errTest)
 		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
 
@@ -99,8 +96,6 @@ func TestDeleteImage(t *testing.T) {
 			Status: ds.Status{Message: "status"},
 		}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
-		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
 		s.imageStorageMock.EXPECT().DeleteImage(gomock.Any()).Return(res, nil)
 		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
 
@@ -115,7 +110,6 @@ func TestDeleteImage(t *testing.T) {
 
 		req := &ds.DeleteImageRequest{}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
 		s.imageStorageMock.EXPECT().DeleteImage(gomock.Any()).Return(nil, errTest)
 		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
 
@@ -124,6 +118,81 @@ func TestDeleteImage(t *testing.T) {
 	})
 }
 
+func TestRestoreImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RestoreImage ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.RestoreImageRequest{}
+
+		res := &ds.RestoreImageResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.imageStorageMock.EXPECT().RestoreImage(gomock.Any()).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RestoreImage(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("RestoreImage error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.RestoreImageRequest{}
+
+		s.imageStorageMock.EXPECT().RestoreImage(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RestoreImage(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetImageMeta(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetImageMeta ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetImageMetaRequest{Uid: uuid.New()}
+
+		res := &ds.GetImageMetaResponse{
+			Meta: &ds.ImageMeta{Uid: req.Uid, Format: "png"},
+		}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.imageStorageMock.EXPECT().GetImageMeta(req).Return(res, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp := s.srv.GetImageMeta(req)
+		require.NotNil(t, resp)
+		require.Equal(t, res.Meta, resp.Meta)
+	})
+
+	t.Run("GetImageMeta error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetImageMetaRequest{}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.imageStorageMock.EXPECT().GetImageMeta(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetImageMeta(req)
+		require.Nil(t, resp)
+	})
+}
+
 func TestGetProductImage(t *testing.T) {
 	t.Parallel()
 
@@ -132,7 +201,7 @@ func TestGetProductImage(t *testing.T) {
 
 		s := NewTestService(t)
 
-		req := &ds.GetProductImageRequest{}
+		req := &ds.GetProductImageRequest{ImageVariant: ds.ImageVariant{Width: 200}}
 
 		res := &ds.GetProductImageResponse{
 			Status: ds.Status{Message: "status"},
@@ -157,6 +226,7 @@ func TestGetProductImage(t *testing.T) {
 
 		req := &ds.GetProductImageRequest{
 			AvoidCacheFlag: ds.AvoidCacheFlag{Flag: true},
+			ImageVariant:   ds.ImageVariant{Width: 200},
 		}
 
 		res := &ds.GetProductImageResponse{
@@ -177,7 +247,7 @@ func TestGetProductImage(t *testing.T) {
 
 		s := NewTestService(t)
 
-		req := &ds.GetProductImageRequest{}
+		req := &ds.GetProductImageRequest{ImageVariant: ds.ImageVariant{Width: 200}}
 
 		uid := uuid.New()
 		cached := true
@@ -206,7 +276,7 @@ func TestGetProductImage(t *testing.T) {
 
 		s := NewTestService(t)
 
-		req := &ds.GetProductImageRequest{}
+		req := &ds.GetProductImageRequest{ImageVariant: ds.ImageVariant{Width: 200}}
 
 		res := &ds.GetProductImageResponse{
 			Status: ds.Status{Message: "status"},
@@ -228,7 +298,7 @@ func TestGetProductImage(t *testing.T) {
 
 		s := NewTestService(t)
 
-		req := &ds.GetProductImageRequest{}
+		req := &ds.GetProductImageRequest{ImageVariant: ds.ImageVariant{Width: 200}}
 
 		res := &ds.GetProductImageResponse{
 			Status: ds.Status{Message: "status"},
@@ -258,6 +328,25 @@ func TestGetProductImage(t *testing.T) {
 		resp := s.srv.GetProductImage(req)
 		require.Nil(t, resp)
 	})
+
+	t.Run("GetProductImage original not cached", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetProductImageRequest{}
+
+		res := &ds.GetProductImageResponse{
+			ImageContent: ds.ImageContent{Content: io.NopCloser(bytes.NewReader(supports.TestImage))},
+		}
+
+		s.imageStorageMock.EXPECT().GetProductImage(gomock.Any()).Return(res, nil)
+
+		resp := s.srv.GetProductImage(req)
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Content)
+		require.Nil(t, resp.Image)
+	})
 }
 
 func TestGetImage(t *testing.T) {
@@ -268,7 +357,7 @@ func TestGetImage(t *testing.T) {
 
 		s := NewTestService(t)
 
-		req := &ds.GetImageRequest{}
+		req := &ds.GetImageRequest{ImageVariant: ds.ImageVariant{Width: 200}}
 
 		res := &ds.GetImageResponse{
 			Status: ds.Status{Message: "status"},
@@ -291,7 +380,7 @@ func TestGetImage(t *testing.T) {
 
 		s := NewTestService(t)
 
-		req := &ds.GetImageRequest{AvoidCacheFlag: ds.AvoidCacheFlag{Flag: true}}
+		req := &ds.GetImageRequest{AvoidCacheFlag: ds.AvoidCacheFlag{Flag: true}, ImageVariant: ds.ImageVariant{Width: 200}}
 
 		res := &ds.GetImageResponse{
 			Status: ds.Status{Message: "status"},
@@ -311,7 +400,7 @@ func TestGetImage(t *testing.T) {
 
 		s := NewTestService(t)
 
-		req := &ds.GetImageRequest{}
+		req := &ds.GetImageRequest{ImageVariant: ds.ImageVariant{Width: 200}}
 
 		uid := uuid.New()
 		cached := true
@@ -340,7 +429,7 @@ func TestGetImage(t *testing.T) {
 
 		s := NewTestService(t)
 
-		req := &ds.GetImageRequest{}
+		req := &ds.GetImageRequest{ImageVariant: ds.ImageVariant{Width: 200}}
 
 		res := &ds.GetImageResponse{
 			Status: ds.Status{Message: "status"},
@@ -362,7 +451,7 @@ func TestGetImage(t *testing.T) {
 
 		s := NewTestService(t)
 
-		req := &ds.GetImageRequest{}
+		req := &ds.GetImageRequest{ImageVariant: ds.ImageVariant{Width: 200}}
 
 		res := &ds.GetImageResponse{
 			Status: ds.Status{Message: "status"},
@@ -384,7 +473,7 @@ func TestGetImage(t *testing.T) {
 
 		s := NewTestService(t)
 
-		req := &ds.GetImageRequest{AvoidCacheFlag: ds.AvoidCacheFlag{Flag: true}}
+		req := &ds.GetImageRequest{AvoidCacheFlag: ds.AvoidCacheFlag{Flag: true}, ImageVariant: ds.ImageVariant{Width: 200}}
 
 		s.imageStorageMock.EXPECT().GetImage(gomock.Any()).Return(nil, errTest)
 		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
@@ -392,4 +481,212 @@ func TestGetImage(t *testing.T) {
 		resp := s.srv.GetImage(req)
 		require.Nil(t, resp)
 	})
+	t.Run("GetImage resized ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetImageRequest{ImageVariant: ds.ImageVariant{Width: 200, Height: 200, Format: "png"}}
+
+		modified := time.Now()
+		res := &ds.GetImageResponse{
+			ImageContent: ds.ImageContent{
+				Content:    io.NopCloser(bytes.NewReader(supports.TestImage)),
+				Hash:       "original",
+				ModifiedAt: modified,
+			},
+		}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.imageStorageMock.EXPECT().GetImage(gomock.Any()).Return(res, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp := s.srv.GetImage(req)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.Image[:4], []byte("\x89PNG"))
+
+		hash, modifiedAt := resp.Validators()
+		require.Empty(t, hash)
+		require.Equal(t, modified, modifiedAt)
+	})
+
+	t.Run("GetImage not resizable", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetImageRequest{ImageVariant: ds.ImageVariant{Width: 200}}
+
+		res := &ds.GetImageResponse{
+			ImageContent: ds.ImageContent{Content: io.NopCloser(bytes.NewReader([]byte("not an image")))},
+		}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.imageStorageMock.EXPECT().GetImage(gomock.Any()).Return(res, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetImage(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Image)
+		require.Nil(t, resp.Content)
+		require.Equal(t, resp.GetStatus(), ds.StatusImageNotResizable)
+	})
+
+	t.Run("GetImage original not cached", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetImageRequest{}
+
+		res := &ds.GetImageResponse{
+			ImageContent: ds.ImageContent{Content: io.NopCloser(bytes.NewReader(supports.TestImage))},
+		}
+
+		s.imageStorageMock.EXPECT().GetImage(gomock.Any()).Return(res, nil)
+
+		resp := s.srv.GetImage(req)
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.Content)
+		require.Nil(t, resp.Image)
+	})
+}
+
+func TestAttachProductImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AttachProductImage ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.AttachProductImageRequest{ProductUid: uuid.New(), ImageUid: uuid.New(), Primary: true}
+
+		res := &ds.AttachProductImageResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+
+		s.imageStorageMock.EXPECT().AttachProductImage(req).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.AttachProductImage(req)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusOK)
+	})
+
+	t.Run("AttachProductImage again after detach", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestServiceWithCache(t)
+
+		productUid, imageUid := uuid.New(), uuid.New()
+		attach := &ds.AttachProductImageRequest{ProductUid: productUid, ImageUid: imageUid}
+		detach := &ds.DetachProductImageRequest{ProductUid: productUid, ImageUid: imageUid}
+
+		gomock.InOrder(
+			s.imageStorageMock.EXPECT().AttachProductImage(attach).
+				Return(&ds.AttachProductImageResponse{Status: ds.Status{Message: ds.StatusOK}}, nil),
+			s.imageStorageMock.EXPECT().DetachProductImage(detach).
+				Return(&ds.DetachProductImageResponse{Status: ds.Status{Message: ds.StatusOK}}, nil),
+			s.imageStorageMock.EXPECT().AttachProductImage(attach).
+				Return(&ds.AttachProductImageResponse{Status: ds.Status{Message: ds.StatusOK}}, nil),
+			s.imageStorageMock.EXPECT().DetachProductImage(detach).
+				Return(&ds.DetachProductImageResponse{Status: ds.Status{Message: ds.StatusOK}}, nil),
+		)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All()).Times(4)
+
+		require.Equal(t, ds.StatusOK, s.srv.AttachProductImage(attach).GetStatus())
+		require.Equal(t, ds.StatusOK, s.srv.DetachProductImage(detach).GetStatus())
+		require.Equal(t, ds.StatusOK, s.srv.AttachProductImage(attach).GetStatus())
+		require.Equal(t, ds.StatusOK, s.srv.DetachProductImage(detach).GetStatus())
+	})
+
+	t.Run("AttachProductImage error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.AttachProductImageRequest{ProductUid: uuid.New(), ImageUid: uuid.New(), Primary: true}
+
+		s.imageStorageMock.EXPECT().AttachProductImage(req).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.AttachProductImage(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestDetachProductImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DetachProductImage ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.DetachProductImageRequest{ProductUid: uuid.New(), ImageUid: uuid.New()}
+
+		res := &ds.DetachProductImageResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+
+		s.imageStorageMock.EXPECT().DetachProductImage(req).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.DetachProductImage(req)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusOK)
+	})
+
+	t.Run("DetachProductImage error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.DetachProductImageRequest{ProductUid: uuid.New(), ImageUid: uuid.New()}
+
+		s.imageStorageMock.EXPECT().DetachProductImage(req).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.DetachProductImage(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestReorderProductImages(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ReorderProductImages ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.ReorderProductImagesRequest{ProductUid: uuid.New(), ImageUids: []uuid.UUID{uuid.New(), uuid.New()}}
+
+		res := &ds.ReorderProductImagesResponse{
+			Status: ds.Status{Message: ds.StatusOK},
+		}
+
+		s.imageStorageMock.EXPECT().ReorderProductImages(req).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.ReorderProductImages(req)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.GetStatus(), ds.StatusOK)
+	})
+
+	t.Run("ReorderProductImages error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.ReorderProductImagesRequest{ProductUid: uuid.New(), ImageUids: []uuid.UUID{uuid.New(), uuid.New()}}
+
+		s.imageStorageMock.EXPECT().ReorderProductImages(req).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.ReorderProductImages(req)
+		require.Nil(t, resp)
+	})
 }
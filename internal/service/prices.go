@@ -0,0 +1,54 @@
+package service
+
+import (
+	ds "shopapi/internal/datastruct"
+)
+
+func (s *Service) SetVariantPrice(req *ds.SetVariantPriceRequest) *ds.SetVariantPriceResponse {
+	resp, err := s.productStorage.SetVariantPrice(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on SetVariantPrice", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("SetVariantPrice", resp.GetStatus())
+
+	return resp
+}
+
+// GetVariantPrices bypasses the cache, so a price just set is listed right away.
+func (s *Service) GetVariantPrices(req *ds.GetVariantPricesRequest) *ds.GetVariantPricesResponse {
+	resp, err := s.productStorage.GetVariantPrices(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on GetVariantPrices", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetVariantPrices", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) SetExchangeRate(req *ds.SetExchangeRateRequest) *ds.SetExchangeRateResponse {
+	resp, err := s.productStorage.SetExchangeRate(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on SetExchangeRate", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("SetExchangeRate", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) GetExchangeRates(req *ds.GetExchangeRatesRequest) *ds.GetExchangeRatesResponse {
+	resp, err := s.productStorage.GetExchangeRates(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on GetExchangeRates", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetExchangeRates", resp.GetStatus())
+
+	return resp
+}
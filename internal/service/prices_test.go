@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetVariantPrice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SetVariantPrice ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.SetVariantPriceRequest{VariantUid: uuid.New(), Price: money.New(24995, money.RUB)}
+		res := &ds.SetVariantPriceResponse{Id: 42}
+
+		s.productStorageMock.EXPECT().SetVariantPrice(req).Return(res, nil)
+
+		resp := s.srv.SetVariantPrice(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("SetVariantPrice error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.productStorageMock.EXPECT().SetVariantPrice(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.SetVariantPrice(&ds.SetVariantPriceRequest{})
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetVariantPrices(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetVariantPrices ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetVariantPricesRequest{VariantUid: uuid.New()}
+		res := &ds.GetVariantPricesResponse{Prices: []ds.VariantPrice{{Id: 1, Price: money.New(29995, money.RUB)}}}
+
+		s.productStorageMock.EXPECT().GetVariantPrices(req).Return(res, nil)
+
+		resp := s.srv.GetVariantPrices(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("GetVariantPrices error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.productStorageMock.EXPECT().GetVariantPrices(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetVariantPrices(&ds.GetVariantPricesRequest{})
+		require.Nil(t, resp)
+	})
+}
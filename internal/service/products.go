@@ -2,9 +2,11 @@ package service
 
 import (
 	"encoding/json"
+	"shopapi/internal/barcode"
 	ds "shopapi/internal/datastruct"
 	"shopapi/internal/supports"
 	"strconv"
+	"strings"
 )
 
 func (s *Service) AddProduct(req *ds.AddProductRequest) *ds.AddProductResponse {
@@ -43,7 +45,8 @@ func (s *Service) DecreaseProducts(req *ds.DecreaseProductsRequest) *ds.Decrease
 }
 
 func (s *Service) GetProduct(req *ds.GetProductRequest) (resp *ds.GetProductResponse) {
-	key := makeCacheKey("GetProduct", req.Uid.String())
+	key := makeCacheKey("GetProduct", req.Uid.String(), strconv.FormatBool(req.IncludeDeleted), asOfKey(req.AsOf),
+		req.Currency)
 
 	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetProductResponse, error) {
 		return s.productStorage.GetProduct(req)
@@ -59,8 +62,43 @@ func (s *Service) GetProduct(req *ds.GetProductRequest) (resp *ds.GetProductResp
 	return
 }
 
+func (s *Service) GetProductBySku(req *ds.GetProductBySkuRequest) (resp *ds.GetProductResponse) {
+	key := makeCacheKey("GetProductBySku", req.Sku, req.Currency)
+
+	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetProductResponse, error) {
+		return s.productStorage.GetProductBySku(req)
+	})
+
+	if err != nil {
+		s.logger.ErrorKV("failed on GetProductBySku", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetProductBySku", resp.GetStatus())
+
+	return
+}
+
+func (s *Service) GetProductByBarcode(req *ds.GetProductByBarcodeRequest) (resp *ds.GetProductResponse) {
+	key := makeCacheKey("GetProductByBarcode", req.Barcode, req.Currency)
+
+	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetProductResponse, error) {
+		return s.productStorage.GetProductByBarcode(req)
+	})
+
+	if err != nil {
+		s.logger.ErrorKV("failed on GetProductByBarcode", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetProductByBarcode", resp.GetStatus())
+
+	return
+}
+
 func (s *Service) GetProducts(req *ds.GetProductsRequest) *ds.GetProductsResponse {
-	key := makeCacheKey("GetProducts", strconv.FormatInt(req.Limit, 10), strconv.FormatInt(req.Offset, 10))
+	key := makeCacheKey("GetProducts", strconv.FormatInt(req.Limit, 10), strconv.FormatInt(req.Offset, 10),
+		req.CategoryUid.String(), strings.Join(req.Attributes, "&"), strconv.FormatBool(req.IncludeDeleted), req.Currency)
 
 	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetProductsResponse, error) {
 		return s.productStorage.GetProducts(req)
@@ -71,16 +109,35 @@ func (s *Service) GetProducts(req *ds.GetProductsRequest) *ds.GetProductsRespons
 		return nil
 	}
 
+	s.logHandlerStatus("GetProducts", resp.GetStatus())
+
 	return resp
 }
 
-func (s *Service) DeleteProduct(req *ds.DeleteProductRequest) *ds.DeleteProductResponse {
-	key := makeCacheKey("DeleteProduct", req.Uid.String())
+func (s *Service) UpdateProductAttributes(req *ds.UpdateProductAttributesRequest) *ds.UpdateProductAttributesResponse {
+	reqKey, err := json.Marshal(req.Attributes)
+	if err != nil {
+		s.logger.ErrorKV("failed making cache key", "message", err.Error(), "data", *req)
+		req.AvoidCacheFlag.Flag = true
+	}
+	key := makeCacheKey("UpdateProductAttributes", req.Uid.String(), supports.GetHash(reqKey))
 
-	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.DeleteProductResponse, error) {
-		return s.productStorage.DeleteProduct(req)
+	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.UpdateProductAttributesResponse, error) {
+		return s.productStorage.UpdateProductAttributes(req)
 	})
 
+	if err != nil {
+		s.logger.ErrorKV("failed on UpdateProductAttributes", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("UpdateProductAttributes", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) DeleteProduct(req *ds.DeleteProductRequest) *ds.DeleteProductResponse {
+	resp, err := s.productStorage.DeleteProduct(req)
 	if err != nil {
 		s.logger.ErrorKV("failed on DeleteProduct", "message", err.Error())
 		return nil
@@ -90,3 +147,81 @@ func (s *Service) DeleteProduct(req *ds.DeleteProductRequest) *ds.DeleteProductR
 
 	return resp
 }
+
+func (s *Service) RestoreProduct(req *ds.RestoreProductRequest) *ds.RestoreProductResponse {
+	resp, err := s.productStorage.RestoreProduct(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on RestoreProduct", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("RestoreProduct", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) AddProductVariant(req *ds.AddProductVariantRequest) *ds.AddProductVariantResponse {
+	reqKey, err := json.Marshal(req.ProductVariant)
+	if err != nil {
+		s.logger.ErrorKV("failed making cache key", "message", err.Error(), "data", *req)
+		req.AvoidCacheFlag.Flag = true
+	}
+	key := makeCacheKey("AddProductVariant", req.ProductUid.String(), supports.GetHash(reqKey))
+
+	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.AddProductVariantResponse, error) {
+		return s.productStorage.AddProductVariant(req)
+	})
+
+	if err != nil {
+		s.logger.ErrorKV("failed on AddProductVariant", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("AddProductVariant", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) DeleteProductVariant(req *ds.DeleteProductVariantRequest) *ds.DeleteProductVariantResponse {
+	resp, err := s.productStorage.DeleteProductVariant(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on DeleteProductVariant", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("DeleteProductVariant", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) GetBarcode(req *ds.GetBarcodeRequest) (resp *ds.GetBarcodeResponse) {
+	format := req.Format
+	if format == "" {
+		format = barcode.FormatPNG
+	}
+	scale := req.Scale
+	if scale == 0 {
+		scale = barcode.DefaultScale
+	}
+	height := req.Height
+	if height == 0 {
+		height = barcode.DefaultHeight
+	}
+
+	key := makeCacheKey("GetBarcode", req.Code, format, strconv.Itoa(scale), strconv.Itoa(height))
+
+	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetBarcodeResponse, error) {
+		image, err := barcode.Render(req.Code, format, scale, height)
+		if err != nil {
+			return nil, err
+		}
+		return &ds.GetBarcodeResponse{Image: image}, nil
+	})
+
+	if err != nil {
+		s.logger.ErrorKV("failed on GetBarcode", "message", err.Error())
+		return nil
+	}
+
+	return
+}
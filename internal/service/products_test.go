@@ -3,8 +3,10 @@ package service
 import (
 	ds "shopapi/internal/datastruct"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -109,6 +111,26 @@ func TestGetProduct(t *testing.T) {
 		require.False(t, resp.Cached)
 	})
 
+	t.Run("GetProduct cached until the price change", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		next := time.Now().Add(time.Minute)
+		later := next.Add(time.Hour)
+		res := &ds.GetProductResponse{Product: &ds.Product{Variants: []ds.ProductVariant{
+			{NextPriceChange: &later}, {}, {NextPriceChange: &next},
+		}}}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.productStorageMock.EXPECT().GetProduct(gomock.Any()).Return(res, nil)
+		s.cacheMock.EXPECT().WriteUntil(gomock.Any(), res, next).Return(nil)
+
+		resp := s.srv.GetProduct(&ds.GetProductRequest{})
+		require.NotNil(t, resp)
+		require.False(t, resp.Cached)
+	})
+
 	t.Run("GetProduct cached ok", func(t *testing.T) {
 		t.Parallel()
 
@@ -119,13 +141,13 @@ func TestGetProduct(t *testing.T) {
 		cached := true
 
 		name := "name"
-		category := "category"
+		categoryUid := uuid.New()
 		getCached := func(key string, v any) (bool, error) {
 			target := v.(**ds.GetProductResponse)
 			vv := &ds.GetProductResponse{}
 			vv.Product = &ds.Product{
-				Name:     name,
-				Category: category,
+				Name:        name,
+				CategoryUid: categoryUid,
 			}
 			vv.Status = ds.Status{Message: "status"}
 
@@ -140,7 +162,7 @@ func TestGetProduct(t *testing.T) {
 		require.NotNil(t, resp)
 		require.True(t, resp.Cached)
 		require.Equal(t, resp.Product.Name, name)
-		require.Equal(t, resp.Product.Category, category)
+		require.Equal(t, resp.Product.CategoryUid, categoryUid)
 	})
 
 	t.Run("GetProduct avoid cache ok", func(t *testing.T) {
@@ -222,6 +244,102 @@ func TestGetProduct(t *testing.T) {
 	})
 }
 
+func TestGetProductBySku(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductBySku ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetProductBySkuRequest{Sku: "BEAM-OAK-2M"}
+
+		res := &ds.GetProductResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.productStorageMock.EXPECT().GetProductBySku(req).Return(res, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetProductBySku(req)
+		require.NotNil(t, resp)
+		require.False(t, resp.Cached)
+	})
+
+	t.Run("GetProductBySku error on storage", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetProductBySkuRequest{Sku: "BEAM-OAK-2M", AvoidCacheFlag: ds.AvoidCacheFlag{Flag: true}}
+
+		s.productStorageMock.EXPECT().GetProductBySku(req).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetProductBySku(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetProductByBarcode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetProductByBarcode ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetProductByBarcodeRequest{Barcode: "4006381333931"}
+
+		res := &ds.GetProductResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.productStorageMock.EXPECT().GetProductByBarcode(req).Return(res, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetProductByBarcode(req)
+		require.NotNil(t, resp)
+		require.False(t, resp.Cached)
+	})
+}
+
+func TestGetBarcode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetBarcode ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetBarcodeRequest{Code: "4006381333931"}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+
+		resp := s.srv.GetBarcode(req)
+		require.NotNil(t, resp)
+		require.Equal(t, resp.Image[:4], []byte("\x89PNG"))
+	})
+
+	t.Run("GetBarcode error on invalid code", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetBarcodeRequest{Code: "4006381333932", Format: "svg", AvoidCacheFlag: ds.AvoidCacheFlag{Flag: true}}
+
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetBarcode(req)
+		require.Nil(t, resp)
+	})
+}
+
 func TestGetProducts(t *testing.T) {
 	t.Parallel()
 
@@ -272,8 +390,6 @@ func TestDeleteProduct(t *testing.T) {
 			Status: ds.Status{Message: "status"},
 		}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
-		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
 		s.productStorageMock.EXPECT().DeleteProduct(gomock.Any()).Return(res, nil)
 		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
 
@@ -288,7 +404,6 @@ func TestDeleteProduct(t *testing.T) {
 
 		req := &ds.DeleteProductRequest{}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
 		s.productStorageMock.EXPECT().DeleteProduct(gomock.Any()).Return(nil, errTest)
 		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
 
@@ -296,3 +411,168 @@ func TestDeleteProduct(t *testing.T) {
 		require.Nil(t, resp)
 	})
 }
+
+func TestRestoreProduct(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RestoreProduct ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.RestoreProductRequest{}
+
+		res := &ds.RestoreProductResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.productStorageMock.EXPECT().RestoreProduct(gomock.Any()).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RestoreProduct(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("RestoreProduct error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.RestoreProductRequest{}
+
+		s.productStorageMock.EXPECT().RestoreProduct(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RestoreProduct(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestAddProductVariant(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddProductVariant ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.AddProductVariantRequest{}
+
+		res := &ds.AddProductVariantResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+		s.productStorageMock.EXPECT().AddProductVariant(gomock.Any()).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.AddProductVariant(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("AddProductVariant error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.AddProductVariantRequest{}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.productStorageMock.EXPECT().AddProductVariant(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.AddProductVariant(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestDeleteProductVariant(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteProductVariant ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.DeleteProductVariantRequest{}
+
+		res := &ds.DeleteProductVariantResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.productStorageMock.EXPECT().DeleteProductVariant(gomock.Any()).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.DeleteProductVariant(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("DeleteProductVariant repeated", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestServiceWithCache(t)
+
+		req := &ds.DeleteProductVariantRequest{}
+		res := &ds.DeleteProductVariantResponse{Status: ds.Status{Message: ds.StatusOK}}
+
+		s.productStorageMock.EXPECT().DeleteProductVariant(req).Return(res, nil).Times(2)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All()).Times(2)
+
+		require.NotNil(t, s.srv.DeleteProductVariant(req))
+		require.NotNil(t, s.srv.DeleteProductVariant(req))
+	})
+
+	t.Run("DeleteProductVariant error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.DeleteProductVariantRequest{}
+
+		s.productStorageMock.EXPECT().DeleteProductVariant(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.DeleteProductVariant(req)
+		require.Nil(t, resp)
+	})
+}
+
+func TestUpdateProductAttributes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UpdateProductAttributes ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.UpdateProductAttributesRequest{}
+
+		res := &ds.UpdateProductAttributesResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+		s.productStorageMock.EXPECT().UpdateProductAttributes(gomock.Any()).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.UpdateProductAttributes(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("UpdateProductAttributes error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.UpdateProductAttributesRequest{}
+
+		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
+		s.productStorageMock.EXPECT().UpdateProductAttributes(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.UpdateProductAttributes(req)
+		require.Nil(t, resp)
+	})
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"slices"
+	"time"
+
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+
+	"github.com/google/uuid"
+)
+
+// The promotions bypass the cache, a changed promotion applies to the next basket.
+
+func (s *Service) AddPromotion(req *ds.AddPromotionRequest) *ds.AddPromotionResponse {
+	resp, err := s.promotionStorage.AddPromotion(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on AddPromotion", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("AddPromotion", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) UpdatePromotion(req *ds.UpdatePromotionRequest) *ds.UpdatePromotionResponse {
+	resp, err := s.promotionStorage.UpdatePromotion(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on UpdatePromotion", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("UpdatePromotion", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) GetPromotions(req *ds.GetPromotionsRequest) *ds.GetPromotionsResponse {
+	resp, err := s.promotionStorage.GetPromotions(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on GetPromotions", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetPromotions", resp.GetStatus())
+
+	return resp
+}
+
+// PriceBasket applies the promotions to the basket without counting them, the limits are counted by RedeemBasket.
+func (s *Service) PriceBasket(req *ds.PriceBasketRequest) *ds.PriceBasketResponse {
+	resp, err := s.priceBasket(req, "")
+	if err != nil {
+		s.logger.ErrorKV("failed on PriceBasket", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("PriceBasket", resp.GetStatus())
+
+	return resp
+}
+
+// RedeemBasket prices the basket as PriceBasket does and redeems the applied promotions. The basket is refused
+// if one of them has reached its limit since it was priced, the client is to price it again then.
+// A retried order is priced as it was the first time and its promotions aren't counted twice.
+func (s *Service) RedeemBasket(req *ds.RedeemBasketRequest) *ds.PriceBasketResponse {
+	resp, err := s.priceBasket(&req.PriceBasketRequest, req.OrderID)
+	if err != nil {
+		s.logger.ErrorKV("failed on RedeemBasket", "message", err.Error())
+		return nil
+	}
+
+	if resp.GetStatus() == "" && len(resp.Promotions) > 0 {
+		redemptions := make([]ds.Redemption, len(resp.Promotions))
+		for i, p := range resp.Promotions {
+			redemptions[i] = ds.Redemption{PromotionUid: p.Uid, Discount: p.Discount}
+		}
+
+		redeemed, err := s.promotionStorage.RedeemPromotions(&ds.RedeemPromotionsRequest{
+			Audit:       req.Audit,
+			ClientUid:   req.ClientUid,
+			OrderID:     req.OrderID,
+			Redemptions: redemptions,
+		})
+		if err != nil {
+			s.logger.ErrorKV("failed on RedeemBasket", "message", err.Error())
+			return nil
+		}
+
+		if redeemed.GetStatus() != ds.StatusOK {
+			resp = &ds.PriceBasketResponse{Status: redeemed.Status}
+		}
+	}
+
+	s.logHandlerStatus("RedeemBasket", resp.GetStatus())
+
+	return resp
+}
+
+// priceBasket prices the basket of the order, the order's own uses of the promotions aren't counted against it.
+func (s *Service) priceBasket(req *ds.PriceBasketRequest, orderID string) (*ds.PriceBasketResponse, error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = money.RUB
+	}
+
+	// The prices are read from the storage, so that an order is never priced by a stale cached product.
+	products := make(map[uuid.UUID]*ds.Product, len(req.Items))
+	for _, item := range req.Items {
+		if _, ok := products[item.ProductUid]; ok {
+			continue
+		}
+
+		resp, err := s.productStorage.GetProduct(&ds.GetProductRequest{
+			CurrencyFlag: ds.CurrencyFlag{Currency: currency},
+			Uid:          item.ProductUid,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.GetStatus() != "" {
+			return &ds.PriceBasketResponse{Status: resp.Status}, nil
+		}
+
+		products[item.ProductUid] = resp.Product
+	}
+
+	applicable, err := s.promotionStorage.GetApplicablePromotions(&ds.GetApplicablePromotionsRequest{
+		ClientUid: req.ClientUid,
+		OrderID:   orderID,
+		Code:      req.Code,
+		Currency:  currency,
+		At:        time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if applicable.GetStatus() != "" {
+		return &ds.PriceBasketResponse{Status: applicable.Status}, nil
+	}
+
+	promotions, status := usablePromotions(applicable.Promotions, req.Code)
+	if status != "" {
+		return &ds.PriceBasketResponse{Status: ds.Status{Message: status}}, nil
+	}
+
+	zero := money.New(0, currency)
+	resp := &ds.PriceBasketResponse{
+		Lines:    make([]ds.BasketLine, 0, len(req.Items)),
+		Subtotal: zero,
+		Discount: zero,
+		Total:    zero,
+	}
+	applied := make(map[uuid.UUID]int)
+
+	for _, item := range req.Items {
+		product := products[item.ProductUid]
+
+		variant, status := basketVariant(product, item.VariantUid)
+		if status != "" {
+			return &ds.PriceBasketResponse{Status: ds.Status{Message: status}}, nil
+		}
+
+		subtotal, err := variant.Price.Mul(item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		discounts, discount, err := lineDiscounts(promotions, product, subtotal, item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Lines = append(resp.Lines, ds.BasketLine{
+			ProductUid: product.Uid,
+			VariantUid: variant.Uid,
+			Sku:        variant.Sku,
+			Quantity:   item.Quantity,
+			UnitPrice:  variant.Price,
+			Subtotal:   subtotal,
+			Discount:   discount,
+			Total:      subtotal.Sub(discount),
+			Promotions: discounts,
+		})
+		resp.Subtotal = resp.Subtotal.Add(subtotal)
+		resp.Discount = resp.Discount.Add(discount)
+
+		for _, d := range discounts {
+			if i, ok := applied[d.Uid]; ok {
+				resp.Promotions[i].Discount = resp.Promotions[i].Discount.Add(d.Discount)
+				continue
+			}
+			applied[d.Uid] = len(resp.Promotions)
+			resp.Promotions = append(resp.Promotions, d)
+		}
+	}
+	resp.Total = resp.Subtotal.Sub(resp.Discount)
+
+	return resp, nil
+}
+
+// usablePromotions leaves out the promotions that reached their limits, the fixed amounts are in the basket currency.
+// The given code has to be of one of the promotions and not reached its limits.
+func usablePromotions(promotions []ds.ApplicablePromotion, code string) ([]ds.ApplicablePromotion, string) {
+	usable := make([]ds.ApplicablePromotion, 0, len(promotions))
+	codeFound := code == ""
+
+	for _, p := range promotions {
+		exhausted := p.UsageLimit != nil && p.Uses >= int64(*p.UsageLimit) ||
+			p.PerClientLimit != nil && p.ClientUses >= int64(*p.PerClientLimit)
+
+		if p.Code != "" {
+			codeFound = true
+			if exhausted {
+				return nil, ds.StatusPromoCodeExhausted
+			}
+		}
+
+		if exhausted {
+			continue
+		}
+		usable = append(usable, p)
+	}
+
+	if !codeFound {
+		return nil, ds.StatusInvalidPromoCode
+	}
+
+	return usable, ""
+}
+
+// basketVariant returns the variant of the item, the only one of the product if it's not given.
+func basketVariant(product *ds.Product, variantUid *uuid.UUID) (*ds.ProductVariant, string) {
+	if variantUid == nil {
+		if len(product.Variants) != 1 {
+			return nil, ds.StatusVariantRequired
+		}
+		return &product.Variants[0], ""
+	}
+
+	for i := range product.Variants {
+		if product.Variants[i].Uid == *variantUid {
+			return &product.Variants[i], ""
+		}
+	}
+
+	return nil, ds.StatusNotFound
+}
+
+// lineDiscounts takes the larger of the best single promotion and all the stackable ones combined. The promotions
+// are in the order of priority, the stackable ones apply one after another, each to what's left of the subtotal.
+func lineDiscounts(promotions []ds.ApplicablePromotion, product *ds.Product, subtotal money.Money,
+	quantity int64) ([]ds.AppliedPromotion, money.Money, error) {
+	var best, stacked []ds.AppliedPromotion
+	bestTotal := money.New(0, subtotal.Currency())
+	stackedTotal := bestTotal
+
+	for i := range promotions {
+		p := &promotions[i]
+		if !inPromotionScope(p, product) {
+			continue
+		}
+
+		if p.Stackable {
+			discount, err := promotionDiscount(p, subtotal.Sub(stackedTotal), quantity)
+			if err != nil {
+				return nil, money.Money{}, err
+			}
+			if discount.Minor() > 0 {
+				stacked = append(stacked, appliedPromotion(p, discount))
+				stackedTotal = stackedTotal.Add(discount)
+			}
+			continue
+		}
+
+		discount, err := promotionDiscount(p, subtotal, quantity)
+		if err != nil {
+			return nil, money.Money{}, err
+		}
+		if discount.Minor() > bestTotal.Minor() {
+			best = []ds.AppliedPromotion{appliedPromotion(p, discount)}
+			bestTotal = discount
+		}
+	}
+
+	if stackedTotal.Minor() > bestTotal.Minor() {
+		return stacked, stackedTotal, nil
+	}
+	return best, bestTotal, nil
+}
+
+// promotionDiscount returns the discount of the promotion on the amount, a fixed one is taken off each unit
+// and never goes over the amount.
+func promotionDiscount(p *ds.ApplicablePromotion, amount money.Money, quantity int64) (money.Money, error) {
+	if p.Kind == ds.PromotionPercent {
+		return amount.Percent(int64(p.Percent))
+	}
+
+	discount, err := p.Amount.Mul(quantity)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if discount.Minor() > amount.Minor() {
+		return amount, nil
+	}
+	return discount, nil
+}
+
+// inPromotionScope tells whether the promotion applies to the product, a promotion without a scope applies to all.
+func inPromotionScope(p *ds.ApplicablePromotion, product *ds.Product) bool {
+	if len(p.ProductUids) == 0 && len(p.CategoryUids) == 0 && len(p.SupplierUids) == 0 {
+		return true
+	}
+
+	return slices.Contains(p.ProductUids, product.Uid) ||
+		slices.Contains(p.CategoryTree, product.CategoryUid) ||
+		slices.Contains(p.SupplierUids, product.SupplierUid)
+}
+
+func appliedPromotion(p *ds.ApplicablePromotion, discount money.Money) ds.AppliedPromotion {
+	return ds.AppliedPromotion{
+		Uid:      p.Uid,
+		Name:     p.Name,
+		Code:     p.Code,
+		Discount: discount,
+	}
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"testing"
+
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/money"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPromotion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddPromotion ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		uid := uuid.New()
+		req := &ds.AddPromotionRequest{Promotion: ds.Promotion{Name: "Spring sale", Kind: ds.PromotionPercent, Percent: 25}}
+		res := &ds.AddPromotionResponse{Uid: &uid}
+
+		s.promotionStorageMock.EXPECT().AddPromotion(req).Return(res, nil)
+
+		resp := s.srv.AddPromotion(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("AddPromotion error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.promotionStorageMock.EXPECT().AddPromotion(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.AddPromotion(&ds.AddPromotionRequest{})
+		require.Nil(t, resp)
+	})
+}
+
+func TestUpdatePromotion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UpdatePromotion ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.UpdatePromotionRequest{Promotion: ds.Promotion{Uid: uuid.New(), Name: "Spring sale"}}
+		res := &ds.UpdatePromotionResponse{Status: ds.Status{Message: ds.StatusOK}}
+
+		s.promotionStorageMock.EXPECT().UpdatePromotion(req).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.UpdatePromotion(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("UpdatePromotion error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.promotionStorageMock.EXPECT().UpdatePromotion(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.UpdatePromotion(&ds.UpdatePromotionRequest{})
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetPromotions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetPromotions ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetPromotionsRequest{IncludeExpired: true}
+		res := &ds.GetPromotionsResponse{Promotions: []ds.Promotion{{Name: "Spring sale"}}}
+
+		s.promotionStorageMock.EXPECT().GetPromotions(req).Return(res, nil)
+
+		resp := s.srv.GetPromotions(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("GetPromotions error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.promotionStorageMock.EXPECT().GetPromotions(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetPromotions(&ds.GetPromotionsRequest{})
+		require.Nil(t, resp)
+	})
+}
+
+// testBasket has a beam of two variants in the category and a single variant nail of the supplier.
+type testBasket struct {
+	clientUid   uuid.UUID
+	categoryUid uuid.UUID
+	supplierUid uuid.UUID
+	beam        *ds.Product
+	nail        *ds.Product
+	orderID     string
+}
+
+func newTestBasket() *testBasket {
+	b := &testBasket{clientUid: uuid.New(), categoryUid: uuid.New(), supplierUid: uuid.New()}
+	b.beam = &ds.Product{
+		Uid:         uuid.New(),
+		SupplierUid: uuid.New(),
+		CategoryUid: b.categoryUid,
+		Variants: []ds.ProductVariant{
+			{Uid: uuid.New(), Sku: "BEAM-OAK-2M", Price: money.New(100000, money.RUB)},
+			{Uid: uuid.New(), Sku: "BEAM-OAK-3M", Price: money.New(150000, money.RUB)},
+		},
+	}
+	b.nail = &ds.Product{
+		Uid:         uuid.New(),
+		SupplierUid: b.supplierUid,
+		CategoryUid: uuid.New(),
+		Variants:    []ds.ProductVariant{{Uid: uuid.New(), Sku: "NAIL-100", Price: money.New(1050, money.RUB)}},
+	}
+	return b
+}
+
+func (b *testBasket) request(code string) *ds.PriceBasketRequest {
+	return &ds.PriceBasketRequest{
+		ClientUid: b.clientUid,
+		Code:      code,
+		Items: []ds.BasketItem{
+			{ProductUid: b.beam.Uid, VariantUid: &b.beam.Variants[0].Uid, Quantity: 2},
+			{ProductUid: b.nail.Uid, Quantity: 10},
+		},
+	}
+}
+
+func (b *testBasket) expectProducts(s *TestService) {
+	for _, p := range []*ds.Product{b.beam, b.nail} {
+		s.productStorageMock.EXPECT().GetProduct(&ds.GetProductRequest{
+			CurrencyFlag: ds.CurrencyFlag{Currency: money.RUB},
+			Uid:          p.Uid,
+		}).Return(&ds.GetProductResponse{Product: p}, nil)
+	}
+}
+
+func (b *testBasket) expectPromotions(t *testing.T, s *TestService, code string, promotions ...ds.ApplicablePromotion) {
+	s.promotionStorageMock.EXPECT().GetApplicablePromotions(gomock.Any()).DoAndReturn(
+		func(req *ds.GetApplicablePromotionsRequest) (*ds.GetApplicablePromotionsResponse, error) {
+			require.Equal(t, b.clientUid, req.ClientUid)
+			require.Equal(t, code, req.Code)
+			require.Equal(t, money.RUB, req.Currency)
+			require.Equal(t, b.orderID, req.OrderID)
+			return &ds.GetApplicablePromotionsResponse{Promotions: promotions}, nil
+		})
+}
+
+func TestPriceBasket(t *testing.T) {
+	t.Parallel()
+
+	limit := int32(1)
+	fixed := money.New(10000, money.RUB)
+
+	t.Run("PriceBasket best single promotion", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+
+		sale := ds.ApplicablePromotion{Promotion: ds.Promotion{
+			Uid: uuid.New(), Name: "Sale", Kind: ds.PromotionPercent, Percent: 5,
+		}}
+		beams := ds.ApplicablePromotion{
+			Promotion: ds.Promotion{
+				Uid: uuid.New(), Name: "Beams", Kind: ds.PromotionFixed, Amount: &fixed,
+				CategoryUids: []uuid.UUID{uuid.New()},
+			},
+			CategoryTree: []uuid.UUID{b.categoryUid},
+		}
+
+		b.expectProducts(s)
+		b.expectPromotions(t, s, "", sale, beams)
+
+		resp := s.srv.PriceBasket(b.request(""))
+		require.Empty(t, resp.GetStatus())
+		require.Len(t, resp.Lines, 2)
+
+		// 5% of 2000.00 is 100.00, less than 100.00 off each of the two beams.
+		require.Equal(t, money.New(200000, money.RUB), resp.Lines[0].Subtotal)
+		require.Equal(t, money.New(20000, money.RUB), resp.Lines[0].Discount)
+		require.Equal(t, []ds.AppliedPromotion{{Uid: beams.Uid, Name: "Beams", Discount: money.New(20000, money.RUB)}},
+			resp.Lines[0].Promotions)
+
+		// The nails are out of the beams scope, 5% of 105.00.
+		require.Equal(t, b.nail.Variants[0].Uid, resp.Lines[1].VariantUid)
+		require.Equal(t, money.New(525, money.RUB), resp.Lines[1].Discount)
+		require.Equal(t, money.New(9975, money.RUB), resp.Lines[1].Total)
+
+		require.Equal(t, money.New(210500, money.RUB), resp.Subtotal)
+		require.Equal(t, money.New(20525, money.RUB), resp.Discount)
+		require.Equal(t, money.New(189975, money.RUB), resp.Total)
+		require.Equal(t, []ds.AppliedPromotion{
+			{Uid: beams.Uid, Name: "Beams", Discount: money.New(20000, money.RUB)},
+			{Uid: sale.Uid, Name: "Sale", Discount: money.New(525, money.RUB)},
+		}, resp.Promotions)
+	})
+
+	t.Run("PriceBasket stackable promotions", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+
+		code := ds.ApplicablePromotion{Promotion: ds.Promotion{
+			Uid: uuid.New(), Name: "Code", Code: "SPRING25", Kind: ds.PromotionPercent, Percent: 25,
+			Stackable: true, PerClientLimit: &limit,
+		}}
+		nails := ds.ApplicablePromotion{Promotion: ds.Promotion{
+			Uid: uuid.New(), Name: "Nails", Kind: ds.PromotionPercent, Percent: 20, Stackable: true,
+			SupplierUids: []uuid.UUID{b.supplierUid},
+		}}
+		sale := ds.ApplicablePromotion{Promotion: ds.Promotion{
+			Uid: uuid.New(), Name: "Sale", Kind: ds.PromotionPercent, Percent: 30,
+		}}
+
+		b.expectProducts(s)
+		b.expectPromotions(t, s, "spring25", code, nails, sale)
+
+		resp := s.srv.PriceBasket(b.request("spring25"))
+		require.Empty(t, resp.GetStatus())
+
+		// 25% of 2000.00 is less than 30%, the sale alone is better.
+		require.Equal(t, money.New(60000, money.RUB), resp.Lines[0].Discount)
+
+		// 25% of 105.00 is 26.25, then 20% of 78.75 is 15.75, 42.00 together is more than 31.50.
+		require.Equal(t, money.New(4200, money.RUB), resp.Lines[1].Discount)
+		require.Equal(t, []ds.AppliedPromotion{
+			{Uid: code.Uid, Name: "Code", Code: "SPRING25", Discount: money.New(2625, money.RUB)},
+			{Uid: nails.Uid, Name: "Nails", Discount: money.New(1575, money.RUB)},
+		}, resp.Lines[1].Promotions)
+		require.Len(t, resp.Promotions, 3)
+	})
+
+	t.Run("PriceBasket skips exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+
+		b.expectProducts(s)
+		b.expectPromotions(t, s, "",
+			ds.ApplicablePromotion{Promotion: ds.Promotion{
+				Uid: uuid.New(), Kind: ds.PromotionPercent, Percent: 50, PerClientLimit: &limit,
+			}, ClientUses: 1},
+		)
+
+		resp := s.srv.PriceBasket(b.request(""))
+		require.Empty(t, resp.GetStatus())
+		require.Equal(t, money.New(0, money.RUB), resp.Discount)
+		require.Equal(t, resp.Subtotal, resp.Total)
+		require.Empty(t, resp.Promotions)
+	})
+
+	t.Run("PriceBasket invalid code", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+
+		b.expectProducts(s)
+		b.expectPromotions(t, s, "NOPE")
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.PriceBasket(b.request("NOPE"))
+		require.Equal(t, ds.StatusInvalidPromoCode, resp.GetStatus())
+	})
+
+	t.Run("PriceBasket code exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+
+		b.expectProducts(s)
+		b.expectPromotions(t, s, "SPRING25", ds.ApplicablePromotion{Promotion: ds.Promotion{
+			Code: "SPRING25", Kind: ds.PromotionPercent, Percent: 25, UsageLimit: &limit, Uses: 1,
+		}})
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.PriceBasket(b.request("SPRING25"))
+		require.Equal(t, ds.StatusPromoCodeExhausted, resp.GetStatus())
+	})
+
+	t.Run("PriceBasket variant required", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+
+		req := b.request("")
+		req.Items[0].VariantUid = nil
+
+		b.expectProducts(s)
+		b.expectPromotions(t, s, "")
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.PriceBasket(req)
+		require.Equal(t, ds.StatusVariantRequired, resp.GetStatus())
+	})
+
+	t.Run("PriceBasket product not found", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+
+		s.productStorageMock.EXPECT().GetProduct(gomock.Any()).
+			Return(&ds.GetProductResponse{Status: ds.Status{Message: ds.StatusNotFound}}, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.PriceBasket(b.request(""))
+		require.Equal(t, ds.StatusNotFound, resp.GetStatus())
+	})
+
+	t.Run("PriceBasket error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+
+		b.expectProducts(s)
+		s.promotionStorageMock.EXPECT().GetApplicablePromotions(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.PriceBasket(b.request(""))
+		require.Nil(t, resp)
+	})
+}
+
+func TestRedeemBasket(t *testing.T) {
+	t.Parallel()
+
+	sale := ds.ApplicablePromotion{Promotion: ds.Promotion{
+		Uid: uuid.New(), Name: "Sale", Kind: ds.PromotionPercent, Percent: 10,
+	}}
+
+	t.Run("RedeemBasket ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+		b.orderID = "ORD-1"
+
+		b.expectProducts(s)
+		b.expectPromotions(t, s, "", sale)
+		s.promotionStorageMock.EXPECT().RedeemPromotions(&ds.RedeemPromotionsRequest{
+			ClientUid:   b.clientUid,
+			OrderID:     "ORD-1",
+			Redemptions: []ds.Redemption{{PromotionUid: sale.Uid, Discount: money.New(21050, money.RUB)}},
+		}).Return(&ds.RedeemPromotionsResponse{Status: ds.Status{Message: ds.StatusOK}}, nil)
+
+		resp := s.srv.RedeemBasket(&ds.RedeemBasketRequest{PriceBasketRequest: *b.request(""), OrderID: b.orderID})
+		require.Empty(t, resp.GetStatus())
+		require.Equal(t, money.New(189450, money.RUB), resp.Total)
+	})
+
+	t.Run("RedeemBasket nothing to redeem", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+
+		b.expectProducts(s)
+		b.expectPromotions(t, s, "")
+
+		resp := s.srv.RedeemBasket(&ds.RedeemBasketRequest{PriceBasketRequest: *b.request("")})
+		require.Empty(t, resp.GetStatus())
+		require.Equal(t, resp.Subtotal, resp.Total)
+	})
+
+	t.Run("RedeemBasket exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+
+		b.expectProducts(s)
+		b.expectPromotions(t, s, "", sale)
+		s.promotionStorageMock.EXPECT().RedeemPromotions(gomock.Any()).
+			Return(&ds.RedeemPromotionsResponse{Status: ds.Status{Message: ds.StatusPromotionExhausted}}, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RedeemBasket(&ds.RedeemBasketRequest{PriceBasketRequest: *b.request("")})
+		require.Equal(t, ds.StatusPromotionExhausted, resp.GetStatus())
+		require.Empty(t, resp.Lines)
+	})
+
+	t.Run("RedeemBasket error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+		b := newTestBasket()
+
+		b.expectProducts(s)
+		b.expectPromotions(t, s, "", sale)
+		s.promotionStorageMock.EXPECT().RedeemPromotions(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RedeemBasket(&ds.RedeemBasketRequest{PriceBasketRequest: *b.request("")})
+		require.Nil(t, resp)
+	})
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	ds "shopapi/internal/datastruct"
+)
+
+// The roles bypass the cache as the API keys do, a changed role takes effect on the next request.
+// A caller can grant a role only the permissions its own roles grant.
+
+func (s *Service) GetRoles(req *ds.GetRolesRequest) *ds.GetRolesResponse {
+	resp, err := s.authStorage.GetRoles(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on GetRoles", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("GetRoles", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) AddRole(req *ds.AddRoleRequest) *ds.AddRoleResponse {
+	if missing := ds.MissingPermissions(req.Granted, req.Permissions); len(missing) > 0 {
+		resp := &ds.AddRoleResponse{
+			Status:             ds.Status{Message: ds.StatusForbidden},
+			MissingPermissions: missing,
+		}
+		s.logHandlerStatus("AddRole", resp.GetStatus())
+		return resp
+	}
+
+	resp, err := s.authStorage.AddRole(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on AddRole", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("AddRole", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) UpdateRole(req *ds.UpdateRoleRequest) *ds.UpdateRoleResponse {
+	if missing := ds.MissingPermissions(req.Granted, req.Permissions); len(missing) > 0 {
+		resp := &ds.UpdateRoleResponse{
+			Status:             ds.Status{Message: ds.StatusForbidden},
+			MissingPermissions: missing,
+		}
+		s.logHandlerStatus("UpdateRole", resp.GetStatus())
+		return resp
+	}
+
+	resp, err := s.authStorage.UpdateRole(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on UpdateRole", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("UpdateRole", resp.GetStatus())
+
+	return resp
+}
+
+func (s *Service) DeleteRole(req *ds.DeleteRoleRequest) *ds.DeleteRoleResponse {
+	resp, err := s.authStorage.DeleteRole(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on DeleteRole", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("DeleteRole", resp.GetStatus())
+
+	return resp
+}
+
+// GetPermissions is called on every authenticated request, so it logs only the failures.
+func (s *Service) GetPermissions(req *ds.GetPermissionsRequest) *ds.GetPermissionsResponse {
+	resp, err := s.authStorage.GetPermissions(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on GetPermissions", "message", err.Error())
+		return nil
+	}
+
+	return resp
+}
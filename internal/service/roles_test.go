@@ -0,0 +1,184 @@
+package service
+
+import (
+	"testing"
+
+	ds "shopapi/internal/datastruct"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRoles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetRoles ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetRolesRequest{}
+		res := &ds.GetRolesResponse{Roles: []ds.Role{{Name: ds.RoleAdmin, Permissions: []string{ds.PermissionAll}}}}
+
+		s.authStorageMock.EXPECT().GetRoles(req).Return(res, nil)
+
+		resp := s.srv.GetRoles(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("GetRoles error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.authStorageMock.EXPECT().GetRoles(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetRoles(&ds.GetRolesRequest{})
+		require.Nil(t, resp)
+	})
+}
+
+func TestAddRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddRole ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.AddRoleRequest{
+			Audit: ds.Audit{Granted: []string{ds.PermissionClientsRead, ds.PermissionRolesManage}},
+			Role:  ds.Role{Name: "auditor", Permissions: []string{ds.PermissionClientsRead}},
+		}
+		res := &ds.AddRoleResponse{Status: ds.Status{Message: ds.StatusOK}}
+
+		s.authStorageMock.EXPECT().AddRole(req).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.AddRole(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("AddRole error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.authStorageMock.EXPECT().AddRole(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.AddRole(&ds.AddRoleRequest{})
+		require.Nil(t, resp)
+	})
+
+	t.Run("AddRole permissions the caller lacks", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.AddRoleRequest{
+			Audit: ds.Audit{Granted: []string{ds.PermissionRolesManage}},
+			Role:  ds.Role{Name: "root", Permissions: []string{ds.PermissionAll}},
+		}
+
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.AddRole(req)
+		require.Equal(t, ds.StatusForbidden, resp.GetStatus())
+		require.Equal(t, []string{ds.PermissionAll}, resp.MissingPermissions)
+	})
+}
+
+func TestUpdateRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UpdateRole protected", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.UpdateRoleRequest{Role: ds.Role{Name: ds.RoleAdmin}}
+		res := &ds.UpdateRoleResponse{Status: ds.Status{Message: ds.StatusRoleProtected}}
+
+		s.authStorageMock.EXPECT().UpdateRole(req).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.UpdateRole(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("UpdateRole error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.authStorageMock.EXPECT().UpdateRole(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.UpdateRole(&ds.UpdateRoleRequest{})
+		require.Nil(t, resp)
+	})
+}
+
+func TestDeleteRole(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DeleteRole in use", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.DeleteRoleRequest{Name: "crm"}
+		res := &ds.DeleteRoleResponse{Status: ds.Status{Message: ds.StatusRoleInUse}, ApiKeys: []uuid.UUID{uuid.New()}}
+
+		s.authStorageMock.EXPECT().DeleteRole(req).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.DeleteRole(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("DeleteRole error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.authStorageMock.EXPECT().DeleteRole(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.DeleteRole(&ds.DeleteRoleRequest{Name: "crm"})
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetPermissions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetPermissions ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.GetPermissionsRequest{Roles: []string{"viewer"}}
+		res := &ds.GetPermissionsResponse{Permissions: []string{ds.PermissionProductsRead}}
+
+		s.authStorageMock.EXPECT().GetPermissions(req).Return(res, nil)
+
+		resp := s.srv.GetPermissions(req)
+		require.Equal(t, res, resp)
+	})
+
+	t.Run("GetPermissions error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		s.authStorageMock.EXPECT().GetPermissions(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.GetPermissions(&ds.GetPermissionsRequest{})
+		require.Nil(t, resp)
+	})
+}
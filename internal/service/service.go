@@ -5,9 +5,10 @@ import (
 	ds "shopapi/internal/datastruct"
 	"shopapi/internal/supports"
 	"strings"
+	"time"
 )
 
-//go:generate mockgen -source=service.go -destination=service_mock.go -package=service ILogger,ICache,IClientStorage,IProductStorage,ISupplierStorage,IImageStorage
+//go:generate mockgen -source=service.go -destination=service_mock.go -package=service ILogger,ICache,IClientStorage,IProductStorage,ISupplierStorage,IImageStorage,ICategoryStorage,IAuthStorage,IAuditStorage,IPromotionStorage
 
 type ILogger interface {
 	InfoKV(message string, argsKV ...any)
@@ -23,67 +24,142 @@ type ILogger interface {
 type ICache interface {
 	Read(key string, v any) (bool, error)
 	Write(key string, v any) error
+	WriteUntil(key string, v any, until time.Time) error
 }
 
 type ICachedState interface {
 	SetCached(bool)
 }
 
+// IExpiring is a response going stale at a known moment, as a product at the next change of its prices.
+// It is cached until then at most.
+type IExpiring interface {
+	StaleAt() *time.Time
+}
+
 type IClientStorage interface {
 	AddClient(*ds.AddClientRequest) (*ds.AddClientResponse, error)
 	DeleteClient(*ds.DeleteClientRequest) (*ds.DeleteClientResponse, error)
+	RestoreClient(*ds.RestoreClientRequest) (*ds.RestoreClientResponse, error)
 	GetClientsByName(*ds.GetClientsByNameRequest) (*ds.GetClientsByNameResponse, error)
 	GetClients(*ds.GetClientsRequest) (*ds.GetClientsResponse, error)
 	PatchClientAddress(*ds.PatchClientAddressRequest) (*ds.PatchClientAddressResponse, error)
+	GetClientHistory(*ds.GetHistoryRequest) (*ds.GetClientHistoryResponse, error)
 }
 
 type IProductStorage interface {
 	AddProduct(*ds.AddProductRequest) (*ds.AddProductResponse, error)
 	DecreaseProducts(*ds.DecreaseProductsRequest) (*ds.DecreaseProductsResponse, error)
 	GetProduct(*ds.GetProductRequest) (*ds.GetProductResponse, error)
+	GetProductBySku(*ds.GetProductBySkuRequest) (*ds.GetProductResponse, error)
+	GetProductByBarcode(*ds.GetProductByBarcodeRequest) (*ds.GetProductResponse, error)
 	GetProducts(*ds.GetProductsRequest) (*ds.GetProductsResponse, error)
 	DeleteProduct(*ds.DeleteProductRequest) (*ds.DeleteProductResponse, error)
+	RestoreProduct(*ds.RestoreProductRequest) (*ds.RestoreProductResponse, error)
+	UpdateProductAttributes(*ds.UpdateProductAttributesRequest) (*ds.UpdateProductAttributesResponse, error)
+	AddProductVariant(*ds.AddProductVariantRequest) (*ds.AddProductVariantResponse, error)
+	DeleteProductVariant(*ds.DeleteProductVariantRequest) (*ds.DeleteProductVariantResponse, error)
+	AddProductDocument(*ds.AddProductDocumentRequest) (*ds.AddProductDocumentResponse, error)
+	GetProductDocument(*ds.GetProductDocumentRequest) (*ds.GetProductDocumentResponse, error)
+	GetProductDocuments(*ds.GetProductDocumentsRequest) (*ds.GetProductDocumentsResponse, error)
+	DeleteProductDocument(*ds.DeleteProductDocumentRequest) (*ds.DeleteProductDocumentResponse, error)
+	GetProductHistory(*ds.GetHistoryRequest) (*ds.GetProductHistoryResponse, error)
+	SetVariantPrice(*ds.SetVariantPriceRequest) (*ds.SetVariantPriceResponse, error)
+	GetVariantPrices(*ds.GetVariantPricesRequest) (*ds.GetVariantPricesResponse, error)
+	SetExchangeRate(*ds.SetExchangeRateRequest) (*ds.SetExchangeRateResponse, error)
+	GetExchangeRates(*ds.GetExchangeRatesRequest) (*ds.GetExchangeRatesResponse, error)
 }
 
 type ISupplierStorage interface {
 	AddSupplier(*ds.AddSupplierRequest) (*ds.AddSupplierResponse, error)
 	UpdateSupplierAddress(*ds.UpdateSupplierAddressRequest) (*ds.UpdateSupplierAddressResponse, error)
 	DeleteSupplier(*ds.DeleteSupplierRequest) (*ds.DeleteSupplierResponse, error)
+	RestoreSupplier(*ds.RestoreSupplierRequest) (*ds.RestoreSupplierResponse, error)
 	GetSuppliers(*ds.GetSuppliersRequest) (*ds.GetSuppliersResponse, error)
 	GetSupplier(*ds.GetSupplierRequest) (*ds.GetSupplierResponse, error)
+	GetSupplierHistory(*ds.GetHistoryRequest) (*ds.GetSupplierHistoryResponse, error)
 }
 
 type IImageStorage interface {
 	AddImage(*ds.AddImageRequest) (*ds.AddImageResponse, error)
 	UpdateImage(*ds.UpdateImageRequest) (*ds.UpdateImageResponse, error)
 	DeleteImage(*ds.DeleteImageRequest) (*ds.DeleteImageResponse, error)
+	RestoreImage(*ds.RestoreImageRequest) (*ds.RestoreImageResponse, error)
+	GetImageMeta(*ds.GetImageMetaRequest) (*ds.GetImageMetaResponse, error)
 	GetProductImage(*ds.GetProductImageRequest) (*ds.GetProductImageResponse, error)
 	GetImage(*ds.GetImageRequest) (*ds.GetImageResponse, error)
+	AttachProductImage(*ds.AttachProductImageRequest) (*ds.AttachProductImageResponse, error)
+	DetachProductImage(*ds.DetachProductImageRequest) (*ds.DetachProductImageResponse, error)
+	ReorderProductImages(*ds.ReorderProductImagesRequest) (*ds.ReorderProductImagesResponse, error)
+}
+
+type ICategoryStorage interface {
+	AddCategory(*ds.AddCategoryRequest) (*ds.AddCategoryResponse, error)
+	UpdateCategory(*ds.UpdateCategoryRequest) (*ds.UpdateCategoryResponse, error)
+	DeleteCategory(*ds.DeleteCategoryRequest) (*ds.DeleteCategoryResponse, error)
+	GetCategory(*ds.GetCategoryRequest) (*ds.GetCategoryResponse, error)
+	GetCategoriesTree(*ds.GetCategoriesTreeRequest) (*ds.GetCategoriesTreeResponse, error)
+}
+
+type IAuthStorage interface {
+	AddApiKey(*ds.IssueApiKeyRequest) (*ds.IssueApiKeyResponse, error)
+	GetApiKeys(*ds.GetApiKeysRequest) (*ds.GetApiKeysResponse, error)
+	RevokeApiKey(*ds.RevokeApiKeyRequest) (*ds.RevokeApiKeyResponse, error)
+	AuthenticateApiKey(*ds.AuthenticateApiKeyRequest) (*ds.AuthenticateApiKeyResponse, error)
+	GetRoles(*ds.GetRolesRequest) (*ds.GetRolesResponse, error)
+	AddRole(*ds.AddRoleRequest) (*ds.AddRoleResponse, error)
+	UpdateRole(*ds.UpdateRoleRequest) (*ds.UpdateRoleResponse, error)
+	DeleteRole(*ds.DeleteRoleRequest) (*ds.DeleteRoleResponse, error)
+	GetPermissions(*ds.GetPermissionsRequest) (*ds.GetPermissionsResponse, error)
+}
+
+type IAuditStorage interface {
+	GetAudit(*ds.GetAuditRequest) (*ds.GetAuditResponse, error)
+}
+
+type IPromotionStorage interface {
+	AddPromotion(*ds.AddPromotionRequest) (*ds.AddPromotionResponse, error)
+	UpdatePromotion(*ds.UpdatePromotionRequest) (*ds.UpdatePromotionResponse, error)
+	GetPromotions(*ds.GetPromotionsRequest) (*ds.GetPromotionsResponse, error)
+	GetApplicablePromotions(*ds.GetApplicablePromotionsRequest) (*ds.GetApplicablePromotionsResponse, error)
+	RedeemPromotions(*ds.RedeemPromotionsRequest) (*ds.RedeemPromotionsResponse, error)
 }
 
 type Service struct {
-	ctx             context.Context
-	logger          ILogger
-	cache           ICache
-	clientStorage   IClientStorage
-	productStorage  IProductStorage
-	supplierStorage ISupplierStorage
-	imageStorage    IImageStorage
+	ctx              context.Context
+	logger           ILogger
+	cache            ICache
+	clientStorage    IClientStorage
+	productStorage   IProductStorage
+	supplierStorage  ISupplierStorage
+	imageStorage     IImageStorage
+	categoryStorage  ICategoryStorage
+	authStorage      IAuthStorage
+	auditStorage     IAuditStorage
+	promotionStorage IPromotionStorage
 }
 
 func NewService(ctx context.Context, l ILogger, c ICache,
 	cs IClientStorage,
 	ps IProductStorage,
 	ss ISupplierStorage,
-	is IImageStorage) *Service {
+	is IImageStorage,
+	cats ICategoryStorage,
+	as IAuthStorage,
+	aus IAuditStorage,
+	prs IPromotionStorage) *Service {
 	return &Service{
-		ctx:             ctx,
-		logger:          l,
-		cache:           c,
-		clientStorage:   cs,
-		productStorage:  ps,
-		supplierStorage: ss,
-		imageStorage:    is,
+		ctx:              ctx,
+		logger:           l,
+		cache:            c,
+		clientStorage:    cs,
+		productStorage:   ps,
+		supplierStorage:  ss,
+		imageStorage:     is,
+		categoryStorage:  cats,
+		authStorage:      as,
+		auditStorage:     aus,
+		promotionStorage: prs,
 	}
 }
 
@@ -110,6 +186,16 @@ func makeCacheKey(vv ...string) string {
 	return b.String()
 }
 
+// asOfKey tells apart in the cache the reads of a past moment, it is empty for the current state.
+func asOfKey(asOf *time.Time) string {
+	if asOf == nil {
+		return ""
+	}
+	return asOf.UTC().Format(time.RFC3339Nano)
+}
+
+// execWithCache serves reads only. Changes such as deletes, restores, updates, attaches and detaches
+// call the storage directly: a cached response would skip a repeated change and its audit entry.
 func execWithCache[RespT ICachedState](s *Service, key string, avoidCache bool, fetch func() (RespT, error)) (RespT, error) {
 	var response RespT
 	var cached bool
@@ -133,7 +219,11 @@ func execWithCache[RespT ICachedState](s *Service, key string, avoidCache bool,
 		return empty, err
 	}
 
-	err = s.cache.Write(key, response)
+	if expiring, ok := any(response).(IExpiring); ok && expiring.StaleAt() != nil {
+		err = s.cache.WriteUntil(key, response, *expiring.StaleAt())
+	} else {
+		err = s.cache.Write(key, response)
+	}
 	if err != nil {
 		s.logger.ErrorKV("failed writing cache", "message", err.Error())
 	}
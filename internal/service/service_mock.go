@@ -7,6 +7,7 @@ package service
 import (
 	reflect "reflect"
 	datastruct "shopapi/internal/datastruct"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -222,6 +223,20 @@ func (mr *MockICacheMockRecorder) Write(key, v interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockICache)(nil).Write), key, v)
 }
 
+// WriteUntil mocks base method.
+func (m *MockICache) WriteUntil(key string, v any, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteUntil", key, v, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteUntil indicates an expected call of WriteUntil.
+func (mr *MockICacheMockRecorder) WriteUntil(key, v, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteUntil", reflect.TypeOf((*MockICache)(nil).WriteUntil), key, v, until)
+}
+
 // MockICachedState is a mock of ICachedState interface.
 type MockICachedState struct {
 	ctrl     *gomock.Controller
@@ -257,6 +272,43 @@ func (mr *MockICachedStateMockRecorder) SetCached(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCached", reflect.TypeOf((*MockICachedState)(nil).SetCached), arg0)
 }
 
+// MockIExpiring is a mock of IExpiring interface.
+type MockIExpiring struct {
+	ctrl     *gomock.Controller
+	recorder *MockIExpiringMockRecorder
+}
+
+// MockIExpiringMockRecorder is the mock recorder for MockIExpiring.
+type MockIExpiringMockRecorder struct {
+	mock *MockIExpiring
+}
+
+// NewMockIExpiring creates a new mock instance.
+func NewMockIExpiring(ctrl *gomock.Controller) *MockIExpiring {
+	mock := &MockIExpiring{ctrl: ctrl}
+	mock.recorder = &MockIExpiringMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIExpiring) EXPECT() *MockIExpiringMockRecorder {
+	return m.recorder
+}
+
+// StaleAt mocks base method.
+func (m *MockIExpiring) StaleAt() *time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StaleAt")
+	ret0, _ := ret[0].(*time.Time)
+	return ret0
+}
+
+// StaleAt indicates an expected call of StaleAt.
+func (mr *MockIExpiringMockRecorder) StaleAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StaleAt", reflect.TypeOf((*MockIExpiring)(nil).StaleAt))
+}
+
 // MockIClientStorage is a mock of IClientStorage interface.
 type MockIClientStorage struct {
 	ctrl     *gomock.Controller
@@ -310,6 +362,21 @@ func (mr *MockIClientStorageMockRecorder) DeleteClient(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockIClientStorage)(nil).DeleteClient), arg0)
 }
 
+// GetClientHistory mocks base method.
+func (m *MockIClientStorage) GetClientHistory(arg0 *datastruct.GetHistoryRequest) (*datastruct.GetClientHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientHistory", arg0)
+	ret0, _ := ret[0].(*datastruct.GetClientHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientHistory indicates an expected call of GetClientHistory.
+func (mr *MockIClientStorageMockRecorder) GetClientHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientHistory", reflect.TypeOf((*MockIClientStorage)(nil).GetClientHistory), arg0)
+}
+
 // GetClients mocks base method.
 func (m *MockIClientStorage) GetClients(arg0 *datastruct.GetClientsRequest) (*datastruct.GetClientsResponse, error) {
 	m.ctrl.T.Helper()
@@ -355,6 +422,21 @@ func (mr *MockIClientStorageMockRecorder) PatchClientAddress(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchClientAddress", reflect.TypeOf((*MockIClientStorage)(nil).PatchClientAddress), arg0)
 }
 
+// RestoreClient mocks base method.
+func (m *MockIClientStorage) RestoreClient(arg0 *datastruct.RestoreClientRequest) (*datastruct.RestoreClientResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreClient", arg0)
+	ret0, _ := ret[0].(*datastruct.RestoreClientResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreClient indicates an expected call of RestoreClient.
+func (mr *MockIClientStorageMockRecorder) RestoreClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreClient", reflect.TypeOf((*MockIClientStorage)(nil).RestoreClient), arg0)
+}
+
 // MockIProductStorage is a mock of IProductStorage interface.
 type MockIProductStorage struct {
 	ctrl     *gomock.Controller
@@ -393,6 +475,36 @@ func (mr *MockIProductStorageMockRecorder) AddProduct(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockIProductStorage)(nil).AddProduct), arg0)
 }
 
+// AddProductDocument mocks base method.
+func (m *MockIProductStorage) AddProductDocument(arg0 *datastruct.AddProductDocumentRequest) (*datastruct.AddProductDocumentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProductDocument", arg0)
+	ret0, _ := ret[0].(*datastruct.AddProductDocumentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProductDocument indicates an expected call of AddProductDocument.
+func (mr *MockIProductStorageMockRecorder) AddProductDocument(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductDocument", reflect.TypeOf((*MockIProductStorage)(nil).AddProductDocument), arg0)
+}
+
+// AddProductVariant mocks base method.
+func (m *MockIProductStorage) AddProductVariant(arg0 *datastruct.AddProductVariantRequest) (*datastruct.AddProductVariantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProductVariant", arg0)
+	ret0, _ := ret[0].(*datastruct.AddProductVariantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProductVariant indicates an expected call of AddProductVariant.
+func (mr *MockIProductStorageMockRecorder) AddProductVariant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductVariant", reflect.TypeOf((*MockIProductStorage)(nil).AddProductVariant), arg0)
+}
+
 // DecreaseProducts mocks base method.
 func (m *MockIProductStorage) DecreaseProducts(arg0 *datastruct.DecreaseProductsRequest) (*datastruct.DecreaseProductsResponse, error) {
 	m.ctrl.T.Helper()
@@ -423,6 +535,51 @@ func (mr *MockIProductStorageMockRecorder) DeleteProduct(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockIProductStorage)(nil).DeleteProduct), arg0)
 }
 
+// DeleteProductDocument mocks base method.
+func (m *MockIProductStorage) DeleteProductDocument(arg0 *datastruct.DeleteProductDocumentRequest) (*datastruct.DeleteProductDocumentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductDocument", arg0)
+	ret0, _ := ret[0].(*datastruct.DeleteProductDocumentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProductDocument indicates an expected call of DeleteProductDocument.
+func (mr *MockIProductStorageMockRecorder) DeleteProductDocument(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductDocument", reflect.TypeOf((*MockIProductStorage)(nil).DeleteProductDocument), arg0)
+}
+
+// DeleteProductVariant mocks base method.
+func (m *MockIProductStorage) DeleteProductVariant(arg0 *datastruct.DeleteProductVariantRequest) (*datastruct.DeleteProductVariantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductVariant", arg0)
+	ret0, _ := ret[0].(*datastruct.DeleteProductVariantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProductVariant indicates an expected call of DeleteProductVariant.
+func (mr *MockIProductStorageMockRecorder) DeleteProductVariant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductVariant", reflect.TypeOf((*MockIProductStorage)(nil).DeleteProductVariant), arg0)
+}
+
+// GetExchangeRates mocks base method.
+func (m *MockIProductStorage) GetExchangeRates(arg0 *datastruct.GetExchangeRatesRequest) (*datastruct.GetExchangeRatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates", arg0)
+	ret0, _ := ret[0].(*datastruct.GetExchangeRatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockIProductStorageMockRecorder) GetExchangeRates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockIProductStorage)(nil).GetExchangeRates), arg0)
+}
+
 // GetProduct mocks base method.
 func (m *MockIProductStorage) GetProduct(arg0 *datastruct.GetProductRequest) (*datastruct.GetProductResponse, error) {
 	m.ctrl.T.Helper()
@@ -438,6 +595,81 @@ func (mr *MockIProductStorageMockRecorder) GetProduct(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockIProductStorage)(nil).GetProduct), arg0)
 }
 
+// GetProductByBarcode mocks base method.
+func (m *MockIProductStorage) GetProductByBarcode(arg0 *datastruct.GetProductByBarcodeRequest) (*datastruct.GetProductResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByBarcode", arg0)
+	ret0, _ := ret[0].(*datastruct.GetProductResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductByBarcode indicates an expected call of GetProductByBarcode.
+func (mr *MockIProductStorageMockRecorder) GetProductByBarcode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByBarcode", reflect.TypeOf((*MockIProductStorage)(nil).GetProductByBarcode), arg0)
+}
+
+// GetProductBySku mocks base method.
+func (m *MockIProductStorage) GetProductBySku(arg0 *datastruct.GetProductBySkuRequest) (*datastruct.GetProductResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductBySku", arg0)
+	ret0, _ := ret[0].(*datastruct.GetProductResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductBySku indicates an expected call of GetProductBySku.
+func (mr *MockIProductStorageMockRecorder) GetProductBySku(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductBySku", reflect.TypeOf((*MockIProductStorage)(nil).GetProductBySku), arg0)
+}
+
+// GetProductDocument mocks base method.
+func (m *MockIProductStorage) GetProductDocument(arg0 *datastruct.GetProductDocumentRequest) (*datastruct.GetProductDocumentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductDocument", arg0)
+	ret0, _ := ret[0].(*datastruct.GetProductDocumentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductDocument indicates an expected call of GetProductDocument.
+func (mr *MockIProductStorageMockRecorder) GetProductDocument(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductDocument", reflect.TypeOf((*MockIProductStorage)(nil).GetProductDocument), arg0)
+}
+
+// GetProductDocuments mocks base method.
+func (m *MockIProductStorage) GetProductDocuments(arg0 *datastruct.GetProductDocumentsRequest) (*datastruct.GetProductDocumentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductDocuments", arg0)
+	ret0, _ := ret[0].(*datastruct.GetProductDocumentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductDocuments indicates an expected call of GetProductDocuments.
+func (mr *MockIProductStorageMockRecorder) GetProductDocuments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductDocuments", reflect.TypeOf((*MockIProductStorage)(nil).GetProductDocuments), arg0)
+}
+
+// GetProductHistory mocks base method.
+func (m *MockIProductStorage) GetProductHistory(arg0 *datastruct.GetHistoryRequest) (*datastruct.GetProductHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductHistory", arg0)
+	ret0, _ := ret[0].(*datastruct.GetProductHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductHistory indicates an expected call of GetProductHistory.
+func (mr *MockIProductStorageMockRecorder) GetProductHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductHistory", reflect.TypeOf((*MockIProductStorage)(nil).GetProductHistory), arg0)
+}
+
 // GetProducts mocks base method.
 func (m *MockIProductStorage) GetProducts(arg0 *datastruct.GetProductsRequest) (*datastruct.GetProductsResponse, error) {
 	m.ctrl.T.Helper()
@@ -453,6 +685,81 @@ func (mr *MockIProductStorageMockRecorder) GetProducts(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProducts", reflect.TypeOf((*MockIProductStorage)(nil).GetProducts), arg0)
 }
 
+// GetVariantPrices mocks base method.
+func (m *MockIProductStorage) GetVariantPrices(arg0 *datastruct.GetVariantPricesRequest) (*datastruct.GetVariantPricesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantPrices", arg0)
+	ret0, _ := ret[0].(*datastruct.GetVariantPricesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantPrices indicates an expected call of GetVariantPrices.
+func (mr *MockIProductStorageMockRecorder) GetVariantPrices(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantPrices", reflect.TypeOf((*MockIProductStorage)(nil).GetVariantPrices), arg0)
+}
+
+// RestoreProduct mocks base method.
+func (m *MockIProductStorage) RestoreProduct(arg0 *datastruct.RestoreProductRequest) (*datastruct.RestoreProductResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProduct", arg0)
+	ret0, _ := ret[0].(*datastruct.RestoreProductResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockIProductStorageMockRecorder) RestoreProduct(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockIProductStorage)(nil).RestoreProduct), arg0)
+}
+
+// SetExchangeRate mocks base method.
+func (m *MockIProductStorage) SetExchangeRate(arg0 *datastruct.SetExchangeRateRequest) (*datastruct.SetExchangeRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExchangeRate", arg0)
+	ret0, _ := ret[0].(*datastruct.SetExchangeRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetExchangeRate indicates an expected call of SetExchangeRate.
+func (mr *MockIProductStorageMockRecorder) SetExchangeRate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExchangeRate", reflect.TypeOf((*MockIProductStorage)(nil).SetExchangeRate), arg0)
+}
+
+// SetVariantPrice mocks base method.
+func (m *MockIProductStorage) SetVariantPrice(arg0 *datastruct.SetVariantPriceRequest) (*datastruct.SetVariantPriceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVariantPrice", arg0)
+	ret0, _ := ret[0].(*datastruct.SetVariantPriceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVariantPrice indicates an expected call of SetVariantPrice.
+func (mr *MockIProductStorageMockRecorder) SetVariantPrice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVariantPrice", reflect.TypeOf((*MockIProductStorage)(nil).SetVariantPrice), arg0)
+}
+
+// UpdateProductAttributes mocks base method.
+func (m *MockIProductStorage) UpdateProductAttributes(arg0 *datastruct.UpdateProductAttributesRequest) (*datastruct.UpdateProductAttributesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductAttributes", arg0)
+	ret0, _ := ret[0].(*datastruct.UpdateProductAttributesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductAttributes indicates an expected call of UpdateProductAttributes.
+func (mr *MockIProductStorageMockRecorder) UpdateProductAttributes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductAttributes", reflect.TypeOf((*MockIProductStorage)(nil).UpdateProductAttributes), arg0)
+}
+
 // MockISupplierStorage is a mock of ISupplierStorage interface.
 type MockISupplierStorage struct {
 	ctrl     *gomock.Controller
@@ -521,6 +828,21 @@ func (mr *MockISupplierStorageMockRecorder) GetSupplier(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockISupplierStorage)(nil).GetSupplier), arg0)
 }
 
+// GetSupplierHistory mocks base method.
+func (m *MockISupplierStorage) GetSupplierHistory(arg0 *datastruct.GetHistoryRequest) (*datastruct.GetSupplierHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplierHistory", arg0)
+	ret0, _ := ret[0].(*datastruct.GetSupplierHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplierHistory indicates an expected call of GetSupplierHistory.
+func (mr *MockISupplierStorageMockRecorder) GetSupplierHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplierHistory", reflect.TypeOf((*MockISupplierStorage)(nil).GetSupplierHistory), arg0)
+}
+
 // GetSuppliers mocks base method.
 func (m *MockISupplierStorage) GetSuppliers(arg0 *datastruct.GetSuppliersRequest) (*datastruct.GetSuppliersResponse, error) {
 	m.ctrl.T.Helper()
@@ -536,6 +858,21 @@ func (mr *MockISupplierStorageMockRecorder) GetSuppliers(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuppliers", reflect.TypeOf((*MockISupplierStorage)(nil).GetSuppliers), arg0)
 }
 
+// RestoreSupplier mocks base method.
+func (m *MockISupplierStorage) RestoreSupplier(arg0 *datastruct.RestoreSupplierRequest) (*datastruct.RestoreSupplierResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSupplier", arg0)
+	ret0, _ := ret[0].(*datastruct.RestoreSupplierResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreSupplier indicates an expected call of RestoreSupplier.
+func (mr *MockISupplierStorageMockRecorder) RestoreSupplier(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSupplier", reflect.TypeOf((*MockISupplierStorage)(nil).RestoreSupplier), arg0)
+}
+
 // UpdateSupplierAddress mocks base method.
 func (m *MockISupplierStorage) UpdateSupplierAddress(arg0 *datastruct.UpdateSupplierAddressRequest) (*datastruct.UpdateSupplierAddressResponse, error) {
 	m.ctrl.T.Helper()
@@ -589,6 +926,21 @@ func (mr *MockIImageStorageMockRecorder) AddImage(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddImage", reflect.TypeOf((*MockIImageStorage)(nil).AddImage), arg0)
 }
 
+// AttachProductImage mocks base method.
+func (m *MockIImageStorage) AttachProductImage(arg0 *datastruct.AttachProductImageRequest) (*datastruct.AttachProductImageResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachProductImage", arg0)
+	ret0, _ := ret[0].(*datastruct.AttachProductImageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachProductImage indicates an expected call of AttachProductImage.
+func (mr *MockIImageStorageMockRecorder) AttachProductImage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachProductImage", reflect.TypeOf((*MockIImageStorage)(nil).AttachProductImage), arg0)
+}
+
 // DeleteImage mocks base method.
 func (m *MockIImageStorage) DeleteImage(arg0 *datastruct.DeleteImageRequest) (*datastruct.DeleteImageResponse, error) {
 	m.ctrl.T.Helper()
@@ -604,6 +956,21 @@ func (mr *MockIImageStorageMockRecorder) DeleteImage(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockIImageStorage)(nil).DeleteImage), arg0)
 }
 
+// DetachProductImage mocks base method.
+func (m *MockIImageStorage) DetachProductImage(arg0 *datastruct.DetachProductImageRequest) (*datastruct.DetachProductImageResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachProductImage", arg0)
+	ret0, _ := ret[0].(*datastruct.DetachProductImageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetachProductImage indicates an expected call of DetachProductImage.
+func (mr *MockIImageStorageMockRecorder) DetachProductImage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachProductImage", reflect.TypeOf((*MockIImageStorage)(nil).DetachProductImage), arg0)
+}
+
 // GetImage mocks base method.
 func (m *MockIImageStorage) GetImage(arg0 *datastruct.GetImageRequest) (*datastruct.GetImageResponse, error) {
 	m.ctrl.T.Helper()
@@ -619,6 +986,21 @@ func (mr *MockIImageStorageMockRecorder) GetImage(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImage", reflect.TypeOf((*MockIImageStorage)(nil).GetImage), arg0)
 }
 
+// GetImageMeta mocks base method.
+func (m *MockIImageStorage) GetImageMeta(arg0 *datastruct.GetImageMetaRequest) (*datastruct.GetImageMetaResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageMeta", arg0)
+	ret0, _ := ret[0].(*datastruct.GetImageMetaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImageMeta indicates an expected call of GetImageMeta.
+func (mr *MockIImageStorageMockRecorder) GetImageMeta(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageMeta", reflect.TypeOf((*MockIImageStorage)(nil).GetImageMeta), arg0)
+}
+
 // GetProductImage mocks base method.
 func (m *MockIImageStorage) GetProductImage(arg0 *datastruct.GetProductImageRequest) (*datastruct.GetProductImageResponse, error) {
 	m.ctrl.T.Helper()
@@ -634,6 +1016,36 @@ func (mr *MockIImageStorageMockRecorder) GetProductImage(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImage", reflect.TypeOf((*MockIImageStorage)(nil).GetProductImage), arg0)
 }
 
+// ReorderProductImages mocks base method.
+func (m *MockIImageStorage) ReorderProductImages(arg0 *datastruct.ReorderProductImagesRequest) (*datastruct.ReorderProductImagesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderProductImages", arg0)
+	ret0, _ := ret[0].(*datastruct.ReorderProductImagesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderProductImages indicates an expected call of ReorderProductImages.
+func (mr *MockIImageStorageMockRecorder) ReorderProductImages(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderProductImages", reflect.TypeOf((*MockIImageStorage)(nil).ReorderProductImages), arg0)
+}
+
+// RestoreImage mocks base method.
+func (m *MockIImageStorage) RestoreImage(arg0 *datastruct.RestoreImageRequest) (*datastruct.RestoreImageResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreImage", arg0)
+	ret0, _ := ret[0].(*datastruct.RestoreImageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreImage indicates an expected call of RestoreImage.
+func (mr *MockIImageStorageMockRecorder) RestoreImage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreImage", reflect.TypeOf((*MockIImageStorage)(nil).RestoreImage), arg0)
+}
+
 // UpdateImage mocks base method.
 func (m *MockIImageStorage) UpdateImage(arg0 *datastruct.UpdateImageRequest) (*datastruct.UpdateImageResponse, error) {
 	m.ctrl.T.Helper()
@@ -648,3 +1060,395 @@ func (mr *MockIImageStorageMockRecorder) UpdateImage(arg0 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImage", reflect.TypeOf((*MockIImageStorage)(nil).UpdateImage), arg0)
 }
+
+// MockICategoryStorage is a mock of ICategoryStorage interface.
+type MockICategoryStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockICategoryStorageMockRecorder
+}
+
+// MockICategoryStorageMockRecorder is the mock recorder for MockICategoryStorage.
+type MockICategoryStorageMockRecorder struct {
+	mock *MockICategoryStorage
+}
+
+// NewMockICategoryStorage creates a new mock instance.
+func NewMockICategoryStorage(ctrl *gomock.Controller) *MockICategoryStorage {
+	mock := &MockICategoryStorage{ctrl: ctrl}
+	mock.recorder = &MockICategoryStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICategoryStorage) EXPECT() *MockICategoryStorageMockRecorder {
+	return m.recorder
+}
+
+// AddCategory mocks base method.
+func (m *MockICategoryStorage) AddCategory(arg0 *datastruct.AddCategoryRequest) (*datastruct.AddCategoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCategory", arg0)
+	ret0, _ := ret[0].(*datastruct.AddCategoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCategory indicates an expected call of AddCategory.
+func (mr *MockICategoryStorageMockRecorder) AddCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCategory", reflect.TypeOf((*MockICategoryStorage)(nil).AddCategory), arg0)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockICategoryStorage) DeleteCategory(arg0 *datastruct.DeleteCategoryRequest) (*datastruct.DeleteCategoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0)
+	ret0, _ := ret[0].(*datastruct.DeleteCategoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockICategoryStorageMockRecorder) DeleteCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockICategoryStorage)(nil).DeleteCategory), arg0)
+}
+
+// GetCategoriesTree mocks base method.
+func (m *MockICategoryStorage) GetCategoriesTree(arg0 *datastruct.GetCategoriesTreeRequest) (*datastruct.GetCategoriesTreeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesTree", arg0)
+	ret0, _ := ret[0].(*datastruct.GetCategoriesTreeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoriesTree indicates an expected call of GetCategoriesTree.
+func (mr *MockICategoryStorageMockRecorder) GetCategoriesTree(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesTree", reflect.TypeOf((*MockICategoryStorage)(nil).GetCategoriesTree), arg0)
+}
+
+// GetCategory mocks base method.
+func (m *MockICategoryStorage) GetCategory(arg0 *datastruct.GetCategoryRequest) (*datastruct.GetCategoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0)
+	ret0, _ := ret[0].(*datastruct.GetCategoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockICategoryStorageMockRecorder) GetCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockICategoryStorage)(nil).GetCategory), arg0)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockICategoryStorage) UpdateCategory(arg0 *datastruct.UpdateCategoryRequest) (*datastruct.UpdateCategoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0)
+	ret0, _ := ret[0].(*datastruct.UpdateCategoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockICategoryStorageMockRecorder) UpdateCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockICategoryStorage)(nil).UpdateCategory), arg0)
+}
+
+// MockIAuthStorage is a mock of IAuthStorage interface.
+type MockIAuthStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuthStorageMockRecorder
+}
+
+// MockIAuthStorageMockRecorder is the mock recorder for MockIAuthStorage.
+type MockIAuthStorageMockRecorder struct {
+	mock *MockIAuthStorage
+}
+
+// NewMockIAuthStorage creates a new mock instance.
+func NewMockIAuthStorage(ctrl *gomock.Controller) *MockIAuthStorage {
+	mock := &MockIAuthStorage{ctrl: ctrl}
+	mock.recorder = &MockIAuthStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuthStorage) EXPECT() *MockIAuthStorageMockRecorder {
+	return m.recorder
+}
+
+// AddApiKey mocks base method.
+func (m *MockIAuthStorage) AddApiKey(arg0 *datastruct.IssueApiKeyRequest) (*datastruct.IssueApiKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddApiKey", arg0)
+	ret0, _ := ret[0].(*datastruct.IssueApiKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddApiKey indicates an expected call of AddApiKey.
+func (mr *MockIAuthStorageMockRecorder) AddApiKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddApiKey", reflect.TypeOf((*MockIAuthStorage)(nil).AddApiKey), arg0)
+}
+
+// AddRole mocks base method.
+func (m *MockIAuthStorage) AddRole(arg0 *datastruct.AddRoleRequest) (*datastruct.AddRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRole", arg0)
+	ret0, _ := ret[0].(*datastruct.AddRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRole indicates an expected call of AddRole.
+func (mr *MockIAuthStorageMockRecorder) AddRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRole", reflect.TypeOf((*MockIAuthStorage)(nil).AddRole), arg0)
+}
+
+// AuthenticateApiKey mocks base method.
+func (m *MockIAuthStorage) AuthenticateApiKey(arg0 *datastruct.AuthenticateApiKeyRequest) (*datastruct.AuthenticateApiKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateApiKey", arg0)
+	ret0, _ := ret[0].(*datastruct.AuthenticateApiKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateApiKey indicates an expected call of AuthenticateApiKey.
+func (mr *MockIAuthStorageMockRecorder) AuthenticateApiKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateApiKey", reflect.TypeOf((*MockIAuthStorage)(nil).AuthenticateApiKey), arg0)
+}
+
+// DeleteRole mocks base method.
+func (m *MockIAuthStorage) DeleteRole(arg0 *datastruct.DeleteRoleRequest) (*datastruct.DeleteRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", arg0)
+	ret0, _ := ret[0].(*datastruct.DeleteRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockIAuthStorageMockRecorder) DeleteRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockIAuthStorage)(nil).DeleteRole), arg0)
+}
+
+// GetApiKeys mocks base method.
+func (m *MockIAuthStorage) GetApiKeys(arg0 *datastruct.GetApiKeysRequest) (*datastruct.GetApiKeysResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeys", arg0)
+	ret0, _ := ret[0].(*datastruct.GetApiKeysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeys indicates an expected call of GetApiKeys.
+func (mr *MockIAuthStorageMockRecorder) GetApiKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeys", reflect.TypeOf((*MockIAuthStorage)(nil).GetApiKeys), arg0)
+}
+
+// GetPermissions mocks base method.
+func (m *MockIAuthStorage) GetPermissions(arg0 *datastruct.GetPermissionsRequest) (*datastruct.GetPermissionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissions", arg0)
+	ret0, _ := ret[0].(*datastruct.GetPermissionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissions indicates an expected call of GetPermissions.
+func (mr *MockIAuthStorageMockRecorder) GetPermissions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissions", reflect.TypeOf((*MockIAuthStorage)(nil).GetPermissions), arg0)
+}
+
+// GetRoles mocks base method.
+func (m *MockIAuthStorage) GetRoles(arg0 *datastruct.GetRolesRequest) (*datastruct.GetRolesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoles", arg0)
+	ret0, _ := ret[0].(*datastruct.GetRolesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoles indicates an expected call of GetRoles.
+func (mr *MockIAuthStorageMockRecorder) GetRoles(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockIAuthStorage)(nil).GetRoles), arg0)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockIAuthStorage) RevokeApiKey(arg0 *datastruct.RevokeApiKeyRequest) (*datastruct.RevokeApiKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeApiKey", arg0)
+	ret0, _ := ret[0].(*datastruct.RevokeApiKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockIAuthStorageMockRecorder) RevokeApiKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockIAuthStorage)(nil).RevokeApiKey), arg0)
+}
+
+// UpdateRole mocks base method.
+func (m *MockIAuthStorage) UpdateRole(arg0 *datastruct.UpdateRoleRequest) (*datastruct.UpdateRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", arg0)
+	ret0, _ := ret[0].(*datastruct.UpdateRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockIAuthStorageMockRecorder) UpdateRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockIAuthStorage)(nil).UpdateRole), arg0)
+}
+
+// MockIAuditStorage is a mock of IAuditStorage interface.
+type MockIAuditStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditStorageMockRecorder
+}
+
+// MockIAuditStorageMockRecorder is the mock recorder for MockIAuditStorage.
+type MockIAuditStorageMockRecorder struct {
+	mock *MockIAuditStorage
+}
+
+// NewMockIAuditStorage creates a new mock instance.
+func NewMockIAuditStorage(ctrl *gomock.Controller) *MockIAuditStorage {
+	mock := &MockIAuditStorage{ctrl: ctrl}
+	mock.recorder = &MockIAuditStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditStorage) EXPECT() *MockIAuditStorageMockRecorder {
+	return m.recorder
+}
+
+// GetAudit mocks base method.
+func (m *MockIAuditStorage) GetAudit(arg0 *datastruct.GetAuditRequest) (*datastruct.GetAuditResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAudit", arg0)
+	ret0, _ := ret[0].(*datastruct.GetAuditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAudit indicates an expected call of GetAudit.
+func (mr *MockIAuditStorageMockRecorder) GetAudit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAudit", reflect.TypeOf((*MockIAuditStorage)(nil).GetAudit), arg0)
+}
+
+// MockIPromotionStorage is a mock of IPromotionStorage interface.
+type MockIPromotionStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPromotionStorageMockRecorder
+}
+
+// MockIPromotionStorageMockRecorder is the mock recorder for MockIPromotionStorage.
+type MockIPromotionStorageMockRecorder struct {
+	mock *MockIPromotionStorage
+}
+
+// NewMockIPromotionStorage creates a new mock instance.
+func NewMockIPromotionStorage(ctrl *gomock.Controller) *MockIPromotionStorage {
+	mock := &MockIPromotionStorage{ctrl: ctrl}
+	mock.recorder = &MockIPromotionStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPromotionStorage) EXPECT() *MockIPromotionStorageMockRecorder {
+	return m.recorder
+}
+
+// AddPromotion mocks base method.
+func (m *MockIPromotionStorage) AddPromotion(arg0 *datastruct.AddPromotionRequest) (*datastruct.AddPromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPromotion", arg0)
+	ret0, _ := ret[0].(*datastruct.AddPromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPromotion indicates an expected call of AddPromotion.
+func (mr *MockIPromotionStorageMockRecorder) AddPromotion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPromotion", reflect.TypeOf((*MockIPromotionStorage)(nil).AddPromotion), arg0)
+}
+
+// GetApplicablePromotions mocks base method.
+func (m *MockIPromotionStorage) GetApplicablePromotions(arg0 *datastruct.GetApplicablePromotionsRequest) (*datastruct.GetApplicablePromotionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicablePromotions", arg0)
+	ret0, _ := ret[0].(*datastruct.GetApplicablePromotionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicablePromotions indicates an expected call of GetApplicablePromotions.
+func (mr *MockIPromotionStorageMockRecorder) GetApplicablePromotions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicablePromotions", reflect.TypeOf((*MockIPromotionStorage)(nil).GetApplicablePromotions), arg0)
+}
+
+// GetPromotions mocks base method.
+func (m *MockIPromotionStorage) GetPromotions(arg0 *datastruct.GetPromotionsRequest) (*datastruct.GetPromotionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotions", arg0)
+	ret0, _ := ret[0].(*datastruct.GetPromotionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotions indicates an expected call of GetPromotions.
+func (mr *MockIPromotionStorageMockRecorder) GetPromotions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotions", reflect.TypeOf((*MockIPromotionStorage)(nil).GetPromotions), arg0)
+}
+
+// RedeemPromotions mocks base method.
+func (m *MockIPromotionStorage) RedeemPromotions(arg0 *datastruct.RedeemPromotionsRequest) (*datastruct.RedeemPromotionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemPromotions", arg0)
+	ret0, _ := ret[0].(*datastruct.RedeemPromotionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemPromotions indicates an expected call of RedeemPromotions.
+func (mr *MockIPromotionStorageMockRecorder) RedeemPromotions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemPromotions", reflect.TypeOf((*MockIPromotionStorage)(nil).RedeemPromotions), arg0)
+}
+
+// UpdatePromotion mocks base method.
+func (m *MockIPromotionStorage) UpdatePromotion(arg0 *datastruct.UpdatePromotionRequest) (*datastruct.UpdatePromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePromotion", arg0)
+	ret0, _ := ret[0].(*datastruct.UpdatePromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePromotion indicates an expected call of UpdatePromotion.
+func (mr *MockIPromotionStorageMockRecorder) UpdatePromotion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePromotion", reflect.TypeOf((*MockIPromotionStorage)(nil).UpdatePromotion), arg0)
+}
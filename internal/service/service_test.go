@@ -5,34 +5,53 @@ import (
 	"errors"
 	"testing"
 
+	"shopapi/internal/mem_cache"
+
 	"github.com/golang/mock/gomock"
 )
 
 var errTest = errors.New("error")
 
 type TestService struct {
-	loggerMock          *MockILogger
-	cacheMock           *MockICache
-	clientStorageMock   *MockIClientStorage
-	productStorageMock  *MockIProductStorage
-	supplierStorageMock *MockISupplierStorage
-	imageStorageMock    *MockIImageStorage
-	srv                 *Service
+	loggerMock           *MockILogger
+	cacheMock            *MockICache
+	clientStorageMock    *MockIClientStorage
+	productStorageMock   *MockIProductStorage
+	supplierStorageMock  *MockISupplierStorage
+	imageStorageMock     *MockIImageStorage
+	categoryStorageMock  *MockICategoryStorage
+	authStorageMock      *MockIAuthStorage
+	auditStorageMock     *MockIAuditStorage
+	promotionStorageMock *MockIPromotionStorage
+	srv                  *Service
 }
 
 func NewTestService(t *testing.T) *TestService {
 	mc := gomock.NewController(t)
 	s := &TestService{
-		loggerMock:          NewMockILogger(mc),
-		cacheMock:           NewMockICache(mc),
-		clientStorageMock:   NewMockIClientStorage(mc),
-		imageStorageMock:    NewMockIImageStorage(mc),
-		productStorageMock:  NewMockIProductStorage(mc),
-		supplierStorageMock: NewMockISupplierStorage(mc),
+		loggerMock:           NewMockILogger(mc),
+		cacheMock:            NewMockICache(mc),
+		clientStorageMock:    NewMockIClientStorage(mc),
+		imageStorageMock:     NewMockIImageStorage(mc),
+		productStorageMock:   NewMockIProductStorage(mc),
+		supplierStorageMock:  NewMockISupplierStorage(mc),
+		categoryStorageMock:  NewMockICategoryStorage(mc),
+		authStorageMock:      NewMockIAuthStorage(mc),
+		auditStorageMock:     NewMockIAuditStorage(mc),
+		promotionStorageMock: NewMockIPromotionStorage(mc),
 	}
 
 	s.srv = NewService(context.Background(), s.loggerMock, s.cacheMock, s.clientStorageMock,
-		s.productStorageMock, s.supplierStorageMock, s.imageStorageMock)
+		s.productStorageMock, s.supplierStorageMock, s.imageStorageMock, s.categoryStorageMock, s.authStorageMock,
+		s.auditStorageMock, s.promotionStorageMock)
+
+	return s
+}
 
+// NewTestServiceWithCache caches in memory instead of the cache mock, so that the repeated calls see what
+// the earlier ones cached.
+func NewTestServiceWithCache(t *testing.T) *TestService {
+	s := NewTestService(t)
+	s.srv.cache = mem_cache.NewCache()
 	return s
 }
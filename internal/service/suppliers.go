@@ -50,12 +50,7 @@ func (s *Service) UpdateSupplierAddress(req *ds.UpdateSupplierAddressRequest) *d
 }
 
 func (s *Service) DeleteSupplier(req *ds.DeleteSupplierRequest) *ds.DeleteSupplierResponse {
-	key := makeCacheKey("DeleteSupplier", req.Uid.String())
-
-	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.DeleteSupplierResponse, error) {
-		return s.supplierStorage.DeleteSupplier(req)
-	})
-
+	resp, err := s.supplierStorage.DeleteSupplier(req)
 	if err != nil {
 		s.logger.ErrorKV("failed on DeleteSupplier", "message", err.Error())
 		return nil
@@ -66,8 +61,21 @@ func (s *Service) DeleteSupplier(req *ds.DeleteSupplierRequest) *ds.DeleteSuppli
 	return resp
 }
 
+func (s *Service) RestoreSupplier(req *ds.RestoreSupplierRequest) *ds.RestoreSupplierResponse {
+	resp, err := s.supplierStorage.RestoreSupplier(req)
+	if err != nil {
+		s.logger.ErrorKV("failed on RestoreSupplier", "message", err.Error())
+		return nil
+	}
+
+	s.logHandlerStatus("RestoreSupplier", resp.GetStatus())
+
+	return resp
+}
+
 func (s *Service) GetSuppliers(req *ds.GetSuppliersRequest) *ds.GetSuppliersResponse {
-	key := makeCacheKey("GetSuppliers", strconv.FormatInt(req.Limit, 10), strconv.FormatInt(req.Offset, 10))
+	key := makeCacheKey("GetSuppliers", strconv.FormatInt(req.Limit, 10), strconv.FormatInt(req.Offset, 10),
+		strconv.FormatBool(req.IncludeDeleted))
 
 	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetSuppliersResponse, error) {
 		return s.supplierStorage.GetSuppliers(req)
@@ -82,7 +90,7 @@ func (s *Service) GetSuppliers(req *ds.GetSuppliersRequest) *ds.GetSuppliersResp
 }
 
 func (s *Service) GetSupplier(req *ds.GetSupplierRequest) (resp *ds.GetSupplierResponse) {
-	key := makeCacheKey("GetSupplier", req.Uid.String())
+	key := makeCacheKey("GetSupplier", req.Uid.String(), strconv.FormatBool(req.IncludeDeleted), asOfKey(req.AsOf))
 
 	resp, err := execWithCache(s, key, req.AvoidCache(), func() (*ds.GetSupplierResponse, error) {
 		return s.supplierStorage.GetSupplier(req)
@@ -98,8 +98,6 @@ func TestDeleteSupplier(t *testing.T) {
 			Status: ds.Status{Message: "status"},
 		}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
-		s.cacheMock.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
 		s.supplierStorageMock.EXPECT().DeleteSupplier(gomock.Any()).Return(res, nil)
 		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
 
@@ -114,7 +112,6 @@ func TestDeleteSupplier(t *testing.T) {
 
 		req := &ds.DeleteSupplierRequest{}
 
-		s.cacheMock.EXPECT().Read(gomock.Any(), gomock.Any()).Return(false, nil)
 		s.supplierStorageMock.EXPECT().DeleteSupplier(gomock.Any()).Return(nil, errTest)
 		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
 
@@ -123,6 +120,42 @@ func TestDeleteSupplier(t *testing.T) {
 	})
 }
 
+func TestRestoreSupplier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RestoreSupplier ok", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.RestoreSupplierRequest{}
+
+		res := &ds.RestoreSupplierResponse{
+			Status: ds.Status{Message: "status"},
+		}
+
+		s.supplierStorageMock.EXPECT().RestoreSupplier(gomock.Any()).Return(res, nil)
+		s.loggerMock.EXPECT().InfoKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RestoreSupplier(req)
+		require.NotNil(t, resp)
+	})
+
+	t.Run("RestoreSupplier error", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewTestService(t)
+
+		req := &ds.RestoreSupplierRequest{}
+
+		s.supplierStorageMock.EXPECT().RestoreSupplier(gomock.Any()).Return(nil, errTest)
+		s.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.All())
+
+		resp := s.srv.RestoreSupplier(req)
+		require.Nil(t, resp)
+	})
+}
+
 func TestGetSuppliers(t *testing.T) {
 	t.Parallel()
 
@@ -2,13 +2,19 @@ package supports
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+
+	"shopapi/internal/barcode"
+	"shopapi/internal/money"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -19,10 +25,33 @@ const (
 	defaultPhoneRegion = "RU"
 )
 
-var validatorInstance validator.Validate = *validator.New()
+var validatorInstance = validator.New()
+
+var (
+	ErrTooLarge = errors.New("content exceeds size limit")
+	ErrRejected = errors.New("content is rejected by scanner")
+)
+
+func init() {
+	err := validatorInstance.RegisterValidation("barcode", func(fl validator.FieldLevel) bool {
+		return barcode.IsValid(fl.Field().String())
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// Money is validated as its amount in minor units, the one without a currency as missing.
+	validatorInstance.RegisterCustomTypeFunc(func(v reflect.Value) any {
+		m := v.Interface().(money.Money)
+		if m.IsZero() {
+			return nil
+		}
+		return m.Minor()
+	}, money.Money{})
+}
 
 func StructValidator() *validator.Validate {
-	return &validatorInstance
+	return validatorInstance
 }
 
 func MakeKVMessagesJSON(kvs ...any) (bytes []byte, err error) {
@@ -144,6 +173,10 @@ func IsFieldByteSlice(field reflect.Value) bool {
 	return field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8
 }
 
+func IsFieldReader(field reflect.Value) bool {
+	return field.Type() == reflect.TypeFor[io.Reader]()
+}
+
 func GetDateAsFileName(t time.Time) string {
 	return strings.ReplaceAll(
 		strings.ReplaceAll(
@@ -172,3 +205,54 @@ func Concat(ss ...string) string {
 
 	return b.String()
 }
+
+// Slugify lowercases the string and joins groups of letters and digits with '-'.
+func Slugify(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	pendingDash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if pendingDash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			pendingDash = false
+			continue
+		}
+		pendingDash = true
+	}
+
+	return b.String()
+}
+
+type limitedReader struct {
+	r    io.Reader
+	left int64
+}
+
+// LimitReader reads at most n bytes from r. Unlike io.LimitReader it fails with ErrTooLarge
+// when r has more, so a truncated content is never taken as a whole one.
+func LimitReader(r io.Reader, n int64) io.Reader {
+	return &limitedReader{r: r, left: n}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.left < 0 {
+		return 0, ErrTooLarge
+	}
+
+	// One byte over the limit is enough to know the content is too large.
+	if int64(len(p)) > l.left+1 {
+		p = p[:l.left+1]
+	}
+
+	n, err := l.r.Read(p)
+	l.left -= int64(n)
+	if l.left < 0 {
+		return n + int(l.left), ErrTooLarge
+	}
+
+	return n, err
+}
@@ -1,7 +1,9 @@
 package supports
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -119,6 +121,35 @@ func TestIsFieldByteSlice(t *testing.T) {
 	require.False(t, IsFieldByteSlice(field))
 }
 
+func TestIsFieldReader(t *testing.T) {
+	t.Parallel()
+
+	tt := &struct {
+		Reader io.Reader `fieldKey:"fieldValue"`
+		Bytes  []byte    `anotherFieldKey:"anotherFieldValue"`
+	}{}
+
+	_, field, err := GetStructFieldByTagKey(tt, "fieldKey")
+	require.Nil(t, err)
+	require.True(t, IsFieldReader(field))
+
+	_, field, err = GetStructFieldByTagKey(tt, "anotherFieldKey")
+	require.Nil(t, err)
+	require.False(t, IsFieldReader(field))
+}
+
+func TestLimitReader(t *testing.T) {
+	t.Parallel()
+
+	data, err := io.ReadAll(LimitReader(bytes.NewReader([]byte("12345")), 5))
+	require.Nil(t, err)
+	require.Equal(t, []byte("12345"), data)
+
+	data, err = io.ReadAll(LimitReader(bytes.NewReader([]byte("123456")), 5))
+	require.ErrorIs(t, err, ErrTooLarge)
+	require.Equal(t, []byte("12345"), data)
+}
+
 func TestGetDateAsFileName(t *testing.T) {
 	t.Parallel()
 
@@ -141,3 +172,12 @@ func TestConcat(t *testing.T) {
 	res := Concat("\"", "Cat", "_", "Dog", "\"")
 	require.Equal(t, res, "\"Cat_Dog\"")
 }
+
+func TestSlugify(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, Slugify("Construction "), "construction")
+	require.Equal(t, Slugify("  Wooden  Beams & Boards!"), "wooden-beams-boards")
+	require.Equal(t, Slugify("Стройматериалы 2026"), "стройматериалы-2026")
+	require.Equal(t, Slugify("--"), "")
+}
@@ -0,0 +1,109 @@
+package supports
+
+import (
+	"strings"
+	"unicode"
+)
+
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "",
+	'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// Ordered from the longest latin sequence to the shortest so digraphs win over single letters.
+var latinToCyrillic = []struct {
+	latin    string
+	cyrillic string
+}{
+	{"shch", "щ"},
+	{"zh", "ж"}, {"kh", "х"}, {"ts", "ц"}, {"ch", "ч"}, {"sh", "ш"},
+	{"yo", "ё"}, {"yu", "ю"}, {"ya", "я"},
+	{"a", "а"}, {"b", "б"}, {"v", "в"}, {"g", "г"}, {"d", "д"}, {"e", "е"},
+	{"z", "з"}, {"i", "и"}, {"y", "й"}, {"k", "к"}, {"l", "л"}, {"m", "м"},
+	{"n", "н"}, {"o", "о"}, {"p", "п"}, {"r", "р"}, {"s", "с"}, {"t", "т"},
+	{"u", "у"}, {"f", "ф"}, {"h", "х"}, {"c", "к"}, {"w", "в"}, {"x", "кс"},
+	{"j", "дж"}, {"q", "к"},
+}
+
+// Transliterate converts a Cyrillic string to Latin or a Latin string to Cyrillic
+// depending on which script prevails in it. Other symbols are kept as is.
+func Transliterate(s string) string {
+	if isMostlyCyrillic(s) {
+		return CyrillicToLatin(s)
+	}
+	return LatinToCyrillic(s)
+}
+
+func CyrillicToLatin(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for _, r := range s {
+		lower := unicode.ToLower(r)
+		latin, ok := cyrillicToLatin[lower]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+
+		if lower != r && latin != "" {
+			latin = strings.ToUpper(latin[:1]) + latin[1:]
+		}
+		b.WriteString(latin)
+	}
+
+	return b.String()
+}
+
+func LatinToCyrillic(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) * 2)
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		matched := false
+
+		for _, pair := range latinToCyrillic {
+			n := len(pair.latin)
+			if i+n > len(runes) || !strings.EqualFold(string(runes[i:i+n]), pair.latin) {
+				continue
+			}
+
+			cyrillic := pair.cyrillic
+			if unicode.IsUpper(runes[i]) {
+				first := []rune(cyrillic)
+				first[0] = unicode.ToUpper(first[0])
+				cyrillic = string(first)
+			}
+
+			b.WriteString(cyrillic)
+			i += n
+			matched = true
+			break
+		}
+
+		if !matched {
+			b.WriteRune(runes[i])
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+func isMostlyCyrillic(s string) bool {
+	cyrillic, latin := 0, 0
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+
+	return cyrillic > latin
+}
@@ -0,0 +1,33 @@
+package supports
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransliterate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Cyrillic to Latin", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "Vasilisa", Transliterate("Василиса"))
+		require.Equal(t, "Shchukina Yuliya", Transliterate("Щукина Юлия"))
+	})
+
+	t.Run("Latin to Cyrillic", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "Василиса", Transliterate("Vasilisa"))
+		require.Equal(t, "Жуков", Transliterate("Zhukov"))
+		require.Equal(t, "Щукина", Transliterate("Shchukina"))
+	})
+
+	t.Run("Keeps other symbols", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "Анна-Мария 2", Transliterate("Anna-Mariya 2"))
+		require.Equal(t, "", Transliterate(""))
+	})
+}
@@ -0,0 +1,170 @@
+package sweeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/service"
+	"shopapi/internal/supports"
+)
+
+//go:generate mockgen -source=sweeper.go -destination=sweeper_mock.go -package=sweeper IStorage
+
+const (
+	DefaultGracePeriod = 72 * time.Hour
+	DefaultRetention   = 30 * 24 * time.Hour
+	batch              = 100
+
+	interval_secret_path     = "./secrets/orphans_sweep_interval.txt"
+	grace_period_secret_path = "./secrets/orphans_grace_period.txt"
+	dry_run_secret_path      = "./secrets/orphans_dry_run.txt"
+	retention_secret_path    = "./secrets/deleted_retention.txt"
+)
+
+type IStorage interface {
+	SweepOrphans(*ds.SweepOrphansRequest) (*ds.SweepOrphansResponse, error)
+	PurgeDeleted(*ds.PurgeDeletedRequest) (*ds.PurgeDeletedResponse, error)
+}
+
+// Config of the orphans sweep and the trash purge. Zero Interval disables both in the background,
+// zero Retention disables the purge only.
+type Config struct {
+	Interval    time.Duration
+	GracePeriod time.Duration
+	DryRun      bool
+	Retention   time.Duration
+}
+
+// ReadConfig reads the sweep config from the secrets, the missing ones are defaulted.
+func ReadConfig() (Config, error) {
+	config := Config{GracePeriod: DefaultGracePeriod, Retention: DefaultRetention}
+
+	var err error
+	if config.Interval, err = readDuration(interval_secret_path, 0); err != nil {
+		return Config{}, err
+	}
+	if config.GracePeriod, err = readDuration(grace_period_secret_path, DefaultGracePeriod); err != nil {
+		return Config{}, err
+	}
+	if config.Retention, err = readDuration(retention_secret_path, DefaultRetention); err != nil {
+		return Config{}, err
+	}
+
+	value, err := supports.ReadSecret(dry_run_secret_path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Config{}, err
+	}
+	if err == nil {
+		if config.DryRun, err = strconv.ParseBool(value); err != nil {
+			return Config{}, fmt.Errorf("orphans dry run has to be true or false, got '%s'", value)
+		}
+	}
+
+	return config, nil
+}
+
+func readDuration(path string, defaultValue time.Duration) (time.Duration, error) {
+	value, err := supports.ReadSecret(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return defaultValue, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("'%s' has to be a non-negative duration as 24h, got '%s'", path, value)
+	}
+
+	return d, nil
+}
+
+type Sweeper struct {
+	storage IStorage
+	logger  service.ILogger
+	config  Config
+}
+
+func NewSweeper(storage IStorage, logger service.ILogger, config Config) *Sweeper {
+	return &Sweeper{
+		storage: storage,
+		logger:  logger,
+		config:  config,
+	}
+}
+
+// Sweep runs a single sweep and logs its result, the dry run logs every orphan past the grace period.
+func (s *Sweeper) Sweep() (*ds.SweepOrphansResponse, error) {
+	resp, err := s.storage.SweepOrphans(&ds.SweepOrphansRequest{
+		DryRun:      s.config.DryRun,
+		GracePeriod: s.config.GracePeriod,
+		Batch:       batch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.DryRun {
+		for _, img := range resp.Images {
+			s.logger.InfoKV("orphaned image", "uid", img.Uid.String(), "size", img.Size, "orphaned_at", img.OrphanedAt)
+		}
+		for _, a := range resp.Addresses {
+			s.logger.InfoKV("orphaned address", "id", a.Id, "orphaned_at", a.OrphanedAt)
+		}
+	}
+
+	s.logger.InfoKV("orphans swept", "dry_run", resp.DryRun, "marked", resp.Marked,
+		"images", len(resp.Images), "addresses", len(resp.Addresses))
+
+	return resp, nil
+}
+
+// Purge deletes for good the resources in the trash longer than the retention period and logs the result.
+// The addresses the purged clients and suppliers leave unused are swept as orphans afterwards.
+func (s *Sweeper) Purge() (*ds.PurgeDeletedResponse, error) {
+	resp, err := s.storage.PurgeDeleted(&ds.PurgeDeletedRequest{
+		Retention: s.config.Retention,
+		Batch:     batch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoKV("deleted purged", "products", len(resp.Products), "images", len(resp.Images),
+		"suppliers", len(resp.Suppliers), "clients", len(resp.Clients))
+
+	return resp, nil
+}
+
+// Run purges the trash and sweeps by the interval until the context is done, a failure is logged and retried
+// by the next run.
+func (s *Sweeper) Run(ctx context.Context) {
+	if s.config.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if s.config.Retention > 0 {
+				if _, err := s.Purge(); err != nil {
+					s.logger.ErrorKV("failed purging deleted", "error", err.Error())
+				}
+			}
+			if _, err := s.Sweep(); err != nil {
+				s.logger.ErrorKV("failed sweeping orphans", "error", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package sweeper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ds "shopapi/internal/datastruct"
+	"shopapi/internal/service"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test error")
+
+type TestSweeper struct {
+	storageMock *MockIStorage
+	loggerMock  *service.MockILogger
+}
+
+func NewTestSweeper(t *testing.T) *TestSweeper {
+	ctrl := gomock.NewController(t)
+
+	return &TestSweeper{
+		storageMock: NewMockIStorage(ctrl),
+		loggerMock:  service.NewMockILogger(ctrl),
+	}
+}
+
+func TestReadConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ReadConfig defaults", func(t *testing.T) {
+		t.Parallel()
+
+		config, err := ReadConfig()
+		require.Nil(t, err)
+		require.Equal(t, Config{GracePeriod: DefaultGracePeriod, Retention: DefaultRetention}, config)
+	})
+}
+
+func TestSweep(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Sweep Ok", func(t *testing.T) {
+		t.Parallel()
+
+		ts := NewTestSweeper(t)
+
+		config := Config{GracePeriod: time.Hour}
+		resp := &ds.SweepOrphansResponse{Marked: 1, Images: []ds.OrphanedImage{{Uid: uuid.New()}}}
+		ts.storageMock.EXPECT().SweepOrphans(&ds.SweepOrphansRequest{GracePeriod: time.Hour, Batch: batch}).Return(resp, nil)
+		ts.loggerMock.EXPECT().InfoKV("orphans swept", "dry_run", false, "marked", int64(1), "images", 1, "addresses", 0)
+
+		got, err := NewSweeper(ts.storageMock, ts.loggerMock, config).Sweep()
+		require.Nil(t, err)
+		require.Equal(t, resp, got)
+	})
+
+	t.Run("Sweep dry run logs orphans", func(t *testing.T) {
+		t.Parallel()
+
+		ts := NewTestSweeper(t)
+
+		resp := &ds.SweepOrphansResponse{
+			DryRun:    true,
+			Images:    []ds.OrphanedImage{{Uid: uuid.New()}, {Uid: uuid.New()}},
+			Addresses: []ds.OrphanedAddress{{Id: 1}},
+		}
+		ts.storageMock.EXPECT().SweepOrphans(gomock.Any()).DoAndReturn(
+			func(req *ds.SweepOrphansRequest) (*ds.SweepOrphansResponse, error) {
+				require.True(t, req.DryRun)
+				return resp, nil
+			})
+		ts.loggerMock.EXPECT().InfoKV("orphaned image", gomock.Any()).Times(2)
+		ts.loggerMock.EXPECT().InfoKV("orphaned address", gomock.Any())
+		ts.loggerMock.EXPECT().InfoKV("orphans swept", gomock.Any())
+
+		_, err := NewSweeper(ts.storageMock, ts.loggerMock, Config{DryRun: true}).Sweep()
+		require.Nil(t, err)
+	})
+
+	t.Run("Sweep error on SweepOrphans", func(t *testing.T) {
+		t.Parallel()
+
+		ts := NewTestSweeper(t)
+
+		ts.storageMock.EXPECT().SweepOrphans(gomock.Any()).Return(nil, errTest)
+
+		resp, err := NewSweeper(ts.storageMock, ts.loggerMock, Config{}).Sweep()
+		require.True(t, errors.Is(err, errTest))
+		require.Nil(t, resp)
+	})
+}
+
+func TestPurge(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Purge Ok", func(t *testing.T) {
+		t.Parallel()
+
+		ts := NewTestSweeper(t)
+
+		resp := &ds.PurgeDeletedResponse{Products: []uuid.UUID{uuid.New()}, Clients: []uuid.UUID{uuid.New(), uuid.New()}}
+		ts.storageMock.EXPECT().PurgeDeleted(&ds.PurgeDeletedRequest{Retention: time.Hour, Batch: batch}).Return(resp, nil)
+		ts.loggerMock.EXPECT().InfoKV("deleted purged", "products", 1, "images", 0, "suppliers", 0, "clients", 2)
+
+		got, err := NewSweeper(ts.storageMock, ts.loggerMock, Config{Retention: time.Hour}).Purge()
+		require.Nil(t, err)
+		require.Equal(t, resp, got)
+	})
+
+	t.Run("Purge error on PurgeDeleted", func(t *testing.T) {
+		t.Parallel()
+
+		ts := NewTestSweeper(t)
+
+		ts.storageMock.EXPECT().PurgeDeleted(gomock.Any()).Return(nil, errTest)
+
+		resp, err := NewSweeper(ts.storageMock, ts.loggerMock, Config{Retention: time.Hour}).Purge()
+		require.True(t, errors.Is(err, errTest))
+		require.Nil(t, resp)
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Run sweeps until done", func(t *testing.T) {
+		t.Parallel()
+
+		ts := NewTestSweeper(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		// A tick may come before the cancel is seen, so the sweep can repeat.
+		ts.storageMock.EXPECT().SweepOrphans(gomock.Any()).Return(nil, errTest).MinTimes(1)
+		ts.loggerMock.EXPECT().ErrorKV("failed sweeping orphans", gomock.Any()).Do(func(string, ...any) { cancel() }).MinTimes(1)
+
+		done := make(chan struct{})
+		go func() {
+			NewSweeper(ts.storageMock, ts.loggerMock, Config{Interval: time.Millisecond}).Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Run hasn't stopped")
+		}
+	})
+
+	t.Run("Run purges before sweeping", func(t *testing.T) {
+		t.Parallel()
+
+		ts := NewTestSweeper(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		gomock.InOrder(
+			ts.storageMock.EXPECT().PurgeDeleted(gomock.Any()).Return(nil, errTest),
+			ts.loggerMock.EXPECT().ErrorKV("failed purging deleted", gomock.Any()),
+			ts.storageMock.EXPECT().SweepOrphans(gomock.Any()).Return(nil, errTest),
+			ts.loggerMock.EXPECT().ErrorKV("failed sweeping orphans", gomock.Any()).Do(func(string, ...any) { cancel() }),
+		)
+		// A tick may come before the cancel is seen, so the run can repeat.
+		ts.storageMock.EXPECT().PurgeDeleted(gomock.Any()).Return(nil, errTest).AnyTimes()
+		ts.storageMock.EXPECT().SweepOrphans(gomock.Any()).Return(nil, errTest).AnyTimes()
+		ts.loggerMock.EXPECT().ErrorKV(gomock.Any(), gomock.Any()).AnyTimes()
+
+		done := make(chan struct{})
+		go func() {
+			NewSweeper(ts.storageMock, ts.loggerMock, Config{Interval: time.Millisecond, Retention: time.Hour}).Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Run hasn't stopped")
+		}
+	})
+
+	t.Run("Run disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ts := NewTestSweeper(t)
+
+		NewSweeper(ts.storageMock, ts.loggerMock, Config{}).Run(context.Background())
+	})
+}
@@ -0,0 +1,204 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	FitCover   = "cover"
+	FitContain = "contain"
+	FitFill    = "fill"
+
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+
+	// MaxSide bounds the requested width and height.
+	MaxSide = 2000
+	// MaxSourcePixels bounds the decoded source, so a small file can't expand into a huge bitmap.
+	MaxSourcePixels = 40_000_000
+
+	jpegQuality = 85
+)
+
+var (
+	// ErrUnsupported is returned when the image can't be decoded or encoded into the requested format.
+	ErrUnsupported = errors.New("image can not be resized")
+	ErrInvalidSize = fmt.Errorf("width and height have to be in range 0..%d and not both zero", MaxSide)
+)
+
+// Options describe the variant to generate. Zero width or height keeps the aspect ratio by the other side.
+// Empty fit means cover, empty format keeps the source format if it can be encoded or falls back to png.
+// Only jpeg and png are encoded, so a gif or webp source becomes png.
+type Options struct {
+	Width  int
+	Height int
+	Fit    string
+	Format string
+}
+
+// Resize decodes jpeg, png, gif or webp data and returns it resized and encoded according to opts.
+func Resize(data []byte, opts Options) ([]byte, error) {
+	if opts.Width < 0 || opts.Height < 0 || opts.Width > MaxSide || opts.Height > MaxSide ||
+		opts.Width == 0 && opts.Height == 0 {
+		return nil, ErrInvalidSize
+	}
+
+	cfg, srcFormat, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupported, err)
+	}
+	if cfg.Width == 0 || cfg.Height == 0 || cfg.Width*cfg.Height > MaxSourcePixels {
+		return nil, fmt.Errorf("%w: source is %dx%d", ErrUnsupported, cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupported, err)
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = srcFormat
+		if format != FormatJPEG {
+			format = FormatPNG
+		}
+	}
+
+	dst := resizeImage(toRGBA(src), opts)
+
+	var b bytes.Buffer
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&b, dst, &jpeg.Options{Quality: jpegQuality})
+	case FormatPNG:
+		err = png.Encode(&b, dst)
+	default:
+		return nil, fmt.Errorf("%w: format '%s'", ErrUnsupported, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Rect, src, b.Min, draw.Src)
+	return rgba
+}
+
+// resizeImage scales the source to the target size by fit, cover crops the center of the scaled source.
+func resizeImage(src *image.RGBA, opts Options) *image.RGBA {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	w, h := opts.Width, opts.Height
+
+	switch {
+	case w == 0:
+		w = max(1, sw*h/sh)
+	case h == 0:
+		h = max(1, sh*w/sw)
+	}
+
+	crop := src.Rect
+	switch opts.Fit {
+	case FitFill:
+	case FitContain:
+		if sw*h > sh*w {
+			h = max(1, sh*w/sw)
+		} else {
+			w = max(1, sw*h/sh)
+		}
+	default:
+		if sw*h > sh*w {
+			cw := sh * w / h
+			crop = image.Rect((sw-cw)/2, 0, (sw-cw)/2+cw, sh)
+		} else {
+			ch := sw * h / w
+			crop = image.Rect(0, (sh-ch)/2, sw, (sh-ch)/2+ch)
+		}
+	}
+
+	return scale(src.SubImage(crop).(*image.RGBA), w, h)
+}
+
+// scale resamples src to w x h averaging the covered source area, in two separable passes.
+func scale(src *image.RGBA, w, h int) *image.RGBA {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+
+	tmp := make([]float64, w*sh*4)
+	xWeights := makeWeights(sw, w)
+	for y := 0; y < sh; y++ {
+		row := src.Pix[src.PixOffset(src.Rect.Min.X, src.Rect.Min.Y+y):]
+		for x, ws := range xWeights {
+			var px [4]float64
+			for _, wt := range ws {
+				for c := range px {
+					px[c] += float64(row[wt.index*4+c]) * wt.weight
+				}
+			}
+			copy(tmp[(y*w+x)*4:], px[:])
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	yWeights := makeWeights(sh, h)
+	for x := 0; x < w; x++ {
+		for y, ws := range yWeights {
+			var px [4]float64
+			for _, wt := range ws {
+				for c := range px {
+					px[c] += tmp[(wt.index*w+x)*4+c] * wt.weight
+				}
+			}
+			for c := range px {
+				dst.Pix[y*dst.Stride+x*4+c] = uint8(min(255, px[c]+0.5))
+			}
+		}
+	}
+
+	return dst
+}
+
+type weight struct {
+	index  int
+	weight float64
+}
+
+// makeWeights maps every target pixel to the source pixels it covers with their coverage share.
+// When upscaling a target pixel covers a part of a single source pixel, so it is the nearest one.
+func makeWeights(from, to int) [][]weight {
+	ratio := float64(from) / float64(to)
+	weights := make([][]weight, to)
+
+	for i := range weights {
+		start := float64(i) * ratio
+		end := start + ratio
+		if ratio < 1 {
+			weights[i] = []weight{{index: min(from-1, int(start+ratio/2)), weight: 1}}
+			continue
+		}
+
+		for j := int(start); j < from && float64(j) < end; j++ {
+			cover := min(end, float64(j+1)) - max(start, float64(j))
+			if cover > 0 {
+				weights[i] = append(weights[i], weight{index: j, weight: cover / ratio})
+			}
+		}
+	}
+
+	return weights
+}
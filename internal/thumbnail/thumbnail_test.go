@@ -0,0 +1,147 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"shopapi/internal/supports"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testWebP is a 1x1 lossy webp.
+var testWebP = []byte{
+	0x52, 0x49, 0x46, 0x46, 0x22, 0x00, 0x00, 0x00, 0x57, 0x45, 0x42, 0x50, 0x56, 0x50, 0x38, 0x20,
+	0x16, 0x00, 0x00, 0x00, 0x30, 0x01, 0x00, 0x9d, 0x01, 0x2a, 0x01, 0x00, 0x01, 0x00, 0x0e, 0xc0,
+	0xfe, 0x25, 0xa4, 0x00, 0x03, 0x70, 0x00, 0x00, 0x00, 0x00,
+}
+
+func decode(t *testing.T, data []byte) (image.Image, string) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	require.Nil(t, err)
+	return img, format
+}
+
+func TestResize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Resize cover", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := Resize(supports.TestImage, Options{Width: 200, Height: 200})
+		require.Nil(t, err)
+
+		img, format := decode(t, data)
+		require.Equal(t, "jpeg", format)
+		require.Equal(t, image.Rect(0, 0, 200, 200), img.Bounds())
+	})
+
+	t.Run("Resize contain", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := Resize(supports.TestImage, Options{Width: 200, Height: 200, Fit: FitContain, Format: FormatPNG})
+		require.Nil(t, err)
+
+		img, format := decode(t, data)
+		require.Equal(t, "png", format)
+		require.Equal(t, image.Rect(0, 0, 200, 112), img.Bounds())
+	})
+
+	t.Run("Resize fill", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := Resize(supports.TestImage, Options{Width: 100, Height: 300, Fit: FitFill})
+		require.Nil(t, err)
+
+		img, _ := decode(t, data)
+		require.Equal(t, image.Rect(0, 0, 100, 300), img.Bounds())
+	})
+
+	t.Run("Resize keeps aspect ratio by one side", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := Resize(supports.TestImage, Options{Height: 90})
+		require.Nil(t, err)
+
+		img, _ := decode(t, data)
+		require.Equal(t, image.Rect(0, 0, 160, 90), img.Bounds())
+	})
+
+	t.Run("Resize averages colors", func(t *testing.T) {
+		t.Parallel()
+
+		src := image.NewRGBA(image.Rect(0, 0, 4, 4))
+		for y := 0; y < 4; y++ {
+			for x := 0; x < 4; x++ {
+				if x%2 == 0 {
+					src.Set(x, y, color.RGBA{R: 200, A: 255})
+				} else {
+					src.Set(x, y, color.RGBA{B: 100, A: 255})
+				}
+			}
+		}
+		var b bytes.Buffer
+		require.Nil(t, png.Encode(&b, src))
+
+		data, err := Resize(b.Bytes(), Options{Width: 2, Height: 2})
+		require.Nil(t, err)
+
+		img, format := decode(t, data)
+		require.Equal(t, "png", format)
+		require.Equal(t, color.RGBA{R: 100, B: 50, A: 255}, color.RGBAModel.Convert(img.At(1, 1)))
+	})
+
+	t.Run("Resize upscale", func(t *testing.T) {
+		t.Parallel()
+
+		src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+		src.Set(0, 0, color.RGBA{R: 255, A: 255})
+		src.Set(1, 0, color.RGBA{G: 255, A: 255})
+		var b bytes.Buffer
+		require.Nil(t, png.Encode(&b, src))
+
+		data, err := Resize(b.Bytes(), Options{Width: 4, Height: 2, Fit: FitFill})
+		require.Nil(t, err)
+
+		img, _ := decode(t, data)
+		require.Equal(t, color.RGBA{R: 255, A: 255}, color.RGBAModel.Convert(img.At(1, 1)))
+		require.Equal(t, color.RGBA{G: 255, A: 255}, color.RGBAModel.Convert(img.At(2, 0)))
+	})
+
+	t.Run("Resize webp", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := Resize(testWebP, Options{Width: 4, Height: 4})
+		require.Nil(t, err)
+
+		img, format := decode(t, data)
+		require.Equal(t, "png", format)
+		require.Equal(t, image.Rect(0, 0, 4, 4), img.Bounds())
+
+		_, err = Resize(testWebP, Options{Width: 4, Format: "webp"})
+		require.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("Resize error on size", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Resize(supports.TestImage, Options{})
+		require.ErrorIs(t, err, ErrInvalidSize)
+
+		_, err = Resize(supports.TestImage, Options{Width: MaxSide + 1})
+		require.ErrorIs(t, err, ErrInvalidSize)
+	})
+
+	t.Run("Resize error on unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Resize([]byte("RIFF\x00\x00\x00\x00WEBPVP8 "), Options{Width: 10})
+		require.ErrorIs(t, err, ErrUnsupported)
+
+		_, err = Resize(supports.TestImage, Options{Width: 10, Format: "webp"})
+		require.ErrorIs(t, err, ErrUnsupported)
+	})
+}
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS clients_full_name_trgm_idx
+ON clients USING GIN ((client_name || ' ' || client_surname) gin_trgm_ops);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS clients_full_name_trgm_idx;
+DROP EXTENSION IF EXISTS pg_trgm;
+
+-- +goose StatementEnd
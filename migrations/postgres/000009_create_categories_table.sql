@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS categories (
+    "uid" UUID NOT NULL,
+    parent_id UUID REFERENCES categories(uid) ON DELETE RESTRICT,
+    "name" TEXT NOT NULL,
+    slug TEXT NOT NULL,
+    sort_order INT NOT NULL DEFAULT 0,
+
+    UNIQUE(uid),
+    UNIQUE(slug)
+);
+
+INSERT INTO categories (uid, "name", slug)
+SELECT gen_random_uuid(), MIN(TRIM(p.category)), s.slug
+FROM products p,
+    LATERAL (SELECT TRIM(BOTH '-' FROM LOWER(REGEXP_REPLACE(TRIM(p.category), '[^[:alnum:]]+', '-', 'g'))) AS slug) s
+GROUP BY s.slug;
+
+ALTER TABLE products ADD COLUMN category_id UUID REFERENCES categories(uid) ON DELETE RESTRICT;
+
+UPDATE products p
+SET category_id = c.uid
+FROM categories c
+WHERE c.slug = TRIM(BOTH '-' FROM LOWER(REGEXP_REPLACE(TRIM(p.category), '[^[:alnum:]]+', '-', 'g')));
+
+ALTER TABLE products ALTER COLUMN category_id SET NOT NULL;
+
+ALTER TABLE products DROP COLUMN category;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE products ADD COLUMN category TEXT;
+
+UPDATE products p
+SET category = c.name
+FROM categories c
+WHERE c.uid = p.category_id;
+
+ALTER TABLE products ALTER COLUMN category SET NOT NULL;
+
+ALTER TABLE products DROP COLUMN category_id;
+
+DROP TABLE IF EXISTS categories;
+
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE products ADD COLUMN options JSONB NOT NULL DEFAULT '[]';
+
+CREATE TABLE IF NOT EXISTS product_variants (
+    "uid" UUID NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(uid) ON DELETE CASCADE,
+    sku TEXT NOT NULL,
+    options JSONB NOT NULL DEFAULT '{}',
+    price BIGINT NOT NULL,
+    available_stock BIGINT NOT NULL,
+    image_id UUID,
+
+    UNIQUE(uid),
+    UNIQUE(sku),
+    UNIQUE(product_id, options)
+);
+
+CREATE INDEX IF NOT EXISTS product_variants_product_id_idx ON product_variants (product_id);
+
+INSERT INTO product_variants (uid, product_id, sku, price, available_stock)
+SELECT p.uid, p.uid, p.uid::text, p.price, p.available_stock
+FROM products p;
+
+ALTER TABLE products DROP COLUMN price;
+ALTER TABLE products DROP COLUMN available_stock;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE products ADD COLUMN price BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN available_stock BIGINT NOT NULL DEFAULT 0;
+
+UPDATE products p
+SET price = v.price,
+    available_stock = v.available_stock
+FROM (
+    SELECT DISTINCT ON (product_id) product_id, price, available_stock
+    FROM product_variants
+    ORDER BY product_id, sku
+) v
+WHERE v.product_id = p.uid;
+
+ALTER TABLE products ALTER COLUMN price DROP DEFAULT;
+ALTER TABLE products ALTER COLUMN available_stock DROP DEFAULT;
+
+DROP TABLE IF EXISTS product_variants;
+
+ALTER TABLE products DROP COLUMN options;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE categories ADD COLUMN attribute_schema JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE products ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS products_attributes_idx ON products USING GIN (attributes jsonb_path_ops);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS products_attributes_idx;
+
+ALTER TABLE products DROP COLUMN attributes;
+
+ALTER TABLE categories DROP COLUMN attribute_schema;
+
+-- +goose StatementEnd
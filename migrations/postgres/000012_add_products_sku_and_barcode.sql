@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE products ADD COLUMN sku TEXT;
+ALTER TABLE products ADD COLUMN barcode TEXT;
+
+UPDATE products SET sku = uid::text;
+
+ALTER TABLE products ALTER COLUMN sku SET NOT NULL;
+ALTER TABLE products ADD CONSTRAINT products_sku_key UNIQUE (sku);
+ALTER TABLE products ADD CONSTRAINT products_barcode_key UNIQUE (barcode);
+
+ALTER TABLE product_variants ADD COLUMN barcode TEXT;
+ALTER TABLE product_variants ADD CONSTRAINT product_variants_barcode_key UNIQUE (barcode);
+
+-- The unique constraints hold within a table, the triggers keep a code of a product from being used by a
+-- variant of another one and the other way around, so a lookup by code finds a single product.
+CREATE OR REPLACE FUNCTION products_codes_unique() RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM product_variants v
+        WHERE v.product_id <> NEW.uid AND (v.sku = NEW.sku OR v.barcode = NEW.barcode)
+    ) THEN
+        RAISE EXCEPTION 'sku % or barcode % is used by a variant of another product', NEW.sku, NEW.barcode
+            USING ERRCODE = 'unique_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION product_variants_codes_unique() RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM products p
+        WHERE p.uid <> NEW.product_id AND (p.sku = NEW.sku OR p.barcode = NEW.barcode)
+    ) THEN
+        RAISE EXCEPTION 'sku % or barcode % is used by another product', NEW.sku, NEW.barcode
+            USING ERRCODE = 'unique_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_codes_unique
+BEFORE INSERT OR UPDATE OF sku, barcode ON products
+FOR EACH ROW EXECUTE FUNCTION products_codes_unique();
+
+CREATE TRIGGER product_variants_codes_unique
+BEFORE INSERT OR UPDATE OF sku, barcode, product_id ON product_variants
+FOR EACH ROW EXECUTE FUNCTION product_variants_codes_unique();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS product_variants_codes_unique ON product_variants;
+DROP TRIGGER IF EXISTS products_codes_unique ON products;
+DROP FUNCTION IF EXISTS product_variants_codes_unique();
+DROP FUNCTION IF EXISTS products_codes_unique();
+
+ALTER TABLE product_variants DROP COLUMN barcode;
+
+ALTER TABLE products DROP COLUMN barcode;
+ALTER TABLE products DROP COLUMN sku;
+
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS product_images (
+    product_id UUID NOT NULL REFERENCES products(uid) ON DELETE CASCADE,
+    image_id UUID NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    alt_text TEXT NOT NULL DEFAULT '',
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+
+    PRIMARY KEY (product_id, image_id)
+);
+
+-- A product has at most one primary image.
+CREATE UNIQUE INDEX IF NOT EXISTS product_images_primary_idx ON product_images (product_id) WHERE is_primary;
+
+INSERT INTO product_images (product_id, image_id, position, is_primary)
+SELECT p.uid, p.image_id, 0, TRUE
+FROM products p;
+
+ALTER TABLE products DROP COLUMN image_id;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE products ADD COLUMN image_id UUID;
+
+UPDATE products p
+SET image_id = pi.image_id
+FROM product_images pi
+WHERE pi.product_id = p.uid AND pi.is_primary;
+
+ALTER TABLE products ALTER COLUMN image_id SET NOT NULL;
+
+DROP TABLE IF EXISTS product_images;
+
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Image content moves to the blob storage, the table keeps only metadata.
+-- The image column holds the content of rows not yet moved by `migrator images`.
+ALTER TABLE images ALTER COLUMN image DROP NOT NULL;
+
+ALTER TABLE images ADD COLUMN size BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE images ADD COLUMN mime TEXT NOT NULL DEFAULT 'application/octet-stream';
+ALTER TABLE images ADD COLUMN hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE images ADD COLUMN storage_key TEXT;
+
+UPDATE images SET size = length(image), hash = encode(sha256(image), 'hex');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Images already moved to the blob storage have no content here and can't be rolled back.
+DELETE FROM images WHERE image IS NULL;
+
+ALTER TABLE images DROP COLUMN storage_key;
+ALTER TABLE images DROP COLUMN hash;
+ALTER TABLE images DROP COLUMN mime;
+ALTER TABLE images DROP COLUMN size;
+
+ALTER TABLE images ALTER COLUMN image SET NOT NULL;
+
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Served as Last-Modified of the image.
+ALTER TABLE images ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE images DROP COLUMN updated_at;
+
+-- +goose StatementEnd
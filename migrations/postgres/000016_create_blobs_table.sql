@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Image content is stored once per hash, images refer to it by the storage key.
+-- ref_count is the number of images referring to the blob, the content is deleted when it drops to zero.
+CREATE TABLE IF NOT EXISTS blobs (
+    "storage_key" TEXT PRIMARY KEY,
+    "hash" TEXT NOT NULL UNIQUE,
+    "size" BIGINT NOT NULL,
+    "mime" TEXT NOT NULL,
+    "ref_count" INTEGER NOT NULL CHECK (ref_count >= 0)
+);
+
+INSERT INTO blobs (storage_key, hash, size, mime, ref_count)
+SELECT DISTINCT ON (hash) storage_key, hash, size, mime, 0
+FROM images
+WHERE storage_key IS NOT NULL
+ORDER BY hash, storage_key;
+
+-- Duplicates already moved refer to the kept blob, their own content is left unreferenced in the storage.
+UPDATE images i
+SET storage_key = b.storage_key
+FROM blobs b
+WHERE i.hash = b.hash AND i.storage_key IS NOT NULL AND i.storage_key <> b.storage_key;
+
+UPDATE blobs b
+SET ref_count = (SELECT count(*) FROM images i WHERE i.storage_key = b.storage_key);
+
+ALTER TABLE images ADD CONSTRAINT images_storage_key_fkey
+    FOREIGN KEY (storage_key) REFERENCES blobs (storage_key);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE images DROP CONSTRAINT images_storage_key_fkey;
+
+DROP TABLE IF EXISTS blobs;
+
+-- +goose StatementEnd
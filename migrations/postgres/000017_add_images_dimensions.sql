@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Recorded by decoding the image on upload, zero and empty for images uploaded before.
+ALTER TABLE images ADD COLUMN width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE images ADD COLUMN height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE images ADD COLUMN format TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE images DROP COLUMN format;
+ALTER TABLE images DROP COLUMN height;
+ALTER TABLE images DROP COLUMN width;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Documents such as spec sheets of products, their content is stored as blobs shared by the hash as images do.
+CREATE TABLE IF NOT EXISTS product_documents (
+    "uid" UUID PRIMARY KEY,
+    "product_id" UUID NOT NULL REFERENCES products(uid) ON DELETE CASCADE,
+    "title" TEXT NOT NULL,
+    "storage_key" TEXT NOT NULL REFERENCES blobs(storage_key),
+    "size" BIGINT NOT NULL,
+    "mime" TEXT NOT NULL,
+    "hash" TEXT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS product_documents_product_idx ON product_documents (product_id, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS product_documents;
+
+-- +goose StatementEnd
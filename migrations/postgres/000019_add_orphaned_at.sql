@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Set by the orphans sweep when nothing refers to the row, the row is deleted once the grace period
+-- since the mark passes. A new reference clears the mark.
+ALTER TABLE images ADD COLUMN orphaned_at TIMESTAMPTZ;
+ALTER TABLE addresses ADD COLUMN orphaned_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS product_variants_image_id_idx ON product_variants (image_id);
+CREATE INDEX IF NOT EXISTS product_images_image_id_idx ON product_images (image_id);
+CREATE INDEX IF NOT EXISTS clients_address_id_idx ON clients (address_id);
+CREATE INDEX IF NOT EXISTS suppliers_address_id_idx ON suppliers (address_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS suppliers_address_id_idx;
+DROP INDEX IF EXISTS clients_address_id_idx;
+DROP INDEX IF EXISTS product_images_image_id_idx;
+DROP INDEX IF EXISTS product_variants_image_id_idx;
+
+ALTER TABLE addresses DROP COLUMN orphaned_at;
+ALTER TABLE images DROP COLUMN orphaned_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The keys can't be added over dangling references, `migrator references` lists them to be fixed first.
+DO $$
+DECLARE
+    dangling BIGINT;
+BEGIN
+    SELECT (SELECT COUNT(*) FROM products p
+            WHERE NOT EXISTS (SELECT 1 FROM suppliers s WHERE s.uid = p.supplier_id))
+        + (SELECT COUNT(*) FROM product_images pi
+            WHERE NOT EXISTS (SELECT 1 FROM images i WHERE i.uid = pi.image_id))
+        + (SELECT COUNT(*) FROM product_variants pv
+            WHERE pv.image_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM images i WHERE i.uid = pv.image_id))
+    INTO dangling;
+
+    IF dangling > 0 THEN
+        RAISE EXCEPTION '% products refer to missing suppliers or images, run `migrator references` to list them', dangling;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS products_supplier_id_idx ON products (supplier_id);
+
+ALTER TABLE products
+    ADD CONSTRAINT products_supplier_id_fkey FOREIGN KEY (supplier_id) REFERENCES suppliers (uid);
+ALTER TABLE product_images
+    ADD CONSTRAINT product_images_image_id_fkey FOREIGN KEY (image_id) REFERENCES images (uid);
+ALTER TABLE product_variants
+    ADD CONSTRAINT product_variants_image_id_fkey FOREIGN KEY (image_id) REFERENCES images (uid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE product_variants DROP CONSTRAINT IF EXISTS product_variants_image_id_fkey;
+ALTER TABLE product_images DROP CONSTRAINT IF EXISTS product_images_image_id_fkey;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_supplier_id_fkey;
+
+DROP INDEX IF EXISTS products_supplier_id_idx;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Deleted rows are only marked, the purge removes them once the retention period since the mark passes.
+ALTER TABLE clients ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE suppliers ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE products ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE images ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS clients_deleted_at_idx ON clients (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS suppliers_deleted_at_idx ON suppliers (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS products_deleted_at_idx ON products (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS images_deleted_at_idx ON images (deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE OR REPLACE VIEW client_details AS
+SELECT 
+    c.client_name, c.client_surname, c.birthday, c.gender,
+    c.uid, c.registration_date, a.country, a.city, a.street, c.deleted_at
+FROM clients c JOIN addresses a ON c.address_id = a.id;
+
+CREATE OR REPLACE VIEW supplier_details AS
+SELECT s.uid, s.name, s.phone_number, a.country, a.city, a.street, s.deleted_at
+FROM suppliers s JOIN addresses a ON s.address_id = a.id;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP VIEW IF EXISTS supplier_details;
+CREATE VIEW supplier_details AS
+SELECT s.uid, s.name, s.phone_number, a.country, a.city, a.street
+FROM suppliers s JOIN addresses a ON s.address_id = a.id;
+
+DROP VIEW IF EXISTS client_details;
+CREATE VIEW client_details AS
+SELECT 
+    c.client_name, c.client_surname, c.birthday, c.gender,
+    c.uid, c.registration_date, a.country, a.city, a.street
+FROM clients c JOIN addresses a ON c.address_id = a.id;
+
+DROP INDEX IF EXISTS images_deleted_at_idx;
+DROP INDEX IF EXISTS products_deleted_at_idx;
+DROP INDEX IF EXISTS suppliers_deleted_at_idx;
+DROP INDEX IF EXISTS clients_deleted_at_idx;
+
+ALTER TABLE images DROP COLUMN deleted_at;
+ALTER TABLE products DROP COLUMN deleted_at;
+ALTER TABLE suppliers DROP COLUMN deleted_at;
+ALTER TABLE clients DROP COLUMN deleted_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- API keys of the services calling the API. Only the hash of a key is kept, the key is shown once on issue.
+CREATE TABLE IF NOT EXISTS api_keys (
+    "uid" UUID PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "prefix" TEXT NOT NULL,
+    "key_hash" TEXT NOT NULL UNIQUE,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT now(),
+    "expires_at" TIMESTAMPTZ,
+    "last_used_at" TIMESTAMPTZ,
+    "revoked_at" TIMESTAMPTZ
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS api_keys;
+
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Roles grant permissions to the callers, '*' grants all of them.
+CREATE TABLE IF NOT EXISTS roles (
+    "name" TEXT PRIMARY KEY,
+    "description" TEXT NOT NULL DEFAULT '',
+    "permissions" TEXT[] NOT NULL DEFAULT '{}'
+);
+
+INSERT INTO roles (name, description, permissions) VALUES
+    ('viewer', 'Reads the catalog', '{products:read,suppliers:read,images:read,categories:read}'),
+    ('catalog_manager', 'Manages the catalog', '{products:read,products:write,suppliers:read,images:read,images:write,categories:read,categories:write}'),
+    ('warehouse', 'Manages the stock', '{products:read,stock:write,suppliers:read,suppliers:write,images:read,categories:read}'),
+    ('crm', 'Manages the clients', '{clients:read,clients:write,products:read}'),
+    ('admin', 'Has all permissions', '{*}')
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS "roles" TEXT[] NOT NULL DEFAULT '{}';
+
+-- The keys issued before the roles keep the full access they had.
+UPDATE api_keys SET roles = '{admin}';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS "roles";
+
+DROP TABLE IF EXISTS roles;
+
+-- +goose StatementEnd
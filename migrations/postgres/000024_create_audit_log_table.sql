@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Every successful mutation leaves an entry written in its own transaction,
+-- the snapshots are the entity as it was before and after the change, null if it didn't exist.
+CREATE TABLE IF NOT EXISTS audit_log (
+    "id" BIGSERIAL PRIMARY KEY,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT now(),
+    "action" TEXT NOT NULL,
+    "entity" TEXT NOT NULL,
+    "entity_id" TEXT NOT NULL,
+    "actor" TEXT NOT NULL,
+    "actor_kind" TEXT NOT NULL,
+    "actor_key" UUID,
+    "request_id" TEXT NOT NULL,
+    "before_state" JSONB NOT NULL DEFAULT 'null',
+    "after_state" JSONB NOT NULL DEFAULT 'null'
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity, entity_id, id);
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON audit_log (actor, id);
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
+
+-- The log is append-only, the entries can't be changed or removed.
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+BEFORE UPDATE OR DELETE ON audit_log
+FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TRIGGER audit_log_no_truncate
+BEFORE TRUNCATE ON audit_log
+FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
+
+-- +goose StatementEnd
@@ -0,0 +1,250 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Every change of a client, supplier or product closes its current version and opens a new one,
+-- a version is the state of the entity from valid_from until valid_to, the current one has no valid_to.
+CREATE TABLE IF NOT EXISTS clients_history (
+    "uid" UUID NOT NULL,
+    "version" INT NOT NULL,
+    "valid_from" TIMESTAMPTZ NOT NULL,
+    "valid_to" TIMESTAMPTZ,
+    "tx_id" BIGINT NOT NULL,
+    "client_name" TEXT NOT NULL,
+    "client_surname" TEXT NOT NULL,
+    "birthday" TIMESTAMPTZ NOT NULL,
+    "gender" TEXT NOT NULL,
+    "registration_date" TIMESTAMPTZ NOT NULL,
+    "country" TEXT NOT NULL,
+    "city" TEXT NOT NULL,
+    "street" TEXT NOT NULL,
+    "deleted_at" TIMESTAMPTZ,
+
+    PRIMARY KEY (uid, version)
+);
+
+CREATE TABLE IF NOT EXISTS suppliers_history (
+    "uid" UUID NOT NULL,
+    "version" INT NOT NULL,
+    "valid_from" TIMESTAMPTZ NOT NULL,
+    "valid_to" TIMESTAMPTZ,
+    "tx_id" BIGINT NOT NULL,
+    "name" TEXT NOT NULL,
+    "phone_number" TEXT NOT NULL,
+    "country" TEXT NOT NULL,
+    "city" TEXT NOT NULL,
+    "street" TEXT NOT NULL,
+    "deleted_at" TIMESTAMPTZ,
+
+    PRIMARY KEY (uid, version)
+);
+
+-- The product version keeps its variants and images as they were, in the shape of their rows.
+CREATE TABLE IF NOT EXISTS products_history (
+    "uid" UUID NOT NULL,
+    "version" INT NOT NULL,
+    "valid_from" TIMESTAMPTZ NOT NULL,
+    "valid_to" TIMESTAMPTZ,
+    "tx_id" BIGINT NOT NULL,
+    "name" TEXT NOT NULL,
+    "last_update_date" TIMESTAMPTZ NOT NULL,
+    "supplier_id" UUID NOT NULL,
+    "category_id" UUID NOT NULL,
+    "options" JSONB NOT NULL,
+    "attributes" JSONB NOT NULL,
+    "sku" TEXT NOT NULL,
+    "barcode" TEXT,
+    "deleted_at" TIMESTAMPTZ,
+    "variants" JSONB NOT NULL DEFAULT '[]',
+    "images" JSONB NOT NULL DEFAULT '[]',
+
+    PRIMARY KEY (uid, version)
+);
+
+CREATE INDEX IF NOT EXISTS clients_history_valid_idx ON clients_history (valid_from, valid_to);
+CREATE INDEX IF NOT EXISTS suppliers_history_valid_idx ON suppliers_history (uid, valid_from);
+CREATE INDEX IF NOT EXISTS products_history_valid_idx ON products_history (uid, valid_from);
+
+-- The record_*_version functions are called by the triggers below. Recording is serialized per entity,
+-- so the versions follow one another in the order the transactions commit. A transaction changing
+-- the entity more than once replaces its own version instead of adding another one. Nothing is
+-- opened for a removed entity, its last version is only closed.
+CREATE OR REPLACE FUNCTION record_client_version(client_uid UUID) RETURNS void AS $$
+DECLARE
+    since TIMESTAMPTZ;
+BEGIN
+    PERFORM pg_advisory_xact_lock('clients_history'::regclass::oid::int, hashtext(client_uid::text));
+
+    DELETE FROM clients_history
+    WHERE uid = client_uid AND valid_to IS NULL AND tx_id = txid_current()
+    RETURNING valid_from INTO since;
+    since := COALESCE(since, clock_timestamp());
+
+    UPDATE clients_history SET valid_to = since WHERE uid = client_uid AND valid_to IS NULL;
+
+    INSERT INTO clients_history (
+        uid, version, valid_from, tx_id, client_name, client_surname, birthday, gender,
+        registration_date, country, city, street, deleted_at
+    )
+    SELECT
+        c.uid, COALESCE((SELECT max(h.version) FROM clients_history h WHERE h.uid = c.uid), 0) + 1,
+        since, txid_current(), c.client_name, c.client_surname, c.birthday, c.gender,
+        c.registration_date, c.country, c.city, c.street, c.deleted_at
+    FROM client_details c
+    WHERE c.uid = client_uid;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION record_supplier_version(supplier_uid UUID) RETURNS void AS $$
+DECLARE
+    since TIMESTAMPTZ;
+BEGIN
+    PERFORM pg_advisory_xact_lock('suppliers_history'::regclass::oid::int, hashtext(supplier_uid::text));
+
+    DELETE FROM suppliers_history
+    WHERE uid = supplier_uid AND valid_to IS NULL AND tx_id = txid_current()
+    RETURNING valid_from INTO since;
+    since := COALESCE(since, clock_timestamp());
+
+    UPDATE suppliers_history SET valid_to = since WHERE uid = supplier_uid AND valid_to IS NULL;
+
+    INSERT INTO suppliers_history (
+        uid, version, valid_from, tx_id, name, phone_number, country, city, street, deleted_at
+    )
+    SELECT
+        s.uid, COALESCE((SELECT max(h.version) FROM suppliers_history h WHERE h.uid = s.uid), 0) + 1,
+        since, txid_current(), s.name, s.phone_number, s.country, s.city, s.street, s.deleted_at
+    FROM supplier_details s
+    WHERE s.uid = supplier_uid;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION record_product_version(product_uid UUID) RETURNS void AS $$
+DECLARE
+    since TIMESTAMPTZ;
+BEGIN
+    PERFORM pg_advisory_xact_lock('products_history'::regclass::oid::int, hashtext(product_uid::text));
+
+    DELETE FROM products_history
+    WHERE uid = product_uid AND valid_to IS NULL AND tx_id = txid_current()
+    RETURNING valid_from INTO since;
+    since := COALESCE(since, clock_timestamp());
+
+    UPDATE products_history SET valid_to = since WHERE uid = product_uid AND valid_to IS NULL;
+
+    INSERT INTO products_history (
+        uid, version, valid_from, tx_id, name, last_update_date, supplier_id, category_id,
+        options, attributes, sku, barcode, deleted_at, variants, images
+    )
+    SELECT
+        p.uid, COALESCE((SELECT max(h.version) FROM products_history h WHERE h.uid = p.uid), 0) + 1,
+        since, txid_current(), p.name, p.last_update_date, p.supplier_id, p.category_id,
+        p.options, p.attributes, p.sku, p.barcode, p.deleted_at,
+        COALESCE((SELECT jsonb_agg(to_jsonb(v) ORDER BY v.sku) FROM product_variants v WHERE v.product_id = p.uid), '[]'),
+        COALESCE((SELECT jsonb_agg(to_jsonb(i) ORDER BY i.position) FROM product_images i WHERE i.product_id = p.uid), '[]')
+    FROM products p
+    WHERE p.uid = product_uid;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION clients_history_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM record_client_version(CASE WHEN TG_OP = 'DELETE' THEN OLD.uid ELSE NEW.uid END);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION suppliers_history_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM record_supplier_version(CASE WHEN TG_OP = 'DELETE' THEN OLD.uid ELSE NEW.uid END);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Addresses are updated in place, the clients and suppliers living there get a new version.
+CREATE OR REPLACE FUNCTION addresses_history_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM record_client_version(c.uid) FROM clients c WHERE c.address_id = NEW.id;
+    PERFORM record_supplier_version(s.uid) FROM suppliers s WHERE s.address_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION products_history_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM record_product_version(CASE WHEN TG_OP = 'DELETE' THEN OLD.uid ELSE NEW.uid END);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Variants and images are part of the product version.
+CREATE OR REPLACE FUNCTION product_parts_history_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM record_product_version(CASE WHEN TG_OP = 'DELETE' THEN OLD.product_id ELSE NEW.product_id END);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER clients_history_insert_delete AFTER INSERT OR DELETE ON clients
+FOR EACH ROW EXECUTE FUNCTION clients_history_trigger();
+CREATE TRIGGER clients_history_update AFTER UPDATE ON clients
+FOR EACH ROW WHEN (OLD IS DISTINCT FROM NEW) EXECUTE FUNCTION clients_history_trigger();
+
+CREATE TRIGGER suppliers_history_insert_delete AFTER INSERT OR DELETE ON suppliers
+FOR EACH ROW EXECUTE FUNCTION suppliers_history_trigger();
+CREATE TRIGGER suppliers_history_update AFTER UPDATE ON suppliers
+FOR EACH ROW WHEN (OLD IS DISTINCT FROM NEW) EXECUTE FUNCTION suppliers_history_trigger();
+
+CREATE TRIGGER addresses_history_update AFTER UPDATE ON addresses
+FOR EACH ROW WHEN (OLD IS DISTINCT FROM NEW) EXECUTE FUNCTION addresses_history_trigger();
+
+CREATE TRIGGER products_history_insert_delete AFTER INSERT OR DELETE ON products
+FOR EACH ROW EXECUTE FUNCTION products_history_trigger();
+CREATE TRIGGER products_history_update AFTER UPDATE ON products
+FOR EACH ROW WHEN (OLD IS DISTINCT FROM NEW) EXECUTE FUNCTION products_history_trigger();
+
+CREATE TRIGGER product_variants_history_insert_delete AFTER INSERT OR DELETE ON product_variants
+FOR EACH ROW EXECUTE FUNCTION product_parts_history_trigger();
+CREATE TRIGGER product_variants_history_update AFTER UPDATE ON product_variants
+FOR EACH ROW WHEN (OLD IS DISTINCT FROM NEW) EXECUTE FUNCTION product_parts_history_trigger();
+
+CREATE TRIGGER product_images_history_insert_delete AFTER INSERT OR DELETE ON product_images
+FOR EACH ROW EXECUTE FUNCTION product_parts_history_trigger();
+CREATE TRIGGER product_images_history_update AFTER UPDATE ON product_images
+FOR EACH ROW WHEN (OLD IS DISTINCT FROM NEW) EXECUTE FUNCTION product_parts_history_trigger();
+
+-- The history starts with the entities as they are now.
+SELECT record_client_version(uid) FROM clients;
+SELECT record_supplier_version(uid) FROM suppliers;
+SELECT record_product_version(uid) FROM products;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS product_images_history_update ON product_images;
+DROP TRIGGER IF EXISTS product_images_history_insert_delete ON product_images;
+DROP TRIGGER IF EXISTS product_variants_history_update ON product_variants;
+DROP TRIGGER IF EXISTS product_variants_history_insert_delete ON product_variants;
+DROP TRIGGER IF EXISTS products_history_update ON products;
+DROP TRIGGER IF EXISTS products_history_insert_delete ON products;
+DROP TRIGGER IF EXISTS addresses_history_update ON addresses;
+DROP TRIGGER IF EXISTS suppliers_history_update ON suppliers;
+DROP TRIGGER IF EXISTS suppliers_history_insert_delete ON suppliers;
+DROP TRIGGER IF EXISTS clients_history_update ON clients;
+DROP TRIGGER IF EXISTS clients_history_insert_delete ON clients;
+
+DROP FUNCTION IF EXISTS product_parts_history_trigger();
+DROP FUNCTION IF EXISTS products_history_trigger();
+DROP FUNCTION IF EXISTS addresses_history_trigger();
+DROP FUNCTION IF EXISTS suppliers_history_trigger();
+DROP FUNCTION IF EXISTS clients_history_trigger();
+DROP FUNCTION IF EXISTS record_product_version(UUID);
+DROP FUNCTION IF EXISTS record_supplier_version(UUID);
+DROP FUNCTION IF EXISTS record_client_version(UUID);
+
+DROP TABLE IF EXISTS products_history;
+DROP TABLE IF EXISTS suppliers_history;
+DROP TABLE IF EXISTS clients_history;
+
+-- +goose StatementEnd
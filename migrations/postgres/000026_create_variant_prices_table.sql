@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The prices of a variant, past, current and scheduled. A price without effective_to is a regular one and stays
+-- until the next regular price starts, a price with effective_to is temporary and overrides the regular one
+-- while it lasts. The latest started price of each kind wins. product_variants.price keeps the price
+-- the variant was added with.
+CREATE TABLE IF NOT EXISTS variant_prices (
+    "id" BIGSERIAL PRIMARY KEY,
+    "variant_id" UUID NOT NULL REFERENCES product_variants (uid) ON DELETE CASCADE,
+    "price" BIGINT NOT NULL,
+    "effective_from" TIMESTAMPTZ NOT NULL,
+    "effective_to" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CHECK (price > 0),
+    CHECK (effective_to IS NULL OR effective_to > effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS variant_prices_variant_id_idx ON variant_prices (variant_id, effective_from);
+
+CREATE OR REPLACE FUNCTION variant_prices_initial() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO variant_prices (variant_id, price, effective_from) VALUES (NEW.uid, NEW.price, now());
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER variant_prices_initial
+AFTER INSERT ON product_variants
+FOR EACH ROW EXECUTE FUNCTION variant_prices_initial();
+
+INSERT INTO variant_prices (variant_id, price, effective_from)
+SELECT v.uid, v.price, p.last_update_date
+FROM product_variants v JOIN products p ON p.uid = v.product_id;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS variant_prices_initial ON product_variants;
+DROP FUNCTION IF EXISTS variant_prices_initial();
+DROP TABLE IF EXISTS variant_prices;
+
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Prices are in minor units of their currency, the exponent of the currency says how many of them make a unit.
+ALTER TABLE product_variants ADD COLUMN IF NOT EXISTS "currency" CHAR(3) NOT NULL DEFAULT 'RUB'
+    CHECK (currency ~ '^[A-Z]{3}$');
+ALTER TABLE variant_prices ADD COLUMN IF NOT EXISTS "currency" CHAR(3) NOT NULL DEFAULT 'RUB'
+    CHECK (currency ~ '^[A-Z]{3}$');
+
+CREATE OR REPLACE FUNCTION variant_prices_initial() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO variant_prices (variant_id, price, currency, effective_from)
+    VALUES (NEW.uid, NEW.price, NEW.currency, now());
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The rate is the amount of RUB paid for a unit of the currency, RUB itself is always 1.
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    "currency" CHAR(3) PRIMARY KEY,
+    "rate" NUMERIC NOT NULL,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CHECK (currency ~ '^[A-Z]{3}$' AND currency <> 'RUB'),
+    CHECK (rate > 0)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS exchange_rates;
+
+CREATE OR REPLACE FUNCTION variant_prices_initial() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO variant_prices (variant_id, price, effective_from) VALUES (NEW.uid, NEW.price, now());
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE variant_prices DROP COLUMN IF EXISTS "currency";
+ALTER TABLE product_variants DROP COLUMN IF EXISTS "currency";
+
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- A promotion takes a percent or a fixed amount off each unit of the products it's scoped to: the listed products,
+-- the products of the listed categories with their subcategories and of the listed suppliers, all of them if
+-- nothing is listed. A promotion with a code applies only to the baskets the code is given for.
+CREATE TABLE IF NOT EXISTS promotions (
+    "uid" UUID PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    "code" TEXT UNIQUE,
+    "kind" TEXT NOT NULL,
+    "percent" INT NOT NULL DEFAULT 0,
+    "amount" BIGINT NOT NULL DEFAULT 0,
+    "currency" CHAR(3) NOT NULL DEFAULT 'RUB',
+    "product_ids" UUID[] NOT NULL DEFAULT '{}',
+    "category_ids" UUID[] NOT NULL DEFAULT '{}',
+    "supplier_ids" UUID[] NOT NULL DEFAULT '{}',
+    "starts_at" TIMESTAMPTZ,
+    "ends_at" TIMESTAMPTZ,
+    "usage_limit" INT,
+    "per_client_limit" INT,
+    "stackable" BOOLEAN NOT NULL DEFAULT false,
+    "priority" INT NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CHECK (kind = 'percent' AND percent BETWEEN 1 AND 100 AND amount = 0
+        OR kind = 'fixed' AND amount > 0 AND percent = 0),
+    CHECK (currency ~ '^[A-Z]{3}$'),
+    CHECK (code IS NULL OR code = upper(code)),
+    CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at),
+    CHECK (usage_limit IS NULL OR usage_limit > 0),
+    CHECK (per_client_limit IS NULL OR per_client_limit > 0)
+);
+
+-- Every use of a promotion in a placed order, the usage limits count them.
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+    "id" BIGSERIAL PRIMARY KEY,
+    "promotion_id" UUID NOT NULL REFERENCES promotions (uid) ON DELETE CASCADE,
+    "client_id" UUID NOT NULL REFERENCES clients (uid) ON DELETE CASCADE,
+    "order_id" TEXT NOT NULL,
+    "discount" BIGINT NOT NULL,
+    "currency" CHAR(3) NOT NULL,
+    "redeemed_at" TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (order_id, promotion_id)
+);
+
+CREATE INDEX IF NOT EXISTS promotion_redemptions_promotion_id_idx ON promotion_redemptions (promotion_id, client_id);
+
+UPDATE roles SET permissions = permissions || '{promotions:read}' WHERE name = 'viewer';
+UPDATE roles SET permissions = permissions || '{promotions:read,promotions:write}' WHERE name = 'catalog_manager';
+UPDATE roles SET permissions = permissions || '{promotions:read,promotions:redeem}' WHERE name = 'crm';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+UPDATE roles
+SET permissions = array_remove(array_remove(array_remove(permissions,
+    'promotions:read'), 'promotions:write'), 'promotions:redeem');
+
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TABLE IF EXISTS promotions;
+
+-- +goose StatementEnd